@@ -28,6 +28,8 @@ type AuthRequest struct {
 	Email         string                 `protobuf:"bytes,1,opt,name=email,proto3" json:"email,omitempty"`
 	Login         string                 `protobuf:"bytes,2,opt,name=login,proto3" json:"login,omitempty"`
 	Password      string                 `protobuf:"bytes,3,opt,name=password,proto3" json:"password,omitempty"`
+	UserAgent     string                 `protobuf:"bytes,4,opt,name=userAgent,proto3" json:"userAgent,omitempty"`
+	Ip            string                 `protobuf:"bytes,5,opt,name=ip,proto3" json:"ip,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -83,6 +85,20 @@ func (x *AuthRequest) GetPassword() string {
 	return ""
 }
 
+func (x *AuthRequest) GetUserAgent() string {
+	if x != nil {
+		return x.UserAgent
+	}
+	return ""
+}
+
+func (x *AuthRequest) GetIp() string {
+	if x != nil {
+		return x.Ip
+	}
+	return ""
+}
+
 type UpdateAboutRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
@@ -187,6 +203,58 @@ func (x *UpdateEmailRequest) GetNewEmail() string {
 	return ""
 }
 
+type UpdateDiscoverableRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Discoverable  bool                   `protobuf:"varint,2,opt,name=discoverable,proto3" json:"discoverable,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateDiscoverableRequest) Reset() {
+	*x = UpdateDiscoverableRequest{}
+	mi := &file_auth_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateDiscoverableRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateDiscoverableRequest) ProtoMessage() {}
+
+func (x *UpdateDiscoverableRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateDiscoverableRequest.ProtoReflect.Descriptor instead.
+func (*UpdateDiscoverableRequest) Descriptor() ([]byte, []int) {
+	return file_auth_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *UpdateDiscoverableRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *UpdateDiscoverableRequest) GetDiscoverable() bool {
+	if x != nil {
+		return x.Discoverable
+	}
+	return false
+}
+
 type UpdatePhotoRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
@@ -197,7 +265,7 @@ type UpdatePhotoRequest struct {
 
 func (x *UpdatePhotoRequest) Reset() {
 	*x = UpdatePhotoRequest{}
-	mi := &file_auth_proto_msgTypes[3]
+	mi := &file_auth_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -209,7 +277,7 @@ func (x *UpdatePhotoRequest) String() string {
 func (*UpdatePhotoRequest) ProtoMessage() {}
 
 func (x *UpdatePhotoRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_auth_proto_msgTypes[3]
+	mi := &file_auth_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -222,7 +290,7 @@ func (x *UpdatePhotoRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdatePhotoRequest.ProtoReflect.Descriptor instead.
 func (*UpdatePhotoRequest) Descriptor() ([]byte, []int) {
-	return file_auth_proto_rawDescGZIP(), []int{3}
+	return file_auth_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *UpdatePhotoRequest) GetId() string {
@@ -240,17 +308,19 @@ func (x *UpdatePhotoRequest) GetNewPhoto() string {
 }
 
 type ChangePasswordRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	NewPassword   string                 `protobuf:"bytes,2,opt,name=new_password,json=newPassword,proto3" json:"new_password,omitempty"`
-	OldPassword   string                 `protobuf:"bytes,3,opt,name=old_password,json=oldPassword,proto3" json:"old_password,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	Id                  string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	NewPassword         string                 `protobuf:"bytes,2,opt,name=new_password,json=newPassword,proto3" json:"new_password,omitempty"`
+	OldPassword         string                 `protobuf:"bytes,3,opt,name=old_password,json=oldPassword,proto3" json:"old_password,omitempty"`
+	RevokeOtherSessions bool                   `protobuf:"varint,4,opt,name=revokeOtherSessions,proto3" json:"revokeOtherSessions,omitempty"`
+	AccessToken         string                 `protobuf:"bytes,5,opt,name=accessToken,proto3" json:"accessToken,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
 }
 
 func (x *ChangePasswordRequest) Reset() {
 	*x = ChangePasswordRequest{}
-	mi := &file_auth_proto_msgTypes[4]
+	mi := &file_auth_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -262,7 +332,7 @@ func (x *ChangePasswordRequest) String() string {
 func (*ChangePasswordRequest) ProtoMessage() {}
 
 func (x *ChangePasswordRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_auth_proto_msgTypes[4]
+	mi := &file_auth_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -275,7 +345,7 @@ func (x *ChangePasswordRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ChangePasswordRequest.ProtoReflect.Descriptor instead.
 func (*ChangePasswordRequest) Descriptor() ([]byte, []int) {
-	return file_auth_proto_rawDescGZIP(), []int{4}
+	return file_auth_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *ChangePasswordRequest) GetId() string {
@@ -299,32 +369,44 @@ func (x *ChangePasswordRequest) GetOldPassword() string {
 	return ""
 }
 
-type AuthResponse struct {
+func (x *ChangePasswordRequest) GetRevokeOtherSessions() bool {
+	if x != nil {
+		return x.RevokeOtherSessions
+	}
+	return false
+}
+
+func (x *ChangePasswordRequest) GetAccessToken() string {
+	if x != nil {
+		return x.AccessToken
+	}
+	return ""
+}
+
+type CreateWorkspaceRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	AccessToken   string                 `protobuf:"bytes,1,opt,name=access_token,json=accessToken,proto3" json:"access_token,omitempty"`
-	RefreshToken  string                 `protobuf:"bytes,2,opt,name=refresh_token,json=refreshToken,proto3" json:"refresh_token,omitempty"`
-	ExpAccess     int64                  `protobuf:"varint,3,opt,name=expAccess,proto3" json:"expAccess,omitempty"`
-	ExpRefresh    int64                  `protobuf:"varint,4,opt,name=expRefresh,proto3" json:"expRefresh,omitempty"`
-	Metadata      *User                  `protobuf:"bytes,5,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Title         string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	UserId        string                 `protobuf:"bytes,3,opt,name=userId,proto3" json:"userId,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *AuthResponse) Reset() {
-	*x = AuthResponse{}
-	mi := &file_auth_proto_msgTypes[5]
+func (x *CreateWorkspaceRequest) Reset() {
+	*x = CreateWorkspaceRequest{}
+	mi := &file_auth_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *AuthResponse) String() string {
+func (x *CreateWorkspaceRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*AuthResponse) ProtoMessage() {}
+func (*CreateWorkspaceRequest) ProtoMessage() {}
 
-func (x *AuthResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_auth_proto_msgTypes[5]
+func (x *CreateWorkspaceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -335,87 +417,2583 @@ func (x *AuthResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use AuthResponse.ProtoReflect.Descriptor instead.
-func (*AuthResponse) Descriptor() ([]byte, []int) {
-	return file_auth_proto_rawDescGZIP(), []int{5}
+// Deprecated: Use CreateWorkspaceRequest.ProtoReflect.Descriptor instead.
+func (*CreateWorkspaceRequest) Descriptor() ([]byte, []int) {
+	return file_auth_proto_rawDescGZIP(), []int{6}
 }
 
-func (x *AuthResponse) GetAccessToken() string {
+func (x *CreateWorkspaceRequest) GetId() string {
 	if x != nil {
-		return x.AccessToken
+		return x.Id
 	}
 	return ""
 }
 
-func (x *AuthResponse) GetRefreshToken() string {
+func (x *CreateWorkspaceRequest) GetTitle() string {
 	if x != nil {
-		return x.RefreshToken
+		return x.Title
 	}
 	return ""
 }
 
-func (x *AuthResponse) GetExpAccess() int64 {
+func (x *CreateWorkspaceRequest) GetUserId() string {
 	if x != nil {
-		return x.ExpAccess
+		return x.UserId
+	}
+	return ""
+}
+
+type WorkspaceMemberRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	WorkspaceId   string                 `protobuf:"bytes,1,opt,name=workspaceId,proto3" json:"workspaceId,omitempty"`
+	MemberId      string                 `protobuf:"bytes,2,opt,name=memberId,proto3" json:"memberId,omitempty"`
+	Role          string                 `protobuf:"bytes,3,opt,name=role,proto3" json:"role,omitempty"`
+	UserId        string                 `protobuf:"bytes,4,opt,name=userId,proto3" json:"userId,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WorkspaceMemberRequest) Reset() {
+	*x = WorkspaceMemberRequest{}
+	mi := &file_auth_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WorkspaceMemberRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WorkspaceMemberRequest) ProtoMessage() {}
+
+func (x *WorkspaceMemberRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WorkspaceMemberRequest.ProtoReflect.Descriptor instead.
+func (*WorkspaceMemberRequest) Descriptor() ([]byte, []int) {
+	return file_auth_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *WorkspaceMemberRequest) GetWorkspaceId() string {
+	if x != nil {
+		return x.WorkspaceId
+	}
+	return ""
+}
+
+func (x *WorkspaceMemberRequest) GetMemberId() string {
+	if x != nil {
+		return x.MemberId
+	}
+	return ""
+}
+
+func (x *WorkspaceMemberRequest) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+func (x *WorkspaceMemberRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type Session struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	UserAgent     string                 `protobuf:"bytes,2,opt,name=userAgent,proto3" json:"userAgent,omitempty"`
+	Ip            string                 `protobuf:"bytes,3,opt,name=ip,proto3" json:"ip,omitempty"`
+	CreatedAt     int64                  `protobuf:"varint,4,opt,name=createdAt,proto3" json:"createdAt,omitempty"`
+	LastSeenAt    int64                  `protobuf:"varint,5,opt,name=lastSeenAt,proto3" json:"lastSeenAt,omitempty"`
+	Current       bool                   `protobuf:"varint,6,opt,name=current,proto3" json:"current,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Session) Reset() {
+	*x = Session{}
+	mi := &file_auth_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Session) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Session) ProtoMessage() {}
+
+func (x *Session) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Session.ProtoReflect.Descriptor instead.
+func (*Session) Descriptor() ([]byte, []int) {
+	return file_auth_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *Session) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Session) GetUserAgent() string {
+	if x != nil {
+		return x.UserAgent
+	}
+	return ""
+}
+
+func (x *Session) GetIp() string {
+	if x != nil {
+		return x.Ip
+	}
+	return ""
+}
+
+func (x *Session) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
 	}
 	return 0
 }
 
-func (x *AuthResponse) GetExpRefresh() int64 {
+func (x *Session) GetLastSeenAt() int64 {
 	if x != nil {
-		return x.ExpRefresh
+		return x.LastSeenAt
 	}
 	return 0
 }
 
-func (x *AuthResponse) GetMetadata() *User {
+func (x *Session) GetCurrent() bool {
 	if x != nil {
-		return x.Metadata
+		return x.Current
+	}
+	return false
+}
+
+type Sessions struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Items         []*Session             `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Sessions) Reset() {
+	*x = Sessions{}
+	mi := &file_auth_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Sessions) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Sessions) ProtoMessage() {}
+
+func (x *Sessions) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Sessions.ProtoReflect.Descriptor instead.
+func (*Sessions) Descriptor() ([]byte, []int) {
+	return file_auth_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *Sessions) GetItems() []*Session {
+	if x != nil {
+		return x.Items
 	}
 	return nil
 }
 
-var File_auth_proto protoreflect.FileDescriptor
+type ListSessionsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=userId,proto3" json:"userId,omitempty"`
+	AccessToken   string                 `protobuf:"bytes,2,opt,name=accessToken,proto3" json:"accessToken,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
 
-const file_auth_proto_rawDesc = "" +
-	"\n" +
-	"\n" +
-	"auth.proto\x12\x03brz\x1a\fdomain.proto\x1a\x1bgoogle/protobuf/empty.proto\"U\n" +
-	"\vAuthRequest\x12\x14\n" +
-	"\x05email\x18\x01 \x01(\tR\x05email\x12\x14\n" +
-	"\x05login\x18\x02 \x01(\tR\x05login\x12\x1a\n" +
-	"\bpassword\x18\x03 \x01(\tR\bpassword\"A\n" +
-	"\x12UpdateAboutRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1b\n" +
-	"\tnew_about\x18\x02 \x01(\tR\bnewAbout\"A\n" +
-	"\x12UpdateEmailRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1b\n" +
-	"\tnew_email\x18\x02 \x01(\tR\bnewEmail\"A\n" +
-	"\x12UpdatePhotoRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1b\n" +
-	"\tnew_photo\x18\x02 \x01(\tR\bnewPhoto\"m\n" +
-	"\x15ChangePasswordRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\x12!\n" +
-	"\fnew_password\x18\x02 \x01(\tR\vnewPassword\x12!\n" +
-	"\fold_password\x18\x03 \x01(\tR\voldPassword\"\xbb\x01\n" +
-	"\fAuthResponse\x12!\n" +
-	"\faccess_token\x18\x01 \x01(\tR\vaccessToken\x12#\n" +
-	"\rrefresh_token\x18\x02 \x01(\tR\frefreshToken\x12\x1c\n" +
-	"\texpAccess\x18\x03 \x01(\x03R\texpAccess\x12\x1e\n" +
-	"\n" +
-	"expRefresh\x18\x04 \x01(\x03R\n" +
-	"expRefresh\x12%\n" +
-	"\bmetadata\x18\x05 \x01(\v2\t.brz.UserR\bmetadata2\xce\x05\n" +
+func (x *ListSessionsRequest) Reset() {
+	*x = ListSessionsRequest{}
+	mi := &file_auth_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSessionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSessionsRequest) ProtoMessage() {}
+
+func (x *ListSessionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSessionsRequest.ProtoReflect.Descriptor instead.
+func (*ListSessionsRequest) Descriptor() ([]byte, []int) {
+	return file_auth_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ListSessionsRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ListSessionsRequest) GetAccessToken() string {
+	if x != nil {
+		return x.AccessToken
+	}
+	return ""
+}
+
+type UserSessionId struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=userId,proto3" json:"userId,omitempty"`
+	SessionId     string                 `protobuf:"bytes,2,opt,name=sessionId,proto3" json:"sessionId,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UserSessionId) Reset() {
+	*x = UserSessionId{}
+	mi := &file_auth_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UserSessionId) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UserSessionId) ProtoMessage() {}
+
+func (x *UserSessionId) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UserSessionId.ProtoReflect.Descriptor instead.
+func (*UserSessionId) Descriptor() ([]byte, []int) {
+	return file_auth_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *UserSessionId) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *UserSessionId) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+// JWK public key of token signing in RFC 7517 form. X is set for OKP keys, N and E for RSA
+type JWK struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Kid           string                 `protobuf:"bytes,1,opt,name=kid,proto3" json:"kid,omitempty"`
+	Kty           string                 `protobuf:"bytes,2,opt,name=kty,proto3" json:"kty,omitempty"`
+	Alg           string                 `protobuf:"bytes,3,opt,name=alg,proto3" json:"alg,omitempty"`
+	Use           string                 `protobuf:"bytes,4,opt,name=use,proto3" json:"use,omitempty"`
+	Crv           string                 `protobuf:"bytes,5,opt,name=crv,proto3" json:"crv,omitempty"`
+	X             string                 `protobuf:"bytes,6,opt,name=x,proto3" json:"x,omitempty"`
+	N             string                 `protobuf:"bytes,7,opt,name=n,proto3" json:"n,omitempty"`
+	E             string                 `protobuf:"bytes,8,opt,name=e,proto3" json:"e,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *JWK) Reset() {
+	*x = JWK{}
+	mi := &file_auth_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *JWK) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JWK) ProtoMessage() {}
+
+func (x *JWK) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JWK.ProtoReflect.Descriptor instead.
+func (*JWK) Descriptor() ([]byte, []int) {
+	return file_auth_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *JWK) GetKid() string {
+	if x != nil {
+		return x.Kid
+	}
+	return ""
+}
+
+func (x *JWK) GetKty() string {
+	if x != nil {
+		return x.Kty
+	}
+	return ""
+}
+
+func (x *JWK) GetAlg() string {
+	if x != nil {
+		return x.Alg
+	}
+	return ""
+}
+
+func (x *JWK) GetUse() string {
+	if x != nil {
+		return x.Use
+	}
+	return ""
+}
+
+func (x *JWK) GetCrv() string {
+	if x != nil {
+		return x.Crv
+	}
+	return ""
+}
+
+func (x *JWK) GetX() string {
+	if x != nil {
+		return x.X
+	}
+	return ""
+}
+
+func (x *JWK) GetN() string {
+	if x != nil {
+		return x.N
+	}
+	return ""
+}
+
+func (x *JWK) GetE() string {
+	if x != nil {
+		return x.E
+	}
+	return ""
+}
+
+type JWKS struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Keys          []*JWK                 `protobuf:"bytes,1,rep,name=keys,proto3" json:"keys,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *JWKS) Reset() {
+	*x = JWKS{}
+	mi := &file_auth_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *JWKS) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JWKS) ProtoMessage() {}
+
+func (x *JWKS) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JWKS.ProtoReflect.Descriptor instead.
+func (*JWKS) Descriptor() ([]byte, []int) {
+	return file_auth_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *JWKS) GetKeys() []*JWK {
+	if x != nil {
+		return x.Keys
+	}
+	return nil
+}
+
+type AuthResponse struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	AccessToken  string                 `protobuf:"bytes,1,opt,name=access_token,json=accessToken,proto3" json:"access_token,omitempty"`
+	RefreshToken string                 `protobuf:"bytes,2,opt,name=refresh_token,json=refreshToken,proto3" json:"refresh_token,omitempty"`
+	ExpAccess    int64                  `protobuf:"varint,3,opt,name=expAccess,proto3" json:"expAccess,omitempty"`
+	ExpRefresh   int64                  `protobuf:"varint,4,opt,name=expRefresh,proto3" json:"expRefresh,omitempty"`
+	Metadata     *User                  `protobuf:"bytes,5,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	// challengeToken is set instead of tokens when user has 2FA: login is finished by VerifySecondFactor
+	ChallengeToken string `protobuf:"bytes,6,opt,name=challengeToken,proto3" json:"challengeToken,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *AuthResponse) Reset() {
+	*x = AuthResponse{}
+	mi := &file_auth_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AuthResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AuthResponse) ProtoMessage() {}
+
+func (x *AuthResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AuthResponse.ProtoReflect.Descriptor instead.
+func (*AuthResponse) Descriptor() ([]byte, []int) {
+	return file_auth_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *AuthResponse) GetAccessToken() string {
+	if x != nil {
+		return x.AccessToken
+	}
+	return ""
+}
+
+func (x *AuthResponse) GetRefreshToken() string {
+	if x != nil {
+		return x.RefreshToken
+	}
+	return ""
+}
+
+func (x *AuthResponse) GetExpAccess() int64 {
+	if x != nil {
+		return x.ExpAccess
+	}
+	return 0
+}
+
+func (x *AuthResponse) GetExpRefresh() int64 {
+	if x != nil {
+		return x.ExpRefresh
+	}
+	return 0
+}
+
+func (x *AuthResponse) GetMetadata() *User {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *AuthResponse) GetChallengeToken() string {
+	if x != nil {
+		return x.ChallengeToken
+	}
+	return ""
+}
+
+type SecondFactorRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	ChallengeToken string                 `protobuf:"bytes,1,opt,name=challengeToken,proto3" json:"challengeToken,omitempty"`
+	Code           string                 `protobuf:"bytes,2,opt,name=code,proto3" json:"code,omitempty"`
+	UserAgent      string                 `protobuf:"bytes,3,opt,name=userAgent,proto3" json:"userAgent,omitempty"`
+	Ip             string                 `protobuf:"bytes,4,opt,name=ip,proto3" json:"ip,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *SecondFactorRequest) Reset() {
+	*x = SecondFactorRequest{}
+	mi := &file_auth_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SecondFactorRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SecondFactorRequest) ProtoMessage() {}
+
+func (x *SecondFactorRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SecondFactorRequest.ProtoReflect.Descriptor instead.
+func (*SecondFactorRequest) Descriptor() ([]byte, []int) {
+	return file_auth_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *SecondFactorRequest) GetChallengeToken() string {
+	if x != nil {
+		return x.ChallengeToken
+	}
+	return ""
+}
+
+func (x *SecondFactorRequest) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *SecondFactorRequest) GetUserAgent() string {
+	if x != nil {
+		return x.UserAgent
+	}
+	return ""
+}
+
+func (x *SecondFactorRequest) GetIp() string {
+	if x != nil {
+		return x.Ip
+	}
+	return ""
+}
+
+type TotpSetup struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Secret        string                 `protobuf:"bytes,1,opt,name=secret,proto3" json:"secret,omitempty"`
+	Uri           string                 `protobuf:"bytes,2,opt,name=uri,proto3" json:"uri,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TotpSetup) Reset() {
+	*x = TotpSetup{}
+	mi := &file_auth_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TotpSetup) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TotpSetup) ProtoMessage() {}
+
+func (x *TotpSetup) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TotpSetup.ProtoReflect.Descriptor instead.
+func (*TotpSetup) Descriptor() ([]byte, []int) {
+	return file_auth_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *TotpSetup) GetSecret() string {
+	if x != nil {
+		return x.Secret
+	}
+	return ""
+}
+
+func (x *TotpSetup) GetUri() string {
+	if x != nil {
+		return x.Uri
+	}
+	return ""
+}
+
+type TotpCodeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=userId,proto3" json:"userId,omitempty"`
+	Code          string                 `protobuf:"bytes,2,opt,name=code,proto3" json:"code,omitempty"`
+	Password      string                 `protobuf:"bytes,3,opt,name=password,proto3" json:"password,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TotpCodeRequest) Reset() {
+	*x = TotpCodeRequest{}
+	mi := &file_auth_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TotpCodeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TotpCodeRequest) ProtoMessage() {}
+
+func (x *TotpCodeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TotpCodeRequest.ProtoReflect.Descriptor instead.
+func (*TotpCodeRequest) Descriptor() ([]byte, []int) {
+	return file_auth_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *TotpCodeRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *TotpCodeRequest) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *TotpCodeRequest) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
+type RecoveryCodes struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Codes         []string               `protobuf:"bytes,1,rep,name=codes,proto3" json:"codes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RecoveryCodes) Reset() {
+	*x = RecoveryCodes{}
+	mi := &file_auth_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RecoveryCodes) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecoveryCodes) ProtoMessage() {}
+
+func (x *RecoveryCodes) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecoveryCodes.ProtoReflect.Descriptor instead.
+func (*RecoveryCodes) Descriptor() ([]byte, []int) {
+	return file_auth_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *RecoveryCodes) GetCodes() []string {
+	if x != nil {
+		return x.Codes
+	}
+	return nil
+}
+
+type ResetPasswordRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	NewPassword   string                 `protobuf:"bytes,2,opt,name=newPassword,proto3" json:"newPassword,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResetPasswordRequest) Reset() {
+	*x = ResetPasswordRequest{}
+	mi := &file_auth_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResetPasswordRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResetPasswordRequest) ProtoMessage() {}
+
+func (x *ResetPasswordRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResetPasswordRequest.ProtoReflect.Descriptor instead.
+func (*ResetPasswordRequest) Descriptor() ([]byte, []int) {
+	return file_auth_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *ResetPasswordRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *ResetPasswordRequest) GetNewPassword() string {
+	if x != nil {
+		return x.NewPassword
+	}
+	return ""
+}
+
+// Pat personal access token. Secret part is returned only by CreatePat
+type Pat struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Scopes        []string               `protobuf:"bytes,3,rep,name=scopes,proto3" json:"scopes,omitempty"`
+	CreatedAt     int64                  `protobuf:"varint,4,opt,name=createdAt,proto3" json:"createdAt,omitempty"`
+	ExpiresAt     int64                  `protobuf:"varint,5,opt,name=expiresAt,proto3" json:"expiresAt,omitempty"`
+	LastUsedAt    int64                  `protobuf:"varint,6,opt,name=lastUsedAt,proto3" json:"lastUsedAt,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Pat) Reset() {
+	*x = Pat{}
+	mi := &file_auth_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Pat) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Pat) ProtoMessage() {}
+
+func (x *Pat) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Pat.ProtoReflect.Descriptor instead.
+func (*Pat) Descriptor() ([]byte, []int) {
+	return file_auth_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *Pat) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Pat) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Pat) GetScopes() []string {
+	if x != nil {
+		return x.Scopes
+	}
+	return nil
+}
+
+func (x *Pat) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+func (x *Pat) GetExpiresAt() int64 {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return 0
+}
+
+func (x *Pat) GetLastUsedAt() int64 {
+	if x != nil {
+		return x.LastUsedAt
+	}
+	return 0
+}
+
+type Pats struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Items         []*Pat                 `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Pats) Reset() {
+	*x = Pats{}
+	mi := &file_auth_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Pats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Pats) ProtoMessage() {}
+
+func (x *Pats) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Pats.ProtoReflect.Descriptor instead.
+func (*Pats) Descriptor() ([]byte, []int) {
+	return file_auth_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *Pats) GetItems() []*Pat {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+type CreatePatRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=userId,proto3" json:"userId,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Scopes        []string               `protobuf:"bytes,3,rep,name=scopes,proto3" json:"scopes,omitempty"`
+	ExpiresAt     int64                  `protobuf:"varint,4,opt,name=expiresAt,proto3" json:"expiresAt,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreatePatRequest) Reset() {
+	*x = CreatePatRequest{}
+	mi := &file_auth_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreatePatRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreatePatRequest) ProtoMessage() {}
+
+func (x *CreatePatRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreatePatRequest.ProtoReflect.Descriptor instead.
+func (*CreatePatRequest) Descriptor() ([]byte, []int) {
+	return file_auth_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *CreatePatRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *CreatePatRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreatePatRequest) GetScopes() []string {
+	if x != nil {
+		return x.Scopes
+	}
+	return nil
+}
+
+func (x *CreatePatRequest) GetExpiresAt() int64 {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return 0
+}
+
+type PatCreated struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	Pat           *Pat                   `protobuf:"bytes,2,opt,name=pat,proto3" json:"pat,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PatCreated) Reset() {
+	*x = PatCreated{}
+	mi := &file_auth_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PatCreated) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PatCreated) ProtoMessage() {}
+
+func (x *PatCreated) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PatCreated.ProtoReflect.Descriptor instead.
+func (*PatCreated) Descriptor() ([]byte, []int) {
+	return file_auth_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *PatCreated) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *PatCreated) GetPat() *Pat {
+	if x != nil {
+		return x.Pat
+	}
+	return nil
+}
+
+type PatId struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=userId,proto3" json:"userId,omitempty"`
+	Id            string                 `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PatId) Reset() {
+	*x = PatId{}
+	mi := &file_auth_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PatId) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PatId) ProtoMessage() {}
+
+func (x *PatId) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PatId.ProtoReflect.Descriptor instead.
+func (*PatId) Descriptor() ([]byte, []int) {
+	return file_auth_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *PatId) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *PatId) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type PatOwner struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=userId,proto3" json:"userId,omitempty"`
+	Scopes        []string               `protobuf:"bytes,2,rep,name=scopes,proto3" json:"scopes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PatOwner) Reset() {
+	*x = PatOwner{}
+	mi := &file_auth_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PatOwner) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PatOwner) ProtoMessage() {}
+
+func (x *PatOwner) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PatOwner.ProtoReflect.Descriptor instead.
+func (*PatOwner) Descriptor() ([]byte, []int) {
+	return file_auth_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *PatOwner) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *PatOwner) GetScopes() []string {
+	if x != nil {
+		return x.Scopes
+	}
+	return nil
+}
+
+type OidcStartResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Url           string                 `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`
+	State         string                 `protobuf:"bytes,2,opt,name=state,proto3" json:"state,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OidcStartResponse) Reset() {
+	*x = OidcStartResponse{}
+	mi := &file_auth_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OidcStartResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OidcStartResponse) ProtoMessage() {}
+
+func (x *OidcStartResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OidcStartResponse.ProtoReflect.Descriptor instead.
+func (*OidcStartResponse) Descriptor() ([]byte, []int) {
+	return file_auth_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *OidcStartResponse) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *OidcStartResponse) GetState() string {
+	if x != nil {
+		return x.State
+	}
+	return ""
+}
+
+type OidcCallbackRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Provider      string                 `protobuf:"bytes,1,opt,name=provider,proto3" json:"provider,omitempty"`
+	Code          string                 `protobuf:"bytes,2,opt,name=code,proto3" json:"code,omitempty"`
+	State         string                 `protobuf:"bytes,3,opt,name=state,proto3" json:"state,omitempty"`
+	UserAgent     string                 `protobuf:"bytes,4,opt,name=userAgent,proto3" json:"userAgent,omitempty"`
+	Ip            string                 `protobuf:"bytes,5,opt,name=ip,proto3" json:"ip,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OidcCallbackRequest) Reset() {
+	*x = OidcCallbackRequest{}
+	mi := &file_auth_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OidcCallbackRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OidcCallbackRequest) ProtoMessage() {}
+
+func (x *OidcCallbackRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OidcCallbackRequest.ProtoReflect.Descriptor instead.
+func (*OidcCallbackRequest) Descriptor() ([]byte, []int) {
+	return file_auth_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *OidcCallbackRequest) GetProvider() string {
+	if x != nil {
+		return x.Provider
+	}
+	return ""
+}
+
+func (x *OidcCallbackRequest) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *OidcCallbackRequest) GetState() string {
+	if x != nil {
+		return x.State
+	}
+	return ""
+}
+
+func (x *OidcCallbackRequest) GetUserAgent() string {
+	if x != nil {
+		return x.UserAgent
+	}
+	return ""
+}
+
+func (x *OidcCallbackRequest) GetIp() string {
+	if x != nil {
+		return x.Ip
+	}
+	return ""
+}
+
+type UnlockLoginRequest struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	ActorId string                 `protobuf:"bytes,1,opt,name=actorId,proto3" json:"actorId,omitempty"`
+	// login or email
+	Identifier    string `protobuf:"bytes,2,opt,name=identifier,proto3" json:"identifier,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnlockLoginRequest) Reset() {
+	*x = UnlockLoginRequest{}
+	mi := &file_auth_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnlockLoginRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnlockLoginRequest) ProtoMessage() {}
+
+func (x *UnlockLoginRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnlockLoginRequest.ProtoReflect.Descriptor instead.
+func (*UnlockLoginRequest) Descriptor() ([]byte, []int) {
+	return file_auth_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *UnlockLoginRequest) GetActorId() string {
+	if x != nil {
+		return x.ActorId
+	}
+	return ""
+}
+
+func (x *UnlockLoginRequest) GetIdentifier() string {
+	if x != nil {
+		return x.Identifier
+	}
+	return ""
+}
+
+type AdminUserRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ActorId       string                 `protobuf:"bytes,1,opt,name=actorId,proto3" json:"actorId,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=userId,proto3" json:"userId,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AdminUserRequest) Reset() {
+	*x = AdminUserRequest{}
+	mi := &file_auth_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AdminUserRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AdminUserRequest) ProtoMessage() {}
+
+func (x *AdminUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AdminUserRequest.ProtoReflect.Descriptor instead.
+func (*AdminUserRequest) Descriptor() ([]byte, []int) {
+	return file_auth_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *AdminUserRequest) GetActorId() string {
+	if x != nil {
+		return x.ActorId
+	}
+	return ""
+}
+
+func (x *AdminUserRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type ListUsersRequest struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	ActorId string                 `protobuf:"bytes,1,opt,name=actorId,proto3" json:"actorId,omitempty"`
+	// login or email contains query, empty is all users
+	Query         string `protobuf:"bytes,2,opt,name=query,proto3" json:"query,omitempty"`
+	Limit         int32  `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	Offset        int32  `protobuf:"varint,4,opt,name=offset,proto3" json:"offset,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListUsersRequest) Reset() {
+	*x = ListUsersRequest{}
+	mi := &file_auth_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListUsersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListUsersRequest) ProtoMessage() {}
+
+func (x *ListUsersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListUsersRequest.ProtoReflect.Descriptor instead.
+func (*ListUsersRequest) Descriptor() ([]byte, []int) {
+	return file_auth_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *ListUsersRequest) GetActorId() string {
+	if x != nil {
+		return x.ActorId
+	}
+	return ""
+}
+
+func (x *ListUsersRequest) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+func (x *ListUsersRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListUsersRequest) GetOffset() int32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+type AdminUsers struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Users         []*User                `protobuf:"bytes,1,rep,name=users,proto3" json:"users,omitempty"`
+	Total         int32                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AdminUsers) Reset() {
+	*x = AdminUsers{}
+	mi := &file_auth_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AdminUsers) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AdminUsers) ProtoMessage() {}
+
+func (x *AdminUsers) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AdminUsers.ProtoReflect.Descriptor instead.
+func (*AdminUsers) Descriptor() ([]byte, []int) {
+	return file_auth_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *AdminUsers) GetUsers() []*User {
+	if x != nil {
+		return x.Users
+	}
+	return nil
+}
+
+func (x *AdminUsers) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+type SetUserDisabledRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ActorId       string                 `protobuf:"bytes,1,opt,name=actorId,proto3" json:"actorId,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=userId,proto3" json:"userId,omitempty"`
+	Disabled      bool                   `protobuf:"varint,3,opt,name=disabled,proto3" json:"disabled,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetUserDisabledRequest) Reset() {
+	*x = SetUserDisabledRequest{}
+	mi := &file_auth_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetUserDisabledRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetUserDisabledRequest) ProtoMessage() {}
+
+func (x *SetUserDisabledRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetUserDisabledRequest.ProtoReflect.Descriptor instead.
+func (*SetUserDisabledRequest) Descriptor() ([]byte, []int) {
+	return file_auth_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *SetUserDisabledRequest) GetActorId() string {
+	if x != nil {
+		return x.ActorId
+	}
+	return ""
+}
+
+func (x *SetUserDisabledRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *SetUserDisabledRequest) GetDisabled() bool {
+	if x != nil {
+		return x.Disabled
+	}
+	return false
+}
+
+type SetUserRoleRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ActorId       string                 `protobuf:"bytes,1,opt,name=actorId,proto3" json:"actorId,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=userId,proto3" json:"userId,omitempty"`
+	Role          string                 `protobuf:"bytes,3,opt,name=role,proto3" json:"role,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetUserRoleRequest) Reset() {
+	*x = SetUserRoleRequest{}
+	mi := &file_auth_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetUserRoleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetUserRoleRequest) ProtoMessage() {}
+
+func (x *SetUserRoleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetUserRoleRequest.ProtoReflect.Descriptor instead.
+func (*SetUserRoleRequest) Descriptor() ([]byte, []int) {
+	return file_auth_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *SetUserRoleRequest) GetActorId() string {
+	if x != nil {
+		return x.ActorId
+	}
+	return ""
+}
+
+func (x *SetUserRoleRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *SetUserRoleRequest) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+type AuditEventsRequest struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	ActorId string                 `protobuf:"bytes,1,opt,name=actorId,proto3" json:"actorId,omitempty"`
+	// userId is optional filter
+	UserId        string `protobuf:"bytes,2,opt,name=userId,proto3" json:"userId,omitempty"`
+	Limit         int32  `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	Offset        int32  `protobuf:"varint,4,opt,name=offset,proto3" json:"offset,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AuditEventsRequest) Reset() {
+	*x = AuditEventsRequest{}
+	mi := &file_auth_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AuditEventsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AuditEventsRequest) ProtoMessage() {}
+
+func (x *AuditEventsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AuditEventsRequest.ProtoReflect.Descriptor instead.
+func (*AuditEventsRequest) Descriptor() ([]byte, []int) {
+	return file_auth_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *AuditEventsRequest) GetActorId() string {
+	if x != nil {
+		return x.ActorId
+	}
+	return ""
+}
+
+func (x *AuditEventsRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *AuditEventsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *AuditEventsRequest) GetOffset() int32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+type AuditEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=userId,proto3" json:"userId,omitempty"`
+	ActorId       string                 `protobuf:"bytes,3,opt,name=actorId,proto3" json:"actorId,omitempty"`
+	Action        string                 `protobuf:"bytes,4,opt,name=action,proto3" json:"action,omitempty"`
+	Target        string                 `protobuf:"bytes,5,opt,name=target,proto3" json:"target,omitempty"`
+	Ip            string                 `protobuf:"bytes,6,opt,name=ip,proto3" json:"ip,omitempty"`
+	CreatedAt     int64                  `protobuf:"varint,7,opt,name=createdAt,proto3" json:"createdAt,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AuditEvent) Reset() {
+	*x = AuditEvent{}
+	mi := &file_auth_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AuditEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AuditEvent) ProtoMessage() {}
+
+func (x *AuditEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AuditEvent.ProtoReflect.Descriptor instead.
+func (*AuditEvent) Descriptor() ([]byte, []int) {
+	return file_auth_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *AuditEvent) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *AuditEvent) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *AuditEvent) GetActorId() string {
+	if x != nil {
+		return x.ActorId
+	}
+	return ""
+}
+
+func (x *AuditEvent) GetAction() string {
+	if x != nil {
+		return x.Action
+	}
+	return ""
+}
+
+func (x *AuditEvent) GetTarget() string {
+	if x != nil {
+		return x.Target
+	}
+	return ""
+}
+
+func (x *AuditEvent) GetIp() string {
+	if x != nil {
+		return x.Ip
+	}
+	return ""
+}
+
+func (x *AuditEvent) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+type AuditEvents struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Events        []*AuditEvent          `protobuf:"bytes,1,rep,name=events,proto3" json:"events,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AuditEvents) Reset() {
+	*x = AuditEvents{}
+	mi := &file_auth_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AuditEvents) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AuditEvents) ProtoMessage() {}
+
+func (x *AuditEvents) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AuditEvents.ProtoReflect.Descriptor instead.
+func (*AuditEvents) Descriptor() ([]byte, []int) {
+	return file_auth_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *AuditEvents) GetEvents() []*AuditEvent {
+	if x != nil {
+		return x.Events
+	}
+	return nil
+}
+
+type UpdatePreferencesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=userId,proto3" json:"userId,omitempty"`
+	Preferences   *Preferences           `protobuf:"bytes,2,opt,name=preferences,proto3" json:"preferences,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdatePreferencesRequest) Reset() {
+	*x = UpdatePreferencesRequest{}
+	mi := &file_auth_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdatePreferencesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdatePreferencesRequest) ProtoMessage() {}
+
+func (x *UpdatePreferencesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdatePreferencesRequest.ProtoReflect.Descriptor instead.
+func (*UpdatePreferencesRequest) Descriptor() ([]byte, []int) {
+	return file_auth_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *UpdatePreferencesRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *UpdatePreferencesRequest) GetPreferences() *Preferences {
+	if x != nil {
+		return x.Preferences
+	}
+	return nil
+}
+
+type SearchUsersRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	UserId string                 `protobuf:"bytes,1,opt,name=userId,proto3" json:"userId,omitempty"`
+	// start of login or email, or login with typo
+	Query         string `protobuf:"bytes,2,opt,name=query,proto3" json:"query,omitempty"`
+	Limit         int32  `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchUsersRequest) Reset() {
+	*x = SearchUsersRequest{}
+	mi := &file_auth_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchUsersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchUsersRequest) ProtoMessage() {}
+
+func (x *SearchUsersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchUsersRequest.ProtoReflect.Descriptor instead.
+func (*SearchUsersRequest) Descriptor() ([]byte, []int) {
+	return file_auth_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *SearchUsersRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *SearchUsersRequest) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+func (x *SearchUsersRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+// UserCard is public part of user, without email
+type UserCard struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Login         string                 `protobuf:"bytes,2,opt,name=login,proto3" json:"login,omitempty"`
+	Photo         string                 `protobuf:"bytes,3,opt,name=photo,proto3" json:"photo,omitempty"`
+	About         string                 `protobuf:"bytes,4,opt,name=about,proto3" json:"about,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UserCard) Reset() {
+	*x = UserCard{}
+	mi := &file_auth_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UserCard) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UserCard) ProtoMessage() {}
+
+func (x *UserCard) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UserCard.ProtoReflect.Descriptor instead.
+func (*UserCard) Descriptor() ([]byte, []int) {
+	return file_auth_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *UserCard) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *UserCard) GetLogin() string {
+	if x != nil {
+		return x.Login
+	}
+	return ""
+}
+
+func (x *UserCard) GetPhoto() string {
+	if x != nil {
+		return x.Photo
+	}
+	return ""
+}
+
+func (x *UserCard) GetAbout() string {
+	if x != nil {
+		return x.About
+	}
+	return ""
+}
+
+type UserCards struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Users         []*UserCard            `protobuf:"bytes,1,rep,name=users,proto3" json:"users,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UserCards) Reset() {
+	*x = UserCards{}
+	mi := &file_auth_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UserCards) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UserCards) ProtoMessage() {}
+
+func (x *UserCards) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UserCards.ProtoReflect.Descriptor instead.
+func (*UserCards) Descriptor() ([]byte, []int) {
+	return file_auth_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *UserCards) GetUsers() []*UserCard {
+	if x != nil {
+		return x.Users
+	}
+	return nil
+}
+
+// AccountDeletion state of deletion saga. step is next step to make
+type AccountDeletion struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=userId,proto3" json:"userId,omitempty"`
+	State         string                 `protobuf:"bytes,2,opt,name=state,proto3" json:"state,omitempty"`
+	Step          string                 `protobuf:"bytes,3,opt,name=step,proto3" json:"step,omitempty"`
+	Attempts      int32                  `protobuf:"varint,4,opt,name=attempts,proto3" json:"attempts,omitempty"`
+	LastError     string                 `protobuf:"bytes,5,opt,name=lastError,proto3" json:"lastError,omitempty"`
+	RequestedAt   int64                  `protobuf:"varint,6,opt,name=requestedAt,proto3" json:"requestedAt,omitempty"`
+	PurgeAt       int64                  `protobuf:"varint,7,opt,name=purgeAt,proto3" json:"purgeAt,omitempty"`
+	NextAttemptAt int64                  `protobuf:"varint,8,opt,name=nextAttemptAt,proto3" json:"nextAttemptAt,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AccountDeletion) Reset() {
+	*x = AccountDeletion{}
+	mi := &file_auth_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AccountDeletion) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AccountDeletion) ProtoMessage() {}
+
+func (x *AccountDeletion) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AccountDeletion.ProtoReflect.Descriptor instead.
+func (*AccountDeletion) Descriptor() ([]byte, []int) {
+	return file_auth_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *AccountDeletion) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *AccountDeletion) GetState() string {
+	if x != nil {
+		return x.State
+	}
+	return ""
+}
+
+func (x *AccountDeletion) GetStep() string {
+	if x != nil {
+		return x.Step
+	}
+	return ""
+}
+
+func (x *AccountDeletion) GetAttempts() int32 {
+	if x != nil {
+		return x.Attempts
+	}
+	return 0
+}
+
+func (x *AccountDeletion) GetLastError() string {
+	if x != nil {
+		return x.LastError
+	}
+	return ""
+}
+
+func (x *AccountDeletion) GetRequestedAt() int64 {
+	if x != nil {
+		return x.RequestedAt
+	}
+	return 0
+}
+
+func (x *AccountDeletion) GetPurgeAt() int64 {
+	if x != nil {
+		return x.PurgeAt
+	}
+	return 0
+}
+
+func (x *AccountDeletion) GetNextAttemptAt() int64 {
+	if x != nil {
+		return x.NextAttemptAt
+	}
+	return 0
+}
+
+type AccountDeletions struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Deletions     []*AccountDeletion     `protobuf:"bytes,1,rep,name=deletions,proto3" json:"deletions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AccountDeletions) Reset() {
+	*x = AccountDeletions{}
+	mi := &file_auth_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AccountDeletions) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AccountDeletions) ProtoMessage() {}
+
+func (x *AccountDeletions) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AccountDeletions.ProtoReflect.Descriptor instead.
+func (*AccountDeletions) Descriptor() ([]byte, []int) {
+	return file_auth_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *AccountDeletions) GetDeletions() []*AccountDeletion {
+	if x != nil {
+		return x.Deletions
+	}
+	return nil
+}
+
+type ClaimDeletionsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Limit         int32                  `protobuf:"varint,1,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ClaimDeletionsRequest) Reset() {
+	*x = ClaimDeletionsRequest{}
+	mi := &file_auth_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ClaimDeletionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClaimDeletionsRequest) ProtoMessage() {}
+
+func (x *ClaimDeletionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClaimDeletionsRequest.ProtoReflect.Descriptor instead.
+func (*ClaimDeletionsRequest) Descriptor() ([]byte, []int) {
+	return file_auth_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *ClaimDeletionsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type DeletionStepRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	UserId string                 `protobuf:"bytes,1,opt,name=userId,proto3" json:"userId,omitempty"`
+	Step   string                 `protobuf:"bytes,2,opt,name=step,proto3" json:"step,omitempty"`
+	// error of failed step
+	Error         string `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeletionStepRequest) Reset() {
+	*x = DeletionStepRequest{}
+	mi := &file_auth_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeletionStepRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeletionStepRequest) ProtoMessage() {}
+
+func (x *DeletionStepRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeletionStepRequest.ProtoReflect.Descriptor instead.
+func (*DeletionStepRequest) Descriptor() ([]byte, []int) {
+	return file_auth_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *DeletionStepRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *DeletionStepRequest) GetStep() string {
+	if x != nil {
+		return x.Step
+	}
+	return ""
+}
+
+func (x *DeletionStepRequest) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+var File_auth_proto protoreflect.FileDescriptor
+
+const file_auth_proto_rawDesc = "" +
+	"\n" +
+	"\n" +
+	"auth.proto\x12\x03brz\x1a\fdomain.proto\x1a\x1bgoogle/protobuf/empty.proto\"\x83\x01\n" +
+	"\vAuthRequest\x12\x14\n" +
+	"\x05email\x18\x01 \x01(\tR\x05email\x12\x14\n" +
+	"\x05login\x18\x02 \x01(\tR\x05login\x12\x1a\n" +
+	"\bpassword\x18\x03 \x01(\tR\bpassword\x12\x1c\n" +
+	"\tuserAgent\x18\x04 \x01(\tR\tuserAgent\x12\x0e\n" +
+	"\x02ip\x18\x05 \x01(\tR\x02ip\"A\n" +
+	"\x12UpdateAboutRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1b\n" +
+	"\tnew_about\x18\x02 \x01(\tR\bnewAbout\"A\n" +
+	"\x12UpdateEmailRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1b\n" +
+	"\tnew_email\x18\x02 \x01(\tR\bnewEmail\"O\n" +
+	"\x19UpdateDiscoverableRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\"\n" +
+	"\fdiscoverable\x18\x02 \x01(\bR\fdiscoverable\"A\n" +
+	"\x12UpdatePhotoRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1b\n" +
+	"\tnew_photo\x18\x02 \x01(\tR\bnewPhoto\"\xc1\x01\n" +
+	"\x15ChangePasswordRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12!\n" +
+	"\fnew_password\x18\x02 \x01(\tR\vnewPassword\x12!\n" +
+	"\fold_password\x18\x03 \x01(\tR\voldPassword\x120\n" +
+	"\x13revokeOtherSessions\x18\x04 \x01(\bR\x13revokeOtherSessions\x12 \n" +
+	"\vaccessToken\x18\x05 \x01(\tR\vaccessToken\"V\n" +
+	"\x16CreateWorkspaceRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
+	"\x05title\x18\x02 \x01(\tR\x05title\x12\x16\n" +
+	"\x06userId\x18\x03 \x01(\tR\x06userId\"\x82\x01\n" +
+	"\x16WorkspaceMemberRequest\x12 \n" +
+	"\vworkspaceId\x18\x01 \x01(\tR\vworkspaceId\x12\x1a\n" +
+	"\bmemberId\x18\x02 \x01(\tR\bmemberId\x12\x12\n" +
+	"\x04role\x18\x03 \x01(\tR\x04role\x12\x16\n" +
+	"\x06userId\x18\x04 \x01(\tR\x06userId\"\x9f\x01\n" +
+	"\aSession\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1c\n" +
+	"\tuserAgent\x18\x02 \x01(\tR\tuserAgent\x12\x0e\n" +
+	"\x02ip\x18\x03 \x01(\tR\x02ip\x12\x1c\n" +
+	"\tcreatedAt\x18\x04 \x01(\x03R\tcreatedAt\x12\x1e\n" +
+	"\n" +
+	"lastSeenAt\x18\x05 \x01(\x03R\n" +
+	"lastSeenAt\x12\x18\n" +
+	"\acurrent\x18\x06 \x01(\bR\acurrent\".\n" +
+	"\bSessions\x12\"\n" +
+	"\x05items\x18\x01 \x03(\v2\f.brz.SessionR\x05items\"O\n" +
+	"\x13ListSessionsRequest\x12\x16\n" +
+	"\x06userId\x18\x01 \x01(\tR\x06userId\x12 \n" +
+	"\vaccessToken\x18\x02 \x01(\tR\vaccessToken\"E\n" +
+	"\rUserSessionId\x12\x16\n" +
+	"\x06userId\x18\x01 \x01(\tR\x06userId\x12\x1c\n" +
+	"\tsessionId\x18\x02 \x01(\tR\tsessionId\"\x89\x01\n" +
+	"\x03JWK\x12\x10\n" +
+	"\x03kid\x18\x01 \x01(\tR\x03kid\x12\x10\n" +
+	"\x03kty\x18\x02 \x01(\tR\x03kty\x12\x10\n" +
+	"\x03alg\x18\x03 \x01(\tR\x03alg\x12\x10\n" +
+	"\x03use\x18\x04 \x01(\tR\x03use\x12\x10\n" +
+	"\x03crv\x18\x05 \x01(\tR\x03crv\x12\f\n" +
+	"\x01x\x18\x06 \x01(\tR\x01x\x12\f\n" +
+	"\x01n\x18\a \x01(\tR\x01n\x12\f\n" +
+	"\x01e\x18\b \x01(\tR\x01e\"$\n" +
+	"\x04JWKS\x12\x1c\n" +
+	"\x04keys\x18\x01 \x03(\v2\b.brz.JWKR\x04keys\"\xe3\x01\n" +
+	"\fAuthResponse\x12!\n" +
+	"\faccess_token\x18\x01 \x01(\tR\vaccessToken\x12#\n" +
+	"\rrefresh_token\x18\x02 \x01(\tR\frefreshToken\x12\x1c\n" +
+	"\texpAccess\x18\x03 \x01(\x03R\texpAccess\x12\x1e\n" +
+	"\n" +
+	"expRefresh\x18\x04 \x01(\x03R\n" +
+	"expRefresh\x12%\n" +
+	"\bmetadata\x18\x05 \x01(\v2\t.brz.UserR\bmetadata\x12&\n" +
+	"\x0echallengeToken\x18\x06 \x01(\tR\x0echallengeToken\"\x7f\n" +
+	"\x13SecondFactorRequest\x12&\n" +
+	"\x0echallengeToken\x18\x01 \x01(\tR\x0echallengeToken\x12\x12\n" +
+	"\x04code\x18\x02 \x01(\tR\x04code\x12\x1c\n" +
+	"\tuserAgent\x18\x03 \x01(\tR\tuserAgent\x12\x0e\n" +
+	"\x02ip\x18\x04 \x01(\tR\x02ip\"5\n" +
+	"\tTotpSetup\x12\x16\n" +
+	"\x06secret\x18\x01 \x01(\tR\x06secret\x12\x10\n" +
+	"\x03uri\x18\x02 \x01(\tR\x03uri\"Y\n" +
+	"\x0fTotpCodeRequest\x12\x16\n" +
+	"\x06userId\x18\x01 \x01(\tR\x06userId\x12\x12\n" +
+	"\x04code\x18\x02 \x01(\tR\x04code\x12\x1a\n" +
+	"\bpassword\x18\x03 \x01(\tR\bpassword\"%\n" +
+	"\rRecoveryCodes\x12\x14\n" +
+	"\x05codes\x18\x01 \x03(\tR\x05codes\"N\n" +
+	"\x14ResetPasswordRequest\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\x12 \n" +
+	"\vnewPassword\x18\x02 \x01(\tR\vnewPassword\"\x9d\x01\n" +
+	"\x03Pat\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x16\n" +
+	"\x06scopes\x18\x03 \x03(\tR\x06scopes\x12\x1c\n" +
+	"\tcreatedAt\x18\x04 \x01(\x03R\tcreatedAt\x12\x1c\n" +
+	"\texpiresAt\x18\x05 \x01(\x03R\texpiresAt\x12\x1e\n" +
+	"\n" +
+	"lastUsedAt\x18\x06 \x01(\x03R\n" +
+	"lastUsedAt\"&\n" +
+	"\x04Pats\x12\x1e\n" +
+	"\x05items\x18\x01 \x03(\v2\b.brz.PatR\x05items\"t\n" +
+	"\x10CreatePatRequest\x12\x16\n" +
+	"\x06userId\x18\x01 \x01(\tR\x06userId\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x16\n" +
+	"\x06scopes\x18\x03 \x03(\tR\x06scopes\x12\x1c\n" +
+	"\texpiresAt\x18\x04 \x01(\x03R\texpiresAt\">\n" +
+	"\n" +
+	"PatCreated\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\x12\x1a\n" +
+	"\x03pat\x18\x02 \x01(\v2\b.brz.PatR\x03pat\"/\n" +
+	"\x05PatId\x12\x16\n" +
+	"\x06userId\x18\x01 \x01(\tR\x06userId\x12\x0e\n" +
+	"\x02id\x18\x02 \x01(\tR\x02id\":\n" +
+	"\bPatOwner\x12\x16\n" +
+	"\x06userId\x18\x01 \x01(\tR\x06userId\x12\x16\n" +
+	"\x06scopes\x18\x02 \x03(\tR\x06scopes\";\n" +
+	"\x11OidcStartResponse\x12\x10\n" +
+	"\x03url\x18\x01 \x01(\tR\x03url\x12\x14\n" +
+	"\x05state\x18\x02 \x01(\tR\x05state\"\x89\x01\n" +
+	"\x13OidcCallbackRequest\x12\x1a\n" +
+	"\bprovider\x18\x01 \x01(\tR\bprovider\x12\x12\n" +
+	"\x04code\x18\x02 \x01(\tR\x04code\x12\x14\n" +
+	"\x05state\x18\x03 \x01(\tR\x05state\x12\x1c\n" +
+	"\tuserAgent\x18\x04 \x01(\tR\tuserAgent\x12\x0e\n" +
+	"\x02ip\x18\x05 \x01(\tR\x02ip\"N\n" +
+	"\x12UnlockLoginRequest\x12\x18\n" +
+	"\aactorId\x18\x01 \x01(\tR\aactorId\x12\x1e\n" +
+	"\n" +
+	"identifier\x18\x02 \x01(\tR\n" +
+	"identifier\"D\n" +
+	"\x10AdminUserRequest\x12\x18\n" +
+	"\aactorId\x18\x01 \x01(\tR\aactorId\x12\x16\n" +
+	"\x06userId\x18\x02 \x01(\tR\x06userId\"p\n" +
+	"\x10ListUsersRequest\x12\x18\n" +
+	"\aactorId\x18\x01 \x01(\tR\aactorId\x12\x14\n" +
+	"\x05query\x18\x02 \x01(\tR\x05query\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\x05R\x05limit\x12\x16\n" +
+	"\x06offset\x18\x04 \x01(\x05R\x06offset\"C\n" +
+	"\n" +
+	"AdminUsers\x12\x1f\n" +
+	"\x05users\x18\x01 \x03(\v2\t.brz.UserR\x05users\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\x05R\x05total\"f\n" +
+	"\x16SetUserDisabledRequest\x12\x18\n" +
+	"\aactorId\x18\x01 \x01(\tR\aactorId\x12\x16\n" +
+	"\x06userId\x18\x02 \x01(\tR\x06userId\x12\x1a\n" +
+	"\bdisabled\x18\x03 \x01(\bR\bdisabled\"Z\n" +
+	"\x12SetUserRoleRequest\x12\x18\n" +
+	"\aactorId\x18\x01 \x01(\tR\aactorId\x12\x16\n" +
+	"\x06userId\x18\x02 \x01(\tR\x06userId\x12\x12\n" +
+	"\x04role\x18\x03 \x01(\tR\x04role\"t\n" +
+	"\x12AuditEventsRequest\x12\x18\n" +
+	"\aactorId\x18\x01 \x01(\tR\aactorId\x12\x16\n" +
+	"\x06userId\x18\x02 \x01(\tR\x06userId\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\x05R\x05limit\x12\x16\n" +
+	"\x06offset\x18\x04 \x01(\x05R\x06offset\"\xac\x01\n" +
+	"\n" +
+	"AuditEvent\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x16\n" +
+	"\x06userId\x18\x02 \x01(\tR\x06userId\x12\x18\n" +
+	"\aactorId\x18\x03 \x01(\tR\aactorId\x12\x16\n" +
+	"\x06action\x18\x04 \x01(\tR\x06action\x12\x16\n" +
+	"\x06target\x18\x05 \x01(\tR\x06target\x12\x0e\n" +
+	"\x02ip\x18\x06 \x01(\tR\x02ip\x12\x1c\n" +
+	"\tcreatedAt\x18\a \x01(\x03R\tcreatedAt\"6\n" +
+	"\vAuditEvents\x12'\n" +
+	"\x06events\x18\x01 \x03(\v2\x0f.brz.AuditEventR\x06events\"f\n" +
+	"\x18UpdatePreferencesRequest\x12\x16\n" +
+	"\x06userId\x18\x01 \x01(\tR\x06userId\x122\n" +
+	"\vpreferences\x18\x02 \x01(\v2\x10.brz.PreferencesR\vpreferences\"X\n" +
+	"\x12SearchUsersRequest\x12\x16\n" +
+	"\x06userId\x18\x01 \x01(\tR\x06userId\x12\x14\n" +
+	"\x05query\x18\x02 \x01(\tR\x05query\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\x05R\x05limit\"\\\n" +
+	"\bUserCard\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
+	"\x05login\x18\x02 \x01(\tR\x05login\x12\x14\n" +
+	"\x05photo\x18\x03 \x01(\tR\x05photo\x12\x14\n" +
+	"\x05about\x18\x04 \x01(\tR\x05about\"0\n" +
+	"\tUserCards\x12#\n" +
+	"\x05users\x18\x01 \x03(\v2\r.brz.UserCardR\x05users\"\xef\x01\n" +
+	"\x0fAccountDeletion\x12\x16\n" +
+	"\x06userId\x18\x01 \x01(\tR\x06userId\x12\x14\n" +
+	"\x05state\x18\x02 \x01(\tR\x05state\x12\x12\n" +
+	"\x04step\x18\x03 \x01(\tR\x04step\x12\x1a\n" +
+	"\battempts\x18\x04 \x01(\x05R\battempts\x12\x1c\n" +
+	"\tlastError\x18\x05 \x01(\tR\tlastError\x12 \n" +
+	"\vrequestedAt\x18\x06 \x01(\x03R\vrequestedAt\x12\x18\n" +
+	"\apurgeAt\x18\a \x01(\x03R\apurgeAt\x12$\n" +
+	"\rnextAttemptAt\x18\b \x01(\x03R\rnextAttemptAt\"F\n" +
+	"\x10AccountDeletions\x122\n" +
+	"\tdeletions\x18\x01 \x03(\v2\x14.brz.AccountDeletionR\tdeletions\"-\n" +
+	"\x15ClaimDeletionsRequest\x12\x14\n" +
+	"\x05limit\x18\x01 \x01(\x05R\x05limit\"W\n" +
+	"\x13DeletionStepRequest\x12\x16\n" +
+	"\x06userId\x18\x01 \x01(\tR\x06userId\x12\x12\n" +
+	"\x04step\x18\x02 \x01(\tR\x04step\x12\x14\n" +
+	"\x05error\x18\x03 \x01(\tR\x05error2\xb0\x14\n" +
 	"\vAuthService\x12+\n" +
 	"\x04Auth\x12\x10.brz.AuthRequest\x1a\x11.brz.AuthResponse\x12$\n" +
-	"\x03Reg\x12\x10.brz.AuthRequest\x1a\v.brz.Tokens\x12)\n" +
-	"\x0eValidateTokens\x12\v.brz.Tokens\x1a\n" +
-	".brz.Token\x121\n" +
+	"\x03Reg\x12\x10.brz.AuthRequest\x1a\v.brz.Tokens\x12*\n" +
+	"\x0eValidateTokens\x12\v.brz.Tokens\x1a\v.brz.Tokens\x12-\n" +
+	"\x06Logout\x12\v.brz.Tokens\x1a\x16.google.protobuf.Empty\x120\n" +
+	"\tLogoutAll\x12\v.brz.UserId\x1a\x16.google.protobuf.Empty\x127\n" +
+	"\fListSessions\x12\x18.brz.ListSessionsRequest\x1a\r.brz.Sessions\x12;\n" +
+	"\rRevokeSession\x12\x12.brz.UserSessionId\x1a\x16.google.protobuf.Empty\x12,\n" +
+	"\aGetJWKS\x12\x16.google.protobuf.Empty\x1a\t.brz.JWKS\x12A\n" +
+	"\x12VerifySecondFactor\x12\x18.brz.SecondFactorRequest\x1a\x11.brz.AuthResponse\x12(\n" +
+	"\tSetupTotp\x12\v.brz.UserId\x1a\x0e.brz.TotpSetup\x127\n" +
+	"\vConfirmTotp\x12\x14.brz.TotpCodeRequest\x1a\x12.brz.RecoveryCodes\x12;\n" +
+	"\vDisableTotp\x12\x14.brz.TotpCodeRequest\x1a\x16.google.protobuf.Empty\x127\n" +
+	"\x10SendVerification\x12\v.brz.UserId\x1a\x16.google.protobuf.Empty\x122\n" +
+	"\vVerifyEmail\x12\v.brz.String\x1a\x16.google.protobuf.Empty\x125\n" +
+	"\x0eForgotPassword\x12\v.brz.String\x1a\x16.google.protobuf.Empty\x12B\n" +
+	"\rResetPassword\x12\x19.brz.ResetPasswordRequest\x1a\x16.google.protobuf.Empty\x125\n" +
+	"\rOidcProviders\x12\x16.google.protobuf.Empty\x1a\f.brz.Strings\x123\n" +
+	"\tCreatePat\x12\x15.brz.CreatePatRequest\x1a\x0f.brz.PatCreated\x12\"\n" +
+	"\bListPats\x12\v.brz.UserId\x1a\t.brz.Pats\x12/\n" +
+	"\tRevokePat\x12\n" +
+	".brz.PatId\x1a\x16.google.protobuf.Empty\x12(\n" +
+	"\vValidatePat\x12\n" +
+	".brz.Token\x1a\r.brz.PatOwner\x120\n" +
+	"\tOidcStart\x12\v.brz.String\x1a\x16.brz.OidcStartResponse\x12;\n" +
+	"\fOidcCallback\x12\x18.brz.OidcCallbackRequest\x1a\x11.brz.AuthResponse\x121\n" +
 	"\n" +
-	"DeleteUser\x12\v.brz.UserId\x1a\x16.google.protobuf.Empty\x12>\n" +
+	"DeleteUser\x12\v.brz.UserId\x1a\x16.google.protobuf.Empty\x124\n" +
+	"\x0fRequestDeletion\x12\v.brz.UserId\x1a\x14.brz.AccountDeletion\x125\n" +
+	"\x0eCancelDeletion\x12\v.brz.UserId\x1a\x16.google.protobuf.Empty\x120\n" +
+	"\vGetDeletion\x12\v.brz.UserId\x1a\x14.brz.AccountDeletion\x12>\n" +
 	"\vUpdateAbout\x12\x17.brz.UpdateAboutRequest\x1a\x16.google.protobuf.Empty\x12>\n" +
 	"\vUpdateEmail\x12\x17.brz.UpdateEmailRequest\x1a\x16.google.protobuf.Empty\x12>\n" +
-	"\vUpdatePhoto\x12\x17.brz.UpdatePhotoRequest\x1a\x16.google.protobuf.Empty\x12B\n" +
+	"\vUpdatePhoto\x12\x17.brz.UpdatePhotoRequest\x1a\x16.google.protobuf.Empty\x12L\n" +
+	"\x12UpdateDiscoverable\x12\x1e.brz.UpdateDiscoverableRequest\x1a\x16.google.protobuf.Empty\x12/\n" +
+	"\x0eGetPreferences\x12\v.brz.UserId\x1a\x10.brz.Preferences\x12D\n" +
+	"\x11UpdatePreferences\x12\x1d.brz.UpdatePreferencesRequest\x1a\x10.brz.Preferences\x12B\n" +
 	"\fChangePasswd\x12\x1a.brz.ChangePasswordRequest\x1a\x16.google.protobuf.Empty\x12/\n" +
 	"\n" +
 	"CreateUser\x12\t.brz.User\x1a\x16.google.protobuf.Empty\x12-\n" +
@@ -423,10 +3001,30 @@ const file_auth_proto_rawDesc = "" +
 	".brz.Token\x1a\t.brz.User\x12%\n" +
 	"\x0eGetIdFromToken\x12\n" +
 	".brz.Token\x1a\a.brz.Id\x12&\n" +
-	"\x0eGetIdFromLogin\x12\v.brz.String\x1a\a.brz.Id\x12 \n" +
+	"\x0eGetIdFromLogin\x12\v.brz.String\x1a\a.brz.Id\x126\n" +
+	"\vSearchUsers\x12\x17.brz.SearchUsersRequest\x1a\x0e.brz.UserCards\x12 \n" +
 	"\bGetInfos\x12\b.brz.Ids\x1a\n" +
-	".brz.Users\x129\n" +
-	"\aHealthz\x12\x16.google.protobuf.Empty\x1a\x16.google.protobuf.EmptyB,Z*github.com/autumnterror/breezynotes;brzrpcb\x06proto3"
+	".brz.Users\x12F\n" +
+	"\x0fCreateWorkspace\x12\x1b.brz.CreateWorkspaceRequest\x1a\x16.google.protobuf.Empty\x12?\n" +
+	"\x0fDeleteWorkspace\x12\x14.brz.UserWorkspaceId\x1a\x16.google.protobuf.Empty\x123\n" +
+	"\x13GetWorkspacesByUser\x12\v.brz.UserId\x1a\x0f.brz.Workspaces\x12B\n" +
+	"\x13GetWorkspaceMembers\x12\x14.brz.UserWorkspaceId\x1a\x15.brz.WorkspaceMembers\x12I\n" +
+	"\x12AddWorkspaceMember\x12\x1b.brz.WorkspaceMemberRequest\x1a\x16.google.protobuf.Empty\x12L\n" +
+	"\x15RemoveWorkspaceMember\x12\x1b.brz.WorkspaceMemberRequest\x1a\x16.google.protobuf.Empty\x129\n" +
+	"\aHealthz\x12\x16.google.protobuf.Empty\x1a\x16.google.protobuf.Empty2\xf1\x03\n" +
+	"\fAdminService\x123\n" +
+	"\tListUsers\x12\x15.brz.ListUsersRequest\x1a\x0f.brz.AdminUsers\x12+\n" +
+	"\aGetUser\x12\x15.brz.AdminUserRequest\x1a\t.brz.User\x12F\n" +
+	"\x0fSetUserDisabled\x12\x1b.brz.SetUserDisabledRequest\x1a\x16.google.protobuf.Empty\x12>\n" +
+	"\vSetUserRole\x12\x17.brz.SetUserRoleRequest\x1a\x16.google.protobuf.Empty\x12C\n" +
+	"\x12ForcePasswordReset\x12\x15.brz.AdminUserRequest\x1a\x16.google.protobuf.Empty\x125\n" +
+	"\vImpersonate\x12\x15.brz.AdminUserRequest\x1a\x0f.brz.PatCreated\x12>\n" +
+	"\vUnlockLogin\x12\x17.brz.UnlockLoginRequest\x1a\x16.google.protobuf.Empty\x12;\n" +
+	"\x0eGetAuditEvents\x12\x17.brz.AuditEventsRequest\x1a\x10.brz.AuditEvents2\xeb\x01\n" +
+	"\x16AccountDeletionService\x12C\n" +
+	"\x0eClaimDeletions\x12\x1a.brz.ClaimDeletionsRequest\x1a\x15.brz.AccountDeletions\x12F\n" +
+	"\x14CompleteDeletionStep\x12\x18.brz.DeletionStepRequest\x1a\x14.brz.AccountDeletion\x12D\n" +
+	"\x10FailDeletionStep\x12\x18.brz.DeletionStepRequest\x1a\x16.google.protobuf.EmptyB,Z*github.com/autumnterror/breezynotes;brzrpcb\x06proto3"
 
 var (
 	file_auth_proto_rawDescOnce sync.Once
@@ -440,59 +3038,200 @@ func file_auth_proto_rawDescGZIP() []byte {
 	return file_auth_proto_rawDescData
 }
 
-var file_auth_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_auth_proto_msgTypes = make([]protoimpl.MessageInfo, 45)
 var file_auth_proto_goTypes = []any{
-	(*AuthRequest)(nil),           // 0: brz.AuthRequest
-	(*UpdateAboutRequest)(nil),    // 1: brz.UpdateAboutRequest
-	(*UpdateEmailRequest)(nil),    // 2: brz.UpdateEmailRequest
-	(*UpdatePhotoRequest)(nil),    // 3: brz.UpdatePhotoRequest
-	(*ChangePasswordRequest)(nil), // 4: brz.ChangePasswordRequest
-	(*AuthResponse)(nil),          // 5: brz.AuthResponse
-	(*User)(nil),                  // 6: brz.User
-	(*Tokens)(nil),                // 7: brz.Tokens
-	(*UserId)(nil),                // 8: brz.UserId
-	(*Token)(nil),                 // 9: brz.Token
-	(*String)(nil),                // 10: brz.String
-	(*Ids)(nil),                   // 11: brz.Ids
-	(*emptypb.Empty)(nil),         // 12: google.protobuf.Empty
-	(*Id)(nil),                    // 13: brz.Id
-	(*Users)(nil),                 // 14: brz.Users
+	(*AuthRequest)(nil),               // 0: brz.AuthRequest
+	(*UpdateAboutRequest)(nil),        // 1: brz.UpdateAboutRequest
+	(*UpdateEmailRequest)(nil),        // 2: brz.UpdateEmailRequest
+	(*UpdateDiscoverableRequest)(nil), // 3: brz.UpdateDiscoverableRequest
+	(*UpdatePhotoRequest)(nil),        // 4: brz.UpdatePhotoRequest
+	(*ChangePasswordRequest)(nil),     // 5: brz.ChangePasswordRequest
+	(*CreateWorkspaceRequest)(nil),    // 6: brz.CreateWorkspaceRequest
+	(*WorkspaceMemberRequest)(nil),    // 7: brz.WorkspaceMemberRequest
+	(*Session)(nil),                   // 8: brz.Session
+	(*Sessions)(nil),                  // 9: brz.Sessions
+	(*ListSessionsRequest)(nil),       // 10: brz.ListSessionsRequest
+	(*UserSessionId)(nil),             // 11: brz.UserSessionId
+	(*JWK)(nil),                       // 12: brz.JWK
+	(*JWKS)(nil),                      // 13: brz.JWKS
+	(*AuthResponse)(nil),              // 14: brz.AuthResponse
+	(*SecondFactorRequest)(nil),       // 15: brz.SecondFactorRequest
+	(*TotpSetup)(nil),                 // 16: brz.TotpSetup
+	(*TotpCodeRequest)(nil),           // 17: brz.TotpCodeRequest
+	(*RecoveryCodes)(nil),             // 18: brz.RecoveryCodes
+	(*ResetPasswordRequest)(nil),      // 19: brz.ResetPasswordRequest
+	(*Pat)(nil),                       // 20: brz.Pat
+	(*Pats)(nil),                      // 21: brz.Pats
+	(*CreatePatRequest)(nil),          // 22: brz.CreatePatRequest
+	(*PatCreated)(nil),                // 23: brz.PatCreated
+	(*PatId)(nil),                     // 24: brz.PatId
+	(*PatOwner)(nil),                  // 25: brz.PatOwner
+	(*OidcStartResponse)(nil),         // 26: brz.OidcStartResponse
+	(*OidcCallbackRequest)(nil),       // 27: brz.OidcCallbackRequest
+	(*UnlockLoginRequest)(nil),        // 28: brz.UnlockLoginRequest
+	(*AdminUserRequest)(nil),          // 29: brz.AdminUserRequest
+	(*ListUsersRequest)(nil),          // 30: brz.ListUsersRequest
+	(*AdminUsers)(nil),                // 31: brz.AdminUsers
+	(*SetUserDisabledRequest)(nil),    // 32: brz.SetUserDisabledRequest
+	(*SetUserRoleRequest)(nil),        // 33: brz.SetUserRoleRequest
+	(*AuditEventsRequest)(nil),        // 34: brz.AuditEventsRequest
+	(*AuditEvent)(nil),                // 35: brz.AuditEvent
+	(*AuditEvents)(nil),               // 36: brz.AuditEvents
+	(*UpdatePreferencesRequest)(nil),  // 37: brz.UpdatePreferencesRequest
+	(*SearchUsersRequest)(nil),        // 38: brz.SearchUsersRequest
+	(*UserCard)(nil),                  // 39: brz.UserCard
+	(*UserCards)(nil),                 // 40: brz.UserCards
+	(*AccountDeletion)(nil),           // 41: brz.AccountDeletion
+	(*AccountDeletions)(nil),          // 42: brz.AccountDeletions
+	(*ClaimDeletionsRequest)(nil),     // 43: brz.ClaimDeletionsRequest
+	(*DeletionStepRequest)(nil),       // 44: brz.DeletionStepRequest
+	(*User)(nil),                      // 45: brz.User
+	(*Preferences)(nil),               // 46: brz.Preferences
+	(*Tokens)(nil),                    // 47: brz.Tokens
+	(*UserId)(nil),                    // 48: brz.UserId
+	(*emptypb.Empty)(nil),             // 49: google.protobuf.Empty
+	(*String)(nil),                    // 50: brz.String
+	(*Token)(nil),                     // 51: brz.Token
+	(*Ids)(nil),                       // 52: brz.Ids
+	(*UserWorkspaceId)(nil),           // 53: brz.UserWorkspaceId
+	(*Strings)(nil),                   // 54: brz.Strings
+	(*Id)(nil),                        // 55: brz.Id
+	(*Users)(nil),                     // 56: brz.Users
+	(*Workspaces)(nil),                // 57: brz.Workspaces
+	(*WorkspaceMembers)(nil),          // 58: brz.WorkspaceMembers
 }
 var file_auth_proto_depIdxs = []int32{
-	6,  // 0: brz.AuthResponse.metadata:type_name -> brz.User
-	0,  // 1: brz.AuthService.Auth:input_type -> brz.AuthRequest
-	0,  // 2: brz.AuthService.Reg:input_type -> brz.AuthRequest
-	7,  // 3: brz.AuthService.ValidateTokens:input_type -> brz.Tokens
-	8,  // 4: brz.AuthService.DeleteUser:input_type -> brz.UserId
-	1,  // 5: brz.AuthService.UpdateAbout:input_type -> brz.UpdateAboutRequest
-	2,  // 6: brz.AuthService.UpdateEmail:input_type -> brz.UpdateEmailRequest
-	3,  // 7: brz.AuthService.UpdatePhoto:input_type -> brz.UpdatePhotoRequest
-	4,  // 8: brz.AuthService.ChangePasswd:input_type -> brz.ChangePasswordRequest
-	6,  // 9: brz.AuthService.CreateUser:input_type -> brz.User
-	9,  // 10: brz.AuthService.GetUserDataFromToken:input_type -> brz.Token
-	9,  // 11: brz.AuthService.GetIdFromToken:input_type -> brz.Token
-	10, // 12: brz.AuthService.GetIdFromLogin:input_type -> brz.String
-	11, // 13: brz.AuthService.GetInfos:input_type -> brz.Ids
-	12, // 14: brz.AuthService.Healthz:input_type -> google.protobuf.Empty
-	5,  // 15: brz.AuthService.Auth:output_type -> brz.AuthResponse
-	7,  // 16: brz.AuthService.Reg:output_type -> brz.Tokens
-	9,  // 17: brz.AuthService.ValidateTokens:output_type -> brz.Token
-	12, // 18: brz.AuthService.DeleteUser:output_type -> google.protobuf.Empty
-	12, // 19: brz.AuthService.UpdateAbout:output_type -> google.protobuf.Empty
-	12, // 20: brz.AuthService.UpdateEmail:output_type -> google.protobuf.Empty
-	12, // 21: brz.AuthService.UpdatePhoto:output_type -> google.protobuf.Empty
-	12, // 22: brz.AuthService.ChangePasswd:output_type -> google.protobuf.Empty
-	12, // 23: brz.AuthService.CreateUser:output_type -> google.protobuf.Empty
-	6,  // 24: brz.AuthService.GetUserDataFromToken:output_type -> brz.User
-	13, // 25: brz.AuthService.GetIdFromToken:output_type -> brz.Id
-	13, // 26: brz.AuthService.GetIdFromLogin:output_type -> brz.Id
-	14, // 27: brz.AuthService.GetInfos:output_type -> brz.Users
-	12, // 28: brz.AuthService.Healthz:output_type -> google.protobuf.Empty
-	15, // [15:29] is the sub-list for method output_type
-	1,  // [1:15] is the sub-list for method input_type
-	1,  // [1:1] is the sub-list for extension type_name
-	1,  // [1:1] is the sub-list for extension extendee
-	0,  // [0:1] is the sub-list for field type_name
+	8,  // 0: brz.Sessions.items:type_name -> brz.Session
+	12, // 1: brz.JWKS.keys:type_name -> brz.JWK
+	45, // 2: brz.AuthResponse.metadata:type_name -> brz.User
+	20, // 3: brz.Pats.items:type_name -> brz.Pat
+	20, // 4: brz.PatCreated.pat:type_name -> brz.Pat
+	45, // 5: brz.AdminUsers.users:type_name -> brz.User
+	35, // 6: brz.AuditEvents.events:type_name -> brz.AuditEvent
+	46, // 7: brz.UpdatePreferencesRequest.preferences:type_name -> brz.Preferences
+	39, // 8: brz.UserCards.users:type_name -> brz.UserCard
+	41, // 9: brz.AccountDeletions.deletions:type_name -> brz.AccountDeletion
+	0,  // 10: brz.AuthService.Auth:input_type -> brz.AuthRequest
+	0,  // 11: brz.AuthService.Reg:input_type -> brz.AuthRequest
+	47, // 12: brz.AuthService.ValidateTokens:input_type -> brz.Tokens
+	47, // 13: brz.AuthService.Logout:input_type -> brz.Tokens
+	48, // 14: brz.AuthService.LogoutAll:input_type -> brz.UserId
+	10, // 15: brz.AuthService.ListSessions:input_type -> brz.ListSessionsRequest
+	11, // 16: brz.AuthService.RevokeSession:input_type -> brz.UserSessionId
+	49, // 17: brz.AuthService.GetJWKS:input_type -> google.protobuf.Empty
+	15, // 18: brz.AuthService.VerifySecondFactor:input_type -> brz.SecondFactorRequest
+	48, // 19: brz.AuthService.SetupTotp:input_type -> brz.UserId
+	17, // 20: brz.AuthService.ConfirmTotp:input_type -> brz.TotpCodeRequest
+	17, // 21: brz.AuthService.DisableTotp:input_type -> brz.TotpCodeRequest
+	48, // 22: brz.AuthService.SendVerification:input_type -> brz.UserId
+	50, // 23: brz.AuthService.VerifyEmail:input_type -> brz.String
+	50, // 24: brz.AuthService.ForgotPassword:input_type -> brz.String
+	19, // 25: brz.AuthService.ResetPassword:input_type -> brz.ResetPasswordRequest
+	49, // 26: brz.AuthService.OidcProviders:input_type -> google.protobuf.Empty
+	22, // 27: brz.AuthService.CreatePat:input_type -> brz.CreatePatRequest
+	48, // 28: brz.AuthService.ListPats:input_type -> brz.UserId
+	24, // 29: brz.AuthService.RevokePat:input_type -> brz.PatId
+	51, // 30: brz.AuthService.ValidatePat:input_type -> brz.Token
+	50, // 31: brz.AuthService.OidcStart:input_type -> brz.String
+	27, // 32: brz.AuthService.OidcCallback:input_type -> brz.OidcCallbackRequest
+	48, // 33: brz.AuthService.DeleteUser:input_type -> brz.UserId
+	48, // 34: brz.AuthService.RequestDeletion:input_type -> brz.UserId
+	48, // 35: brz.AuthService.CancelDeletion:input_type -> brz.UserId
+	48, // 36: brz.AuthService.GetDeletion:input_type -> brz.UserId
+	1,  // 37: brz.AuthService.UpdateAbout:input_type -> brz.UpdateAboutRequest
+	2,  // 38: brz.AuthService.UpdateEmail:input_type -> brz.UpdateEmailRequest
+	4,  // 39: brz.AuthService.UpdatePhoto:input_type -> brz.UpdatePhotoRequest
+	3,  // 40: brz.AuthService.UpdateDiscoverable:input_type -> brz.UpdateDiscoverableRequest
+	48, // 41: brz.AuthService.GetPreferences:input_type -> brz.UserId
+	37, // 42: brz.AuthService.UpdatePreferences:input_type -> brz.UpdatePreferencesRequest
+	5,  // 43: brz.AuthService.ChangePasswd:input_type -> brz.ChangePasswordRequest
+	45, // 44: brz.AuthService.CreateUser:input_type -> brz.User
+	51, // 45: brz.AuthService.GetUserDataFromToken:input_type -> brz.Token
+	51, // 46: brz.AuthService.GetIdFromToken:input_type -> brz.Token
+	50, // 47: brz.AuthService.GetIdFromLogin:input_type -> brz.String
+	38, // 48: brz.AuthService.SearchUsers:input_type -> brz.SearchUsersRequest
+	52, // 49: brz.AuthService.GetInfos:input_type -> brz.Ids
+	6,  // 50: brz.AuthService.CreateWorkspace:input_type -> brz.CreateWorkspaceRequest
+	53, // 51: brz.AuthService.DeleteWorkspace:input_type -> brz.UserWorkspaceId
+	48, // 52: brz.AuthService.GetWorkspacesByUser:input_type -> brz.UserId
+	53, // 53: brz.AuthService.GetWorkspaceMembers:input_type -> brz.UserWorkspaceId
+	7,  // 54: brz.AuthService.AddWorkspaceMember:input_type -> brz.WorkspaceMemberRequest
+	7,  // 55: brz.AuthService.RemoveWorkspaceMember:input_type -> brz.WorkspaceMemberRequest
+	49, // 56: brz.AuthService.Healthz:input_type -> google.protobuf.Empty
+	30, // 57: brz.AdminService.ListUsers:input_type -> brz.ListUsersRequest
+	29, // 58: brz.AdminService.GetUser:input_type -> brz.AdminUserRequest
+	32, // 59: brz.AdminService.SetUserDisabled:input_type -> brz.SetUserDisabledRequest
+	33, // 60: brz.AdminService.SetUserRole:input_type -> brz.SetUserRoleRequest
+	29, // 61: brz.AdminService.ForcePasswordReset:input_type -> brz.AdminUserRequest
+	29, // 62: brz.AdminService.Impersonate:input_type -> brz.AdminUserRequest
+	28, // 63: brz.AdminService.UnlockLogin:input_type -> brz.UnlockLoginRequest
+	34, // 64: brz.AdminService.GetAuditEvents:input_type -> brz.AuditEventsRequest
+	43, // 65: brz.AccountDeletionService.ClaimDeletions:input_type -> brz.ClaimDeletionsRequest
+	44, // 66: brz.AccountDeletionService.CompleteDeletionStep:input_type -> brz.DeletionStepRequest
+	44, // 67: brz.AccountDeletionService.FailDeletionStep:input_type -> brz.DeletionStepRequest
+	14, // 68: brz.AuthService.Auth:output_type -> brz.AuthResponse
+	47, // 69: brz.AuthService.Reg:output_type -> brz.Tokens
+	47, // 70: brz.AuthService.ValidateTokens:output_type -> brz.Tokens
+	49, // 71: brz.AuthService.Logout:output_type -> google.protobuf.Empty
+	49, // 72: brz.AuthService.LogoutAll:output_type -> google.protobuf.Empty
+	9,  // 73: brz.AuthService.ListSessions:output_type -> brz.Sessions
+	49, // 74: brz.AuthService.RevokeSession:output_type -> google.protobuf.Empty
+	13, // 75: brz.AuthService.GetJWKS:output_type -> brz.JWKS
+	14, // 76: brz.AuthService.VerifySecondFactor:output_type -> brz.AuthResponse
+	16, // 77: brz.AuthService.SetupTotp:output_type -> brz.TotpSetup
+	18, // 78: brz.AuthService.ConfirmTotp:output_type -> brz.RecoveryCodes
+	49, // 79: brz.AuthService.DisableTotp:output_type -> google.protobuf.Empty
+	49, // 80: brz.AuthService.SendVerification:output_type -> google.protobuf.Empty
+	49, // 81: brz.AuthService.VerifyEmail:output_type -> google.protobuf.Empty
+	49, // 82: brz.AuthService.ForgotPassword:output_type -> google.protobuf.Empty
+	49, // 83: brz.AuthService.ResetPassword:output_type -> google.protobuf.Empty
+	54, // 84: brz.AuthService.OidcProviders:output_type -> brz.Strings
+	23, // 85: brz.AuthService.CreatePat:output_type -> brz.PatCreated
+	21, // 86: brz.AuthService.ListPats:output_type -> brz.Pats
+	49, // 87: brz.AuthService.RevokePat:output_type -> google.protobuf.Empty
+	25, // 88: brz.AuthService.ValidatePat:output_type -> brz.PatOwner
+	26, // 89: brz.AuthService.OidcStart:output_type -> brz.OidcStartResponse
+	14, // 90: brz.AuthService.OidcCallback:output_type -> brz.AuthResponse
+	49, // 91: brz.AuthService.DeleteUser:output_type -> google.protobuf.Empty
+	41, // 92: brz.AuthService.RequestDeletion:output_type -> brz.AccountDeletion
+	49, // 93: brz.AuthService.CancelDeletion:output_type -> google.protobuf.Empty
+	41, // 94: brz.AuthService.GetDeletion:output_type -> brz.AccountDeletion
+	49, // 95: brz.AuthService.UpdateAbout:output_type -> google.protobuf.Empty
+	49, // 96: brz.AuthService.UpdateEmail:output_type -> google.protobuf.Empty
+	49, // 97: brz.AuthService.UpdatePhoto:output_type -> google.protobuf.Empty
+	49, // 98: brz.AuthService.UpdateDiscoverable:output_type -> google.protobuf.Empty
+	46, // 99: brz.AuthService.GetPreferences:output_type -> brz.Preferences
+	46, // 100: brz.AuthService.UpdatePreferences:output_type -> brz.Preferences
+	49, // 101: brz.AuthService.ChangePasswd:output_type -> google.protobuf.Empty
+	49, // 102: brz.AuthService.CreateUser:output_type -> google.protobuf.Empty
+	45, // 103: brz.AuthService.GetUserDataFromToken:output_type -> brz.User
+	55, // 104: brz.AuthService.GetIdFromToken:output_type -> brz.Id
+	55, // 105: brz.AuthService.GetIdFromLogin:output_type -> brz.Id
+	40, // 106: brz.AuthService.SearchUsers:output_type -> brz.UserCards
+	56, // 107: brz.AuthService.GetInfos:output_type -> brz.Users
+	49, // 108: brz.AuthService.CreateWorkspace:output_type -> google.protobuf.Empty
+	49, // 109: brz.AuthService.DeleteWorkspace:output_type -> google.protobuf.Empty
+	57, // 110: brz.AuthService.GetWorkspacesByUser:output_type -> brz.Workspaces
+	58, // 111: brz.AuthService.GetWorkspaceMembers:output_type -> brz.WorkspaceMembers
+	49, // 112: brz.AuthService.AddWorkspaceMember:output_type -> google.protobuf.Empty
+	49, // 113: brz.AuthService.RemoveWorkspaceMember:output_type -> google.protobuf.Empty
+	49, // 114: brz.AuthService.Healthz:output_type -> google.protobuf.Empty
+	31, // 115: brz.AdminService.ListUsers:output_type -> brz.AdminUsers
+	45, // 116: brz.AdminService.GetUser:output_type -> brz.User
+	49, // 117: brz.AdminService.SetUserDisabled:output_type -> google.protobuf.Empty
+	49, // 118: brz.AdminService.SetUserRole:output_type -> google.protobuf.Empty
+	49, // 119: brz.AdminService.ForcePasswordReset:output_type -> google.protobuf.Empty
+	23, // 120: brz.AdminService.Impersonate:output_type -> brz.PatCreated
+	49, // 121: brz.AdminService.UnlockLogin:output_type -> google.protobuf.Empty
+	36, // 122: brz.AdminService.GetAuditEvents:output_type -> brz.AuditEvents
+	42, // 123: brz.AccountDeletionService.ClaimDeletions:output_type -> brz.AccountDeletions
+	41, // 124: brz.AccountDeletionService.CompleteDeletionStep:output_type -> brz.AccountDeletion
+	49, // 125: brz.AccountDeletionService.FailDeletionStep:output_type -> google.protobuf.Empty
+	68, // [68:126] is the sub-list for method output_type
+	10, // [10:68] is the sub-list for method input_type
+	10, // [10:10] is the sub-list for extension type_name
+	10, // [10:10] is the sub-list for extension extendee
+	0,  // [0:10] is the sub-list for field type_name
 }
 
 func init() { file_auth_proto_init() }
@@ -507,9 +3246,9 @@ func file_auth_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_auth_proto_rawDesc), len(file_auth_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   6,
+			NumMessages:   45,
 			NumExtensions: 0,
-			NumServices:   1,
+			NumServices:   3,
 		},
 		GoTypes:           file_auth_proto_goTypes,
 		DependencyIndexes: file_auth_proto_depIdxs,
@@ -20,20 +20,53 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	AuthService_Auth_FullMethodName                 = "/brz.AuthService/Auth"
-	AuthService_Reg_FullMethodName                  = "/brz.AuthService/Reg"
-	AuthService_ValidateTokens_FullMethodName       = "/brz.AuthService/ValidateTokens"
-	AuthService_DeleteUser_FullMethodName           = "/brz.AuthService/DeleteUser"
-	AuthService_UpdateAbout_FullMethodName          = "/brz.AuthService/UpdateAbout"
-	AuthService_UpdateEmail_FullMethodName          = "/brz.AuthService/UpdateEmail"
-	AuthService_UpdatePhoto_FullMethodName          = "/brz.AuthService/UpdatePhoto"
-	AuthService_ChangePasswd_FullMethodName         = "/brz.AuthService/ChangePasswd"
-	AuthService_CreateUser_FullMethodName           = "/brz.AuthService/CreateUser"
-	AuthService_GetUserDataFromToken_FullMethodName = "/brz.AuthService/GetUserDataFromToken"
-	AuthService_GetIdFromToken_FullMethodName       = "/brz.AuthService/GetIdFromToken"
-	AuthService_GetIdFromLogin_FullMethodName       = "/brz.AuthService/GetIdFromLogin"
-	AuthService_GetInfos_FullMethodName             = "/brz.AuthService/GetInfos"
-	AuthService_Healthz_FullMethodName              = "/brz.AuthService/Healthz"
+	AuthService_Auth_FullMethodName                  = "/brz.AuthService/Auth"
+	AuthService_Reg_FullMethodName                   = "/brz.AuthService/Reg"
+	AuthService_ValidateTokens_FullMethodName        = "/brz.AuthService/ValidateTokens"
+	AuthService_Logout_FullMethodName                = "/brz.AuthService/Logout"
+	AuthService_LogoutAll_FullMethodName             = "/brz.AuthService/LogoutAll"
+	AuthService_ListSessions_FullMethodName          = "/brz.AuthService/ListSessions"
+	AuthService_RevokeSession_FullMethodName         = "/brz.AuthService/RevokeSession"
+	AuthService_GetJWKS_FullMethodName               = "/brz.AuthService/GetJWKS"
+	AuthService_VerifySecondFactor_FullMethodName    = "/brz.AuthService/VerifySecondFactor"
+	AuthService_SetupTotp_FullMethodName             = "/brz.AuthService/SetupTotp"
+	AuthService_ConfirmTotp_FullMethodName           = "/brz.AuthService/ConfirmTotp"
+	AuthService_DisableTotp_FullMethodName           = "/brz.AuthService/DisableTotp"
+	AuthService_SendVerification_FullMethodName      = "/brz.AuthService/SendVerification"
+	AuthService_VerifyEmail_FullMethodName           = "/brz.AuthService/VerifyEmail"
+	AuthService_ForgotPassword_FullMethodName        = "/brz.AuthService/ForgotPassword"
+	AuthService_ResetPassword_FullMethodName         = "/brz.AuthService/ResetPassword"
+	AuthService_OidcProviders_FullMethodName         = "/brz.AuthService/OidcProviders"
+	AuthService_CreatePat_FullMethodName             = "/brz.AuthService/CreatePat"
+	AuthService_ListPats_FullMethodName              = "/brz.AuthService/ListPats"
+	AuthService_RevokePat_FullMethodName             = "/brz.AuthService/RevokePat"
+	AuthService_ValidatePat_FullMethodName           = "/brz.AuthService/ValidatePat"
+	AuthService_OidcStart_FullMethodName             = "/brz.AuthService/OidcStart"
+	AuthService_OidcCallback_FullMethodName          = "/brz.AuthService/OidcCallback"
+	AuthService_DeleteUser_FullMethodName            = "/brz.AuthService/DeleteUser"
+	AuthService_RequestDeletion_FullMethodName       = "/brz.AuthService/RequestDeletion"
+	AuthService_CancelDeletion_FullMethodName        = "/brz.AuthService/CancelDeletion"
+	AuthService_GetDeletion_FullMethodName           = "/brz.AuthService/GetDeletion"
+	AuthService_UpdateAbout_FullMethodName           = "/brz.AuthService/UpdateAbout"
+	AuthService_UpdateEmail_FullMethodName           = "/brz.AuthService/UpdateEmail"
+	AuthService_UpdatePhoto_FullMethodName           = "/brz.AuthService/UpdatePhoto"
+	AuthService_UpdateDiscoverable_FullMethodName    = "/brz.AuthService/UpdateDiscoverable"
+	AuthService_GetPreferences_FullMethodName        = "/brz.AuthService/GetPreferences"
+	AuthService_UpdatePreferences_FullMethodName     = "/brz.AuthService/UpdatePreferences"
+	AuthService_ChangePasswd_FullMethodName          = "/brz.AuthService/ChangePasswd"
+	AuthService_CreateUser_FullMethodName            = "/brz.AuthService/CreateUser"
+	AuthService_GetUserDataFromToken_FullMethodName  = "/brz.AuthService/GetUserDataFromToken"
+	AuthService_GetIdFromToken_FullMethodName        = "/brz.AuthService/GetIdFromToken"
+	AuthService_GetIdFromLogin_FullMethodName        = "/brz.AuthService/GetIdFromLogin"
+	AuthService_SearchUsers_FullMethodName           = "/brz.AuthService/SearchUsers"
+	AuthService_GetInfos_FullMethodName              = "/brz.AuthService/GetInfos"
+	AuthService_CreateWorkspace_FullMethodName       = "/brz.AuthService/CreateWorkspace"
+	AuthService_DeleteWorkspace_FullMethodName       = "/brz.AuthService/DeleteWorkspace"
+	AuthService_GetWorkspacesByUser_FullMethodName   = "/brz.AuthService/GetWorkspacesByUser"
+	AuthService_GetWorkspaceMembers_FullMethodName   = "/brz.AuthService/GetWorkspaceMembers"
+	AuthService_AddWorkspaceMember_FullMethodName    = "/brz.AuthService/AddWorkspaceMember"
+	AuthService_RemoveWorkspaceMember_FullMethodName = "/brz.AuthService/RemoveWorkspaceMember"
+	AuthService_Healthz_FullMethodName               = "/brz.AuthService/Healthz"
 )
 
 // AuthServiceClient is the client API for AuthService service.
@@ -44,17 +77,50 @@ const (
 type AuthServiceClient interface {
 	Auth(ctx context.Context, in *AuthRequest, opts ...grpc.CallOption) (*AuthResponse, error)
 	Reg(ctx context.Context, in *AuthRequest, opts ...grpc.CallOption) (*Tokens, error)
-	ValidateTokens(ctx context.Context, in *Tokens, opts ...grpc.CallOption) (*Token, error)
+	ValidateTokens(ctx context.Context, in *Tokens, opts ...grpc.CallOption) (*Tokens, error)
+	Logout(ctx context.Context, in *Tokens, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	LogoutAll(ctx context.Context, in *UserId, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	ListSessions(ctx context.Context, in *ListSessionsRequest, opts ...grpc.CallOption) (*Sessions, error)
+	RevokeSession(ctx context.Context, in *UserSessionId, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	GetJWKS(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*JWKS, error)
+	VerifySecondFactor(ctx context.Context, in *SecondFactorRequest, opts ...grpc.CallOption) (*AuthResponse, error)
+	SetupTotp(ctx context.Context, in *UserId, opts ...grpc.CallOption) (*TotpSetup, error)
+	ConfirmTotp(ctx context.Context, in *TotpCodeRequest, opts ...grpc.CallOption) (*RecoveryCodes, error)
+	DisableTotp(ctx context.Context, in *TotpCodeRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	SendVerification(ctx context.Context, in *UserId, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	VerifyEmail(ctx context.Context, in *String, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	ForgotPassword(ctx context.Context, in *String, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	ResetPassword(ctx context.Context, in *ResetPasswordRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	OidcProviders(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*Strings, error)
+	CreatePat(ctx context.Context, in *CreatePatRequest, opts ...grpc.CallOption) (*PatCreated, error)
+	ListPats(ctx context.Context, in *UserId, opts ...grpc.CallOption) (*Pats, error)
+	RevokePat(ctx context.Context, in *PatId, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	ValidatePat(ctx context.Context, in *Token, opts ...grpc.CallOption) (*PatOwner, error)
+	OidcStart(ctx context.Context, in *String, opts ...grpc.CallOption) (*OidcStartResponse, error)
+	OidcCallback(ctx context.Context, in *OidcCallbackRequest, opts ...grpc.CallOption) (*AuthResponse, error)
 	DeleteUser(ctx context.Context, in *UserId, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	RequestDeletion(ctx context.Context, in *UserId, opts ...grpc.CallOption) (*AccountDeletion, error)
+	CancelDeletion(ctx context.Context, in *UserId, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	GetDeletion(ctx context.Context, in *UserId, opts ...grpc.CallOption) (*AccountDeletion, error)
 	UpdateAbout(ctx context.Context, in *UpdateAboutRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	UpdateEmail(ctx context.Context, in *UpdateEmailRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	UpdatePhoto(ctx context.Context, in *UpdatePhotoRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	UpdateDiscoverable(ctx context.Context, in *UpdateDiscoverableRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	GetPreferences(ctx context.Context, in *UserId, opts ...grpc.CallOption) (*Preferences, error)
+	UpdatePreferences(ctx context.Context, in *UpdatePreferencesRequest, opts ...grpc.CallOption) (*Preferences, error)
 	ChangePasswd(ctx context.Context, in *ChangePasswordRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	CreateUser(ctx context.Context, in *User, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	GetUserDataFromToken(ctx context.Context, in *Token, opts ...grpc.CallOption) (*User, error)
 	GetIdFromToken(ctx context.Context, in *Token, opts ...grpc.CallOption) (*Id, error)
 	GetIdFromLogin(ctx context.Context, in *String, opts ...grpc.CallOption) (*Id, error)
+	SearchUsers(ctx context.Context, in *SearchUsersRequest, opts ...grpc.CallOption) (*UserCards, error)
 	GetInfos(ctx context.Context, in *Ids, opts ...grpc.CallOption) (*Users, error)
+	CreateWorkspace(ctx context.Context, in *CreateWorkspaceRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	DeleteWorkspace(ctx context.Context, in *UserWorkspaceId, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	GetWorkspacesByUser(ctx context.Context, in *UserId, opts ...grpc.CallOption) (*Workspaces, error)
+	GetWorkspaceMembers(ctx context.Context, in *UserWorkspaceId, opts ...grpc.CallOption) (*WorkspaceMembers, error)
+	AddWorkspaceMember(ctx context.Context, in *WorkspaceMemberRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	RemoveWorkspaceMember(ctx context.Context, in *WorkspaceMemberRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	Healthz(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*emptypb.Empty, error)
 }
 
@@ -86,9 +152,9 @@ func (c *authServiceClient) Reg(ctx context.Context, in *AuthRequest, opts ...gr
 	return out, nil
 }
 
-func (c *authServiceClient) ValidateTokens(ctx context.Context, in *Tokens, opts ...grpc.CallOption) (*Token, error) {
+func (c *authServiceClient) ValidateTokens(ctx context.Context, in *Tokens, opts ...grpc.CallOption) (*Tokens, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(Token)
+	out := new(Tokens)
 	err := c.cc.Invoke(ctx, AuthService_ValidateTokens_FullMethodName, in, out, cOpts...)
 	if err != nil {
 		return nil, err
@@ -96,6 +162,206 @@ func (c *authServiceClient) ValidateTokens(ctx context.Context, in *Tokens, opts
 	return out, nil
 }
 
+func (c *authServiceClient) Logout(ctx context.Context, in *Tokens, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, AuthService_Logout_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) LogoutAll(ctx context.Context, in *UserId, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, AuthService_LogoutAll_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) ListSessions(ctx context.Context, in *ListSessionsRequest, opts ...grpc.CallOption) (*Sessions, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Sessions)
+	err := c.cc.Invoke(ctx, AuthService_ListSessions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) RevokeSession(ctx context.Context, in *UserSessionId, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, AuthService_RevokeSession_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) GetJWKS(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*JWKS, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(JWKS)
+	err := c.cc.Invoke(ctx, AuthService_GetJWKS_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) VerifySecondFactor(ctx context.Context, in *SecondFactorRequest, opts ...grpc.CallOption) (*AuthResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AuthResponse)
+	err := c.cc.Invoke(ctx, AuthService_VerifySecondFactor_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) SetupTotp(ctx context.Context, in *UserId, opts ...grpc.CallOption) (*TotpSetup, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TotpSetup)
+	err := c.cc.Invoke(ctx, AuthService_SetupTotp_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) ConfirmTotp(ctx context.Context, in *TotpCodeRequest, opts ...grpc.CallOption) (*RecoveryCodes, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RecoveryCodes)
+	err := c.cc.Invoke(ctx, AuthService_ConfirmTotp_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) DisableTotp(ctx context.Context, in *TotpCodeRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, AuthService_DisableTotp_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) SendVerification(ctx context.Context, in *UserId, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, AuthService_SendVerification_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) VerifyEmail(ctx context.Context, in *String, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, AuthService_VerifyEmail_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) ForgotPassword(ctx context.Context, in *String, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, AuthService_ForgotPassword_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) ResetPassword(ctx context.Context, in *ResetPasswordRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, AuthService_ResetPassword_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) OidcProviders(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*Strings, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Strings)
+	err := c.cc.Invoke(ctx, AuthService_OidcProviders_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) CreatePat(ctx context.Context, in *CreatePatRequest, opts ...grpc.CallOption) (*PatCreated, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PatCreated)
+	err := c.cc.Invoke(ctx, AuthService_CreatePat_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) ListPats(ctx context.Context, in *UserId, opts ...grpc.CallOption) (*Pats, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Pats)
+	err := c.cc.Invoke(ctx, AuthService_ListPats_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) RevokePat(ctx context.Context, in *PatId, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, AuthService_RevokePat_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) ValidatePat(ctx context.Context, in *Token, opts ...grpc.CallOption) (*PatOwner, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PatOwner)
+	err := c.cc.Invoke(ctx, AuthService_ValidatePat_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) OidcStart(ctx context.Context, in *String, opts ...grpc.CallOption) (*OidcStartResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(OidcStartResponse)
+	err := c.cc.Invoke(ctx, AuthService_OidcStart_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) OidcCallback(ctx context.Context, in *OidcCallbackRequest, opts ...grpc.CallOption) (*AuthResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AuthResponse)
+	err := c.cc.Invoke(ctx, AuthService_OidcCallback_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *authServiceClient) DeleteUser(ctx context.Context, in *UserId, opts ...grpc.CallOption) (*emptypb.Empty, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(emptypb.Empty)
@@ -106,6 +372,36 @@ func (c *authServiceClient) DeleteUser(ctx context.Context, in *UserId, opts ...
 	return out, nil
 }
 
+func (c *authServiceClient) RequestDeletion(ctx context.Context, in *UserId, opts ...grpc.CallOption) (*AccountDeletion, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AccountDeletion)
+	err := c.cc.Invoke(ctx, AuthService_RequestDeletion_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) CancelDeletion(ctx context.Context, in *UserId, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, AuthService_CancelDeletion_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) GetDeletion(ctx context.Context, in *UserId, opts ...grpc.CallOption) (*AccountDeletion, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AccountDeletion)
+	err := c.cc.Invoke(ctx, AuthService_GetDeletion_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *authServiceClient) UpdateAbout(ctx context.Context, in *UpdateAboutRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(emptypb.Empty)
@@ -136,6 +432,36 @@ func (c *authServiceClient) UpdatePhoto(ctx context.Context, in *UpdatePhotoRequ
 	return out, nil
 }
 
+func (c *authServiceClient) UpdateDiscoverable(ctx context.Context, in *UpdateDiscoverableRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, AuthService_UpdateDiscoverable_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) GetPreferences(ctx context.Context, in *UserId, opts ...grpc.CallOption) (*Preferences, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Preferences)
+	err := c.cc.Invoke(ctx, AuthService_GetPreferences_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) UpdatePreferences(ctx context.Context, in *UpdatePreferencesRequest, opts ...grpc.CallOption) (*Preferences, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Preferences)
+	err := c.cc.Invoke(ctx, AuthService_UpdatePreferences_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *authServiceClient) ChangePasswd(ctx context.Context, in *ChangePasswordRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(emptypb.Empty)
@@ -186,6 +512,16 @@ func (c *authServiceClient) GetIdFromLogin(ctx context.Context, in *String, opts
 	return out, nil
 }
 
+func (c *authServiceClient) SearchUsers(ctx context.Context, in *SearchUsersRequest, opts ...grpc.CallOption) (*UserCards, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UserCards)
+	err := c.cc.Invoke(ctx, AuthService_SearchUsers_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *authServiceClient) GetInfos(ctx context.Context, in *Ids, opts ...grpc.CallOption) (*Users, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(Users)
@@ -196,6 +532,66 @@ func (c *authServiceClient) GetInfos(ctx context.Context, in *Ids, opts ...grpc.
 	return out, nil
 }
 
+func (c *authServiceClient) CreateWorkspace(ctx context.Context, in *CreateWorkspaceRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, AuthService_CreateWorkspace_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) DeleteWorkspace(ctx context.Context, in *UserWorkspaceId, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, AuthService_DeleteWorkspace_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) GetWorkspacesByUser(ctx context.Context, in *UserId, opts ...grpc.CallOption) (*Workspaces, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Workspaces)
+	err := c.cc.Invoke(ctx, AuthService_GetWorkspacesByUser_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) GetWorkspaceMembers(ctx context.Context, in *UserWorkspaceId, opts ...grpc.CallOption) (*WorkspaceMembers, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(WorkspaceMembers)
+	err := c.cc.Invoke(ctx, AuthService_GetWorkspaceMembers_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) AddWorkspaceMember(ctx context.Context, in *WorkspaceMemberRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, AuthService_AddWorkspaceMember_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) RemoveWorkspaceMember(ctx context.Context, in *WorkspaceMemberRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, AuthService_RemoveWorkspaceMember_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *authServiceClient) Healthz(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*emptypb.Empty, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(emptypb.Empty)
@@ -214,17 +610,50 @@ func (c *authServiceClient) Healthz(ctx context.Context, in *emptypb.Empty, opts
 type AuthServiceServer interface {
 	Auth(context.Context, *AuthRequest) (*AuthResponse, error)
 	Reg(context.Context, *AuthRequest) (*Tokens, error)
-	ValidateTokens(context.Context, *Tokens) (*Token, error)
+	ValidateTokens(context.Context, *Tokens) (*Tokens, error)
+	Logout(context.Context, *Tokens) (*emptypb.Empty, error)
+	LogoutAll(context.Context, *UserId) (*emptypb.Empty, error)
+	ListSessions(context.Context, *ListSessionsRequest) (*Sessions, error)
+	RevokeSession(context.Context, *UserSessionId) (*emptypb.Empty, error)
+	GetJWKS(context.Context, *emptypb.Empty) (*JWKS, error)
+	VerifySecondFactor(context.Context, *SecondFactorRequest) (*AuthResponse, error)
+	SetupTotp(context.Context, *UserId) (*TotpSetup, error)
+	ConfirmTotp(context.Context, *TotpCodeRequest) (*RecoveryCodes, error)
+	DisableTotp(context.Context, *TotpCodeRequest) (*emptypb.Empty, error)
+	SendVerification(context.Context, *UserId) (*emptypb.Empty, error)
+	VerifyEmail(context.Context, *String) (*emptypb.Empty, error)
+	ForgotPassword(context.Context, *String) (*emptypb.Empty, error)
+	ResetPassword(context.Context, *ResetPasswordRequest) (*emptypb.Empty, error)
+	OidcProviders(context.Context, *emptypb.Empty) (*Strings, error)
+	CreatePat(context.Context, *CreatePatRequest) (*PatCreated, error)
+	ListPats(context.Context, *UserId) (*Pats, error)
+	RevokePat(context.Context, *PatId) (*emptypb.Empty, error)
+	ValidatePat(context.Context, *Token) (*PatOwner, error)
+	OidcStart(context.Context, *String) (*OidcStartResponse, error)
+	OidcCallback(context.Context, *OidcCallbackRequest) (*AuthResponse, error)
 	DeleteUser(context.Context, *UserId) (*emptypb.Empty, error)
+	RequestDeletion(context.Context, *UserId) (*AccountDeletion, error)
+	CancelDeletion(context.Context, *UserId) (*emptypb.Empty, error)
+	GetDeletion(context.Context, *UserId) (*AccountDeletion, error)
 	UpdateAbout(context.Context, *UpdateAboutRequest) (*emptypb.Empty, error)
 	UpdateEmail(context.Context, *UpdateEmailRequest) (*emptypb.Empty, error)
 	UpdatePhoto(context.Context, *UpdatePhotoRequest) (*emptypb.Empty, error)
+	UpdateDiscoverable(context.Context, *UpdateDiscoverableRequest) (*emptypb.Empty, error)
+	GetPreferences(context.Context, *UserId) (*Preferences, error)
+	UpdatePreferences(context.Context, *UpdatePreferencesRequest) (*Preferences, error)
 	ChangePasswd(context.Context, *ChangePasswordRequest) (*emptypb.Empty, error)
 	CreateUser(context.Context, *User) (*emptypb.Empty, error)
 	GetUserDataFromToken(context.Context, *Token) (*User, error)
 	GetIdFromToken(context.Context, *Token) (*Id, error)
 	GetIdFromLogin(context.Context, *String) (*Id, error)
+	SearchUsers(context.Context, *SearchUsersRequest) (*UserCards, error)
 	GetInfos(context.Context, *Ids) (*Users, error)
+	CreateWorkspace(context.Context, *CreateWorkspaceRequest) (*emptypb.Empty, error)
+	DeleteWorkspace(context.Context, *UserWorkspaceId) (*emptypb.Empty, error)
+	GetWorkspacesByUser(context.Context, *UserId) (*Workspaces, error)
+	GetWorkspaceMembers(context.Context, *UserWorkspaceId) (*WorkspaceMembers, error)
+	AddWorkspaceMember(context.Context, *WorkspaceMemberRequest) (*emptypb.Empty, error)
+	RemoveWorkspaceMember(context.Context, *WorkspaceMemberRequest) (*emptypb.Empty, error)
 	Healthz(context.Context, *emptypb.Empty) (*emptypb.Empty, error)
 	mustEmbedUnimplementedAuthServiceServer()
 }
@@ -242,377 +671,1765 @@ func (UnimplementedAuthServiceServer) Auth(context.Context, *AuthRequest) (*Auth
 func (UnimplementedAuthServiceServer) Reg(context.Context, *AuthRequest) (*Tokens, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Reg not implemented")
 }
-func (UnimplementedAuthServiceServer) ValidateTokens(context.Context, *Tokens) (*Token, error) {
+func (UnimplementedAuthServiceServer) ValidateTokens(context.Context, *Tokens) (*Tokens, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ValidateTokens not implemented")
 }
-func (UnimplementedAuthServiceServer) DeleteUser(context.Context, *UserId) (*emptypb.Empty, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method DeleteUser not implemented")
+func (UnimplementedAuthServiceServer) Logout(context.Context, *Tokens) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Logout not implemented")
 }
-func (UnimplementedAuthServiceServer) UpdateAbout(context.Context, *UpdateAboutRequest) (*emptypb.Empty, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method UpdateAbout not implemented")
+func (UnimplementedAuthServiceServer) LogoutAll(context.Context, *UserId) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method LogoutAll not implemented")
 }
-func (UnimplementedAuthServiceServer) UpdateEmail(context.Context, *UpdateEmailRequest) (*emptypb.Empty, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method UpdateEmail not implemented")
+func (UnimplementedAuthServiceServer) ListSessions(context.Context, *ListSessionsRequest) (*Sessions, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListSessions not implemented")
+}
+func (UnimplementedAuthServiceServer) RevokeSession(context.Context, *UserSessionId) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RevokeSession not implemented")
+}
+func (UnimplementedAuthServiceServer) GetJWKS(context.Context, *emptypb.Empty) (*JWKS, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetJWKS not implemented")
+}
+func (UnimplementedAuthServiceServer) VerifySecondFactor(context.Context, *SecondFactorRequest) (*AuthResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method VerifySecondFactor not implemented")
+}
+func (UnimplementedAuthServiceServer) SetupTotp(context.Context, *UserId) (*TotpSetup, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetupTotp not implemented")
+}
+func (UnimplementedAuthServiceServer) ConfirmTotp(context.Context, *TotpCodeRequest) (*RecoveryCodes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ConfirmTotp not implemented")
+}
+func (UnimplementedAuthServiceServer) DisableTotp(context.Context, *TotpCodeRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DisableTotp not implemented")
+}
+func (UnimplementedAuthServiceServer) SendVerification(context.Context, *UserId) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SendVerification not implemented")
+}
+func (UnimplementedAuthServiceServer) VerifyEmail(context.Context, *String) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method VerifyEmail not implemented")
+}
+func (UnimplementedAuthServiceServer) ForgotPassword(context.Context, *String) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ForgotPassword not implemented")
+}
+func (UnimplementedAuthServiceServer) ResetPassword(context.Context, *ResetPasswordRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ResetPassword not implemented")
+}
+func (UnimplementedAuthServiceServer) OidcProviders(context.Context, *emptypb.Empty) (*Strings, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method OidcProviders not implemented")
+}
+func (UnimplementedAuthServiceServer) CreatePat(context.Context, *CreatePatRequest) (*PatCreated, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreatePat not implemented")
+}
+func (UnimplementedAuthServiceServer) ListPats(context.Context, *UserId) (*Pats, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListPats not implemented")
+}
+func (UnimplementedAuthServiceServer) RevokePat(context.Context, *PatId) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RevokePat not implemented")
+}
+func (UnimplementedAuthServiceServer) ValidatePat(context.Context, *Token) (*PatOwner, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ValidatePat not implemented")
+}
+func (UnimplementedAuthServiceServer) OidcStart(context.Context, *String) (*OidcStartResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method OidcStart not implemented")
+}
+func (UnimplementedAuthServiceServer) OidcCallback(context.Context, *OidcCallbackRequest) (*AuthResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method OidcCallback not implemented")
+}
+func (UnimplementedAuthServiceServer) DeleteUser(context.Context, *UserId) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteUser not implemented")
+}
+func (UnimplementedAuthServiceServer) RequestDeletion(context.Context, *UserId) (*AccountDeletion, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RequestDeletion not implemented")
+}
+func (UnimplementedAuthServiceServer) CancelDeletion(context.Context, *UserId) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelDeletion not implemented")
+}
+func (UnimplementedAuthServiceServer) GetDeletion(context.Context, *UserId) (*AccountDeletion, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDeletion not implemented")
+}
+func (UnimplementedAuthServiceServer) UpdateAbout(context.Context, *UpdateAboutRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateAbout not implemented")
+}
+func (UnimplementedAuthServiceServer) UpdateEmail(context.Context, *UpdateEmailRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateEmail not implemented")
+}
+func (UnimplementedAuthServiceServer) UpdatePhoto(context.Context, *UpdatePhotoRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdatePhoto not implemented")
+}
+func (UnimplementedAuthServiceServer) UpdateDiscoverable(context.Context, *UpdateDiscoverableRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateDiscoverable not implemented")
+}
+func (UnimplementedAuthServiceServer) GetPreferences(context.Context, *UserId) (*Preferences, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPreferences not implemented")
+}
+func (UnimplementedAuthServiceServer) UpdatePreferences(context.Context, *UpdatePreferencesRequest) (*Preferences, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdatePreferences not implemented")
+}
+func (UnimplementedAuthServiceServer) ChangePasswd(context.Context, *ChangePasswordRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ChangePasswd not implemented")
+}
+func (UnimplementedAuthServiceServer) CreateUser(context.Context, *User) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateUser not implemented")
+}
+func (UnimplementedAuthServiceServer) GetUserDataFromToken(context.Context, *Token) (*User, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetUserDataFromToken not implemented")
+}
+func (UnimplementedAuthServiceServer) GetIdFromToken(context.Context, *Token) (*Id, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetIdFromToken not implemented")
+}
+func (UnimplementedAuthServiceServer) GetIdFromLogin(context.Context, *String) (*Id, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetIdFromLogin not implemented")
+}
+func (UnimplementedAuthServiceServer) SearchUsers(context.Context, *SearchUsersRequest) (*UserCards, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SearchUsers not implemented")
+}
+func (UnimplementedAuthServiceServer) GetInfos(context.Context, *Ids) (*Users, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetInfos not implemented")
+}
+func (UnimplementedAuthServiceServer) CreateWorkspace(context.Context, *CreateWorkspaceRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateWorkspace not implemented")
+}
+func (UnimplementedAuthServiceServer) DeleteWorkspace(context.Context, *UserWorkspaceId) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteWorkspace not implemented")
+}
+func (UnimplementedAuthServiceServer) GetWorkspacesByUser(context.Context, *UserId) (*Workspaces, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetWorkspacesByUser not implemented")
+}
+func (UnimplementedAuthServiceServer) GetWorkspaceMembers(context.Context, *UserWorkspaceId) (*WorkspaceMembers, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetWorkspaceMembers not implemented")
+}
+func (UnimplementedAuthServiceServer) AddWorkspaceMember(context.Context, *WorkspaceMemberRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddWorkspaceMember not implemented")
+}
+func (UnimplementedAuthServiceServer) RemoveWorkspaceMember(context.Context, *WorkspaceMemberRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveWorkspaceMember not implemented")
+}
+func (UnimplementedAuthServiceServer) Healthz(context.Context, *emptypb.Empty) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Healthz not implemented")
+}
+func (UnimplementedAuthServiceServer) mustEmbedUnimplementedAuthServiceServer() {}
+func (UnimplementedAuthServiceServer) testEmbeddedByValue()                     {}
+
+// UnsafeAuthServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to AuthServiceServer will
+// result in compilation errors.
+type UnsafeAuthServiceServer interface {
+	mustEmbedUnimplementedAuthServiceServer()
+}
+
+func RegisterAuthServiceServer(s grpc.ServiceRegistrar, srv AuthServiceServer) {
+	// If the following call pancis, it indicates UnimplementedAuthServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&AuthService_ServiceDesc, srv)
+}
+
+func _AuthService_Auth_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AuthRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).Auth(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_Auth_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).Auth(ctx, req.(*AuthRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_Reg_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AuthRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).Reg(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_Reg_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).Reg(ctx, req.(*AuthRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_ValidateTokens_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Tokens)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).ValidateTokens(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_ValidateTokens_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).ValidateTokens(ctx, req.(*Tokens))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_Logout_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Tokens)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).Logout(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_Logout_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).Logout(ctx, req.(*Tokens))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_LogoutAll_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserId)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).LogoutAll(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_LogoutAll_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).LogoutAll(ctx, req.(*UserId))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_ListSessions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListSessionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).ListSessions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_ListSessions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).ListSessions(ctx, req.(*ListSessionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_RevokeSession_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserSessionId)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).RevokeSession(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_RevokeSession_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).RevokeSession(ctx, req.(*UserSessionId))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_GetJWKS_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).GetJWKS(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_GetJWKS_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).GetJWKS(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_VerifySecondFactor_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SecondFactorRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).VerifySecondFactor(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_VerifySecondFactor_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).VerifySecondFactor(ctx, req.(*SecondFactorRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_SetupTotp_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserId)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).SetupTotp(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_SetupTotp_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).SetupTotp(ctx, req.(*UserId))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_ConfirmTotp_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TotpCodeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).ConfirmTotp(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_ConfirmTotp_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).ConfirmTotp(ctx, req.(*TotpCodeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_DisableTotp_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TotpCodeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).DisableTotp(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_DisableTotp_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).DisableTotp(ctx, req.(*TotpCodeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_SendVerification_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserId)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).SendVerification(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_SendVerification_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).SendVerification(ctx, req.(*UserId))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_VerifyEmail_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(String)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).VerifyEmail(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_VerifyEmail_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).VerifyEmail(ctx, req.(*String))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_ForgotPassword_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(String)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).ForgotPassword(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_ForgotPassword_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).ForgotPassword(ctx, req.(*String))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_ResetPassword_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResetPasswordRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).ResetPassword(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_ResetPassword_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).ResetPassword(ctx, req.(*ResetPasswordRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_OidcProviders_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).OidcProviders(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_OidcProviders_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).OidcProviders(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_CreatePat_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreatePatRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).CreatePat(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_CreatePat_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).CreatePat(ctx, req.(*CreatePatRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_ListPats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserId)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).ListPats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_ListPats_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).ListPats(ctx, req.(*UserId))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_RevokePat_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PatId)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).RevokePat(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_RevokePat_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).RevokePat(ctx, req.(*PatId))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_ValidatePat_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Token)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).ValidatePat(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_ValidatePat_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).ValidatePat(ctx, req.(*Token))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_OidcStart_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(String)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).OidcStart(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_OidcStart_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).OidcStart(ctx, req.(*String))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_OidcCallback_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(OidcCallbackRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).OidcCallback(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_OidcCallback_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).OidcCallback(ctx, req.(*OidcCallbackRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_DeleteUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserId)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).DeleteUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_DeleteUser_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).DeleteUser(ctx, req.(*UserId))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_RequestDeletion_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserId)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).RequestDeletion(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_RequestDeletion_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).RequestDeletion(ctx, req.(*UserId))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_CancelDeletion_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserId)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).CancelDeletion(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_CancelDeletion_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).CancelDeletion(ctx, req.(*UserId))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_GetDeletion_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserId)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).GetDeletion(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_GetDeletion_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).GetDeletion(ctx, req.(*UserId))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_UpdateAbout_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateAboutRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).UpdateAbout(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_UpdateAbout_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).UpdateAbout(ctx, req.(*UpdateAboutRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_UpdateEmail_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateEmailRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).UpdateEmail(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_UpdateEmail_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).UpdateEmail(ctx, req.(*UpdateEmailRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_UpdatePhoto_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdatePhotoRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).UpdatePhoto(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_UpdatePhoto_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).UpdatePhoto(ctx, req.(*UpdatePhotoRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_UpdateDiscoverable_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateDiscoverableRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).UpdateDiscoverable(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_UpdateDiscoverable_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).UpdateDiscoverable(ctx, req.(*UpdateDiscoverableRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_GetPreferences_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserId)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).GetPreferences(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_GetPreferences_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).GetPreferences(ctx, req.(*UserId))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_UpdatePreferences_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdatePreferencesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).UpdatePreferences(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_UpdatePreferences_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).UpdatePreferences(ctx, req.(*UpdatePreferencesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_ChangePasswd_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ChangePasswordRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).ChangePasswd(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_ChangePasswd_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).ChangePasswd(ctx, req.(*ChangePasswordRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_CreateUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(User)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).CreateUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_CreateUser_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).CreateUser(ctx, req.(*User))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_GetUserDataFromToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Token)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).GetUserDataFromToken(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_GetUserDataFromToken_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).GetUserDataFromToken(ctx, req.(*Token))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_GetIdFromToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Token)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).GetIdFromToken(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_GetIdFromToken_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).GetIdFromToken(ctx, req.(*Token))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_GetIdFromLogin_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(String)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).GetIdFromLogin(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_GetIdFromLogin_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).GetIdFromLogin(ctx, req.(*String))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_SearchUsers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchUsersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).SearchUsers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_SearchUsers_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).SearchUsers(ctx, req.(*SearchUsersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_GetInfos_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Ids)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).GetInfos(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_GetInfos_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).GetInfos(ctx, req.(*Ids))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_CreateWorkspace_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateWorkspaceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).CreateWorkspace(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_CreateWorkspace_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).CreateWorkspace(ctx, req.(*CreateWorkspaceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_DeleteWorkspace_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserWorkspaceId)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).DeleteWorkspace(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_DeleteWorkspace_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).DeleteWorkspace(ctx, req.(*UserWorkspaceId))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_GetWorkspacesByUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserId)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).GetWorkspacesByUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_GetWorkspacesByUser_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).GetWorkspacesByUser(ctx, req.(*UserId))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_GetWorkspaceMembers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserWorkspaceId)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).GetWorkspaceMembers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_GetWorkspaceMembers_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).GetWorkspaceMembers(ctx, req.(*UserWorkspaceId))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_AddWorkspaceMember_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WorkspaceMemberRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).AddWorkspaceMember(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_AddWorkspaceMember_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).AddWorkspaceMember(ctx, req.(*WorkspaceMemberRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_RemoveWorkspaceMember_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WorkspaceMemberRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).RemoveWorkspaceMember(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_RemoveWorkspaceMember_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).RemoveWorkspaceMember(ctx, req.(*WorkspaceMemberRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_Healthz_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).Healthz(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_Healthz_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).Healthz(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// AuthService_ServiceDesc is the grpc.ServiceDesc for AuthService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var AuthService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "brz.AuthService",
+	HandlerType: (*AuthServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Auth",
+			Handler:    _AuthService_Auth_Handler,
+		},
+		{
+			MethodName: "Reg",
+			Handler:    _AuthService_Reg_Handler,
+		},
+		{
+			MethodName: "ValidateTokens",
+			Handler:    _AuthService_ValidateTokens_Handler,
+		},
+		{
+			MethodName: "Logout",
+			Handler:    _AuthService_Logout_Handler,
+		},
+		{
+			MethodName: "LogoutAll",
+			Handler:    _AuthService_LogoutAll_Handler,
+		},
+		{
+			MethodName: "ListSessions",
+			Handler:    _AuthService_ListSessions_Handler,
+		},
+		{
+			MethodName: "RevokeSession",
+			Handler:    _AuthService_RevokeSession_Handler,
+		},
+		{
+			MethodName: "GetJWKS",
+			Handler:    _AuthService_GetJWKS_Handler,
+		},
+		{
+			MethodName: "VerifySecondFactor",
+			Handler:    _AuthService_VerifySecondFactor_Handler,
+		},
+		{
+			MethodName: "SetupTotp",
+			Handler:    _AuthService_SetupTotp_Handler,
+		},
+		{
+			MethodName: "ConfirmTotp",
+			Handler:    _AuthService_ConfirmTotp_Handler,
+		},
+		{
+			MethodName: "DisableTotp",
+			Handler:    _AuthService_DisableTotp_Handler,
+		},
+		{
+			MethodName: "SendVerification",
+			Handler:    _AuthService_SendVerification_Handler,
+		},
+		{
+			MethodName: "VerifyEmail",
+			Handler:    _AuthService_VerifyEmail_Handler,
+		},
+		{
+			MethodName: "ForgotPassword",
+			Handler:    _AuthService_ForgotPassword_Handler,
+		},
+		{
+			MethodName: "ResetPassword",
+			Handler:    _AuthService_ResetPassword_Handler,
+		},
+		{
+			MethodName: "OidcProviders",
+			Handler:    _AuthService_OidcProviders_Handler,
+		},
+		{
+			MethodName: "CreatePat",
+			Handler:    _AuthService_CreatePat_Handler,
+		},
+		{
+			MethodName: "ListPats",
+			Handler:    _AuthService_ListPats_Handler,
+		},
+		{
+			MethodName: "RevokePat",
+			Handler:    _AuthService_RevokePat_Handler,
+		},
+		{
+			MethodName: "ValidatePat",
+			Handler:    _AuthService_ValidatePat_Handler,
+		},
+		{
+			MethodName: "OidcStart",
+			Handler:    _AuthService_OidcStart_Handler,
+		},
+		{
+			MethodName: "OidcCallback",
+			Handler:    _AuthService_OidcCallback_Handler,
+		},
+		{
+			MethodName: "DeleteUser",
+			Handler:    _AuthService_DeleteUser_Handler,
+		},
+		{
+			MethodName: "RequestDeletion",
+			Handler:    _AuthService_RequestDeletion_Handler,
+		},
+		{
+			MethodName: "CancelDeletion",
+			Handler:    _AuthService_CancelDeletion_Handler,
+		},
+		{
+			MethodName: "GetDeletion",
+			Handler:    _AuthService_GetDeletion_Handler,
+		},
+		{
+			MethodName: "UpdateAbout",
+			Handler:    _AuthService_UpdateAbout_Handler,
+		},
+		{
+			MethodName: "UpdateEmail",
+			Handler:    _AuthService_UpdateEmail_Handler,
+		},
+		{
+			MethodName: "UpdatePhoto",
+			Handler:    _AuthService_UpdatePhoto_Handler,
+		},
+		{
+			MethodName: "UpdateDiscoverable",
+			Handler:    _AuthService_UpdateDiscoverable_Handler,
+		},
+		{
+			MethodName: "GetPreferences",
+			Handler:    _AuthService_GetPreferences_Handler,
+		},
+		{
+			MethodName: "UpdatePreferences",
+			Handler:    _AuthService_UpdatePreferences_Handler,
+		},
+		{
+			MethodName: "ChangePasswd",
+			Handler:    _AuthService_ChangePasswd_Handler,
+		},
+		{
+			MethodName: "CreateUser",
+			Handler:    _AuthService_CreateUser_Handler,
+		},
+		{
+			MethodName: "GetUserDataFromToken",
+			Handler:    _AuthService_GetUserDataFromToken_Handler,
+		},
+		{
+			MethodName: "GetIdFromToken",
+			Handler:    _AuthService_GetIdFromToken_Handler,
+		},
+		{
+			MethodName: "GetIdFromLogin",
+			Handler:    _AuthService_GetIdFromLogin_Handler,
+		},
+		{
+			MethodName: "SearchUsers",
+			Handler:    _AuthService_SearchUsers_Handler,
+		},
+		{
+			MethodName: "GetInfos",
+			Handler:    _AuthService_GetInfos_Handler,
+		},
+		{
+			MethodName: "CreateWorkspace",
+			Handler:    _AuthService_CreateWorkspace_Handler,
+		},
+		{
+			MethodName: "DeleteWorkspace",
+			Handler:    _AuthService_DeleteWorkspace_Handler,
+		},
+		{
+			MethodName: "GetWorkspacesByUser",
+			Handler:    _AuthService_GetWorkspacesByUser_Handler,
+		},
+		{
+			MethodName: "GetWorkspaceMembers",
+			Handler:    _AuthService_GetWorkspaceMembers_Handler,
+		},
+		{
+			MethodName: "AddWorkspaceMember",
+			Handler:    _AuthService_AddWorkspaceMember_Handler,
+		},
+		{
+			MethodName: "RemoveWorkspaceMember",
+			Handler:    _AuthService_RemoveWorkspaceMember_Handler,
+		},
+		{
+			MethodName: "Healthz",
+			Handler:    _AuthService_Healthz_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "auth.proto",
+}
+
+const (
+	AdminService_ListUsers_FullMethodName          = "/brz.AdminService/ListUsers"
+	AdminService_GetUser_FullMethodName            = "/brz.AdminService/GetUser"
+	AdminService_SetUserDisabled_FullMethodName    = "/brz.AdminService/SetUserDisabled"
+	AdminService_SetUserRole_FullMethodName        = "/brz.AdminService/SetUserRole"
+	AdminService_ForcePasswordReset_FullMethodName = "/brz.AdminService/ForcePasswordReset"
+	AdminService_Impersonate_FullMethodName        = "/brz.AdminService/Impersonate"
+	AdminService_UnlockLogin_FullMethodName        = "/brz.AdminService/UnlockLogin"
+	AdminService_GetAuditEvents_FullMethodName     = "/brz.AdminService/GetAuditEvents"
+)
+
+// AdminServiceClient is the client API for AdminService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// ===== Admin Service =====
+// Every call is checked: actor must be admin. Every call is written to audit log
+type AdminServiceClient interface {
+	ListUsers(ctx context.Context, in *ListUsersRequest, opts ...grpc.CallOption) (*AdminUsers, error)
+	GetUser(ctx context.Context, in *AdminUserRequest, opts ...grpc.CallOption) (*User, error)
+	SetUserDisabled(ctx context.Context, in *SetUserDisabledRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	SetUserRole(ctx context.Context, in *SetUserRoleRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	ForcePasswordReset(ctx context.Context, in *AdminUserRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	Impersonate(ctx context.Context, in *AdminUserRequest, opts ...grpc.CallOption) (*PatCreated, error)
+	UnlockLogin(ctx context.Context, in *UnlockLoginRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	GetAuditEvents(ctx context.Context, in *AuditEventsRequest, opts ...grpc.CallOption) (*AuditEvents, error)
+}
+
+type adminServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewAdminServiceClient(cc grpc.ClientConnInterface) AdminServiceClient {
+	return &adminServiceClient{cc}
+}
+
+func (c *adminServiceClient) ListUsers(ctx context.Context, in *ListUsersRequest, opts ...grpc.CallOption) (*AdminUsers, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AdminUsers)
+	err := c.cc.Invoke(ctx, AdminService_ListUsers_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) GetUser(ctx context.Context, in *AdminUserRequest, opts ...grpc.CallOption) (*User, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(User)
+	err := c.cc.Invoke(ctx, AdminService_GetUser_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) SetUserDisabled(ctx context.Context, in *SetUserDisabledRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, AdminService_SetUserDisabled_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) SetUserRole(ctx context.Context, in *SetUserRoleRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, AdminService_SetUserRole_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) ForcePasswordReset(ctx context.Context, in *AdminUserRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, AdminService_ForcePasswordReset_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) Impersonate(ctx context.Context, in *AdminUserRequest, opts ...grpc.CallOption) (*PatCreated, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PatCreated)
+	err := c.cc.Invoke(ctx, AdminService_Impersonate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) UnlockLogin(ctx context.Context, in *UnlockLoginRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, AdminService_UnlockLogin_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) GetAuditEvents(ctx context.Context, in *AuditEventsRequest, opts ...grpc.CallOption) (*AuditEvents, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AuditEvents)
+	err := c.cc.Invoke(ctx, AdminService_GetAuditEvents_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedAuthServiceServer) UpdatePhoto(context.Context, *UpdatePhotoRequest) (*emptypb.Empty, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method UpdatePhoto not implemented")
+
+// AdminServiceServer is the server API for AdminService service.
+// All implementations must embed UnimplementedAdminServiceServer
+// for forward compatibility.
+//
+// ===== Admin Service =====
+// Every call is checked: actor must be admin. Every call is written to audit log
+type AdminServiceServer interface {
+	ListUsers(context.Context, *ListUsersRequest) (*AdminUsers, error)
+	GetUser(context.Context, *AdminUserRequest) (*User, error)
+	SetUserDisabled(context.Context, *SetUserDisabledRequest) (*emptypb.Empty, error)
+	SetUserRole(context.Context, *SetUserRoleRequest) (*emptypb.Empty, error)
+	ForcePasswordReset(context.Context, *AdminUserRequest) (*emptypb.Empty, error)
+	Impersonate(context.Context, *AdminUserRequest) (*PatCreated, error)
+	UnlockLogin(context.Context, *UnlockLoginRequest) (*emptypb.Empty, error)
+	GetAuditEvents(context.Context, *AuditEventsRequest) (*AuditEvents, error)
+	mustEmbedUnimplementedAdminServiceServer()
 }
-func (UnimplementedAuthServiceServer) ChangePasswd(context.Context, *ChangePasswordRequest) (*emptypb.Empty, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method ChangePasswd not implemented")
+
+// UnimplementedAdminServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedAdminServiceServer struct{}
+
+func (UnimplementedAdminServiceServer) ListUsers(context.Context, *ListUsersRequest) (*AdminUsers, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListUsers not implemented")
 }
-func (UnimplementedAuthServiceServer) CreateUser(context.Context, *User) (*emptypb.Empty, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method CreateUser not implemented")
+func (UnimplementedAdminServiceServer) GetUser(context.Context, *AdminUserRequest) (*User, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetUser not implemented")
 }
-func (UnimplementedAuthServiceServer) GetUserDataFromToken(context.Context, *Token) (*User, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetUserDataFromToken not implemented")
+func (UnimplementedAdminServiceServer) SetUserDisabled(context.Context, *SetUserDisabledRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetUserDisabled not implemented")
 }
-func (UnimplementedAuthServiceServer) GetIdFromToken(context.Context, *Token) (*Id, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetIdFromToken not implemented")
+func (UnimplementedAdminServiceServer) SetUserRole(context.Context, *SetUserRoleRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetUserRole not implemented")
 }
-func (UnimplementedAuthServiceServer) GetIdFromLogin(context.Context, *String) (*Id, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetIdFromLogin not implemented")
+func (UnimplementedAdminServiceServer) ForcePasswordReset(context.Context, *AdminUserRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ForcePasswordReset not implemented")
 }
-func (UnimplementedAuthServiceServer) GetInfos(context.Context, *Ids) (*Users, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetInfos not implemented")
+func (UnimplementedAdminServiceServer) Impersonate(context.Context, *AdminUserRequest) (*PatCreated, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Impersonate not implemented")
 }
-func (UnimplementedAuthServiceServer) Healthz(context.Context, *emptypb.Empty) (*emptypb.Empty, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method Healthz not implemented")
+func (UnimplementedAdminServiceServer) UnlockLogin(context.Context, *UnlockLoginRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UnlockLogin not implemented")
 }
-func (UnimplementedAuthServiceServer) mustEmbedUnimplementedAuthServiceServer() {}
-func (UnimplementedAuthServiceServer) testEmbeddedByValue()                     {}
+func (UnimplementedAdminServiceServer) GetAuditEvents(context.Context, *AuditEventsRequest) (*AuditEvents, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAuditEvents not implemented")
+}
+func (UnimplementedAdminServiceServer) mustEmbedUnimplementedAdminServiceServer() {}
+func (UnimplementedAdminServiceServer) testEmbeddedByValue()                      {}
 
-// UnsafeAuthServiceServer may be embedded to opt out of forward compatibility for this service.
-// Use of this interface is not recommended, as added methods to AuthServiceServer will
+// UnsafeAdminServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to AdminServiceServer will
 // result in compilation errors.
-type UnsafeAuthServiceServer interface {
-	mustEmbedUnimplementedAuthServiceServer()
+type UnsafeAdminServiceServer interface {
+	mustEmbedUnimplementedAdminServiceServer()
 }
 
-func RegisterAuthServiceServer(s grpc.ServiceRegistrar, srv AuthServiceServer) {
-	// If the following call pancis, it indicates UnimplementedAuthServiceServer was
+func RegisterAdminServiceServer(s grpc.ServiceRegistrar, srv AdminServiceServer) {
+	// If the following call pancis, it indicates UnimplementedAdminServiceServer was
 	// embedded by pointer and is nil.  This will cause panics if an
 	// unimplemented method is ever invoked, so we test this at initialization
 	// time to prevent it from happening at runtime later due to I/O.
 	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
 		t.testEmbeddedByValue()
 	}
-	s.RegisterService(&AuthService_ServiceDesc, srv)
+	s.RegisterService(&AdminService_ServiceDesc, srv)
 }
 
-func _AuthService_Auth_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(AuthRequest)
+func _AdminService_ListUsers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListUsersRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(AuthServiceServer).Auth(ctx, in)
+		return srv.(AdminServiceServer).ListUsers(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: AuthService_Auth_FullMethodName,
+		FullMethod: AdminService_ListUsers_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(AuthServiceServer).Auth(ctx, req.(*AuthRequest))
+		return srv.(AdminServiceServer).ListUsers(ctx, req.(*ListUsersRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _AuthService_Reg_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(AuthRequest)
+func _AdminService_GetUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AdminUserRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(AuthServiceServer).Reg(ctx, in)
+		return srv.(AdminServiceServer).GetUser(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: AuthService_Reg_FullMethodName,
+		FullMethod: AdminService_GetUser_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(AuthServiceServer).Reg(ctx, req.(*AuthRequest))
+		return srv.(AdminServiceServer).GetUser(ctx, req.(*AdminUserRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _AuthService_ValidateTokens_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(Tokens)
+func _AdminService_SetUserDisabled_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetUserDisabledRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(AuthServiceServer).ValidateTokens(ctx, in)
+		return srv.(AdminServiceServer).SetUserDisabled(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: AuthService_ValidateTokens_FullMethodName,
+		FullMethod: AdminService_SetUserDisabled_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(AuthServiceServer).ValidateTokens(ctx, req.(*Tokens))
+		return srv.(AdminServiceServer).SetUserDisabled(ctx, req.(*SetUserDisabledRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _AuthService_DeleteUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(UserId)
+func _AdminService_SetUserRole_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetUserRoleRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(AuthServiceServer).DeleteUser(ctx, in)
+		return srv.(AdminServiceServer).SetUserRole(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: AuthService_DeleteUser_FullMethodName,
+		FullMethod: AdminService_SetUserRole_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(AuthServiceServer).DeleteUser(ctx, req.(*UserId))
+		return srv.(AdminServiceServer).SetUserRole(ctx, req.(*SetUserRoleRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _AuthService_UpdateAbout_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(UpdateAboutRequest)
+func _AdminService_ForcePasswordReset_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AdminUserRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(AuthServiceServer).UpdateAbout(ctx, in)
+		return srv.(AdminServiceServer).ForcePasswordReset(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: AuthService_UpdateAbout_FullMethodName,
+		FullMethod: AdminService_ForcePasswordReset_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(AuthServiceServer).UpdateAbout(ctx, req.(*UpdateAboutRequest))
+		return srv.(AdminServiceServer).ForcePasswordReset(ctx, req.(*AdminUserRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _AuthService_UpdateEmail_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(UpdateEmailRequest)
+func _AdminService_Impersonate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AdminUserRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(AuthServiceServer).UpdateEmail(ctx, in)
+		return srv.(AdminServiceServer).Impersonate(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: AuthService_UpdateEmail_FullMethodName,
+		FullMethod: AdminService_Impersonate_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(AuthServiceServer).UpdateEmail(ctx, req.(*UpdateEmailRequest))
+		return srv.(AdminServiceServer).Impersonate(ctx, req.(*AdminUserRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _AuthService_UpdatePhoto_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(UpdatePhotoRequest)
+func _AdminService_UnlockLogin_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnlockLoginRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(AuthServiceServer).UpdatePhoto(ctx, in)
+		return srv.(AdminServiceServer).UnlockLogin(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: AuthService_UpdatePhoto_FullMethodName,
+		FullMethod: AdminService_UnlockLogin_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(AuthServiceServer).UpdatePhoto(ctx, req.(*UpdatePhotoRequest))
+		return srv.(AdminServiceServer).UnlockLogin(ctx, req.(*UnlockLoginRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _AuthService_ChangePasswd_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ChangePasswordRequest)
+func _AdminService_GetAuditEvents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AuditEventsRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(AuthServiceServer).ChangePasswd(ctx, in)
+		return srv.(AdminServiceServer).GetAuditEvents(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: AuthService_ChangePasswd_FullMethodName,
+		FullMethod: AdminService_GetAuditEvents_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(AuthServiceServer).ChangePasswd(ctx, req.(*ChangePasswordRequest))
+		return srv.(AdminServiceServer).GetAuditEvents(ctx, req.(*AuditEventsRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _AuthService_CreateUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(User)
-	if err := dec(in); err != nil {
+// AdminService_ServiceDesc is the grpc.ServiceDesc for AdminService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var AdminService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "brz.AdminService",
+	HandlerType: (*AdminServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListUsers",
+			Handler:    _AdminService_ListUsers_Handler,
+		},
+		{
+			MethodName: "GetUser",
+			Handler:    _AdminService_GetUser_Handler,
+		},
+		{
+			MethodName: "SetUserDisabled",
+			Handler:    _AdminService_SetUserDisabled_Handler,
+		},
+		{
+			MethodName: "SetUserRole",
+			Handler:    _AdminService_SetUserRole_Handler,
+		},
+		{
+			MethodName: "ForcePasswordReset",
+			Handler:    _AdminService_ForcePasswordReset_Handler,
+		},
+		{
+			MethodName: "Impersonate",
+			Handler:    _AdminService_Impersonate_Handler,
+		},
+		{
+			MethodName: "UnlockLogin",
+			Handler:    _AdminService_UnlockLogin_Handler,
+		},
+		{
+			MethodName: "GetAuditEvents",
+			Handler:    _AdminService_GetAuditEvents_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "auth.proto",
+}
+
+const (
+	AccountDeletionService_ClaimDeletions_FullMethodName       = "/brz.AccountDeletionService/ClaimDeletions"
+	AccountDeletionService_CompleteDeletionStep_FullMethodName = "/brz.AccountDeletionService/CompleteDeletionStep"
+	AccountDeletionService_FailDeletionStep_FullMethodName     = "/brz.AccountDeletionService/FailDeletionStep"
+)
+
+// AccountDeletionServiceClient is the client API for AccountDeletionService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// ===== Account Deletion Service =====
+// Used by worker of gateway, which makes steps of deletion in other services
+type AccountDeletionServiceClient interface {
+	// ClaimDeletions return due deletions and lock them for domain.DeletionLease. Scheduled ones become running
+	ClaimDeletions(ctx context.Context, in *ClaimDeletionsRequest, opts ...grpc.CallOption) (*AccountDeletions, error)
+	// CompleteDeletionStep move deletion to next step. Completing of step which is already completed changes nothing
+	CompleteDeletionStep(ctx context.Context, in *DeletionStepRequest, opts ...grpc.CallOption) (*AccountDeletion, error)
+	FailDeletionStep(ctx context.Context, in *DeletionStepRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+}
+
+type accountDeletionServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewAccountDeletionServiceClient(cc grpc.ClientConnInterface) AccountDeletionServiceClient {
+	return &accountDeletionServiceClient{cc}
+}
+
+func (c *accountDeletionServiceClient) ClaimDeletions(ctx context.Context, in *ClaimDeletionsRequest, opts ...grpc.CallOption) (*AccountDeletions, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AccountDeletions)
+	err := c.cc.Invoke(ctx, AccountDeletionService_ClaimDeletions_FullMethodName, in, out, cOpts...)
+	if err != nil {
 		return nil, err
 	}
-	if interceptor == nil {
-		return srv.(AuthServiceServer).CreateUser(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: AuthService_CreateUser_FullMethodName,
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(AuthServiceServer).CreateUser(ctx, req.(*User))
-	}
-	return interceptor(ctx, in, info, handler)
+	return out, nil
 }
 
-func _AuthService_GetUserDataFromToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(Token)
-	if err := dec(in); err != nil {
+func (c *accountDeletionServiceClient) CompleteDeletionStep(ctx context.Context, in *DeletionStepRequest, opts ...grpc.CallOption) (*AccountDeletion, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AccountDeletion)
+	err := c.cc.Invoke(ctx, AccountDeletionService_CompleteDeletionStep_FullMethodName, in, out, cOpts...)
+	if err != nil {
 		return nil, err
 	}
-	if interceptor == nil {
-		return srv.(AuthServiceServer).GetUserDataFromToken(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: AuthService_GetUserDataFromToken_FullMethodName,
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(AuthServiceServer).GetUserDataFromToken(ctx, req.(*Token))
-	}
-	return interceptor(ctx, in, info, handler)
+	return out, nil
 }
 
-func _AuthService_GetIdFromToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(Token)
-	if err := dec(in); err != nil {
+func (c *accountDeletionServiceClient) FailDeletionStep(ctx context.Context, in *DeletionStepRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, AccountDeletionService_FailDeletionStep_FullMethodName, in, out, cOpts...)
+	if err != nil {
 		return nil, err
 	}
-	if interceptor == nil {
-		return srv.(AuthServiceServer).GetIdFromToken(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: AuthService_GetIdFromToken_FullMethodName,
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(AuthServiceServer).GetIdFromToken(ctx, req.(*Token))
+	return out, nil
+}
+
+// AccountDeletionServiceServer is the server API for AccountDeletionService service.
+// All implementations must embed UnimplementedAccountDeletionServiceServer
+// for forward compatibility.
+//
+// ===== Account Deletion Service =====
+// Used by worker of gateway, which makes steps of deletion in other services
+type AccountDeletionServiceServer interface {
+	// ClaimDeletions return due deletions and lock them for domain.DeletionLease. Scheduled ones become running
+	ClaimDeletions(context.Context, *ClaimDeletionsRequest) (*AccountDeletions, error)
+	// CompleteDeletionStep move deletion to next step. Completing of step which is already completed changes nothing
+	CompleteDeletionStep(context.Context, *DeletionStepRequest) (*AccountDeletion, error)
+	FailDeletionStep(context.Context, *DeletionStepRequest) (*emptypb.Empty, error)
+	mustEmbedUnimplementedAccountDeletionServiceServer()
+}
+
+// UnimplementedAccountDeletionServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedAccountDeletionServiceServer struct{}
+
+func (UnimplementedAccountDeletionServiceServer) ClaimDeletions(context.Context, *ClaimDeletionsRequest) (*AccountDeletions, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ClaimDeletions not implemented")
+}
+func (UnimplementedAccountDeletionServiceServer) CompleteDeletionStep(context.Context, *DeletionStepRequest) (*AccountDeletion, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CompleteDeletionStep not implemented")
+}
+func (UnimplementedAccountDeletionServiceServer) FailDeletionStep(context.Context, *DeletionStepRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FailDeletionStep not implemented")
+}
+func (UnimplementedAccountDeletionServiceServer) mustEmbedUnimplementedAccountDeletionServiceServer() {
+}
+func (UnimplementedAccountDeletionServiceServer) testEmbeddedByValue() {}
+
+// UnsafeAccountDeletionServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to AccountDeletionServiceServer will
+// result in compilation errors.
+type UnsafeAccountDeletionServiceServer interface {
+	mustEmbedUnimplementedAccountDeletionServiceServer()
+}
+
+func RegisterAccountDeletionServiceServer(s grpc.ServiceRegistrar, srv AccountDeletionServiceServer) {
+	// If the following call pancis, it indicates UnimplementedAccountDeletionServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
 	}
-	return interceptor(ctx, in, info, handler)
+	s.RegisterService(&AccountDeletionService_ServiceDesc, srv)
 }
 
-func _AuthService_GetIdFromLogin_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(String)
+func _AccountDeletionService_ClaimDeletions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ClaimDeletionsRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(AuthServiceServer).GetIdFromLogin(ctx, in)
+		return srv.(AccountDeletionServiceServer).ClaimDeletions(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: AuthService_GetIdFromLogin_FullMethodName,
+		FullMethod: AccountDeletionService_ClaimDeletions_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(AuthServiceServer).GetIdFromLogin(ctx, req.(*String))
+		return srv.(AccountDeletionServiceServer).ClaimDeletions(ctx, req.(*ClaimDeletionsRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _AuthService_GetInfos_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(Ids)
+func _AccountDeletionService_CompleteDeletionStep_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeletionStepRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(AuthServiceServer).GetInfos(ctx, in)
+		return srv.(AccountDeletionServiceServer).CompleteDeletionStep(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: AuthService_GetInfos_FullMethodName,
+		FullMethod: AccountDeletionService_CompleteDeletionStep_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(AuthServiceServer).GetInfos(ctx, req.(*Ids))
+		return srv.(AccountDeletionServiceServer).CompleteDeletionStep(ctx, req.(*DeletionStepRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _AuthService_Healthz_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(emptypb.Empty)
+func _AccountDeletionService_FailDeletionStep_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeletionStepRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(AuthServiceServer).Healthz(ctx, in)
+		return srv.(AccountDeletionServiceServer).FailDeletionStep(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: AuthService_Healthz_FullMethodName,
+		FullMethod: AccountDeletionService_FailDeletionStep_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(AuthServiceServer).Healthz(ctx, req.(*emptypb.Empty))
+		return srv.(AccountDeletionServiceServer).FailDeletionStep(ctx, req.(*DeletionStepRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-// AuthService_ServiceDesc is the grpc.ServiceDesc for AuthService service.
+// AccountDeletionService_ServiceDesc is the grpc.ServiceDesc for AccountDeletionService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
-var AuthService_ServiceDesc = grpc.ServiceDesc{
-	ServiceName: "brz.AuthService",
-	HandlerType: (*AuthServiceServer)(nil),
+var AccountDeletionService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "brz.AccountDeletionService",
+	HandlerType: (*AccountDeletionServiceServer)(nil),
 	Methods: []grpc.MethodDesc{
 		{
-			MethodName: "Auth",
-			Handler:    _AuthService_Auth_Handler,
-		},
-		{
-			MethodName: "Reg",
-			Handler:    _AuthService_Reg_Handler,
-		},
-		{
-			MethodName: "ValidateTokens",
-			Handler:    _AuthService_ValidateTokens_Handler,
-		},
-		{
-			MethodName: "DeleteUser",
-			Handler:    _AuthService_DeleteUser_Handler,
-		},
-		{
-			MethodName: "UpdateAbout",
-			Handler:    _AuthService_UpdateAbout_Handler,
-		},
-		{
-			MethodName: "UpdateEmail",
-			Handler:    _AuthService_UpdateEmail_Handler,
-		},
-		{
-			MethodName: "UpdatePhoto",
-			Handler:    _AuthService_UpdatePhoto_Handler,
-		},
-		{
-			MethodName: "ChangePasswd",
-			Handler:    _AuthService_ChangePasswd_Handler,
-		},
-		{
-			MethodName: "CreateUser",
-			Handler:    _AuthService_CreateUser_Handler,
-		},
-		{
-			MethodName: "GetUserDataFromToken",
-			Handler:    _AuthService_GetUserDataFromToken_Handler,
-		},
-		{
-			MethodName: "GetIdFromToken",
-			Handler:    _AuthService_GetIdFromToken_Handler,
-		},
-		{
-			MethodName: "GetIdFromLogin",
-			Handler:    _AuthService_GetIdFromLogin_Handler,
+			MethodName: "ClaimDeletions",
+			Handler:    _AccountDeletionService_ClaimDeletions_Handler,
 		},
 		{
-			MethodName: "GetInfos",
-			Handler:    _AuthService_GetInfos_Handler,
+			MethodName: "CompleteDeletionStep",
+			Handler:    _AccountDeletionService_CompleteDeletionStep_Handler,
 		},
 		{
-			MethodName: "Healthz",
-			Handler:    _AuthService_Healthz_Handler,
+			MethodName: "FailDeletionStep",
+			Handler:    _AccountDeletionService_FailDeletionStep_Handler,
 		},
 	},
 	Streams:  []grpc.StreamDesc{},
@@ -842,6 +842,58 @@ func (x *UserTagId) GetUserId() string {
 	return ""
 }
 
+type UserWorkspaceId struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=userId,proto3" json:"userId,omitempty"`
+	WorkspaceId   string                 `protobuf:"bytes,2,opt,name=workspaceId,proto3" json:"workspaceId,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UserWorkspaceId) Reset() {
+	*x = UserWorkspaceId{}
+	mi := &file_domain_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UserWorkspaceId) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UserWorkspaceId) ProtoMessage() {}
+
+func (x *UserWorkspaceId) ProtoReflect() protoreflect.Message {
+	mi := &file_domain_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UserWorkspaceId.ProtoReflect.Descriptor instead.
+func (*UserWorkspaceId) Descriptor() ([]byte, []int) {
+	return file_domain_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *UserWorkspaceId) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *UserWorkspaceId) GetWorkspaceId() string {
+	if x != nil {
+		return x.WorkspaceId
+	}
+	return ""
+}
+
 type NoteTagId struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	NoteId        string                 `protobuf:"bytes,1,opt,name=noteId,proto3" json:"noteId,omitempty"`
@@ -852,7 +904,7 @@ type NoteTagId struct {
 
 func (x *NoteTagId) Reset() {
 	*x = NoteTagId{}
-	mi := &file_domain_proto_msgTypes[17]
+	mi := &file_domain_proto_msgTypes[18]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -864,7 +916,7 @@ func (x *NoteTagId) String() string {
 func (*NoteTagId) ProtoMessage() {}
 
 func (x *NoteTagId) ProtoReflect() protoreflect.Message {
-	mi := &file_domain_proto_msgTypes[17]
+	mi := &file_domain_proto_msgTypes[18]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -877,7 +929,7 @@ func (x *NoteTagId) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use NoteTagId.ProtoReflect.Descriptor instead.
 func (*NoteTagId) Descriptor() ([]byte, []int) {
-	return file_domain_proto_rawDescGZIP(), []int{17}
+	return file_domain_proto_rawDescGZIP(), []int{18}
 }
 
 func (x *NoteTagId) GetNoteId() string {
@@ -905,7 +957,7 @@ type NoteTagUserId struct {
 
 func (x *NoteTagUserId) Reset() {
 	*x = NoteTagUserId{}
-	mi := &file_domain_proto_msgTypes[18]
+	mi := &file_domain_proto_msgTypes[19]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -917,7 +969,7 @@ func (x *NoteTagUserId) String() string {
 func (*NoteTagUserId) ProtoMessage() {}
 
 func (x *NoteTagUserId) ProtoReflect() protoreflect.Message {
-	mi := &file_domain_proto_msgTypes[18]
+	mi := &file_domain_proto_msgTypes[19]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -930,7 +982,7 @@ func (x *NoteTagUserId) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use NoteTagUserId.ProtoReflect.Descriptor instead.
 func (*NoteTagUserId) Descriptor() ([]byte, []int) {
-	return file_domain_proto_rawDescGZIP(), []int{18}
+	return file_domain_proto_rawDescGZIP(), []int{19}
 }
 
 func (x *NoteTagUserId) GetNoteId() string {
@@ -962,13 +1014,18 @@ type User struct {
 	About         string                 `protobuf:"bytes,4,opt,name=about,proto3" json:"about,omitempty"`
 	Photo         string                 `protobuf:"bytes,5,opt,name=photo,proto3" json:"photo,omitempty"`
 	Password      string                 `protobuf:"bytes,6,opt,name=password,proto3" json:"password,omitempty"`
+	EmailVerified bool                   `protobuf:"varint,7,opt,name=emailVerified,proto3" json:"emailVerified,omitempty"`
+	Role          string                 `protobuf:"bytes,8,opt,name=role,proto3" json:"role,omitempty"`
+	Disabled      bool                   `protobuf:"varint,9,opt,name=disabled,proto3" json:"disabled,omitempty"`
+	// discoverable user is found by part of login or email in SearchUsers
+	Discoverable  bool `protobuf:"varint,10,opt,name=discoverable,proto3" json:"discoverable,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *User) Reset() {
 	*x = User{}
-	mi := &file_domain_proto_msgTypes[19]
+	mi := &file_domain_proto_msgTypes[20]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -980,7 +1037,7 @@ func (x *User) String() string {
 func (*User) ProtoMessage() {}
 
 func (x *User) ProtoReflect() protoreflect.Message {
-	mi := &file_domain_proto_msgTypes[19]
+	mi := &file_domain_proto_msgTypes[20]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -993,7 +1050,7 @@ func (x *User) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use User.ProtoReflect.Descriptor instead.
 func (*User) Descriptor() ([]byte, []int) {
-	return file_domain_proto_rawDescGZIP(), []int{19}
+	return file_domain_proto_rawDescGZIP(), []int{20}
 }
 
 func (x *User) GetId() string {
@@ -1038,6 +1095,213 @@ func (x *User) GetPassword() string {
 	return ""
 }
 
+func (x *User) GetEmailVerified() bool {
+	if x != nil {
+		return x.EmailVerified
+	}
+	return false
+}
+
+func (x *User) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+func (x *User) GetDisabled() bool {
+	if x != nil {
+		return x.Disabled
+	}
+	return false
+}
+
+func (x *User) GetDiscoverable() bool {
+	if x != nil {
+		return x.Discoverable
+	}
+	return false
+}
+
+type NotificationPreferences struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	EmailShares   bool                   `protobuf:"varint,1,opt,name=emailShares,proto3" json:"emailShares,omitempty"`
+	EmailComments bool                   `protobuf:"varint,2,opt,name=emailComments,proto3" json:"emailComments,omitempty"`
+	EmailMentions bool                   `protobuf:"varint,3,opt,name=emailMentions,proto3" json:"emailMentions,omitempty"`
+	EmailSecurity bool                   `protobuf:"varint,4,opt,name=emailSecurity,proto3" json:"emailSecurity,omitempty"`
+	// off, daily or weekly
+	Digest        string `protobuf:"bytes,5,opt,name=digest,proto3" json:"digest,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NotificationPreferences) Reset() {
+	*x = NotificationPreferences{}
+	mi := &file_domain_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NotificationPreferences) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NotificationPreferences) ProtoMessage() {}
+
+func (x *NotificationPreferences) ProtoReflect() protoreflect.Message {
+	mi := &file_domain_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NotificationPreferences.ProtoReflect.Descriptor instead.
+func (*NotificationPreferences) Descriptor() ([]byte, []int) {
+	return file_domain_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *NotificationPreferences) GetEmailShares() bool {
+	if x != nil {
+		return x.EmailShares
+	}
+	return false
+}
+
+func (x *NotificationPreferences) GetEmailComments() bool {
+	if x != nil {
+		return x.EmailComments
+	}
+	return false
+}
+
+func (x *NotificationPreferences) GetEmailMentions() bool {
+	if x != nil {
+		return x.EmailMentions
+	}
+	return false
+}
+
+func (x *NotificationPreferences) GetEmailSecurity() bool {
+	if x != nil {
+		return x.EmailSecurity
+	}
+	return false
+}
+
+func (x *NotificationPreferences) GetDigest() string {
+	if x != nil {
+		return x.Digest
+	}
+	return ""
+}
+
+type Preferences struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Version    int32                  `protobuf:"varint,1,opt,name=version,proto3" json:"version,omitempty"`
+	Theme      string                 `protobuf:"bytes,2,opt,name=theme,proto3" json:"theme,omitempty"`
+	Locale     string                 `protobuf:"bytes,3,opt,name=locale,proto3" json:"locale,omitempty"`
+	NoteSort   string                 `protobuf:"bytes,4,opt,name=noteSort,proto3" json:"noteSort,omitempty"`
+	DefaultTag string                 `protobuf:"bytes,5,opt,name=defaultTag,proto3" json:"defaultTag,omitempty"`
+	// trashRetentionDays is kept by blocknote, auth doesn't store it
+	TrashRetentionDays int32                    `protobuf:"varint,6,opt,name=trashRetentionDays,proto3" json:"trashRetentionDays,omitempty"`
+	Notifications      *NotificationPreferences `protobuf:"bytes,7,opt,name=notifications,proto3" json:"notifications,omitempty"`
+	// revision of saved preferences, update with other revision is rejected
+	Revision      int64 `protobuf:"varint,8,opt,name=revision,proto3" json:"revision,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Preferences) Reset() {
+	*x = Preferences{}
+	mi := &file_domain_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Preferences) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Preferences) ProtoMessage() {}
+
+func (x *Preferences) ProtoReflect() protoreflect.Message {
+	mi := &file_domain_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Preferences.ProtoReflect.Descriptor instead.
+func (*Preferences) Descriptor() ([]byte, []int) {
+	return file_domain_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *Preferences) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *Preferences) GetTheme() string {
+	if x != nil {
+		return x.Theme
+	}
+	return ""
+}
+
+func (x *Preferences) GetLocale() string {
+	if x != nil {
+		return x.Locale
+	}
+	return ""
+}
+
+func (x *Preferences) GetNoteSort() string {
+	if x != nil {
+		return x.NoteSort
+	}
+	return ""
+}
+
+func (x *Preferences) GetDefaultTag() string {
+	if x != nil {
+		return x.DefaultTag
+	}
+	return ""
+}
+
+func (x *Preferences) GetTrashRetentionDays() int32 {
+	if x != nil {
+		return x.TrashRetentionDays
+	}
+	return 0
+}
+
+func (x *Preferences) GetNotifications() *NotificationPreferences {
+	if x != nil {
+		return x.Notifications
+	}
+	return nil
+}
+
+func (x *Preferences) GetRevision() int64 {
+	if x != nil {
+		return x.Revision
+	}
+	return 0
+}
+
 type Tag struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
@@ -1046,13 +1310,14 @@ type Tag struct {
 	Emoji         string                 `protobuf:"bytes,4,opt,name=emoji,proto3" json:"emoji,omitempty"`
 	UserId        string                 `protobuf:"bytes,5,opt,name=userId,proto3" json:"userId,omitempty"`
 	IsPinned      bool                   `protobuf:"varint,6,opt,name=isPinned,proto3" json:"isPinned,omitempty"`
+	WorkspaceId   string                 `protobuf:"bytes,7,opt,name=workspaceId,proto3" json:"workspaceId,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *Tag) Reset() {
 	*x = Tag{}
-	mi := &file_domain_proto_msgTypes[20]
+	mi := &file_domain_proto_msgTypes[23]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1064,7 +1329,7 @@ func (x *Tag) String() string {
 func (*Tag) ProtoMessage() {}
 
 func (x *Tag) ProtoReflect() protoreflect.Message {
-	mi := &file_domain_proto_msgTypes[20]
+	mi := &file_domain_proto_msgTypes[23]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1077,7 +1342,7 @@ func (x *Tag) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Tag.ProtoReflect.Descriptor instead.
 func (*Tag) Descriptor() ([]byte, []int) {
-	return file_domain_proto_rawDescGZIP(), []int{20}
+	return file_domain_proto_rawDescGZIP(), []int{23}
 }
 
 func (x *Tag) GetId() string {
@@ -1122,6 +1387,13 @@ func (x *Tag) GetIsPinned() bool {
 	return false
 }
 
+func (x *Tag) GetWorkspaceId() string {
+	if x != nil {
+		return x.WorkspaceId
+	}
+	return ""
+}
+
 type Block struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
@@ -1137,7 +1409,7 @@ type Block struct {
 
 func (x *Block) Reset() {
 	*x = Block{}
-	mi := &file_domain_proto_msgTypes[21]
+	mi := &file_domain_proto_msgTypes[24]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1149,7 +1421,7 @@ func (x *Block) String() string {
 func (*Block) ProtoMessage() {}
 
 func (x *Block) ProtoReflect() protoreflect.Message {
-	mi := &file_domain_proto_msgTypes[21]
+	mi := &file_domain_proto_msgTypes[24]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1162,7 +1434,7 @@ func (x *Block) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Block.ProtoReflect.Descriptor instead.
 func (*Block) Descriptor() ([]byte, []int) {
-	return file_domain_proto_rawDescGZIP(), []int{21}
+	return file_domain_proto_rawDescGZIP(), []int{24}
 }
 
 func (x *Block) GetId() string {
@@ -1214,38 +1486,29 @@ func (x *Block) GetData() *structpb.Struct {
 	return nil
 }
 
-type Note struct {
+type TextRange struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Title         string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
-	CreatedAt     int64                  `protobuf:"varint,3,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	UpdatedAt     int64                  `protobuf:"varint,4,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
-	Tag           *Tag                   `protobuf:"bytes,5,opt,name=tag,proto3" json:"tag,omitempty"`
-	Author        string                 `protobuf:"bytes,6,opt,name=author,proto3" json:"author,omitempty"`
-	Editors       []string               `protobuf:"bytes,7,rep,name=editors,proto3" json:"editors,omitempty"`
-	Readers       []string               `protobuf:"bytes,8,rep,name=readers,proto3" json:"readers,omitempty"`
-	Blocks        []string               `protobuf:"bytes,9,rep,name=blocks,proto3" json:"blocks,omitempty"`
-	IsPublic      bool                   `protobuf:"varint,10,opt,name=isPublic,proto3" json:"isPublic,omitempty"`
-	IsBlog        bool                   `protobuf:"varint,11,opt,name=isBlog,proto3" json:"isBlog,omitempty"`
+	Start         int32                  `protobuf:"varint,1,opt,name=start,proto3" json:"start,omitempty"`
+	End           int32                  `protobuf:"varint,2,opt,name=end,proto3" json:"end,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Note) Reset() {
-	*x = Note{}
-	mi := &file_domain_proto_msgTypes[22]
+func (x *TextRange) Reset() {
+	*x = TextRange{}
+	mi := &file_domain_proto_msgTypes[25]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Note) String() string {
+func (x *TextRange) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Note) ProtoMessage() {}
+func (*TextRange) ProtoMessage() {}
 
-func (x *Note) ProtoReflect() protoreflect.Message {
-	mi := &file_domain_proto_msgTypes[22]
+func (x *TextRange) ProtoReflect() protoreflect.Message {
+	mi := &file_domain_proto_msgTypes[25]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1256,120 +1519,796 @@ func (x *Note) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Note.ProtoReflect.Descriptor instead.
-func (*Note) Descriptor() ([]byte, []int) {
-	return file_domain_proto_rawDescGZIP(), []int{22}
-}
-
-func (x *Note) GetId() string {
-	if x != nil {
-		return x.Id
-	}
-	return ""
+// Deprecated: Use TextRange.ProtoReflect.Descriptor instead.
+func (*TextRange) Descriptor() ([]byte, []int) {
+	return file_domain_proto_rawDescGZIP(), []int{25}
 }
 
-func (x *Note) GetTitle() string {
+func (x *TextRange) GetStart() int32 {
 	if x != nil {
-		return x.Title
+		return x.Start
 	}
-	return ""
+	return 0
 }
 
-func (x *Note) GetCreatedAt() int64 {
+func (x *TextRange) GetEnd() int32 {
 	if x != nil {
-		return x.CreatedAt
+		return x.End
 	}
 	return 0
 }
 
-func (x *Note) GetUpdatedAt() int64 {
-	if x != nil {
+type Comment struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	NoteId        string                 `protobuf:"bytes,2,opt,name=noteId,proto3" json:"noteId,omitempty"`
+	BlockId       string                 `protobuf:"bytes,3,opt,name=blockId,proto3" json:"blockId,omitempty"`
+	ThreadId      string                 `protobuf:"bytes,4,opt,name=threadId,proto3" json:"threadId,omitempty"`
+	Author        string                 `protobuf:"bytes,5,opt,name=author,proto3" json:"author,omitempty"`
+	Text          string                 `protobuf:"bytes,6,opt,name=text,proto3" json:"text,omitempty"`
+	Mentions      []string               `protobuf:"bytes,7,rep,name=mentions,proto3" json:"mentions,omitempty"`
+	Anchor        *TextRange             `protobuf:"bytes,8,opt,name=anchor,proto3" json:"anchor,omitempty"`
+	Resolved      bool                   `protobuf:"varint,9,opt,name=resolved,proto3" json:"resolved,omitempty"`
+	Orphaned      bool                   `protobuf:"varint,10,opt,name=orphaned,proto3" json:"orphaned,omitempty"`
+	CreatedAt     int64                  `protobuf:"varint,11,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     int64                  `protobuf:"varint,12,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Comment) Reset() {
+	*x = Comment{}
+	mi := &file_domain_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Comment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Comment) ProtoMessage() {}
+
+func (x *Comment) ProtoReflect() protoreflect.Message {
+	mi := &file_domain_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Comment.ProtoReflect.Descriptor instead.
+func (*Comment) Descriptor() ([]byte, []int) {
+	return file_domain_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *Comment) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Comment) GetNoteId() string {
+	if x != nil {
+		return x.NoteId
+	}
+	return ""
+}
+
+func (x *Comment) GetBlockId() string {
+	if x != nil {
+		return x.BlockId
+	}
+	return ""
+}
+
+func (x *Comment) GetThreadId() string {
+	if x != nil {
+		return x.ThreadId
+	}
+	return ""
+}
+
+func (x *Comment) GetAuthor() string {
+	if x != nil {
+		return x.Author
+	}
+	return ""
+}
+
+func (x *Comment) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *Comment) GetMentions() []string {
+	if x != nil {
+		return x.Mentions
+	}
+	return nil
+}
+
+func (x *Comment) GetAnchor() *TextRange {
+	if x != nil {
+		return x.Anchor
+	}
+	return nil
+}
+
+func (x *Comment) GetResolved() bool {
+	if x != nil {
+		return x.Resolved
+	}
+	return false
+}
+
+func (x *Comment) GetOrphaned() bool {
+	if x != nil {
+		return x.Orphaned
+	}
+	return false
+}
+
+func (x *Comment) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+func (x *Comment) GetUpdatedAt() int64 {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return 0
+}
+
+type Activity struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	NoteId        string                 `protobuf:"bytes,2,opt,name=noteId,proto3" json:"noteId,omitempty"`
+	Actor         string                 `protobuf:"bytes,3,opt,name=actor,proto3" json:"actor,omitempty"`
+	Action        string                 `protobuf:"bytes,4,opt,name=action,proto3" json:"action,omitempty"`
+	BlockId       string                 `protobuf:"bytes,5,opt,name=blockId,proto3" json:"blockId,omitempty"`
+	Summary       string                 `protobuf:"bytes,6,opt,name=summary,proto3" json:"summary,omitempty"`
+	CreatedAt     int64                  `protobuf:"varint,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Activity) Reset() {
+	*x = Activity{}
+	mi := &file_domain_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Activity) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Activity) ProtoMessage() {}
+
+func (x *Activity) ProtoReflect() protoreflect.Message {
+	mi := &file_domain_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Activity.ProtoReflect.Descriptor instead.
+func (*Activity) Descriptor() ([]byte, []int) {
+	return file_domain_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *Activity) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Activity) GetNoteId() string {
+	if x != nil {
+		return x.NoteId
+	}
+	return ""
+}
+
+func (x *Activity) GetActor() string {
+	if x != nil {
+		return x.Actor
+	}
+	return ""
+}
+
+func (x *Activity) GetAction() string {
+	if x != nil {
+		return x.Action
+	}
+	return ""
+}
+
+func (x *Activity) GetBlockId() string {
+	if x != nil {
+		return x.BlockId
+	}
+	return ""
+}
+
+func (x *Activity) GetSummary() string {
+	if x != nil {
+		return x.Summary
+	}
+	return ""
+}
+
+func (x *Activity) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+type DeletedBlock struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Block         *Block                 `protobuf:"bytes,1,opt,name=block,proto3" json:"block,omitempty"`
+	Position      int32                  `protobuf:"varint,2,opt,name=position,proto3" json:"position,omitempty"`
+	DeletedAt     int64                  `protobuf:"varint,3,opt,name=deletedAt,proto3" json:"deletedAt,omitempty"`
+	DeletedBy     string                 `protobuf:"bytes,4,opt,name=deletedBy,proto3" json:"deletedBy,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeletedBlock) Reset() {
+	*x = DeletedBlock{}
+	mi := &file_domain_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeletedBlock) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeletedBlock) ProtoMessage() {}
+
+func (x *DeletedBlock) ProtoReflect() protoreflect.Message {
+	mi := &file_domain_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeletedBlock.ProtoReflect.Descriptor instead.
+func (*DeletedBlock) Descriptor() ([]byte, []int) {
+	return file_domain_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *DeletedBlock) GetBlock() *Block {
+	if x != nil {
+		return x.Block
+	}
+	return nil
+}
+
+func (x *DeletedBlock) GetPosition() int32 {
+	if x != nil {
+		return x.Position
+	}
+	return 0
+}
+
+func (x *DeletedBlock) GetDeletedAt() int64 {
+	if x != nil {
+		return x.DeletedAt
+	}
+	return 0
+}
+
+func (x *DeletedBlock) GetDeletedBy() string {
+	if x != nil {
+		return x.DeletedBy
+	}
+	return ""
+}
+
+type Note struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Title         string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	CreatedAt     int64                  `protobuf:"varint,3,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     int64                  `protobuf:"varint,4,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	Tag           *Tag                   `protobuf:"bytes,5,opt,name=tag,proto3" json:"tag,omitempty"`
+	Author        string                 `protobuf:"bytes,6,opt,name=author,proto3" json:"author,omitempty"`
+	Editors       []string               `protobuf:"bytes,7,rep,name=editors,proto3" json:"editors,omitempty"`
+	Readers       []string               `protobuf:"bytes,8,rep,name=readers,proto3" json:"readers,omitempty"`
+	Blocks        []string               `protobuf:"bytes,9,rep,name=blocks,proto3" json:"blocks,omitempty"`
+	IsPublic      bool                   `protobuf:"varint,10,opt,name=isPublic,proto3" json:"isPublic,omitempty"`
+	IsBlog        bool                   `protobuf:"varint,11,opt,name=isBlog,proto3" json:"isBlog,omitempty"`
+	WorkspaceId   string                 `protobuf:"bytes,12,opt,name=workspaceId,proto3" json:"workspaceId,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Note) Reset() {
+	*x = Note{}
+	mi := &file_domain_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Note) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Note) ProtoMessage() {}
+
+func (x *Note) ProtoReflect() protoreflect.Message {
+	mi := &file_domain_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Note.ProtoReflect.Descriptor instead.
+func (*Note) Descriptor() ([]byte, []int) {
+	return file_domain_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *Note) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Note) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *Note) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+func (x *Note) GetUpdatedAt() int64 {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return 0
+}
+
+func (x *Note) GetTag() *Tag {
+	if x != nil {
+		return x.Tag
+	}
+	return nil
+}
+
+func (x *Note) GetAuthor() string {
+	if x != nil {
+		return x.Author
+	}
+	return ""
+}
+
+func (x *Note) GetEditors() []string {
+	if x != nil {
+		return x.Editors
+	}
+	return nil
+}
+
+func (x *Note) GetReaders() []string {
+	if x != nil {
+		return x.Readers
+	}
+	return nil
+}
+
+func (x *Note) GetBlocks() []string {
+	if x != nil {
+		return x.Blocks
+	}
+	return nil
+}
+
+func (x *Note) GetIsPublic() bool {
+	if x != nil {
+		return x.IsPublic
+	}
+	return false
+}
+
+func (x *Note) GetIsBlog() bool {
+	if x != nil {
+		return x.IsBlog
+	}
+	return false
+}
+
+func (x *Note) GetWorkspaceId() string {
+	if x != nil {
+		return x.WorkspaceId
+	}
+	return ""
+}
+
+type NoteWithBlocks struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Title         string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	CreatedAt     int64                  `protobuf:"varint,3,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     int64                  `protobuf:"varint,4,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	Tag           *Tag                   `protobuf:"bytes,5,opt,name=tag,proto3" json:"tag,omitempty"`
+	Author        string                 `protobuf:"bytes,6,opt,name=author,proto3" json:"author,omitempty"`
+	Editors       []string               `protobuf:"bytes,7,rep,name=editors,proto3" json:"editors,omitempty"`
+	Readers       []string               `protobuf:"bytes,8,rep,name=readers,proto3" json:"readers,omitempty"`
+	Blocks        []*Block               `protobuf:"bytes,9,rep,name=blocks,proto3" json:"blocks,omitempty"`
+	IsPublic      bool                   `protobuf:"varint,10,opt,name=isPublic,proto3" json:"isPublic,omitempty"`
+	IsBlog        bool                   `protobuf:"varint,11,opt,name=isBlog,proto3" json:"isBlog,omitempty"`
+	WorkspaceId   string                 `protobuf:"bytes,12,opt,name=workspaceId,proto3" json:"workspaceId,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NoteWithBlocks) Reset() {
+	*x = NoteWithBlocks{}
+	mi := &file_domain_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NoteWithBlocks) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NoteWithBlocks) ProtoMessage() {}
+
+func (x *NoteWithBlocks) ProtoReflect() protoreflect.Message {
+	mi := &file_domain_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NoteWithBlocks.ProtoReflect.Descriptor instead.
+func (*NoteWithBlocks) Descriptor() ([]byte, []int) {
+	return file_domain_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *NoteWithBlocks) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *NoteWithBlocks) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *NoteWithBlocks) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+func (x *NoteWithBlocks) GetUpdatedAt() int64 {
+	if x != nil {
 		return x.UpdatedAt
 	}
 	return 0
 }
 
-func (x *Note) GetTag() *Tag {
+func (x *NoteWithBlocks) GetTag() *Tag {
 	if x != nil {
 		return x.Tag
 	}
 	return nil
 }
 
-func (x *Note) GetAuthor() string {
+func (x *NoteWithBlocks) GetAuthor() string {
 	if x != nil {
 		return x.Author
 	}
 	return ""
 }
 
-func (x *Note) GetEditors() []string {
+func (x *NoteWithBlocks) GetEditors() []string {
 	if x != nil {
 		return x.Editors
 	}
 	return nil
 }
 
-func (x *Note) GetReaders() []string {
+func (x *NoteWithBlocks) GetReaders() []string {
 	if x != nil {
 		return x.Readers
 	}
 	return nil
 }
 
-func (x *Note) GetBlocks() []string {
+func (x *NoteWithBlocks) GetBlocks() []*Block {
 	if x != nil {
 		return x.Blocks
 	}
 	return nil
 }
 
-func (x *Note) GetIsPublic() bool {
+func (x *NoteWithBlocks) GetIsPublic() bool {
 	if x != nil {
 		return x.IsPublic
 	}
 	return false
 }
 
-func (x *Note) GetIsBlog() bool {
+func (x *NoteWithBlocks) GetIsBlog() bool {
 	if x != nil {
 		return x.IsBlog
 	}
 	return false
 }
 
-type NoteWithBlocks struct {
+func (x *NoteWithBlocks) GetWorkspaceId() string {
+	if x != nil {
+		return x.WorkspaceId
+	}
+	return ""
+}
+
+type NotePart struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
 	Title         string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
-	CreatedAt     int64                  `protobuf:"varint,3,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	UpdatedAt     int64                  `protobuf:"varint,4,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
-	Tag           *Tag                   `protobuf:"bytes,5,opt,name=tag,proto3" json:"tag,omitempty"`
-	Author        string                 `protobuf:"bytes,6,opt,name=author,proto3" json:"author,omitempty"`
-	Editors       []string               `protobuf:"bytes,7,rep,name=editors,proto3" json:"editors,omitempty"`
-	Readers       []string               `protobuf:"bytes,8,rep,name=readers,proto3" json:"readers,omitempty"`
-	Blocks        []*Block               `protobuf:"bytes,9,rep,name=blocks,proto3" json:"blocks,omitempty"`
+	Tag           *Tag                   `protobuf:"bytes,3,opt,name=tag,proto3" json:"tag,omitempty"`
+	FirstBlock    string                 `protobuf:"bytes,4,opt,name=first_block,json=firstBlock,proto3" json:"first_block,omitempty"`
+	UpdatedAt     int64                  `protobuf:"varint,5,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	Role          string                 `protobuf:"bytes,6,opt,name=role,proto3" json:"role,omitempty"`
 	IsPublic      bool                   `protobuf:"varint,10,opt,name=isPublic,proto3" json:"isPublic,omitempty"`
 	IsBlog        bool                   `protobuf:"varint,11,opt,name=isBlog,proto3" json:"isBlog,omitempty"`
+	WorkspaceId   string                 `protobuf:"bytes,12,opt,name=workspaceId,proto3" json:"workspaceId,omitempty"`
+	DaysLeft      int32                  `protobuf:"varint,13,opt,name=daysLeft,proto3" json:"daysLeft,omitempty"`
+	CreatedAt     int64                  `protobuf:"varint,14,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NotePart) Reset() {
+	*x = NotePart{}
+	mi := &file_domain_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NotePart) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NotePart) ProtoMessage() {}
+
+func (x *NotePart) ProtoReflect() protoreflect.Message {
+	mi := &file_domain_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NotePart.ProtoReflect.Descriptor instead.
+func (*NotePart) Descriptor() ([]byte, []int) {
+	return file_domain_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *NotePart) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *NotePart) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *NotePart) GetTag() *Tag {
+	if x != nil {
+		return x.Tag
+	}
+	return nil
+}
+
+func (x *NotePart) GetFirstBlock() string {
+	if x != nil {
+		return x.FirstBlock
+	}
+	return ""
+}
+
+func (x *NotePart) GetUpdatedAt() int64 {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return 0
+}
+
+func (x *NotePart) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+func (x *NotePart) GetIsPublic() bool {
+	if x != nil {
+		return x.IsPublic
+	}
+	return false
+}
+
+func (x *NotePart) GetIsBlog() bool {
+	if x != nil {
+		return x.IsBlog
+	}
+	return false
+}
+
+func (x *NotePart) GetWorkspaceId() string {
+	if x != nil {
+		return x.WorkspaceId
+	}
+	return ""
+}
+
+func (x *NotePart) GetDaysLeft() int32 {
+	if x != nil {
+		return x.DaysLeft
+	}
+	return 0
+}
+
+func (x *NotePart) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+type Workspace struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Title         string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	OwnerId       string                 `protobuf:"bytes,3,opt,name=ownerId,proto3" json:"ownerId,omitempty"`
+	Role          string                 `protobuf:"bytes,4,opt,name=role,proto3" json:"role,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Workspace) Reset() {
+	*x = Workspace{}
+	mi := &file_domain_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Workspace) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Workspace) ProtoMessage() {}
+
+func (x *Workspace) ProtoReflect() protoreflect.Message {
+	mi := &file_domain_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Workspace.ProtoReflect.Descriptor instead.
+func (*Workspace) Descriptor() ([]byte, []int) {
+	return file_domain_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *Workspace) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Workspace) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *Workspace) GetOwnerId() string {
+	if x != nil {
+		return x.OwnerId
+	}
+	return ""
+}
+
+func (x *Workspace) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+type WorkspaceMember struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=userId,proto3" json:"userId,omitempty"`
+	Role          string                 `protobuf:"bytes,2,opt,name=role,proto3" json:"role,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *NoteWithBlocks) Reset() {
-	*x = NoteWithBlocks{}
-	mi := &file_domain_proto_msgTypes[23]
+func (x *WorkspaceMember) Reset() {
+	*x = WorkspaceMember{}
+	mi := &file_domain_proto_msgTypes[33]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *NoteWithBlocks) String() string {
+func (x *WorkspaceMember) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*NoteWithBlocks) ProtoMessage() {}
+func (*WorkspaceMember) ProtoMessage() {}
 
-func (x *NoteWithBlocks) ProtoReflect() protoreflect.Message {
-	mi := &file_domain_proto_msgTypes[23]
+func (x *WorkspaceMember) ProtoReflect() protoreflect.Message {
+	mi := &file_domain_proto_msgTypes[33]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1380,117 +2319,92 @@ func (x *NoteWithBlocks) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use NoteWithBlocks.ProtoReflect.Descriptor instead.
-func (*NoteWithBlocks) Descriptor() ([]byte, []int) {
-	return file_domain_proto_rawDescGZIP(), []int{23}
+// Deprecated: Use WorkspaceMember.ProtoReflect.Descriptor instead.
+func (*WorkspaceMember) Descriptor() ([]byte, []int) {
+	return file_domain_proto_rawDescGZIP(), []int{33}
 }
 
-func (x *NoteWithBlocks) GetId() string {
+func (x *WorkspaceMember) GetUserId() string {
 	if x != nil {
-		return x.Id
+		return x.UserId
 	}
 	return ""
 }
 
-func (x *NoteWithBlocks) GetTitle() string {
+func (x *WorkspaceMember) GetRole() string {
 	if x != nil {
-		return x.Title
+		return x.Role
 	}
 	return ""
 }
 
-func (x *NoteWithBlocks) GetCreatedAt() int64 {
-	if x != nil {
-		return x.CreatedAt
-	}
-	return 0
+// ===== Collections =====
+type Blocks struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Items         []*Block               `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *NoteWithBlocks) GetUpdatedAt() int64 {
-	if x != nil {
-		return x.UpdatedAt
-	}
-	return 0
+func (x *Blocks) Reset() {
+	*x = Blocks{}
+	mi := &file_domain_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
-func (x *NoteWithBlocks) GetTag() *Tag {
-	if x != nil {
-		return x.Tag
-	}
-	return nil
+func (x *Blocks) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-func (x *NoteWithBlocks) GetAuthor() string {
-	if x != nil {
-		return x.Author
-	}
-	return ""
-}
+func (*Blocks) ProtoMessage() {}
 
-func (x *NoteWithBlocks) GetEditors() []string {
+func (x *Blocks) ProtoReflect() protoreflect.Message {
+	mi := &file_domain_proto_msgTypes[34]
 	if x != nil {
-		return x.Editors
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return nil
+	return mi.MessageOf(x)
 }
 
-func (x *NoteWithBlocks) GetReaders() []string {
-	if x != nil {
-		return x.Readers
-	}
-	return nil
+// Deprecated: Use Blocks.ProtoReflect.Descriptor instead.
+func (*Blocks) Descriptor() ([]byte, []int) {
+	return file_domain_proto_rawDescGZIP(), []int{34}
 }
 
-func (x *NoteWithBlocks) GetBlocks() []*Block {
+func (x *Blocks) GetItems() []*Block {
 	if x != nil {
-		return x.Blocks
+		return x.Items
 	}
 	return nil
 }
 
-func (x *NoteWithBlocks) GetIsPublic() bool {
-	if x != nil {
-		return x.IsPublic
-	}
-	return false
-}
-
-func (x *NoteWithBlocks) GetIsBlog() bool {
-	if x != nil {
-		return x.IsBlog
-	}
-	return false
-}
-
-type NotePart struct {
+type DeletedBlocks struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Title         string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
-	Tag           *Tag                   `protobuf:"bytes,3,opt,name=tag,proto3" json:"tag,omitempty"`
-	FirstBlock    string                 `protobuf:"bytes,4,opt,name=first_block,json=firstBlock,proto3" json:"first_block,omitempty"`
-	UpdatedAt     int64                  `protobuf:"varint,5,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
-	Role          string                 `protobuf:"bytes,6,opt,name=role,proto3" json:"role,omitempty"`
-	IsPublic      bool                   `protobuf:"varint,10,opt,name=isPublic,proto3" json:"isPublic,omitempty"`
-	IsBlog        bool                   `protobuf:"varint,11,opt,name=isBlog,proto3" json:"isBlog,omitempty"`
+	Items         []*DeletedBlock        `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *NotePart) Reset() {
-	*x = NotePart{}
-	mi := &file_domain_proto_msgTypes[24]
+func (x *DeletedBlocks) Reset() {
+	*x = DeletedBlocks{}
+	mi := &file_domain_proto_msgTypes[35]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *NotePart) String() string {
+func (x *DeletedBlocks) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*NotePart) ProtoMessage() {}
+func (*DeletedBlocks) ProtoMessage() {}
 
-func (x *NotePart) ProtoReflect() protoreflect.Message {
-	mi := &file_domain_proto_msgTypes[24]
+func (x *DeletedBlocks) ProtoReflect() protoreflect.Message {
+	mi := &file_domain_proto_msgTypes[35]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1501,90 +2415,85 @@ func (x *NotePart) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use NotePart.ProtoReflect.Descriptor instead.
-func (*NotePart) Descriptor() ([]byte, []int) {
-	return file_domain_proto_rawDescGZIP(), []int{24}
+// Deprecated: Use DeletedBlocks.ProtoReflect.Descriptor instead.
+func (*DeletedBlocks) Descriptor() ([]byte, []int) {
+	return file_domain_proto_rawDescGZIP(), []int{35}
 }
 
-func (x *NotePart) GetId() string {
+func (x *DeletedBlocks) GetItems() []*DeletedBlock {
 	if x != nil {
-		return x.Id
+		return x.Items
 	}
-	return ""
+	return nil
 }
 
-func (x *NotePart) GetTitle() string {
-	if x != nil {
-		return x.Title
-	}
-	return ""
+type Comments struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Items         []*Comment             `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *NotePart) GetTag() *Tag {
-	if x != nil {
-		return x.Tag
-	}
-	return nil
+func (x *Comments) Reset() {
+	*x = Comments{}
+	mi := &file_domain_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
-func (x *NotePart) GetFirstBlock() string {
-	if x != nil {
-		return x.FirstBlock
-	}
-	return ""
+func (x *Comments) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-func (x *NotePart) GetUpdatedAt() int64 {
-	if x != nil {
-		return x.UpdatedAt
-	}
-	return 0
-}
+func (*Comments) ProtoMessage() {}
 
-func (x *NotePart) GetRole() string {
+func (x *Comments) ProtoReflect() protoreflect.Message {
+	mi := &file_domain_proto_msgTypes[36]
 	if x != nil {
-		return x.Role
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return ""
+	return mi.MessageOf(x)
 }
 
-func (x *NotePart) GetIsPublic() bool {
-	if x != nil {
-		return x.IsPublic
-	}
-	return false
+// Deprecated: Use Comments.ProtoReflect.Descriptor instead.
+func (*Comments) Descriptor() ([]byte, []int) {
+	return file_domain_proto_rawDescGZIP(), []int{36}
 }
 
-func (x *NotePart) GetIsBlog() bool {
+func (x *Comments) GetItems() []*Comment {
 	if x != nil {
-		return x.IsBlog
+		return x.Items
 	}
-	return false
+	return nil
 }
 
-// ===== Collections =====
-type Blocks struct {
+type Activities struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Items         []*Block               `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+	Items         []*Activity            `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+	Total         int64                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Blocks) Reset() {
-	*x = Blocks{}
-	mi := &file_domain_proto_msgTypes[25]
+func (x *Activities) Reset() {
+	*x = Activities{}
+	mi := &file_domain_proto_msgTypes[37]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Blocks) String() string {
+func (x *Activities) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Blocks) ProtoMessage() {}
+func (*Activities) ProtoMessage() {}
 
-func (x *Blocks) ProtoReflect() protoreflect.Message {
-	mi := &file_domain_proto_msgTypes[25]
+func (x *Activities) ProtoReflect() protoreflect.Message {
+	mi := &file_domain_proto_msgTypes[37]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1595,18 +2504,25 @@ func (x *Blocks) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Blocks.ProtoReflect.Descriptor instead.
-func (*Blocks) Descriptor() ([]byte, []int) {
-	return file_domain_proto_rawDescGZIP(), []int{25}
+// Deprecated: Use Activities.ProtoReflect.Descriptor instead.
+func (*Activities) Descriptor() ([]byte, []int) {
+	return file_domain_proto_rawDescGZIP(), []int{37}
 }
 
-func (x *Blocks) GetItems() []*Block {
+func (x *Activities) GetItems() []*Activity {
 	if x != nil {
 		return x.Items
 	}
 	return nil
 }
 
+func (x *Activities) GetTotal() int64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
 type Notes struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Items         []*Note                `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
@@ -1616,7 +2532,7 @@ type Notes struct {
 
 func (x *Notes) Reset() {
 	*x = Notes{}
-	mi := &file_domain_proto_msgTypes[26]
+	mi := &file_domain_proto_msgTypes[38]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1628,7 +2544,7 @@ func (x *Notes) String() string {
 func (*Notes) ProtoMessage() {}
 
 func (x *Notes) ProtoReflect() protoreflect.Message {
-	mi := &file_domain_proto_msgTypes[26]
+	mi := &file_domain_proto_msgTypes[38]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1641,7 +2557,7 @@ func (x *Notes) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Notes.ProtoReflect.Descriptor instead.
 func (*Notes) Descriptor() ([]byte, []int) {
-	return file_domain_proto_rawDescGZIP(), []int{26}
+	return file_domain_proto_rawDescGZIP(), []int{38}
 }
 
 func (x *Notes) GetItems() []*Note {
@@ -1660,7 +2576,7 @@ type NoteParts struct {
 
 func (x *NoteParts) Reset() {
 	*x = NoteParts{}
-	mi := &file_domain_proto_msgTypes[27]
+	mi := &file_domain_proto_msgTypes[39]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1672,7 +2588,7 @@ func (x *NoteParts) String() string {
 func (*NoteParts) ProtoMessage() {}
 
 func (x *NoteParts) ProtoReflect() protoreflect.Message {
-	mi := &file_domain_proto_msgTypes[27]
+	mi := &file_domain_proto_msgTypes[39]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1685,7 +2601,7 @@ func (x *NoteParts) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use NoteParts.ProtoReflect.Descriptor instead.
 func (*NoteParts) Descriptor() ([]byte, []int) {
-	return file_domain_proto_rawDescGZIP(), []int{27}
+	return file_domain_proto_rawDescGZIP(), []int{39}
 }
 
 func (x *NoteParts) GetItems() []*NotePart {
@@ -1704,7 +2620,7 @@ type Tags struct {
 
 func (x *Tags) Reset() {
 	*x = Tags{}
-	mi := &file_domain_proto_msgTypes[28]
+	mi := &file_domain_proto_msgTypes[40]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1716,7 +2632,7 @@ func (x *Tags) String() string {
 func (*Tags) ProtoMessage() {}
 
 func (x *Tags) ProtoReflect() protoreflect.Message {
-	mi := &file_domain_proto_msgTypes[28]
+	mi := &file_domain_proto_msgTypes[40]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1729,7 +2645,7 @@ func (x *Tags) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Tags.ProtoReflect.Descriptor instead.
 func (*Tags) Descriptor() ([]byte, []int) {
-	return file_domain_proto_rawDescGZIP(), []int{28}
+	return file_domain_proto_rawDescGZIP(), []int{40}
 }
 
 func (x *Tags) GetItems() []*Tag {
@@ -1739,6 +2655,94 @@ func (x *Tags) GetItems() []*Tag {
 	return nil
 }
 
+type Workspaces struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Items         []*Workspace           `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Workspaces) Reset() {
+	*x = Workspaces{}
+	mi := &file_domain_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Workspaces) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Workspaces) ProtoMessage() {}
+
+func (x *Workspaces) ProtoReflect() protoreflect.Message {
+	mi := &file_domain_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Workspaces.ProtoReflect.Descriptor instead.
+func (*Workspaces) Descriptor() ([]byte, []int) {
+	return file_domain_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *Workspaces) GetItems() []*Workspace {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+type WorkspaceMembers struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Items         []*WorkspaceMember     `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WorkspaceMembers) Reset() {
+	*x = WorkspaceMembers{}
+	mi := &file_domain_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WorkspaceMembers) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WorkspaceMembers) ProtoMessage() {}
+
+func (x *WorkspaceMembers) ProtoReflect() protoreflect.Message {
+	mi := &file_domain_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WorkspaceMembers.ProtoReflect.Descriptor instead.
+func (*WorkspaceMembers) Descriptor() ([]byte, []int) {
+	return file_domain_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *WorkspaceMembers) GetItems() []*WorkspaceMember {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
 var File_domain_proto protoreflect.FileDescriptor
 
 const file_domain_proto_rawDesc = "" +
@@ -1789,28 +2793,54 @@ const file_domain_proto_rawDesc = "" +
 	"\x06userId\x18\x03 \x01(\tR\x06userId\"9\n" +
 	"\tUserTagId\x12\x14\n" +
 	"\x05tagId\x18\x01 \x01(\tR\x05tagId\x12\x16\n" +
-	"\x06userId\x18\x02 \x01(\tR\x06userId\"9\n" +
+	"\x06userId\x18\x02 \x01(\tR\x06userId\"K\n" +
+	"\x0fUserWorkspaceId\x12\x16\n" +
+	"\x06userId\x18\x01 \x01(\tR\x06userId\x12 \n" +
+	"\vworkspaceId\x18\x02 \x01(\tR\vworkspaceId\"9\n" +
 	"\tNoteTagId\x12\x16\n" +
 	"\x06noteId\x18\x01 \x01(\tR\x06noteId\x12\x14\n" +
 	"\x05tagId\x18\x02 \x01(\tR\x05tagId\"U\n" +
 	"\rNoteTagUserId\x12\x16\n" +
 	"\x06noteId\x18\x01 \x01(\tR\x06noteId\x12\x14\n" +
 	"\x05tagId\x18\x02 \x01(\tR\x05tagId\x12\x16\n" +
-	"\x06userId\x18\x03 \x01(\tR\x06userId\"\x8a\x01\n" +
+	"\x06userId\x18\x03 \x01(\tR\x06userId\"\x84\x02\n" +
 	"\x04User\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
 	"\x05login\x18\x02 \x01(\tR\x05login\x12\x14\n" +
 	"\x05email\x18\x03 \x01(\tR\x05email\x12\x14\n" +
 	"\x05about\x18\x04 \x01(\tR\x05about\x12\x14\n" +
 	"\x05photo\x18\x05 \x01(\tR\x05photo\x12\x1a\n" +
-	"\bpassword\x18\x06 \x01(\tR\bpassword\"\x8b\x01\n" +
+	"\bpassword\x18\x06 \x01(\tR\bpassword\x12$\n" +
+	"\remailVerified\x18\a \x01(\bR\remailVerified\x12\x12\n" +
+	"\x04role\x18\b \x01(\tR\x04role\x12\x1a\n" +
+	"\bdisabled\x18\t \x01(\bR\bdisabled\x12\"\n" +
+	"\fdiscoverable\x18\n" +
+	" \x01(\bR\fdiscoverable\"\xc5\x01\n" +
+	"\x17NotificationPreferences\x12 \n" +
+	"\vemailShares\x18\x01 \x01(\bR\vemailShares\x12$\n" +
+	"\remailComments\x18\x02 \x01(\bR\remailComments\x12$\n" +
+	"\remailMentions\x18\x03 \x01(\bR\remailMentions\x12$\n" +
+	"\remailSecurity\x18\x04 \x01(\bR\remailSecurity\x12\x16\n" +
+	"\x06digest\x18\x05 \x01(\tR\x06digest\"\xa1\x02\n" +
+	"\vPreferences\x12\x18\n" +
+	"\aversion\x18\x01 \x01(\x05R\aversion\x12\x14\n" +
+	"\x05theme\x18\x02 \x01(\tR\x05theme\x12\x16\n" +
+	"\x06locale\x18\x03 \x01(\tR\x06locale\x12\x1a\n" +
+	"\bnoteSort\x18\x04 \x01(\tR\bnoteSort\x12\x1e\n" +
+	"\n" +
+	"defaultTag\x18\x05 \x01(\tR\n" +
+	"defaultTag\x12.\n" +
+	"\x12trashRetentionDays\x18\x06 \x01(\x05R\x12trashRetentionDays\x12B\n" +
+	"\rnotifications\x18\a \x01(\v2\x1c.brz.NotificationPreferencesR\rnotifications\x12\x1a\n" +
+	"\brevision\x18\b \x01(\x03R\brevision\"\xad\x01\n" +
 	"\x03Tag\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
 	"\x05title\x18\x02 \x01(\tR\x05title\x12\x14\n" +
 	"\x05color\x18\x03 \x01(\tR\x05color\x12\x14\n" +
 	"\x05emoji\x18\x04 \x01(\tR\x05emoji\x12\x16\n" +
 	"\x06userId\x18\x05 \x01(\tR\x06userId\x12\x1a\n" +
-	"\bisPinned\x18\x06 \x01(\bR\bisPinned\"\xc8\x01\n" +
+	"\bisPinned\x18\x06 \x01(\bR\bisPinned\x12 \n" +
+	"\vworkspaceId\x18\a \x01(\tR\vworkspaceId\"\xc8\x01\n" +
 	"\x05Block\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
 	"\x04type\x18\x02 \x01(\tR\x04type\x12\x17\n" +
@@ -1820,7 +2850,41 @@ const file_domain_proto_rawDesc = "" +
 	"\n" +
 	"updated_at\x18\x05 \x01(\x03R\tupdatedAt\x12\x17\n" +
 	"\ais_used\x18\x06 \x01(\bR\x06isUsed\x12+\n" +
-	"\x04data\x18\a \x01(\v2\x17.google.protobuf.StructR\x04data\"\x9e\x02\n" +
+	"\x04data\x18\a \x01(\v2\x17.google.protobuf.StructR\x04data\"3\n" +
+	"\tTextRange\x12\x14\n" +
+	"\x05start\x18\x01 \x01(\x05R\x05start\x12\x10\n" +
+	"\x03end\x18\x02 \x01(\x05R\x03end\"\xcd\x02\n" +
+	"\aComment\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x16\n" +
+	"\x06noteId\x18\x02 \x01(\tR\x06noteId\x12\x18\n" +
+	"\ablockId\x18\x03 \x01(\tR\ablockId\x12\x1a\n" +
+	"\bthreadId\x18\x04 \x01(\tR\bthreadId\x12\x16\n" +
+	"\x06author\x18\x05 \x01(\tR\x06author\x12\x12\n" +
+	"\x04text\x18\x06 \x01(\tR\x04text\x12\x1a\n" +
+	"\bmentions\x18\a \x03(\tR\bmentions\x12&\n" +
+	"\x06anchor\x18\b \x01(\v2\x0e.brz.TextRangeR\x06anchor\x12\x1a\n" +
+	"\bresolved\x18\t \x01(\bR\bresolved\x12\x1a\n" +
+	"\borphaned\x18\n" +
+	" \x01(\bR\borphaned\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\v \x01(\x03R\tcreatedAt\x12\x1d\n" +
+	"\n" +
+	"updated_at\x18\f \x01(\x03R\tupdatedAt\"\xb3\x01\n" +
+	"\bActivity\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x16\n" +
+	"\x06noteId\x18\x02 \x01(\tR\x06noteId\x12\x14\n" +
+	"\x05actor\x18\x03 \x01(\tR\x05actor\x12\x16\n" +
+	"\x06action\x18\x04 \x01(\tR\x06action\x12\x18\n" +
+	"\ablockId\x18\x05 \x01(\tR\ablockId\x12\x18\n" +
+	"\asummary\x18\x06 \x01(\tR\asummary\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\a \x01(\x03R\tcreatedAt\"\x88\x01\n" +
+	"\fDeletedBlock\x12 \n" +
+	"\x05block\x18\x01 \x01(\v2\n" +
+	".brz.BlockR\x05block\x12\x1a\n" +
+	"\bposition\x18\x02 \x01(\x05R\bposition\x12\x1c\n" +
+	"\tdeletedAt\x18\x03 \x01(\x03R\tdeletedAt\x12\x1c\n" +
+	"\tdeletedBy\x18\x04 \x01(\tR\tdeletedBy\"\xc0\x02\n" +
 	"\x04Note\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
 	"\x05title\x18\x02 \x01(\tR\x05title\x12\x1d\n" +
@@ -1835,7 +2899,8 @@ const file_domain_proto_rawDesc = "" +
 	"\x06blocks\x18\t \x03(\tR\x06blocks\x12\x1a\n" +
 	"\bisPublic\x18\n" +
 	" \x01(\bR\bisPublic\x12\x16\n" +
-	"\x06isBlog\x18\v \x01(\bR\x06isBlog\"\xb4\x02\n" +
+	"\x06isBlog\x18\v \x01(\bR\x06isBlog\x12 \n" +
+	"\vworkspaceId\x18\f \x01(\tR\vworkspaceId\"\xd6\x02\n" +
 	"\x0eNoteWithBlocks\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
 	"\x05title\x18\x02 \x01(\tR\x05title\x12\x1d\n" +
@@ -1851,7 +2916,8 @@ const file_domain_proto_rawDesc = "" +
 	".brz.BlockR\x06blocks\x12\x1a\n" +
 	"\bisPublic\x18\n" +
 	" \x01(\bR\bisPublic\x12\x16\n" +
-	"\x06isBlog\x18\v \x01(\bR\x06isBlog\"\xd4\x01\n" +
+	"\x06isBlog\x18\v \x01(\bR\x06isBlog\x12 \n" +
+	"\vworkspaceId\x18\f \x01(\tR\vworkspaceId\"\xb1\x02\n" +
 	"\bNotePart\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
 	"\x05title\x18\x02 \x01(\tR\x05title\x12\x1a\n" +
@@ -1863,16 +2929,41 @@ const file_domain_proto_rawDesc = "" +
 	"\x04role\x18\x06 \x01(\tR\x04role\x12\x1a\n" +
 	"\bisPublic\x18\n" +
 	" \x01(\bR\bisPublic\x12\x16\n" +
-	"\x06isBlog\x18\v \x01(\bR\x06isBlog\"*\n" +
+	"\x06isBlog\x18\v \x01(\bR\x06isBlog\x12 \n" +
+	"\vworkspaceId\x18\f \x01(\tR\vworkspaceId\x12\x1a\n" +
+	"\bdaysLeft\x18\r \x01(\x05R\bdaysLeft\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x0e \x01(\x03R\tcreatedAt\"_\n" +
+	"\tWorkspace\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
+	"\x05title\x18\x02 \x01(\tR\x05title\x12\x18\n" +
+	"\aownerId\x18\x03 \x01(\tR\aownerId\x12\x12\n" +
+	"\x04role\x18\x04 \x01(\tR\x04role\"=\n" +
+	"\x0fWorkspaceMember\x12\x16\n" +
+	"\x06userId\x18\x01 \x01(\tR\x06userId\x12\x12\n" +
+	"\x04role\x18\x02 \x01(\tR\x04role\"*\n" +
 	"\x06Blocks\x12 \n" +
 	"\x05items\x18\x01 \x03(\v2\n" +
-	".brz.BlockR\x05items\"(\n" +
+	".brz.BlockR\x05items\"8\n" +
+	"\rDeletedBlocks\x12'\n" +
+	"\x05items\x18\x01 \x03(\v2\x11.brz.DeletedBlockR\x05items\".\n" +
+	"\bComments\x12\"\n" +
+	"\x05items\x18\x01 \x03(\v2\f.brz.CommentR\x05items\"G\n" +
+	"\n" +
+	"Activities\x12#\n" +
+	"\x05items\x18\x01 \x03(\v2\r.brz.ActivityR\x05items\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\x03R\x05total\"(\n" +
 	"\x05Notes\x12\x1f\n" +
 	"\x05items\x18\x01 \x03(\v2\t.brz.NoteR\x05items\"0\n" +
 	"\tNoteParts\x12#\n" +
 	"\x05items\x18\x01 \x03(\v2\r.brz.NotePartR\x05items\"&\n" +
 	"\x04Tags\x12\x1e\n" +
-	"\x05items\x18\x01 \x03(\v2\b.brz.TagR\x05itemsB,Z*github.com/autumnterror/breezynotes;brzrpcb\x06proto3"
+	"\x05items\x18\x01 \x03(\v2\b.brz.TagR\x05items\"2\n" +
+	"\n" +
+	"Workspaces\x12$\n" +
+	"\x05items\x18\x01 \x03(\v2\x0e.brz.WorkspaceR\x05items\">\n" +
+	"\x10WorkspaceMembers\x12*\n" +
+	"\x05items\x18\x01 \x03(\v2\x14.brz.WorkspaceMemberR\x05itemsB,Z*github.com/autumnterror/breezynotes;brzrpcb\x06proto3"
 
 var (
 	file_domain_proto_rawDescOnce sync.Once
@@ -1886,55 +2977,77 @@ func file_domain_proto_rawDescGZIP() []byte {
 	return file_domain_proto_rawDescData
 }
 
-var file_domain_proto_msgTypes = make([]protoimpl.MessageInfo, 29)
+var file_domain_proto_msgTypes = make([]protoimpl.MessageInfo, 43)
 var file_domain_proto_goTypes = []any{
-	(*BoolResponse)(nil),    // 0: brz.BoolResponse
-	(*StringResponse)(nil),  // 1: brz.StringResponse
-	(*String)(nil),          // 2: brz.String
-	(*Strings)(nil),         // 3: brz.Strings
-	(*Token)(nil),           // 4: brz.Token
-	(*Tokens)(nil),          // 5: brz.Tokens
-	(*UserId)(nil),          // 6: brz.UserId
-	(*NoteId)(nil),          // 7: brz.NoteId
-	(*BlockId)(nil),         // 8: brz.BlockId
-	(*TagId)(nil),           // 9: brz.TagId
-	(*Id)(nil),              // 10: brz.Id
-	(*Ids)(nil),             // 11: brz.Ids
-	(*Users)(nil),           // 12: brz.Users
-	(*UserNoteId)(nil),      // 13: brz.UserNoteId
-	(*NoteBlockId)(nil),     // 14: brz.NoteBlockId
-	(*NoteBlockUserId)(nil), // 15: brz.NoteBlockUserId
-	(*UserTagId)(nil),       // 16: brz.UserTagId
-	(*NoteTagId)(nil),       // 17: brz.NoteTagId
-	(*NoteTagUserId)(nil),   // 18: brz.NoteTagUserId
-	(*User)(nil),            // 19: brz.User
-	(*Tag)(nil),             // 20: brz.Tag
-	(*Block)(nil),           // 21: brz.Block
-	(*Note)(nil),            // 22: brz.Note
-	(*NoteWithBlocks)(nil),  // 23: brz.NoteWithBlocks
-	(*NotePart)(nil),        // 24: brz.NotePart
-	(*Blocks)(nil),          // 25: brz.Blocks
-	(*Notes)(nil),           // 26: brz.Notes
-	(*NoteParts)(nil),       // 27: brz.NoteParts
-	(*Tags)(nil),            // 28: brz.Tags
-	(*structpb.Struct)(nil), // 29: google.protobuf.Struct
+	(*BoolResponse)(nil),            // 0: brz.BoolResponse
+	(*StringResponse)(nil),          // 1: brz.StringResponse
+	(*String)(nil),                  // 2: brz.String
+	(*Strings)(nil),                 // 3: brz.Strings
+	(*Token)(nil),                   // 4: brz.Token
+	(*Tokens)(nil),                  // 5: brz.Tokens
+	(*UserId)(nil),                  // 6: brz.UserId
+	(*NoteId)(nil),                  // 7: brz.NoteId
+	(*BlockId)(nil),                 // 8: brz.BlockId
+	(*TagId)(nil),                   // 9: brz.TagId
+	(*Id)(nil),                      // 10: brz.Id
+	(*Ids)(nil),                     // 11: brz.Ids
+	(*Users)(nil),                   // 12: brz.Users
+	(*UserNoteId)(nil),              // 13: brz.UserNoteId
+	(*NoteBlockId)(nil),             // 14: brz.NoteBlockId
+	(*NoteBlockUserId)(nil),         // 15: brz.NoteBlockUserId
+	(*UserTagId)(nil),               // 16: brz.UserTagId
+	(*UserWorkspaceId)(nil),         // 17: brz.UserWorkspaceId
+	(*NoteTagId)(nil),               // 18: brz.NoteTagId
+	(*NoteTagUserId)(nil),           // 19: brz.NoteTagUserId
+	(*User)(nil),                    // 20: brz.User
+	(*NotificationPreferences)(nil), // 21: brz.NotificationPreferences
+	(*Preferences)(nil),             // 22: brz.Preferences
+	(*Tag)(nil),                     // 23: brz.Tag
+	(*Block)(nil),                   // 24: brz.Block
+	(*TextRange)(nil),               // 25: brz.TextRange
+	(*Comment)(nil),                 // 26: brz.Comment
+	(*Activity)(nil),                // 27: brz.Activity
+	(*DeletedBlock)(nil),            // 28: brz.DeletedBlock
+	(*Note)(nil),                    // 29: brz.Note
+	(*NoteWithBlocks)(nil),          // 30: brz.NoteWithBlocks
+	(*NotePart)(nil),                // 31: brz.NotePart
+	(*Workspace)(nil),               // 32: brz.Workspace
+	(*WorkspaceMember)(nil),         // 33: brz.WorkspaceMember
+	(*Blocks)(nil),                  // 34: brz.Blocks
+	(*DeletedBlocks)(nil),           // 35: brz.DeletedBlocks
+	(*Comments)(nil),                // 36: brz.Comments
+	(*Activities)(nil),              // 37: brz.Activities
+	(*Notes)(nil),                   // 38: brz.Notes
+	(*NoteParts)(nil),               // 39: brz.NoteParts
+	(*Tags)(nil),                    // 40: brz.Tags
+	(*Workspaces)(nil),              // 41: brz.Workspaces
+	(*WorkspaceMembers)(nil),        // 42: brz.WorkspaceMembers
+	(*structpb.Struct)(nil),         // 43: google.protobuf.Struct
 }
 var file_domain_proto_depIdxs = []int32{
-	19, // 0: brz.Users.users:type_name -> brz.User
-	29, // 1: brz.Block.data:type_name -> google.protobuf.Struct
-	20, // 2: brz.Note.tag:type_name -> brz.Tag
-	20, // 3: brz.NoteWithBlocks.tag:type_name -> brz.Tag
-	21, // 4: brz.NoteWithBlocks.blocks:type_name -> brz.Block
-	20, // 5: brz.NotePart.tag:type_name -> brz.Tag
-	21, // 6: brz.Blocks.items:type_name -> brz.Block
-	22, // 7: brz.Notes.items:type_name -> brz.Note
-	24, // 8: brz.NoteParts.items:type_name -> brz.NotePart
-	20, // 9: brz.Tags.items:type_name -> brz.Tag
-	10, // [10:10] is the sub-list for method output_type
-	10, // [10:10] is the sub-list for method input_type
-	10, // [10:10] is the sub-list for extension type_name
-	10, // [10:10] is the sub-list for extension extendee
-	0,  // [0:10] is the sub-list for field type_name
+	20, // 0: brz.Users.users:type_name -> brz.User
+	21, // 1: brz.Preferences.notifications:type_name -> brz.NotificationPreferences
+	43, // 2: brz.Block.data:type_name -> google.protobuf.Struct
+	25, // 3: brz.Comment.anchor:type_name -> brz.TextRange
+	24, // 4: brz.DeletedBlock.block:type_name -> brz.Block
+	23, // 5: brz.Note.tag:type_name -> brz.Tag
+	23, // 6: brz.NoteWithBlocks.tag:type_name -> brz.Tag
+	24, // 7: brz.NoteWithBlocks.blocks:type_name -> brz.Block
+	23, // 8: brz.NotePart.tag:type_name -> brz.Tag
+	24, // 9: brz.Blocks.items:type_name -> brz.Block
+	28, // 10: brz.DeletedBlocks.items:type_name -> brz.DeletedBlock
+	26, // 11: brz.Comments.items:type_name -> brz.Comment
+	27, // 12: brz.Activities.items:type_name -> brz.Activity
+	29, // 13: brz.Notes.items:type_name -> brz.Note
+	31, // 14: brz.NoteParts.items:type_name -> brz.NotePart
+	23, // 15: brz.Tags.items:type_name -> brz.Tag
+	32, // 16: brz.Workspaces.items:type_name -> brz.Workspace
+	33, // 17: brz.WorkspaceMembers.items:type_name -> brz.WorkspaceMember
+	18, // [18:18] is the sub-list for method output_type
+	18, // [18:18] is the sub-list for method input_type
+	18, // [18:18] is the sub-list for extension type_name
+	18, // [18:18] is the sub-list for extension extendee
+	0,  // [0:18] is the sub-list for field type_name
 }
 
 func init() { file_domain_proto_init() }
@@ -1948,7 +3061,7 @@ func file_domain_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_domain_proto_rawDesc), len(file_domain_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   29,
+			NumMessages:   43,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -748,29 +748,980 @@ func (x *CreateBlockRequest) GetNewId() string {
 	return ""
 }
 
+type ConvertHTMLRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Html  string                 `protobuf:"bytes,1,opt,name=html,proto3" json:"html,omitempty"`
+	// note where blocks are inserted at pos, empty if blocks are only converted
+	NoteId        string `protobuf:"bytes,2,opt,name=noteId,proto3" json:"noteId,omitempty"`
+	UserId        string `protobuf:"bytes,3,opt,name=userId,proto3" json:"userId,omitempty"`
+	Pos           int32  `protobuf:"varint,4,opt,name=pos,proto3" json:"pos,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConvertHTMLRequest) Reset() {
+	*x = ConvertHTMLRequest{}
+	mi := &file_notes_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConvertHTMLRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConvertHTMLRequest) ProtoMessage() {}
+
+func (x *ConvertHTMLRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_notes_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConvertHTMLRequest.ProtoReflect.Descriptor instead.
+func (*ConvertHTMLRequest) Descriptor() ([]byte, []int) {
+	return file_notes_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ConvertHTMLRequest) GetHtml() string {
+	if x != nil {
+		return x.Html
+	}
+	return ""
+}
+
+func (x *ConvertHTMLRequest) GetNoteId() string {
+	if x != nil {
+		return x.NoteId
+	}
+	return ""
+}
+
+func (x *ConvertHTMLRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ConvertHTMLRequest) GetPos() int32 {
+	if x != nil {
+		return x.Pos
+	}
+	return 0
+}
+
 type SearchRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	UserId        string                 `protobuf:"bytes,1,opt,name=userId,proto3" json:"userId,omitempty"`
-	Prompt        string                 `protobuf:"bytes,2,opt,name=prompt,proto3" json:"prompt,omitempty"`
+	Prompt        string                 `protobuf:"bytes,2,opt,name=prompt,proto3" json:"prompt,omitempty"`
+	WorkspaceId   string                 `protobuf:"bytes,3,opt,name=workspaceId,proto3" json:"workspaceId,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchRequest) Reset() {
+	*x = SearchRequest{}
+	mi := &file_notes_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchRequest) ProtoMessage() {}
+
+func (x *SearchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_notes_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchRequest.ProtoReflect.Descriptor instead.
+func (*SearchRequest) Descriptor() ([]byte, []int) {
+	return file_notes_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *SearchRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *SearchRequest) GetPrompt() string {
+	if x != nil {
+		return x.Prompt
+	}
+	return ""
+}
+
+func (x *SearchRequest) GetWorkspaceId() string {
+	if x != nil {
+		return x.WorkspaceId
+	}
+	return ""
+}
+
+type TrashRetentionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=userId,proto3" json:"userId,omitempty"`
+	Days          int32                  `protobuf:"varint,2,opt,name=days,proto3" json:"days,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TrashRetentionRequest) Reset() {
+	*x = TrashRetentionRequest{}
+	mi := &file_notes_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TrashRetentionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TrashRetentionRequest) ProtoMessage() {}
+
+func (x *TrashRetentionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_notes_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TrashRetentionRequest.ProtoReflect.Descriptor instead.
+func (*TrashRetentionRequest) Descriptor() ([]byte, []int) {
+	return file_notes_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *TrashRetentionRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *TrashRetentionRequest) GetDays() int32 {
+	if x != nil {
+		return x.Days
+	}
+	return 0
+}
+
+type TrashRetention struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Days          int32                  `protobuf:"varint,1,opt,name=days,proto3" json:"days,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TrashRetention) Reset() {
+	*x = TrashRetention{}
+	mi := &file_notes_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TrashRetention) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TrashRetention) ProtoMessage() {}
+
+func (x *TrashRetention) ProtoReflect() protoreflect.Message {
+	mi := &file_notes_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TrashRetention.ProtoReflect.Descriptor instead.
+func (*TrashRetention) Descriptor() ([]byte, []int) {
+	return file_notes_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *TrashRetention) GetDays() int32 {
+	if x != nil {
+		return x.Days
+	}
+	return 0
+}
+
+type CreateCommentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	NoteId        string                 `protobuf:"bytes,2,opt,name=noteId,proto3" json:"noteId,omitempty"`
+	BlockId       string                 `protobuf:"bytes,3,opt,name=blockId,proto3" json:"blockId,omitempty"`
+	ThreadId      string                 `protobuf:"bytes,4,opt,name=threadId,proto3" json:"threadId,omitempty"`
+	Text          string                 `protobuf:"bytes,5,opt,name=text,proto3" json:"text,omitempty"`
+	Mentions      []string               `protobuf:"bytes,6,rep,name=mentions,proto3" json:"mentions,omitempty"`
+	Anchor        *TextRange             `protobuf:"bytes,7,opt,name=anchor,proto3" json:"anchor,omitempty"`
+	UserId        string                 `protobuf:"bytes,8,opt,name=userId,proto3" json:"userId,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateCommentRequest) Reset() {
+	*x = CreateCommentRequest{}
+	mi := &file_notes_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateCommentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateCommentRequest) ProtoMessage() {}
+
+func (x *CreateCommentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_notes_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateCommentRequest.ProtoReflect.Descriptor instead.
+func (*CreateCommentRequest) Descriptor() ([]byte, []int) {
+	return file_notes_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *CreateCommentRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *CreateCommentRequest) GetNoteId() string {
+	if x != nil {
+		return x.NoteId
+	}
+	return ""
+}
+
+func (x *CreateCommentRequest) GetBlockId() string {
+	if x != nil {
+		return x.BlockId
+	}
+	return ""
+}
+
+func (x *CreateCommentRequest) GetThreadId() string {
+	if x != nil {
+		return x.ThreadId
+	}
+	return ""
+}
+
+func (x *CreateCommentRequest) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *CreateCommentRequest) GetMentions() []string {
+	if x != nil {
+		return x.Mentions
+	}
+	return nil
+}
+
+func (x *CreateCommentRequest) GetAnchor() *TextRange {
+	if x != nil {
+		return x.Anchor
+	}
+	return nil
+}
+
+func (x *CreateCommentRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type UpdateCommentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Text          string                 `protobuf:"bytes,2,opt,name=text,proto3" json:"text,omitempty"`
+	Mentions      []string               `protobuf:"bytes,3,rep,name=mentions,proto3" json:"mentions,omitempty"`
+	UserId        string                 `protobuf:"bytes,4,opt,name=userId,proto3" json:"userId,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateCommentRequest) Reset() {
+	*x = UpdateCommentRequest{}
+	mi := &file_notes_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateCommentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateCommentRequest) ProtoMessage() {}
+
+func (x *UpdateCommentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_notes_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateCommentRequest.ProtoReflect.Descriptor instead.
+func (*UpdateCommentRequest) Descriptor() ([]byte, []int) {
+	return file_notes_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *UpdateCommentRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *UpdateCommentRequest) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *UpdateCommentRequest) GetMentions() []string {
+	if x != nil {
+		return x.Mentions
+	}
+	return nil
+}
+
+func (x *UpdateCommentRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type UserCommentId struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=userId,proto3" json:"userId,omitempty"`
+	CommentId     string                 `protobuf:"bytes,2,opt,name=commentId,proto3" json:"commentId,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UserCommentId) Reset() {
+	*x = UserCommentId{}
+	mi := &file_notes_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UserCommentId) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UserCommentId) ProtoMessage() {}
+
+func (x *UserCommentId) ProtoReflect() protoreflect.Message {
+	mi := &file_notes_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UserCommentId.ProtoReflect.Descriptor instead.
+func (*UserCommentId) Descriptor() ([]byte, []int) {
+	return file_notes_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *UserCommentId) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *UserCommentId) GetCommentId() string {
+	if x != nil {
+		return x.CommentId
+	}
+	return ""
+}
+
+type ResolveThreadRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ThreadId      string                 `protobuf:"bytes,1,opt,name=threadId,proto3" json:"threadId,omitempty"`
+	Resolved      bool                   `protobuf:"varint,2,opt,name=resolved,proto3" json:"resolved,omitempty"`
+	UserId        string                 `protobuf:"bytes,3,opt,name=userId,proto3" json:"userId,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResolveThreadRequest) Reset() {
+	*x = ResolveThreadRequest{}
+	mi := &file_notes_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResolveThreadRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResolveThreadRequest) ProtoMessage() {}
+
+func (x *ResolveThreadRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_notes_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResolveThreadRequest.ProtoReflect.Descriptor instead.
+func (*ResolveThreadRequest) Descriptor() ([]byte, []int) {
+	return file_notes_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *ResolveThreadRequest) GetThreadId() string {
+	if x != nil {
+		return x.ThreadId
+	}
+	return ""
+}
+
+func (x *ResolveThreadRequest) GetResolved() bool {
+	if x != nil {
+		return x.Resolved
+	}
+	return false
+}
+
+func (x *ResolveThreadRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type NoteActivityRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	NoteId        string                 `protobuf:"bytes,1,opt,name=noteId,proto3" json:"noteId,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=userId,proto3" json:"userId,omitempty"`
+	Start         int32                  `protobuf:"varint,3,opt,name=start,proto3" json:"start,omitempty"`
+	End           int32                  `protobuf:"varint,4,opt,name=end,proto3" json:"end,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NoteActivityRequest) Reset() {
+	*x = NoteActivityRequest{}
+	mi := &file_notes_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NoteActivityRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NoteActivityRequest) ProtoMessage() {}
+
+func (x *NoteActivityRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_notes_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NoteActivityRequest.ProtoReflect.Descriptor instead.
+func (*NoteActivityRequest) Descriptor() ([]byte, []int) {
+	return file_notes_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *NoteActivityRequest) GetNoteId() string {
+	if x != nil {
+		return x.NoteId
+	}
+	return ""
+}
+
+func (x *NoteActivityRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *NoteActivityRequest) GetStart() int32 {
+	if x != nil {
+		return x.Start
+	}
+	return 0
+}
+
+func (x *NoteActivityRequest) GetEnd() int32 {
+	if x != nil {
+		return x.End
+	}
+	return 0
+}
+
+type ActivityFeedRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=userId,proto3" json:"userId,omitempty"`
+	Limit         int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ActivityFeedRequest) Reset() {
+	*x = ActivityFeedRequest{}
+	mi := &file_notes_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ActivityFeedRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ActivityFeedRequest) ProtoMessage() {}
+
+func (x *ActivityFeedRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_notes_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ActivityFeedRequest.ProtoReflect.Descriptor instead.
+func (*ActivityFeedRequest) Descriptor() ([]byte, []int) {
+	return file_notes_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *ActivityFeedRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ActivityFeedRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type UserStats struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Notes      int64                  `protobuf:"varint,1,opt,name=notes,proto3" json:"notes,omitempty"`
+	TrashNotes int64                  `protobuf:"varint,2,opt,name=trashNotes,proto3" json:"trashNotes,omitempty"`
+	Blocks     int64                  `protobuf:"varint,3,opt,name=blocks,proto3" json:"blocks,omitempty"`
+	// storageBytes size of notes and blocks in db, files are not counted
+	StorageBytes  int64 `protobuf:"varint,4,opt,name=storageBytes,proto3" json:"storageBytes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UserStats) Reset() {
+	*x = UserStats{}
+	mi := &file_notes_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UserStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UserStats) ProtoMessage() {}
+
+func (x *UserStats) ProtoReflect() protoreflect.Message {
+	mi := &file_notes_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UserStats.ProtoReflect.Descriptor instead.
+func (*UserStats) Descriptor() ([]byte, []int) {
+	return file_notes_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *UserStats) GetNotes() int64 {
+	if x != nil {
+		return x.Notes
+	}
+	return 0
+}
+
+func (x *UserStats) GetTrashNotes() int64 {
+	if x != nil {
+		return x.TrashNotes
+	}
+	return 0
+}
+
+func (x *UserStats) GetBlocks() int64 {
+	if x != nil {
+		return x.Blocks
+	}
+	return 0
+}
+
+func (x *UserStats) GetStorageBytes() int64 {
+	if x != nil {
+		return x.StorageBytes
+	}
+	return 0
+}
+
+type RenderNoteRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Note  *NoteWithBlocks        `protobuf:"bytes,1,opt,name=note,proto3" json:"note,omitempty"`
+	// format of result: "md" or "json" for BreezyNotes document format
+	Format        string `protobuf:"bytes,2,opt,name=format,proto3" json:"format,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RenderNoteRequest) Reset() {
+	*x = RenderNoteRequest{}
+	mi := &file_notes_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RenderNoteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RenderNoteRequest) ProtoMessage() {}
+
+func (x *RenderNoteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_notes_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RenderNoteRequest.ProtoReflect.Descriptor instead.
+func (*RenderNoteRequest) Descriptor() ([]byte, []int) {
+	return file_notes_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *RenderNoteRequest) GetNote() *NoteWithBlocks {
+	if x != nil {
+		return x.Note
+	}
+	return nil
+}
+
+func (x *RenderNoteRequest) GetFormat() string {
+	if x != nil {
+		return x.Format
+	}
+	return ""
+}
+
+type ExportNoteRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	UserId string                 `protobuf:"bytes,1,opt,name=userId,proto3" json:"userId,omitempty"`
+	NoteId string                 `protobuf:"bytes,2,opt,name=noteId,proto3" json:"noteId,omitempty"`
+	// html or pdf
+	Format string `protobuf:"bytes,3,opt,name=format,proto3" json:"format,omitempty"`
+	// content of stored pictures of note by src, they are embedded into document
+	Files         map[string][]byte `protobuf:"bytes,4,rep,name=files,proto3" json:"files,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportNoteRequest) Reset() {
+	*x = ExportNoteRequest{}
+	mi := &file_notes_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportNoteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportNoteRequest) ProtoMessage() {}
+
+func (x *ExportNoteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_notes_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportNoteRequest.ProtoReflect.Descriptor instead.
+func (*ExportNoteRequest) Descriptor() ([]byte, []int) {
+	return file_notes_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *ExportNoteRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ExportNoteRequest) GetNoteId() string {
+	if x != nil {
+		return x.NoteId
+	}
+	return ""
+}
+
+func (x *ExportNoteRequest) GetFormat() string {
+	if x != nil {
+		return x.Format
+	}
+	return ""
+}
+
+func (x *ExportNoteRequest) GetFiles() map[string][]byte {
+	if x != nil {
+		return x.Files
+	}
+	return nil
+}
+
+type ExportCollectionRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	UserId string                 `protobuf:"bytes,1,opt,name=userId,proto3" json:"userId,omitempty"`
+	// blog notes of author are exported
+	AuthorId string `protobuf:"bytes,2,opt,name=authorId,proto3" json:"authorId,omitempty"`
+	// name of author for metadata of book
+	AuthorName string `protobuf:"bytes,3,opt,name=authorName,proto3" json:"authorName,omitempty"`
+	// only epub now
+	Format        string `protobuf:"bytes,4,opt,name=format,proto3" json:"format,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportCollectionRequest) Reset() {
+	*x = ExportCollectionRequest{}
+	mi := &file_notes_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportCollectionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportCollectionRequest) ProtoMessage() {}
+
+func (x *ExportCollectionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_notes_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportCollectionRequest.ProtoReflect.Descriptor instead.
+func (*ExportCollectionRequest) Descriptor() ([]byte, []int) {
+	return file_notes_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *ExportCollectionRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ExportCollectionRequest) GetAuthorId() string {
+	if x != nil {
+		return x.AuthorId
+	}
+	return ""
+}
+
+func (x *ExportCollectionRequest) GetAuthorName() string {
+	if x != nil {
+		return x.AuthorName
+	}
+	return ""
+}
+
+func (x *ExportCollectionRequest) GetFormat() string {
+	if x != nil {
+		return x.Format
+	}
+	return ""
+}
+
+// ExportPart is piece of exported file, pieces of one file go in a row
+type ExportPart struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// name of file in archive, empty if export is one file
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Data []byte `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	// src of stored file which is content of named file, it is taken from storage of gateway
+	File          string `protobuf:"bytes,3,opt,name=file,proto3" json:"file,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportPart) Reset() {
+	*x = ExportPart{}
+	mi := &file_notes_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportPart) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportPart) ProtoMessage() {}
+
+func (x *ExportPart) ProtoReflect() protoreflect.Message {
+	mi := &file_notes_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportPart.ProtoReflect.Descriptor instead.
+func (*ExportPart) Descriptor() ([]byte, []int) {
+	return file_notes_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *ExportPart) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ExportPart) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *ExportPart) GetFile() string {
+	if x != nil {
+		return x.File
+	}
+	return ""
+}
+
+type ImportTagsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=userId,proto3" json:"userId,omitempty"`
+	Tags          []*Tag                 `protobuf:"bytes,2,rep,name=tags,proto3" json:"tags,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *SearchRequest) Reset() {
-	*x = SearchRequest{}
-	mi := &file_notes_proto_msgTypes[11]
+func (x *ImportTagsRequest) Reset() {
+	*x = ImportTagsRequest{}
+	mi := &file_notes_proto_msgTypes[26]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *SearchRequest) String() string {
+func (x *ImportTagsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SearchRequest) ProtoMessage() {}
+func (*ImportTagsRequest) ProtoMessage() {}
 
-func (x *SearchRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_notes_proto_msgTypes[11]
+func (x *ImportTagsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_notes_proto_msgTypes[26]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -781,25 +1732,269 @@ func (x *SearchRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SearchRequest.ProtoReflect.Descriptor instead.
-func (*SearchRequest) Descriptor() ([]byte, []int) {
-	return file_notes_proto_rawDescGZIP(), []int{11}
+// Deprecated: Use ImportTagsRequest.ProtoReflect.Descriptor instead.
+func (*ImportTagsRequest) Descriptor() ([]byte, []int) {
+	return file_notes_proto_rawDescGZIP(), []int{26}
 }
 
-func (x *SearchRequest) GetUserId() string {
+func (x *ImportTagsRequest) GetUserId() string {
 	if x != nil {
 		return x.UserId
 	}
 	return ""
 }
 
-func (x *SearchRequest) GetPrompt() string {
+func (x *ImportTagsRequest) GetTags() []*Tag {
 	if x != nil {
-		return x.Prompt
+		return x.Tags
+	}
+	return nil
+}
+
+type ImportNoteRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	UserId string                 `protobuf:"bytes,1,opt,name=userId,proto3" json:"userId,omitempty"`
+	Note   *NoteWithBlocks        `protobuf:"bytes,2,opt,name=note,proto3" json:"note,omitempty"`
+	// note is put to trash after import
+	Trash bool `protobuf:"varint,3,opt,name=trash,proto3" json:"trash,omitempty"`
+	// old tag id -> new tag id, from ImportReport of ImportTags
+	Tags map[string]string `protobuf:"bytes,4,rep,name=tags,proto3" json:"tags,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// id of created note, so caller can link to it before import. Empty is generated
+	NewId string `protobuf:"bytes,5,opt,name=newId,proto3" json:"newId,omitempty"`
+	// body of note in html, it is converted to blocks which are put after blocks of note.
+	// Links with download attribute become file blocks
+	Html          string `protobuf:"bytes,6,opt,name=html,proto3" json:"html,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportNoteRequest) Reset() {
+	*x = ImportNoteRequest{}
+	mi := &file_notes_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportNoteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportNoteRequest) ProtoMessage() {}
+
+func (x *ImportNoteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_notes_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportNoteRequest.ProtoReflect.Descriptor instead.
+func (*ImportNoteRequest) Descriptor() ([]byte, []int) {
+	return file_notes_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *ImportNoteRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ImportNoteRequest) GetNote() *NoteWithBlocks {
+	if x != nil {
+		return x.Note
+	}
+	return nil
+}
+
+func (x *ImportNoteRequest) GetTrash() bool {
+	if x != nil {
+		return x.Trash
+	}
+	return false
+}
+
+func (x *ImportNoteRequest) GetTags() map[string]string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *ImportNoteRequest) GetNewId() string {
+	if x != nil {
+		return x.NewId
+	}
+	return ""
+}
+
+func (x *ImportNoteRequest) GetHtml() string {
+	if x != nil {
+		return x.Html
+	}
+	return ""
+}
+
+type ImportConflict struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// tag_merged, tag_invalid, note_exists, block_type, block_invalid
+	Kind string `protobuf:"bytes,1,opt,name=kind,proto3" json:"kind,omitempty"`
+	// old id of tag, note or block
+	Id            string `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	Title         string `protobuf:"bytes,3,opt,name=title,proto3" json:"title,omitempty"`
+	Detail        string `protobuf:"bytes,4,opt,name=detail,proto3" json:"detail,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportConflict) Reset() {
+	*x = ImportConflict{}
+	mi := &file_notes_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportConflict) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportConflict) ProtoMessage() {}
+
+func (x *ImportConflict) ProtoReflect() protoreflect.Message {
+	mi := &file_notes_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportConflict.ProtoReflect.Descriptor instead.
+func (*ImportConflict) Descriptor() ([]byte, []int) {
+	return file_notes_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *ImportConflict) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *ImportConflict) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ImportConflict) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *ImportConflict) GetDetail() string {
+	if x != nil {
+		return x.Detail
 	}
 	return ""
 }
 
+type ImportReport struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// old id -> new id of imported tags, notes and blocks
+	Ids           map[string]string `protobuf:"bytes,1,rep,name=ids,proto3" json:"ids,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Notes         int32             `protobuf:"varint,2,opt,name=notes,proto3" json:"notes,omitempty"`
+	Trash         int32             `protobuf:"varint,3,opt,name=trash,proto3" json:"trash,omitempty"`
+	Blocks        int32             `protobuf:"varint,4,opt,name=blocks,proto3" json:"blocks,omitempty"`
+	Tags          int32             `protobuf:"varint,5,opt,name=tags,proto3" json:"tags,omitempty"`
+	Conflicts     []*ImportConflict `protobuf:"bytes,6,rep,name=conflicts,proto3" json:"conflicts,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportReport) Reset() {
+	*x = ImportReport{}
+	mi := &file_notes_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportReport) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportReport) ProtoMessage() {}
+
+func (x *ImportReport) ProtoReflect() protoreflect.Message {
+	mi := &file_notes_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportReport.ProtoReflect.Descriptor instead.
+func (*ImportReport) Descriptor() ([]byte, []int) {
+	return file_notes_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *ImportReport) GetIds() map[string]string {
+	if x != nil {
+		return x.Ids
+	}
+	return nil
+}
+
+func (x *ImportReport) GetNotes() int32 {
+	if x != nil {
+		return x.Notes
+	}
+	return 0
+}
+
+func (x *ImportReport) GetTrash() int32 {
+	if x != nil {
+		return x.Trash
+	}
+	return 0
+}
+
+func (x *ImportReport) GetBlocks() int32 {
+	if x != nil {
+		return x.Blocks
+	}
+	return 0
+}
+
+func (x *ImportReport) GetTags() int32 {
+	if x != nil {
+		return x.Tags
+	}
+	return 0
+}
+
+func (x *ImportReport) GetConflicts() []*ImportConflict {
+	if x != nil {
+		return x.Conflicts
+	}
+	return nil
+}
+
 var File_notes_proto protoreflect.FileDescriptor
 
 const file_notes_proto_rawDesc = "" +
@@ -856,10 +2051,109 @@ const file_notes_proto_rawDesc = "" +
 	"\x03pos\x18\x03 \x01(\x05R\x03pos\x12+\n" +
 	"\x04data\x18\x04 \x01(\v2\x17.google.protobuf.StructR\x04data\x12\x16\n" +
 	"\x06userId\x18\x05 \x01(\tR\x06userId\x12\x14\n" +
-	"\x05newId\x18\x06 \x01(\tR\x05newId\"?\n" +
+	"\x05newId\x18\x06 \x01(\tR\x05newId\"j\n" +
+	"\x12ConvertHTMLRequest\x12\x12\n" +
+	"\x04html\x18\x01 \x01(\tR\x04html\x12\x16\n" +
+	"\x06noteId\x18\x02 \x01(\tR\x06noteId\x12\x16\n" +
+	"\x06userId\x18\x03 \x01(\tR\x06userId\x12\x10\n" +
+	"\x03pos\x18\x04 \x01(\x05R\x03pos\"a\n" +
 	"\rSearchRequest\x12\x16\n" +
 	"\x06userId\x18\x01 \x01(\tR\x06userId\x12\x16\n" +
-	"\x06prompt\x18\x02 \x01(\tR\x06prompt2\xf4\x0f\n" +
+	"\x06prompt\x18\x02 \x01(\tR\x06prompt\x12 \n" +
+	"\vworkspaceId\x18\x03 \x01(\tR\vworkspaceId\"C\n" +
+	"\x15TrashRetentionRequest\x12\x16\n" +
+	"\x06userId\x18\x01 \x01(\tR\x06userId\x12\x12\n" +
+	"\x04days\x18\x02 \x01(\x05R\x04days\"$\n" +
+	"\x0eTrashRetention\x12\x12\n" +
+	"\x04days\x18\x01 \x01(\x05R\x04days\"\xe4\x01\n" +
+	"\x14CreateCommentRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x16\n" +
+	"\x06noteId\x18\x02 \x01(\tR\x06noteId\x12\x18\n" +
+	"\ablockId\x18\x03 \x01(\tR\ablockId\x12\x1a\n" +
+	"\bthreadId\x18\x04 \x01(\tR\bthreadId\x12\x12\n" +
+	"\x04text\x18\x05 \x01(\tR\x04text\x12\x1a\n" +
+	"\bmentions\x18\x06 \x03(\tR\bmentions\x12&\n" +
+	"\x06anchor\x18\a \x01(\v2\x0e.brz.TextRangeR\x06anchor\x12\x16\n" +
+	"\x06userId\x18\b \x01(\tR\x06userId\"n\n" +
+	"\x14UpdateCommentRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04text\x18\x02 \x01(\tR\x04text\x12\x1a\n" +
+	"\bmentions\x18\x03 \x03(\tR\bmentions\x12\x16\n" +
+	"\x06userId\x18\x04 \x01(\tR\x06userId\"E\n" +
+	"\rUserCommentId\x12\x16\n" +
+	"\x06userId\x18\x01 \x01(\tR\x06userId\x12\x1c\n" +
+	"\tcommentId\x18\x02 \x01(\tR\tcommentId\"f\n" +
+	"\x14ResolveThreadRequest\x12\x1a\n" +
+	"\bthreadId\x18\x01 \x01(\tR\bthreadId\x12\x1a\n" +
+	"\bresolved\x18\x02 \x01(\bR\bresolved\x12\x16\n" +
+	"\x06userId\x18\x03 \x01(\tR\x06userId\"m\n" +
+	"\x13NoteActivityRequest\x12\x16\n" +
+	"\x06noteId\x18\x01 \x01(\tR\x06noteId\x12\x16\n" +
+	"\x06userId\x18\x02 \x01(\tR\x06userId\x12\x14\n" +
+	"\x05start\x18\x03 \x01(\x05R\x05start\x12\x10\n" +
+	"\x03end\x18\x04 \x01(\x05R\x03end\"C\n" +
+	"\x13ActivityFeedRequest\x12\x16\n" +
+	"\x06userId\x18\x01 \x01(\tR\x06userId\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\"}\n" +
+	"\tUserStats\x12\x14\n" +
+	"\x05notes\x18\x01 \x01(\x03R\x05notes\x12\x1e\n" +
+	"\n" +
+	"trashNotes\x18\x02 \x01(\x03R\n" +
+	"trashNotes\x12\x16\n" +
+	"\x06blocks\x18\x03 \x01(\x03R\x06blocks\x12\"\n" +
+	"\fstorageBytes\x18\x04 \x01(\x03R\fstorageBytes\"T\n" +
+	"\x11RenderNoteRequest\x12'\n" +
+	"\x04note\x18\x01 \x01(\v2\x13.brz.NoteWithBlocksR\x04note\x12\x16\n" +
+	"\x06format\x18\x02 \x01(\tR\x06format\"\xce\x01\n" +
+	"\x11ExportNoteRequest\x12\x16\n" +
+	"\x06userId\x18\x01 \x01(\tR\x06userId\x12\x16\n" +
+	"\x06noteId\x18\x02 \x01(\tR\x06noteId\x12\x16\n" +
+	"\x06format\x18\x03 \x01(\tR\x06format\x127\n" +
+	"\x05files\x18\x04 \x03(\v2!.brz.ExportNoteRequest.FilesEntryR\x05files\x1a8\n" +
+	"\n" +
+	"FilesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\fR\x05value:\x028\x01\"\x85\x01\n" +
+	"\x17ExportCollectionRequest\x12\x16\n" +
+	"\x06userId\x18\x01 \x01(\tR\x06userId\x12\x1a\n" +
+	"\bauthorId\x18\x02 \x01(\tR\bauthorId\x12\x1e\n" +
+	"\n" +
+	"authorName\x18\x03 \x01(\tR\n" +
+	"authorName\x12\x16\n" +
+	"\x06format\x18\x04 \x01(\tR\x06format\"H\n" +
+	"\n" +
+	"ExportPart\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x12\n" +
+	"\x04data\x18\x02 \x01(\fR\x04data\x12\x12\n" +
+	"\x04file\x18\x03 \x01(\tR\x04file\"I\n" +
+	"\x11ImportTagsRequest\x12\x16\n" +
+	"\x06userId\x18\x01 \x01(\tR\x06userId\x12\x1c\n" +
+	"\x04tags\x18\x02 \x03(\v2\b.brz.TagR\x04tags\"\x83\x02\n" +
+	"\x11ImportNoteRequest\x12\x16\n" +
+	"\x06userId\x18\x01 \x01(\tR\x06userId\x12'\n" +
+	"\x04note\x18\x02 \x01(\v2\x13.brz.NoteWithBlocksR\x04note\x12\x14\n" +
+	"\x05trash\x18\x03 \x01(\bR\x05trash\x124\n" +
+	"\x04tags\x18\x04 \x03(\v2 .brz.ImportNoteRequest.TagsEntryR\x04tags\x12\x14\n" +
+	"\x05newId\x18\x05 \x01(\tR\x05newId\x12\x12\n" +
+	"\x04html\x18\x06 \x01(\tR\x04html\x1a7\n" +
+	"\tTagsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"b\n" +
+	"\x0eImportConflict\x12\x12\n" +
+	"\x04kind\x18\x01 \x01(\tR\x04kind\x12\x0e\n" +
+	"\x02id\x18\x02 \x01(\tR\x02id\x12\x14\n" +
+	"\x05title\x18\x03 \x01(\tR\x05title\x12\x16\n" +
+	"\x06detail\x18\x04 \x01(\tR\x06detail\"\xff\x01\n" +
+	"\fImportReport\x12,\n" +
+	"\x03ids\x18\x01 \x03(\v2\x1a.brz.ImportReport.IdsEntryR\x03ids\x12\x14\n" +
+	"\x05notes\x18\x02 \x01(\x05R\x05notes\x12\x14\n" +
+	"\x05trash\x18\x03 \x01(\x05R\x05trash\x12\x16\n" +
+	"\x06blocks\x18\x04 \x01(\x05R\x06blocks\x12\x12\n" +
+	"\x04tags\x18\x05 \x01(\x05R\x04tags\x121\n" +
+	"\tconflicts\x18\x06 \x03(\v2\x13.brz.ImportConflictR\tconflicts\x1a6\n" +
+	"\bIdsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x012\xf8\x1a\n" +
 	"\x10BlockNoteService\x12;\n" +
 	"\x13GetRegisteredBlocks\x12\x16.google.protobuf.Empty\x1a\f.brz.Strings\x12;\n" +
 	"\vDeleteBlock\x12\x14.brz.NoteBlockUserId\x1a\x16.google.protobuf.Empty\x12/\n" +
@@ -868,26 +2162,54 @@ const file_notes_proto_rawDesc = "" +
 	"\bGetBlock\x12\x14.brz.NoteBlockUserId\x1a\n" +
 	".brz.Block\x12H\n" +
 	"\x10ChangeBlockOrder\x12\x1c.brz.ChangeBlockOrderRequest\x1a\x16.google.protobuf.Empty\x12F\n" +
-	"\x0fChangeTypeBlock\x12\x1b.brz.ChangeTypeBlockRequest\x1a\x16.google.protobuf.Empty\x121\n" +
+	"\x0fChangeTypeBlock\x12\x1b.brz.ChangeTypeBlockRequest\x1a\x16.google.protobuf.Empty\x127\n" +
+	"\x10GetDeletedBlocks\x12\x0f.brz.UserNoteId\x1a\x12.brz.DeletedBlocks\x12<\n" +
+	"\fRestoreBlock\x12\x14.brz.NoteBlockUserId\x1a\x16.google.protobuf.Empty\x12B\n" +
+	"\rCreateComment\x12\x19.brz.CreateCommentRequest\x1a\x16.google.protobuf.Empty\x12-\n" +
+	"\vGetComments\x12\x0f.brz.UserNoteId\x1a\r.brz.Comments\x12B\n" +
+	"\rUpdateComment\x12\x19.brz.UpdateCommentRequest\x1a\x16.google.protobuf.Empty\x12;\n" +
+	"\rDeleteComment\x12\x12.brz.UserCommentId\x1a\x16.google.protobuf.Empty\x12B\n" +
+	"\rResolveThread\x12\x19.brz.ResolveThreadRequest\x1a\x16.google.protobuf.Empty\x12<\n" +
+	"\x0fGetNoteActivity\x12\x18.brz.NoteActivityRequest\x1a\x0f.brz.Activities\x12<\n" +
+	"\x0fGetActivityFeed\x12\x18.brz.ActivityFeedRequest\x1a\x0f.brz.Activities\x121\n" +
 	"\n" +
 	"CleanTrash\x12\v.brz.UserId\x1a\x16.google.protobuf.Empty\x126\n" +
 	"\vNoteToTrash\x12\x0f.brz.UserNoteId\x1a\x16.google.protobuf.Empty\x123\n" +
 	"\fNotesToTrash\x12\v.brz.UserId\x1a\x16.google.protobuf.Empty\x128\n" +
 	"\rNoteFromTrash\x12\x0f.brz.UserNoteId\x1a\x16.google.protobuf.Empty\x127\n" +
-	"\x0fFindNoteInTrash\x12\x0f.brz.UserNoteId\x1a\x13.brz.NoteWithBlocks\x12/\n" +
+	"\x0fFindNoteInTrash\x12\x0f.brz.UserNoteId\x1a\x13.brz.NoteWithBlocks\x12=\n" +
+	"\x12PurgeNoteFromTrash\x12\x0f.brz.UserNoteId\x1a\x16.google.protobuf.Empty\x12G\n" +
+	"\x11SetTrashRetention\x12\x1a.brz.TrashRetentionRequest\x1a\x16.google.protobuf.Empty\x125\n" +
+	"\x11GetTrashRetention\x12\v.brz.UserId\x1a\x13.brz.TrashRetention\x12/\n" +
 	"\aGetNote\x12\x0f.brz.UserNoteId\x1a\x13.brz.NoteWithBlocks\x12/\n" +
 	"\n" +
 	"CreateNote\x12\t.brz.Note\x1a\x16.google.protobuf.Empty\x12F\n" +
 	"\x0fChangeTitleNote\x12\x1b.brz.ChangeTitleNoteRequest\x1a\x16.google.protobuf.Empty\x12/\n" +
-	"\x12GetAllBlocksInNote\x12\f.brz.Strings\x1a\v.brz.Blocks\x12*\n" +
-	"\vGetAllNotes\x12\v.brz.UserId\x1a\x0e.brz.NoteParts\x12/\n" +
+	"\x12GetAllBlocksInNote\x12\f.brz.Strings\x1a\v.brz.Blocks\x123\n" +
+	"\vGetAllNotes\x12\x14.brz.UserWorkspaceId\x1a\x0e.brz.NoteParts\x12/\n" +
 	"\rGetNotesByTag\x12\x0e.brz.UserTagId\x1a\x0e.brz.NoteParts\x120\n" +
-	"\x11GetNotesFromTrash\x12\v.brz.UserId\x1a\x0e.brz.NoteParts\x12-\n" +
-	"\x06Search\x12\x12.brz.SearchRequest\x1a\r.brz.NotePart0\x01\x12:\n" +
+	"\x11GetNotesFromTrash\x12\v.brz.UserId\x1a\x0e.brz.NoteParts\x12+\n" +
+	"\fGetUserStats\x12\v.brz.UserId\x1a\x0e.brz.UserStats\x12:\n" +
+	"\x13RemoveUserFromNotes\x12\v.brz.UserId\x1a\x16.google.protobuf.Empty\x12)\n" +
+	"\fGetUserFiles\x12\v.brz.UserId\x1a\f.brz.Strings\x121\n" +
+	"\n" +
+	"RenderNote\x12\x16.brz.RenderNoteRequest\x1a\v.brz.String\x127\n" +
+	"\n" +
+	"ImportTags\x12\x16.brz.ImportTagsRequest\x1a\x11.brz.ImportReport\x127\n" +
+	"\n" +
+	"ImportNote\x12\x16.brz.ImportNoteRequest\x1a\x11.brz.ImportReport\x12+\n" +
+	"\x0fConvertMarkdown\x12\v.brz.String\x1a\v.brz.Blocks\x123\n" +
+	"\vConvertHTML\x12\x17.brz.ConvertHTMLRequest\x1a\v.brz.Blocks\x123\n" +
+	"\x0fConvertDocument\x12\v.brz.String\x1a\x13.brz.NoteWithBlocks\x128\n" +
+	"\x11GetDocumentSchema\x12\x16.google.protobuf.Empty\x1a\v.brz.String\x12-\n" +
+	"\x06Search\x12\x12.brz.SearchRequest\x1a\r.brz.NotePart0\x01\x127\n" +
+	"\n" +
+	"ExportNote\x12\x16.brz.ExportNoteRequest\x1a\x0f.brz.ExportPart0\x01\x12C\n" +
+	"\x10ExportCollection\x12\x1c.brz.ExportCollectionRequest\x1a\x0f.brz.ExportPart0\x01\x12:\n" +
 	"\fAddTagToNote\x12\x12.brz.NoteTagUserId\x1a\x16.google.protobuf.Empty\x12<\n" +
 	"\x11RemoveTagFromNote\x12\x0f.brz.UserNoteId\x1a\x16.google.protobuf.Empty\x12-\n" +
-	"\tCreateTag\x12\b.brz.Tag\x1a\x16.google.protobuf.Empty\x12'\n" +
-	"\rGetTagsByUser\x12\v.brz.UserId\x1a\t.brz.Tags\x12-\n" +
+	"\tCreateTag\x12\b.brz.Tag\x1a\x16.google.protobuf.Empty\x120\n" +
+	"\rGetTagsByUser\x12\x14.brz.UserWorkspaceId\x1a\t.brz.Tags\x12-\n" +
 	"\x13GetPinnedTagsByUser\x12\v.brz.UserId\x1a\t.brz.Tags\x12D\n" +
 	"\x0eUpdateTagTitle\x12\x1a.brz.UpdateTagTitleRequest\x1a\x16.google.protobuf.Empty\x12D\n" +
 	"\x0eUpdateTagColor\x12\x1a.brz.UpdateTagColorRequest\x1a\x16.google.protobuf.Empty\x12D\n" +
@@ -915,7 +2237,7 @@ func file_notes_proto_rawDescGZIP() []byte {
 	return file_notes_proto_rawDescData
 }
 
-var file_notes_proto_msgTypes = make([]protoimpl.MessageInfo, 12)
+var file_notes_proto_msgTypes = make([]protoimpl.MessageInfo, 33)
 var file_notes_proto_goTypes = []any{
 	(*ChangeBlockOrderRequest)(nil), // 0: brz.ChangeBlockOrderRequest
 	(*ChangeTypeBlockRequest)(nil),  // 1: brz.ChangeTypeBlockRequest
@@ -928,105 +2250,188 @@ var file_notes_proto_goTypes = []any{
 	(*ShareNoteRequest)(nil),        // 8: brz.ShareNoteRequest
 	(*ChangeUserRoleRequest)(nil),   // 9: brz.ChangeUserRoleRequest
 	(*CreateBlockRequest)(nil),      // 10: brz.CreateBlockRequest
-	(*SearchRequest)(nil),           // 11: brz.SearchRequest
-	(*structpb.Struct)(nil),         // 12: google.protobuf.Struct
-	(*emptypb.Empty)(nil),           // 13: google.protobuf.Empty
-	(*NoteBlockUserId)(nil),         // 14: brz.NoteBlockUserId
-	(*UserId)(nil),                  // 15: brz.UserId
-	(*UserNoteId)(nil),              // 16: brz.UserNoteId
-	(*Note)(nil),                    // 17: brz.Note
-	(*Strings)(nil),                 // 18: brz.Strings
-	(*UserTagId)(nil),               // 19: brz.UserTagId
-	(*NoteTagUserId)(nil),           // 20: brz.NoteTagUserId
-	(*Tag)(nil),                     // 21: brz.Tag
-	(*Id)(nil),                      // 22: brz.Id
-	(*Block)(nil),                   // 23: brz.Block
-	(*NoteWithBlocks)(nil),          // 24: brz.NoteWithBlocks
-	(*Blocks)(nil),                  // 25: brz.Blocks
-	(*NoteParts)(nil),               // 26: brz.NoteParts
-	(*NotePart)(nil),                // 27: brz.NotePart
-	(*Tags)(nil),                    // 28: brz.Tags
+	(*ConvertHTMLRequest)(nil),      // 11: brz.ConvertHTMLRequest
+	(*SearchRequest)(nil),           // 12: brz.SearchRequest
+	(*TrashRetentionRequest)(nil),   // 13: brz.TrashRetentionRequest
+	(*TrashRetention)(nil),          // 14: brz.TrashRetention
+	(*CreateCommentRequest)(nil),    // 15: brz.CreateCommentRequest
+	(*UpdateCommentRequest)(nil),    // 16: brz.UpdateCommentRequest
+	(*UserCommentId)(nil),           // 17: brz.UserCommentId
+	(*ResolveThreadRequest)(nil),    // 18: brz.ResolveThreadRequest
+	(*NoteActivityRequest)(nil),     // 19: brz.NoteActivityRequest
+	(*ActivityFeedRequest)(nil),     // 20: brz.ActivityFeedRequest
+	(*UserStats)(nil),               // 21: brz.UserStats
+	(*RenderNoteRequest)(nil),       // 22: brz.RenderNoteRequest
+	(*ExportNoteRequest)(nil),       // 23: brz.ExportNoteRequest
+	(*ExportCollectionRequest)(nil), // 24: brz.ExportCollectionRequest
+	(*ExportPart)(nil),              // 25: brz.ExportPart
+	(*ImportTagsRequest)(nil),       // 26: brz.ImportTagsRequest
+	(*ImportNoteRequest)(nil),       // 27: brz.ImportNoteRequest
+	(*ImportConflict)(nil),          // 28: brz.ImportConflict
+	(*ImportReport)(nil),            // 29: brz.ImportReport
+	nil,                             // 30: brz.ExportNoteRequest.FilesEntry
+	nil,                             // 31: brz.ImportNoteRequest.TagsEntry
+	nil,                             // 32: brz.ImportReport.IdsEntry
+	(*structpb.Struct)(nil),         // 33: google.protobuf.Struct
+	(*TextRange)(nil),               // 34: brz.TextRange
+	(*NoteWithBlocks)(nil),          // 35: brz.NoteWithBlocks
+	(*Tag)(nil),                     // 36: brz.Tag
+	(*emptypb.Empty)(nil),           // 37: google.protobuf.Empty
+	(*NoteBlockUserId)(nil),         // 38: brz.NoteBlockUserId
+	(*UserNoteId)(nil),              // 39: brz.UserNoteId
+	(*UserId)(nil),                  // 40: brz.UserId
+	(*Note)(nil),                    // 41: brz.Note
+	(*Strings)(nil),                 // 42: brz.Strings
+	(*UserWorkspaceId)(nil),         // 43: brz.UserWorkspaceId
+	(*UserTagId)(nil),               // 44: brz.UserTagId
+	(*String)(nil),                  // 45: brz.String
+	(*NoteTagUserId)(nil),           // 46: brz.NoteTagUserId
+	(*Id)(nil),                      // 47: brz.Id
+	(*Block)(nil),                   // 48: brz.Block
+	(*DeletedBlocks)(nil),           // 49: brz.DeletedBlocks
+	(*Comments)(nil),                // 50: brz.Comments
+	(*Activities)(nil),              // 51: brz.Activities
+	(*Blocks)(nil),                  // 52: brz.Blocks
+	(*NoteParts)(nil),               // 53: brz.NoteParts
+	(*NotePart)(nil),                // 54: brz.NotePart
+	(*Tags)(nil),                    // 55: brz.Tags
 }
 var file_notes_proto_depIdxs = []int32{
-	12, // 0: brz.OpBlockRequest.data:type_name -> google.protobuf.Struct
-	12, // 1: brz.CreateBlockRequest.data:type_name -> google.protobuf.Struct
-	13, // 2: brz.BlockNoteService.GetRegisteredBlocks:input_type -> google.protobuf.Empty
-	14, // 3: brz.BlockNoteService.DeleteBlock:input_type -> brz.NoteBlockUserId
-	10, // 4: brz.BlockNoteService.CreateBlock:input_type -> brz.CreateBlockRequest
-	2,  // 5: brz.BlockNoteService.OpBlock:input_type -> brz.OpBlockRequest
-	14, // 6: brz.BlockNoteService.GetBlock:input_type -> brz.NoteBlockUserId
-	0,  // 7: brz.BlockNoteService.ChangeBlockOrder:input_type -> brz.ChangeBlockOrderRequest
-	1,  // 8: brz.BlockNoteService.ChangeTypeBlock:input_type -> brz.ChangeTypeBlockRequest
-	15, // 9: brz.BlockNoteService.CleanTrash:input_type -> brz.UserId
-	16, // 10: brz.BlockNoteService.NoteToTrash:input_type -> brz.UserNoteId
-	15, // 11: brz.BlockNoteService.NotesToTrash:input_type -> brz.UserId
-	16, // 12: brz.BlockNoteService.NoteFromTrash:input_type -> brz.UserNoteId
-	16, // 13: brz.BlockNoteService.FindNoteInTrash:input_type -> brz.UserNoteId
-	16, // 14: brz.BlockNoteService.GetNote:input_type -> brz.UserNoteId
-	17, // 15: brz.BlockNoteService.CreateNote:input_type -> brz.Note
-	3,  // 16: brz.BlockNoteService.ChangeTitleNote:input_type -> brz.ChangeTitleNoteRequest
-	18, // 17: brz.BlockNoteService.GetAllBlocksInNote:input_type -> brz.Strings
-	15, // 18: brz.BlockNoteService.GetAllNotes:input_type -> brz.UserId
-	19, // 19: brz.BlockNoteService.GetNotesByTag:input_type -> brz.UserTagId
-	15, // 20: brz.BlockNoteService.GetNotesFromTrash:input_type -> brz.UserId
-	11, // 21: brz.BlockNoteService.Search:input_type -> brz.SearchRequest
-	20, // 22: brz.BlockNoteService.AddTagToNote:input_type -> brz.NoteTagUserId
-	16, // 23: brz.BlockNoteService.RemoveTagFromNote:input_type -> brz.UserNoteId
-	21, // 24: brz.BlockNoteService.CreateTag:input_type -> brz.Tag
-	15, // 25: brz.BlockNoteService.GetTagsByUser:input_type -> brz.UserId
-	15, // 26: brz.BlockNoteService.GetPinnedTagsByUser:input_type -> brz.UserId
-	4,  // 27: brz.BlockNoteService.UpdateTagTitle:input_type -> brz.UpdateTagTitleRequest
-	5,  // 28: brz.BlockNoteService.UpdateTagColor:input_type -> brz.UpdateTagColorRequest
-	6,  // 29: brz.BlockNoteService.UpdateTagEmoji:input_type -> brz.UpdateTagEmojiRequest
-	19, // 30: brz.BlockNoteService.UpdateTagPinned:input_type -> brz.UserTagId
-	19, // 31: brz.BlockNoteService.DeleteTag:input_type -> brz.UserTagId
-	15, // 32: brz.BlockNoteService.DeleteTags:input_type -> brz.UserId
-	8,  // 33: brz.BlockNoteService.ShareNote:input_type -> brz.ShareNoteRequest
-	16, // 34: brz.BlockNoteService.PublicNote:input_type -> brz.UserNoteId
-	16, // 35: brz.BlockNoteService.AddPublicNote:input_type -> brz.UserNoteId
-	16, // 36: brz.BlockNoteService.BlogNote:input_type -> brz.UserNoteId
-	13, // 37: brz.BlockNoteService.Healthz:input_type -> google.protobuf.Empty
-	18, // 38: brz.BlockNoteService.GetRegisteredBlocks:output_type -> brz.Strings
-	13, // 39: brz.BlockNoteService.DeleteBlock:output_type -> google.protobuf.Empty
-	22, // 40: brz.BlockNoteService.CreateBlock:output_type -> brz.Id
-	13, // 41: brz.BlockNoteService.OpBlock:output_type -> google.protobuf.Empty
-	23, // 42: brz.BlockNoteService.GetBlock:output_type -> brz.Block
-	13, // 43: brz.BlockNoteService.ChangeBlockOrder:output_type -> google.protobuf.Empty
-	13, // 44: brz.BlockNoteService.ChangeTypeBlock:output_type -> google.protobuf.Empty
-	13, // 45: brz.BlockNoteService.CleanTrash:output_type -> google.protobuf.Empty
-	13, // 46: brz.BlockNoteService.NoteToTrash:output_type -> google.protobuf.Empty
-	13, // 47: brz.BlockNoteService.NotesToTrash:output_type -> google.protobuf.Empty
-	13, // 48: brz.BlockNoteService.NoteFromTrash:output_type -> google.protobuf.Empty
-	24, // 49: brz.BlockNoteService.FindNoteInTrash:output_type -> brz.NoteWithBlocks
-	24, // 50: brz.BlockNoteService.GetNote:output_type -> brz.NoteWithBlocks
-	13, // 51: brz.BlockNoteService.CreateNote:output_type -> google.protobuf.Empty
-	13, // 52: brz.BlockNoteService.ChangeTitleNote:output_type -> google.protobuf.Empty
-	25, // 53: brz.BlockNoteService.GetAllBlocksInNote:output_type -> brz.Blocks
-	26, // 54: brz.BlockNoteService.GetAllNotes:output_type -> brz.NoteParts
-	26, // 55: brz.BlockNoteService.GetNotesByTag:output_type -> brz.NoteParts
-	26, // 56: brz.BlockNoteService.GetNotesFromTrash:output_type -> brz.NoteParts
-	27, // 57: brz.BlockNoteService.Search:output_type -> brz.NotePart
-	13, // 58: brz.BlockNoteService.AddTagToNote:output_type -> google.protobuf.Empty
-	13, // 59: brz.BlockNoteService.RemoveTagFromNote:output_type -> google.protobuf.Empty
-	13, // 60: brz.BlockNoteService.CreateTag:output_type -> google.protobuf.Empty
-	28, // 61: brz.BlockNoteService.GetTagsByUser:output_type -> brz.Tags
-	28, // 62: brz.BlockNoteService.GetPinnedTagsByUser:output_type -> brz.Tags
-	13, // 63: brz.BlockNoteService.UpdateTagTitle:output_type -> google.protobuf.Empty
-	13, // 64: brz.BlockNoteService.UpdateTagColor:output_type -> google.protobuf.Empty
-	13, // 65: brz.BlockNoteService.UpdateTagEmoji:output_type -> google.protobuf.Empty
-	13, // 66: brz.BlockNoteService.UpdateTagPinned:output_type -> google.protobuf.Empty
-	13, // 67: brz.BlockNoteService.DeleteTag:output_type -> google.protobuf.Empty
-	13, // 68: brz.BlockNoteService.DeleteTags:output_type -> google.protobuf.Empty
-	13, // 69: brz.BlockNoteService.ShareNote:output_type -> google.protobuf.Empty
-	13, // 70: brz.BlockNoteService.PublicNote:output_type -> google.protobuf.Empty
-	13, // 71: brz.BlockNoteService.AddPublicNote:output_type -> google.protobuf.Empty
-	13, // 72: brz.BlockNoteService.BlogNote:output_type -> google.protobuf.Empty
-	13, // 73: brz.BlockNoteService.Healthz:output_type -> google.protobuf.Empty
-	38, // [38:74] is the sub-list for method output_type
-	2,  // [2:38] is the sub-list for method input_type
-	2,  // [2:2] is the sub-list for extension type_name
-	2,  // [2:2] is the sub-list for extension extendee
-	0,  // [0:2] is the sub-list for field type_name
+	33, // 0: brz.OpBlockRequest.data:type_name -> google.protobuf.Struct
+	33, // 1: brz.CreateBlockRequest.data:type_name -> google.protobuf.Struct
+	34, // 2: brz.CreateCommentRequest.anchor:type_name -> brz.TextRange
+	35, // 3: brz.RenderNoteRequest.note:type_name -> brz.NoteWithBlocks
+	30, // 4: brz.ExportNoteRequest.files:type_name -> brz.ExportNoteRequest.FilesEntry
+	36, // 5: brz.ImportTagsRequest.tags:type_name -> brz.Tag
+	35, // 6: brz.ImportNoteRequest.note:type_name -> brz.NoteWithBlocks
+	31, // 7: brz.ImportNoteRequest.tags:type_name -> brz.ImportNoteRequest.TagsEntry
+	32, // 8: brz.ImportReport.ids:type_name -> brz.ImportReport.IdsEntry
+	28, // 9: brz.ImportReport.conflicts:type_name -> brz.ImportConflict
+	37, // 10: brz.BlockNoteService.GetRegisteredBlocks:input_type -> google.protobuf.Empty
+	38, // 11: brz.BlockNoteService.DeleteBlock:input_type -> brz.NoteBlockUserId
+	10, // 12: brz.BlockNoteService.CreateBlock:input_type -> brz.CreateBlockRequest
+	2,  // 13: brz.BlockNoteService.OpBlock:input_type -> brz.OpBlockRequest
+	38, // 14: brz.BlockNoteService.GetBlock:input_type -> brz.NoteBlockUserId
+	0,  // 15: brz.BlockNoteService.ChangeBlockOrder:input_type -> brz.ChangeBlockOrderRequest
+	1,  // 16: brz.BlockNoteService.ChangeTypeBlock:input_type -> brz.ChangeTypeBlockRequest
+	39, // 17: brz.BlockNoteService.GetDeletedBlocks:input_type -> brz.UserNoteId
+	38, // 18: brz.BlockNoteService.RestoreBlock:input_type -> brz.NoteBlockUserId
+	15, // 19: brz.BlockNoteService.CreateComment:input_type -> brz.CreateCommentRequest
+	39, // 20: brz.BlockNoteService.GetComments:input_type -> brz.UserNoteId
+	16, // 21: brz.BlockNoteService.UpdateComment:input_type -> brz.UpdateCommentRequest
+	17, // 22: brz.BlockNoteService.DeleteComment:input_type -> brz.UserCommentId
+	18, // 23: brz.BlockNoteService.ResolveThread:input_type -> brz.ResolveThreadRequest
+	19, // 24: brz.BlockNoteService.GetNoteActivity:input_type -> brz.NoteActivityRequest
+	20, // 25: brz.BlockNoteService.GetActivityFeed:input_type -> brz.ActivityFeedRequest
+	40, // 26: brz.BlockNoteService.CleanTrash:input_type -> brz.UserId
+	39, // 27: brz.BlockNoteService.NoteToTrash:input_type -> brz.UserNoteId
+	40, // 28: brz.BlockNoteService.NotesToTrash:input_type -> brz.UserId
+	39, // 29: brz.BlockNoteService.NoteFromTrash:input_type -> brz.UserNoteId
+	39, // 30: brz.BlockNoteService.FindNoteInTrash:input_type -> brz.UserNoteId
+	39, // 31: brz.BlockNoteService.PurgeNoteFromTrash:input_type -> brz.UserNoteId
+	13, // 32: brz.BlockNoteService.SetTrashRetention:input_type -> brz.TrashRetentionRequest
+	40, // 33: brz.BlockNoteService.GetTrashRetention:input_type -> brz.UserId
+	39, // 34: brz.BlockNoteService.GetNote:input_type -> brz.UserNoteId
+	41, // 35: brz.BlockNoteService.CreateNote:input_type -> brz.Note
+	3,  // 36: brz.BlockNoteService.ChangeTitleNote:input_type -> brz.ChangeTitleNoteRequest
+	42, // 37: brz.BlockNoteService.GetAllBlocksInNote:input_type -> brz.Strings
+	43, // 38: brz.BlockNoteService.GetAllNotes:input_type -> brz.UserWorkspaceId
+	44, // 39: brz.BlockNoteService.GetNotesByTag:input_type -> brz.UserTagId
+	40, // 40: brz.BlockNoteService.GetNotesFromTrash:input_type -> brz.UserId
+	40, // 41: brz.BlockNoteService.GetUserStats:input_type -> brz.UserId
+	40, // 42: brz.BlockNoteService.RemoveUserFromNotes:input_type -> brz.UserId
+	40, // 43: brz.BlockNoteService.GetUserFiles:input_type -> brz.UserId
+	22, // 44: brz.BlockNoteService.RenderNote:input_type -> brz.RenderNoteRequest
+	26, // 45: brz.BlockNoteService.ImportTags:input_type -> brz.ImportTagsRequest
+	27, // 46: brz.BlockNoteService.ImportNote:input_type -> brz.ImportNoteRequest
+	45, // 47: brz.BlockNoteService.ConvertMarkdown:input_type -> brz.String
+	11, // 48: brz.BlockNoteService.ConvertHTML:input_type -> brz.ConvertHTMLRequest
+	45, // 49: brz.BlockNoteService.ConvertDocument:input_type -> brz.String
+	37, // 50: brz.BlockNoteService.GetDocumentSchema:input_type -> google.protobuf.Empty
+	12, // 51: brz.BlockNoteService.Search:input_type -> brz.SearchRequest
+	23, // 52: brz.BlockNoteService.ExportNote:input_type -> brz.ExportNoteRequest
+	24, // 53: brz.BlockNoteService.ExportCollection:input_type -> brz.ExportCollectionRequest
+	46, // 54: brz.BlockNoteService.AddTagToNote:input_type -> brz.NoteTagUserId
+	39, // 55: brz.BlockNoteService.RemoveTagFromNote:input_type -> brz.UserNoteId
+	36, // 56: brz.BlockNoteService.CreateTag:input_type -> brz.Tag
+	43, // 57: brz.BlockNoteService.GetTagsByUser:input_type -> brz.UserWorkspaceId
+	40, // 58: brz.BlockNoteService.GetPinnedTagsByUser:input_type -> brz.UserId
+	4,  // 59: brz.BlockNoteService.UpdateTagTitle:input_type -> brz.UpdateTagTitleRequest
+	5,  // 60: brz.BlockNoteService.UpdateTagColor:input_type -> brz.UpdateTagColorRequest
+	6,  // 61: brz.BlockNoteService.UpdateTagEmoji:input_type -> brz.UpdateTagEmojiRequest
+	44, // 62: brz.BlockNoteService.UpdateTagPinned:input_type -> brz.UserTagId
+	44, // 63: brz.BlockNoteService.DeleteTag:input_type -> brz.UserTagId
+	40, // 64: brz.BlockNoteService.DeleteTags:input_type -> brz.UserId
+	8,  // 65: brz.BlockNoteService.ShareNote:input_type -> brz.ShareNoteRequest
+	39, // 66: brz.BlockNoteService.PublicNote:input_type -> brz.UserNoteId
+	39, // 67: brz.BlockNoteService.AddPublicNote:input_type -> brz.UserNoteId
+	39, // 68: brz.BlockNoteService.BlogNote:input_type -> brz.UserNoteId
+	37, // 69: brz.BlockNoteService.Healthz:input_type -> google.protobuf.Empty
+	42, // 70: brz.BlockNoteService.GetRegisteredBlocks:output_type -> brz.Strings
+	37, // 71: brz.BlockNoteService.DeleteBlock:output_type -> google.protobuf.Empty
+	47, // 72: brz.BlockNoteService.CreateBlock:output_type -> brz.Id
+	37, // 73: brz.BlockNoteService.OpBlock:output_type -> google.protobuf.Empty
+	48, // 74: brz.BlockNoteService.GetBlock:output_type -> brz.Block
+	37, // 75: brz.BlockNoteService.ChangeBlockOrder:output_type -> google.protobuf.Empty
+	37, // 76: brz.BlockNoteService.ChangeTypeBlock:output_type -> google.protobuf.Empty
+	49, // 77: brz.BlockNoteService.GetDeletedBlocks:output_type -> brz.DeletedBlocks
+	37, // 78: brz.BlockNoteService.RestoreBlock:output_type -> google.protobuf.Empty
+	37, // 79: brz.BlockNoteService.CreateComment:output_type -> google.protobuf.Empty
+	50, // 80: brz.BlockNoteService.GetComments:output_type -> brz.Comments
+	37, // 81: brz.BlockNoteService.UpdateComment:output_type -> google.protobuf.Empty
+	37, // 82: brz.BlockNoteService.DeleteComment:output_type -> google.protobuf.Empty
+	37, // 83: brz.BlockNoteService.ResolveThread:output_type -> google.protobuf.Empty
+	51, // 84: brz.BlockNoteService.GetNoteActivity:output_type -> brz.Activities
+	51, // 85: brz.BlockNoteService.GetActivityFeed:output_type -> brz.Activities
+	37, // 86: brz.BlockNoteService.CleanTrash:output_type -> google.protobuf.Empty
+	37, // 87: brz.BlockNoteService.NoteToTrash:output_type -> google.protobuf.Empty
+	37, // 88: brz.BlockNoteService.NotesToTrash:output_type -> google.protobuf.Empty
+	37, // 89: brz.BlockNoteService.NoteFromTrash:output_type -> google.protobuf.Empty
+	35, // 90: brz.BlockNoteService.FindNoteInTrash:output_type -> brz.NoteWithBlocks
+	37, // 91: brz.BlockNoteService.PurgeNoteFromTrash:output_type -> google.protobuf.Empty
+	37, // 92: brz.BlockNoteService.SetTrashRetention:output_type -> google.protobuf.Empty
+	14, // 93: brz.BlockNoteService.GetTrashRetention:output_type -> brz.TrashRetention
+	35, // 94: brz.BlockNoteService.GetNote:output_type -> brz.NoteWithBlocks
+	37, // 95: brz.BlockNoteService.CreateNote:output_type -> google.protobuf.Empty
+	37, // 96: brz.BlockNoteService.ChangeTitleNote:output_type -> google.protobuf.Empty
+	52, // 97: brz.BlockNoteService.GetAllBlocksInNote:output_type -> brz.Blocks
+	53, // 98: brz.BlockNoteService.GetAllNotes:output_type -> brz.NoteParts
+	53, // 99: brz.BlockNoteService.GetNotesByTag:output_type -> brz.NoteParts
+	53, // 100: brz.BlockNoteService.GetNotesFromTrash:output_type -> brz.NoteParts
+	21, // 101: brz.BlockNoteService.GetUserStats:output_type -> brz.UserStats
+	37, // 102: brz.BlockNoteService.RemoveUserFromNotes:output_type -> google.protobuf.Empty
+	42, // 103: brz.BlockNoteService.GetUserFiles:output_type -> brz.Strings
+	45, // 104: brz.BlockNoteService.RenderNote:output_type -> brz.String
+	29, // 105: brz.BlockNoteService.ImportTags:output_type -> brz.ImportReport
+	29, // 106: brz.BlockNoteService.ImportNote:output_type -> brz.ImportReport
+	52, // 107: brz.BlockNoteService.ConvertMarkdown:output_type -> brz.Blocks
+	52, // 108: brz.BlockNoteService.ConvertHTML:output_type -> brz.Blocks
+	35, // 109: brz.BlockNoteService.ConvertDocument:output_type -> brz.NoteWithBlocks
+	45, // 110: brz.BlockNoteService.GetDocumentSchema:output_type -> brz.String
+	54, // 111: brz.BlockNoteService.Search:output_type -> brz.NotePart
+	25, // 112: brz.BlockNoteService.ExportNote:output_type -> brz.ExportPart
+	25, // 113: brz.BlockNoteService.ExportCollection:output_type -> brz.ExportPart
+	37, // 114: brz.BlockNoteService.AddTagToNote:output_type -> google.protobuf.Empty
+	37, // 115: brz.BlockNoteService.RemoveTagFromNote:output_type -> google.protobuf.Empty
+	37, // 116: brz.BlockNoteService.CreateTag:output_type -> google.protobuf.Empty
+	55, // 117: brz.BlockNoteService.GetTagsByUser:output_type -> brz.Tags
+	55, // 118: brz.BlockNoteService.GetPinnedTagsByUser:output_type -> brz.Tags
+	37, // 119: brz.BlockNoteService.UpdateTagTitle:output_type -> google.protobuf.Empty
+	37, // 120: brz.BlockNoteService.UpdateTagColor:output_type -> google.protobuf.Empty
+	37, // 121: brz.BlockNoteService.UpdateTagEmoji:output_type -> google.protobuf.Empty
+	37, // 122: brz.BlockNoteService.UpdateTagPinned:output_type -> google.protobuf.Empty
+	37, // 123: brz.BlockNoteService.DeleteTag:output_type -> google.protobuf.Empty
+	37, // 124: brz.BlockNoteService.DeleteTags:output_type -> google.protobuf.Empty
+	37, // 125: brz.BlockNoteService.ShareNote:output_type -> google.protobuf.Empty
+	37, // 126: brz.BlockNoteService.PublicNote:output_type -> google.protobuf.Empty
+	37, // 127: brz.BlockNoteService.AddPublicNote:output_type -> google.protobuf.Empty
+	37, // 128: brz.BlockNoteService.BlogNote:output_type -> google.protobuf.Empty
+	37, // 129: brz.BlockNoteService.Healthz:output_type -> google.protobuf.Empty
+	70, // [70:130] is the sub-list for method output_type
+	10, // [10:70] is the sub-list for method input_type
+	10, // [10:10] is the sub-list for extension type_name
+	10, // [10:10] is the sub-list for extension extendee
+	0,  // [0:10] is the sub-list for field type_name
 }
 
 func init() { file_notes_proto_init() }
@@ -1041,7 +2446,7 @@ func file_notes_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_notes_proto_rawDesc), len(file_notes_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   12,
+			NumMessages:   33,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
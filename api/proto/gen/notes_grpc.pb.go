@@ -27,11 +27,23 @@ const (
 	BlockNoteService_GetBlock_FullMethodName            = "/brz.BlockNoteService/GetBlock"
 	BlockNoteService_ChangeBlockOrder_FullMethodName    = "/brz.BlockNoteService/ChangeBlockOrder"
 	BlockNoteService_ChangeTypeBlock_FullMethodName     = "/brz.BlockNoteService/ChangeTypeBlock"
+	BlockNoteService_GetDeletedBlocks_FullMethodName    = "/brz.BlockNoteService/GetDeletedBlocks"
+	BlockNoteService_RestoreBlock_FullMethodName        = "/brz.BlockNoteService/RestoreBlock"
+	BlockNoteService_CreateComment_FullMethodName       = "/brz.BlockNoteService/CreateComment"
+	BlockNoteService_GetComments_FullMethodName         = "/brz.BlockNoteService/GetComments"
+	BlockNoteService_UpdateComment_FullMethodName       = "/brz.BlockNoteService/UpdateComment"
+	BlockNoteService_DeleteComment_FullMethodName       = "/brz.BlockNoteService/DeleteComment"
+	BlockNoteService_ResolveThread_FullMethodName       = "/brz.BlockNoteService/ResolveThread"
+	BlockNoteService_GetNoteActivity_FullMethodName     = "/brz.BlockNoteService/GetNoteActivity"
+	BlockNoteService_GetActivityFeed_FullMethodName     = "/brz.BlockNoteService/GetActivityFeed"
 	BlockNoteService_CleanTrash_FullMethodName          = "/brz.BlockNoteService/CleanTrash"
 	BlockNoteService_NoteToTrash_FullMethodName         = "/brz.BlockNoteService/NoteToTrash"
 	BlockNoteService_NotesToTrash_FullMethodName        = "/brz.BlockNoteService/NotesToTrash"
 	BlockNoteService_NoteFromTrash_FullMethodName       = "/brz.BlockNoteService/NoteFromTrash"
 	BlockNoteService_FindNoteInTrash_FullMethodName     = "/brz.BlockNoteService/FindNoteInTrash"
+	BlockNoteService_PurgeNoteFromTrash_FullMethodName  = "/brz.BlockNoteService/PurgeNoteFromTrash"
+	BlockNoteService_SetTrashRetention_FullMethodName   = "/brz.BlockNoteService/SetTrashRetention"
+	BlockNoteService_GetTrashRetention_FullMethodName   = "/brz.BlockNoteService/GetTrashRetention"
 	BlockNoteService_GetNote_FullMethodName             = "/brz.BlockNoteService/GetNote"
 	BlockNoteService_CreateNote_FullMethodName          = "/brz.BlockNoteService/CreateNote"
 	BlockNoteService_ChangeTitleNote_FullMethodName     = "/brz.BlockNoteService/ChangeTitleNote"
@@ -39,7 +51,19 @@ const (
 	BlockNoteService_GetAllNotes_FullMethodName         = "/brz.BlockNoteService/GetAllNotes"
 	BlockNoteService_GetNotesByTag_FullMethodName       = "/brz.BlockNoteService/GetNotesByTag"
 	BlockNoteService_GetNotesFromTrash_FullMethodName   = "/brz.BlockNoteService/GetNotesFromTrash"
+	BlockNoteService_GetUserStats_FullMethodName        = "/brz.BlockNoteService/GetUserStats"
+	BlockNoteService_RemoveUserFromNotes_FullMethodName = "/brz.BlockNoteService/RemoveUserFromNotes"
+	BlockNoteService_GetUserFiles_FullMethodName        = "/brz.BlockNoteService/GetUserFiles"
+	BlockNoteService_RenderNote_FullMethodName          = "/brz.BlockNoteService/RenderNote"
+	BlockNoteService_ImportTags_FullMethodName          = "/brz.BlockNoteService/ImportTags"
+	BlockNoteService_ImportNote_FullMethodName          = "/brz.BlockNoteService/ImportNote"
+	BlockNoteService_ConvertMarkdown_FullMethodName     = "/brz.BlockNoteService/ConvertMarkdown"
+	BlockNoteService_ConvertHTML_FullMethodName         = "/brz.BlockNoteService/ConvertHTML"
+	BlockNoteService_ConvertDocument_FullMethodName     = "/brz.BlockNoteService/ConvertDocument"
+	BlockNoteService_GetDocumentSchema_FullMethodName   = "/brz.BlockNoteService/GetDocumentSchema"
 	BlockNoteService_Search_FullMethodName              = "/brz.BlockNoteService/Search"
+	BlockNoteService_ExportNote_FullMethodName          = "/brz.BlockNoteService/ExportNote"
+	BlockNoteService_ExportCollection_FullMethodName    = "/brz.BlockNoteService/ExportCollection"
 	BlockNoteService_AddTagToNote_FullMethodName        = "/brz.BlockNoteService/AddTagToNote"
 	BlockNoteService_RemoveTagFromNote_FullMethodName   = "/brz.BlockNoteService/RemoveTagFromNote"
 	BlockNoteService_CreateTag_FullMethodName           = "/brz.BlockNoteService/CreateTag"
@@ -72,24 +96,61 @@ type BlockNoteServiceClient interface {
 	// rpc GetBlockAsFirst(BlockId) returns (StringResponse);
 	ChangeBlockOrder(ctx context.Context, in *ChangeBlockOrderRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	ChangeTypeBlock(ctx context.Context, in *ChangeTypeBlockRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	GetDeletedBlocks(ctx context.Context, in *UserNoteId, opts ...grpc.CallOption) (*DeletedBlocks, error)
+	RestoreBlock(ctx context.Context, in *NoteBlockUserId, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	CreateComment(ctx context.Context, in *CreateCommentRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	GetComments(ctx context.Context, in *UserNoteId, opts ...grpc.CallOption) (*Comments, error)
+	UpdateComment(ctx context.Context, in *UpdateCommentRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	DeleteComment(ctx context.Context, in *UserCommentId, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	ResolveThread(ctx context.Context, in *ResolveThreadRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	GetNoteActivity(ctx context.Context, in *NoteActivityRequest, opts ...grpc.CallOption) (*Activities, error)
+	GetActivityFeed(ctx context.Context, in *ActivityFeedRequest, opts ...grpc.CallOption) (*Activities, error)
 	CleanTrash(ctx context.Context, in *UserId, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	NoteToTrash(ctx context.Context, in *UserNoteId, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	NotesToTrash(ctx context.Context, in *UserId, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	NoteFromTrash(ctx context.Context, in *UserNoteId, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	FindNoteInTrash(ctx context.Context, in *UserNoteId, opts ...grpc.CallOption) (*NoteWithBlocks, error)
+	PurgeNoteFromTrash(ctx context.Context, in *UserNoteId, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	SetTrashRetention(ctx context.Context, in *TrashRetentionRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// GetTrashRetention return retention set by user, 0 is retention of server
+	GetTrashRetention(ctx context.Context, in *UserId, opts ...grpc.CallOption) (*TrashRetention, error)
 	GetNote(ctx context.Context, in *UserNoteId, opts ...grpc.CallOption) (*NoteWithBlocks, error)
 	CreateNote(ctx context.Context, in *Note, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	// rpc UpdateNoteTitle(UpdateNoteTitleRequest) returns (google.protobuf.Empty);
 	ChangeTitleNote(ctx context.Context, in *ChangeTitleNoteRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	GetAllBlocksInNote(ctx context.Context, in *Strings, opts ...grpc.CallOption) (*Blocks, error)
-	GetAllNotes(ctx context.Context, in *UserId, opts ...grpc.CallOption) (*NoteParts, error)
+	GetAllNotes(ctx context.Context, in *UserWorkspaceId, opts ...grpc.CallOption) (*NoteParts, error)
 	GetNotesByTag(ctx context.Context, in *UserTagId, opts ...grpc.CallOption) (*NoteParts, error)
 	GetNotesFromTrash(ctx context.Context, in *UserId, opts ...grpc.CallOption) (*NoteParts, error)
+	// GetUserStats is for admins, gateway checks rights
+	GetUserStats(ctx context.Context, in *UserId, opts ...grpc.CallOption) (*UserStats, error)
+	// RemoveUserFromNotes pull user from editors and readers of all notes, used when account is deleted
+	RemoveUserFromNotes(ctx context.Context, in *UserId, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// GetUserFiles return names of local files and images used only in notes of user
+	GetUserFiles(ctx context.Context, in *UserId, opts ...grpc.CallOption) (*Strings, error)
+	// RenderNote render given note, so it works for notes from trash too
+	RenderNote(ctx context.Context, in *RenderNoteRequest, opts ...grpc.CallOption) (*String, error)
+	// ImportTags create tags of archive for user, tags with same title as existing are merged
+	ImportTags(ctx context.Context, in *ImportTagsRequest, opts ...grpc.CallOption) (*ImportReport, error)
+	// ImportNote create note with blocks under new ids for user, timestamps are kept
+	ImportNote(ctx context.Context, in *ImportNoteRequest, opts ...grpc.CallOption) (*ImportReport, error)
+	// ConvertMarkdown convert markdown to blocks with type and data, blocks are not saved
+	ConvertMarkdown(ctx context.Context, in *String, opts ...grpc.CallOption) (*Blocks, error)
+	// ConvertHTML convert html to blocks with type and data. If noteId is set, blocks are inserted into note
+	ConvertHTML(ctx context.Context, in *ConvertHTMLRequest, opts ...grpc.CallOption) (*Blocks, error)
+	// ConvertDocument check note in BreezyNotes document format and convert it, note is not saved
+	ConvertDocument(ctx context.Context, in *String, opts ...grpc.CallOption) (*NoteWithBlocks, error)
+	// GetDocumentSchema return JSON schema of BreezyNotes document format
+	GetDocumentSchema(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*String, error)
 	Search(ctx context.Context, in *SearchRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[NotePart], error)
+	// ExportNote render note to standalone document
+	ExportNote(ctx context.Context, in *ExportNoteRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ExportPart], error)
+	// ExportCollection render blog notes of author to book, files of book go as named parts
+	ExportCollection(ctx context.Context, in *ExportCollectionRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ExportPart], error)
 	AddTagToNote(ctx context.Context, in *NoteTagUserId, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	RemoveTagFromNote(ctx context.Context, in *UserNoteId, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	CreateTag(ctx context.Context, in *Tag, opts ...grpc.CallOption) (*emptypb.Empty, error)
-	GetTagsByUser(ctx context.Context, in *UserId, opts ...grpc.CallOption) (*Tags, error)
+	GetTagsByUser(ctx context.Context, in *UserWorkspaceId, opts ...grpc.CallOption) (*Tags, error)
 	GetPinnedTagsByUser(ctx context.Context, in *UserId, opts ...grpc.CallOption) (*Tags, error)
 	// rpc GetTag(UserTagId) returns (Tag);
 	UpdateTagTitle(ctx context.Context, in *UpdateTagTitleRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
@@ -183,6 +244,96 @@ func (c *blockNoteServiceClient) ChangeTypeBlock(ctx context.Context, in *Change
 	return out, nil
 }
 
+func (c *blockNoteServiceClient) GetDeletedBlocks(ctx context.Context, in *UserNoteId, opts ...grpc.CallOption) (*DeletedBlocks, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeletedBlocks)
+	err := c.cc.Invoke(ctx, BlockNoteService_GetDeletedBlocks_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blockNoteServiceClient) RestoreBlock(ctx context.Context, in *NoteBlockUserId, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, BlockNoteService_RestoreBlock_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blockNoteServiceClient) CreateComment(ctx context.Context, in *CreateCommentRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, BlockNoteService_CreateComment_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blockNoteServiceClient) GetComments(ctx context.Context, in *UserNoteId, opts ...grpc.CallOption) (*Comments, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Comments)
+	err := c.cc.Invoke(ctx, BlockNoteService_GetComments_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blockNoteServiceClient) UpdateComment(ctx context.Context, in *UpdateCommentRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, BlockNoteService_UpdateComment_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blockNoteServiceClient) DeleteComment(ctx context.Context, in *UserCommentId, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, BlockNoteService_DeleteComment_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blockNoteServiceClient) ResolveThread(ctx context.Context, in *ResolveThreadRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, BlockNoteService_ResolveThread_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blockNoteServiceClient) GetNoteActivity(ctx context.Context, in *NoteActivityRequest, opts ...grpc.CallOption) (*Activities, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Activities)
+	err := c.cc.Invoke(ctx, BlockNoteService_GetNoteActivity_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blockNoteServiceClient) GetActivityFeed(ctx context.Context, in *ActivityFeedRequest, opts ...grpc.CallOption) (*Activities, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Activities)
+	err := c.cc.Invoke(ctx, BlockNoteService_GetActivityFeed_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *blockNoteServiceClient) CleanTrash(ctx context.Context, in *UserId, opts ...grpc.CallOption) (*emptypb.Empty, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(emptypb.Empty)
@@ -233,6 +384,36 @@ func (c *blockNoteServiceClient) FindNoteInTrash(ctx context.Context, in *UserNo
 	return out, nil
 }
 
+func (c *blockNoteServiceClient) PurgeNoteFromTrash(ctx context.Context, in *UserNoteId, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, BlockNoteService_PurgeNoteFromTrash_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blockNoteServiceClient) SetTrashRetention(ctx context.Context, in *TrashRetentionRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, BlockNoteService_SetTrashRetention_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blockNoteServiceClient) GetTrashRetention(ctx context.Context, in *UserId, opts ...grpc.CallOption) (*TrashRetention, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TrashRetention)
+	err := c.cc.Invoke(ctx, BlockNoteService_GetTrashRetention_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *blockNoteServiceClient) GetNote(ctx context.Context, in *UserNoteId, opts ...grpc.CallOption) (*NoteWithBlocks, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(NoteWithBlocks)
@@ -273,7 +454,7 @@ func (c *blockNoteServiceClient) GetAllBlocksInNote(ctx context.Context, in *Str
 	return out, nil
 }
 
-func (c *blockNoteServiceClient) GetAllNotes(ctx context.Context, in *UserId, opts ...grpc.CallOption) (*NoteParts, error) {
+func (c *blockNoteServiceClient) GetAllNotes(ctx context.Context, in *UserWorkspaceId, opts ...grpc.CallOption) (*NoteParts, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(NoteParts)
 	err := c.cc.Invoke(ctx, BlockNoteService_GetAllNotes_FullMethodName, in, out, cOpts...)
@@ -303,6 +484,106 @@ func (c *blockNoteServiceClient) GetNotesFromTrash(ctx context.Context, in *User
 	return out, nil
 }
 
+func (c *blockNoteServiceClient) GetUserStats(ctx context.Context, in *UserId, opts ...grpc.CallOption) (*UserStats, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UserStats)
+	err := c.cc.Invoke(ctx, BlockNoteService_GetUserStats_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blockNoteServiceClient) RemoveUserFromNotes(ctx context.Context, in *UserId, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, BlockNoteService_RemoveUserFromNotes_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blockNoteServiceClient) GetUserFiles(ctx context.Context, in *UserId, opts ...grpc.CallOption) (*Strings, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Strings)
+	err := c.cc.Invoke(ctx, BlockNoteService_GetUserFiles_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blockNoteServiceClient) RenderNote(ctx context.Context, in *RenderNoteRequest, opts ...grpc.CallOption) (*String, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(String)
+	err := c.cc.Invoke(ctx, BlockNoteService_RenderNote_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blockNoteServiceClient) ImportTags(ctx context.Context, in *ImportTagsRequest, opts ...grpc.CallOption) (*ImportReport, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ImportReport)
+	err := c.cc.Invoke(ctx, BlockNoteService_ImportTags_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blockNoteServiceClient) ImportNote(ctx context.Context, in *ImportNoteRequest, opts ...grpc.CallOption) (*ImportReport, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ImportReport)
+	err := c.cc.Invoke(ctx, BlockNoteService_ImportNote_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blockNoteServiceClient) ConvertMarkdown(ctx context.Context, in *String, opts ...grpc.CallOption) (*Blocks, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Blocks)
+	err := c.cc.Invoke(ctx, BlockNoteService_ConvertMarkdown_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blockNoteServiceClient) ConvertHTML(ctx context.Context, in *ConvertHTMLRequest, opts ...grpc.CallOption) (*Blocks, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Blocks)
+	err := c.cc.Invoke(ctx, BlockNoteService_ConvertHTML_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blockNoteServiceClient) ConvertDocument(ctx context.Context, in *String, opts ...grpc.CallOption) (*NoteWithBlocks, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(NoteWithBlocks)
+	err := c.cc.Invoke(ctx, BlockNoteService_ConvertDocument_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blockNoteServiceClient) GetDocumentSchema(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*String, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(String)
+	err := c.cc.Invoke(ctx, BlockNoteService_GetDocumentSchema_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *blockNoteServiceClient) Search(ctx context.Context, in *SearchRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[NotePart], error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	stream, err := c.cc.NewStream(ctx, &BlockNoteService_ServiceDesc.Streams[0], BlockNoteService_Search_FullMethodName, cOpts...)
@@ -322,6 +603,44 @@ func (c *blockNoteServiceClient) Search(ctx context.Context, in *SearchRequest,
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type BlockNoteService_SearchClient = grpc.ServerStreamingClient[NotePart]
 
+func (c *blockNoteServiceClient) ExportNote(ctx context.Context, in *ExportNoteRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ExportPart], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &BlockNoteService_ServiceDesc.Streams[1], BlockNoteService_ExportNote_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ExportNoteRequest, ExportPart]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type BlockNoteService_ExportNoteClient = grpc.ServerStreamingClient[ExportPart]
+
+func (c *blockNoteServiceClient) ExportCollection(ctx context.Context, in *ExportCollectionRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ExportPart], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &BlockNoteService_ServiceDesc.Streams[2], BlockNoteService_ExportCollection_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ExportCollectionRequest, ExportPart]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type BlockNoteService_ExportCollectionClient = grpc.ServerStreamingClient[ExportPart]
+
 func (c *blockNoteServiceClient) AddTagToNote(ctx context.Context, in *NoteTagUserId, opts ...grpc.CallOption) (*emptypb.Empty, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(emptypb.Empty)
@@ -352,7 +671,7 @@ func (c *blockNoteServiceClient) CreateTag(ctx context.Context, in *Tag, opts ..
 	return out, nil
 }
 
-func (c *blockNoteServiceClient) GetTagsByUser(ctx context.Context, in *UserId, opts ...grpc.CallOption) (*Tags, error) {
+func (c *blockNoteServiceClient) GetTagsByUser(ctx context.Context, in *UserWorkspaceId, opts ...grpc.CallOption) (*Tags, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(Tags)
 	err := c.cc.Invoke(ctx, BlockNoteService_GetTagsByUser_FullMethodName, in, out, cOpts...)
@@ -496,24 +815,61 @@ type BlockNoteServiceServer interface {
 	// rpc GetBlockAsFirst(BlockId) returns (StringResponse);
 	ChangeBlockOrder(context.Context, *ChangeBlockOrderRequest) (*emptypb.Empty, error)
 	ChangeTypeBlock(context.Context, *ChangeTypeBlockRequest) (*emptypb.Empty, error)
+	GetDeletedBlocks(context.Context, *UserNoteId) (*DeletedBlocks, error)
+	RestoreBlock(context.Context, *NoteBlockUserId) (*emptypb.Empty, error)
+	CreateComment(context.Context, *CreateCommentRequest) (*emptypb.Empty, error)
+	GetComments(context.Context, *UserNoteId) (*Comments, error)
+	UpdateComment(context.Context, *UpdateCommentRequest) (*emptypb.Empty, error)
+	DeleteComment(context.Context, *UserCommentId) (*emptypb.Empty, error)
+	ResolveThread(context.Context, *ResolveThreadRequest) (*emptypb.Empty, error)
+	GetNoteActivity(context.Context, *NoteActivityRequest) (*Activities, error)
+	GetActivityFeed(context.Context, *ActivityFeedRequest) (*Activities, error)
 	CleanTrash(context.Context, *UserId) (*emptypb.Empty, error)
 	NoteToTrash(context.Context, *UserNoteId) (*emptypb.Empty, error)
 	NotesToTrash(context.Context, *UserId) (*emptypb.Empty, error)
 	NoteFromTrash(context.Context, *UserNoteId) (*emptypb.Empty, error)
 	FindNoteInTrash(context.Context, *UserNoteId) (*NoteWithBlocks, error)
+	PurgeNoteFromTrash(context.Context, *UserNoteId) (*emptypb.Empty, error)
+	SetTrashRetention(context.Context, *TrashRetentionRequest) (*emptypb.Empty, error)
+	// GetTrashRetention return retention set by user, 0 is retention of server
+	GetTrashRetention(context.Context, *UserId) (*TrashRetention, error)
 	GetNote(context.Context, *UserNoteId) (*NoteWithBlocks, error)
 	CreateNote(context.Context, *Note) (*emptypb.Empty, error)
 	// rpc UpdateNoteTitle(UpdateNoteTitleRequest) returns (google.protobuf.Empty);
 	ChangeTitleNote(context.Context, *ChangeTitleNoteRequest) (*emptypb.Empty, error)
 	GetAllBlocksInNote(context.Context, *Strings) (*Blocks, error)
-	GetAllNotes(context.Context, *UserId) (*NoteParts, error)
+	GetAllNotes(context.Context, *UserWorkspaceId) (*NoteParts, error)
 	GetNotesByTag(context.Context, *UserTagId) (*NoteParts, error)
 	GetNotesFromTrash(context.Context, *UserId) (*NoteParts, error)
+	// GetUserStats is for admins, gateway checks rights
+	GetUserStats(context.Context, *UserId) (*UserStats, error)
+	// RemoveUserFromNotes pull user from editors and readers of all notes, used when account is deleted
+	RemoveUserFromNotes(context.Context, *UserId) (*emptypb.Empty, error)
+	// GetUserFiles return names of local files and images used only in notes of user
+	GetUserFiles(context.Context, *UserId) (*Strings, error)
+	// RenderNote render given note, so it works for notes from trash too
+	RenderNote(context.Context, *RenderNoteRequest) (*String, error)
+	// ImportTags create tags of archive for user, tags with same title as existing are merged
+	ImportTags(context.Context, *ImportTagsRequest) (*ImportReport, error)
+	// ImportNote create note with blocks under new ids for user, timestamps are kept
+	ImportNote(context.Context, *ImportNoteRequest) (*ImportReport, error)
+	// ConvertMarkdown convert markdown to blocks with type and data, blocks are not saved
+	ConvertMarkdown(context.Context, *String) (*Blocks, error)
+	// ConvertHTML convert html to blocks with type and data. If noteId is set, blocks are inserted into note
+	ConvertHTML(context.Context, *ConvertHTMLRequest) (*Blocks, error)
+	// ConvertDocument check note in BreezyNotes document format and convert it, note is not saved
+	ConvertDocument(context.Context, *String) (*NoteWithBlocks, error)
+	// GetDocumentSchema return JSON schema of BreezyNotes document format
+	GetDocumentSchema(context.Context, *emptypb.Empty) (*String, error)
 	Search(*SearchRequest, grpc.ServerStreamingServer[NotePart]) error
+	// ExportNote render note to standalone document
+	ExportNote(*ExportNoteRequest, grpc.ServerStreamingServer[ExportPart]) error
+	// ExportCollection render blog notes of author to book, files of book go as named parts
+	ExportCollection(*ExportCollectionRequest, grpc.ServerStreamingServer[ExportPart]) error
 	AddTagToNote(context.Context, *NoteTagUserId) (*emptypb.Empty, error)
 	RemoveTagFromNote(context.Context, *UserNoteId) (*emptypb.Empty, error)
 	CreateTag(context.Context, *Tag) (*emptypb.Empty, error)
-	GetTagsByUser(context.Context, *UserId) (*Tags, error)
+	GetTagsByUser(context.Context, *UserWorkspaceId) (*Tags, error)
 	GetPinnedTagsByUser(context.Context, *UserId) (*Tags, error)
 	// rpc GetTag(UserTagId) returns (Tag);
 	UpdateTagTitle(context.Context, *UpdateTagTitleRequest) (*emptypb.Empty, error)
@@ -558,6 +914,33 @@ func (UnimplementedBlockNoteServiceServer) ChangeBlockOrder(context.Context, *Ch
 func (UnimplementedBlockNoteServiceServer) ChangeTypeBlock(context.Context, *ChangeTypeBlockRequest) (*emptypb.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ChangeTypeBlock not implemented")
 }
+func (UnimplementedBlockNoteServiceServer) GetDeletedBlocks(context.Context, *UserNoteId) (*DeletedBlocks, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDeletedBlocks not implemented")
+}
+func (UnimplementedBlockNoteServiceServer) RestoreBlock(context.Context, *NoteBlockUserId) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RestoreBlock not implemented")
+}
+func (UnimplementedBlockNoteServiceServer) CreateComment(context.Context, *CreateCommentRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateComment not implemented")
+}
+func (UnimplementedBlockNoteServiceServer) GetComments(context.Context, *UserNoteId) (*Comments, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetComments not implemented")
+}
+func (UnimplementedBlockNoteServiceServer) UpdateComment(context.Context, *UpdateCommentRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateComment not implemented")
+}
+func (UnimplementedBlockNoteServiceServer) DeleteComment(context.Context, *UserCommentId) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteComment not implemented")
+}
+func (UnimplementedBlockNoteServiceServer) ResolveThread(context.Context, *ResolveThreadRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ResolveThread not implemented")
+}
+func (UnimplementedBlockNoteServiceServer) GetNoteActivity(context.Context, *NoteActivityRequest) (*Activities, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetNoteActivity not implemented")
+}
+func (UnimplementedBlockNoteServiceServer) GetActivityFeed(context.Context, *ActivityFeedRequest) (*Activities, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetActivityFeed not implemented")
+}
 func (UnimplementedBlockNoteServiceServer) CleanTrash(context.Context, *UserId) (*emptypb.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CleanTrash not implemented")
 }
@@ -573,6 +956,15 @@ func (UnimplementedBlockNoteServiceServer) NoteFromTrash(context.Context, *UserN
 func (UnimplementedBlockNoteServiceServer) FindNoteInTrash(context.Context, *UserNoteId) (*NoteWithBlocks, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method FindNoteInTrash not implemented")
 }
+func (UnimplementedBlockNoteServiceServer) PurgeNoteFromTrash(context.Context, *UserNoteId) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PurgeNoteFromTrash not implemented")
+}
+func (UnimplementedBlockNoteServiceServer) SetTrashRetention(context.Context, *TrashRetentionRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetTrashRetention not implemented")
+}
+func (UnimplementedBlockNoteServiceServer) GetTrashRetention(context.Context, *UserId) (*TrashRetention, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTrashRetention not implemented")
+}
 func (UnimplementedBlockNoteServiceServer) GetNote(context.Context, *UserNoteId) (*NoteWithBlocks, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetNote not implemented")
 }
@@ -585,7 +977,7 @@ func (UnimplementedBlockNoteServiceServer) ChangeTitleNote(context.Context, *Cha
 func (UnimplementedBlockNoteServiceServer) GetAllBlocksInNote(context.Context, *Strings) (*Blocks, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetAllBlocksInNote not implemented")
 }
-func (UnimplementedBlockNoteServiceServer) GetAllNotes(context.Context, *UserId) (*NoteParts, error) {
+func (UnimplementedBlockNoteServiceServer) GetAllNotes(context.Context, *UserWorkspaceId) (*NoteParts, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetAllNotes not implemented")
 }
 func (UnimplementedBlockNoteServiceServer) GetNotesByTag(context.Context, *UserTagId) (*NoteParts, error) {
@@ -594,9 +986,45 @@ func (UnimplementedBlockNoteServiceServer) GetNotesByTag(context.Context, *UserT
 func (UnimplementedBlockNoteServiceServer) GetNotesFromTrash(context.Context, *UserId) (*NoteParts, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetNotesFromTrash not implemented")
 }
+func (UnimplementedBlockNoteServiceServer) GetUserStats(context.Context, *UserId) (*UserStats, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetUserStats not implemented")
+}
+func (UnimplementedBlockNoteServiceServer) RemoveUserFromNotes(context.Context, *UserId) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveUserFromNotes not implemented")
+}
+func (UnimplementedBlockNoteServiceServer) GetUserFiles(context.Context, *UserId) (*Strings, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetUserFiles not implemented")
+}
+func (UnimplementedBlockNoteServiceServer) RenderNote(context.Context, *RenderNoteRequest) (*String, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RenderNote not implemented")
+}
+func (UnimplementedBlockNoteServiceServer) ImportTags(context.Context, *ImportTagsRequest) (*ImportReport, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ImportTags not implemented")
+}
+func (UnimplementedBlockNoteServiceServer) ImportNote(context.Context, *ImportNoteRequest) (*ImportReport, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ImportNote not implemented")
+}
+func (UnimplementedBlockNoteServiceServer) ConvertMarkdown(context.Context, *String) (*Blocks, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ConvertMarkdown not implemented")
+}
+func (UnimplementedBlockNoteServiceServer) ConvertHTML(context.Context, *ConvertHTMLRequest) (*Blocks, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ConvertHTML not implemented")
+}
+func (UnimplementedBlockNoteServiceServer) ConvertDocument(context.Context, *String) (*NoteWithBlocks, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ConvertDocument not implemented")
+}
+func (UnimplementedBlockNoteServiceServer) GetDocumentSchema(context.Context, *emptypb.Empty) (*String, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDocumentSchema not implemented")
+}
 func (UnimplementedBlockNoteServiceServer) Search(*SearchRequest, grpc.ServerStreamingServer[NotePart]) error {
 	return status.Errorf(codes.Unimplemented, "method Search not implemented")
 }
+func (UnimplementedBlockNoteServiceServer) ExportNote(*ExportNoteRequest, grpc.ServerStreamingServer[ExportPart]) error {
+	return status.Errorf(codes.Unimplemented, "method ExportNote not implemented")
+}
+func (UnimplementedBlockNoteServiceServer) ExportCollection(*ExportCollectionRequest, grpc.ServerStreamingServer[ExportPart]) error {
+	return status.Errorf(codes.Unimplemented, "method ExportCollection not implemented")
+}
 func (UnimplementedBlockNoteServiceServer) AddTagToNote(context.Context, *NoteTagUserId) (*emptypb.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method AddTagToNote not implemented")
 }
@@ -606,7 +1034,7 @@ func (UnimplementedBlockNoteServiceServer) RemoveTagFromNote(context.Context, *U
 func (UnimplementedBlockNoteServiceServer) CreateTag(context.Context, *Tag) (*emptypb.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CreateTag not implemented")
 }
-func (UnimplementedBlockNoteServiceServer) GetTagsByUser(context.Context, *UserId) (*Tags, error) {
+func (UnimplementedBlockNoteServiceServer) GetTagsByUser(context.Context, *UserWorkspaceId) (*Tags, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetTagsByUser not implemented")
 }
 func (UnimplementedBlockNoteServiceServer) GetPinnedTagsByUser(context.Context, *UserId) (*Tags, error) {
@@ -738,56 +1166,218 @@ func _BlockNoteService_OpBlock_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
-func _BlockNoteService_GetBlock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(NoteBlockUserId)
+func _BlockNoteService_GetBlock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NoteBlockUserId)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlockNoteServiceServer).GetBlock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BlockNoteService_GetBlock_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlockNoteServiceServer).GetBlock(ctx, req.(*NoteBlockUserId))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BlockNoteService_ChangeBlockOrder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ChangeBlockOrderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlockNoteServiceServer).ChangeBlockOrder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BlockNoteService_ChangeBlockOrder_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlockNoteServiceServer).ChangeBlockOrder(ctx, req.(*ChangeBlockOrderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BlockNoteService_ChangeTypeBlock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ChangeTypeBlockRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlockNoteServiceServer).ChangeTypeBlock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BlockNoteService_ChangeTypeBlock_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlockNoteServiceServer).ChangeTypeBlock(ctx, req.(*ChangeTypeBlockRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BlockNoteService_GetDeletedBlocks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserNoteId)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlockNoteServiceServer).GetDeletedBlocks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BlockNoteService_GetDeletedBlocks_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlockNoteServiceServer).GetDeletedBlocks(ctx, req.(*UserNoteId))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BlockNoteService_RestoreBlock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NoteBlockUserId)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlockNoteServiceServer).RestoreBlock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BlockNoteService_RestoreBlock_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlockNoteServiceServer).RestoreBlock(ctx, req.(*NoteBlockUserId))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BlockNoteService_CreateComment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateCommentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlockNoteServiceServer).CreateComment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BlockNoteService_CreateComment_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlockNoteServiceServer).CreateComment(ctx, req.(*CreateCommentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BlockNoteService_GetComments_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserNoteId)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlockNoteServiceServer).GetComments(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BlockNoteService_GetComments_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlockNoteServiceServer).GetComments(ctx, req.(*UserNoteId))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BlockNoteService_UpdateComment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateCommentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlockNoteServiceServer).UpdateComment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BlockNoteService_UpdateComment_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlockNoteServiceServer).UpdateComment(ctx, req.(*UpdateCommentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BlockNoteService_DeleteComment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserCommentId)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlockNoteServiceServer).DeleteComment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BlockNoteService_DeleteComment_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlockNoteServiceServer).DeleteComment(ctx, req.(*UserCommentId))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BlockNoteService_ResolveThread_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResolveThreadRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(BlockNoteServiceServer).GetBlock(ctx, in)
+		return srv.(BlockNoteServiceServer).ResolveThread(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: BlockNoteService_GetBlock_FullMethodName,
+		FullMethod: BlockNoteService_ResolveThread_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(BlockNoteServiceServer).GetBlock(ctx, req.(*NoteBlockUserId))
+		return srv.(BlockNoteServiceServer).ResolveThread(ctx, req.(*ResolveThreadRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _BlockNoteService_ChangeBlockOrder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ChangeBlockOrderRequest)
+func _BlockNoteService_GetNoteActivity_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NoteActivityRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(BlockNoteServiceServer).ChangeBlockOrder(ctx, in)
+		return srv.(BlockNoteServiceServer).GetNoteActivity(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: BlockNoteService_ChangeBlockOrder_FullMethodName,
+		FullMethod: BlockNoteService_GetNoteActivity_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(BlockNoteServiceServer).ChangeBlockOrder(ctx, req.(*ChangeBlockOrderRequest))
+		return srv.(BlockNoteServiceServer).GetNoteActivity(ctx, req.(*NoteActivityRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _BlockNoteService_ChangeTypeBlock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ChangeTypeBlockRequest)
+func _BlockNoteService_GetActivityFeed_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ActivityFeedRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(BlockNoteServiceServer).ChangeTypeBlock(ctx, in)
+		return srv.(BlockNoteServiceServer).GetActivityFeed(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: BlockNoteService_ChangeTypeBlock_FullMethodName,
+		FullMethod: BlockNoteService_GetActivityFeed_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(BlockNoteServiceServer).ChangeTypeBlock(ctx, req.(*ChangeTypeBlockRequest))
+		return srv.(BlockNoteServiceServer).GetActivityFeed(ctx, req.(*ActivityFeedRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
@@ -882,6 +1472,60 @@ func _BlockNoteService_FindNoteInTrash_Handler(srv interface{}, ctx context.Cont
 	return interceptor(ctx, in, info, handler)
 }
 
+func _BlockNoteService_PurgeNoteFromTrash_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserNoteId)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlockNoteServiceServer).PurgeNoteFromTrash(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BlockNoteService_PurgeNoteFromTrash_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlockNoteServiceServer).PurgeNoteFromTrash(ctx, req.(*UserNoteId))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BlockNoteService_SetTrashRetention_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TrashRetentionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlockNoteServiceServer).SetTrashRetention(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BlockNoteService_SetTrashRetention_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlockNoteServiceServer).SetTrashRetention(ctx, req.(*TrashRetentionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BlockNoteService_GetTrashRetention_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserId)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlockNoteServiceServer).GetTrashRetention(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BlockNoteService_GetTrashRetention_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlockNoteServiceServer).GetTrashRetention(ctx, req.(*UserId))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _BlockNoteService_GetNote_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(UserNoteId)
 	if err := dec(in); err != nil {
@@ -955,7 +1599,7 @@ func _BlockNoteService_GetAllBlocksInNote_Handler(srv interface{}, ctx context.C
 }
 
 func _BlockNoteService_GetAllNotes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(UserId)
+	in := new(UserWorkspaceId)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
@@ -967,7 +1611,7 @@ func _BlockNoteService_GetAllNotes_Handler(srv interface{}, ctx context.Context,
 		FullMethod: BlockNoteService_GetAllNotes_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(BlockNoteServiceServer).GetAllNotes(ctx, req.(*UserId))
+		return srv.(BlockNoteServiceServer).GetAllNotes(ctx, req.(*UserWorkspaceId))
 	}
 	return interceptor(ctx, in, info, handler)
 }
@@ -1008,6 +1652,186 @@ func _BlockNoteService_GetNotesFromTrash_Handler(srv interface{}, ctx context.Co
 	return interceptor(ctx, in, info, handler)
 }
 
+func _BlockNoteService_GetUserStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserId)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlockNoteServiceServer).GetUserStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BlockNoteService_GetUserStats_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlockNoteServiceServer).GetUserStats(ctx, req.(*UserId))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BlockNoteService_RemoveUserFromNotes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserId)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlockNoteServiceServer).RemoveUserFromNotes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BlockNoteService_RemoveUserFromNotes_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlockNoteServiceServer).RemoveUserFromNotes(ctx, req.(*UserId))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BlockNoteService_GetUserFiles_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserId)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlockNoteServiceServer).GetUserFiles(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BlockNoteService_GetUserFiles_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlockNoteServiceServer).GetUserFiles(ctx, req.(*UserId))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BlockNoteService_RenderNote_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RenderNoteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlockNoteServiceServer).RenderNote(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BlockNoteService_RenderNote_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlockNoteServiceServer).RenderNote(ctx, req.(*RenderNoteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BlockNoteService_ImportTags_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ImportTagsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlockNoteServiceServer).ImportTags(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BlockNoteService_ImportTags_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlockNoteServiceServer).ImportTags(ctx, req.(*ImportTagsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BlockNoteService_ImportNote_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ImportNoteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlockNoteServiceServer).ImportNote(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BlockNoteService_ImportNote_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlockNoteServiceServer).ImportNote(ctx, req.(*ImportNoteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BlockNoteService_ConvertMarkdown_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(String)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlockNoteServiceServer).ConvertMarkdown(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BlockNoteService_ConvertMarkdown_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlockNoteServiceServer).ConvertMarkdown(ctx, req.(*String))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BlockNoteService_ConvertHTML_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ConvertHTMLRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlockNoteServiceServer).ConvertHTML(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BlockNoteService_ConvertHTML_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlockNoteServiceServer).ConvertHTML(ctx, req.(*ConvertHTMLRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BlockNoteService_ConvertDocument_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(String)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlockNoteServiceServer).ConvertDocument(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BlockNoteService_ConvertDocument_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlockNoteServiceServer).ConvertDocument(ctx, req.(*String))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BlockNoteService_GetDocumentSchema_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlockNoteServiceServer).GetDocumentSchema(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BlockNoteService_GetDocumentSchema_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlockNoteServiceServer).GetDocumentSchema(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _BlockNoteService_Search_Handler(srv interface{}, stream grpc.ServerStream) error {
 	m := new(SearchRequest)
 	if err := stream.RecvMsg(m); err != nil {
@@ -1019,6 +1843,28 @@ func _BlockNoteService_Search_Handler(srv interface{}, stream grpc.ServerStream)
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type BlockNoteService_SearchServer = grpc.ServerStreamingServer[NotePart]
 
+func _BlockNoteService_ExportNote_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ExportNoteRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(BlockNoteServiceServer).ExportNote(m, &grpc.GenericServerStream[ExportNoteRequest, ExportPart]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type BlockNoteService_ExportNoteServer = grpc.ServerStreamingServer[ExportPart]
+
+func _BlockNoteService_ExportCollection_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ExportCollectionRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(BlockNoteServiceServer).ExportCollection(m, &grpc.GenericServerStream[ExportCollectionRequest, ExportPart]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type BlockNoteService_ExportCollectionServer = grpc.ServerStreamingServer[ExportPart]
+
 func _BlockNoteService_AddTagToNote_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(NoteTagUserId)
 	if err := dec(in); err != nil {
@@ -1074,7 +1920,7 @@ func _BlockNoteService_CreateTag_Handler(srv interface{}, ctx context.Context, d
 }
 
 func _BlockNoteService_GetTagsByUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(UserId)
+	in := new(UserWorkspaceId)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
@@ -1086,7 +1932,7 @@ func _BlockNoteService_GetTagsByUser_Handler(srv interface{}, ctx context.Contex
 		FullMethod: BlockNoteService_GetTagsByUser_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(BlockNoteServiceServer).GetTagsByUser(ctx, req.(*UserId))
+		return srv.(BlockNoteServiceServer).GetTagsByUser(ctx, req.(*UserWorkspaceId))
 	}
 	return interceptor(ctx, in, info, handler)
 }
@@ -1342,6 +2188,42 @@ var BlockNoteService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ChangeTypeBlock",
 			Handler:    _BlockNoteService_ChangeTypeBlock_Handler,
 		},
+		{
+			MethodName: "GetDeletedBlocks",
+			Handler:    _BlockNoteService_GetDeletedBlocks_Handler,
+		},
+		{
+			MethodName: "RestoreBlock",
+			Handler:    _BlockNoteService_RestoreBlock_Handler,
+		},
+		{
+			MethodName: "CreateComment",
+			Handler:    _BlockNoteService_CreateComment_Handler,
+		},
+		{
+			MethodName: "GetComments",
+			Handler:    _BlockNoteService_GetComments_Handler,
+		},
+		{
+			MethodName: "UpdateComment",
+			Handler:    _BlockNoteService_UpdateComment_Handler,
+		},
+		{
+			MethodName: "DeleteComment",
+			Handler:    _BlockNoteService_DeleteComment_Handler,
+		},
+		{
+			MethodName: "ResolveThread",
+			Handler:    _BlockNoteService_ResolveThread_Handler,
+		},
+		{
+			MethodName: "GetNoteActivity",
+			Handler:    _BlockNoteService_GetNoteActivity_Handler,
+		},
+		{
+			MethodName: "GetActivityFeed",
+			Handler:    _BlockNoteService_GetActivityFeed_Handler,
+		},
 		{
 			MethodName: "CleanTrash",
 			Handler:    _BlockNoteService_CleanTrash_Handler,
@@ -1362,6 +2244,18 @@ var BlockNoteService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "FindNoteInTrash",
 			Handler:    _BlockNoteService_FindNoteInTrash_Handler,
 		},
+		{
+			MethodName: "PurgeNoteFromTrash",
+			Handler:    _BlockNoteService_PurgeNoteFromTrash_Handler,
+		},
+		{
+			MethodName: "SetTrashRetention",
+			Handler:    _BlockNoteService_SetTrashRetention_Handler,
+		},
+		{
+			MethodName: "GetTrashRetention",
+			Handler:    _BlockNoteService_GetTrashRetention_Handler,
+		},
 		{
 			MethodName: "GetNote",
 			Handler:    _BlockNoteService_GetNote_Handler,
@@ -1390,6 +2284,46 @@ var BlockNoteService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetNotesFromTrash",
 			Handler:    _BlockNoteService_GetNotesFromTrash_Handler,
 		},
+		{
+			MethodName: "GetUserStats",
+			Handler:    _BlockNoteService_GetUserStats_Handler,
+		},
+		{
+			MethodName: "RemoveUserFromNotes",
+			Handler:    _BlockNoteService_RemoveUserFromNotes_Handler,
+		},
+		{
+			MethodName: "GetUserFiles",
+			Handler:    _BlockNoteService_GetUserFiles_Handler,
+		},
+		{
+			MethodName: "RenderNote",
+			Handler:    _BlockNoteService_RenderNote_Handler,
+		},
+		{
+			MethodName: "ImportTags",
+			Handler:    _BlockNoteService_ImportTags_Handler,
+		},
+		{
+			MethodName: "ImportNote",
+			Handler:    _BlockNoteService_ImportNote_Handler,
+		},
+		{
+			MethodName: "ConvertMarkdown",
+			Handler:    _BlockNoteService_ConvertMarkdown_Handler,
+		},
+		{
+			MethodName: "ConvertHTML",
+			Handler:    _BlockNoteService_ConvertHTML_Handler,
+		},
+		{
+			MethodName: "ConvertDocument",
+			Handler:    _BlockNoteService_ConvertDocument_Handler,
+		},
+		{
+			MethodName: "GetDocumentSchema",
+			Handler:    _BlockNoteService_GetDocumentSchema_Handler,
+		},
 		{
 			MethodName: "AddTagToNote",
 			Handler:    _BlockNoteService_AddTagToNote_Handler,
@@ -1461,6 +2395,16 @@ var BlockNoteService_ServiceDesc = grpc.ServiceDesc{
 			Handler:       _BlockNoteService_Search_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "ExportNote",
+			Handler:       _BlockNoteService_ExportNote_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ExportCollection",
+			Handler:       _BlockNoteService_ExportCollection_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "notes.proto",
 }
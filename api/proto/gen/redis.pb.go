@@ -231,6 +231,111 @@ func (x *TagsByUser) GetItems() []*Tag {
 	return nil
 }
 
+type PreferencesByUser struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Preferences   *Preferences           `protobuf:"bytes,2,opt,name=preferences,proto3" json:"preferences,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PreferencesByUser) Reset() {
+	*x = PreferencesByUser{}
+	mi := &file_redis_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PreferencesByUser) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PreferencesByUser) ProtoMessage() {}
+
+func (x *PreferencesByUser) ProtoReflect() protoreflect.Message {
+	mi := &file_redis_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PreferencesByUser.ProtoReflect.Descriptor instead.
+func (*PreferencesByUser) Descriptor() ([]byte, []int) {
+	return file_redis_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *PreferencesByUser) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *PreferencesByUser) GetPreferences() *Preferences {
+	if x != nil {
+		return x.Preferences
+	}
+	return nil
+}
+
+// WorkspaceRolesByUser membership of user as workspaceId:role pairs joined by comma, empty if user has no workspaces
+type WorkspaceRolesByUser struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Roles         string                 `protobuf:"bytes,2,opt,name=roles,proto3" json:"roles,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WorkspaceRolesByUser) Reset() {
+	*x = WorkspaceRolesByUser{}
+	mi := &file_redis_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WorkspaceRolesByUser) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WorkspaceRolesByUser) ProtoMessage() {}
+
+func (x *WorkspaceRolesByUser) ProtoReflect() protoreflect.Message {
+	mi := &file_redis_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WorkspaceRolesByUser.ProtoReflect.Descriptor instead.
+func (*WorkspaceRolesByUser) Descriptor() ([]byte, []int) {
+	return file_redis_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *WorkspaceRolesByUser) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *WorkspaceRolesByUser) GetRoles() string {
+	if x != nil {
+		return x.Roles
+	}
+	return ""
+}
+
 type BlocksOnNote struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	NoteId        string                 `protobuf:"bytes,1,opt,name=note_id,json=noteId,proto3" json:"note_id,omitempty"`
@@ -241,7 +346,7 @@ type BlocksOnNote struct {
 
 func (x *BlocksOnNote) Reset() {
 	*x = BlocksOnNote{}
-	mi := &file_redis_proto_msgTypes[4]
+	mi := &file_redis_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -253,7 +358,7 @@ func (x *BlocksOnNote) String() string {
 func (*BlocksOnNote) ProtoMessage() {}
 
 func (x *BlocksOnNote) ProtoReflect() protoreflect.Message {
-	mi := &file_redis_proto_msgTypes[4]
+	mi := &file_redis_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -266,7 +371,7 @@ func (x *BlocksOnNote) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use BlocksOnNote.ProtoReflect.Descriptor instead.
 func (*BlocksOnNote) Descriptor() ([]byte, []int) {
-	return file_redis_proto_rawDescGZIP(), []int{4}
+	return file_redis_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *BlocksOnNote) GetNoteId() string {
@@ -293,7 +398,7 @@ type RateLimitRequest struct {
 
 func (x *RateLimitRequest) Reset() {
 	*x = RateLimitRequest{}
-	mi := &file_redis_proto_msgTypes[5]
+	mi := &file_redis_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -305,7 +410,7 @@ func (x *RateLimitRequest) String() string {
 func (*RateLimitRequest) ProtoMessage() {}
 
 func (x *RateLimitRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_redis_proto_msgTypes[5]
+	mi := &file_redis_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -318,7 +423,7 @@ func (x *RateLimitRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RateLimitRequest.ProtoReflect.Descriptor instead.
 func (*RateLimitRequest) Descriptor() ([]byte, []int) {
-	return file_redis_proto_rawDescGZIP(), []int{5}
+	return file_redis_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *RateLimitRequest) GetKey() string {
@@ -345,7 +450,7 @@ type RateLimitResponse struct {
 
 func (x *RateLimitResponse) Reset() {
 	*x = RateLimitResponse{}
-	mi := &file_redis_proto_msgTypes[6]
+	mi := &file_redis_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -357,7 +462,7 @@ func (x *RateLimitResponse) String() string {
 func (*RateLimitResponse) ProtoMessage() {}
 
 func (x *RateLimitResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_redis_proto_msgTypes[6]
+	mi := &file_redis_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -370,7 +475,7 @@ func (x *RateLimitResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RateLimitResponse.ProtoReflect.Descriptor instead.
 func (*RateLimitResponse) Descriptor() ([]byte, []int) {
-	return file_redis_proto_rawDescGZIP(), []int{6}
+	return file_redis_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *RateLimitResponse) GetCount() int64 {
@@ -387,6 +492,213 @@ func (x *RateLimitResponse) GetTtl() int64 {
 	return 0
 }
 
+// ExportJob archive with all data of user, made by gateway. state is queued, running, ready or failed
+type ExportJob struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Id         string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	UserId     string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	State      string                 `protobuf:"bytes,3,opt,name=state,proto3" json:"state,omitempty"`
+	Error      string                 `protobuf:"bytes,4,opt,name=error,proto3" json:"error,omitempty"`
+	CreatedAt  int64                  `protobuf:"varint,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	FinishedAt int64                  `protobuf:"varint,6,opt,name=finished_at,json=finishedAt,proto3" json:"finished_at,omitempty"`
+	// expires_at archive is deleted after it
+	ExpiresAt     int64 `protobuf:"varint,7,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	Size          int64 `protobuf:"varint,8,opt,name=size,proto3" json:"size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportJob) Reset() {
+	*x = ExportJob{}
+	mi := &file_redis_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportJob) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportJob) ProtoMessage() {}
+
+func (x *ExportJob) ProtoReflect() protoreflect.Message {
+	mi := &file_redis_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportJob.ProtoReflect.Descriptor instead.
+func (*ExportJob) Descriptor() ([]byte, []int) {
+	return file_redis_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ExportJob) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ExportJob) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ExportJob) GetState() string {
+	if x != nil {
+		return x.State
+	}
+	return ""
+}
+
+func (x *ExportJob) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *ExportJob) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+func (x *ExportJob) GetFinishedAt() int64 {
+	if x != nil {
+		return x.FinishedAt
+	}
+	return 0
+}
+
+func (x *ExportJob) GetExpiresAt() int64 {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return 0
+}
+
+func (x *ExportJob) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+type ExportJobTtl struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Job             *ExportJob             `protobuf:"bytes,1,opt,name=job,proto3" json:"job,omitempty"`
+	TtlMilliseconds int64                  `protobuf:"varint,2,opt,name=ttlMilliseconds,proto3" json:"ttlMilliseconds,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ExportJobTtl) Reset() {
+	*x = ExportJobTtl{}
+	mi := &file_redis_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportJobTtl) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportJobTtl) ProtoMessage() {}
+
+func (x *ExportJobTtl) ProtoReflect() protoreflect.Message {
+	mi := &file_redis_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportJobTtl.ProtoReflect.Descriptor instead.
+func (*ExportJobTtl) Descriptor() ([]byte, []int) {
+	return file_redis_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ExportJobTtl) GetJob() *ExportJob {
+	if x != nil {
+		return x.Job
+	}
+	return nil
+}
+
+func (x *ExportJobTtl) GetTtlMilliseconds() int64 {
+	if x != nil {
+		return x.TtlMilliseconds
+	}
+	return 0
+}
+
+// RevokedFamilies token families revoked by auth. They are kept ttlMilliseconds, as long as access tokens of them live
+type RevokedFamilies struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Ids             []string               `protobuf:"bytes,1,rep,name=ids,proto3" json:"ids,omitempty"`
+	TtlMilliseconds int64                  `protobuf:"varint,2,opt,name=ttlMilliseconds,proto3" json:"ttlMilliseconds,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *RevokedFamilies) Reset() {
+	*x = RevokedFamilies{}
+	mi := &file_redis_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokedFamilies) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokedFamilies) ProtoMessage() {}
+
+func (x *RevokedFamilies) ProtoReflect() protoreflect.Message {
+	mi := &file_redis_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokedFamilies.ProtoReflect.Descriptor instead.
+func (*RevokedFamilies) Descriptor() ([]byte, []int) {
+	return file_redis_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *RevokedFamilies) GetIds() []string {
+	if x != nil {
+		return x.Ids
+	}
+	return nil
+}
+
+func (x *RevokedFamilies) GetTtlMilliseconds() int64 {
+	if x != nil {
+		return x.TtlMilliseconds
+	}
+	return 0
+}
+
 var File_redis_proto protoreflect.FileDescriptor
 
 const file_redis_proto_rawDesc = "" +
@@ -405,7 +717,13 @@ const file_redis_proto_rawDesc = "" +
 	"\n" +
 	"TagsByUser\x12\x17\n" +
 	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1e\n" +
-	"\x05items\x18\x02 \x03(\v2\b.brz.TagR\x05items\"I\n" +
+	"\x05items\x18\x02 \x03(\v2\b.brz.TagR\x05items\"`\n" +
+	"\x11PreferencesByUser\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x122\n" +
+	"\vpreferences\x18\x02 \x01(\v2\x10.brz.PreferencesR\vpreferences\"E\n" +
+	"\x14WorkspaceRolesByUser\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x14\n" +
+	"\x05roles\x18\x02 \x01(\tR\x05roles\"I\n" +
 	"\fBlocksOnNote\x12\x17\n" +
 	"\anote_id\x18\x01 \x01(\tR\x06noteId\x12 \n" +
 	"\x05items\x18\x02 \x03(\v2\n" +
@@ -415,23 +733,55 @@ const file_redis_proto_rawDesc = "" +
 	"\x12windowMilliseconds\x18\x02 \x01(\x03R\x12windowMilliseconds\";\n" +
 	"\x11RateLimitResponse\x12\x14\n" +
 	"\x05count\x18\x01 \x01(\x03R\x05count\x12\x10\n" +
-	"\x03ttl\x18\x02 \x01(\x03R\x03ttl2\xe9\x06\n" +
+	"\x03ttl\x18\x02 \x01(\x03R\x03ttl\"\xd3\x01\n" +
+	"\tExportJob\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x14\n" +
+	"\x05state\x18\x03 \x01(\tR\x05state\x12\x14\n" +
+	"\x05error\x18\x04 \x01(\tR\x05error\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x05 \x01(\x03R\tcreatedAt\x12\x1f\n" +
+	"\vfinished_at\x18\x06 \x01(\x03R\n" +
+	"finishedAt\x12\x1d\n" +
+	"\n" +
+	"expires_at\x18\a \x01(\x03R\texpiresAt\x12\x12\n" +
+	"\x04size\x18\b \x01(\x03R\x04size\"Z\n" +
+	"\fExportJobTtl\x12 \n" +
+	"\x03job\x18\x01 \x01(\v2\x0e.brz.ExportJobR\x03job\x12(\n" +
+	"\x0fttlMilliseconds\x18\x02 \x01(\x03R\x0fttlMilliseconds\"M\n" +
+	"\x0fRevokedFamilies\x12\x10\n" +
+	"\x03ids\x18\x01 \x03(\tR\x03ids\x12(\n" +
+	"\x0fttlMilliseconds\x18\x02 \x01(\x03R\x0fttlMilliseconds2\xe1\f\n" +
 	"\fRedisService\x125\n" +
 	"\rGetNoteByUser\x12\x0f.brz.UserNoteId\x1a\x13.brz.NoteWithBlocks\x120\n" +
 	"\x11GetNoteListByUser\x12\v.brz.UserId\x1a\x0e.brz.NoteParts\x126\n" +
 	"\x17GetNotesFromTrashByUser\x12\v.brz.UserId\x1a\x0e.brz.NoteParts\x12'\n" +
-	"\rGetTagsByUser\x12\v.brz.UserId\x1a\t.brz.Tags\x128\n" +
-	"\rSetTagsByUser\x12\x0f.brz.TagsByUser\x1a\x16.google.protobuf.Empty\x128\n" +
+	"\rGetTagsByUser\x12\v.brz.UserId\x1a\t.brz.Tags\x125\n" +
+	"\x14GetPreferencesByUser\x12\v.brz.UserId\x1a\x10.brz.Preferences\x123\n" +
+	"\x17GetWorkspaceRolesByUser\x12\v.brz.UserId\x1a\v.brz.String\x128\n" +
+	"\rSetTagsByUser\x12\x0f.brz.TagsByUser\x1a\x16.google.protobuf.Empty\x12F\n" +
+	"\x14SetPreferencesByUser\x12\x16.brz.PreferencesByUser\x1a\x16.google.protobuf.Empty\x12L\n" +
+	"\x17SetWorkspaceRolesByUser\x12\x19.brz.WorkspaceRolesByUser\x1a\x16.google.protobuf.Empty\x128\n" +
 	"\rSetNoteByUser\x12\x0f.brz.NoteByUser\x1a\x16.google.protobuf.Empty\x12F\n" +
 	"\x17SetNotesFromTrashByUser\x12\x13.brz.NoteListByUser\x1a\x16.google.protobuf.Empty\x12@\n" +
 	"\x11SetNoteListByUser\x12\x13.brz.NoteListByUser\x1a\x16.google.protobuf.Empty\x123\n" +
-	"\fRmTagsByUser\x12\v.brz.UserId\x1a\x16.google.protobuf.Empty\x127\n" +
+	"\fRmTagsByUser\x12\v.brz.UserId\x1a\x16.google.protobuf.Empty\x12:\n" +
+	"\x13RmPreferencesByUser\x12\v.brz.UserId\x1a\x16.google.protobuf.Empty\x12=\n" +
+	"\x16RmWorkspaceRolesByUser\x12\v.brz.UserId\x1a\x16.google.protobuf.Empty\x127\n" +
 	"\fRmNoteByUser\x12\x0f.brz.UserNoteId\x1a\x16.google.protobuf.Empty\x12=\n" +
 	"\x16RmNotesFromTrashByUser\x12\v.brz.UserId\x1a\x16.google.protobuf.Empty\x127\n" +
 	"\x10RmNoteListByUser\x12\v.brz.UserId\x1a\x16.google.protobuf.Empty\x124\n" +
-	"\rCleanNoteById\x12\v.brz.NoteId\x1a\x16.google.protobuf.Empty\x129\n" +
+	"\rCleanNoteById\x12\v.brz.NoteId\x1a\x16.google.protobuf.Empty\x126\n" +
+	"\x0fRmSessionByUser\x12\v.brz.UserId\x1a\x16.google.protobuf.Empty\x12+\n" +
+	"\fGetExportJob\x12\v.brz.UserId\x1a\x0e.brz.ExportJob\x129\n" +
+	"\fSetExportJob\x12\x11.brz.ExportJobTtl\x1a\x16.google.protobuf.Empty\x12>\n" +
+	"\x0eRevokeFamilies\x12\x14.brz.RevokedFamilies\x1a\x16.google.protobuf.Empty\x121\n" +
+	"\x0fIsFamilyRevoked\x12\v.brz.String\x1a\x11.brz.BoolResponse\x129\n" +
 	"\aHealthz\x12\x16.google.protobuf.Empty\x1a\x16.google.protobuf.Empty\x12:\n" +
-	"\tRateLimit\x12\x15.brz.RateLimitRequest\x1a\x16.brz.RateLimitResponseB,Z*github.com/autumnterror/breezynotes;brzrpcb\x06proto3"
+	"\tRateLimit\x12\x15.brz.RateLimitRequest\x1a\x16.brz.RateLimitResponse\x121\n" +
+	"\n" +
+	"GetCounter\x12\v.brz.String\x1a\x16.brz.RateLimitResponse\x123\n" +
+	"\fResetCounter\x12\v.brz.String\x1a\x16.google.protobuf.EmptyB,Z*github.com/autumnterror/breezynotes;brzrpcb\x06proto3"
 
 var (
 	file_redis_proto_rawDescOnce sync.Once
@@ -445,68 +795,104 @@ func file_redis_proto_rawDescGZIP() []byte {
 	return file_redis_proto_rawDescData
 }
 
-var file_redis_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+var file_redis_proto_msgTypes = make([]protoimpl.MessageInfo, 12)
 var file_redis_proto_goTypes = []any{
-	(*NoteListByUser)(nil),    // 0: brz.NoteListByUser
-	(*NotesByUser)(nil),       // 1: brz.NotesByUser
-	(*NoteByUser)(nil),        // 2: brz.NoteByUser
-	(*TagsByUser)(nil),        // 3: brz.TagsByUser
-	(*BlocksOnNote)(nil),      // 4: brz.BlocksOnNote
-	(*RateLimitRequest)(nil),  // 5: brz.RateLimitRequest
-	(*RateLimitResponse)(nil), // 6: brz.RateLimitResponse
-	(*NotePart)(nil),          // 7: brz.NotePart
-	(*Note)(nil),              // 8: brz.Note
-	(*NoteWithBlocks)(nil),    // 9: brz.NoteWithBlocks
-	(*Tag)(nil),               // 10: brz.Tag
-	(*Block)(nil),             // 11: brz.Block
-	(*UserNoteId)(nil),        // 12: brz.UserNoteId
-	(*UserId)(nil),            // 13: brz.UserId
-	(*NoteId)(nil),            // 14: brz.NoteId
-	(*emptypb.Empty)(nil),     // 15: google.protobuf.Empty
-	(*NoteParts)(nil),         // 16: brz.NoteParts
-	(*Tags)(nil),              // 17: brz.Tags
+	(*NoteListByUser)(nil),       // 0: brz.NoteListByUser
+	(*NotesByUser)(nil),          // 1: brz.NotesByUser
+	(*NoteByUser)(nil),           // 2: brz.NoteByUser
+	(*TagsByUser)(nil),           // 3: brz.TagsByUser
+	(*PreferencesByUser)(nil),    // 4: brz.PreferencesByUser
+	(*WorkspaceRolesByUser)(nil), // 5: brz.WorkspaceRolesByUser
+	(*BlocksOnNote)(nil),         // 6: brz.BlocksOnNote
+	(*RateLimitRequest)(nil),     // 7: brz.RateLimitRequest
+	(*RateLimitResponse)(nil),    // 8: brz.RateLimitResponse
+	(*ExportJob)(nil),            // 9: brz.ExportJob
+	(*ExportJobTtl)(nil),         // 10: brz.ExportJobTtl
+	(*RevokedFamilies)(nil),      // 11: brz.RevokedFamilies
+	(*NotePart)(nil),             // 12: brz.NotePart
+	(*Note)(nil),                 // 13: brz.Note
+	(*NoteWithBlocks)(nil),       // 14: brz.NoteWithBlocks
+	(*Tag)(nil),                  // 15: brz.Tag
+	(*Preferences)(nil),          // 16: brz.Preferences
+	(*Block)(nil),                // 17: brz.Block
+	(*UserNoteId)(nil),           // 18: brz.UserNoteId
+	(*UserId)(nil),               // 19: brz.UserId
+	(*NoteId)(nil),               // 20: brz.NoteId
+	(*String)(nil),               // 21: brz.String
+	(*emptypb.Empty)(nil),        // 22: google.protobuf.Empty
+	(*NoteParts)(nil),            // 23: brz.NoteParts
+	(*Tags)(nil),                 // 24: brz.Tags
+	(*BoolResponse)(nil),         // 25: brz.BoolResponse
 }
 var file_redis_proto_depIdxs = []int32{
-	7,  // 0: brz.NoteListByUser.items:type_name -> brz.NotePart
-	8,  // 1: brz.NotesByUser.items:type_name -> brz.Note
-	9,  // 2: brz.NoteByUser.note:type_name -> brz.NoteWithBlocks
-	10, // 3: brz.TagsByUser.items:type_name -> brz.Tag
-	11, // 4: brz.BlocksOnNote.items:type_name -> brz.Block
-	12, // 5: brz.RedisService.GetNoteByUser:input_type -> brz.UserNoteId
-	13, // 6: brz.RedisService.GetNoteListByUser:input_type -> brz.UserId
-	13, // 7: brz.RedisService.GetNotesFromTrashByUser:input_type -> brz.UserId
-	13, // 8: brz.RedisService.GetTagsByUser:input_type -> brz.UserId
-	3,  // 9: brz.RedisService.SetTagsByUser:input_type -> brz.TagsByUser
-	2,  // 10: brz.RedisService.SetNoteByUser:input_type -> brz.NoteByUser
-	0,  // 11: brz.RedisService.SetNotesFromTrashByUser:input_type -> brz.NoteListByUser
-	0,  // 12: brz.RedisService.SetNoteListByUser:input_type -> brz.NoteListByUser
-	13, // 13: brz.RedisService.RmTagsByUser:input_type -> brz.UserId
-	12, // 14: brz.RedisService.RmNoteByUser:input_type -> brz.UserNoteId
-	13, // 15: brz.RedisService.RmNotesFromTrashByUser:input_type -> brz.UserId
-	13, // 16: brz.RedisService.RmNoteListByUser:input_type -> brz.UserId
-	14, // 17: brz.RedisService.CleanNoteById:input_type -> brz.NoteId
-	15, // 18: brz.RedisService.Healthz:input_type -> google.protobuf.Empty
-	5,  // 19: brz.RedisService.RateLimit:input_type -> brz.RateLimitRequest
-	9,  // 20: brz.RedisService.GetNoteByUser:output_type -> brz.NoteWithBlocks
-	16, // 21: brz.RedisService.GetNoteListByUser:output_type -> brz.NoteParts
-	16, // 22: brz.RedisService.GetNotesFromTrashByUser:output_type -> brz.NoteParts
-	17, // 23: brz.RedisService.GetTagsByUser:output_type -> brz.Tags
-	15, // 24: brz.RedisService.SetTagsByUser:output_type -> google.protobuf.Empty
-	15, // 25: brz.RedisService.SetNoteByUser:output_type -> google.protobuf.Empty
-	15, // 26: brz.RedisService.SetNotesFromTrashByUser:output_type -> google.protobuf.Empty
-	15, // 27: brz.RedisService.SetNoteListByUser:output_type -> google.protobuf.Empty
-	15, // 28: brz.RedisService.RmTagsByUser:output_type -> google.protobuf.Empty
-	15, // 29: brz.RedisService.RmNoteByUser:output_type -> google.protobuf.Empty
-	15, // 30: brz.RedisService.RmNotesFromTrashByUser:output_type -> google.protobuf.Empty
-	15, // 31: brz.RedisService.RmNoteListByUser:output_type -> google.protobuf.Empty
-	15, // 32: brz.RedisService.CleanNoteById:output_type -> google.protobuf.Empty
-	15, // 33: brz.RedisService.Healthz:output_type -> google.protobuf.Empty
-	6,  // 34: brz.RedisService.RateLimit:output_type -> brz.RateLimitResponse
-	20, // [20:35] is the sub-list for method output_type
-	5,  // [5:20] is the sub-list for method input_type
-	5,  // [5:5] is the sub-list for extension type_name
-	5,  // [5:5] is the sub-list for extension extendee
-	0,  // [0:5] is the sub-list for field type_name
+	12, // 0: brz.NoteListByUser.items:type_name -> brz.NotePart
+	13, // 1: brz.NotesByUser.items:type_name -> brz.Note
+	14, // 2: brz.NoteByUser.note:type_name -> brz.NoteWithBlocks
+	15, // 3: brz.TagsByUser.items:type_name -> brz.Tag
+	16, // 4: brz.PreferencesByUser.preferences:type_name -> brz.Preferences
+	17, // 5: brz.BlocksOnNote.items:type_name -> brz.Block
+	9,  // 6: brz.ExportJobTtl.job:type_name -> brz.ExportJob
+	18, // 7: brz.RedisService.GetNoteByUser:input_type -> brz.UserNoteId
+	19, // 8: brz.RedisService.GetNoteListByUser:input_type -> brz.UserId
+	19, // 9: brz.RedisService.GetNotesFromTrashByUser:input_type -> brz.UserId
+	19, // 10: brz.RedisService.GetTagsByUser:input_type -> brz.UserId
+	19, // 11: brz.RedisService.GetPreferencesByUser:input_type -> brz.UserId
+	19, // 12: brz.RedisService.GetWorkspaceRolesByUser:input_type -> brz.UserId
+	3,  // 13: brz.RedisService.SetTagsByUser:input_type -> brz.TagsByUser
+	4,  // 14: brz.RedisService.SetPreferencesByUser:input_type -> brz.PreferencesByUser
+	5,  // 15: brz.RedisService.SetWorkspaceRolesByUser:input_type -> brz.WorkspaceRolesByUser
+	2,  // 16: brz.RedisService.SetNoteByUser:input_type -> brz.NoteByUser
+	0,  // 17: brz.RedisService.SetNotesFromTrashByUser:input_type -> brz.NoteListByUser
+	0,  // 18: brz.RedisService.SetNoteListByUser:input_type -> brz.NoteListByUser
+	19, // 19: brz.RedisService.RmTagsByUser:input_type -> brz.UserId
+	19, // 20: brz.RedisService.RmPreferencesByUser:input_type -> brz.UserId
+	19, // 21: brz.RedisService.RmWorkspaceRolesByUser:input_type -> brz.UserId
+	18, // 22: brz.RedisService.RmNoteByUser:input_type -> brz.UserNoteId
+	19, // 23: brz.RedisService.RmNotesFromTrashByUser:input_type -> brz.UserId
+	19, // 24: brz.RedisService.RmNoteListByUser:input_type -> brz.UserId
+	20, // 25: brz.RedisService.CleanNoteById:input_type -> brz.NoteId
+	19, // 26: brz.RedisService.RmSessionByUser:input_type -> brz.UserId
+	19, // 27: brz.RedisService.GetExportJob:input_type -> brz.UserId
+	10, // 28: brz.RedisService.SetExportJob:input_type -> brz.ExportJobTtl
+	11, // 29: brz.RedisService.RevokeFamilies:input_type -> brz.RevokedFamilies
+	21, // 30: brz.RedisService.IsFamilyRevoked:input_type -> brz.String
+	22, // 31: brz.RedisService.Healthz:input_type -> google.protobuf.Empty
+	7,  // 32: brz.RedisService.RateLimit:input_type -> brz.RateLimitRequest
+	21, // 33: brz.RedisService.GetCounter:input_type -> brz.String
+	21, // 34: brz.RedisService.ResetCounter:input_type -> brz.String
+	14, // 35: brz.RedisService.GetNoteByUser:output_type -> brz.NoteWithBlocks
+	23, // 36: brz.RedisService.GetNoteListByUser:output_type -> brz.NoteParts
+	23, // 37: brz.RedisService.GetNotesFromTrashByUser:output_type -> brz.NoteParts
+	24, // 38: brz.RedisService.GetTagsByUser:output_type -> brz.Tags
+	16, // 39: brz.RedisService.GetPreferencesByUser:output_type -> brz.Preferences
+	21, // 40: brz.RedisService.GetWorkspaceRolesByUser:output_type -> brz.String
+	22, // 41: brz.RedisService.SetTagsByUser:output_type -> google.protobuf.Empty
+	22, // 42: brz.RedisService.SetPreferencesByUser:output_type -> google.protobuf.Empty
+	22, // 43: brz.RedisService.SetWorkspaceRolesByUser:output_type -> google.protobuf.Empty
+	22, // 44: brz.RedisService.SetNoteByUser:output_type -> google.protobuf.Empty
+	22, // 45: brz.RedisService.SetNotesFromTrashByUser:output_type -> google.protobuf.Empty
+	22, // 46: brz.RedisService.SetNoteListByUser:output_type -> google.protobuf.Empty
+	22, // 47: brz.RedisService.RmTagsByUser:output_type -> google.protobuf.Empty
+	22, // 48: brz.RedisService.RmPreferencesByUser:output_type -> google.protobuf.Empty
+	22, // 49: brz.RedisService.RmWorkspaceRolesByUser:output_type -> google.protobuf.Empty
+	22, // 50: brz.RedisService.RmNoteByUser:output_type -> google.protobuf.Empty
+	22, // 51: brz.RedisService.RmNotesFromTrashByUser:output_type -> google.protobuf.Empty
+	22, // 52: brz.RedisService.RmNoteListByUser:output_type -> google.protobuf.Empty
+	22, // 53: brz.RedisService.CleanNoteById:output_type -> google.protobuf.Empty
+	22, // 54: brz.RedisService.RmSessionByUser:output_type -> google.protobuf.Empty
+	9,  // 55: brz.RedisService.GetExportJob:output_type -> brz.ExportJob
+	22, // 56: brz.RedisService.SetExportJob:output_type -> google.protobuf.Empty
+	22, // 57: brz.RedisService.RevokeFamilies:output_type -> google.protobuf.Empty
+	25, // 58: brz.RedisService.IsFamilyRevoked:output_type -> brz.BoolResponse
+	22, // 59: brz.RedisService.Healthz:output_type -> google.protobuf.Empty
+	8,  // 60: brz.RedisService.RateLimit:output_type -> brz.RateLimitResponse
+	8,  // 61: brz.RedisService.GetCounter:output_type -> brz.RateLimitResponse
+	22, // 62: brz.RedisService.ResetCounter:output_type -> google.protobuf.Empty
+	35, // [35:63] is the sub-list for method output_type
+	7,  // [7:35] is the sub-list for method input_type
+	7,  // [7:7] is the sub-list for extension type_name
+	7,  // [7:7] is the sub-list for extension extendee
+	0,  // [0:7] is the sub-list for field type_name
 }
 
 func init() { file_redis_proto_init() }
@@ -521,7 +907,7 @@ func file_redis_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_redis_proto_rawDesc), len(file_redis_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   7,
+			NumMessages:   12,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -24,17 +24,30 @@ const (
 	RedisService_GetNoteListByUser_FullMethodName       = "/brz.RedisService/GetNoteListByUser"
 	RedisService_GetNotesFromTrashByUser_FullMethodName = "/brz.RedisService/GetNotesFromTrashByUser"
 	RedisService_GetTagsByUser_FullMethodName           = "/brz.RedisService/GetTagsByUser"
+	RedisService_GetPreferencesByUser_FullMethodName    = "/brz.RedisService/GetPreferencesByUser"
+	RedisService_GetWorkspaceRolesByUser_FullMethodName = "/brz.RedisService/GetWorkspaceRolesByUser"
 	RedisService_SetTagsByUser_FullMethodName           = "/brz.RedisService/SetTagsByUser"
+	RedisService_SetPreferencesByUser_FullMethodName    = "/brz.RedisService/SetPreferencesByUser"
+	RedisService_SetWorkspaceRolesByUser_FullMethodName = "/brz.RedisService/SetWorkspaceRolesByUser"
 	RedisService_SetNoteByUser_FullMethodName           = "/brz.RedisService/SetNoteByUser"
 	RedisService_SetNotesFromTrashByUser_FullMethodName = "/brz.RedisService/SetNotesFromTrashByUser"
 	RedisService_SetNoteListByUser_FullMethodName       = "/brz.RedisService/SetNoteListByUser"
 	RedisService_RmTagsByUser_FullMethodName            = "/brz.RedisService/RmTagsByUser"
+	RedisService_RmPreferencesByUser_FullMethodName     = "/brz.RedisService/RmPreferencesByUser"
+	RedisService_RmWorkspaceRolesByUser_FullMethodName  = "/brz.RedisService/RmWorkspaceRolesByUser"
 	RedisService_RmNoteByUser_FullMethodName            = "/brz.RedisService/RmNoteByUser"
 	RedisService_RmNotesFromTrashByUser_FullMethodName  = "/brz.RedisService/RmNotesFromTrashByUser"
 	RedisService_RmNoteListByUser_FullMethodName        = "/brz.RedisService/RmNoteListByUser"
 	RedisService_CleanNoteById_FullMethodName           = "/brz.RedisService/CleanNoteById"
+	RedisService_RmSessionByUser_FullMethodName         = "/brz.RedisService/RmSessionByUser"
+	RedisService_GetExportJob_FullMethodName            = "/brz.RedisService/GetExportJob"
+	RedisService_SetExportJob_FullMethodName            = "/brz.RedisService/SetExportJob"
+	RedisService_RevokeFamilies_FullMethodName          = "/brz.RedisService/RevokeFamilies"
+	RedisService_IsFamilyRevoked_FullMethodName         = "/brz.RedisService/IsFamilyRevoked"
 	RedisService_Healthz_FullMethodName                 = "/brz.RedisService/Healthz"
 	RedisService_RateLimit_FullMethodName               = "/brz.RedisService/RateLimit"
+	RedisService_GetCounter_FullMethodName              = "/brz.RedisService/GetCounter"
+	RedisService_ResetCounter_FullMethodName            = "/brz.RedisService/ResetCounter"
 )
 
 // RedisServiceClient is the client API for RedisService service.
@@ -45,17 +58,36 @@ type RedisServiceClient interface {
 	GetNoteListByUser(ctx context.Context, in *UserId, opts ...grpc.CallOption) (*NoteParts, error)
 	GetNotesFromTrashByUser(ctx context.Context, in *UserId, opts ...grpc.CallOption) (*NoteParts, error)
 	GetTagsByUser(ctx context.Context, in *UserId, opts ...grpc.CallOption) (*Tags, error)
+	// GetPreferencesByUser return NotFound if preferences are not cached
+	GetPreferencesByUser(ctx context.Context, in *UserId, opts ...grpc.CallOption) (*Preferences, error)
+	// GetWorkspaceRolesByUser return NotFound if membership is not cached, value is roles of WorkspaceRolesByUser
+	GetWorkspaceRolesByUser(ctx context.Context, in *UserId, opts ...grpc.CallOption) (*String, error)
 	SetTagsByUser(ctx context.Context, in *TagsByUser, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	SetPreferencesByUser(ctx context.Context, in *PreferencesByUser, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	SetWorkspaceRolesByUser(ctx context.Context, in *WorkspaceRolesByUser, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	SetNoteByUser(ctx context.Context, in *NoteByUser, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	SetNotesFromTrashByUser(ctx context.Context, in *NoteListByUser, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	SetNoteListByUser(ctx context.Context, in *NoteListByUser, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	RmTagsByUser(ctx context.Context, in *UserId, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	RmPreferencesByUser(ctx context.Context, in *UserId, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	RmWorkspaceRolesByUser(ctx context.Context, in *UserId, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	RmNoteByUser(ctx context.Context, in *UserNoteId, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	RmNotesFromTrashByUser(ctx context.Context, in *UserId, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	RmNoteListByUser(ctx context.Context, in *UserId, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	CleanNoteById(ctx context.Context, in *NoteId, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// RmSessionByUser remove whole cache of user
+	RmSessionByUser(ctx context.Context, in *UserId, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// GetExportJob return last export of user, NotFound if there is none or it is expired
+	GetExportJob(ctx context.Context, in *UserId, opts ...grpc.CallOption) (*ExportJob, error)
+	SetExportJob(ctx context.Context, in *ExportJobTtl, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// RevokeFamilies deny access tokens of families until they expire
+	RevokeFamilies(ctx context.Context, in *RevokedFamilies, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	IsFamilyRevoked(ctx context.Context, in *String, opts ...grpc.CallOption) (*BoolResponse, error)
 	Healthz(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	RateLimit(ctx context.Context, in *RateLimitRequest, opts ...grpc.CallOption) (*RateLimitResponse, error)
+	// GetCounter return counter of RateLimit without increment, count is 0 if window is over
+	GetCounter(ctx context.Context, in *String, opts ...grpc.CallOption) (*RateLimitResponse, error)
+	ResetCounter(ctx context.Context, in *String, opts ...grpc.CallOption) (*emptypb.Empty, error)
 }
 
 type redisServiceClient struct {
@@ -106,6 +138,26 @@ func (c *redisServiceClient) GetTagsByUser(ctx context.Context, in *UserId, opts
 	return out, nil
 }
 
+func (c *redisServiceClient) GetPreferencesByUser(ctx context.Context, in *UserId, opts ...grpc.CallOption) (*Preferences, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Preferences)
+	err := c.cc.Invoke(ctx, RedisService_GetPreferencesByUser_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *redisServiceClient) GetWorkspaceRolesByUser(ctx context.Context, in *UserId, opts ...grpc.CallOption) (*String, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(String)
+	err := c.cc.Invoke(ctx, RedisService_GetWorkspaceRolesByUser_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *redisServiceClient) SetTagsByUser(ctx context.Context, in *TagsByUser, opts ...grpc.CallOption) (*emptypb.Empty, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(emptypb.Empty)
@@ -116,6 +168,26 @@ func (c *redisServiceClient) SetTagsByUser(ctx context.Context, in *TagsByUser,
 	return out, nil
 }
 
+func (c *redisServiceClient) SetPreferencesByUser(ctx context.Context, in *PreferencesByUser, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, RedisService_SetPreferencesByUser_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *redisServiceClient) SetWorkspaceRolesByUser(ctx context.Context, in *WorkspaceRolesByUser, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, RedisService_SetWorkspaceRolesByUser_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *redisServiceClient) SetNoteByUser(ctx context.Context, in *NoteByUser, opts ...grpc.CallOption) (*emptypb.Empty, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(emptypb.Empty)
@@ -156,6 +228,26 @@ func (c *redisServiceClient) RmTagsByUser(ctx context.Context, in *UserId, opts
 	return out, nil
 }
 
+func (c *redisServiceClient) RmPreferencesByUser(ctx context.Context, in *UserId, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, RedisService_RmPreferencesByUser_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *redisServiceClient) RmWorkspaceRolesByUser(ctx context.Context, in *UserId, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, RedisService_RmWorkspaceRolesByUser_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *redisServiceClient) RmNoteByUser(ctx context.Context, in *UserNoteId, opts ...grpc.CallOption) (*emptypb.Empty, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(emptypb.Empty)
@@ -196,6 +288,56 @@ func (c *redisServiceClient) CleanNoteById(ctx context.Context, in *NoteId, opts
 	return out, nil
 }
 
+func (c *redisServiceClient) RmSessionByUser(ctx context.Context, in *UserId, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, RedisService_RmSessionByUser_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *redisServiceClient) GetExportJob(ctx context.Context, in *UserId, opts ...grpc.CallOption) (*ExportJob, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ExportJob)
+	err := c.cc.Invoke(ctx, RedisService_GetExportJob_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *redisServiceClient) SetExportJob(ctx context.Context, in *ExportJobTtl, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, RedisService_SetExportJob_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *redisServiceClient) RevokeFamilies(ctx context.Context, in *RevokedFamilies, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, RedisService_RevokeFamilies_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *redisServiceClient) IsFamilyRevoked(ctx context.Context, in *String, opts ...grpc.CallOption) (*BoolResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BoolResponse)
+	err := c.cc.Invoke(ctx, RedisService_IsFamilyRevoked_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *redisServiceClient) Healthz(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*emptypb.Empty, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(emptypb.Empty)
@@ -216,6 +358,26 @@ func (c *redisServiceClient) RateLimit(ctx context.Context, in *RateLimitRequest
 	return out, nil
 }
 
+func (c *redisServiceClient) GetCounter(ctx context.Context, in *String, opts ...grpc.CallOption) (*RateLimitResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RateLimitResponse)
+	err := c.cc.Invoke(ctx, RedisService_GetCounter_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *redisServiceClient) ResetCounter(ctx context.Context, in *String, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, RedisService_ResetCounter_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // RedisServiceServer is the server API for RedisService service.
 // All implementations must embed UnimplementedRedisServiceServer
 // for forward compatibility.
@@ -224,17 +386,36 @@ type RedisServiceServer interface {
 	GetNoteListByUser(context.Context, *UserId) (*NoteParts, error)
 	GetNotesFromTrashByUser(context.Context, *UserId) (*NoteParts, error)
 	GetTagsByUser(context.Context, *UserId) (*Tags, error)
+	// GetPreferencesByUser return NotFound if preferences are not cached
+	GetPreferencesByUser(context.Context, *UserId) (*Preferences, error)
+	// GetWorkspaceRolesByUser return NotFound if membership is not cached, value is roles of WorkspaceRolesByUser
+	GetWorkspaceRolesByUser(context.Context, *UserId) (*String, error)
 	SetTagsByUser(context.Context, *TagsByUser) (*emptypb.Empty, error)
+	SetPreferencesByUser(context.Context, *PreferencesByUser) (*emptypb.Empty, error)
+	SetWorkspaceRolesByUser(context.Context, *WorkspaceRolesByUser) (*emptypb.Empty, error)
 	SetNoteByUser(context.Context, *NoteByUser) (*emptypb.Empty, error)
 	SetNotesFromTrashByUser(context.Context, *NoteListByUser) (*emptypb.Empty, error)
 	SetNoteListByUser(context.Context, *NoteListByUser) (*emptypb.Empty, error)
 	RmTagsByUser(context.Context, *UserId) (*emptypb.Empty, error)
+	RmPreferencesByUser(context.Context, *UserId) (*emptypb.Empty, error)
+	RmWorkspaceRolesByUser(context.Context, *UserId) (*emptypb.Empty, error)
 	RmNoteByUser(context.Context, *UserNoteId) (*emptypb.Empty, error)
 	RmNotesFromTrashByUser(context.Context, *UserId) (*emptypb.Empty, error)
 	RmNoteListByUser(context.Context, *UserId) (*emptypb.Empty, error)
 	CleanNoteById(context.Context, *NoteId) (*emptypb.Empty, error)
+	// RmSessionByUser remove whole cache of user
+	RmSessionByUser(context.Context, *UserId) (*emptypb.Empty, error)
+	// GetExportJob return last export of user, NotFound if there is none or it is expired
+	GetExportJob(context.Context, *UserId) (*ExportJob, error)
+	SetExportJob(context.Context, *ExportJobTtl) (*emptypb.Empty, error)
+	// RevokeFamilies deny access tokens of families until they expire
+	RevokeFamilies(context.Context, *RevokedFamilies) (*emptypb.Empty, error)
+	IsFamilyRevoked(context.Context, *String) (*BoolResponse, error)
 	Healthz(context.Context, *emptypb.Empty) (*emptypb.Empty, error)
 	RateLimit(context.Context, *RateLimitRequest) (*RateLimitResponse, error)
+	// GetCounter return counter of RateLimit without increment, count is 0 if window is over
+	GetCounter(context.Context, *String) (*RateLimitResponse, error)
+	ResetCounter(context.Context, *String) (*emptypb.Empty, error)
 	mustEmbedUnimplementedRedisServiceServer()
 }
 
@@ -257,9 +438,21 @@ func (UnimplementedRedisServiceServer) GetNotesFromTrashByUser(context.Context,
 func (UnimplementedRedisServiceServer) GetTagsByUser(context.Context, *UserId) (*Tags, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetTagsByUser not implemented")
 }
+func (UnimplementedRedisServiceServer) GetPreferencesByUser(context.Context, *UserId) (*Preferences, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPreferencesByUser not implemented")
+}
+func (UnimplementedRedisServiceServer) GetWorkspaceRolesByUser(context.Context, *UserId) (*String, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetWorkspaceRolesByUser not implemented")
+}
 func (UnimplementedRedisServiceServer) SetTagsByUser(context.Context, *TagsByUser) (*emptypb.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method SetTagsByUser not implemented")
 }
+func (UnimplementedRedisServiceServer) SetPreferencesByUser(context.Context, *PreferencesByUser) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetPreferencesByUser not implemented")
+}
+func (UnimplementedRedisServiceServer) SetWorkspaceRolesByUser(context.Context, *WorkspaceRolesByUser) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetWorkspaceRolesByUser not implemented")
+}
 func (UnimplementedRedisServiceServer) SetNoteByUser(context.Context, *NoteByUser) (*emptypb.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method SetNoteByUser not implemented")
 }
@@ -272,6 +465,12 @@ func (UnimplementedRedisServiceServer) SetNoteListByUser(context.Context, *NoteL
 func (UnimplementedRedisServiceServer) RmTagsByUser(context.Context, *UserId) (*emptypb.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method RmTagsByUser not implemented")
 }
+func (UnimplementedRedisServiceServer) RmPreferencesByUser(context.Context, *UserId) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RmPreferencesByUser not implemented")
+}
+func (UnimplementedRedisServiceServer) RmWorkspaceRolesByUser(context.Context, *UserId) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RmWorkspaceRolesByUser not implemented")
+}
 func (UnimplementedRedisServiceServer) RmNoteByUser(context.Context, *UserNoteId) (*emptypb.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method RmNoteByUser not implemented")
 }
@@ -284,12 +483,33 @@ func (UnimplementedRedisServiceServer) RmNoteListByUser(context.Context, *UserId
 func (UnimplementedRedisServiceServer) CleanNoteById(context.Context, *NoteId) (*emptypb.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CleanNoteById not implemented")
 }
+func (UnimplementedRedisServiceServer) RmSessionByUser(context.Context, *UserId) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RmSessionByUser not implemented")
+}
+func (UnimplementedRedisServiceServer) GetExportJob(context.Context, *UserId) (*ExportJob, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetExportJob not implemented")
+}
+func (UnimplementedRedisServiceServer) SetExportJob(context.Context, *ExportJobTtl) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetExportJob not implemented")
+}
+func (UnimplementedRedisServiceServer) RevokeFamilies(context.Context, *RevokedFamilies) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RevokeFamilies not implemented")
+}
+func (UnimplementedRedisServiceServer) IsFamilyRevoked(context.Context, *String) (*BoolResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method IsFamilyRevoked not implemented")
+}
 func (UnimplementedRedisServiceServer) Healthz(context.Context, *emptypb.Empty) (*emptypb.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Healthz not implemented")
 }
 func (UnimplementedRedisServiceServer) RateLimit(context.Context, *RateLimitRequest) (*RateLimitResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method RateLimit not implemented")
 }
+func (UnimplementedRedisServiceServer) GetCounter(context.Context, *String) (*RateLimitResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCounter not implemented")
+}
+func (UnimplementedRedisServiceServer) ResetCounter(context.Context, *String) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ResetCounter not implemented")
+}
 func (UnimplementedRedisServiceServer) mustEmbedUnimplementedRedisServiceServer() {}
 func (UnimplementedRedisServiceServer) testEmbeddedByValue()                      {}
 
@@ -383,6 +603,42 @@ func _RedisService_GetTagsByUser_Handler(srv interface{}, ctx context.Context, d
 	return interceptor(ctx, in, info, handler)
 }
 
+func _RedisService_GetPreferencesByUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserId)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RedisServiceServer).GetPreferencesByUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RedisService_GetPreferencesByUser_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RedisServiceServer).GetPreferencesByUser(ctx, req.(*UserId))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RedisService_GetWorkspaceRolesByUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserId)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RedisServiceServer).GetWorkspaceRolesByUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RedisService_GetWorkspaceRolesByUser_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RedisServiceServer).GetWorkspaceRolesByUser(ctx, req.(*UserId))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _RedisService_SetTagsByUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(TagsByUser)
 	if err := dec(in); err != nil {
@@ -401,6 +657,42 @@ func _RedisService_SetTagsByUser_Handler(srv interface{}, ctx context.Context, d
 	return interceptor(ctx, in, info, handler)
 }
 
+func _RedisService_SetPreferencesByUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PreferencesByUser)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RedisServiceServer).SetPreferencesByUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RedisService_SetPreferencesByUser_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RedisServiceServer).SetPreferencesByUser(ctx, req.(*PreferencesByUser))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RedisService_SetWorkspaceRolesByUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WorkspaceRolesByUser)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RedisServiceServer).SetWorkspaceRolesByUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RedisService_SetWorkspaceRolesByUser_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RedisServiceServer).SetWorkspaceRolesByUser(ctx, req.(*WorkspaceRolesByUser))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _RedisService_SetNoteByUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(NoteByUser)
 	if err := dec(in); err != nil {
@@ -473,6 +765,42 @@ func _RedisService_RmTagsByUser_Handler(srv interface{}, ctx context.Context, de
 	return interceptor(ctx, in, info, handler)
 }
 
+func _RedisService_RmPreferencesByUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserId)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RedisServiceServer).RmPreferencesByUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RedisService_RmPreferencesByUser_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RedisServiceServer).RmPreferencesByUser(ctx, req.(*UserId))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RedisService_RmWorkspaceRolesByUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserId)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RedisServiceServer).RmWorkspaceRolesByUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RedisService_RmWorkspaceRolesByUser_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RedisServiceServer).RmWorkspaceRolesByUser(ctx, req.(*UserId))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _RedisService_RmNoteByUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(UserNoteId)
 	if err := dec(in); err != nil {
@@ -545,6 +873,96 @@ func _RedisService_CleanNoteById_Handler(srv interface{}, ctx context.Context, d
 	return interceptor(ctx, in, info, handler)
 }
 
+func _RedisService_RmSessionByUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserId)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RedisServiceServer).RmSessionByUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RedisService_RmSessionByUser_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RedisServiceServer).RmSessionByUser(ctx, req.(*UserId))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RedisService_GetExportJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserId)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RedisServiceServer).GetExportJob(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RedisService_GetExportJob_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RedisServiceServer).GetExportJob(ctx, req.(*UserId))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RedisService_SetExportJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExportJobTtl)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RedisServiceServer).SetExportJob(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RedisService_SetExportJob_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RedisServiceServer).SetExportJob(ctx, req.(*ExportJobTtl))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RedisService_RevokeFamilies_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevokedFamilies)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RedisServiceServer).RevokeFamilies(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RedisService_RevokeFamilies_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RedisServiceServer).RevokeFamilies(ctx, req.(*RevokedFamilies))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RedisService_IsFamilyRevoked_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(String)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RedisServiceServer).IsFamilyRevoked(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RedisService_IsFamilyRevoked_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RedisServiceServer).IsFamilyRevoked(ctx, req.(*String))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _RedisService_Healthz_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(emptypb.Empty)
 	if err := dec(in); err != nil {
@@ -581,6 +999,42 @@ func _RedisService_RateLimit_Handler(srv interface{}, ctx context.Context, dec f
 	return interceptor(ctx, in, info, handler)
 }
 
+func _RedisService_GetCounter_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(String)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RedisServiceServer).GetCounter(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RedisService_GetCounter_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RedisServiceServer).GetCounter(ctx, req.(*String))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RedisService_ResetCounter_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(String)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RedisServiceServer).ResetCounter(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RedisService_ResetCounter_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RedisServiceServer).ResetCounter(ctx, req.(*String))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // RedisService_ServiceDesc is the grpc.ServiceDesc for RedisService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -604,10 +1058,26 @@ var RedisService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetTagsByUser",
 			Handler:    _RedisService_GetTagsByUser_Handler,
 		},
+		{
+			MethodName: "GetPreferencesByUser",
+			Handler:    _RedisService_GetPreferencesByUser_Handler,
+		},
+		{
+			MethodName: "GetWorkspaceRolesByUser",
+			Handler:    _RedisService_GetWorkspaceRolesByUser_Handler,
+		},
 		{
 			MethodName: "SetTagsByUser",
 			Handler:    _RedisService_SetTagsByUser_Handler,
 		},
+		{
+			MethodName: "SetPreferencesByUser",
+			Handler:    _RedisService_SetPreferencesByUser_Handler,
+		},
+		{
+			MethodName: "SetWorkspaceRolesByUser",
+			Handler:    _RedisService_SetWorkspaceRolesByUser_Handler,
+		},
 		{
 			MethodName: "SetNoteByUser",
 			Handler:    _RedisService_SetNoteByUser_Handler,
@@ -624,6 +1094,14 @@ var RedisService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "RmTagsByUser",
 			Handler:    _RedisService_RmTagsByUser_Handler,
 		},
+		{
+			MethodName: "RmPreferencesByUser",
+			Handler:    _RedisService_RmPreferencesByUser_Handler,
+		},
+		{
+			MethodName: "RmWorkspaceRolesByUser",
+			Handler:    _RedisService_RmWorkspaceRolesByUser_Handler,
+		},
 		{
 			MethodName: "RmNoteByUser",
 			Handler:    _RedisService_RmNoteByUser_Handler,
@@ -640,6 +1118,26 @@ var RedisService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "CleanNoteById",
 			Handler:    _RedisService_CleanNoteById_Handler,
 		},
+		{
+			MethodName: "RmSessionByUser",
+			Handler:    _RedisService_RmSessionByUser_Handler,
+		},
+		{
+			MethodName: "GetExportJob",
+			Handler:    _RedisService_GetExportJob_Handler,
+		},
+		{
+			MethodName: "SetExportJob",
+			Handler:    _RedisService_SetExportJob_Handler,
+		},
+		{
+			MethodName: "RevokeFamilies",
+			Handler:    _RedisService_RevokeFamilies_Handler,
+		},
+		{
+			MethodName: "IsFamilyRevoked",
+			Handler:    _RedisService_IsFamilyRevoked_Handler,
+		},
 		{
 			MethodName: "Healthz",
 			Handler:    _RedisService_Healthz_Handler,
@@ -648,6 +1146,14 @@ var RedisService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "RateLimit",
 			Handler:    _RedisService_RateLimit_Handler,
 		},
+		{
+			MethodName: "GetCounter",
+			Handler:    _RedisService_GetCounter_Handler,
+		},
+		{
+			MethodName: "ResetCounter",
+			Handler:    _RedisService_ResetCounter_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "redis.proto",
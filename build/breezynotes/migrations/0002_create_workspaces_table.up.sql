@@ -0,0 +1,16 @@
+CREATE TABLE workspaces
+(
+    id       VARCHAR(50) PRIMARY KEY,
+    title    VARCHAR(100) NOT NULL,
+    owner_id VARCHAR(50)  NOT NULL REFERENCES users (id) ON DELETE CASCADE
+);
+
+CREATE TABLE workspace_members
+(
+    workspace_id VARCHAR(50) NOT NULL REFERENCES workspaces (id) ON DELETE CASCADE,
+    user_id      VARCHAR(50) NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    role         VARCHAR(20) NOT NULL,
+    PRIMARY KEY (workspace_id, user_id)
+);
+
+CREATE INDEX workspace_members_user_id_idx ON workspace_members (user_id);
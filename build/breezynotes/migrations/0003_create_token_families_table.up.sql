@@ -0,0 +1,12 @@
+CREATE TABLE token_families
+(
+    id          VARCHAR(50) PRIMARY KEY,
+    user_id     VARCHAR(50) NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    current_jti VARCHAR(50) NOT NULL,
+    prev_jti    VARCHAR(50) NOT NULL DEFAULT '',
+    rotated_at  BIGINT      NOT NULL,
+    expires_at  BIGINT      NOT NULL,
+    revoked_at  BIGINT      NOT NULL DEFAULT 0
+);
+
+CREATE INDEX token_families_user_id_idx ON token_families (user_id);
@@ -0,0 +1,5 @@
+ALTER TABLE token_families
+    DROP COLUMN user_agent,
+    DROP COLUMN ip,
+    DROP COLUMN created_at,
+    DROP COLUMN last_seen_at;
@@ -0,0 +1,5 @@
+ALTER TABLE token_families
+    ADD COLUMN user_agent   VARCHAR(255) NOT NULL DEFAULT '',
+    ADD COLUMN ip           VARCHAR(50)  NOT NULL DEFAULT '',
+    ADD COLUMN created_at   BIGINT       NOT NULL DEFAULT 0,
+    ADD COLUMN last_seen_at BIGINT       NOT NULL DEFAULT 0;
@@ -0,0 +1,21 @@
+CREATE TABLE user_totp
+(
+    user_id         VARCHAR(50) PRIMARY KEY REFERENCES users (id) ON DELETE CASCADE,
+    secret          VARCHAR(64) NOT NULL,
+    enabled         BOOLEAN     NOT NULL DEFAULT FALSE,
+    last_step       BIGINT      NOT NULL DEFAULT 0,
+    failed_attempts INT         NOT NULL DEFAULT 0,
+    failed_at       BIGINT      NOT NULL DEFAULT 0,
+    created_at      BIGINT      NOT NULL,
+    confirmed_at    BIGINT      NOT NULL DEFAULT 0
+);
+
+CREATE TABLE recovery_codes
+(
+    id        VARCHAR(50) PRIMARY KEY,
+    user_id   VARCHAR(50)  NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    code_hash VARCHAR(100) NOT NULL,
+    used_at   BIGINT       NOT NULL DEFAULT 0
+);
+
+CREATE INDEX recovery_codes_user_id_idx ON recovery_codes (user_id);
@@ -0,0 +1,4 @@
+DROP TABLE user_tokens;
+
+ALTER TABLE users
+    DROP COLUMN email_verified;
@@ -0,0 +1,16 @@
+ALTER TABLE users
+    ADD COLUMN email_verified BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- accounts created before verification are trusted
+UPDATE users SET email_verified = TRUE;
+
+CREATE TABLE user_tokens
+(
+    token_hash VARCHAR(64) PRIMARY KEY,
+    user_id    VARCHAR(50) NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    purpose    VARCHAR(20) NOT NULL,
+    expires_at BIGINT      NOT NULL,
+    used_at    BIGINT      NOT NULL DEFAULT 0
+);
+
+CREATE INDEX user_tokens_user_id_idx ON user_tokens (user_id);
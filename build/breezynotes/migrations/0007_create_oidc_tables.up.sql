@@ -0,0 +1,20 @@
+CREATE TABLE user_identities
+(
+    provider   VARCHAR(50)  NOT NULL,
+    subject    VARCHAR(255) NOT NULL,
+    user_id    VARCHAR(50)  NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    email      VARCHAR(255) NOT NULL DEFAULT '',
+    created_at BIGINT       NOT NULL,
+    PRIMARY KEY (provider, subject)
+);
+
+CREATE INDEX user_identities_user_id_idx ON user_identities (user_id);
+
+CREATE TABLE oidc_logins
+(
+    state_hash VARCHAR(64) PRIMARY KEY,
+    provider   VARCHAR(50) NOT NULL,
+    verifier   VARCHAR(64) NOT NULL,
+    nonce      VARCHAR(64) NOT NULL,
+    expires_at BIGINT      NOT NULL
+);
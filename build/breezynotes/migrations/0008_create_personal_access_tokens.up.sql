@@ -0,0 +1,13 @@
+CREATE TABLE personal_access_tokens
+(
+    id           VARCHAR(50) PRIMARY KEY,
+    user_id      VARCHAR(50)  NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    name         VARCHAR(100) NOT NULL,
+    token_hash   VARCHAR(64)  NOT NULL UNIQUE,
+    scopes       TEXT[]       NOT NULL,
+    created_at   BIGINT       NOT NULL,
+    expires_at   BIGINT       NOT NULL,
+    last_used_at BIGINT       NOT NULL DEFAULT 0
+);
+
+CREATE INDEX personal_access_tokens_user_id_idx ON personal_access_tokens (user_id);
@@ -0,0 +1,13 @@
+CREATE TABLE audit_events
+(
+    id         VARCHAR(50) PRIMARY KEY,
+    user_id    VARCHAR(50)  NOT NULL DEFAULT '',
+    actor_id   VARCHAR(50)  NOT NULL DEFAULT '',
+    action     VARCHAR(50)  NOT NULL,
+    target     VARCHAR(255) NOT NULL DEFAULT '',
+    ip         VARCHAR(50)  NOT NULL DEFAULT '',
+    created_at BIGINT       NOT NULL
+);
+
+CREATE INDEX audit_events_user_id_idx ON audit_events (user_id, created_at);
+CREATE INDEX audit_events_created_at_idx ON audit_events (created_at);
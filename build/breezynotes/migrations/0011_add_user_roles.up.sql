@@ -0,0 +1,5 @@
+ALTER TABLE users
+    ADD COLUMN role     VARCHAR(20) NOT NULL DEFAULT 'user' CHECK (role IN ('user', 'admin')),
+    ADD COLUMN disabled BOOLEAN     NOT NULL DEFAULT FALSE;
+
+UPDATE users SET role = 'admin' WHERE login = 'admin';
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS users_email_trgm_idx;
+DROP INDEX IF EXISTS users_login_trgm_idx;
+
+ALTER TABLE users
+    DROP COLUMN discoverable;
@@ -0,0 +1,7 @@
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+ALTER TABLE users
+    ADD COLUMN discoverable BOOLEAN NOT NULL DEFAULT TRUE;
+
+CREATE INDEX users_login_trgm_idx ON users USING gin (login gin_trgm_ops);
+CREATE INDEX users_email_trgm_idx ON users USING gin (email gin_trgm_ops);
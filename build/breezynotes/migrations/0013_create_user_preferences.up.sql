@@ -0,0 +1,7 @@
+CREATE TABLE user_preferences
+(
+    user_id    VARCHAR(50) PRIMARY KEY REFERENCES users (id) ON DELETE CASCADE,
+    version    INT         NOT NULL,
+    data       JSONB       NOT NULL,
+    updated_at BIGINT      NOT NULL
+);
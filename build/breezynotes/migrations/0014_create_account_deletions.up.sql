@@ -0,0 +1,17 @@
+-- account_deletions keeps state of deletion after user is deleted, so there is no foreign key
+CREATE TABLE account_deletions
+(
+    user_id         VARCHAR(50) PRIMARY KEY,
+    state           VARCHAR(20) NOT NULL CHECK (state IN ('scheduled', 'running', 'done', 'cancelled')),
+    step            VARCHAR(20) NOT NULL DEFAULT '',
+    attempts        INT         NOT NULL DEFAULT 0,
+    last_error      TEXT        NOT NULL DEFAULT '',
+    requested_at    BIGINT      NOT NULL,
+    purge_at        BIGINT      NOT NULL,
+    next_attempt_at BIGINT      NOT NULL,
+    locked_until    BIGINT      NOT NULL DEFAULT 0,
+    updated_at      BIGINT      NOT NULL
+);
+
+CREATE INDEX account_deletions_due_idx ON account_deletions (next_attempt_at)
+    WHERE state IN ('scheduled', 'running');
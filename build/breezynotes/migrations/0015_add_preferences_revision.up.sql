@@ -0,0 +1,2 @@
+ALTER TABLE user_preferences
+    ADD COLUMN revision BIGINT NOT NULL DEFAULT 0;
@@ -0,0 +1,22 @@
+const dbName = process.env.MONGO_INITDB_DATABASE || "blocknotedb";
+const dbRef = db.getSiblingDB(dbName);
+
+print("Applying activity indexes...");
+
+dbRef.activity.createIndex(
+  { note_id: 1, created_at: -1 },
+  { name: "idx_activity_note_createdAt" },
+);
+
+dbRef.activity.createIndex(
+  { created_at: 1 },
+  { name: "idx_activity_createdAt" },
+);
+
+dbRef.migrations.updateOne(
+  { _id: "002-activity-indexes" },
+  { $setOnInsert: { appliedAt: new Date() } },
+  { upsert: true },
+);
+
+print("Activity indexes applied successfully ✅");
@@ -4,9 +4,13 @@ import (
 	"context"
 	"github.com/autumnterror/breezynotes/internal/auth/api"
 	"github.com/autumnterror/breezynotes/internal/auth/config"
+	"github.com/autumnterror/breezynotes/internal/auth/denylist"
 	"github.com/autumnterror/breezynotes/internal/auth/infra/psql"
 	"github.com/autumnterror/breezynotes/internal/auth/infra/psql/psqltx"
 	"github.com/autumnterror/breezynotes/internal/auth/jwt"
+	"github.com/autumnterror/breezynotes/internal/auth/limiter"
+	"github.com/autumnterror/breezynotes/internal/auth/mail"
+	"github.com/autumnterror/breezynotes/internal/auth/pkg/hasher"
 	"github.com/autumnterror/breezynotes/internal/auth/service"
 	"github.com/autumnterror/utils_go/pkg/log"
 	"os"
@@ -21,18 +25,52 @@ func main() {
 	cfg := config.MustSetup()
 
 	j := jwt.NewWithConfig(cfg)
+	if cfg.SigningKeysDir != "" {
+		ks, err := jwt.LoadKeySet(cfg.SigningKeysDir, cfg.SigningKeyId)
+		if err != nil {
+			log.Panic(err)
+		}
+		j = jwt.NewWithKeys(cfg, ks)
+	}
+
+	m, err := mail.New(cfg)
+	if err != nil {
+		log.Panic(err)
+	}
+
+	attempts, err := limiter.New(cfg)
+	if err != nil {
+		log.Panic(err)
+	}
+
+	revoked, err := denylist.New(cfg)
+	if err != nil {
+		log.Panic(err)
+	}
+
+	h, err := hasher.New(hasher.Params{
+		Memory:      cfg.PasswordMemory,
+		Iterations:  cfg.PasswordIterations,
+		Parallelism: cfg.PasswordParallelism,
+	})
+	if err != nil {
+		log.Panic(err)
+	}
 
 	db := psql.MustConnect(cfg)
 
 	s := service.NewAuthService(
 		psqltx.NewTxRunner(db.Driver),
-		psqltx.NewRepoProvider(db.Driver),
+		psqltx.NewRepoProvider(db.Driver, h),
 		j,
+		m,
+		attempts,
+		revoked,
 		cfg,
 	)
 
 	ctx, done := context.WithTimeout(context.Background(), 5*time.Second)
-	err := s.CreateAdmin(ctx)
+	err = s.CreateAdmin(ctx)
 	if err != nil {
 		log.Panic(err)
 	}
@@ -41,8 +79,12 @@ func main() {
 	a := api.New(cfg, s)
 	go a.MustRun()
 
+	cleanCtx, stopClean := context.WithCancel(context.Background())
+	go s.RunFamilyCleaner(cleanCtx)
+
 	sign := wait()
 
+	stopClean()
 	a.Stop()
 	if err := db.Disconnect(); err != nil {
 		log.Error(op, "db disconnect", err)
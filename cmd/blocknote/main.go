@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/autumnterror/breezynotes/internal/blocknote/api"
@@ -16,8 +17,11 @@ import (
 	"github.com/autumnterror/breezynotes/internal/blocknote/pkg/block/default/listblock"
 	"github.com/autumnterror/breezynotes/internal/blocknote/pkg/block/default/quoteblock"
 	"github.com/autumnterror/breezynotes/internal/blocknote/pkg/block/default/textblock"
+	"github.com/autumnterror/breezynotes/internal/blocknote/repository/activity"
 	"github.com/autumnterror/breezynotes/internal/blocknote/repository/blocks"
+	"github.com/autumnterror/breezynotes/internal/blocknote/repository/comments"
 	"github.com/autumnterror/breezynotes/internal/blocknote/repository/notes"
+	"github.com/autumnterror/breezynotes/internal/blocknote/repository/settings"
 	"github.com/autumnterror/breezynotes/internal/blocknote/repository/tags"
 	"github.com/autumnterror/breezynotes/internal/blocknote/service"
 	"github.com/autumnterror/utils_go/pkg/log"
@@ -45,16 +49,24 @@ func main() {
 	cfg := config.MustSetup()
 
 	m := mongo.MustConnect(cfg)
-	b := blocks.NewApi(m.Blocks())
+	b := blocks.NewApi(m.Blocks(), m.BlockTrash())
 	t := tags.NewApi(m.Tags(), m.NoteTags())
 	n := notes.NewApi(m.Notes(), m.Trash(), m.NoteTags(), t, b)
-	g := api.New(cfg, service.NewNoteService(cfg, mongotx.NewTxRunner(m.C), n, b, t))
+	st := settings.NewApi(m.Settings())
+	cm := comments.NewApi(m.Comments())
+	ac := activity.NewApi(m.Activity())
+	svc := service.NewNoteService(cfg, mongotx.NewTxRunner(m.C), n, b, t, st, cm, ac)
+	g := api.New(cfg, svc)
 	go g.MustRun()
 
+	purgeCtx, stopPurge := context.WithCancel(context.Background())
+	go svc.RunPurger(purgeCtx)
+
 	stop := make(chan os.Signal, 1)
 	signal.Notify(stop, syscall.SIGTERM, syscall.SIGINT)
 	sign := <-stop
 
+	stopPurge()
 	g.Stop()
 
 	log.Success(op, "stop signal "+fmt.Sprint(sign))
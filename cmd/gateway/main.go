@@ -61,10 +61,15 @@ func main() {
 	e := net.New(cfg, a, b, r)
 	go e.MustRun()
 
+	ctx, cancel := context.WithCancel(context.Background())
+	go e.RunDeletions(ctx)
+	go e.RunExportCleaner(ctx)
+
 	stop := make(chan os.Signal, 1)
 	signal.Notify(stop, syscall.SIGTERM, syscall.SIGINT)
 	s := <-stop
 
+	cancel()
 	if err := e.Stop(); err != nil {
 		log.Error(op, "stop echo", err)
 	}
@@ -0,0 +1,158 @@
+package api
+
+import (
+	"context"
+
+	brzrpc "github.com/autumnterror/breezynotes/api/proto/gen"
+	"github.com/autumnterror/breezynotes/internal/auth/domain"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+func (s *AdminAPI) ListUsers(ctx context.Context, r *brzrpc.ListUsersRequest) (*brzrpc.AdminUsers, error) {
+	const op = "grpc.admin.ListUsers"
+
+	ctx, done := context.WithTimeout(ctx, waitTime)
+	defer done()
+
+	res, err := handleCRUDResponse(ctx, op, func() (any, error) {
+		us, total, err := s.API.ListUsers(ctx, r.GetActorId(), r.GetQuery(), int(r.GetLimit()), int(r.GetOffset()))
+		if err != nil {
+			return nil, err
+		}
+		res := &brzrpc.AdminUsers{Users: make([]*brzrpc.User, 0, len(us)), Total: int32(total)}
+		for _, u := range us {
+			res.Users = append(res.Users, domain.UserToRpc(u))
+		}
+		return res, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return res.(*brzrpc.AdminUsers), nil
+}
+
+func (s *AdminAPI) GetUser(ctx context.Context, r *brzrpc.AdminUserRequest) (*brzrpc.User, error) {
+	const op = "grpc.admin.GetUser"
+
+	ctx, done := context.WithTimeout(ctx, waitTime)
+	defer done()
+
+	res, err := handleCRUDResponse(ctx, op, func() (any, error) {
+		u, err := s.API.GetUser(ctx, r.GetActorId(), r.GetUserId())
+		if err != nil {
+			return nil, err
+		}
+		return domain.UserToRpc(u), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return res.(*brzrpc.User), nil
+}
+
+func (s *AdminAPI) SetUserDisabled(ctx context.Context, r *brzrpc.SetUserDisabledRequest) (*emptypb.Empty, error) {
+	const op = "grpc.admin.SetUserDisabled"
+
+	ctx, done := context.WithTimeout(ctx, waitTime)
+	defer done()
+
+	_, err := handleCRUDResponse(ctx, op, func() (any, error) {
+		return nil, s.API.SetUserDisabled(ctx, r.GetActorId(), r.GetUserId(), r.GetDisabled())
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+func (s *AdminAPI) SetUserRole(ctx context.Context, r *brzrpc.SetUserRoleRequest) (*emptypb.Empty, error) {
+	const op = "grpc.admin.SetUserRole"
+
+	ctx, done := context.WithTimeout(ctx, waitTime)
+	defer done()
+
+	_, err := handleCRUDResponse(ctx, op, func() (any, error) {
+		return nil, s.API.SetUserRole(ctx, r.GetActorId(), r.GetUserId(), r.GetRole())
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+func (s *AdminAPI) ForcePasswordReset(ctx context.Context, r *brzrpc.AdminUserRequest) (*emptypb.Empty, error) {
+	const op = "grpc.admin.ForcePasswordReset"
+
+	ctx, done := context.WithTimeout(ctx, waitTime)
+	defer done()
+
+	_, err := handleCRUDResponse(ctx, op, func() (any, error) {
+		return nil, s.API.ForcePasswordReset(ctx, r.GetActorId(), r.GetUserId())
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+func (s *AdminAPI) Impersonate(ctx context.Context, r *brzrpc.AdminUserRequest) (*brzrpc.PatCreated, error) {
+	const op = "grpc.admin.Impersonate"
+
+	ctx, done := context.WithTimeout(ctx, waitTime)
+	defer done()
+
+	res, err := handleCRUDResponse(ctx, op, func() (any, error) {
+		token, p, err := s.API.Impersonate(ctx, r.GetActorId(), r.GetUserId())
+		if err != nil {
+			return nil, err
+		}
+		return &brzrpc.PatCreated{Token: token, Pat: domain.PatToRpc(p)}, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return res.(*brzrpc.PatCreated), nil
+}
+
+// UnlockLogin reset lockout of login after failed attempts
+func (s *AdminAPI) UnlockLogin(ctx context.Context, r *brzrpc.UnlockLoginRequest) (*emptypb.Empty, error) {
+	const op = "grpc.admin.UnlockLogin"
+
+	ctx, done := context.WithTimeout(ctx, waitTime)
+	defer done()
+
+	_, err := handleCRUDResponse(ctx, op, func() (any, error) {
+		return nil, s.API.UnlockLogin(ctx, r.GetActorId(), r.GetIdentifier())
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+func (s *AdminAPI) GetAuditEvents(ctx context.Context, r *brzrpc.AuditEventsRequest) (*brzrpc.AuditEvents, error) {
+	const op = "grpc.admin.GetAuditEvents"
+
+	ctx, done := context.WithTimeout(ctx, waitTime)
+	defer done()
+
+	res, err := handleCRUDResponse(ctx, op, func() (any, error) {
+		es, err := s.API.GetAuditEvents(ctx, r.GetActorId(), r.GetUserId(), int(r.GetLimit()), int(r.GetOffset()))
+		if err != nil {
+			return nil, err
+		}
+		return domain.AuditEventsToRpc(es), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return res.(*brzrpc.AuditEvents), nil
+}
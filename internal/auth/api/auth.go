@@ -6,6 +6,7 @@ import (
 	"time"
 
 	brzrpc "github.com/autumnterror/breezynotes/api/proto/gen"
+	"google.golang.org/protobuf/types/known/emptypb"
 )
 
 func (s *ServerAPI) Auth(ctx context.Context, r *brzrpc.AuthRequest) (*brzrpc.AuthResponse, error) {
@@ -15,17 +16,11 @@ func (s *ServerAPI) Auth(ctx context.Context, r *brzrpc.AuthRequest) (*brzrpc.Au
 	defer done()
 
 	res, err := handleCRUDResponse(ctx, op, func() (any, error) {
-		user, at, rt, err := s.API.Auth(ctx, r.GetEmail(), r.GetLogin(), r.GetPassword())
+		lr, err := s.API.Auth(ctx, r.GetEmail(), r.GetLogin(), r.GetPassword(), r.GetUserAgent(), r.GetIp())
 		if err != nil {
 			return nil, err
 		}
-		return &brzrpc.AuthResponse{
-			AccessToken:  at,
-			RefreshToken: rt,
-			ExpAccess:    time.Now().UTC().Add(s.Cfg.AccessTokenLifeTime).Unix(),
-			ExpRefresh:   time.Now().UTC().Add(s.Cfg.RefreshTokenLifeTime).Unix(),
-			Metadata:     domain.UserToRpc(user),
-		}, nil
+		return s.authResponse(lr), nil
 	})
 
 	if err != nil {
@@ -42,7 +37,7 @@ func (s *ServerAPI) Reg(ctx context.Context, r *brzrpc.AuthRequest) (*brzrpc.Tok
 	defer done()
 
 	res, err := handleCRUDResponse(ctx, op, func() (any, error) {
-		at, rt, err := s.API.Reg(ctx, r.GetEmail(), r.GetLogin(), r.GetPassword())
+		at, rt, err := s.API.Reg(ctx, r.GetEmail(), r.GetLogin(), r.GetPassword(), r.GetUserAgent(), r.GetIp())
 		if err != nil {
 			return nil, err
 		}
@@ -61,8 +56,8 @@ func (s *ServerAPI) Reg(ctx context.Context, r *brzrpc.AuthRequest) (*brzrpc.Tok
 	return res.(*brzrpc.Tokens), nil
 }
 
-func (s *ServerAPI) ValidateTokens(ctx context.Context, r *brzrpc.Tokens) (*brzrpc.Token, error) {
-	const op = "grpc.Reg"
+func (s *ServerAPI) ValidateTokens(ctx context.Context, r *brzrpc.Tokens) (*brzrpc.Tokens, error) {
+	const op = "grpc.ValidateTokens"
 
 	ctx, done := context.WithTimeout(ctx, waitTime)
 	defer done()
@@ -74,11 +69,190 @@ func (s *ServerAPI) ValidateTokens(ctx context.Context, r *brzrpc.Tokens) (*brzr
 	if err != nil {
 		return nil, err
 	}
-	if res.(string) != "" {
-		return &brzrpc.Token{
-			Value: res.(string),
-			Exp:   time.Now().UTC().Add(s.Cfg.AccessTokenLifeTime).Unix(),
+	if ts, ok := res.(*domain.Tokens); ok && ts != nil {
+		return &brzrpc.Tokens{
+			AccessToken:  ts.Access.Value,
+			RefreshToken: ts.Refresh.Value,
+			ExpAccess:    ts.Access.Exp.Unix(),
+			ExpRefresh:   ts.Refresh.Exp.Unix(),
 		}, nil
 	}
 	return nil, nil
 }
+
+func (s *ServerAPI) Logout(ctx context.Context, r *brzrpc.Tokens) (*emptypb.Empty, error) {
+	const op = "grpc.Logout"
+
+	ctx, done := context.WithTimeout(ctx, waitTime)
+	defer done()
+
+	_, err := handleCRUDResponse(ctx, op, func() (any, error) {
+		return nil, s.API.Logout(ctx, r.GetAccessToken(), r.GetRefreshToken())
+	})
+
+	if err != nil {
+		return nil, err
+	}
+	return nil, nil
+}
+
+func (s *ServerAPI) LogoutAll(ctx context.Context, r *brzrpc.UserId) (*emptypb.Empty, error) {
+	const op = "grpc.LogoutAll"
+
+	ctx, done := context.WithTimeout(ctx, waitTime)
+	defer done()
+
+	_, err := handleCRUDResponse(ctx, op, func() (any, error) {
+		return nil, s.API.LogoutAll(ctx, r.GetUserId())
+	})
+
+	if err != nil {
+		return nil, err
+	}
+	return nil, nil
+}
+
+func (s *ServerAPI) ListSessions(ctx context.Context, r *brzrpc.ListSessionsRequest) (*brzrpc.Sessions, error) {
+	const op = "grpc.ListSessions"
+
+	ctx, done := context.WithTimeout(ctx, waitTime)
+	defer done()
+
+	res, err := handleCRUDResponse(ctx, op, func() (any, error) {
+		ss, err := s.API.ListSessions(ctx, r.GetUserId(), r.GetAccessToken())
+		if err != nil {
+			return nil, err
+		}
+		return domain.SessionsToRpc(ss), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return res.(*brzrpc.Sessions), nil
+}
+
+func (s *ServerAPI) RevokeSession(ctx context.Context, r *brzrpc.UserSessionId) (*emptypb.Empty, error) {
+	const op = "grpc.RevokeSession"
+
+	ctx, done := context.WithTimeout(ctx, waitTime)
+	defer done()
+
+	_, err := handleCRUDResponse(ctx, op, func() (any, error) {
+		return nil, s.API.RevokeSession(ctx, r.GetUserId(), r.GetSessionId())
+	})
+
+	if err != nil {
+		return nil, err
+	}
+	return nil, nil
+}
+
+func (s *ServerAPI) GetJWKS(ctx context.Context, _ *emptypb.Empty) (*brzrpc.JWKS, error) {
+	const op = "grpc.GetJWKS"
+
+	ctx, done := context.WithTimeout(ctx, waitTime)
+	defer done()
+
+	res, err := handleCRUDResponse(ctx, op, func() (any, error) {
+		keys, err := s.API.GetJWKS(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return domain.JWKSToRpc(keys), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return res.(*brzrpc.JWKS), nil
+}
+
+func (s *ServerAPI) authResponse(lr *domain.LoginResult) *brzrpc.AuthResponse {
+	if lr.Challenge != "" {
+		return &brzrpc.AuthResponse{ChallengeToken: lr.Challenge}
+	}
+	return &brzrpc.AuthResponse{
+		AccessToken:  lr.Access,
+		RefreshToken: lr.Refresh,
+		ExpAccess:    time.Now().UTC().Add(s.Cfg.AccessTokenLifeTime).Unix(),
+		ExpRefresh:   time.Now().UTC().Add(s.Cfg.RefreshTokenLifeTime).Unix(),
+		Metadata:     domain.UserToRpc(lr.User),
+	}
+}
+
+func (s *ServerAPI) VerifySecondFactor(ctx context.Context, r *brzrpc.SecondFactorRequest) (*brzrpc.AuthResponse, error) {
+	const op = "grpc.VerifySecondFactor"
+
+	ctx, done := context.WithTimeout(ctx, waitTime)
+	defer done()
+
+	res, err := handleCRUDResponse(ctx, op, func() (any, error) {
+		lr, err := s.API.VerifySecondFactor(ctx, r.GetChallengeToken(), r.GetCode(), r.GetUserAgent(), r.GetIp())
+		if err != nil {
+			return nil, err
+		}
+		return s.authResponse(lr), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return res.(*brzrpc.AuthResponse), nil
+}
+
+func (s *ServerAPI) SetupTotp(ctx context.Context, r *brzrpc.UserId) (*brzrpc.TotpSetup, error) {
+	const op = "grpc.SetupTotp"
+
+	ctx, done := context.WithTimeout(ctx, waitTime)
+	defer done()
+
+	res, err := handleCRUDResponse(ctx, op, func() (any, error) {
+		ts, err := s.API.SetupTotp(ctx, r.GetUserId())
+		if err != nil {
+			return nil, err
+		}
+		return &brzrpc.TotpSetup{Secret: ts.Secret, Uri: ts.Uri}, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return res.(*brzrpc.TotpSetup), nil
+}
+
+func (s *ServerAPI) ConfirmTotp(ctx context.Context, r *brzrpc.TotpCodeRequest) (*brzrpc.RecoveryCodes, error) {
+	const op = "grpc.ConfirmTotp"
+
+	ctx, done := context.WithTimeout(ctx, waitTime)
+	defer done()
+
+	res, err := handleCRUDResponse(ctx, op, func() (any, error) {
+		codes, err := s.API.ConfirmTotp(ctx, r.GetUserId(), r.GetCode())
+		if err != nil {
+			return nil, err
+		}
+		return &brzrpc.RecoveryCodes{Codes: codes}, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return res.(*brzrpc.RecoveryCodes), nil
+}
+
+func (s *ServerAPI) DisableTotp(ctx context.Context, r *brzrpc.TotpCodeRequest) (*emptypb.Empty, error) {
+	const op = "grpc.DisableTotp"
+
+	ctx, done := context.WithTimeout(ctx, waitTime)
+	defer done()
+
+	_, err := handleCRUDResponse(ctx, op, func() (any, error) {
+		return nil, s.API.DisableTotp(ctx, r.GetUserId(), r.GetPassword(), r.GetCode())
+	})
+
+	if err != nil {
+		return nil, err
+	}
+	return nil, nil
+}
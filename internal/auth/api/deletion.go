@@ -0,0 +1,105 @@
+package api
+
+import (
+	"context"
+
+	brzrpc "github.com/autumnterror/breezynotes/api/proto/gen"
+	"github.com/autumnterror/breezynotes/internal/auth/domain"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+func (s *ServerAPI) RequestDeletion(ctx context.Context, r *brzrpc.UserId) (*brzrpc.AccountDeletion, error) {
+	const op = "grpc.RequestDeletion"
+
+	ctx, done := context.WithTimeout(ctx, waitTime)
+	defer done()
+
+	res, err := handleCRUDResponse(ctx, op, func() (any, error) {
+		return s.API.RequestDeletion(ctx, r.GetUserId())
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return domain.AccountDeletionToRpc(res.(*domain.AccountDeletion)), nil
+}
+
+func (s *ServerAPI) CancelDeletion(ctx context.Context, r *brzrpc.UserId) (*emptypb.Empty, error) {
+	const op = "grpc.CancelDeletion"
+
+	ctx, done := context.WithTimeout(ctx, waitTime)
+	defer done()
+
+	_, err := handleCRUDResponse(ctx, op, func() (any, error) {
+		return nil, s.API.CancelDeletion(ctx, r.GetUserId())
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func (s *ServerAPI) GetDeletion(ctx context.Context, r *brzrpc.UserId) (*brzrpc.AccountDeletion, error) {
+	const op = "grpc.GetDeletion"
+
+	ctx, done := context.WithTimeout(ctx, waitTime)
+	defer done()
+
+	res, err := handleCRUDResponse(ctx, op, func() (any, error) {
+		return s.API.GetDeletion(ctx, r.GetUserId())
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return domain.AccountDeletionToRpc(res.(*domain.AccountDeletion)), nil
+}
+
+func (s *DeletionAPI) ClaimDeletions(ctx context.Context, r *brzrpc.ClaimDeletionsRequest) (*brzrpc.AccountDeletions, error) {
+	const op = "grpc.deletion.ClaimDeletions"
+
+	ctx, done := context.WithTimeout(ctx, waitTime)
+	defer done()
+
+	res, err := handleCRUDResponse(ctx, op, func() (any, error) {
+		return s.API.ClaimDeletions(ctx, int(r.GetLimit()))
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return domain.AccountDeletionsToRpc(res.([]*domain.AccountDeletion)), nil
+}
+
+func (s *DeletionAPI) CompleteDeletionStep(ctx context.Context, r *brzrpc.DeletionStepRequest) (*brzrpc.AccountDeletion, error) {
+	const op = "grpc.deletion.CompleteDeletionStep"
+
+	ctx, done := context.WithTimeout(ctx, waitTime)
+	defer done()
+
+	res, err := handleCRUDResponse(ctx, op, func() (any, error) {
+		return s.API.CompleteDeletionStep(ctx, r.GetUserId(), r.GetStep())
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return domain.AccountDeletionToRpc(res.(*domain.AccountDeletion)), nil
+}
+
+func (s *DeletionAPI) FailDeletionStep(ctx context.Context, r *brzrpc.DeletionStepRequest) (*emptypb.Empty, error) {
+	const op = "grpc.deletion.FailDeletionStep"
+
+	ctx, done := context.WithTimeout(ctx, waitTime)
+	defer done()
+
+	_, err := handleCRUDResponse(ctx, op, func() (any, error) {
+		return nil, s.API.FailDeletionStep(ctx, r.GetUserId(), r.GetStep(), r.GetError())
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &emptypb.Empty{}, nil
+}
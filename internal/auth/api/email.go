@@ -0,0 +1,72 @@
+package api
+
+import (
+	"context"
+
+	brzrpc "github.com/autumnterror/breezynotes/api/proto/gen"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+func (s *ServerAPI) SendVerification(ctx context.Context, r *brzrpc.UserId) (*emptypb.Empty, error) {
+	const op = "grpc.SendVerification"
+
+	ctx, done := context.WithTimeout(ctx, waitTime)
+	defer done()
+
+	_, err := handleCRUDResponse(ctx, op, func() (any, error) {
+		return nil, s.API.SendVerification(ctx, r.GetUserId())
+	})
+
+	if err != nil {
+		return nil, err
+	}
+	return nil, nil
+}
+
+func (s *ServerAPI) VerifyEmail(ctx context.Context, r *brzrpc.String) (*emptypb.Empty, error) {
+	const op = "grpc.VerifyEmail"
+
+	ctx, done := context.WithTimeout(ctx, waitTime)
+	defer done()
+
+	_, err := handleCRUDResponse(ctx, op, func() (any, error) {
+		return nil, s.API.VerifyEmail(ctx, r.GetValue())
+	})
+
+	if err != nil {
+		return nil, err
+	}
+	return nil, nil
+}
+
+func (s *ServerAPI) ForgotPassword(ctx context.Context, r *brzrpc.String) (*emptypb.Empty, error) {
+	const op = "grpc.ForgotPassword"
+
+	ctx, done := context.WithTimeout(ctx, waitTime)
+	defer done()
+
+	_, err := handleCRUDResponse(ctx, op, func() (any, error) {
+		return nil, s.API.ForgotPassword(ctx, r.GetValue())
+	})
+
+	if err != nil {
+		return nil, err
+	}
+	return nil, nil
+}
+
+func (s *ServerAPI) ResetPassword(ctx context.Context, r *brzrpc.ResetPasswordRequest) (*emptypb.Empty, error) {
+	const op = "grpc.ResetPassword"
+
+	ctx, done := context.WithTimeout(ctx, waitTime)
+	defer done()
+
+	_, err := handleCRUDResponse(ctx, op, func() (any, error) {
+		return nil, s.API.ResetPassword(ctx, r.GetToken(), r.GetNewPassword())
+	})
+
+	if err != nil {
+		return nil, err
+	}
+	return nil, nil
+}
@@ -0,0 +1,52 @@
+package api
+
+import (
+	"context"
+
+	brzrpc "github.com/autumnterror/breezynotes/api/proto/gen"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+func (s *ServerAPI) OidcProviders(ctx context.Context, _ *emptypb.Empty) (*brzrpc.Strings, error) {
+	return &brzrpc.Strings{Values: s.API.OidcProviders()}, nil
+}
+
+func (s *ServerAPI) OidcStart(ctx context.Context, r *brzrpc.String) (*brzrpc.OidcStartResponse, error) {
+	const op = "grpc.OidcStart"
+
+	ctx, done := context.WithTimeout(ctx, oidcWaitTime)
+	defer done()
+
+	res, err := handleCRUDResponse(ctx, op, func() (any, error) {
+		u, state, err := s.API.OidcStart(ctx, r.GetValue())
+		if err != nil {
+			return nil, err
+		}
+		return &brzrpc.OidcStartResponse{Url: u, State: state}, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return res.(*brzrpc.OidcStartResponse), nil
+}
+
+func (s *ServerAPI) OidcCallback(ctx context.Context, r *brzrpc.OidcCallbackRequest) (*brzrpc.AuthResponse, error) {
+	const op = "grpc.OidcCallback"
+
+	ctx, done := context.WithTimeout(ctx, oidcWaitTime)
+	defer done()
+
+	res, err := handleCRUDResponse(ctx, op, func() (any, error) {
+		lr, err := s.API.OidcCallback(ctx, r.GetProvider(), r.GetCode(), r.GetState(), r.GetUserAgent(), r.GetIp())
+		if err != nil {
+			return nil, err
+		}
+		return s.authResponse(lr), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return res.(*brzrpc.AuthResponse), nil
+}
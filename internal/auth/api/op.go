@@ -35,13 +35,18 @@ func handleCRUDResponse(ctx context.Context, op string, action func() (any, erro
 				return nil, status.Error(codes.AlreadyExists, r.err.Error())
 			case errors.Is(r.err, domain.ErrForeignKey):
 				return nil, status.Error(codes.FailedPrecondition, r.err.Error())
+			case errors.Is(r.err, domain.ErrConflict):
+				return nil, status.Error(codes.Aborted, r.err.Error())
 			case errors.Is(r.err, domain.ErrTokenExpired):
 				return nil, status.Error(codes.ResourceExhausted, r.err.Error())
+			case errors.Is(r.err, domain.ErrTokenRevoked), errors.Is(r.err, domain.ErrForbidden):
+				return nil, status.Error(codes.PermissionDenied, r.err.Error())
 			case errors.Is(r.err, domain.ErrTokenInvalid):
 				return nil, status.Error(codes.Unauthenticated, r.err.Error())
 			case errors.Is(r.err, domain.ErrTokenWrongType):
 				return nil, status.Error(codes.InvalidArgument, r.err.Error())
-			case errors.Is(r.err, service.ErrBadServiceCheck), errors.Is(r.err, domain.ErrWrongInput), errors.Is(r.err, domain.ErrLoginOrPasswordIncorrect):
+			case errors.Is(r.err, service.ErrBadServiceCheck), errors.Is(r.err, domain.ErrWrongInput), errors.Is(r.err, domain.ErrLoginOrPasswordIncorrect),
+				errors.Is(r.err, domain.ErrInvalidCode), errors.Is(r.err, domain.ErrTooManyAttempts), errors.Is(r.err, domain.ErrUserDisabled):
 				return nil, status.Error(codes.InvalidArgument, r.err.Error())
 
 			default:
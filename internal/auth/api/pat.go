@@ -0,0 +1,84 @@
+package api
+
+import (
+	"context"
+
+	brzrpc "github.com/autumnterror/breezynotes/api/proto/gen"
+	"github.com/autumnterror/breezynotes/internal/auth/domain"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+func (s *ServerAPI) CreatePat(ctx context.Context, r *brzrpc.CreatePatRequest) (*brzrpc.PatCreated, error) {
+	const op = "grpc.CreatePat"
+
+	ctx, done := context.WithTimeout(ctx, waitTime)
+	defer done()
+
+	res, err := handleCRUDResponse(ctx, op, func() (any, error) {
+		token, p, err := s.API.CreatePat(ctx, r.GetUserId(), r.GetName(), r.GetScopes(), r.GetExpiresAt())
+		if err != nil {
+			return nil, err
+		}
+		return &brzrpc.PatCreated{Token: token, Pat: domain.PatToRpc(p)}, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return res.(*brzrpc.PatCreated), nil
+}
+
+func (s *ServerAPI) ListPats(ctx context.Context, r *brzrpc.UserId) (*brzrpc.Pats, error) {
+	const op = "grpc.ListPats"
+
+	ctx, done := context.WithTimeout(ctx, waitTime)
+	defer done()
+
+	res, err := handleCRUDResponse(ctx, op, func() (any, error) {
+		ps, err := s.API.ListPats(ctx, r.GetUserId())
+		if err != nil {
+			return nil, err
+		}
+		return domain.PatsToRpc(ps), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return res.(*brzrpc.Pats), nil
+}
+
+func (s *ServerAPI) RevokePat(ctx context.Context, r *brzrpc.PatId) (*emptypb.Empty, error) {
+	const op = "grpc.RevokePat"
+
+	ctx, done := context.WithTimeout(ctx, waitTime)
+	defer done()
+
+	_, err := handleCRUDResponse(ctx, op, func() (any, error) {
+		return nil, s.API.RevokePat(ctx, r.GetUserId(), r.GetId())
+	})
+	if err != nil {
+		return nil, err
+	}
+	return nil, nil
+}
+
+func (s *ServerAPI) ValidatePat(ctx context.Context, r *brzrpc.Token) (*brzrpc.PatOwner, error) {
+	const op = "grpc.ValidatePat"
+
+	ctx, done := context.WithTimeout(ctx, waitTime)
+	defer done()
+
+	res, err := handleCRUDResponse(ctx, op, func() (any, error) {
+		p, err := s.API.ValidatePat(ctx, r.GetValue())
+		if err != nil {
+			return nil, err
+		}
+		return &brzrpc.PatOwner{UserId: p.UserId, Scopes: p.Scopes}, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return res.(*brzrpc.PatOwner), nil
+}
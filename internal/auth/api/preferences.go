@@ -0,0 +1,40 @@
+package api
+
+import (
+	"context"
+
+	brzrpc "github.com/autumnterror/breezynotes/api/proto/gen"
+	"github.com/autumnterror/breezynotes/internal/auth/domain"
+)
+
+func (s *ServerAPI) GetPreferences(ctx context.Context, r *brzrpc.UserId) (*brzrpc.Preferences, error) {
+	const op = "grpc.GetPreferences"
+
+	ctx, done := context.WithTimeout(ctx, waitTime)
+	defer done()
+
+	res, err := handleCRUDResponse(ctx, op, func() (any, error) {
+		return s.API.GetPreferences(ctx, r.GetUserId())
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return domain.PreferencesToRpc(res.(*domain.Preferences)), nil
+}
+
+func (s *ServerAPI) UpdatePreferences(ctx context.Context, r *brzrpc.UpdatePreferencesRequest) (*brzrpc.Preferences, error) {
+	const op = "grpc.UpdatePreferences"
+
+	ctx, done := context.WithTimeout(ctx, waitTime)
+	defer done()
+
+	res, err := handleCRUDResponse(ctx, op, func() (any, error) {
+		return s.API.UpdatePreferences(ctx, r.GetUserId(), domain.PreferencesFromRpc(r.GetPreferences()))
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return domain.PreferencesToRpc(res.(*domain.Preferences)), nil
+}
@@ -14,10 +14,26 @@ type ServerAPI struct {
 	Cfg *config.Config
 }
 
+// AdminAPI is separate service, so admin calls can't be mixed with calls of users by mistake
+type AdminAPI struct {
+	brzrpc.UnimplementedAdminServiceServer
+	API *service.AuthService
+}
+
+// DeletionAPI is called only by deletion worker of gateway
+type DeletionAPI struct {
+	brzrpc.UnimplementedAccountDeletionServiceServer
+	API *service.AuthService
+}
+
 func Register(server *grpc.Server, s *service.AuthService, cfg *config.Config) {
 	brzrpc.RegisterAuthServiceServer(server, &ServerAPI{API: s, Cfg: cfg})
+	brzrpc.RegisterAdminServiceServer(server, &AdminAPI{API: s})
+	brzrpc.RegisterAccountDeletionServiceServer(server, &DeletionAPI{API: s})
 }
 
 const (
 	waitTime = 3 * time.Second
+	// oidcWaitTime SSO calls go to external provider: discovery, JWKS and code exchange
+	oidcWaitTime = 15 * time.Second
 )
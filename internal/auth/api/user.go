@@ -78,7 +78,7 @@ func (s *ServerAPI) ChangePasswd(ctx context.Context, r *brzrpc.ChangePasswordRe
 	defer done()
 
 	_, err := handleCRUDResponse(ctx, op, func() (any, error) {
-		return nil, s.API.UpdatePassword(ctx, r.GetId(), r.GetOldPassword(), r.GetNewPassword())
+		return nil, s.API.UpdatePassword(ctx, r.GetId(), r.GetOldPassword(), r.GetNewPassword(), r.GetRevokeOtherSessions(), r.GetAccessToken())
 	})
 	if err != nil {
 		return nil, err
@@ -149,3 +149,35 @@ func (s *ServerAPI) GetInfos(ctx context.Context, ids *brzrpc.Ids) (*brzrpc.User
 
 	return domain.UsersToRpc(&domain.Users{Us: res.([]domain.User)}), nil
 }
+
+func (s *ServerAPI) UpdateDiscoverable(ctx context.Context, r *brzrpc.UpdateDiscoverableRequest) (*emptypb.Empty, error) {
+	const op = "grpc.UpdateDiscoverable"
+
+	ctx, done := context.WithTimeout(ctx, waitTime)
+	defer done()
+
+	_, err := handleCRUDResponse(ctx, op, func() (any, error) {
+		return nil, s.API.UpdateDiscoverable(ctx, r.GetId(), r.GetDiscoverable())
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func (s *ServerAPI) SearchUsers(ctx context.Context, r *brzrpc.SearchUsersRequest) (*brzrpc.UserCards, error) {
+	const op = "grpc.SearchUsers"
+
+	ctx, done := context.WithTimeout(ctx, waitTime)
+	defer done()
+
+	res, err := handleCRUDResponse(ctx, op, func() (any, error) {
+		return s.API.SearchUsers(ctx, r.GetUserId(), r.GetQuery(), int(r.GetLimit()))
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return domain.UserCardsToRpc(res.([]*domain.UserCard)), nil
+}
@@ -0,0 +1,105 @@
+package api
+
+import (
+	"context"
+
+	brzrpc "github.com/autumnterror/breezynotes/api/proto/gen"
+	"github.com/autumnterror/breezynotes/internal/auth/domain"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+func (s *ServerAPI) CreateWorkspace(ctx context.Context, r *brzrpc.CreateWorkspaceRequest) (*emptypb.Empty, error) {
+	const op = "grpc.CreateWorkspace"
+
+	ctx, done := context.WithTimeout(ctx, waitTime)
+	defer done()
+
+	_, err := handleCRUDResponse(ctx, op, func() (any, error) {
+		return nil, s.API.CreateWorkspace(ctx, r.GetId(), r.GetTitle(), r.GetUserId())
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func (s *ServerAPI) DeleteWorkspace(ctx context.Context, r *brzrpc.UserWorkspaceId) (*emptypb.Empty, error) {
+	const op = "grpc.DeleteWorkspace"
+
+	ctx, done := context.WithTimeout(ctx, waitTime)
+	defer done()
+
+	_, err := handleCRUDResponse(ctx, op, func() (any, error) {
+		return nil, s.API.DeleteWorkspace(ctx, r.GetWorkspaceId(), r.GetUserId())
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func (s *ServerAPI) GetWorkspacesByUser(ctx context.Context, r *brzrpc.UserId) (*brzrpc.Workspaces, error) {
+	const op = "grpc.GetWorkspacesByUser"
+
+	ctx, done := context.WithTimeout(ctx, waitTime)
+	defer done()
+
+	res, err := handleCRUDResponse(ctx, op, func() (any, error) {
+		return s.API.GetWorkspacesByUser(ctx, r.GetUserId())
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return domain.WorkspacesToRpc(res.([]*domain.Workspace)), nil
+}
+
+func (s *ServerAPI) GetWorkspaceMembers(ctx context.Context, r *brzrpc.UserWorkspaceId) (*brzrpc.WorkspaceMembers, error) {
+	const op = "grpc.GetWorkspaceMembers"
+
+	ctx, done := context.WithTimeout(ctx, waitTime)
+	defer done()
+
+	res, err := handleCRUDResponse(ctx, op, func() (any, error) {
+		return s.API.GetWorkspaceMembers(ctx, r.GetWorkspaceId(), r.GetUserId())
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return domain.WorkspaceMembersToRpc(res.([]*domain.WorkspaceMember)), nil
+}
+
+func (s *ServerAPI) AddWorkspaceMember(ctx context.Context, r *brzrpc.WorkspaceMemberRequest) (*emptypb.Empty, error) {
+	const op = "grpc.AddWorkspaceMember"
+
+	ctx, done := context.WithTimeout(ctx, waitTime)
+	defer done()
+
+	_, err := handleCRUDResponse(ctx, op, func() (any, error) {
+		return nil, s.API.AddWorkspaceMember(ctx, r.GetWorkspaceId(), r.GetUserId(), r.GetMemberId(), r.GetRole())
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func (s *ServerAPI) RemoveWorkspaceMember(ctx context.Context, r *brzrpc.WorkspaceMemberRequest) (*emptypb.Empty, error) {
+	const op = "grpc.RemoveWorkspaceMember"
+
+	ctx, done := context.WithTimeout(ctx, waitTime)
+	defer done()
+
+	_, err := handleCRUDResponse(ctx, op, func() (any, error) {
+		return nil, s.API.RemoveWorkspaceMember(ctx, r.GetWorkspaceId(), r.GetUserId(), r.GetMemberId())
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &emptypb.Empty{}, nil
+}
@@ -0,0 +1,28 @@
+// Package denylist publish revoked token families to redis service. Gateway checks access tokens locally
+// and denies tokens of these families until they expire. Without address of redis service nothing is published
+package denylist
+
+import (
+	"context"
+	"time"
+
+	"github.com/autumnterror/breezynotes/internal/auth/config"
+)
+
+type Publisher interface {
+	// Revoke deny access tokens of families for ttl
+	Revoke(ctx context.Context, ids []string, ttl time.Duration) error
+}
+
+// New return publisher to redis service by cfg.AddrRedis. Empty address is Nop
+func New(cfg *config.Config) (Publisher, error) {
+	if cfg.AddrRedis == "" {
+		return Nop{}, nil
+	}
+	return NewRedis(cfg.AddrRedis)
+}
+
+// Nop publish nothing, it is used when gateway does not share redis service with auth
+type Nop struct{}
+
+func (Nop) Revoke(context.Context, []string, time.Duration) error { return nil }
@@ -0,0 +1,41 @@
+package denylist
+
+import (
+	"context"
+	"time"
+
+	brzrpc "github.com/autumnterror/breezynotes/api/proto/gen"
+	"github.com/autumnterror/utils_go/pkg/utils/format"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// Redis publisher to redis service. Connection is lazy, auth starts even if redis service is down
+type Redis struct {
+	api brzrpc.RedisServiceClient
+}
+
+func NewRedis(addr string) (*Redis, error) {
+	const op = "denylist.NewRedis"
+
+	cc, err := grpc.NewClient(addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, format.Error(op, err)
+	}
+	return &Redis{api: brzrpc.NewRedisServiceClient(cc)}, nil
+}
+
+func (r *Redis) Revoke(ctx context.Context, ids []string, ttl time.Duration) error {
+	const op = "denylist.Redis.Revoke"
+	if len(ids) == 0 {
+		return nil
+	}
+
+	if _, err := r.api.RevokeFamilies(ctx, &brzrpc.RevokedFamilies{
+		Ids:             ids,
+		TtlMilliseconds: ttl.Milliseconds(),
+	}); err != nil {
+		return format.Error(op, err)
+	}
+	return nil
+}
@@ -0,0 +1,15 @@
+package domain
+
+const (
+	ActionTokenVerifyEmail   = "verify_email"
+	ActionTokenResetPassword = "reset_password"
+)
+
+// ActionToken single-use token sent to email of user. Only hash of token is stored
+type ActionToken struct {
+	Hash      string
+	UserId    string
+	Purpose   string
+	ExpiresAt int64
+	UsedAt    int64
+}
@@ -0,0 +1,47 @@
+package domain
+
+import brzrpc "github.com/autumnterror/breezynotes/api/proto/gen"
+
+const (
+	AuditLoginLocked          = "login_locked"
+	AuditLoginUnlocked        = "login_unlocked"
+	AuditUsersListed          = "users_listed"
+	AuditUserViewed           = "user_viewed"
+	AuditUserDisabled         = "user_disabled"
+	AuditUserEnabled          = "user_enabled"
+	AuditRoleChanged          = "role_changed"
+	AuditPasswordResetForced  = "password_reset_forced"
+	AuditImpersonationStarted = "impersonation_started"
+	AuditEventsListed         = "audit_listed"
+	AuditDeletionRequested    = "deletion_requested"
+	AuditDeletionCancelled    = "deletion_cancelled"
+	AuditAccountDeleted       = "account_deleted"
+)
+
+// AuditEvent security event or action of admin. UserId is user whom event is about, ActorId who made it: empty for system.
+// Events are kept after user is deleted, so there are no foreign keys
+type AuditEvent struct {
+	Id        string
+	UserId    string
+	ActorId   string
+	Action    string
+	Target    string
+	Ip        string
+	CreatedAt int64
+}
+
+func AuditEventsToRpc(es []*AuditEvent) *brzrpc.AuditEvents {
+	res := &brzrpc.AuditEvents{Events: make([]*brzrpc.AuditEvent, 0, len(es))}
+	for _, e := range es {
+		res.Events = append(res.Events, &brzrpc.AuditEvent{
+			Id:        e.Id,
+			UserId:    e.UserId,
+			ActorId:   e.ActorId,
+			Action:    e.Action,
+			Target:    e.Target,
+			Ip:        e.Ip,
+			CreatedAt: e.CreatedAt,
+		})
+	}
+	return res
+}
@@ -3,4 +3,12 @@ package domain
 const (
 	TokenTypeAccess  = "ACCESS"
 	TokenTypeRefresh = "REFRESH"
+	// TokenTypeChallenge proves that password is checked, it is exchanged to tokens by second factor
+	TokenTypeChallenge = "CHALLENGE"
+)
+
+const (
+	WorkspaceRoleOwner  = "owner"
+	WorkspaceRoleEditor = "editor"
+	WorkspaceRoleReader = "reader"
 )
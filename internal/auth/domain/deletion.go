@@ -0,0 +1,114 @@
+package domain
+
+import (
+	"slices"
+	"time"
+
+	brzrpc "github.com/autumnterror/breezynotes/api/proto/gen"
+)
+
+// States of account deletion. Scheduled deletion can be cancelled until PurgeAt,
+// after that it is running and steps are made one by one until done
+const (
+	DeletionScheduled = "scheduled"
+	DeletionRunning   = "running"
+	DeletionDone      = "done"
+	DeletionCancelled = "cancelled"
+)
+
+// Steps of account deletion. Every step must be idempotent: worker can die after step is made but before it is completed
+const (
+	DeletionStepFiles = "files"
+	DeletionStepAcl   = "acl"
+	DeletionStepNotes = "notes"
+	DeletionStepTags  = "tags"
+	DeletionStepCache = "cache"
+)
+
+// DeletionSteps in order. Files go first, because their list is made from notes of user
+var DeletionSteps = []string{DeletionStepFiles, DeletionStepAcl, DeletionStepNotes, DeletionStepTags, DeletionStepCache}
+
+const (
+	// DeletionLease time while claimed deletion is not given to other worker
+	DeletionLease = 5 * time.Minute
+
+	deletionBackoffMin = time.Minute
+	deletionBackoffMax = 6 * time.Hour
+)
+
+// AccountDeletion state of deletion saga. Row is kept after user is deleted, so there are no foreign keys.
+// Step is next step to make, empty if deletion isn't running
+type AccountDeletion struct {
+	UserId        string
+	State         string
+	Step          string
+	Attempts      int
+	LastError     string
+	RequestedAt   int64
+	PurgeAt       int64
+	NextAttemptAt int64
+	UpdatedAt     int64
+}
+
+func (d *AccountDeletion) Active() bool {
+	return d.State == DeletionScheduled || d.State == DeletionRunning
+}
+
+// NextDeletionStep return step after step, empty after last one
+func NextDeletionStep(step string) string {
+	i := slices.Index(DeletionSteps, step)
+	if i < 0 || i+1 == len(DeletionSteps) {
+		return ""
+	}
+	return DeletionSteps[i+1]
+}
+
+// DeletionStepDone report if step is already completed by deletion d
+func DeletionStepDone(d *AccountDeletion, step string) bool {
+	i := slices.Index(DeletionSteps, step)
+	if i < 0 {
+		return false
+	}
+	switch d.State {
+	case DeletionDone:
+		return true
+	case DeletionRunning:
+		return i < slices.Index(DeletionSteps, d.Step)
+	}
+	return false
+}
+
+// DeletionBackoff delay before next try of failed step: minute doubled on every attempt, but not more than 6 hours
+func DeletionBackoff(attempts int) time.Duration {
+	if attempts < 1 {
+		return deletionBackoffMin
+	}
+	if attempts > 10 {
+		return deletionBackoffMax
+	}
+	return min(deletionBackoffMin<<(attempts-1), deletionBackoffMax)
+}
+
+func AccountDeletionToRpc(d *AccountDeletion) *brzrpc.AccountDeletion {
+	if d == nil {
+		return nil
+	}
+	return &brzrpc.AccountDeletion{
+		UserId:        d.UserId,
+		State:         d.State,
+		Step:          d.Step,
+		Attempts:      int32(d.Attempts),
+		LastError:     d.LastError,
+		RequestedAt:   d.RequestedAt,
+		PurgeAt:       d.PurgeAt,
+		NextAttemptAt: d.NextAttemptAt,
+	}
+}
+
+func AccountDeletionsToRpc(ds []*AccountDeletion) *brzrpc.AccountDeletions {
+	res := &brzrpc.AccountDeletions{Deletions: make([]*brzrpc.AccountDeletion, 0, len(ds))}
+	for _, d := range ds {
+		res.Deletions = append(res.Deletions, AccountDeletionToRpc(d))
+	}
+	return res
+}
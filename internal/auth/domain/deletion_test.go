@@ -0,0 +1,45 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNextDeletionStep(t *testing.T) {
+	t.Parallel()
+
+	var steps []string
+	for step := DeletionSteps[0]; step != ""; step = NextDeletionStep(step) {
+		steps = append(steps, step)
+	}
+	assert.Equal(t, DeletionSteps, steps)
+	assert.Equal(t, "", NextDeletionStep("unknown"))
+}
+
+func TestDeletionStepDone(t *testing.T) {
+	t.Parallel()
+
+	d := &AccountDeletion{State: DeletionRunning, Step: DeletionStepNotes}
+	assert.True(t, DeletionStepDone(d, DeletionStepFiles))
+	assert.True(t, DeletionStepDone(d, DeletionStepAcl))
+	assert.False(t, DeletionStepDone(d, DeletionStepNotes))
+	assert.False(t, DeletionStepDone(d, DeletionStepCache))
+	assert.False(t, DeletionStepDone(d, "unknown"))
+
+	assert.True(t, DeletionStepDone(&AccountDeletion{State: DeletionDone}, DeletionStepCache))
+	assert.False(t, DeletionStepDone(&AccountDeletion{State: DeletionScheduled}, DeletionStepFiles))
+	assert.False(t, DeletionStepDone(&AccountDeletion{State: DeletionCancelled}, DeletionStepFiles))
+}
+
+func TestDeletionBackoff(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, time.Minute, DeletionBackoff(0))
+	assert.Equal(t, time.Minute, DeletionBackoff(1))
+	assert.Equal(t, 2*time.Minute, DeletionBackoff(2))
+	assert.Equal(t, 8*time.Minute, DeletionBackoff(4))
+	assert.Equal(t, 6*time.Hour, DeletionBackoff(10))
+	assert.Equal(t, 6*time.Hour, DeletionBackoff(1000))
+}
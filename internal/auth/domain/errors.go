@@ -11,6 +11,13 @@ var (
 	ErrTokenExpired             = errors.New("token expired")
 	ErrTokenWrongType           = errors.New("token wrong type")
 	ErrTokenInvalid             = errors.New("token invalid")
+	ErrTokenRevoked             = errors.New("token revoked")
 	ErrUnauthorized             = errors.New("unauthorized")
 	ErrWrongType                = errors.New("wrong type of token")
+	ErrInvalidCode              = errors.New("invalid code")
+	ErrTooManyAttempts          = errors.New("too many attempts, try later")
+	ErrForbidden                = errors.New("forbidden")
+	ErrUserDisabled             = errors.New("account is disabled")
+	// ErrConflict obj was changed by other request after it was read
+	ErrConflict = errors.New("obj was changed by other request")
 )
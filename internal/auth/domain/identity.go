@@ -0,0 +1,19 @@
+package domain
+
+// Identity links account at external OIDC provider to user. Subject is stable id of account at provider
+type Identity struct {
+	Provider  string
+	Subject   string
+	UserId    string
+	Email     string
+	CreatedAt int64
+}
+
+// OidcLogin is started login at provider. It is kept until user comes back with code, only hash of state is stored
+type OidcLogin struct {
+	StateHash string
+	Provider  string
+	Verifier  string
+	Nonce     string
+	ExpiresAt int64
+}
@@ -0,0 +1,68 @@
+package domain
+
+import (
+	"slices"
+
+	brzrpc "github.com/autumnterror/breezynotes/api/proto/gen"
+)
+
+// PatPrefix marks personal access tokens, so gateway can tell them from JWT
+const PatPrefix = "brzpat_"
+
+// Scopes of personal access tokens: <resource>:read allows GET requests to resource, <resource>:write others.
+// Write scope includes read
+const (
+	ScopeNotesRead       = "notes:read"
+	ScopeNotesWrite      = "notes:write"
+	ScopeTagsRead        = "tags:read"
+	ScopeTagsWrite       = "tags:write"
+	ScopeFilesWrite      = "files:write"
+	ScopeWorkspacesRead  = "workspaces:read"
+	ScopeWorkspacesWrite = "workspaces:write"
+	ScopeUserRead        = "user:read"
+)
+
+var PatScopes = []string{
+	ScopeNotesRead, ScopeNotesWrite,
+	ScopeTagsRead, ScopeTagsWrite,
+	ScopeFilesWrite,
+	ScopeWorkspacesRead, ScopeWorkspacesWrite,
+	ScopeUserRead,
+}
+
+func ValidScope(scope string) bool {
+	return slices.Contains(PatScopes, scope)
+}
+
+// Pat personal access token of user for scripts. Only hash of token is stored
+type Pat struct {
+	Id         string   `json:"id"`
+	UserId     string   `json:"-"`
+	Name       string   `json:"name"`
+	Scopes     []string `json:"scopes"`
+	CreatedAt  int64    `json:"created_at"`
+	ExpiresAt  int64    `json:"expires_at"`
+	LastUsedAt int64    `json:"last_used_at"`
+}
+
+func PatToRpc(p *Pat) *brzrpc.Pat {
+	if p == nil {
+		return nil
+	}
+	return &brzrpc.Pat{
+		Id:         p.Id,
+		Name:       p.Name,
+		Scopes:     p.Scopes,
+		CreatedAt:  p.CreatedAt,
+		ExpiresAt:  p.ExpiresAt,
+		LastUsedAt: p.LastUsedAt,
+	}
+}
+
+func PatsToRpc(ps []*Pat) *brzrpc.Pats {
+	res := &brzrpc.Pats{Items: make([]*brzrpc.Pat, 0, len(ps))}
+	for _, p := range ps {
+		res.Items = append(res.Items, PatToRpc(p))
+	}
+	return res
+}
@@ -0,0 +1,143 @@
+package domain
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+
+	brzrpc "github.com/autumnterror/breezynotes/api/proto/gen"
+)
+
+// PreferencesVersion of preferences document. Increase it when meaning of field is changed
+// and convert old documents in DecodePreferences. New fields need no new version, they get defaults
+const PreferencesVersion = 1
+
+const (
+	ThemeSystem = "system"
+	ThemeLight  = "light"
+	ThemeDark   = "dark"
+
+	DigestOff    = "off"
+	DigestDaily  = "daily"
+	DigestWeekly = "weekly"
+)
+
+var (
+	Themes    = []string{ThemeSystem, ThemeLight, ThemeDark}
+	NoteSorts = []string{"updated_desc", "updated_asc", "created_desc", "created_asc", "title_asc", "title_desc"}
+	Digests   = []string{DigestOff, DigestDaily, DigestWeekly}
+
+	// localeRe language with optional script and region of BCP 47: en, ru, en-US, zh-Hant-TW
+	localeRe = regexp.MustCompile(`^[a-z]{2,3}(-[A-Z][a-z]{3})?(-([A-Z]{2}|[0-9]{3}))?$`)
+)
+
+type NotificationPreferences struct {
+	// EmailShares letter when note or workspace is shared with user
+	EmailShares   bool `json:"email_shares"`
+	EmailComments bool `json:"email_comments"`
+	EmailMentions bool `json:"email_mentions"`
+	// EmailSecurity letter about new login, password and 2FA changes
+	EmailSecurity bool   `json:"email_security"`
+	Digest        string `json:"digest"`
+}
+
+// Preferences of user stored as JSON document. Retention of trash isn't here, blocknote keeps it
+type Preferences struct {
+	Version int `json:"version"`
+	// Revision is increased on every save, it is stored out of document
+	Revision int64  `json:"-"`
+	Theme    string `json:"theme"`
+	Locale   string `json:"locale"`
+	NoteSort string `json:"note_sort"`
+	// DefaultTag id of tag added to new notes, empty is none
+	DefaultTag    string                  `json:"default_tag"`
+	Notifications NotificationPreferences `json:"notifications"`
+}
+
+func DefaultPreferences() *Preferences {
+	return &Preferences{
+		Version:  PreferencesVersion,
+		Theme:    ThemeSystem,
+		Locale:   "en",
+		NoteSort: NoteSorts[0],
+		Notifications: NotificationPreferences{
+			EmailShares:   true,
+			EmailComments: true,
+			EmailMentions: true,
+			EmailSecurity: true,
+			Digest:        DigestOff,
+		},
+	}
+}
+
+// DecodePreferences read stored document of version. Fields missing in document are default
+func DecodePreferences(version int, data []byte) (*Preferences, error) {
+	if version < 1 || version > PreferencesVersion {
+		return nil, fmt.Errorf("unknown preferences version %d", version)
+	}
+	p := DefaultPreferences()
+	if err := json.Unmarshal(data, p); err != nil {
+		return nil, err
+	}
+	p.Version = PreferencesVersion
+	return p, nil
+}
+
+func (p *Preferences) Validate() error {
+	switch {
+	case !slices.Contains(Themes, p.Theme):
+		return errors.New("theme must be system, light or dark")
+	case !localeRe.MatchString(p.Locale):
+		return errors.New("locale must be language tag like en or en-US")
+	case !slices.Contains(NoteSorts, p.NoteSort):
+		return errors.New("unknown note sort")
+	case !slices.Contains(Digests, p.Notifications.Digest):
+		return errors.New("digest must be off, daily or weekly")
+	}
+	return nil
+}
+
+func PreferencesFromRpc(p *brzrpc.Preferences) *Preferences {
+	if p == nil {
+		return nil
+	}
+	n := p.GetNotifications()
+	return &Preferences{
+		Version:    int(p.GetVersion()),
+		Revision:   p.GetRevision(),
+		Theme:      p.GetTheme(),
+		Locale:     p.GetLocale(),
+		NoteSort:   p.GetNoteSort(),
+		DefaultTag: p.GetDefaultTag(),
+		Notifications: NotificationPreferences{
+			EmailShares:   n.GetEmailShares(),
+			EmailComments: n.GetEmailComments(),
+			EmailMentions: n.GetEmailMentions(),
+			EmailSecurity: n.GetEmailSecurity(),
+			Digest:        n.GetDigest(),
+		},
+	}
+}
+
+func PreferencesToRpc(p *Preferences) *brzrpc.Preferences {
+	if p == nil {
+		return nil
+	}
+	return &brzrpc.Preferences{
+		Version:    int32(p.Version),
+		Revision:   p.Revision,
+		Theme:      p.Theme,
+		Locale:     p.Locale,
+		NoteSort:   p.NoteSort,
+		DefaultTag: p.DefaultTag,
+		Notifications: &brzrpc.NotificationPreferences{
+			EmailShares:   p.Notifications.EmailShares,
+			EmailComments: p.Notifications.EmailComments,
+			EmailMentions: p.Notifications.EmailMentions,
+			EmailSecurity: p.Notifications.EmailSecurity,
+			Digest:        p.Notifications.Digest,
+		},
+	}
+}
@@ -0,0 +1,50 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPreferencesValidate(t *testing.T) {
+	t.Parallel()
+
+	assert.NoError(t, DefaultPreferences().Validate())
+
+	for name, change := range map[string]func(p *Preferences){
+		"theme":  func(p *Preferences) { p.Theme = "blue" },
+		"locale": func(p *Preferences) { p.Locale = "english" },
+		"sort":   func(p *Preferences) { p.NoteSort = "random" },
+		"digest": func(p *Preferences) { p.Notifications.Digest = "hourly" },
+	} {
+		p := DefaultPreferences()
+		change(p)
+		assert.Error(t, p.Validate(), name)
+	}
+
+	p := DefaultPreferences()
+	p.Locale = "zh-Hant-TW"
+	assert.NoError(t, p.Validate())
+}
+
+func TestDecodePreferences(t *testing.T) {
+	t.Parallel()
+
+	p, err := DecodePreferences(1, []byte(`{"version":1,"theme":"dark","notifications":{"email_shares":false}}`))
+	require.NoError(t, err)
+	assert.Equal(t, ThemeDark, p.Theme)
+	assert.Equal(t, "en", p.Locale, "missing field is default")
+	assert.False(t, p.Notifications.EmailShares)
+	assert.True(t, p.Notifications.EmailSecurity)
+	assert.Equal(t, DigestOff, p.Notifications.Digest)
+
+	p, err = DecodePreferences(1, []byte(`{"version":1,"trash_retention_days":30}`))
+	require.NoError(t, err, "retention saved before blocknote kept it is ignored")
+	assert.Equal(t, ThemeSystem, p.Theme)
+
+	_, err = DecodePreferences(PreferencesVersion+1, []byte(`{}`))
+	assert.Error(t, err)
+	_, err = DecodePreferences(1, []byte(`[]`))
+	assert.Error(t, err)
+}
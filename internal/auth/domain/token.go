@@ -15,6 +15,70 @@ type Tokens struct {
 	Refresh Token `json:"refresh"`
 }
 
+// TokenClaims of access and refresh tokens. Both tokens of one login share Family,
+// Jti of refresh token is checked on rotation
+type TokenClaims struct {
+	Id     string
+	Type   string
+	Family string
+	Jti    string
+}
+
+// TokenFamily chain of refresh tokens issued from one login, it is session of user on device.
+// Only CurrentJti can be refreshed, PrevJti is accepted until RotatedAt + grace to survive parallel requests
+type TokenFamily struct {
+	Id         string
+	UserId     string
+	CurrentJti string
+	PrevJti    string
+	RotatedAt  int64
+	ExpiresAt  int64
+	RevokedAt  int64
+	UserAgent  string
+	Ip         string
+	CreatedAt  int64
+	LastSeenAt int64
+}
+
+// Session is TokenFamily as user see it. Current is session of token from request
+type Session struct {
+	Id         string `json:"id"`
+	UserAgent  string `json:"user_agent"`
+	Ip         string `json:"ip"`
+	CreatedAt  int64  `json:"created_at"`
+	LastSeenAt int64  `json:"last_seen_at"`
+	Current    bool   `json:"current"`
+}
+
+func SessionFromFamily(f *TokenFamily, current string) *Session {
+	if f == nil {
+		return nil
+	}
+	return &Session{
+		Id:         f.Id,
+		UserAgent:  f.UserAgent,
+		Ip:         f.Ip,
+		CreatedAt:  f.CreatedAt,
+		LastSeenAt: f.LastSeenAt,
+		Current:    f.Id == current,
+	}
+}
+
+func SessionsToRpc(ss []*Session) *brzrpc.Sessions {
+	res := &brzrpc.Sessions{Items: make([]*brzrpc.Session, 0, len(ss))}
+	for _, s := range ss {
+		res.Items = append(res.Items, &brzrpc.Session{
+			Id:         s.Id,
+			UserAgent:  s.UserAgent,
+			Ip:         s.Ip,
+			CreatedAt:  s.CreatedAt,
+			LastSeenAt: s.LastSeenAt,
+			Current:    s.Current,
+		})
+	}
+	return res
+}
+
 func TokenToRPC(t *Token) *brzrpc.Token {
 	if t == nil {
 		return nil
@@ -36,3 +100,32 @@ func TokensToRPC(ts *Tokens) *brzrpc.Tokens {
 		ExpRefresh:   ts.Refresh.Exp.Unix(),
 	}
 }
+
+// JWK public key of token signing, it is published so other services can verify tokens without auth
+type JWK struct {
+	Kid string `json:"kid"`
+	Kty string `json:"kty"`
+	Alg string `json:"alg"`
+	Use string `json:"use"`
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+	N   string `json:"n,omitempty"`
+	E   string `json:"e,omitempty"`
+}
+
+func JWKSToRpc(keys []JWK) *brzrpc.JWKS {
+	res := &brzrpc.JWKS{Keys: make([]*brzrpc.JWK, 0, len(keys))}
+	for _, k := range keys {
+		res.Keys = append(res.Keys, &brzrpc.JWK{
+			Kid: k.Kid,
+			Kty: k.Kty,
+			Alg: k.Alg,
+			Use: k.Use,
+			Crv: k.Crv,
+			X:   k.X,
+			N:   k.N,
+			E:   k.E,
+		})
+	}
+	return res
+}
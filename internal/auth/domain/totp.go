@@ -0,0 +1,37 @@
+package domain
+
+// Totp second factor of user. Secret is not Enabled until user confirms it with code from app
+type Totp struct {
+	UserId string
+	Secret string
+	// Enabled Auth require code after password
+	Enabled bool
+	// LastStep time step of last accepted code, code can't be used twice
+	LastStep       int64
+	FailedAttempts int
+	FailedAt       int64
+	CreatedAt      int64
+	ConfirmedAt    int64
+}
+
+// RecoveryCode one-time code to login without app. Only hash of code is stored
+type RecoveryCode struct {
+	Id     string
+	UserId string
+	Hash   string
+	UsedAt int64
+}
+
+// TotpSetup is shown to user once on enrolment
+type TotpSetup struct {
+	Secret string `json:"secret"`
+	Uri    string `json:"uri"`
+}
+
+// LoginResult of password check. With enabled 2FA only Challenge is set: tokens are issued after second factor
+type LoginResult struct {
+	User      *User
+	Access    string
+	Refresh   string
+	Challenge string
+}
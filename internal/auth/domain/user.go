@@ -2,6 +2,11 @@ package domain
 
 import brzrpc "github.com/autumnterror/breezynotes/api/proto/gen"
 
+const (
+	RoleUser  = "user"
+	RoleAdmin = "admin"
+)
+
 type User struct {
 	Id       string `json:"id"`
 	Login    string `json:"login"`
@@ -9,6 +14,13 @@ type User struct {
 	About    string `json:"about"`
 	Photo    string `json:"photo"`
 	Password string `json:"password"`
+	// EmailVerified user without verified email can't share notes and publish them to blog
+	EmailVerified bool `json:"email_verified"`
+	// Role is RoleUser or RoleAdmin. Disabled user can't login, his sessions and tokens don't work
+	Role     string `json:"role"`
+	Disabled bool   `json:"disabled"`
+	// Discoverable user is found by part of login or email, else only by exact login or email
+	Discoverable bool `json:"discoverable"`
 }
 
 func UserFromRpc(u *brzrpc.User) *User {
@@ -16,12 +28,16 @@ func UserFromRpc(u *brzrpc.User) *User {
 		return nil
 	}
 	return &User{
-		Id:       u.GetId(),
-		Login:    u.GetLogin(),
-		Email:    u.GetEmail(),
-		About:    u.GetAbout(),
-		Photo:    u.GetPhoto(),
-		Password: u.GetPassword(),
+		Id:            u.GetId(),
+		Login:         u.GetLogin(),
+		Email:         u.GetEmail(),
+		About:         u.GetAbout(),
+		Photo:         u.GetPhoto(),
+		Password:      u.GetPassword(),
+		EmailVerified: u.GetEmailVerified(),
+		Role:          u.GetRole(),
+		Disabled:      u.GetDisabled(),
+		Discoverable:  u.GetDiscoverable(),
 	}
 }
 
@@ -30,12 +46,16 @@ func UserToRpc(u *User) *brzrpc.User {
 		return nil
 	}
 	return &brzrpc.User{
-		Id:       u.Id,
-		Login:    u.Login,
-		Email:    u.Email,
-		About:    u.About,
-		Photo:    u.Photo,
-		Password: u.Password,
+		Id:            u.Id,
+		Login:         u.Login,
+		Email:         u.Email,
+		About:         u.About,
+		Photo:         u.Photo,
+		Password:      u.Password,
+		EmailVerified: u.EmailVerified,
+		Role:          u.Role,
+		Disabled:      u.Disabled,
+		Discoverable:  u.Discoverable,
 	}
 }
 
@@ -58,3 +78,24 @@ func UsersToRpc(us *Users) *brzrpc.Users {
 
 	return usRpc
 }
+
+// UserCard is what other users see in search, email is not shown
+type UserCard struct {
+	Id    string `json:"id"`
+	Login string `json:"login"`
+	Photo string `json:"photo"`
+	About string `json:"about"`
+}
+
+func UserCardsToRpc(us []*UserCard) *brzrpc.UserCards {
+	res := &brzrpc.UserCards{Users: make([]*brzrpc.UserCard, 0, len(us))}
+	for _, u := range us {
+		res.Users = append(res.Users, &brzrpc.UserCard{
+			Id:    u.Id,
+			Login: u.Login,
+			Photo: u.Photo,
+			About: u.About,
+		})
+	}
+	return res
+}
@@ -0,0 +1,51 @@
+package domain
+
+import brzrpc "github.com/autumnterror/breezynotes/api/proto/gen"
+
+// Workspace is a group of users sharing notes and tags. Role is the role of the requesting user
+type Workspace struct {
+	Id      string `json:"id"`
+	Title   string `json:"title"`
+	OwnerId string `json:"owner_id"`
+	Role    string `json:"role"`
+}
+
+type WorkspaceMember struct {
+	UserId string `json:"user_id"`
+	Role   string `json:"role"`
+}
+
+func WorkspaceToRpc(w *Workspace) *brzrpc.Workspace {
+	if w == nil {
+		return nil
+	}
+	return &brzrpc.Workspace{
+		Id:      w.Id,
+		Title:   w.Title,
+		OwnerId: w.OwnerId,
+		Role:    w.Role,
+	}
+}
+
+func WorkspacesToRpc(ws []*Workspace) *brzrpc.Workspaces {
+	res := &brzrpc.Workspaces{
+		Items: []*brzrpc.Workspace{},
+	}
+	for _, w := range ws {
+		res.Items = append(res.Items, WorkspaceToRpc(w))
+	}
+	return res
+}
+
+func WorkspaceMembersToRpc(ms []*WorkspaceMember) *brzrpc.WorkspaceMembers {
+	res := &brzrpc.WorkspaceMembers{
+		Items: []*brzrpc.WorkspaceMember{},
+	}
+	for _, m := range ms {
+		res.Items = append(res.Items, &brzrpc.WorkspaceMember{
+			UserId: m.UserId,
+			Role:   m.Role,
+		})
+	}
+	return res
+}
@@ -7,29 +7,66 @@ import (
 )
 
 type RepoProvider struct {
-	db *sql.DB
+	db     *sql.DB
+	hasher repository.Hasher
 }
 
-func NewRepoProvider(db *sql.DB) *RepoProvider {
-	return &RepoProvider{db: db}
+// NewRepoProvider with nil hasher passwords are hashed by argon2id with default parameters
+func NewRepoProvider(db *sql.DB, hasher repository.Hasher) *RepoProvider {
+	return &RepoProvider{db: db, hasher: hasher}
 }
 
-func (p *RepoProvider) Auth(ctx context.Context) repository.AuthRepo {
+// driver return repository in transaction of ctx, if there is one
+func (p *RepoProvider) driver(ctx context.Context) repository.Driver {
 	if tx, ok := TxFromContext(ctx); ok {
-		return repository.Driver{Driver: tx}
+		return repository.Driver{Driver: tx, Hasher: p.hasher}
 	}
-	return repository.Driver{Driver: p.db}
+	return repository.Driver{Driver: p.db, Hasher: p.hasher}
+}
+
+func (p *RepoProvider) Auth(ctx context.Context) repository.AuthRepo {
+	return p.driver(ctx)
 }
 
 func (p *RepoProvider) User(ctx context.Context) repository.UserRepo {
-	if tx, ok := TxFromContext(ctx); ok {
-		return repository.Driver{Driver: tx}
-	}
-	return repository.Driver{Driver: p.db}
+	return p.driver(ctx)
 }
 func (p *RepoProvider) Health(ctx context.Context) repository.HealthRepo {
-	if tx, ok := TxFromContext(ctx); ok {
-		return repository.Driver{Driver: tx}
-	}
-	return repository.Driver{Driver: p.db}
+	return p.driver(ctx)
+}
+
+func (p *RepoProvider) Workspace(ctx context.Context) repository.WorkspaceRepo {
+	return p.driver(ctx)
+}
+
+func (p *RepoProvider) Token(ctx context.Context) repository.TokenRepo {
+	return p.driver(ctx)
+}
+
+func (p *RepoProvider) TwoFactor(ctx context.Context) repository.TwoFactorRepo {
+	return p.driver(ctx)
+}
+
+func (p *RepoProvider) ActionToken(ctx context.Context) repository.ActionTokenRepo {
+	return p.driver(ctx)
+}
+
+func (p *RepoProvider) Identity(ctx context.Context) repository.IdentityRepo {
+	return p.driver(ctx)
+}
+
+func (p *RepoProvider) Pat(ctx context.Context) repository.PatRepo {
+	return p.driver(ctx)
+}
+
+func (p *RepoProvider) Audit(ctx context.Context) repository.AuditRepo {
+	return p.driver(ctx)
+}
+
+func (p *RepoProvider) Preferences(ctx context.Context) repository.PreferencesRepo {
+	return p.driver(ctx)
+}
+
+func (p *RepoProvider) Deletion(ctx context.Context) repository.DeletionRepo {
+	return p.driver(ctx)
 }
@@ -5,9 +5,17 @@ import (
 )
 
 type WithConfig struct {
-	cfg *config.Config
+	cfg  *config.Config
+	keys *KeySet
 }
 
+// NewWithConfig sign tokens by HS256 with cfg.TokenKey
 func NewWithConfig(cfg *config.Config) *WithConfig {
 	return &WithConfig{cfg: cfg}
 }
+
+// NewWithKeys sign tokens by active key of ks. HS256 tokens with cfg.TokenKey are still verified
+// until cfg.LegacyTokensUntil, so switch from shared key does not logout users
+func NewWithKeys(cfg *config.Config, ks *KeySet) *WithConfig {
+	return &WithConfig{cfg: cfg, keys: ks}
+}
@@ -5,36 +5,57 @@ import (
 	"fmt"
 	"github.com/autumnterror/breezynotes/internal/auth/domain"
 	"github.com/autumnterror/utils_go/pkg/utils/format"
+	"github.com/autumnterror/utils_go/pkg/utils/uid"
 	"github.com/golang-jwt/jwt/v5"
 	"time"
 )
 
 type WithConfigRepo interface {
 	GenerateToken(id, _type string) (string, error)
+	GenerateTokenInFamily(id, _type, family, jti string) (string, error)
 	VerifyToken(tokenString string) (*jwt.Token, error)
 	GetIdFromToken(token *jwt.Token) (string, error)
 	GetTypeFromToken(token *jwt.Token) (string, error)
-	Refresh(refreshToken string) (string, error)
+	GetClaimsFromToken(token *jwt.Token) (*domain.TokenClaims, error)
+	JWKS() []domain.JWK
 }
 
-// GenerateToken generation JWT by TYPE values: "ACCESS" or "REFRESH"
+// GenerateToken generation JWT by TYPE values: "ACCESS" or "REFRESH" in new family
 func (w *WithConfig) GenerateToken(id, _type string) (string, error) {
+	return w.GenerateTokenInFamily(id, _type, uid.New(), uid.New())
+}
+
+// GenerateTokenInFamily generation JWT by TYPE values: "ACCESS", "REFRESH" or "CHALLENGE" with family and jti claims
+func (w *WithConfig) GenerateTokenInFamily(id, _type, family, jti string) (string, error) {
 	const op = "jwt.WithConfig.GenerateToken"
-	token := jwt.New(jwt.SigningMethodHS256)
 
-	claims := token.Claims.(jwt.MapClaims)
+	claims := jwt.MapClaims{}
 	claims["id"] = id
 	claims["type"] = _type
+	claims["fid"] = family
+	claims["jti"] = jti
 	switch _type {
 	case domain.TokenTypeAccess:
 		claims["exp"] = time.Now().Add(w.cfg.AccessTokenLifeTime).Unix()
 	case domain.TokenTypeRefresh:
 		claims["exp"] = time.Now().Add(w.cfg.RefreshTokenLifeTime).Unix()
+	case domain.TokenTypeChallenge:
+		claims["exp"] = time.Now().Add(w.cfg.ChallengeLifeTime).Unix()
 	default:
 		return "", format.Error(op, domain.ErrWrongType)
 	}
 
-	ts, err := token.SignedString([]byte(w.cfg.TokenKey))
+	var (
+		ts  string
+		err error
+	)
+	if w.keys != nil && w.keys.active != nil {
+		token := jwt.NewWithClaims(w.keys.active.Method, claims)
+		token.Header["kid"] = w.keys.active.Kid
+		ts, err = token.SignedString(w.keys.active.Private)
+	} else {
+		ts, err = jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(w.cfg.TokenKey))
+	}
 	if err != nil {
 		return "", format.Error(op, err)
 	}
@@ -45,12 +66,22 @@ func (w *WithConfig) GenerateToken(id, _type string) (string, error) {
 func (w *WithConfig) VerifyToken(tokenString string) (*jwt.Token, error) {
 	const op = "jwt.WithConfig.VerifyToken"
 	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+		switch token.Method.(type) {
+		case *jwt.SigningMethodHMAC:
+			if !w.legacyAccepted() {
+				return nil, domain.ErrTokenInvalid
+			}
+			return []byte(w.cfg.TokenKey), nil
+		case *jwt.SigningMethodEd25519, *jwt.SigningMethodRSA:
+			kid, _ := token.Header["kid"].(string)
+			return w.keys.publicKey(kid, token.Method)
+		default:
 			return nil, domain.ErrTokenInvalid
 			//return nil, format.Error(op, fmt.Errorf("unexpected signing method: %v", token.Header["alg"]))
 		}
-		return []byte(w.cfg.TokenKey), nil
-	})
+	}, jwt.WithValidMethods([]string{
+		jwt.SigningMethodHS256.Alg(), jwt.SigningMethodEdDSA.Alg(), jwt.SigningMethodRS256.Alg(),
+	}))
 	if err != nil {
 		if errors.Is(err, jwt.ErrTokenExpired) {
 			return nil, domain.ErrTokenExpired
@@ -60,6 +91,18 @@ func (w *WithConfig) VerifyToken(tokenString string) (*jwt.Token, error) {
 	return token, nil
 }
 
+// legacyAccepted HS256 tokens are signed without keys. With keys they are migration from shared key and
+// are accepted only until cfg.LegacyTokensUntil
+func (w *WithConfig) legacyAccepted() bool {
+	if w.cfg.TokenKey == "" {
+		return false
+	}
+	if w.keys == nil {
+		return true
+	}
+	return time.Now().Before(w.cfg.LegacyTokensUntil)
+}
+
 // GetIdFromToken return id from token
 func (w *WithConfig) GetIdFromToken(token *jwt.Token) (string, error) {
 	const op = "jwt.WithConfig.GetLoginFromToken"
@@ -92,34 +135,36 @@ func (w *WithConfig) GetTypeFromToken(token *jwt.Token) (string, error) {
 	return tp.(string), nil
 }
 
-// Refresh check refresh token and if all ok return new access token
-func (w *WithConfig) Refresh(refreshToken string) (string, error) {
-	const op = "jwt.WithConfig.Refresh"
+// GetClaimsFromToken return all claims of token. Family and Jti are empty for tokens issued before families
+func (w *WithConfig) GetClaimsFromToken(token *jwt.Token) (*domain.TokenClaims, error) {
+	const op = "jwt.WithConfig.GetClaimsFromToken"
 
-	rawRefToken, err := w.VerifyToken(refreshToken)
-	if err != nil {
-		if errors.Is(err, domain.ErrTokenExpired) {
-			return "", err
-		}
-		return "", format.Error(op, err)
-	}
-	tp, err := w.GetTypeFromToken(rawRefToken)
-	if err != nil {
-		return "", format.Error(op, err)
+	c, ok := token.Claims.(jwt.MapClaims)
+	if !ok || !token.Valid {
+		return nil, format.Error(op, fmt.Errorf("invalid token claims"))
 	}
 
-	if tp != domain.TokenTypeRefresh {
-		return "", domain.ErrWrongType
+	str := func(key string) string {
+		v, _ := c[key].(string)
+		return v
 	}
 
-	id, err := w.GetIdFromToken(rawRefToken)
-	if err != nil {
-		return "", format.Error(op, err)
+	claims := &domain.TokenClaims{
+		Id:     str("id"),
+		Type:   str("type"),
+		Family: str("fid"),
+		Jti:    str("jti"),
 	}
-
-	token, err := w.GenerateToken(id, domain.TokenTypeAccess)
-	if err != nil {
-		return "", format.Error(op, err)
+	if claims.Id == "" {
+		return nil, format.Error(op, fmt.Errorf("id not detected"))
 	}
-	return token, nil
+	if claims.Type == "" {
+		return nil, format.Error(op, fmt.Errorf("type not detected"))
+	}
+	return claims, nil
+}
+
+// JWKS return public keys of token signing. Empty when tokens are signed by shared key
+func (w *WithConfig) JWKS() []domain.JWK {
+	return w.keys.JWKS()
 }
@@ -54,24 +54,6 @@ func TestGenerateAndVerifyToken(t *testing.T) {
 	})
 }
 
-func TestRefreshToken(t *testing.T) {
-	t.Parallel()
-	j := NewWithConfig(config.Test())
-
-	refreshToken, err := j.GenerateToken("refresh_id", domain.TokenTypeRefresh)
-	assert.NoError(t, err)
-
-	accessToken, err := j.Refresh(refreshToken)
-	assert.NoError(t, err)
-
-	parsed, err := j.VerifyToken(accessToken)
-	assert.NoError(t, err)
-
-	tp, err := j.GetTypeFromToken(parsed)
-	assert.NoError(t, err)
-	assert.Equal(t, domain.TokenTypeAccess, tp)
-}
-
 func TestExpiredToken(t *testing.T) {
 	t.Parallel()
 	cfg := config.Test()
@@ -92,15 +74,19 @@ func TestExpiredToken(t *testing.T) {
 	assert.ErrorIs(t, err, domain.ErrTokenExpired)
 }
 
-func TestWrongTypeRefresh(t *testing.T) {
+func TestTokenFamilyClaims(t *testing.T) {
 	t.Parallel()
 	j := NewWithConfig(config.Test())
 
-	accessToken, err := j.GenerateToken("userX", domain.TokenTypeAccess)
+	tokenStr, err := j.GenerateTokenInFamily("userF", domain.TokenTypeRefresh, "family", "jti")
 	assert.NoError(t, err)
 
-	_, err = j.Refresh(accessToken)
-	assert.ErrorIs(t, err, domain.ErrWrongType)
+	token, err := j.VerifyToken(tokenStr)
+	assert.NoError(t, err)
+
+	claims, err := j.GetClaimsFromToken(token)
+	assert.NoError(t, err)
+	assert.Equal(t, &domain.TokenClaims{Id: "userF", Type: domain.TokenTypeRefresh, Family: "family", Jti: "jti"}, claims)
 }
 
 func TestInvalidTokenStructure(t *testing.T) {
@@ -0,0 +1,177 @@
+package jwt
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/autumnterror/breezynotes/internal/auth/domain"
+	"github.com/autumnterror/utils_go/pkg/utils/format"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	keyFileExt    = ".pem"
+	minRsaKeyBits = 2048
+)
+
+// Key private key of token signing. Kid is written in header of every token signed by it
+type Key struct {
+	Kid     string
+	Method  jwt.SigningMethod
+	Private crypto.Signer
+}
+
+// KeySet keys of token signing. Only Active key signs new tokens, all keys verify tokens and are published in JWKS.
+//
+// Rotation without downtime:
+//  1. put new key in dir of every instance and restart them: it is published, but not used yet
+//  2. set signing_key_id to new key and restart: new tokens are signed by it, old tokens are still valid
+//  3. rm old key after refresh_token_life passed
+type KeySet struct {
+	active *Key
+	keys   map[string]*Key
+}
+
+// LoadKeySet read all <kid>.pem files from dir. Supported keys are PKCS#8 Ed25519 (EdDSA) and RSA (RS256)
+func LoadKeySet(dir, activeKid string) (*KeySet, error) {
+	const op = "jwt.LoadKeySet"
+
+	files, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, format.Error(op, err)
+	}
+
+	ks := &KeySet{keys: make(map[string]*Key)}
+	for _, f := range files {
+		if f.IsDir() || filepath.Ext(f.Name()) != keyFileExt {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(dir, f.Name()))
+		if err != nil {
+			return nil, format.Error(op, err)
+		}
+		k, err := ParseKey(strings.TrimSuffix(f.Name(), keyFileExt), data)
+		if err != nil {
+			return nil, format.Error(op, err)
+		}
+		ks.keys[k.Kid] = k
+	}
+
+	return ks, ks.setActive(activeKid)
+}
+
+// NewKeySet make set from keys, key with activeKid signs tokens
+func NewKeySet(activeKid string, keys ...*Key) (*KeySet, error) {
+	ks := &KeySet{keys: make(map[string]*Key, len(keys))}
+	for _, k := range keys {
+		ks.keys[k.Kid] = k
+	}
+	return ks, ks.setActive(activeKid)
+}
+
+func (ks *KeySet) setActive(kid string) error {
+	const op = "jwt.KeySet.setActive"
+
+	active, ok := ks.keys[kid]
+	if !ok {
+		return format.Error(op, fmt.Errorf("signing key %q not found", kid))
+	}
+	ks.active = active
+	return nil
+}
+
+// ParseKey parse PEM private key
+func ParseKey(kid string, data []byte) (*Key, error) {
+	const op = "jwt.ParseKey"
+	if kid == "" {
+		return nil, format.Error(op, errors.New("kid is empty"))
+	}
+
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, format.Error(op, errors.New("no PEM data in key "+kid))
+	}
+
+	var (
+		raw any
+		err error
+	)
+	switch block.Type {
+	case "PRIVATE KEY":
+		raw, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	case "RSA PRIVATE KEY":
+		raw, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	default:
+		return nil, format.Error(op, fmt.Errorf("unsupported PEM type %q of key %s", block.Type, kid))
+	}
+	if err != nil {
+		return nil, format.Error(op, err)
+	}
+
+	switch k := raw.(type) {
+	case ed25519.PrivateKey:
+		return &Key{Kid: kid, Method: jwt.SigningMethodEdDSA, Private: k}, nil
+	case *rsa.PrivateKey:
+		if k.N.BitLen() < minRsaKeyBits {
+			return nil, format.Error(op, fmt.Errorf("rsa key %s is shorter than %d bits", kid, minRsaKeyBits))
+		}
+		return &Key{Kid: kid, Method: jwt.SigningMethodRS256, Private: k}, nil
+	default:
+		return nil, format.Error(op, fmt.Errorf("unsupported type %T of key %s", raw, kid))
+	}
+}
+
+// publicKey return key to verify token signed by kid with method
+func (ks *KeySet) publicKey(kid string, method jwt.SigningMethod) (crypto.PublicKey, error) {
+	if ks == nil {
+		return nil, domain.ErrTokenInvalid
+	}
+	k, ok := ks.keys[kid]
+	if !ok || k.Method.Alg() != method.Alg() {
+		return nil, domain.ErrTokenInvalid
+	}
+	return k.Private.Public(), nil
+}
+
+// JWKS return public part of all keys, sorted by kid
+func (ks *KeySet) JWKS() []domain.JWK {
+	if ks == nil {
+		return []domain.JWK{}
+	}
+
+	res := make([]domain.JWK, 0, len(ks.keys))
+	for _, k := range ks.keys {
+		jwk := domain.JWK{
+			Kid: k.Kid,
+			Alg: k.Method.Alg(),
+			Use: "sig",
+		}
+		switch pub := k.Private.Public().(type) {
+		case ed25519.PublicKey:
+			jwk.Kty = "OKP"
+			jwk.Crv = "Ed25519"
+			jwk.X = base64.RawURLEncoding.EncodeToString(pub)
+		case *rsa.PublicKey:
+			jwk.Kty = "RSA"
+			jwk.N = base64.RawURLEncoding.EncodeToString(pub.N.Bytes())
+			jwk.E = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(pub.E)).Bytes())
+		default:
+			continue
+		}
+		res = append(res, jwk)
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].Kid < res[j].Kid })
+
+	return res
+}
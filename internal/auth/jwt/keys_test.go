@@ -0,0 +1,93 @@
+package jwt
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"testing"
+	"time"
+
+	"github.com/autumnterror/breezynotes/internal/auth/config"
+	"github.com/autumnterror/breezynotes/internal/auth/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func newEdKey(t *testing.T, kid string) *Key {
+	t.Helper()
+	_, priv, err := ed25519.GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+
+	der, err := x509.MarshalPKCS8PrivateKey(priv)
+	assert.NoError(t, err)
+
+	k, err := ParseKey(kid, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}))
+	assert.NoError(t, err)
+	return k
+}
+
+func TestKeyRotation(t *testing.T) {
+	t.Parallel()
+	cfg := config.Test()
+	oldKey, newKey := newEdKey(t, "old"), newEdKey(t, "new")
+
+	before, err := NewKeySet("old", oldKey)
+	assert.NoError(t, err)
+	after, err := NewKeySet("new", oldKey, newKey)
+	assert.NoError(t, err)
+
+	oldToken, err := NewWithKeys(cfg, before).GenerateToken("user", domain.TokenTypeAccess)
+	assert.NoError(t, err)
+
+	j := NewWithKeys(cfg, after)
+	token, err := j.VerifyToken(oldToken)
+	assert.NoError(t, err)
+	assert.Equal(t, "old", token.Header["kid"])
+
+	newToken, err := j.GenerateToken("user", domain.TokenTypeAccess)
+	assert.NoError(t, err)
+	token, err = j.VerifyToken(newToken)
+	assert.NoError(t, err)
+	assert.Equal(t, "new", token.Header["kid"])
+	assert.Equal(t, "EdDSA", token.Method.Alg())
+
+	_, err = NewWithKeys(cfg, before).VerifyToken(newToken)
+	assert.Error(t, err)
+
+	jwks := j.JWKS()
+	assert.Len(t, jwks, 2)
+	assert.Equal(t, "new", jwks[0].Kid)
+	assert.Equal(t, "OKP", jwks[0].Kty)
+	assert.Equal(t, "Ed25519", jwks[0].Crv)
+	assert.NotEmpty(t, jwks[0].X)
+
+	_, err = NewKeySet("missing", oldKey)
+	assert.Error(t, err)
+}
+
+func TestLegacyTokensWindow(t *testing.T) {
+	t.Parallel()
+	cfg := config.Test()
+	ks, err := NewKeySet("k1", newEdKey(t, "k1"))
+	assert.NoError(t, err)
+
+	legacy, err := NewWithConfig(cfg).GenerateToken("user", domain.TokenTypeAccess)
+	assert.NoError(t, err)
+
+	_, err = NewWithKeys(cfg, ks).VerifyToken(legacy)
+	assert.Error(t, err, "without window HS256 is rejected")
+
+	open := *cfg
+	open.LegacyTokensUntil = time.Now().Add(time.Hour)
+	token, err := NewWithKeys(&open, ks).VerifyToken(legacy)
+	assert.NoError(t, err)
+	assert.Equal(t, "HS256", token.Method.Alg())
+
+	closed := *cfg
+	closed.LegacyTokensUntil = time.Now().Add(-time.Second)
+	_, err = NewWithKeys(&closed, ks).VerifyToken(legacy)
+	assert.Error(t, err)
+
+	_, err = NewWithConfig(&closed).VerifyToken(legacy)
+	assert.NoError(t, err, "window does not limit tokens without keys")
+}
@@ -0,0 +1,26 @@
+// Package limiter count attempts by key in fixed window. Counters are kept in redis service, so all replicas
+// of auth see them. Without address of redis service they are kept in memory of process
+package limiter
+
+import (
+	"context"
+	"time"
+
+	"github.com/autumnterror/breezynotes/internal/auth/config"
+)
+
+type Counter interface {
+	// Incr add attempt and return count of attempts in window and time left until window is over
+	Incr(ctx context.Context, key string, window time.Duration) (int64, time.Duration, error)
+	// Get return count like Incr without adding attempt. Count is 0 if there is no window
+	Get(ctx context.Context, key string) (int64, time.Duration, error)
+	Reset(ctx context.Context, key string) error
+}
+
+// New return counter in redis service by cfg.AddrRedis. Empty address is memory
+func New(cfg *config.Config) (Counter, error) {
+	if cfg.AddrRedis == "" {
+		return NewMemory(), nil
+	}
+	return NewRedis(cfg.AddrRedis)
+}
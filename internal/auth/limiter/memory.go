@@ -0,0 +1,68 @@
+package limiter
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+type window struct {
+	count int64
+	until time.Time
+}
+
+// Memory counter for one replica and local development
+type Memory struct {
+	mu      sync.Mutex
+	windows map[string]window
+	now     func() time.Time
+}
+
+func NewMemory() *Memory {
+	return &Memory{windows: make(map[string]window), now: time.Now}
+}
+
+func (m *Memory) Incr(_ context.Context, key string, d time.Duration) (int64, time.Duration, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := m.now()
+	m.cleanup(now)
+	w, ok := m.windows[key]
+	if !ok {
+		w.until = now.Add(d)
+	}
+	w.count++
+	m.windows[key] = w
+
+	return w.count, w.until.Sub(now), nil
+}
+
+func (m *Memory) Get(_ context.Context, key string) (int64, time.Duration, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := m.now()
+	w, ok := m.windows[key]
+	if !ok || !now.Before(w.until) {
+		return 0, 0, nil
+	}
+	return w.count, w.until.Sub(now), nil
+}
+
+func (m *Memory) Reset(_ context.Context, key string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.windows, key)
+	return nil
+}
+
+// cleanup remove windows which are over, so map doesn't grow with keys of every login tried
+func (m *Memory) cleanup(now time.Time) {
+	for k, w := range m.windows {
+		if !now.Before(w.until) {
+			delete(m.windows, k)
+		}
+	}
+}
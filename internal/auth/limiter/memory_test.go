@@ -0,0 +1,51 @@
+package limiter
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemory(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	now := time.Unix(1000, 0)
+	m := NewMemory()
+	m.now = func() time.Time { return now }
+
+	for i := int64(1); i <= 3; i++ {
+		count, ttl, err := m.Incr(ctx, "k", time.Minute)
+		require.NoError(t, err)
+		assert.Equal(t, i, count)
+		assert.Equal(t, time.Minute, ttl)
+	}
+
+	now = now.Add(20 * time.Second)
+	count, ttl, err := m.Get(ctx, "k")
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), count)
+	assert.Equal(t, 40*time.Second, ttl)
+
+	require.NoError(t, m.Reset(ctx, "k"))
+	count, _, err = m.Get(ctx, "k")
+	require.NoError(t, err)
+	assert.Zero(t, count)
+
+	t.Run("window is over", func(t *testing.T) {
+		_, _, err := m.Incr(ctx, "k2", time.Minute)
+		require.NoError(t, err)
+		now = now.Add(time.Minute)
+
+		count, _, err := m.Get(ctx, "k2")
+		require.NoError(t, err)
+		assert.Zero(t, count)
+
+		count, _, err = m.Incr(ctx, "k2", time.Minute)
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), count)
+	})
+}
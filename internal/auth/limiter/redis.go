@@ -0,0 +1,58 @@
+package limiter
+
+import (
+	"context"
+	"time"
+
+	brzrpc "github.com/autumnterror/breezynotes/api/proto/gen"
+	"github.com/autumnterror/utils_go/pkg/utils/format"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// Redis counter in redis service. Connection is lazy, auth starts even if redis service is down
+type Redis struct {
+	api brzrpc.RedisServiceClient
+}
+
+func NewRedis(addr string) (*Redis, error) {
+	const op = "limiter.NewRedis"
+
+	cc, err := grpc.NewClient(addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, format.Error(op, err)
+	}
+	return &Redis{api: brzrpc.NewRedisServiceClient(cc)}, nil
+}
+
+func (r *Redis) Incr(ctx context.Context, key string, window time.Duration) (int64, time.Duration, error) {
+	const op = "limiter.Redis.Incr"
+
+	res, err := r.api.RateLimit(ctx, &brzrpc.RateLimitRequest{
+		Key:                key,
+		WindowMilliseconds: window.Milliseconds(),
+	})
+	if err != nil {
+		return 0, 0, format.Error(op, err)
+	}
+	return res.GetCount(), time.Duration(res.GetTtl()) * time.Millisecond, nil
+}
+
+func (r *Redis) Get(ctx context.Context, key string) (int64, time.Duration, error) {
+	const op = "limiter.Redis.Get"
+
+	res, err := r.api.GetCounter(ctx, &brzrpc.String{Value: key})
+	if err != nil {
+		return 0, 0, format.Error(op, err)
+	}
+	return res.GetCount(), time.Duration(res.GetTtl()) * time.Millisecond, nil
+}
+
+func (r *Redis) Reset(ctx context.Context, key string) error {
+	const op = "limiter.Redis.Reset"
+
+	if _, err := r.api.ResetCounter(ctx, &brzrpc.String{Value: key}); err != nil {
+		return format.Error(op, err)
+	}
+	return nil
+}
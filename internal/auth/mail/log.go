@@ -0,0 +1,41 @@
+package mail
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/autumnterror/utils_go/pkg/log"
+	"github.com/autumnterror/utils_go/pkg/utils/format"
+)
+
+// Log print letters to log and, if dir is set, save them as .eml files. It is for local development only:
+// letters contain tokens
+type Log struct {
+	dir string
+}
+
+func NewLog(dir string) *Log {
+	return &Log{dir: dir}
+}
+
+func (l *Log) Send(_ context.Context, m Message) error {
+	const op = "mail.Log.Send"
+
+	log.Info(op, fmt.Sprintf("to: %s subject: %s\n%s", m.To, m.Subject, m.Body))
+	if l.dir == "" {
+		return nil
+	}
+
+	if err := os.MkdirAll(l.dir, 0o755); err != nil {
+		return format.Error(op, err)
+	}
+	name := fmt.Sprintf("%d-%s.eml", time.Now().UnixNano(), strings.NewReplacer("@", "_at_", "/", "_").Replace(m.To))
+	if err := os.WriteFile(filepath.Join(l.dir, name), build("noreply@localhost", m), 0o644); err != nil {
+		return format.Error(op, err)
+	}
+	return nil
+}
@@ -0,0 +1,42 @@
+// Package mail send letters to users. Sender is chosen by config: smtp for production, log for local development
+package mail
+
+import (
+	"context"
+	"errors"
+
+	"github.com/autumnterror/breezynotes/internal/auth/config"
+	"github.com/autumnterror/utils_go/pkg/utils/format"
+)
+
+const (
+	ModeSMTP = "smtp"
+	ModeLog  = "log"
+)
+
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+type Sender interface {
+	Send(ctx context.Context, m Message) error
+}
+
+// New return sender by cfg.MailMode. Empty mode is log
+func New(cfg *config.Config) (Sender, error) {
+	const op = "mail.New"
+
+	switch cfg.MailMode {
+	case ModeSMTP:
+		if cfg.SmtpHost == "" || cfg.MailFrom == "" {
+			return nil, format.Error(op, errors.New("smtp_host and mail_from are required"))
+		}
+		return NewSMTP(cfg.SmtpHost, cfg.SmtpPort, cfg.SmtpUser, cfg.SmtpPassword, cfg.MailFrom), nil
+	case ModeLog, "":
+		return NewLog(cfg.MailDir), nil
+	default:
+		return nil, format.Error(op, errors.New("unknown mail_mode "+cfg.MailMode))
+	}
+}
@@ -0,0 +1,72 @@
+package mail
+
+import (
+	"context"
+	"fmt"
+	"mime"
+	"net"
+	"net/smtp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/autumnterror/utils_go/pkg/utils/format"
+)
+
+// SMTP send letters by server with PLAIN auth. Connection is upgraded by STARTTLS when server supports it
+type SMTP struct {
+	addr string
+	host string
+	user string
+	pw   string
+	from string
+}
+
+func NewSMTP(host string, port int, user, pw, from string) *SMTP {
+	return &SMTP{
+		addr: net.JoinHostPort(host, strconv.Itoa(port)),
+		host: host,
+		user: user,
+		pw:   pw,
+		from: from,
+	}
+}
+
+func (s *SMTP) Send(ctx context.Context, m Message) error {
+	const op = "mail.SMTP.Send"
+
+	var auth smtp.Auth
+	if s.user != "" {
+		auth = smtp.PlainAuth("", s.user, s.pw, s.host)
+	}
+
+	res := make(chan error, 1)
+	go func() {
+		res <- smtp.SendMail(s.addr, auth, s.from, []string{m.To}, build(s.from, m))
+	}()
+
+	select {
+	case <-ctx.Done():
+		return format.Error(op, ctx.Err())
+	case err := <-res:
+		if err != nil {
+			return format.Error(op, err)
+		}
+		return nil
+	}
+}
+
+// build return letter in RFC 5322 format with utf-8 plain text body
+func build(from string, m Message) []byte {
+	var b strings.Builder
+	fmt.Fprintf(&b, "From: %s\r\n", from)
+	fmt.Fprintf(&b, "To: %s\r\n", m.To)
+	fmt.Fprintf(&b, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", m.Subject))
+	fmt.Fprintf(&b, "Date: %s\r\n", time.Now().UTC().Format(time.RFC1123Z))
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=\"utf-8\"\r\n")
+	b.WriteString("Content-Transfer-Encoding: 8bit\r\n")
+	b.WriteString("\r\n")
+	b.WriteString(strings.ReplaceAll(m.Body, "\n", "\r\n"))
+	return []byte(b.String())
+}
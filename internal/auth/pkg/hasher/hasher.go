@@ -0,0 +1,143 @@
+// Package hasher hashes passwords by argon2id (RFC 9106) to PHC strings:
+// $argon2id$v=19$m=<KiB>,t=<iterations>,p=<parallelism>$<salt>$<key>.
+// Legacy bcrypt hashes are verified too and are reported as outdated
+package hasher
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/bcrypt"
+)
+
+const (
+	saltLen = 16
+	keyLen  = 32
+	// maxMemory limit of hash parameters, hash from database must not make service allocate too much
+	maxMemory = 1 << 20
+)
+
+var (
+	ErrMalformedHash = errors.New("malformed password hash")
+	ErrBadParams     = errors.New("bad argon2id parameters")
+)
+
+var encoding = base64.RawStdEncoding
+
+// Params of argon2id. Memory is in KiB
+type Params struct {
+	Memory      uint32
+	Iterations  uint32
+	Parallelism uint8
+}
+
+// DefaultParams is minimal configuration recommended by OWASP
+var DefaultParams = Params{Memory: 19 * 1024, Iterations: 2, Parallelism: 1}
+
+func (p Params) valid() bool {
+	return p.Memory >= 8*uint32(p.Parallelism) && p.Memory <= maxMemory && p.Iterations > 0 && p.Parallelism > 0
+}
+
+// Argon2id is safe for concurrent use
+type Argon2id struct {
+	p     Params
+	dummy func() string
+}
+
+func New(p Params) (*Argon2id, error) {
+	if !p.valid() {
+		return nil, fmt.Errorf("%w: %+v", ErrBadParams, p)
+	}
+	h := &Argon2id{p: p}
+	h.dummy = sync.OnceValue(func() string {
+		res, _ := h.Hash("breezynotes-dummy-password")
+		return res
+	})
+	return h, nil
+}
+
+// Hash return PHC string of password with random salt
+func (h *Argon2id) Hash(pw string) (string, error) {
+	salt := make([]byte, saltLen)
+	if _, err := rand.Read(salt); err != nil {
+		return "", err
+	}
+	key := argon2.IDKey([]byte(pw), salt, h.p.Iterations, h.p.Memory, h.p.Parallelism, keyLen)
+
+	return fmt.Sprintf("$argon2id$v=%d$m=%d,t=%d,p=%d$%s$%s",
+		argon2.Version, h.p.Memory, h.p.Iterations, h.p.Parallelism,
+		encoding.EncodeToString(salt), encoding.EncodeToString(key),
+	), nil
+}
+
+// Verify compare password with hash. Rehash is true when password is right but hash is bcrypt
+// or argon2id with other parameters, then hash should be replaced by Hash(pw)
+func (h *Argon2id) Verify(hash, pw string) (ok bool, rehash bool, err error) {
+	if isBcrypt(hash) {
+		err := bcrypt.CompareHashAndPassword([]byte(hash), []byte(pw))
+		switch {
+		case err == nil:
+			return true, true, nil
+		case errors.Is(err, bcrypt.ErrMismatchedHashAndPassword):
+			return false, false, nil
+		default:
+			return false, false, err
+		}
+	}
+
+	p, salt, key, err := decode(hash)
+	if err != nil {
+		return false, false, err
+	}
+	other := argon2.IDKey([]byte(pw), salt, p.Iterations, p.Memory, p.Parallelism, uint32(len(key)))
+	if subtle.ConstantTimeCompare(key, other) != 1 {
+		return false, false, nil
+	}
+
+	return true, p != h.p || len(salt) != saltLen || len(key) != keyLen, nil
+}
+
+// VerifyDummy take same time as Verify of current parameters. It is called when user is not found,
+// so answer doesn't show that account exists
+func (h *Argon2id) VerifyDummy(pw string) {
+	_, _, _ = h.Verify(h.dummy(), pw)
+}
+
+func isBcrypt(hash string) bool {
+	return strings.HasPrefix(hash, "$2a$") || strings.HasPrefix(hash, "$2b$") || strings.HasPrefix(hash, "$2y$")
+}
+
+func decode(hash string) (Params, []byte, []byte, error) {
+	var p Params
+
+	parts := strings.Split(hash, "$")
+	if len(parts) != 6 || parts[0] != "" || parts[1] != "argon2id" {
+		return p, nil, nil, ErrMalformedHash
+	}
+	var v int
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &v); err != nil || v != argon2.Version {
+		return p, nil, nil, fmt.Errorf("%w: version %q", ErrMalformedHash, parts[2])
+	}
+	if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &p.Memory, &p.Iterations, &p.Parallelism); err != nil {
+		return p, nil, nil, fmt.Errorf("%w: %w", ErrMalformedHash, err)
+	}
+	if !p.valid() {
+		return p, nil, nil, fmt.Errorf("%w: %+v", ErrBadParams, p)
+	}
+	salt, err := encoding.DecodeString(parts[4])
+	if err != nil {
+		return p, nil, nil, fmt.Errorf("%w: %w", ErrMalformedHash, err)
+	}
+	key, err := encoding.DecodeString(parts[5])
+	if err != nil || len(key) < 16 {
+		return p, nil, nil, fmt.Errorf("%w: key", ErrMalformedHash)
+	}
+
+	return p, salt, key, nil
+}
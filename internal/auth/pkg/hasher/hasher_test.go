@@ -0,0 +1,91 @@
+package hasher
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// testParams are cheap, tests don't need real cost
+var testParams = Params{Memory: 64, Iterations: 1, Parallelism: 1}
+
+func TestHash(t *testing.T) {
+	t.Parallel()
+
+	h, err := New(testParams)
+	require.NoError(t, err)
+
+	hash, err := h.Hash("secret")
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(hash, "$argon2id$v=19$m=64,t=1,p=1$"), hash)
+
+	other, err := h.Hash("secret")
+	require.NoError(t, err)
+	assert.NotEqual(t, hash, other, "salt is random")
+
+	ok, rehash, err := h.Verify(hash, "secret")
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.False(t, rehash)
+
+	ok, _, err = h.Verify(hash, "Secret")
+	require.NoError(t, err)
+	assert.False(t, ok)
+}
+
+func TestRehash(t *testing.T) {
+	t.Parallel()
+
+	old, err := New(testParams)
+	require.NoError(t, err)
+	h, err := New(Params{Memory: 128, Iterations: 1, Parallelism: 1})
+	require.NoError(t, err)
+
+	hash, err := old.Hash("secret")
+	require.NoError(t, err)
+	ok, rehash, err := h.Verify(hash, "secret")
+	require.NoError(t, err)
+	assert.True(t, ok, "hash is checked by its own parameters")
+	assert.True(t, rehash)
+
+	ok, rehash, err = h.Verify(hash, "wrong")
+	require.NoError(t, err)
+	assert.False(t, ok)
+	assert.False(t, rehash)
+
+	legacy, err := bcrypt.GenerateFromPassword([]byte("secret"), bcrypt.MinCost)
+	require.NoError(t, err)
+	ok, rehash, err = h.Verify(string(legacy), "secret")
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.True(t, rehash, "bcrypt is upgraded")
+
+	ok, _, err = h.Verify(string(legacy), "wrong")
+	require.NoError(t, err)
+	assert.False(t, ok)
+}
+
+func TestMalformed(t *testing.T) {
+	t.Parallel()
+
+	h, err := New(testParams)
+	require.NoError(t, err)
+
+	for _, hash := range []string{
+		"",
+		"plain",
+		"$argon2i$v=19$m=64,t=1,p=1$c2FsdHNhbHQ$a2V5a2V5a2V5a2V5a2V5a2V5",
+		"$argon2id$v=16$m=64,t=1,p=1$c2FsdHNhbHQ$a2V5a2V5a2V5a2V5a2V5a2V5",
+		"$argon2id$v=19$m=4194304,t=1,p=1$c2FsdHNhbHQ$a2V5a2V5a2V5a2V5a2V5a2V5",
+		"$argon2id$v=19$m=64,t=1,p=1$c2FsdHNhbHQ$!!",
+	} {
+		_, _, err := h.Verify(hash, "secret")
+		assert.Error(t, err, hash)
+	}
+
+	_, err = New(Params{Memory: 64, Iterations: 0, Parallelism: 1})
+	assert.ErrorIs(t, err, ErrBadParams)
+}
@@ -0,0 +1,218 @@
+package oidc
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testClient   = "breezynotes"
+	testSecret   = "s3cret"
+	testRedirect = "http://localhost:8080/api/auth/oidc/mock/callback"
+)
+
+// mockProvider is local OIDC provider: it issues code for every authorization request
+// and signs ID tokens with Ed25519 key kid
+type mockProvider struct {
+	srv *httptest.Server
+
+	mu     sync.Mutex
+	kid    string
+	key    ed25519.PrivateKey
+	codes  map[string]url.Values
+	claims jwt.MapClaims
+}
+
+func newMockProvider(t *testing.T) *mockProvider {
+	t.Helper()
+
+	m := &mockProvider{codes: make(map[string]url.Values)}
+	m.rotate("k1")
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET "+discoveryPath, func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(Metadata{
+			Issuer:                m.srv.URL,
+			AuthorizationEndpoint: m.srv.URL + "/authorize",
+			TokenEndpoint:         m.srv.URL + "/token",
+			JwksUri:               m.srv.URL + "/jwks",
+		})
+	})
+	mux.HandleFunc("GET /jwks", func(w http.ResponseWriter, r *http.Request) {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+		_ = json.NewEncoder(w).Encode(map[string]any{"keys": []jwk{{
+			Kid: m.kid,
+			Kty: "OKP",
+			Use: "sig",
+			Crv: "Ed25519",
+			X:   base64.RawURLEncoding.EncodeToString(m.key.Public().(ed25519.PublicKey)),
+		}}})
+	})
+	mux.HandleFunc("POST /token", func(w http.ResponseWriter, r *http.Request) {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+
+		id, secret, _ := r.BasicAuth()
+		auth := m.codes[r.FormValue("code")]
+		delete(m.codes, r.FormValue("code"))
+		if id != testClient || secret != testSecret || auth == nil ||
+			auth.Get("redirect_uri") != r.FormValue("redirect_uri") ||
+			auth.Get("code_challenge") != Challenge(r.FormValue("code_verifier")) {
+			w.WriteHeader(http.StatusBadRequest)
+			_ = json.NewEncoder(w).Encode(map[string]string{"error": "invalid_grant"})
+			return
+		}
+
+		claims := jwt.MapClaims{
+			"iss":   m.srv.URL,
+			"aud":   testClient,
+			"sub":   "user-1",
+			"email": "user@corp.example",
+			"exp":   time.Now().Add(time.Minute).Unix(),
+			"nonce": auth.Get("nonce"),
+		}
+		for k, v := range m.claims {
+			claims[k] = v
+		}
+		tok := jwt.NewWithClaims(jwt.SigningMethodEdDSA, claims)
+		tok.Header["kid"] = m.kid
+		raw, _ := tok.SignedString(m.key)
+		_ = json.NewEncoder(w).Encode(map[string]string{"id_token": raw, "token_type": "Bearer"})
+	})
+	m.srv = httptest.NewServer(mux)
+	t.Cleanup(m.srv.Close)
+
+	return m
+}
+
+func (m *mockProvider) rotate(kid string) {
+	_, key, _ := ed25519.GenerateKey(rand.Reader)
+	m.mu.Lock()
+	m.kid, m.key = kid, key
+	m.mu.Unlock()
+}
+
+// authorize is login of user at provider: return code from redirect
+func (m *mockProvider) authorize(t *testing.T, authUrl string, claims jwt.MapClaims) string {
+	t.Helper()
+
+	u, err := url.Parse(authUrl)
+	require.NoError(t, err)
+	q := u.Query()
+	assert.Equal(t, m.srv.URL+"/authorize", u.Scheme+"://"+u.Host+u.Path)
+	assert.Equal(t, "code", q.Get("response_type"))
+	assert.Equal(t, "S256", q.Get("code_challenge_method"))
+	assert.Equal(t, testRedirect, q.Get("redirect_uri"))
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	code := "code-" + q.Get("state")
+	m.codes[code] = q
+	m.claims = claims
+	return code
+}
+
+func (m *mockProvider) provider() *Provider {
+	return New(Config{
+		Issuer:       m.srv.URL + "/",
+		ClientId:     testClient,
+		ClientSecret: testSecret,
+		RedirectUrl:  testRedirect,
+	}, m.srv.Client())
+}
+
+func login(t *testing.T, m *mockProvider, p *Provider, claims jwt.MapClaims) (*Claims, error) {
+	t.Helper()
+	ctx := context.Background()
+
+	verifier, err := NewVerifier()
+	require.NoError(t, err)
+	authUrl, err := p.AuthCodeURL(ctx, "state1", "nonce1", verifier)
+	require.NoError(t, err)
+
+	return p.Login(ctx, m.authorize(t, authUrl, claims), verifier, "nonce1")
+}
+
+func TestLogin(t *testing.T) {
+	t.Parallel()
+	m := newMockProvider(t)
+	p := m.provider()
+
+	c, err := login(t, m, p, jwt.MapClaims{"email_verified": true, "name": "User"})
+	require.NoError(t, err)
+	assert.Equal(t, &Claims{Subject: "user-1", Email: "user@corp.example", EmailVerified: true, Name: "User"}, c)
+
+	c, err = login(t, m, p, jwt.MapClaims{"email_verified": "true"})
+	require.NoError(t, err)
+	assert.True(t, c.EmailVerified)
+
+	c, err = login(t, m, p, nil)
+	require.NoError(t, err)
+	assert.False(t, c.EmailVerified)
+
+	t.Run("key rotation", func(t *testing.T) {
+		m.rotate("k2")
+		p.keys.triedAt = time.Time{}
+		_, err := login(t, m, p, nil)
+		assert.NoError(t, err)
+	})
+}
+
+func TestLoginRejected(t *testing.T) {
+	t.Parallel()
+	m := newMockProvider(t)
+	p := m.provider()
+	ctx := context.Background()
+
+	verifier, err := NewVerifier()
+	require.NoError(t, err)
+	authUrl, err := p.AuthCodeURL(ctx, "state2", "nonce2", verifier)
+	require.NoError(t, err)
+
+	other, err := NewVerifier()
+	require.NoError(t, err)
+	_, err = p.Login(ctx, m.authorize(t, authUrl, nil), other, "nonce2")
+	assert.ErrorIs(t, err, ErrBadResponse, "wrong PKCE verifier")
+
+	_, err = p.Login(ctx, m.authorize(t, authUrl, nil), verifier, "other")
+	assert.ErrorIs(t, err, ErrInvalidToken, "wrong nonce")
+
+	_, err = p.Login(ctx, m.authorize(t, authUrl, jwt.MapClaims{"aud": "other-client"}), verifier, "nonce2")
+	assert.ErrorIs(t, err, ErrInvalidToken, "wrong audience")
+
+	_, err = p.Login(ctx, m.authorize(t, authUrl, jwt.MapClaims{"iss": "http://evil"}), verifier, "nonce2")
+	assert.ErrorIs(t, err, ErrInvalidToken, "wrong issuer")
+
+	_, err = p.Login(ctx, m.authorize(t, authUrl, jwt.MapClaims{"exp": time.Now().Add(-time.Hour).Unix()}), verifier, "nonce2")
+	assert.ErrorIs(t, err, ErrInvalidToken, "expired")
+
+	m.rotate("k3")
+	_, err = p.Login(ctx, m.authorize(t, authUrl, nil), verifier, "nonce2")
+	assert.ErrorIs(t, err, ErrInvalidToken, "unknown kid is not refetched too often")
+}
+
+func TestDiscoveryIssuerMismatch(t *testing.T) {
+	t.Parallel()
+	m := newMockProvider(t)
+
+	// same server by other host: issuer in document is not the configured one
+	u, err := url.Parse(m.srv.URL)
+	require.NoError(t, err)
+	p := New(Config{Issuer: "http://localhost:" + u.Port(), ClientId: testClient}, m.srv.Client())
+	_, err = p.AuthCodeURL(context.Background(), "s", "n", "v")
+	assert.ErrorIs(t, err, ErrBadResponse)
+}
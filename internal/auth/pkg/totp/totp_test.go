@@ -0,0 +1,66 @@
+package totp
+
+import (
+	"encoding/base32"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// rfcSecret is SHA1 seed from RFC 6238 appendix B
+var rfcSecret = base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString([]byte("12345678901234567890"))
+
+func TestCodeAt(t *testing.T) {
+	t.Parallel()
+
+	// last 6 digits of 8 digit codes from RFC 6238 appendix B
+	cases := map[int64]string{
+		59:          "287082",
+		1111111109:  "081804",
+		1111111111:  "050471",
+		1234567890:  "005924",
+		2000000000:  "279037",
+		20000000000: "353130",
+	}
+	for ts, want := range cases {
+		code, err := CodeAt(rfcSecret, Step(time.Unix(ts, 0)))
+		assert.NoError(t, err)
+		assert.Equal(t, want, code, ts)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	t.Parallel()
+	now := time.Unix(1111111111, 0)
+
+	step, ok := Validate(rfcSecret, "050471", now, 1)
+	assert.True(t, ok)
+	assert.Equal(t, Step(now), step)
+
+	prev, err := CodeAt(rfcSecret, Step(now)-1)
+	assert.NoError(t, err)
+	step, ok = Validate(rfcSecret, prev, now, 1)
+	assert.True(t, ok)
+	assert.Equal(t, Step(now)-1, step)
+
+	_, ok = Validate(rfcSecret, prev, now.Add(2*Period), 1)
+	assert.False(t, ok)
+	_, ok = Validate(rfcSecret, "12345", now, 1)
+	assert.False(t, ok)
+	_, ok = Validate("not base32!", "050471", now, 1)
+	assert.False(t, ok)
+}
+
+func TestSecretAndURI(t *testing.T) {
+	t.Parallel()
+
+	s, err := GenerateSecret()
+	assert.NoError(t, err)
+	assert.Len(t, s, 32)
+
+	uri := URI("BreezyNotes", "user@mail.ru", s)
+	assert.True(t, strings.HasPrefix(uri, "otpauth://totp/BreezyNotes:user@mail.ru?"))
+	assert.Contains(t, uri, "secret="+s)
+}
@@ -0,0 +1,81 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/autumnterror/breezynotes/internal/auth/domain"
+	"github.com/autumnterror/utils_go/pkg/utils/format"
+)
+
+type ActionTokenRepo interface {
+	CreateActionToken(ctx context.Context, t *domain.ActionToken) error
+	UseActionToken(ctx context.Context, hash, purpose string, now int64) (string, error)
+	DeleteActionTokens(ctx context.Context, idUser, purpose string) error
+	DeleteExpiredActionTokens(ctx context.Context, before int64) error
+}
+
+func (d Driver) CreateActionToken(ctx context.Context, t *domain.ActionToken) error {
+	const op = "actiontokens.CreateActionToken"
+
+	ctx, done := context.WithTimeout(ctx, waitTime)
+	defer done()
+
+	if _, err := d.Driver.ExecContext(ctx, `
+		INSERT INTO user_tokens (token_hash, user_id, purpose, expires_at) VALUES ($1, $2, $3, $4)
+	`, t.Hash, t.UserId, t.Purpose, t.ExpiresAt); err != nil {
+		return pqError(op, err)
+	}
+	return nil
+}
+
+// UseActionToken mark not used and not expired token as used and return id of its user.
+// Return domain.ErrTokenInvalid if there is no such token
+func (d Driver) UseActionToken(ctx context.Context, hash, purpose string, now int64) (string, error) {
+	const op = "actiontokens.UseActionToken"
+
+	ctx, done := context.WithTimeout(ctx, waitTime)
+	defer done()
+
+	var id string
+	if err := d.Driver.QueryRowContext(ctx, `
+		UPDATE user_tokens SET used_at = $3
+		WHERE token_hash = $1 AND purpose = $2 AND used_at = 0 AND expires_at > $3
+		RETURNING user_id
+	`, hash, purpose, now).Scan(&id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", format.Error(op, domain.ErrTokenInvalid)
+		}
+		return "", format.Error(op, err)
+	}
+
+	return id, nil
+}
+
+// DeleteActionTokens rm tokens of user with purpose, only last sent token must work
+func (d Driver) DeleteActionTokens(ctx context.Context, idUser, purpose string) error {
+	const op = "actiontokens.DeleteActionTokens"
+
+	ctx, done := context.WithTimeout(ctx, waitTime)
+	defer done()
+
+	if _, err := d.Driver.ExecContext(ctx,
+		`DELETE FROM user_tokens WHERE user_id = $1 AND purpose = $2`, idUser, purpose,
+	); err != nil {
+		return format.Error(op, err)
+	}
+	return nil
+}
+
+func (d Driver) DeleteExpiredActionTokens(ctx context.Context, before int64) error {
+	const op = "actiontokens.DeleteExpiredActionTokens"
+
+	ctx, done := context.WithTimeout(ctx, waitTime)
+	defer done()
+
+	if _, err := d.Driver.ExecContext(ctx, `DELETE FROM user_tokens WHERE expires_at < $1`, before); err != nil {
+		return format.Error(op, err)
+	}
+	return nil
+}
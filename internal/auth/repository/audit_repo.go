@@ -0,0 +1,62 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/autumnterror/breezynotes/internal/auth/domain"
+	"github.com/autumnterror/utils_go/pkg/utils/format"
+)
+
+type AuditRepo interface {
+	CreateAuditEvent(ctx context.Context, e *domain.AuditEvent) error
+	GetAuditEvents(ctx context.Context, idUser string, limit, offset int) ([]*domain.AuditEvent, error)
+}
+
+func (d Driver) CreateAuditEvent(ctx context.Context, e *domain.AuditEvent) error {
+	const op = "audit.CreateAuditEvent"
+
+	ctx, done := context.WithTimeout(ctx, waitTime)
+	defer done()
+
+	if _, err := d.Driver.ExecContext(ctx, `
+		INSERT INTO audit_events (id, user_id, actor_id, action, target, ip, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+	`, e.Id, e.UserId, e.ActorId, e.Action, e.Target, e.Ip, e.CreatedAt); err != nil {
+		return pqError(op, err)
+	}
+	return nil
+}
+
+// GetAuditEvents return events newest first. With idUser only events about user or made by him
+func (d Driver) GetAuditEvents(ctx context.Context, idUser string, limit, offset int) ([]*domain.AuditEvent, error) {
+	const op = "audit.GetAuditEvents"
+
+	ctx, done := context.WithTimeout(ctx, waitTime)
+	defer done()
+
+	rows, err := d.Driver.QueryContext(ctx, `
+		SELECT id, user_id, actor_id, action, target, ip, created_at
+		FROM audit_events
+		WHERE $1 = '' OR user_id = $1 OR actor_id = $1
+		ORDER BY created_at DESC, id
+		LIMIT $2 OFFSET $3
+	`, idUser, limit, offset)
+	if err != nil {
+		return nil, format.Error(op, err)
+	}
+	defer rows.Close()
+
+	res := make([]*domain.AuditEvent, 0, limit)
+	for rows.Next() {
+		var e domain.AuditEvent
+		if err := rows.Scan(&e.Id, &e.UserId, &e.ActorId, &e.Action, &e.Target, &e.Ip, &e.CreatedAt); err != nil {
+			return nil, format.Error(op, err)
+		}
+		res = append(res, &e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, format.Error(op, err)
+	}
+
+	return res, nil
+}
@@ -5,15 +5,17 @@ import (
 	"database/sql"
 	"errors"
 	"github.com/autumnterror/breezynotes/internal/auth/domain"
+	"github.com/autumnterror/utils_go/pkg/log"
 	"github.com/autumnterror/utils_go/pkg/utils/format"
-	"golang.org/x/crypto/bcrypt"
 )
 
 type AuthRepo interface {
 	Authentication(ctx context.Context, email, login, pw string) (string, error)
+	CheckPassword(ctx context.Context, id, pw string) error
 }
 
-// Authentication search user login and password in database and compare
+// Authentication search user login and password in database and compare.
+// Hash made by outdated algorithm or parameters is replaced after successful compare
 func (d Driver) Authentication(ctx context.Context, email, login, pw string) (string, error) {
 	const op = "psql.Authentication"
 
@@ -36,21 +38,62 @@ func (d Driver) Authentication(ctx context.Context, email, login, pw string) (st
 		return "", format.Error(op, domain.ErrWrongInput)
 	}
 
+	return d.verifyPassword(ctx, op, query, arg, pw)
+}
+
+// CheckPassword compare password of user with id, it works for accounts without login too
+func (d Driver) CheckPassword(ctx context.Context, id, pw string) error {
+	const op = "psql.CheckPassword"
+
+	ctx, done := context.WithTimeout(ctx, waitTime)
+	defer done()
+
+	_, err := d.verifyPassword(ctx, op, `SELECT id, password FROM users WHERE id = $1`, id, pw)
+	return err
+}
+
+// verifyPassword compare pw with hash of user found by query and return id of user
+func (d Driver) verifyPassword(ctx context.Context, op, query, arg, pw string) (string, error) {
 	var hashed string
 	var id string
 	if err := d.Driver.QueryRowContext(ctx, query, arg).Scan(&id, &hashed); err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
+			d.hasher().VerifyDummy(pw)
 			return "", domain.ErrLoginOrPasswordIncorrect
 		}
 		return "", format.Error(op, err)
 	}
 
-	if err := bcrypt.CompareHashAndPassword([]byte(hashed), []byte(pw)); err != nil {
-		if errors.Is(err, bcrypt.ErrMismatchedHashAndPassword) {
-			return "", domain.ErrLoginOrPasswordIncorrect
-		}
+	ok, rehash, err := d.hasher().Verify(hashed, pw)
+	if err != nil {
 		return "", format.Error(op, err)
 	}
+	if !ok {
+		return "", domain.ErrLoginOrPasswordIncorrect
+	}
+
+	if rehash {
+		if err := d.rehashPassword(ctx, id, hashed, pw); err != nil {
+			log.Error(op, "rehash "+id, err)
+		}
+	}
 
 	return id, nil
 }
+
+// rehashPassword replace old hash of password. It is skipped if password was changed after it was read
+func (d Driver) rehashPassword(ctx context.Context, id, old, pw string) error {
+	const op = "psql.rehashPassword"
+
+	hashed, err := d.hasher().Hash(pw)
+	if err != nil {
+		return format.Error(op, err)
+	}
+
+	if _, err := d.Driver.ExecContext(ctx,
+		`UPDATE users SET password = $1 WHERE id = $2 AND password = $3`, hashed, id, old,
+	); err != nil {
+		return format.Error(op, err)
+	}
+	return nil
+}
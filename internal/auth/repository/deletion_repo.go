@@ -0,0 +1,201 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/autumnterror/breezynotes/internal/auth/domain"
+	"github.com/autumnterror/utils_go/pkg/utils/format"
+)
+
+type DeletionRepo interface {
+	GetDeletion(ctx context.Context, idUser string) (*domain.AccountDeletion, error)
+	ScheduleDeletion(ctx context.Context, d *domain.AccountDeletion) error
+	CancelDeletion(ctx context.Context, idUser string, now int64) error
+	ClaimDeletions(ctx context.Context, now, lockedUntil int64, limit int) ([]*domain.AccountDeletion, error)
+	StartDeletion(ctx context.Context, idUser, step string, now int64) error
+	AdvanceDeletion(ctx context.Context, idUser, step, next string, now int64) error
+	FinishDeletion(ctx context.Context, idUser string, now int64) error
+	FailDeletion(ctx context.Context, idUser, step, lastError string, nextAttemptAt, now int64) error
+}
+
+const deletionColumns = `user_id, state, step, attempts, last_error, requested_at, purge_at, next_attempt_at, updated_at`
+
+func scanDeletion(row interface{ Scan(dest ...any) error }) (*domain.AccountDeletion, error) {
+	var d domain.AccountDeletion
+	if err := row.Scan(&d.UserId, &d.State, &d.Step, &d.Attempts, &d.LastError, &d.RequestedAt, &d.PurgeAt, &d.NextAttemptAt, &d.UpdatedAt); err != nil {
+		return nil, err
+	}
+	return &d, nil
+}
+
+// GetDeletion return domain.ErrNotFound if user never requested deletion
+func (d Driver) GetDeletion(ctx context.Context, idUser string) (*domain.AccountDeletion, error) {
+	const op = "deletions.GetDeletion"
+
+	ctx, done := context.WithTimeout(ctx, waitTime)
+	defer done()
+
+	res, err := scanDeletion(d.Driver.QueryRowContext(ctx,
+		`SELECT `+deletionColumns+` FROM account_deletions WHERE user_id = $1`, idUser))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, format.Error(op, domain.ErrNotFound)
+		}
+		return nil, format.Error(op, err)
+	}
+	return res, nil
+}
+
+// ScheduleDeletion create deletion or start finished one again
+func (d Driver) ScheduleDeletion(ctx context.Context, del *domain.AccountDeletion) error {
+	const op = "deletions.ScheduleDeletion"
+
+	ctx, done := context.WithTimeout(ctx, waitTime)
+	defer done()
+
+	if _, err := d.Driver.ExecContext(ctx, `
+		INSERT INTO account_deletions (user_id, state, step, attempts, last_error, requested_at, purge_at, next_attempt_at, locked_until, updated_at)
+		VALUES ($1, $2, '', 0, '', $3, $4, $4, 0, $3)
+		ON CONFLICT (user_id) DO UPDATE
+		SET state = EXCLUDED.state, step = '', attempts = 0, last_error = '', requested_at = EXCLUDED.requested_at,
+		    purge_at = EXCLUDED.purge_at, next_attempt_at = EXCLUDED.next_attempt_at, locked_until = 0, updated_at = EXCLUDED.updated_at
+	`, del.UserId, domain.DeletionScheduled, del.RequestedAt, del.PurgeAt); err != nil {
+		return pqError(op, err)
+	}
+	return nil
+}
+
+// CancelDeletion return domain.ErrNotFound if there is no scheduled deletion of user
+func (d Driver) CancelDeletion(ctx context.Context, idUser string, now int64) error {
+	const op = "deletions.CancelDeletion"
+
+	ctx, done := context.WithTimeout(ctx, waitTime)
+	defer done()
+
+	res, err := d.Driver.ExecContext(ctx, `
+		UPDATE account_deletions SET state = $1, updated_at = $2 WHERE user_id = $3 AND state = $4
+	`, domain.DeletionCancelled, now, idUser, domain.DeletionScheduled)
+	if err != nil {
+		return format.Error(op, err)
+	}
+	return rowsAffectedOrNotFound(op, res)
+}
+
+// ClaimDeletions lock due deletions until lockedUntil. Rows locked by other transaction are skipped,
+// so several workers don't take same deletion
+func (d Driver) ClaimDeletions(ctx context.Context, now, lockedUntil int64, limit int) ([]*domain.AccountDeletion, error) {
+	const op = "deletions.ClaimDeletions"
+
+	ctx, done := context.WithTimeout(ctx, waitTime)
+	defer done()
+
+	rows, err := d.Driver.QueryContext(ctx, `
+		UPDATE account_deletions SET locked_until = $2, updated_at = $1
+		WHERE user_id IN (
+			SELECT user_id FROM account_deletions
+			WHERE state IN ($4, $5) AND next_attempt_at <= $1 AND locked_until <= $1
+			ORDER BY next_attempt_at
+			LIMIT $3
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING `+deletionColumns,
+		now, lockedUntil, limit, domain.DeletionScheduled, domain.DeletionRunning)
+	if err != nil {
+		return nil, format.Error(op, err)
+	}
+	defer rows.Close()
+
+	res := make([]*domain.AccountDeletion, 0, limit)
+	for rows.Next() {
+		del, err := scanDeletion(rows)
+		if err != nil {
+			return nil, format.Error(op, err)
+		}
+		res = append(res, del)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, format.Error(op, err)
+	}
+	return res, nil
+}
+
+// StartDeletion move scheduled deletion to running with first step
+func (d Driver) StartDeletion(ctx context.Context, idUser, step string, now int64) error {
+	const op = "deletions.StartDeletion"
+
+	ctx, done := context.WithTimeout(ctx, waitTime)
+	defer done()
+
+	res, err := d.Driver.ExecContext(ctx, `
+		UPDATE account_deletions SET state = $1, step = $2, updated_at = $3 WHERE user_id = $4 AND state = $5
+	`, domain.DeletionRunning, step, now, idUser, domain.DeletionScheduled)
+	if err != nil {
+		return format.Error(op, err)
+	}
+	return rowsAffectedOrNotFound(op, res)
+}
+
+// AdvanceDeletion move running deletion from step to next. Deletion stays locked, so worker which claimed it
+// can go on. Return domain.ErrNotFound if deletion isn't on step
+func (d Driver) AdvanceDeletion(ctx context.Context, idUser, step, next string, now int64) error {
+	const op = "deletions.AdvanceDeletion"
+
+	ctx, done := context.WithTimeout(ctx, waitTime)
+	defer done()
+
+	res, err := d.Driver.ExecContext(ctx, `
+		UPDATE account_deletions SET step = $1, attempts = 0, last_error = '', updated_at = $2
+		WHERE user_id = $3 AND state = $4 AND step = $5
+	`, next, now, idUser, domain.DeletionRunning, step)
+	if err != nil {
+		return format.Error(op, err)
+	}
+	return rowsAffectedOrNotFound(op, res)
+}
+
+func (d Driver) FinishDeletion(ctx context.Context, idUser string, now int64) error {
+	const op = "deletions.FinishDeletion"
+
+	ctx, done := context.WithTimeout(ctx, waitTime)
+	defer done()
+
+	res, err := d.Driver.ExecContext(ctx, `
+		UPDATE account_deletions SET state = $1, step = '', attempts = 0, last_error = '', locked_until = 0, updated_at = $2
+		WHERE user_id = $3 AND state = $4
+	`, domain.DeletionDone, now, idUser, domain.DeletionRunning)
+	if err != nil {
+		return format.Error(op, err)
+	}
+	return rowsAffectedOrNotFound(op, res)
+}
+
+// FailDeletion unlock deletion, so it is claimed again at nextAttemptAt
+func (d Driver) FailDeletion(ctx context.Context, idUser, step, lastError string, nextAttemptAt, now int64) error {
+	const op = "deletions.FailDeletion"
+
+	ctx, done := context.WithTimeout(ctx, waitTime)
+	defer done()
+
+	res, err := d.Driver.ExecContext(ctx, `
+		UPDATE account_deletions
+		SET attempts = attempts + 1, last_error = $1, next_attempt_at = $2, locked_until = 0, updated_at = $3
+		WHERE user_id = $4 AND state = $5 AND step = $6
+	`, lastError, nextAttemptAt, now, idUser, domain.DeletionRunning, step)
+	if err != nil {
+		return format.Error(op, err)
+	}
+	return rowsAffectedOrNotFound(op, res)
+}
+
+func rowsAffectedOrNotFound(op string, res sql.Result) error {
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return format.Error(op, err)
+	}
+	if rowsAffected == 0 {
+		return format.Error(op, domain.ErrNotFound)
+	}
+	return nil
+}
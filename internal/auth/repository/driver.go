@@ -12,6 +12,7 @@ type SqlRepo interface {
 }
 type Driver struct {
 	Driver SqlRepo
+	Hasher Hasher
 }
 
 func NewDriver(driver SqlRepo) *Driver {
@@ -0,0 +1,23 @@
+package repository
+
+import (
+	"github.com/autumnterror/breezynotes/internal/auth/pkg/hasher"
+)
+
+// Hasher of passwords. Verify return rehash when hash is valid but made by outdated algorithm or parameters
+type Hasher interface {
+	Hash(pw string) (string, error)
+	Verify(hash, pw string) (ok bool, rehash bool, err error)
+	// VerifyDummy take same time as Verify, it is called when user is not found
+	VerifyDummy(pw string)
+}
+
+var defaultHasher, _ = hasher.New(hasher.DefaultParams)
+
+// hasher return Driver.Hasher, argon2id with default parameters if it is not set
+func (d Driver) hasher() Hasher {
+	if d.Hasher != nil {
+		return d.Hasher
+	}
+	return defaultHasher
+}
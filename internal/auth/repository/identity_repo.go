@@ -0,0 +1,101 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/autumnterror/breezynotes/internal/auth/domain"
+	"github.com/autumnterror/utils_go/pkg/utils/format"
+)
+
+type IdentityRepo interface {
+	CreateOidcLogin(ctx context.Context, l *domain.OidcLogin) error
+	UseOidcLogin(ctx context.Context, stateHash string, now int64) (*domain.OidcLogin, error)
+	DeleteExpiredOidcLogins(ctx context.Context, before int64) error
+	GetIdentity(ctx context.Context, provider, subject string) (string, error)
+	CreateIdentity(ctx context.Context, i *domain.Identity) error
+}
+
+func (d Driver) CreateOidcLogin(ctx context.Context, l *domain.OidcLogin) error {
+	const op = "identities.CreateOidcLogin"
+
+	ctx, done := context.WithTimeout(ctx, waitTime)
+	defer done()
+
+	if _, err := d.Driver.ExecContext(ctx, `
+		INSERT INTO oidc_logins (state_hash, provider, verifier, nonce, expires_at) VALUES ($1, $2, $3, $4, $5)
+	`, l.StateHash, l.Provider, l.Verifier, l.Nonce, l.ExpiresAt); err != nil {
+		return pqError(op, err)
+	}
+	return nil
+}
+
+// UseOidcLogin rm not expired login and return it, so state works once.
+// Return domain.ErrTokenInvalid if there is no such login
+func (d Driver) UseOidcLogin(ctx context.Context, stateHash string, now int64) (*domain.OidcLogin, error) {
+	const op = "identities.UseOidcLogin"
+
+	ctx, done := context.WithTimeout(ctx, waitTime)
+	defer done()
+
+	var l domain.OidcLogin
+	if err := d.Driver.QueryRowContext(ctx, `
+		DELETE FROM oidc_logins WHERE state_hash = $1 AND expires_at > $2
+		RETURNING state_hash, provider, verifier, nonce, expires_at
+	`, stateHash, now).Scan(&l.StateHash, &l.Provider, &l.Verifier, &l.Nonce, &l.ExpiresAt); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, format.Error(op, domain.ErrTokenInvalid)
+		}
+		return nil, format.Error(op, err)
+	}
+
+	return &l, nil
+}
+
+// DeleteExpiredOidcLogins rm logins which user never finished
+func (d Driver) DeleteExpiredOidcLogins(ctx context.Context, before int64) error {
+	const op = "identities.DeleteExpiredOidcLogins"
+
+	ctx, done := context.WithTimeout(ctx, waitTime)
+	defer done()
+
+	if _, err := d.Driver.ExecContext(ctx, `DELETE FROM oidc_logins WHERE expires_at < $1`, before); err != nil {
+		return format.Error(op, err)
+	}
+	return nil
+}
+
+// GetIdentity return id of user linked to account at provider
+func (d Driver) GetIdentity(ctx context.Context, provider, subject string) (string, error) {
+	const op = "identities.GetIdentity"
+
+	ctx, done := context.WithTimeout(ctx, waitTime)
+	defer done()
+
+	var id string
+	if err := d.Driver.QueryRowContext(ctx,
+		`SELECT user_id FROM user_identities WHERE provider = $1 AND subject = $2`, provider, subject,
+	).Scan(&id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", format.Error(op, domain.ErrNotFound)
+		}
+		return "", format.Error(op, err)
+	}
+
+	return id, nil
+}
+
+func (d Driver) CreateIdentity(ctx context.Context, i *domain.Identity) error {
+	const op = "identities.CreateIdentity"
+
+	ctx, done := context.WithTimeout(ctx, waitTime)
+	defer done()
+
+	if _, err := d.Driver.ExecContext(ctx, `
+		INSERT INTO user_identities (provider, subject, user_id, email, created_at) VALUES ($1, $2, $3, $4, $5)
+	`, i.Provider, i.Subject, i.UserId, i.Email, i.CreatedAt); err != nil {
+		return pqError(op, err)
+	}
+	return nil
+}
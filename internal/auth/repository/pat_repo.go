@@ -0,0 +1,163 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/autumnterror/breezynotes/internal/auth/domain"
+	"github.com/autumnterror/utils_go/pkg/utils/format"
+	"github.com/lib/pq"
+)
+
+type PatRepo interface {
+	CreatePat(ctx context.Context, p *domain.Pat, hash string) error
+	CountPats(ctx context.Context, idUser string) (int, error)
+	GetPats(ctx context.Context, idUser string) ([]*domain.Pat, error)
+	GetPatByHash(ctx context.Context, hash string) (*domain.Pat, error)
+	TouchPat(ctx context.Context, id string, lastUsedAt, interval int64) error
+	DeletePat(ctx context.Context, idUser, id string) error
+	DeleteExpiredPats(ctx context.Context, before int64) error
+}
+
+const patColumns = `id, user_id, name, scopes, created_at, expires_at, last_used_at`
+
+func scanPat(row interface{ Scan(dest ...any) error }) (*domain.Pat, error) {
+	var p domain.Pat
+	if err := row.Scan(
+		&p.Id, &p.UserId, &p.Name, pq.Array(&p.Scopes), &p.CreatedAt, &p.ExpiresAt, &p.LastUsedAt,
+	); err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+func (d Driver) CreatePat(ctx context.Context, p *domain.Pat, hash string) error {
+	const op = "pats.CreatePat"
+
+	ctx, done := context.WithTimeout(ctx, waitTime)
+	defer done()
+
+	if _, err := d.Driver.ExecContext(ctx, `
+		INSERT INTO personal_access_tokens (id, user_id, name, token_hash, scopes, created_at, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+	`, p.Id, p.UserId, p.Name, hash, pq.Array(p.Scopes), p.CreatedAt, p.ExpiresAt); err != nil {
+		return pqError(op, err)
+	}
+	return nil
+}
+
+func (d Driver) CountPats(ctx context.Context, idUser string) (int, error) {
+	const op = "pats.CountPats"
+
+	ctx, done := context.WithTimeout(ctx, waitTime)
+	defer done()
+
+	var n int
+	if err := d.Driver.QueryRowContext(ctx,
+		`SELECT COUNT(*) FROM personal_access_tokens WHERE user_id = $1`, idUser,
+	).Scan(&n); err != nil {
+		return 0, format.Error(op, err)
+	}
+	return n, nil
+}
+
+// GetPats return tokens of user, newest first
+func (d Driver) GetPats(ctx context.Context, idUser string) ([]*domain.Pat, error) {
+	const op = "pats.GetPats"
+
+	ctx, done := context.WithTimeout(ctx, waitTime)
+	defer done()
+
+	rows, err := d.Driver.QueryContext(ctx, `
+		SELECT `+patColumns+` FROM personal_access_tokens WHERE user_id = $1 ORDER BY created_at DESC
+	`, idUser)
+	if err != nil {
+		return nil, format.Error(op, err)
+	}
+	defer rows.Close()
+
+	res := make([]*domain.Pat, 0)
+	for rows.Next() {
+		p, err := scanPat(rows)
+		if err != nil {
+			return nil, format.Error(op, err)
+		}
+		res = append(res, p)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, format.Error(op, err)
+	}
+
+	return res, nil
+}
+
+// GetPatByHash return token by hash of secret. Tokens of disabled users are not found
+func (d Driver) GetPatByHash(ctx context.Context, hash string) (*domain.Pat, error) {
+	const op = "pats.GetPatByHash"
+
+	ctx, done := context.WithTimeout(ctx, waitTime)
+	defer done()
+
+	p, err := scanPat(d.Driver.QueryRowContext(ctx, `
+		SELECT `+patColumns+` FROM personal_access_tokens
+		WHERE token_hash = $1 AND NOT EXISTS (SELECT 1 FROM users WHERE users.id = user_id AND users.disabled)
+	`, hash))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, format.Error(op, domain.ErrNotFound)
+		}
+		return nil, format.Error(op, err)
+	}
+
+	return p, nil
+}
+
+// TouchPat set last use of token if previous one is older than interval
+func (d Driver) TouchPat(ctx context.Context, id string, lastUsedAt, interval int64) error {
+	const op = "pats.TouchPat"
+
+	ctx, done := context.WithTimeout(ctx, waitTime)
+	defer done()
+
+	if _, err := d.Driver.ExecContext(ctx, `
+		UPDATE personal_access_tokens SET last_used_at = $2 WHERE id = $1 AND last_used_at <= $2 - $3
+	`, id, lastUsedAt, interval); err != nil {
+		return format.Error(op, err)
+	}
+	return nil
+}
+
+func (d Driver) DeletePat(ctx context.Context, idUser, id string) error {
+	const op = "pats.DeletePat"
+
+	ctx, done := context.WithTimeout(ctx, waitTime)
+	defer done()
+
+	res, err := d.Driver.ExecContext(ctx,
+		`DELETE FROM personal_access_tokens WHERE id = $1 AND user_id = $2`, id, idUser,
+	)
+	if err != nil {
+		return format.Error(op, err)
+	}
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return format.Error(op, err)
+	}
+	if rowsAffected == 0 {
+		return format.Error(op, domain.ErrNotFound)
+	}
+	return nil
+}
+
+func (d Driver) DeleteExpiredPats(ctx context.Context, before int64) error {
+	const op = "pats.DeleteExpiredPats"
+
+	ctx, done := context.WithTimeout(ctx, waitTime)
+	defer done()
+
+	if _, err := d.Driver.ExecContext(ctx, `DELETE FROM personal_access_tokens WHERE expires_at < $1`, before); err != nil {
+		return format.Error(op, err)
+	}
+	return nil
+}
@@ -0,0 +1,77 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/autumnterror/breezynotes/internal/auth/domain"
+	"github.com/autumnterror/utils_go/pkg/utils/format"
+)
+
+type PreferencesRepo interface {
+	GetPreferences(ctx context.Context, idUser string) (*domain.Preferences, error)
+	SetPreferences(ctx context.Context, idUser string, p *domain.Preferences) (int64, error)
+}
+
+// GetPreferences return domain.ErrNotFound if user never saved preferences
+func (d Driver) GetPreferences(ctx context.Context, idUser string) (*domain.Preferences, error) {
+	const op = "preferences.GetPreferences"
+
+	ctx, done := context.WithTimeout(ctx, waitTime)
+	defer done()
+
+	var (
+		version  int
+		revision int64
+		data     []byte
+	)
+	if err := d.Driver.QueryRowContext(ctx, `
+		SELECT version, revision, data FROM user_preferences WHERE user_id = $1
+	`, idUser).Scan(&version, &revision, &data); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, format.Error(op, domain.ErrNotFound)
+		}
+		return nil, format.Error(op, err)
+	}
+
+	p, err := domain.DecodePreferences(version, data)
+	if err != nil {
+		return nil, format.Error(op, err)
+	}
+	p.Revision = revision
+	return p, nil
+}
+
+// SetPreferences save preferences if saved ones have revision p.Revision and return new revision.
+// domain.ErrConflict if they were changed after p was read
+func (d Driver) SetPreferences(ctx context.Context, idUser string, p *domain.Preferences) (int64, error) {
+	const op = "preferences.SetPreferences"
+
+	ctx, done := context.WithTimeout(ctx, waitTime)
+	defer done()
+
+	data, err := json.Marshal(p)
+	if err != nil {
+		return 0, format.Error(op, err)
+	}
+
+	var revision int64
+	if err := d.Driver.QueryRowContext(ctx, `
+		INSERT INTO user_preferences (user_id, version, data, updated_at, revision)
+		VALUES ($1, $2, $3, $4, 1)
+		ON CONFLICT (user_id) DO UPDATE
+		SET version = EXCLUDED.version, data = EXCLUDED.data, updated_at = EXCLUDED.updated_at,
+		    revision = user_preferences.revision + 1
+		WHERE user_preferences.revision = $5
+		RETURNING revision
+	`, idUser, p.Version, data, time.Now().UTC().Unix(), p.Revision).Scan(&revision); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, format.Error(op, domain.ErrConflict)
+		}
+		return 0, pqError(op, err)
+	}
+	return revision, nil
+}
@@ -6,4 +6,13 @@ type Provider interface {
 	Auth(ctx context.Context) AuthRepo
 	User(ctx context.Context) UserRepo
 	Health(ctx context.Context) HealthRepo
+	Workspace(ctx context.Context) WorkspaceRepo
+	Token(ctx context.Context) TokenRepo
+	TwoFactor(ctx context.Context) TwoFactorRepo
+	ActionToken(ctx context.Context) ActionTokenRepo
+	Identity(ctx context.Context) IdentityRepo
+	Pat(ctx context.Context) PatRepo
+	Audit(ctx context.Context) AuditRepo
+	Preferences(ctx context.Context) PreferencesRepo
+	Deletion(ctx context.Context) DeletionRepo
 }
@@ -0,0 +1,214 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/autumnterror/breezynotes/internal/auth/domain"
+	"github.com/autumnterror/utils_go/pkg/utils/format"
+)
+
+type TokenRepo interface {
+	CreateFamily(ctx context.Context, f *domain.TokenFamily) error
+	GetFamily(ctx context.Context, id string) (*domain.TokenFamily, error)
+	GetActiveFamiliesByUser(ctx context.Context, idUser string, now int64) ([]*domain.TokenFamily, error)
+	TouchFamily(ctx context.Context, id string, lastSeenAt int64) error
+	RotateFamily(ctx context.Context, id, oldJti, newJti string, rotatedAt, expiresAt int64) error
+	RevokeFamily(ctx context.Context, id string, revokedAt int64) error
+	RevokeFamiliesByUser(ctx context.Context, idUser, except string, revokedAt int64) ([]string, error)
+	DeleteExpiredFamilies(ctx context.Context, before int64) error
+}
+
+func (d Driver) CreateFamily(ctx context.Context, f *domain.TokenFamily) error {
+	const op = "tokens.CreateFamily"
+
+	ctx, done := context.WithTimeout(ctx, waitTime)
+	defer done()
+
+	if _, err := d.Driver.ExecContext(ctx, `
+		INSERT INTO token_families
+		    (id, user_id, current_jti, prev_jti, rotated_at, expires_at, user_agent, ip, created_at, last_seen_at)
+		VALUES ($1, $2, $3, '', $4, $5, $6, $7, $8, $8)
+	`, f.Id, f.UserId, f.CurrentJti, f.RotatedAt, f.ExpiresAt, f.UserAgent, f.Ip, f.CreatedAt); err != nil {
+		return pqError(op, err)
+	}
+	return nil
+}
+
+func (d Driver) GetFamily(ctx context.Context, id string) (*domain.TokenFamily, error) {
+	const op = "tokens.GetFamily"
+
+	ctx, done := context.WithTimeout(ctx, waitTime)
+	defer done()
+
+	f, err := scanFamily(d.Driver.QueryRowContext(ctx, `
+		SELECT `+familyColumns+` FROM token_families WHERE id = $1
+	`, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, format.Error(op, domain.ErrNotFound)
+		}
+		return nil, format.Error(op, err)
+	}
+
+	return f, nil
+}
+
+const familyColumns = `id, user_id, current_jti, prev_jti, rotated_at, expires_at, revoked_at,
+	user_agent, ip, created_at, last_seen_at`
+
+func scanFamily(row interface{ Scan(dest ...any) error }) (*domain.TokenFamily, error) {
+	var f domain.TokenFamily
+	if err := row.Scan(
+		&f.Id, &f.UserId, &f.CurrentJti, &f.PrevJti, &f.RotatedAt, &f.ExpiresAt, &f.RevokedAt,
+		&f.UserAgent, &f.Ip, &f.CreatedAt, &f.LastSeenAt,
+	); err != nil {
+		return nil, err
+	}
+	return &f, nil
+}
+
+// GetActiveFamiliesByUser return not revoked and not expired families of user, last seen first
+func (d Driver) GetActiveFamiliesByUser(ctx context.Context, idUser string, now int64) ([]*domain.TokenFamily, error) {
+	const op = "tokens.GetActiveFamiliesByUser"
+
+	ctx, done := context.WithTimeout(ctx, waitTime)
+	defer done()
+
+	rows, err := d.Driver.QueryContext(ctx, `
+		SELECT `+familyColumns+` FROM token_families
+		WHERE user_id = $1 AND revoked_at = 0 AND expires_at > $2
+		ORDER BY last_seen_at DESC
+	`, idUser, now)
+	if err != nil {
+		return nil, format.Error(op, err)
+	}
+	defer rows.Close()
+
+	fs := []*domain.TokenFamily{}
+	for rows.Next() {
+		f, err := scanFamily(rows)
+		if err != nil {
+			return nil, format.Error(op, err)
+		}
+		fs = append(fs, f)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, format.Error(op, err)
+	}
+
+	return fs, nil
+}
+
+func (d Driver) TouchFamily(ctx context.Context, id string, lastSeenAt int64) error {
+	const op = "tokens.TouchFamily"
+
+	ctx, done := context.WithTimeout(ctx, waitTime)
+	defer done()
+
+	if _, err := d.Driver.ExecContext(ctx,
+		`UPDATE token_families SET last_seen_at = $2 WHERE id = $1`, id, lastSeenAt,
+	); err != nil {
+		return format.Error(op, err)
+	}
+	return nil
+}
+
+// RotateFamily replace current jti only if it is still oldJti and family is not revoked.
+// Return domain.ErrNotFound if other request rotated family first
+func (d Driver) RotateFamily(ctx context.Context, id, oldJti, newJti string, rotatedAt, expiresAt int64) error {
+	const op = "tokens.RotateFamily"
+
+	ctx, done := context.WithTimeout(ctx, waitTime)
+	defer done()
+
+	res, err := d.Driver.ExecContext(ctx, `
+		UPDATE token_families
+		SET prev_jti = current_jti, current_jti = $3, rotated_at = $4, expires_at = $5, last_seen_at = $4
+		WHERE id = $1 AND current_jti = $2 AND revoked_at = 0
+	`, id, oldJti, newJti, rotatedAt, expiresAt)
+	if err != nil {
+		return format.Error(op, err)
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return format.Error(op, err)
+	}
+	if rowsAffected == 0 {
+		return format.Error(op, domain.ErrNotFound)
+	}
+	return nil
+}
+
+// RevokeFamily mark family revoked. Revoke of already revoked family is not error
+func (d Driver) RevokeFamily(ctx context.Context, id string, revokedAt int64) error {
+	const op = "tokens.RevokeFamily"
+
+	ctx, done := context.WithTimeout(ctx, waitTime)
+	defer done()
+
+	res, err := d.Driver.ExecContext(ctx, `
+		UPDATE token_families SET revoked_at = $2 WHERE id = $1 AND revoked_at = 0
+	`, id, revokedAt)
+	if err != nil {
+		return format.Error(op, err)
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return format.Error(op, err)
+	}
+	if rowsAffected == 0 {
+		if _, err := d.GetFamily(ctx, id); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RevokeFamiliesByUser revoke all active families of user except family with id except (can be empty).
+// Return ids of revoked families
+func (d Driver) RevokeFamiliesByUser(ctx context.Context, idUser, except string, revokedAt int64) ([]string, error) {
+	const op = "tokens.RevokeFamiliesByUser"
+
+	ctx, done := context.WithTimeout(ctx, waitTime)
+	defer done()
+
+	rows, err := d.Driver.QueryContext(ctx, `
+		UPDATE token_families SET revoked_at = $3
+		WHERE user_id = $1 AND id <> $2 AND revoked_at = 0
+		RETURNING id
+	`, idUser, except, revokedAt)
+	if err != nil {
+		return nil, format.Error(op, err)
+	}
+	defer rows.Close()
+
+	ids := []string{}
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, format.Error(op, err)
+		}
+		ids = append(ids, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, format.Error(op, err)
+	}
+	return ids, nil
+}
+
+// DeleteExpiredFamilies rm families which refresh token expired before. Tokens of them can't be used anyway
+func (d Driver) DeleteExpiredFamilies(ctx context.Context, before int64) error {
+	const op = "tokens.DeleteExpiredFamilies"
+
+	ctx, done := context.WithTimeout(ctx, waitTime)
+	defer done()
+
+	if _, err := d.Driver.ExecContext(ctx, `DELETE FROM token_families WHERE expires_at < $1`, before); err != nil {
+		return format.Error(op, err)
+	}
+	return nil
+}
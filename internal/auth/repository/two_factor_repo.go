@@ -0,0 +1,209 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/autumnterror/breezynotes/internal/auth/domain"
+	"github.com/autumnterror/utils_go/pkg/utils/format"
+	"github.com/autumnterror/utils_go/pkg/utils/uid"
+	"golang.org/x/crypto/bcrypt"
+)
+
+type TwoFactorRepo interface {
+	SaveTotp(ctx context.Context, t *domain.Totp) error
+	GetTotp(ctx context.Context, idUser string) (*domain.Totp, error)
+	EnableTotp(ctx context.Context, idUser string, step, confirmedAt int64) error
+	UseTotpStep(ctx context.Context, idUser string, step int64) error
+	FailTotp(ctx context.Context, idUser string, failedAt int64) error
+	DeleteTotp(ctx context.Context, idUser string) error
+	ReplaceRecoveryCodes(ctx context.Context, idUser string, codes []string) error
+	UseRecoveryCode(ctx context.Context, idUser, code string, usedAt int64) error
+}
+
+// SaveTotp insert not enabled secret of user or replace it if it is not enabled yet
+func (d Driver) SaveTotp(ctx context.Context, t *domain.Totp) error {
+	const op = "twofactor.SaveTotp"
+
+	ctx, done := context.WithTimeout(ctx, waitTime)
+	defer done()
+
+	res, err := d.Driver.ExecContext(ctx, `
+		INSERT INTO user_totp (user_id, secret, created_at) VALUES ($1, $2, $3)
+		ON CONFLICT (user_id) DO UPDATE
+		SET secret = EXCLUDED.secret, created_at = EXCLUDED.created_at, last_step = 0,
+		    failed_attempts = 0, failed_at = 0
+		WHERE user_totp.enabled = FALSE
+	`, t.UserId, t.Secret, t.CreatedAt)
+	if err != nil {
+		return pqError(op, err)
+	}
+	if n, err := res.RowsAffected(); err == nil && n == 0 {
+		return format.Error(op, domain.ErrAlreadyExists)
+	}
+	return nil
+}
+
+func (d Driver) GetTotp(ctx context.Context, idUser string) (*domain.Totp, error) {
+	const op = "twofactor.GetTotp"
+
+	ctx, done := context.WithTimeout(ctx, waitTime)
+	defer done()
+
+	var t domain.Totp
+	if err := d.Driver.QueryRowContext(ctx, `
+		SELECT user_id, secret, enabled, last_step, failed_attempts, failed_at, created_at, confirmed_at
+		FROM user_totp WHERE user_id = $1
+	`, idUser).Scan(
+		&t.UserId, &t.Secret, &t.Enabled, &t.LastStep, &t.FailedAttempts, &t.FailedAt, &t.CreatedAt, &t.ConfirmedAt,
+	); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, format.Error(op, domain.ErrNotFound)
+		}
+		return nil, format.Error(op, err)
+	}
+
+	return &t, nil
+}
+
+func (d Driver) EnableTotp(ctx context.Context, idUser string, step, confirmedAt int64) error {
+	const op = "twofactor.EnableTotp"
+
+	ctx, done := context.WithTimeout(ctx, waitTime)
+	defer done()
+
+	res, err := d.Driver.ExecContext(ctx, `
+		UPDATE user_totp SET enabled = TRUE, last_step = $2, confirmed_at = $3, failed_attempts = 0
+		WHERE user_id = $1 AND enabled = FALSE
+	`, idUser, step, confirmedAt)
+	if err != nil {
+		return format.Error(op, err)
+	}
+	if n, err := res.RowsAffected(); err == nil && n == 0 {
+		return format.Error(op, domain.ErrNotFound)
+	}
+	return nil
+}
+
+// UseTotpStep mark step as used and reset failed attempts. Return domain.ErrInvalidCode if step is already used
+func (d Driver) UseTotpStep(ctx context.Context, idUser string, step int64) error {
+	const op = "twofactor.UseTotpStep"
+
+	ctx, done := context.WithTimeout(ctx, waitTime)
+	defer done()
+
+	res, err := d.Driver.ExecContext(ctx, `
+		UPDATE user_totp SET last_step = $2, failed_attempts = 0
+		WHERE user_id = $1 AND last_step < $2
+	`, idUser, step)
+	if err != nil {
+		return format.Error(op, err)
+	}
+	if n, err := res.RowsAffected(); err == nil && n == 0 {
+		return format.Error(op, domain.ErrInvalidCode)
+	}
+	return nil
+}
+
+func (d Driver) FailTotp(ctx context.Context, idUser string, failedAt int64) error {
+	const op = "twofactor.FailTotp"
+
+	ctx, done := context.WithTimeout(ctx, waitTime)
+	defer done()
+
+	if _, err := d.Driver.ExecContext(ctx, `
+		UPDATE user_totp SET failed_attempts = failed_attempts + 1, failed_at = $2 WHERE user_id = $1
+	`, idUser, failedAt); err != nil {
+		return format.Error(op, err)
+	}
+	return nil
+}
+
+// DeleteTotp rm secret and recovery codes of user
+func (d Driver) DeleteTotp(ctx context.Context, idUser string) error {
+	const op = "twofactor.DeleteTotp"
+
+	ctx, done := context.WithTimeout(ctx, waitTime)
+	defer done()
+
+	if _, err := d.Driver.ExecContext(ctx, `DELETE FROM recovery_codes WHERE user_id = $1`, idUser); err != nil {
+		return format.Error(op, err)
+	}
+	if _, err := d.Driver.ExecContext(ctx, `DELETE FROM user_totp WHERE user_id = $1`, idUser); err != nil {
+		return format.Error(op, err)
+	}
+	return nil
+}
+
+// ReplaceRecoveryCodes rm old codes of user and store hashes of new ones
+func (d Driver) ReplaceRecoveryCodes(ctx context.Context, idUser string, codes []string) error {
+	const op = "twofactor.ReplaceRecoveryCodes"
+
+	ctx, done := context.WithTimeout(ctx, waitTime)
+	defer done()
+
+	if _, err := d.Driver.ExecContext(ctx, `DELETE FROM recovery_codes WHERE user_id = $1`, idUser); err != nil {
+		return format.Error(op, err)
+	}
+
+	for _, c := range codes {
+		hash, err := bcrypt.GenerateFromPassword([]byte(c), bcrypt.DefaultCost)
+		if err != nil {
+			return format.Error(op, err)
+		}
+		if _, err := d.Driver.ExecContext(ctx,
+			`INSERT INTO recovery_codes (id, user_id, code_hash) VALUES ($1, $2, $3)`,
+			uid.New(), idUser, string(hash),
+		); err != nil {
+			return pqError(op, err)
+		}
+	}
+	return nil
+}
+
+// UseRecoveryCode search unused code of user and mark it as used. Return domain.ErrInvalidCode if there is no such code
+func (d Driver) UseRecoveryCode(ctx context.Context, idUser, code string, usedAt int64) error {
+	const op = "twofactor.UseRecoveryCode"
+
+	ctx, done := context.WithTimeout(ctx, waitTime)
+	defer done()
+
+	rows, err := d.Driver.QueryContext(ctx,
+		`SELECT id, code_hash FROM recovery_codes WHERE user_id = $1 AND used_at = 0`, idUser,
+	)
+	if err != nil {
+		return format.Error(op, err)
+	}
+	defer rows.Close()
+
+	match := ""
+	for rows.Next() {
+		var id, hash string
+		if err := rows.Scan(&id, &hash); err != nil {
+			return format.Error(op, err)
+		}
+		if bcrypt.CompareHashAndPassword([]byte(hash), []byte(code)) == nil {
+			match = id
+			break
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return format.Error(op, err)
+	}
+	if match == "" {
+		return format.Error(op, domain.ErrInvalidCode)
+	}
+	rows.Close()
+
+	res, err := d.Driver.ExecContext(ctx,
+		`UPDATE recovery_codes SET used_at = $2 WHERE id = $1 AND used_at = 0`, match, usedAt,
+	)
+	if err != nil {
+		return format.Error(op, err)
+	}
+	if n, err := res.RowsAffected(); err == nil && n == 0 {
+		return format.Error(op, domain.ErrInvalidCode)
+	}
+	return nil
+}
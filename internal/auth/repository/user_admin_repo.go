@@ -0,0 +1,95 @@
+package repository
+
+import (
+	"context"
+	"strings"
+
+	"github.com/autumnterror/breezynotes/internal/auth/domain"
+	"github.com/autumnterror/utils_go/pkg/utils/format"
+)
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// ListUsers return page of users sorted by login and count of all found. Empty query is all users,
+// else login or email must contain it
+func (d Driver) ListUsers(ctx context.Context, query string, limit, offset int) ([]*domain.User, int, error) {
+	const op = "users.ListUsers"
+
+	ctx, done := context.WithTimeout(ctx, waitTime)
+	defer done()
+
+	rows, err := d.Driver.QueryContext(ctx, `
+		SELECT id, login, email, about, photo, email_verified, role, disabled, COUNT(*) OVER ()
+		FROM users
+		WHERE $1 = '' OR login ILIKE '%' || $1 || '%' OR email ILIKE '%' || $1 || '%'
+		ORDER BY login
+		LIMIT $2 OFFSET $3
+	`, likeEscaper.Replace(query), limit, offset)
+	if err != nil {
+		return nil, 0, format.Error(op, err)
+	}
+	defer rows.Close()
+
+	var (
+		res   = make([]*domain.User, 0, limit)
+		total int
+	)
+	for rows.Next() {
+		var u domain.User
+		if err := rows.Scan(
+			&u.Id, &u.Login, &u.Email, &u.About, &u.Photo, &u.EmailVerified, &u.Role, &u.Disabled, &total,
+		); err != nil {
+			return nil, 0, format.Error(op, err)
+		}
+		res = append(res, &u)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, format.Error(op, err)
+	}
+
+	return res, total, nil
+}
+
+func (d Driver) SetRole(ctx context.Context, id, role string) error {
+	const op = "users.SetRole"
+
+	ctx, done := context.WithTimeout(ctx, waitTime)
+	defer done()
+
+	res, err := d.Driver.ExecContext(ctx, `UPDATE users SET role = $1 WHERE id = $2`, role, id)
+	if err != nil {
+		return format.Error(op, err)
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return format.Error(op, err)
+	}
+	if rowsAffected == 0 {
+		return format.Error(op, domain.ErrNotFound)
+	}
+
+	return nil
+}
+
+func (d Driver) SetDisabled(ctx context.Context, id string, disabled bool) error {
+	const op = "users.SetDisabled"
+
+	ctx, done := context.WithTimeout(ctx, waitTime)
+	defer done()
+
+	res, err := d.Driver.ExecContext(ctx, `UPDATE users SET disabled = $1 WHERE id = $2`, disabled, id)
+	if err != nil {
+		return format.Error(op, err)
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return format.Error(op, err)
+	}
+	if rowsAffected == 0 {
+		return format.Error(op, domain.ErrNotFound)
+	}
+
+	return nil
+}
@@ -12,7 +12,6 @@ import (
 	"github.com/autumnterror/utils_go/pkg/utils/format"
 	"github.com/autumnterror/utils_go/pkg/utils/uid"
 	"github.com/lib/pq"
-	"golang.org/x/crypto/bcrypt"
 )
 
 type UserRepo interface {
@@ -26,6 +25,13 @@ type UserRepo interface {
 	GetInfo(ctx context.Context, id string) (*domain.User, error)
 	GetInfos(ctx context.Context, ids []string) ([]domain.User, error)
 	GetIdFromLogin(ctx context.Context, login string) (string, error)
+	GetIdFromEmail(ctx context.Context, email string) (string, error)
+	SetEmailVerified(ctx context.Context, id string) error
+	ListUsers(ctx context.Context, query string, limit, offset int) ([]*domain.User, int, error)
+	SetRole(ctx context.Context, id, role string) error
+	SetDisabled(ctx context.Context, id string, disabled bool) error
+	SetDiscoverable(ctx context.Context, id string, discoverable bool) error
+	SearchUsers(ctx context.Context, idUser, query string, limit int) ([]*domain.UserCard, error)
 }
 
 func (d Driver) CreateAdmin(ctx context.Context) (string, error) {
@@ -35,8 +41,8 @@ func (d Driver) CreateAdmin(ctx context.Context) (string, error) {
 	defer done()
 
 	query := `
-				INSERT INTO users (id, login, email, about, password, photo)
-				VALUES ($1, $2, $3, $4, $5, $6)
+				INSERT INTO users (id, login, email, about, password, photo, email_verified, role)
+				VALUES ($1, $2, $3, $4, $5, $6, TRUE, 'admin')
 			`
 	id := uid.New()
 	u := &domain.User{
@@ -54,7 +60,7 @@ func (d Driver) CreateAdmin(ctx context.Context) (string, error) {
 		}
 	}
 
-	hashedPass, err := bcrypt.GenerateFromPassword([]byte(u.Password), bcrypt.DefaultCost)
+	hashedPass, err := d.hasher().Hash(u.Password)
 	if err != nil {
 		return "", format.Error(op, err)
 	}
@@ -84,7 +90,7 @@ func (d Driver) getAll(ctx context.Context) ([]*domain.User, error) {
 	defer done()
 
 	var ls []*domain.User
-	rows, err := d.Driver.QueryContext(ctx, `SELECT * FROM users`)
+	rows, err := d.Driver.QueryContext(ctx, `SELECT id, login, email, about, password, photo FROM users`)
 	if err != nil {
 		return nil, format.Error(op, err)
 	}
@@ -106,11 +112,13 @@ func (d Driver) getAll(ctx context.Context) ([]*domain.User, error) {
 func (d Driver) GetInfo(ctx context.Context, id string) (*domain.User, error) {
 	const op = "users.GetInfo"
 	query := `
-		SELECT login,email,about, photo FROM users
+		SELECT login,email,about, photo, email_verified, role, disabled, discoverable FROM users
 		WHERE id = $1
 	`
 	var u domain.User
-	if err := d.Driver.QueryRowContext(ctx, query, id).Scan(&u.Login, &u.Email, &u.About, &u.Photo); err != nil {
+	if err := d.Driver.QueryRowContext(ctx, query, id).Scan(
+		&u.Login, &u.Email, &u.About, &u.Photo, &u.EmailVerified, &u.Role, &u.Disabled, &u.Discoverable,
+	); err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, format.Error(op, domain.ErrNotFound)
 		}
@@ -138,7 +146,7 @@ func (d Driver) GetInfos(ctx context.Context, ids []string) ([]domain.User, erro
 	}
 
 	query := fmt.Sprintf(`
-		SELECT id, login, email, about, photo
+		SELECT id, login, email, about, photo, email_verified
 		FROM users
 		WHERE id IN (%s)
 	`, strings.Join(placeholders, ","))
@@ -153,7 +161,7 @@ func (d Driver) GetInfos(ctx context.Context, ids []string) ([]domain.User, erro
 
 	for rows.Next() {
 		var u domain.User
-		if err := rows.Scan(&u.Id, &u.Login, &u.Email, &u.About, &u.Photo); err != nil {
+		if err := rows.Scan(&u.Id, &u.Login, &u.Email, &u.About, &u.Photo, &u.EmailVerified); err != nil {
 			return nil, format.Error(op, err)
 		}
 		u.Password = ""
@@ -202,7 +210,7 @@ func (d Driver) Create(ctx context.Context, u *domain.User) error {
 				VALUES ($1, $2, $3, $4, $5, $6)
 			`
 
-	hashedPass, err := bcrypt.GenerateFromPassword([]byte(u.Password), bcrypt.DefaultCost)
+	hashedPass, err := d.hasher().Hash(u.Password)
 	if err != nil {
 		return format.Error(op, err)
 	}
@@ -230,7 +238,7 @@ func (d Driver) UpdatePassword(ctx context.Context, id, newPassword string) erro
 	ctx, done := context.WithTimeout(ctx, waitTime)
 	defer done()
 
-	hashedPass, err := bcrypt.GenerateFromPassword([]byte(newPassword), bcrypt.DefaultCost)
+	hashedPass, err := d.hasher().Hash(newPassword)
 	if err != nil {
 		return format.Error(op, err)
 	}
@@ -275,12 +283,12 @@ func (d Driver) UpdatePhoto(ctx context.Context, id, np string) error {
 	return nil
 }
 
-// UpdateEmail updates user's email by user ID.
+// UpdateEmail updates user's email by user ID. New email is not verified.
 // Returns sql.ErrNoRows if user not found.
 func (d Driver) UpdateEmail(ctx context.Context, id, email string) error {
 	const op = "users.UpdateEmail"
 
-	res, err := d.Driver.ExecContext(ctx, `UPDATE users SET email = $1 WHERE id = $2`, email, id)
+	res, err := d.Driver.ExecContext(ctx, `UPDATE users SET email = $1, email_verified = FALSE WHERE id = $2`, email, id)
 	if err != nil {
 		return format.Error(op, err)
 	}
@@ -339,3 +347,43 @@ func (d Driver) Delete(ctx context.Context, id string) error {
 	}
 	return nil
 }
+
+// GetIdFromEmail get id of user by email
+func (d Driver) GetIdFromEmail(ctx context.Context, email string) (string, error) {
+	const op = "users.GetIdFromEmail"
+
+	ctx, done := context.WithTimeout(ctx, waitTime)
+	defer done()
+
+	var id string
+	if err := d.Driver.QueryRowContext(ctx, `SELECT id FROM users WHERE email = $1`, email).Scan(&id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", format.Error(op, domain.ErrNotFound)
+		}
+		return "", format.Error(op, err)
+	}
+
+	return id, nil
+}
+
+func (d Driver) SetEmailVerified(ctx context.Context, id string) error {
+	const op = "users.SetEmailVerified"
+
+	ctx, done := context.WithTimeout(ctx, waitTime)
+	defer done()
+
+	res, err := d.Driver.ExecContext(ctx, `UPDATE users SET email_verified = TRUE WHERE id = $1`, id)
+	if err != nil {
+		return format.Error(op, err)
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return format.Error(op, err)
+	}
+	if rowsAffected == 0 {
+		return format.Error(op, domain.ErrNotFound)
+	}
+
+	return nil
+}
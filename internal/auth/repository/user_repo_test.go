@@ -317,3 +317,22 @@ func TestAuthPwIncorrect(t *testing.T) {
 	)
 	assert.True(t, errors.Is(err, domain.ErrLoginOrPasswordIncorrect))
 }
+
+func TestCheckPassword(t *testing.T) {
+	t.Parallel()
+	repo, _, cleanup := setupTestTx(t)
+	defer cleanup()
+
+	uid := uid.New()
+	user := &domain.User{
+		Id:       uid,
+		Email:    "nologin@example.com",
+		About:    "test",
+		Password: "password",
+	}
+
+	assert.NoError(t, repo.Create(context.Background(), user))
+
+	assert.NoError(t, repo.CheckPassword(context.Background(), uid, user.Password), "account without login")
+	assert.True(t, errors.Is(repo.CheckPassword(context.Background(), uid, "123"), domain.ErrLoginOrPasswordIncorrect))
+}
@@ -0,0 +1,72 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/autumnterror/breezynotes/internal/auth/domain"
+	"github.com/autumnterror/utils_go/pkg/utils/format"
+)
+
+// SearchUsers return users for sharing, except idUser and disabled ones. Exact login or email
+// finds anyone, prefix of login or email and similar login (pg_trgm) find only discoverable users.
+// Exact match goes first, then prefix, then most similar
+func (d Driver) SearchUsers(ctx context.Context, idUser, query string, limit int) ([]*domain.UserCard, error) {
+	const op = "users.SearchUsers"
+
+	ctx, done := context.WithTimeout(ctx, waitTime)
+	defer done()
+
+	rows, err := d.Driver.QueryContext(ctx, `
+		SELECT id, login, COALESCE(photo, ''), COALESCE(about, '')
+		FROM users
+		WHERE id <> $1 AND NOT disabled AND (
+			lower(login) = lower($2) OR lower(email) = lower($2)
+			OR (discoverable AND (login ILIKE $3 || '%' OR email ILIKE $3 || '%' OR login % $2))
+		)
+		ORDER BY (lower(login) = lower($2) OR lower(email) = lower($2)) DESC,
+			(login ILIKE $3 || '%' OR email ILIKE $3 || '%') DESC,
+			similarity(login, $2) DESC,
+			login
+		LIMIT $4
+	`, idUser, query, likeEscaper.Replace(query), limit)
+	if err != nil {
+		return nil, format.Error(op, err)
+	}
+	defer rows.Close()
+
+	res := make([]*domain.UserCard, 0, limit)
+	for rows.Next() {
+		var u domain.UserCard
+		if err := rows.Scan(&u.Id, &u.Login, &u.Photo, &u.About); err != nil {
+			return nil, format.Error(op, err)
+		}
+		res = append(res, &u)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, format.Error(op, err)
+	}
+
+	return res, nil
+}
+
+func (d Driver) SetDiscoverable(ctx context.Context, id string, discoverable bool) error {
+	const op = "users.SetDiscoverable"
+
+	ctx, done := context.WithTimeout(ctx, waitTime)
+	defer done()
+
+	res, err := d.Driver.ExecContext(ctx, `UPDATE users SET discoverable = $1 WHERE id = $2`, discoverable, id)
+	if err != nil {
+		return format.Error(op, err)
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return format.Error(op, err)
+	}
+	if rowsAffected == 0 {
+		return format.Error(op, domain.ErrNotFound)
+	}
+
+	return nil
+}
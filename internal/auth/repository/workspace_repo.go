@@ -0,0 +1,224 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/autumnterror/breezynotes/internal/auth/domain"
+	"github.com/autumnterror/utils_go/pkg/utils/format"
+	"github.com/lib/pq"
+)
+
+type WorkspaceRepo interface {
+	CreateWorkspace(ctx context.Context, w *domain.Workspace) error
+	DeleteWorkspace(ctx context.Context, id string) error
+	GetWorkspace(ctx context.Context, id string) (*domain.Workspace, error)
+	GetWorkspacesByUser(ctx context.Context, idUser string) ([]*domain.Workspace, error)
+	GetMembers(ctx context.Context, id string) ([]*domain.WorkspaceMember, error)
+	GetRole(ctx context.Context, id, idUser string) (string, error)
+	SetMember(ctx context.Context, id, idUser, role string) error
+	RemoveMember(ctx context.Context, id, idUser string) error
+}
+
+func pqError(op string, err error) error {
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) {
+		switch pqErr.Code {
+		case "23505": // unique_violation
+			return format.Error(op, domain.ErrAlreadyExists)
+		case "23503": // foreign_key_violation
+			return format.Error(op, domain.ErrForeignKey)
+		}
+	}
+	return format.Error(op, err)
+}
+
+// CreateWorkspace insert workspace and its owner as member with domain.WorkspaceRoleOwner
+func (d Driver) CreateWorkspace(ctx context.Context, w *domain.Workspace) error {
+	const op = "workspaces.CreateWorkspace"
+
+	ctx, done := context.WithTimeout(ctx, waitTime)
+	defer done()
+
+	if _, err := d.Driver.ExecContext(ctx,
+		`INSERT INTO workspaces (id, title, owner_id) VALUES ($1, $2, $3)`,
+		w.Id, w.Title, w.OwnerId,
+	); err != nil {
+		return pqError(op, err)
+	}
+
+	if _, err := d.Driver.ExecContext(ctx,
+		`INSERT INTO workspace_members (workspace_id, user_id, role) VALUES ($1, $2, $3)`,
+		w.Id, w.OwnerId, domain.WorkspaceRoleOwner,
+	); err != nil {
+		return pqError(op, err)
+	}
+
+	return nil
+}
+
+// DeleteWorkspace delete workspace, members are removed by cascade
+func (d Driver) DeleteWorkspace(ctx context.Context, id string) error {
+	const op = "workspaces.DeleteWorkspace"
+
+	ctx, done := context.WithTimeout(ctx, waitTime)
+	defer done()
+
+	res, err := d.Driver.ExecContext(ctx, `DELETE FROM workspaces WHERE id = $1`, id)
+	if err != nil {
+		return format.Error(op, err)
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return format.Error(op, err)
+	}
+	if rowsAffected == 0 {
+		return format.Error(op, domain.ErrNotFound)
+	}
+	return nil
+}
+
+func (d Driver) GetWorkspace(ctx context.Context, id string) (*domain.Workspace, error) {
+	const op = "workspaces.GetWorkspace"
+
+	ctx, done := context.WithTimeout(ctx, waitTime)
+	defer done()
+
+	var w domain.Workspace
+	if err := d.Driver.QueryRowContext(ctx,
+		`SELECT id, title, owner_id FROM workspaces WHERE id = $1`, id,
+	).Scan(&w.Id, &w.Title, &w.OwnerId); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, format.Error(op, domain.ErrNotFound)
+		}
+		return nil, format.Error(op, err)
+	}
+
+	return &w, nil
+}
+
+// GetWorkspacesByUser return all workspaces where user is member. Role is the role of user
+func (d Driver) GetWorkspacesByUser(ctx context.Context, idUser string) ([]*domain.Workspace, error) {
+	const op = "workspaces.GetWorkspacesByUser"
+
+	ctx, done := context.WithTimeout(ctx, waitTime)
+	defer done()
+
+	rows, err := d.Driver.QueryContext(ctx, `
+		SELECT w.id, w.title, w.owner_id, m.role
+		FROM workspaces w
+		JOIN workspace_members m ON m.workspace_id = w.id
+		WHERE m.user_id = $1
+		ORDER BY w.title
+	`, idUser)
+	if err != nil {
+		return nil, format.Error(op, err)
+	}
+	defer rows.Close()
+
+	ws := make([]*domain.Workspace, 0)
+	for rows.Next() {
+		var w domain.Workspace
+		if err := rows.Scan(&w.Id, &w.Title, &w.OwnerId, &w.Role); err != nil {
+			return nil, format.Error(op, err)
+		}
+		ws = append(ws, &w)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, format.Error(op, err)
+	}
+
+	return ws, nil
+}
+
+func (d Driver) GetMembers(ctx context.Context, id string) ([]*domain.WorkspaceMember, error) {
+	const op = "workspaces.GetMembers"
+
+	ctx, done := context.WithTimeout(ctx, waitTime)
+	defer done()
+
+	rows, err := d.Driver.QueryContext(ctx,
+		`SELECT user_id, role FROM workspace_members WHERE workspace_id = $1`, id,
+	)
+	if err != nil {
+		return nil, format.Error(op, err)
+	}
+	defer rows.Close()
+
+	ms := make([]*domain.WorkspaceMember, 0)
+	for rows.Next() {
+		var m domain.WorkspaceMember
+		if err := rows.Scan(&m.UserId, &m.Role); err != nil {
+			return nil, format.Error(op, err)
+		}
+		ms = append(ms, &m)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, format.Error(op, err)
+	}
+
+	return ms, nil
+}
+
+// GetRole of user in workspace. Return domain.ErrNotFound if user is not a member
+func (d Driver) GetRole(ctx context.Context, id, idUser string) (string, error) {
+	const op = "workspaces.GetRole"
+
+	ctx, done := context.WithTimeout(ctx, waitTime)
+	defer done()
+
+	var role string
+	if err := d.Driver.QueryRowContext(ctx,
+		`SELECT role FROM workspace_members WHERE workspace_id = $1 AND user_id = $2`, id, idUser,
+	).Scan(&role); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", format.Error(op, domain.ErrNotFound)
+		}
+		return "", format.Error(op, err)
+	}
+
+	return role, nil
+}
+
+// SetMember add user to workspace or rewrite his role
+func (d Driver) SetMember(ctx context.Context, id, idUser, role string) error {
+	const op = "workspaces.SetMember"
+
+	ctx, done := context.WithTimeout(ctx, waitTime)
+	defer done()
+
+	if _, err := d.Driver.ExecContext(ctx, `
+		INSERT INTO workspace_members (workspace_id, user_id, role)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (workspace_id, user_id) DO UPDATE SET role = EXCLUDED.role
+	`, id, idUser, role); err != nil {
+		return pqError(op, err)
+	}
+
+	return nil
+}
+
+func (d Driver) RemoveMember(ctx context.Context, id, idUser string) error {
+	const op = "workspaces.RemoveMember"
+
+	ctx, done := context.WithTimeout(ctx, waitTime)
+	defer done()
+
+	res, err := d.Driver.ExecContext(ctx,
+		`DELETE FROM workspace_members WHERE workspace_id = $1 AND user_id = $2`, id, idUser,
+	)
+	if err != nil {
+		return format.Error(op, err)
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return format.Error(op, err)
+	}
+	if rowsAffected == 0 {
+		return format.Error(op, domain.ErrNotFound)
+	}
+	return nil
+}
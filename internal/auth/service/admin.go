@@ -0,0 +1,290 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/autumnterror/breezynotes/internal/auth/domain"
+	"github.com/autumnterror/utils_go/pkg/utils/format"
+	"github.com/autumnterror/utils_go/pkg/utils/uid"
+)
+
+const (
+	defaultAdminPage = 20
+	maxAdminPage     = 100
+	// impersonationLifeTime of read-only token which admin gets to see account of user
+	impersonationLifeTime = 15 * time.Minute
+)
+
+// impersonationScopes allow only reading, so admin can't change anything on behalf of user
+var impersonationScopes = []string{
+	domain.ScopeNotesRead,
+	domain.ScopeTagsRead,
+	domain.ScopeUserRead,
+	domain.ScopeWorkspacesRead,
+}
+
+// adminPage return limit in [1, maxAdminPage] and not negative offset
+func adminPage(limit, offset int) (int, int) {
+	if limit <= 0 {
+		limit = defaultAdminPage
+	}
+	return min(limit, maxAdminPage), max(offset, 0)
+}
+
+// requireAdmin return actor if he is admin and his account is not disabled, else domain.ErrForbidden
+func (s *AuthService) requireAdmin(ctx context.Context, actorId string) (*domain.User, error) {
+	const op = "service.requireAdmin"
+	if err := idValidation(actorId); err != nil {
+		return nil, wrapServiceCheck(op, err)
+	}
+
+	repo, err := s.userRepo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	u, err := repo.GetInfo(ctx, actorId)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, format.Error(op, domain.ErrForbidden)
+		}
+		return nil, err
+	}
+	if u.Role != domain.RoleAdmin || u.Disabled {
+		return nil, format.Error(op, domain.ErrForbidden)
+	}
+	return u, nil
+}
+
+// ListUsers return page of users which login or email contains query and count of all found
+func (s *AuthService) ListUsers(ctx context.Context, actorId, query string, limit, offset int) ([]*domain.User, int, error) {
+	if _, err := s.requireAdmin(ctx, actorId); err != nil {
+		return nil, 0, err
+	}
+
+	repo, err := s.userRepo(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+	limit, offset = adminPage(limit, offset)
+	query = strings.TrimSpace(query)
+	us, total, err := repo.ListUsers(ctx, query, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	s.audit(ctx, &domain.AuditEvent{ActorId: actorId, Action: domain.AuditUsersListed, Target: query})
+	return us, total, nil
+}
+
+// GetUser return account of user for admin
+func (s *AuthService) GetUser(ctx context.Context, actorId, idUser string) (*domain.User, error) {
+	const op = "service.GetUser"
+	if _, err := s.requireAdmin(ctx, actorId); err != nil {
+		return nil, err
+	}
+	if err := idValidation(idUser); err != nil {
+		return nil, wrapServiceCheck(op, err)
+	}
+
+	repo, err := s.userRepo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	u, err := repo.GetInfo(ctx, idUser)
+	if err != nil {
+		return nil, err
+	}
+
+	s.audit(ctx, &domain.AuditEvent{UserId: idUser, ActorId: actorId, Action: domain.AuditUserViewed})
+	return u, nil
+}
+
+// SetUserDisabled disable or enable account. Sessions of disabled user are revoked,
+// his personal access tokens stop working until he is enabled
+func (s *AuthService) SetUserDisabled(ctx context.Context, actorId, idUser string, disabled bool) error {
+	const op = "service.SetUserDisabled"
+	if _, err := s.requireAdmin(ctx, actorId); err != nil {
+		return err
+	}
+	if err := idValidation(idUser); err != nil {
+		return wrapServiceCheck(op, err)
+	}
+	if idUser == actorId {
+		return wrapServiceCheck(op, errors.New("admin can't disable himself"))
+	}
+
+	var revoked []string
+	if err := s.runInTx(ctx, op, func(ctx context.Context) error {
+		repo, err := s.userRepo(ctx)
+		if err != nil {
+			return err
+		}
+		if err := repo.SetDisabled(ctx, idUser, disabled); err != nil {
+			return err
+		}
+		if !disabled {
+			return nil
+		}
+		repoFamily, err := s.familyRepo(ctx)
+		if err != nil {
+			return err
+		}
+		revoked, err = repoFamily.RevokeFamiliesByUser(ctx, idUser, "", time.Now().UTC().Unix())
+		return err
+	}); err != nil {
+		return err
+	}
+	s.publishRevoked(ctx, revoked...)
+
+	action := domain.AuditUserEnabled
+	if disabled {
+		action = domain.AuditUserDisabled
+	}
+	s.audit(ctx, &domain.AuditEvent{UserId: idUser, ActorId: actorId, Action: action})
+	return nil
+}
+
+// SetUserRole change role of user. Admin can't change own role, so there is always at least one admin
+func (s *AuthService) SetUserRole(ctx context.Context, actorId, idUser, role string) error {
+	const op = "service.SetUserRole"
+	if _, err := s.requireAdmin(ctx, actorId); err != nil {
+		return err
+	}
+	if err := idValidation(idUser); err != nil {
+		return wrapServiceCheck(op, err)
+	}
+	if role != domain.RoleUser && role != domain.RoleAdmin {
+		return wrapServiceCheck(op, errors.New("unknown role"))
+	}
+	if idUser == actorId {
+		return wrapServiceCheck(op, errors.New("admin can't change own role"))
+	}
+
+	repo, err := s.userRepo(ctx)
+	if err != nil {
+		return err
+	}
+	if err := repo.SetRole(ctx, idUser, role); err != nil {
+		return err
+	}
+
+	s.audit(ctx, &domain.AuditEvent{UserId: idUser, ActorId: actorId, Action: domain.AuditRoleChanged, Target: role})
+	return nil
+}
+
+// ForcePasswordReset replace password of user by random one, revoke his sessions and send letter to set new password
+func (s *AuthService) ForcePasswordReset(ctx context.Context, actorId, idUser string) error {
+	const op = "service.ForcePasswordReset"
+	if _, err := s.requireAdmin(ctx, actorId); err != nil {
+		return err
+	}
+	if err := idValidation(idUser); err != nil {
+		return wrapServiceCheck(op, err)
+	}
+
+	pw, _, err := newActionToken()
+	if err != nil {
+		return format.Error(op, err)
+	}
+
+	var (
+		u       *domain.User
+		revoked []string
+	)
+	if err := s.runInTx(ctx, op, func(ctx context.Context) error {
+		repo, err := s.userRepo(ctx)
+		if err != nil {
+			return err
+		}
+		repoFamily, err := s.familyRepo(ctx)
+		if err != nil {
+			return err
+		}
+
+		if u, err = repo.GetInfo(ctx, idUser); err != nil {
+			return err
+		}
+		if err := repo.UpdatePassword(ctx, idUser, pw); err != nil {
+			return err
+		}
+		revoked, err = repoFamily.RevokeFamiliesByUser(ctx, idUser, "", time.Now().UTC().Unix())
+		return err
+	}); err != nil {
+		return err
+	}
+	s.publishRevoked(ctx, revoked...)
+
+	s.audit(ctx, &domain.AuditEvent{UserId: idUser, ActorId: actorId, Action: domain.AuditPasswordResetForced})
+	return s.sendActionToken(ctx, u, domain.ActionTokenResetPassword, s.cfg.ResetLifeTime)
+}
+
+// Impersonate return short-lived personal access token of user with read scopes only. Token is shown
+// in list of tokens of user, so he sees that support had access
+func (s *AuthService) Impersonate(ctx context.Context, actorId, idUser string) (string, *domain.Pat, error) {
+	const op = "service.Impersonate"
+	admin, err := s.requireAdmin(ctx, actorId)
+	if err != nil {
+		return "", nil, err
+	}
+	if err := idValidation(idUser); err != nil {
+		return "", nil, wrapServiceCheck(op, err)
+	}
+	if idUser == actorId {
+		return "", nil, wrapServiceCheck(op, errors.New("admin can't impersonate himself"))
+	}
+
+	secret, _, err := newActionToken()
+	if err != nil {
+		return "", nil, format.Error(op, err)
+	}
+	now := time.Now().UTC()
+	p := &domain.Pat{
+		Id:        uid.New(),
+		UserId:    idUser,
+		Name:      cutString("Read-only support access by "+admin.Login, maxPatNameLn),
+		Scopes:    impersonationScopes,
+		CreatedAt: now.Unix(),
+		ExpiresAt: now.Add(impersonationLifeTime).Unix(),
+	}
+	token := domain.PatPrefix + secret
+
+	repo, err := s.patRepo(ctx)
+	if err != nil {
+		return "", nil, err
+	}
+	if err := repo.CreatePat(ctx, p, hashActionToken(token)); err != nil {
+		return "", nil, err
+	}
+
+	s.audit(ctx, &domain.AuditEvent{UserId: idUser, ActorId: actorId, Action: domain.AuditImpersonationStarted, Target: p.Id})
+	return token, p, nil
+}
+
+// GetAuditEvents return audit log, newest first. With idUser only events about user or made by him
+func (s *AuthService) GetAuditEvents(ctx context.Context, actorId, idUser string, limit, offset int) ([]*domain.AuditEvent, error) {
+	const op = "service.GetAuditEvents"
+	if _, err := s.requireAdmin(ctx, actorId); err != nil {
+		return nil, err
+	}
+	if idUser != "" {
+		if err := idValidation(idUser); err != nil {
+			return nil, wrapServiceCheck(op, err)
+		}
+	}
+
+	repo, err := s.auditRepo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	limit, offset = adminPage(limit, offset)
+	es, err := repo.GetAuditEvents(ctx, idUser, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	s.audit(ctx, &domain.AuditEvent{UserId: idUser, ActorId: actorId, Action: domain.AuditEventsListed})
+	return es, nil
+}
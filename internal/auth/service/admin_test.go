@@ -0,0 +1,34 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/autumnterror/breezynotes/internal/auth/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAdminPage(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		limit, offset, wantLimit, wantOffset int
+	}{
+		{0, 0, defaultAdminPage, 0},
+		{-5, -1, defaultAdminPage, 0},
+		{50, 40, 50, 40},
+		{1000, 0, maxAdminPage, 0},
+	} {
+		limit, offset := adminPage(tc.limit, tc.offset)
+		assert.Equal(t, tc.wantLimit, limit)
+		assert.Equal(t, tc.wantOffset, offset)
+	}
+}
+
+func TestImpersonationScopes(t *testing.T) {
+	t.Parallel()
+
+	for _, s := range impersonationScopes {
+		assert.True(t, domain.ValidScope(s), s)
+		assert.NotContains(t, s, ":write", "impersonation is read-only")
+	}
+}
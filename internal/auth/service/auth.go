@@ -4,55 +4,86 @@ import (
 	"context"
 	"errors"
 	"github.com/autumnterror/breezynotes/internal/auth/domain"
+	"github.com/autumnterror/utils_go/pkg/utils/format"
 	"github.com/autumnterror/utils_go/pkg/utils/uid"
 	"github.com/autumnterror/utils_go/pkg/utils/validate"
 )
 
-func (s *AuthService) Auth(ctx context.Context, email, login, pw string) (*domain.User, string, string, error) {
+// Auth check password of user. If 2FA is enabled, only challenge for VerifySecondFactor is returned.
+// Failed logins are counted by login or email: answers are delayed and after limit login is locked for a while
+func (s *AuthService) Auth(ctx context.Context, email, login, pw, userAgent, ip string) (*domain.LoginResult, error) {
 	const op = "service.Auth"
 	if stringEmpty(email) && stringEmpty(login) {
-		return nil, "", "", wrapServiceCheck(op, errors.New("email and login is empty"))
+		return nil, wrapServiceCheck(op, errors.New("email and login is empty"))
 	}
 	if stringEmpty(pw) {
-		return nil, "", "", wrapServiceCheck(op, errors.New("pw is empty"))
+		return nil, wrapServiceCheck(op, errors.New("pw is empty"))
 	}
 
-	repo, err := s.authRepo(ctx)
-	if err != nil {
-		return nil, "", "", err
-	}
-	repoUser, err := s.userRepo(ctx)
-	if err != nil {
-		return nil, "", "", err
+	if s.loginLocked(ctx, loginKey(email, login)) {
+		return nil, format.Error(op, domain.ErrTooManyAttempts)
 	}
-	repoJwt, err := s.tokenRepo()
+
+	repo, err := s.authRepo(ctx)
 	if err != nil {
-		return nil, "", "", err
+		return nil, err
 	}
 
 	id, err := repo.Authentication(ctx, email, login, pw)
 	if err != nil {
-		return nil, "", "", err
+		if errors.Is(err, domain.ErrLoginOrPasswordIncorrect) {
+			s.loginFailed(ctx, email, login, ip)
+		}
+		return nil, err
 	}
+	s.loginSucceeded(ctx, email, login)
 
-	at, err := repoJwt.GenerateToken(id, domain.TokenTypeAccess)
+	return s.finishLogin(ctx, id, userAgent, ip)
+}
+
+// finishLogin is called after first factor is checked: return challenge if user has 2FA, else issue tokens.
+// Disabled user can't login
+func (s *AuthService) finishLogin(ctx context.Context, id, userAgent, ip string) (*domain.LoginResult, error) {
+	const op = "service.finishLogin"
+
+	repoUser, err := s.userRepo(ctx)
 	if err != nil {
-		return nil, "", "", err
+		return nil, err
 	}
-	rt, err := repoJwt.GenerateToken(id, domain.TokenTypeRefresh)
+	repoTwoFactor, err := s.twoFactorRepo(ctx)
 	if err != nil {
-		return nil, "", "", err
+		return nil, err
 	}
 
 	user, err := repoUser.GetInfo(ctx, id)
 	if err != nil {
-		return nil, "", "", err
+		return nil, err
+	}
+	if user.Disabled {
+		return nil, format.Error(op, domain.ErrUserDisabled)
+	}
+
+	t, err := repoTwoFactor.GetTotp(ctx, id)
+	switch {
+	case err == nil && t.Enabled:
+		challenge, err := s.challenge(id)
+		if err != nil {
+			return nil, err
+		}
+		return &domain.LoginResult{Challenge: challenge}, nil
+	case err != nil && !errors.Is(err, domain.ErrNotFound):
+		return nil, err
+	}
+
+	at, rt, err := s.issueTokens(ctx, id, userAgent, ip)
+	if err != nil {
+		return nil, err
 	}
 
-	return user, at, rt, nil
+	return &domain.LoginResult{User: user, Access: at, Refresh: rt}, nil
 }
 
-func (s *AuthService) Reg(ctx context.Context, email, login, pw string) (string, string, error) {
+func (s *AuthService) Reg(ctx context.Context, email, login, pw, userAgent, ip string) (string, string, error) {
 	const op = "service.Reg"
 	if stringEmpty(email) && stringEmpty(login) {
 		return "", "", wrapServiceCheck(op, errors.New("email and login is empty"))
@@ -67,10 +98,6 @@ func (s *AuthService) Reg(ctx context.Context, email, login, pw string) (string,
 	if err != nil {
 		return "", "", err
 	}
-	repoJwt, err := s.tokenRepo()
-	if err != nil {
-		return "", "", err
-	}
 
 	id := uid.New()
 	if err := repo.Create(ctx, &domain.User{
@@ -84,36 +111,29 @@ func (s *AuthService) Reg(ctx context.Context, email, login, pw string) (string,
 		return "", "", err
 	}
 
-	at, err := repoJwt.GenerateToken(id, domain.TokenTypeAccess)
-	if err != nil {
-		return "", "", err
-	}
-	rt, err := repoJwt.GenerateToken(id, domain.TokenTypeRefresh)
+	at, rt, err := s.issueTokens(ctx, id, userAgent, ip)
 	if err != nil {
 		return "", "", err
 	}
+	s.sendVerificationAsync(ctx, id)
+
 	return at, rt, nil
 }
 
-func (s *AuthService) ValidateTokens(ctx context.Context, at, rt string) (string, error) {
-	const op = "service.Reg"
+// ValidateTokens return nil if access token is valid, else refresh tokens. Revoked tokens can't be refreshed
+func (s *AuthService) ValidateTokens(ctx context.Context, at, rt string) (*domain.Tokens, error) {
+	const op = "service.ValidateTokens"
 	if stringEmpty(at) || stringEmpty(rt) {
-		return "", wrapServiceCheck(op, errors.New("one of tokens is empty"))
+		return nil, wrapServiceCheck(op, errors.New("one of tokens is empty"))
 	}
 
-	repoJwt, err := s.tokenRepo()
+	_, _, err := s.parseToken(ctx, at)
 	if err != nil {
-		return "", err
-	}
-
-	_, err = repoJwt.VerifyToken(at)
-	if err != nil {
-		rt, err := repoJwt.Refresh(rt)
-		if err != nil {
-			return "", err
+		if errors.Is(err, domain.ErrTokenRevoked) {
+			return nil, err
 		}
-		return rt, nil
+		return s.refresh(ctx, rt)
 	}
 
-	return "", nil
+	return nil, nil
 }
@@ -0,0 +1,291 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/autumnterror/breezynotes/internal/auth/domain"
+	"github.com/autumnterror/breezynotes/internal/auth/repository"
+	"github.com/autumnterror/utils_go/pkg/utils/format"
+)
+
+const (
+	defaultClaimDeletions = 10
+	maxClaimDeletions     = 100
+	maxDeletionErrorLn    = 1000
+)
+
+var errDeletionStep = errors.New("deletion isn't on this step")
+
+// RequestDeletion schedule deletion of account after grace period. Request of already scheduled or running
+// deletion return it without changes
+func (s *AuthService) RequestDeletion(ctx context.Context, idUser string) (*domain.AccountDeletion, error) {
+	const op = "service.RequestDeletion"
+	if err := idValidation(idUser); err != nil {
+		return nil, wrapServiceCheck(op, err)
+	}
+
+	var (
+		res     *domain.AccountDeletion
+		created bool
+	)
+	if err := s.runInTx(ctx, op, func(ctx context.Context) error {
+		repoUser, err := s.userRepo(ctx)
+		if err != nil {
+			return err
+		}
+		if _, err := repoUser.GetInfo(ctx, idUser); err != nil {
+			return err
+		}
+
+		repo, err := s.deletionRepo(ctx)
+		if err != nil {
+			return err
+		}
+		old, err := repo.GetDeletion(ctx, idUser)
+		if err != nil && !errors.Is(err, domain.ErrNotFound) {
+			return err
+		}
+		if old != nil && old.Active() {
+			res = old
+			return nil
+		}
+
+		now := time.Now().UTC()
+		res = &domain.AccountDeletion{
+			UserId:        idUser,
+			State:         domain.DeletionScheduled,
+			RequestedAt:   now.Unix(),
+			PurgeAt:       now.Add(s.cfg.DeletionGrace).Unix(),
+			NextAttemptAt: now.Add(s.cfg.DeletionGrace).Unix(),
+			UpdatedAt:     now.Unix(),
+		}
+		created = true
+		return repo.ScheduleDeletion(ctx, res)
+	}); err != nil {
+		return nil, err
+	}
+
+	if created {
+		s.audit(ctx, &domain.AuditEvent{UserId: idUser, ActorId: idUser, Action: domain.AuditDeletionRequested})
+	}
+	return res, nil
+}
+
+// CancelDeletion undo deletion during grace period. Running deletion can't be cancelled
+func (s *AuthService) CancelDeletion(ctx context.Context, idUser string) error {
+	const op = "service.CancelDeletion"
+	if err := idValidation(idUser); err != nil {
+		return wrapServiceCheck(op, err)
+	}
+
+	if err := s.runInTx(ctx, op, func(ctx context.Context) error {
+		repo, err := s.deletionRepo(ctx)
+		if err != nil {
+			return err
+		}
+		d, err := repo.GetDeletion(ctx, idUser)
+		if err != nil {
+			return err
+		}
+		switch d.State {
+		case domain.DeletionScheduled:
+			return repo.CancelDeletion(ctx, idUser, time.Now().UTC().Unix())
+		case domain.DeletionRunning:
+			return wrapServiceCheck(op, errors.New("deletion is already running, it can't be cancelled"))
+		}
+		return format.Error(op, domain.ErrNotFound)
+	}); err != nil {
+		return err
+	}
+
+	s.audit(ctx, &domain.AuditEvent{UserId: idUser, ActorId: idUser, Action: domain.AuditDeletionCancelled})
+	return nil
+}
+
+// GetDeletion return domain.ErrNotFound if user never requested deletion
+func (s *AuthService) GetDeletion(ctx context.Context, idUser string) (*domain.AccountDeletion, error) {
+	const op = "service.GetDeletion"
+	if err := idValidation(idUser); err != nil {
+		return nil, wrapServiceCheck(op, err)
+	}
+
+	repo, err := s.deletionRepo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return repo.GetDeletion(ctx, idUser)
+}
+
+// ClaimDeletions lock due deletions for worker. Deletion which leaves grace period becomes running:
+// user is disabled and his sessions are revoked, so nothing is created while data is deleted
+func (s *AuthService) ClaimDeletions(ctx context.Context, limit int) ([]*domain.AccountDeletion, error) {
+	const op = "service.ClaimDeletions"
+	var (
+		res     []*domain.AccountDeletion
+		revoked []string
+	)
+	if err := s.runInTx(ctx, op, func(ctx context.Context) error {
+		repo, err := s.deletionRepo(ctx)
+		if err != nil {
+			return err
+		}
+		now := time.Now().UTC()
+		n, lockedUntil := deletionClaim(limit, now)
+		res, err = repo.ClaimDeletions(ctx, now.Unix(), lockedUntil, n)
+		if err != nil {
+			return err
+		}
+
+		for _, d := range res {
+			if d.State != domain.DeletionScheduled {
+				continue
+			}
+			ids, err := s.startDeletion(ctx, repo, d, now.Unix())
+			if err != nil {
+				return err
+			}
+			revoked = append(revoked, ids...)
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	s.publishRevoked(ctx, revoked...)
+	return res, nil
+}
+
+// startDeletion return ids of revoked families, they are published after transaction
+func (s *AuthService) startDeletion(ctx context.Context, repo repository.DeletionRepo, d *domain.AccountDeletion, now int64) ([]string, error) {
+	if err := repo.StartDeletion(ctx, d.UserId, domain.DeletionSteps[0], now); err != nil {
+		return nil, err
+	}
+	d.State = domain.DeletionRunning
+	d.Step = domain.DeletionSteps[0]
+
+	repoUser, err := s.userRepo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	// user can be deleted already, for example by admin
+	if err := repoUser.SetDisabled(ctx, d.UserId, true); err != nil && !errors.Is(err, domain.ErrNotFound) {
+		return nil, err
+	}
+	repoFamily, err := s.familyRepo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return repoFamily.RevokeFamiliesByUser(ctx, d.UserId, "", now)
+}
+
+// CompleteDeletionStep mark step as made. After last step user is deleted. Step which is already completed
+// is ignored, so worker can repeat call after lost response
+func (s *AuthService) CompleteDeletionStep(ctx context.Context, idUser, step string) (*domain.AccountDeletion, error) {
+	const op = "service.CompleteDeletionStep"
+	if err := idValidation(idUser); err != nil {
+		return nil, wrapServiceCheck(op, err)
+	}
+
+	var (
+		res      *domain.AccountDeletion
+		finished bool
+	)
+	if err := s.runInTx(ctx, op, func(ctx context.Context) error {
+		repo, err := s.deletionRepo(ctx)
+		if err != nil {
+			return err
+		}
+		d, err := repo.GetDeletion(ctx, idUser)
+		if err != nil {
+			return err
+		}
+		if domain.DeletionStepDone(d, step) {
+			res = d
+			return nil
+		}
+		if err := checkDeletionStep(d, step); err != nil {
+			return wrapServiceCheck(op, err)
+		}
+
+		now := time.Now().UTC().Unix()
+		next := domain.NextDeletionStep(step)
+		if next != "" {
+			if err := repo.AdvanceDeletion(ctx, idUser, step, next, now); err != nil {
+				return err
+			}
+			d.Step, d.Attempts, d.LastError, d.UpdatedAt = next, 0, "", now
+			res = d
+			return nil
+		}
+
+		repoUser, err := s.userRepo(ctx)
+		if err != nil {
+			return err
+		}
+		if err := repoUser.Delete(ctx, idUser); err != nil && !errors.Is(err, domain.ErrNotFound) {
+			return err
+		}
+		if err := repo.FinishDeletion(ctx, idUser, now); err != nil {
+			return err
+		}
+		d.State, d.Step, d.Attempts, d.LastError, d.UpdatedAt = domain.DeletionDone, "", 0, "", now
+		res = d
+		finished = true
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	if finished {
+		s.audit(ctx, &domain.AuditEvent{UserId: idUser, Action: domain.AuditAccountDeleted})
+	}
+	return res, nil
+}
+
+// FailDeletionStep save error of step and put off next try by domain.DeletionBackoff
+func (s *AuthService) FailDeletionStep(ctx context.Context, idUser, step, stepErr string) error {
+	const op = "service.FailDeletionStep"
+	if err := idValidation(idUser); err != nil {
+		return wrapServiceCheck(op, err)
+	}
+
+	return s.runInTx(ctx, op, func(ctx context.Context) error {
+		repo, err := s.deletionRepo(ctx)
+		if err != nil {
+			return err
+		}
+		d, err := repo.GetDeletion(ctx, idUser)
+		if err != nil {
+			return err
+		}
+		if err := checkDeletionStep(d, step); err != nil {
+			return wrapServiceCheck(op, err)
+		}
+
+		now := time.Now().UTC()
+		return repo.FailDeletion(ctx, idUser, step, cutString(stepErr, maxDeletionErrorLn), nextDeletionAttempt(d, now), now.Unix())
+	})
+}
+
+// deletionClaim return count of deletions worker can claim and time until they are locked for it.
+// Deletion which isn't completed or failed by worker until lease end is claimed again
+func deletionClaim(limit int, now time.Time) (int, int64) {
+	if limit <= 0 {
+		limit = defaultClaimDeletions
+	}
+	return min(limit, maxClaimDeletions), now.Add(domain.DeletionLease).Unix()
+}
+
+// checkDeletionStep return errDeletionStep if worker reports step which deletion isn't running now
+func checkDeletionStep(d *domain.AccountDeletion, step string) error {
+	if d.State != domain.DeletionRunning || d.Step != step {
+		return errDeletionStep
+	}
+	return nil
+}
+
+// nextDeletionAttempt return time of next try of failed step, attempt of failure is counted
+func nextDeletionAttempt(d *domain.AccountDeletion, now time.Time) int64 {
+	return now.Add(domain.DeletionBackoff(d.Attempts + 1)).Unix()
+}
@@ -0,0 +1,45 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/autumnterror/breezynotes/internal/auth/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeletionClaim(t *testing.T) {
+	now := time.Date(2025, 1, 31, 12, 0, 0, 0, time.UTC)
+	lease := now.Add(domain.DeletionLease).Unix()
+
+	for limit, want := range map[int]int{0: defaultClaimDeletions, -1: defaultClaimDeletions, 5: 5, 1000: maxClaimDeletions} {
+		n, lockedUntil := deletionClaim(limit, now)
+		assert.Equal(t, want, n, limit)
+		assert.Equal(t, lease, lockedUntil)
+	}
+	assert.Greater(t, lease, now.Unix(), "claimed deletion isn't due for other worker until lease end")
+}
+
+func TestCheckDeletionStep(t *testing.T) {
+	d := &domain.AccountDeletion{UserId: "user", State: domain.DeletionRunning, Step: domain.DeletionSteps[0]}
+	assert.NoError(t, checkDeletionStep(d, domain.DeletionSteps[0]))
+	assert.ErrorIs(t, checkDeletionStep(d, domain.NextDeletionStep(domain.DeletionSteps[0])), errDeletionStep)
+
+	for _, state := range []string{domain.DeletionScheduled, domain.DeletionDone, domain.DeletionCancelled} {
+		d.State = state
+		assert.ErrorIs(t, checkDeletionStep(d, domain.DeletionSteps[0]), errDeletionStep, state)
+	}
+}
+
+func TestNextDeletionAttempt(t *testing.T) {
+	now := time.Date(2025, 1, 31, 12, 0, 0, 0, time.UTC)
+	d := &domain.AccountDeletion{State: domain.DeletionRunning, Step: domain.DeletionSteps[0]}
+
+	assert.Equal(t, now.Add(time.Minute).Unix(), nextDeletionAttempt(d, now))
+	d.Attempts = 1
+	assert.Equal(t, now.Add(2*time.Minute).Unix(), nextDeletionAttempt(d, now))
+	d.Attempts = 3
+	assert.Equal(t, now.Add(8*time.Minute).Unix(), nextDeletionAttempt(d, now))
+	d.Attempts = 100
+	assert.Equal(t, now.Add(6*time.Hour).Unix(), nextDeletionAttempt(d, now))
+}
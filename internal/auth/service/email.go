@@ -0,0 +1,242 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/autumnterror/breezynotes/internal/auth/domain"
+	"github.com/autumnterror/breezynotes/internal/auth/mail"
+	"github.com/autumnterror/utils_go/pkg/log"
+	"github.com/autumnterror/utils_go/pkg/utils/format"
+	"github.com/autumnterror/utils_go/pkg/utils/validate"
+)
+
+const (
+	actionTokenSize = 32
+	mailTimeout     = 30 * time.Second
+)
+
+// newActionToken return random token for letter and its hash for db
+func newActionToken() (string, string, error) {
+	b := make([]byte, actionTokenSize)
+	if _, err := rand.Read(b); err != nil {
+		return "", "", err
+	}
+	token := base64.RawURLEncoding.EncodeToString(b)
+	return token, hashActionToken(token), nil
+}
+
+func hashActionToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// actionLetter return letter with link to frontend page which finishes action
+func (s *AuthService) actionLetter(to, purpose, token string) mail.Message {
+	q := url.Values{"token": {token}}.Encode()
+	switch purpose {
+	case domain.ActionTokenResetPassword:
+		return mail.Message{
+			To:      to,
+			Subject: "BreezyNotes: password reset",
+			Body: fmt.Sprintf("Someone asked to reset password of your account. Open link to set new one:\n%s/reset?%s\n\n"+
+				"Link works %s. If it was not you, ignore this letter.", s.cfg.PublicUrl, q, s.cfg.ResetLifeTime),
+		}
+	default:
+		return mail.Message{
+			To:      to,
+			Subject: "BreezyNotes: confirm email",
+			Body: fmt.Sprintf("Open link to confirm your email:\n%s/verify?%s\n\nLink works %s.",
+				s.cfg.PublicUrl, q, s.cfg.VerifyLifeTime),
+		}
+	}
+}
+
+// sendActionToken send letter with new token. Tokens sent before with same purpose stop working
+func (s *AuthService) sendActionToken(ctx context.Context, u *domain.User, purpose string, lifeTime time.Duration) error {
+	const op = "service.sendActionToken"
+
+	if s.mail == nil {
+		return wrapServiceCheck(op, errors.New("mail sender is nil"))
+	}
+
+	token, hash, err := newActionToken()
+	if err != nil {
+		return format.Error(op, err)
+	}
+
+	if err := s.runInTx(ctx, op, func(ctx context.Context) error {
+		repo, err := s.actionTokenRepo(ctx)
+		if err != nil {
+			return err
+		}
+		if err := repo.DeleteActionTokens(ctx, u.Id, purpose); err != nil {
+			return err
+		}
+		return repo.CreateActionToken(ctx, &domain.ActionToken{
+			Hash:      hash,
+			UserId:    u.Id,
+			Purpose:   purpose,
+			ExpiresAt: time.Now().UTC().Add(lifeTime).Unix(),
+		})
+	}); err != nil {
+		return err
+	}
+
+	return s.mail.Send(ctx, s.actionLetter(u.Email, purpose, token))
+}
+
+// SendVerification send letter with link to confirm email of user
+func (s *AuthService) SendVerification(ctx context.Context, idUser string) error {
+	const op = "service.SendVerification"
+	if err := idValidation(idUser); err != nil {
+		return wrapServiceCheck(op, err)
+	}
+
+	repo, err := s.userRepo(ctx)
+	if err != nil {
+		return err
+	}
+	u, err := repo.GetInfo(ctx, idUser)
+	if err != nil {
+		return err
+	}
+	if u.EmailVerified {
+		return format.Error(op, domain.ErrAlreadyExists)
+	}
+
+	return s.sendActionToken(ctx, u, domain.ActionTokenVerifyEmail, s.cfg.VerifyLifeTime)
+}
+
+// sendVerificationAsync is used after Reg and UpdateEmail: they must not wait for mail server and fail because of it
+func (s *AuthService) sendVerificationAsync(ctx context.Context, idUser string) {
+	const op = "service.sendVerificationAsync"
+
+	go func() {
+		ctx, done := context.WithTimeout(context.WithoutCancel(ctx), mailTimeout)
+		defer done()
+
+		if err := s.SendVerification(ctx, idUser); err != nil {
+			log.Error(op, "send verification to "+idUser, err)
+		}
+	}()
+}
+
+// VerifyEmail confirm email of user by token from letter
+func (s *AuthService) VerifyEmail(ctx context.Context, token string) error {
+	const op = "service.VerifyEmail"
+	if stringEmpty(token) {
+		return wrapServiceCheck(op, errors.New("token is empty"))
+	}
+
+	return s.runInTx(ctx, op, func(ctx context.Context) error {
+		repo, err := s.actionTokenRepo(ctx)
+		if err != nil {
+			return err
+		}
+		repoUser, err := s.userRepo(ctx)
+		if err != nil {
+			return err
+		}
+
+		id, err := repo.UseActionToken(ctx, hashActionToken(token), domain.ActionTokenVerifyEmail, time.Now().UTC().Unix())
+		if err != nil {
+			return err
+		}
+		return repoUser.SetEmailVerified(ctx, id)
+	})
+}
+
+// ForgotPassword send letter with link to reset password. Account is searched and letter is sent in background,
+// so response, its time and errors of mail server are the same for registered and unknown emails
+func (s *AuthService) ForgotPassword(ctx context.Context, email string) error {
+	const op = "service.ForgotPassword"
+	if stringEmpty(email) {
+		return wrapServiceCheck(op, errors.New("email is empty"))
+	}
+
+	go func() {
+		ctx, done := context.WithTimeout(context.WithoutCancel(ctx), mailTimeout)
+		defer done()
+
+		if err := s.sendReset(ctx, email); err != nil {
+			log.Error(op, "send reset", err)
+		}
+	}()
+	return nil
+}
+
+// sendReset send reset letter if email is registered
+func (s *AuthService) sendReset(ctx context.Context, email string) error {
+	repo, err := s.userRepo(ctx)
+	if err != nil {
+		return err
+	}
+
+	id, err := repo.GetIdFromEmail(ctx, email)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil
+		}
+		return err
+	}
+	u, err := repo.GetInfo(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	return s.sendActionToken(ctx, u, domain.ActionTokenResetPassword, s.cfg.ResetLifeTime)
+}
+
+// ResetPassword set new password by token from letter. All sessions of user are revoked,
+// email is verified because user got the letter
+func (s *AuthService) ResetPassword(ctx context.Context, token, newPassword string) error {
+	const op = "service.ResetPassword"
+	if stringEmpty(token) {
+		return wrapServiceCheck(op, errors.New("token is empty"))
+	}
+	if !validate.Password(newPassword) {
+		return wrapServiceCheck(op, errors.New("new password not in policy"))
+	}
+
+	var revoked []string
+	if err := s.runInTx(ctx, op, func(ctx context.Context) error {
+		repo, err := s.actionTokenRepo(ctx)
+		if err != nil {
+			return err
+		}
+		repoUser, err := s.userRepo(ctx)
+		if err != nil {
+			return err
+		}
+		repoFamily, err := s.familyRepo(ctx)
+		if err != nil {
+			return err
+		}
+
+		now := time.Now().UTC().Unix()
+		id, err := repo.UseActionToken(ctx, hashActionToken(token), domain.ActionTokenResetPassword, now)
+		if err != nil {
+			return err
+		}
+		if err := repoUser.UpdatePassword(ctx, id, newPassword); err != nil {
+			return err
+		}
+		if err := repoUser.SetEmailVerified(ctx, id); err != nil {
+			return err
+		}
+		revoked, err = repoFamily.RevokeFamiliesByUser(ctx, id, "", now)
+		return err
+	}); err != nil {
+		return err
+	}
+	s.publishRevoked(ctx, revoked...)
+	return nil
+}
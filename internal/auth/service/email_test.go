@@ -0,0 +1,18 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestActionToken(t *testing.T) {
+	token, hash, err := newActionToken()
+	assert.NoError(t, err)
+	assert.Len(t, hash, 64)
+	assert.Equal(t, hash, hashActionToken(token))
+
+	other, _, err := newActionToken()
+	assert.NoError(t, err)
+	assert.NotEqual(t, token, other)
+}
@@ -0,0 +1,200 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/autumnterror/breezynotes/internal/auth/domain"
+	"github.com/autumnterror/utils_go/pkg/log"
+	"github.com/autumnterror/utils_go/pkg/utils/format"
+	"github.com/autumnterror/utils_go/pkg/utils/uid"
+)
+
+const (
+	loginAttemptsPrefix = "login-attempts:"
+	// loginFreeAttempts failed logins without delay, then delay doubles from loginDelayBase up to loginDelayMax.
+	// loginDelayMax is less than timeout of api
+	loginFreeAttempts = 3
+	loginDelayBase    = 250 * time.Millisecond
+	loginDelayMax     = 2 * time.Second
+	maxAuditTargetLn  = 255
+)
+
+// loginKey key of failed logins counter. It is built only from what user typed, so counter
+// and lockout are same for existing and not existing accounts
+func loginKey(email, login string) string {
+	if login != "" {
+		return loginAttemptsPrefix + "login:" + strings.ToLower(strings.TrimSpace(login))
+	}
+	return loginAttemptsPrefix + "email:" + strings.ToLower(strings.TrimSpace(email))
+}
+
+// loginDelay return delay of answer to failed login number count
+func loginDelay(count int64) time.Duration {
+	n := count - loginFreeAttempts
+	if n <= 0 {
+		return 0
+	}
+	if n > 4 {
+		return loginDelayMax
+	}
+	return min(loginDelayBase<<(n-1), loginDelayMax)
+}
+
+func (s *AuthService) loginMaxAttempts() int64 {
+	if s.cfg == nil || s.cfg.LoginMaxAttempts <= 0 {
+		return 10
+	}
+	return s.cfg.LoginMaxAttempts
+}
+
+func (s *AuthService) loginLockTime() time.Duration {
+	if s.cfg == nil || s.cfg.LoginLockTime <= 0 {
+		return 15 * time.Minute
+	}
+	return s.cfg.LoginLockTime
+}
+
+// loginLocked check lockout of key. If counter is unavailable login is not blocked
+func (s *AuthService) loginLocked(ctx context.Context, key string) bool {
+	const op = "service.loginLocked"
+	if s.attempts == nil {
+		return false
+	}
+
+	count, _, err := s.attempts.Get(ctx, key)
+	if err != nil {
+		log.Error(op, key, err)
+		return false
+	}
+	return count >= s.loginMaxAttempts()
+}
+
+// loginFailed count failed login, lock key on last attempt and hold answer by progressive delay
+func (s *AuthService) loginFailed(ctx context.Context, email, login, ip string) {
+	const op = "service.loginFailed"
+	if s.attempts == nil {
+		return
+	}
+
+	key := loginKey(email, login)
+	count, _, err := s.attempts.Incr(ctx, key, s.loginLockTime())
+	if err != nil {
+		log.Error(op, key, err)
+		return
+	}
+
+	if count == s.loginMaxAttempts() {
+		s.audit(ctx, &domain.AuditEvent{
+			UserId: s.loginOwner(ctx, email, login),
+			Action: domain.AuditLoginLocked,
+			Target: strings.TrimPrefix(key, loginAttemptsPrefix),
+			Ip:     ip,
+		})
+	}
+
+	t := time.NewTimer(loginDelay(count))
+	defer t.Stop()
+	select {
+	case <-t.C:
+	case <-ctx.Done():
+	}
+}
+
+func (s *AuthService) loginSucceeded(ctx context.Context, email, login string) {
+	const op = "service.loginSucceeded"
+	if s.attempts == nil {
+		return
+	}
+
+	if err := s.attempts.Reset(ctx, loginKey(email, login)); err != nil {
+		log.Error(op, "", err)
+	}
+}
+
+// loginOwner return id of user by login or email, empty if there is no such user. It is used only for audit
+func (s *AuthService) loginOwner(ctx context.Context, email, login string) string {
+	repo, err := s.userRepo(ctx)
+	if err != nil {
+		return ""
+	}
+
+	var id string
+	if login != "" {
+		id, err = repo.GetIdFromLogin(ctx, login)
+	} else {
+		id, err = repo.GetIdFromEmail(ctx, email)
+	}
+	if err != nil {
+		return ""
+	}
+	return id
+}
+
+// UnlockLogin reset failed logins by identifier, it is login or email. If it is account, both its login
+// and email are unlocked. Only admin can do it
+func (s *AuthService) UnlockLogin(ctx context.Context, actorId, identifier string) error {
+	const op = "service.UnlockLogin"
+	if _, err := s.requireAdmin(ctx, actorId); err != nil {
+		return err
+	}
+	identifier = strings.TrimSpace(identifier)
+	if stringEmpty(identifier) {
+		return wrapServiceCheck(op, errors.New("identifier is empty"))
+	}
+	if s.attempts == nil {
+		return format.Error(op, errors.New("attempts counter is nil"))
+	}
+
+	keys := []string{loginKey("", identifier), loginKey(identifier, "")}
+	id := s.loginOwner(ctx, "", identifier)
+	if id == "" {
+		id = s.loginOwner(ctx, identifier, "")
+	}
+	if id != "" {
+		repo, err := s.userRepo(ctx)
+		if err != nil {
+			return err
+		}
+		u, err := repo.GetInfo(ctx, id)
+		if err != nil {
+			return err
+		}
+		keys = append(keys, loginKey("", u.Login), loginKey(u.Email, ""))
+	}
+
+	for _, k := range keys {
+		if err := s.attempts.Reset(ctx, k); err != nil {
+			return format.Error(op, err)
+		}
+	}
+
+	s.audit(ctx, &domain.AuditEvent{
+		UserId:  id,
+		ActorId: actorId,
+		Action:  domain.AuditLoginUnlocked,
+		Target:  identifier,
+	})
+	return nil
+}
+
+// audit save event. Error is only logged: action which is audited is already done
+func (s *AuthService) audit(ctx context.Context, e *domain.AuditEvent) {
+	const op = "service.audit"
+
+	repo, err := s.auditRepo(ctx)
+	if err != nil {
+		log.Error(op, e.Action, err)
+		return
+	}
+
+	e.Id = uid.New()
+	e.CreatedAt = time.Now().UTC().Unix()
+	e.Target = cutString(e.Target, maxAuditTargetLn)
+	e.Ip = cutString(e.Ip, maxIpLn)
+	if err := repo.CreateAuditEvent(ctx, e); err != nil {
+		log.Error(op, e.Action, err)
+	}
+}
@@ -0,0 +1,33 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoginKey(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, loginKey("", " Admin"), loginKey("other@mail.com", "admin"), "login is used before email")
+	assert.Equal(t, loginKey("User@Mail.com ", ""), loginKey("user@mail.com", ""))
+	assert.NotEqual(t, loginKey("", "user"), loginKey("user", ""), "login and email are counted apart")
+}
+
+func TestLoginDelay(t *testing.T) {
+	t.Parallel()
+
+	for count, d := range map[int64]time.Duration{
+		1:    0,
+		3:    0,
+		4:    250 * time.Millisecond,
+		5:    500 * time.Millisecond,
+		6:    time.Second,
+		7:    2 * time.Second,
+		8:    2 * time.Second,
+		1000: 2 * time.Second,
+	} {
+		assert.Equal(t, d, loginDelay(count), count)
+	}
+}
@@ -0,0 +1,283 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/autumnterror/breezynotes/internal/auth/config"
+	"github.com/autumnterror/breezynotes/internal/auth/domain"
+	"github.com/autumnterror/breezynotes/internal/auth/pkg/oidc"
+	"github.com/autumnterror/utils_go/pkg/log"
+	"github.com/autumnterror/utils_go/pkg/utils/format"
+	"github.com/autumnterror/utils_go/pkg/utils/uid"
+)
+
+const (
+	oidcLoginLifeTime = 10 * time.Minute
+	oidcHttpTimeout   = 10 * time.Second
+	maxLoginLn        = 30
+	loginAttempts     = 5
+)
+
+func newOidcProviders(cfg *config.Config) map[string]*oidc.Provider {
+	if cfg == nil {
+		return nil
+	}
+
+	client := &http.Client{Timeout: oidcHttpTimeout}
+	res := make(map[string]*oidc.Provider, len(cfg.OidcProviders))
+	for _, p := range cfg.OidcProviders {
+		res[p.Name] = oidc.New(oidc.Config{
+			Issuer:       p.Issuer,
+			ClientId:     p.ClientId,
+			ClientSecret: p.ClientSecret,
+			RedirectUrl:  p.RedirectUrl,
+			Scopes:       p.Scopes,
+		}, client)
+	}
+	return res
+}
+
+// OidcProviders return names of configured SSO providers
+func (s *AuthService) OidcProviders() []string {
+	names := make([]string, 0, len(s.oidc))
+	for name := range s.oidc {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// OidcStart begin login by provider. Return url of provider login page and state,
+// gateway binds state to browser and checks it in callback
+func (s *AuthService) OidcStart(ctx context.Context, provider string) (string, string, error) {
+	const op = "service.OidcStart"
+
+	p, ok := s.oidc[provider]
+	if !ok {
+		return "", "", format.Error(op, domain.ErrNotFound)
+	}
+
+	state, stateHash, err := newActionToken()
+	if err != nil {
+		return "", "", format.Error(op, err)
+	}
+	nonce, _, err := newActionToken()
+	if err != nil {
+		return "", "", format.Error(op, err)
+	}
+	verifier, err := oidc.NewVerifier()
+	if err != nil {
+		return "", "", format.Error(op, err)
+	}
+
+	u, err := p.AuthCodeURL(ctx, state, nonce, verifier)
+	if err != nil {
+		return "", "", format.Error(op, err)
+	}
+
+	repo, err := s.identityRepo(ctx)
+	if err != nil {
+		return "", "", err
+	}
+	if err := repo.CreateOidcLogin(ctx, &domain.OidcLogin{
+		StateHash: stateHash,
+		Provider:  provider,
+		Verifier:  verifier,
+		Nonce:     nonce,
+		ExpiresAt: time.Now().UTC().Add(oidcLoginLifeTime).Unix(),
+	}); err != nil {
+		return "", "", err
+	}
+
+	return u, state, nil
+}
+
+// OidcCallback finish login by provider with code from redirect. Account at provider is linked to user on
+// first login: to user with same email or to new user. It is done only if provider verified email.
+// Then login continues as after password, so user with 2FA gets challenge
+func (s *AuthService) OidcCallback(ctx context.Context, provider, code, state, userAgent, ip string) (*domain.LoginResult, error) {
+	const op = "service.OidcCallback"
+	if stringEmpty(code) || stringEmpty(state) {
+		return nil, wrapServiceCheck(op, errors.New("code or state is empty"))
+	}
+
+	p, ok := s.oidc[provider]
+	if !ok {
+		return nil, format.Error(op, domain.ErrNotFound)
+	}
+
+	repo, err := s.identityRepo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	l, err := repo.UseOidcLogin(ctx, hashActionToken(state), time.Now().UTC().Unix())
+	if err != nil {
+		return nil, err
+	}
+	if l.Provider != provider {
+		return nil, format.Error(op, domain.ErrTokenInvalid)
+	}
+
+	claims, err := p.Login(ctx, code, l.Verifier, l.Nonce)
+	if err != nil {
+		log.Error(op, provider, err)
+		return nil, format.Error(op, domain.ErrUnauthorized)
+	}
+
+	var id string
+	if err := s.runInTx(ctx, op, func(ctx context.Context) error {
+		id, err = s.oidcUser(ctx, provider, claims)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	return s.finishLogin(ctx, id, userAgent, ip)
+}
+
+// oidcUser return user linked to account at provider, link or create it if there is no such user.
+// Local account with not verified email is not linked: it could be registered by anyone with email of SSO user
+func (s *AuthService) oidcUser(ctx context.Context, provider string, c *oidc.Claims) (string, error) {
+	const op = "service.oidcUser"
+
+	repo, err := s.identityRepo(ctx)
+	if err != nil {
+		return "", err
+	}
+	repoUser, err := s.userRepo(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	id, err := repo.GetIdentity(ctx, provider, c.Subject)
+	if err == nil {
+		return id, nil
+	}
+	if !errors.Is(err, domain.ErrNotFound) {
+		return "", err
+	}
+
+	if stringEmpty(c.Email) || !c.EmailVerified {
+		return "", format.Error(op, domain.ErrUnauthorized)
+	}
+
+	id, err = repoUser.GetIdFromEmail(ctx, c.Email)
+	switch {
+	case err == nil:
+		u, err := repoUser.GetInfo(ctx, id)
+		if err != nil {
+			return "", err
+		}
+		if !u.EmailVerified {
+			return "", format.Error(op, domain.ErrAlreadyExists)
+		}
+	case errors.Is(err, domain.ErrNotFound):
+		if id, err = s.createOidcUser(ctx, c.Email); err != nil {
+			return "", err
+		}
+	default:
+		return "", err
+	}
+
+	if err := repo.CreateIdentity(ctx, &domain.Identity{
+		Provider:  provider,
+		Subject:   c.Subject,
+		UserId:    id,
+		Email:     c.Email,
+		CreatedAt: time.Now().UTC().Unix(),
+	}); err != nil {
+		return "", err
+	}
+
+	return id, nil
+}
+
+// createOidcUser create user with verified email and random password. User can set own password by ForgotPassword
+func (s *AuthService) createOidcUser(ctx context.Context, email string) (string, error) {
+	const op = "service.createOidcUser"
+
+	repo, err := s.userRepo(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	login, err := s.freeLogin(ctx, loginFromEmail(email))
+	if err != nil {
+		return "", err
+	}
+	pw, _, err := newActionToken()
+	if err != nil {
+		return "", format.Error(op, err)
+	}
+
+	id := uid.New()
+	if err := repo.Create(ctx, &domain.User{
+		Id:       id,
+		Login:    login,
+		Email:    email,
+		About:    "😌",
+		Photo:    "images/default.png",
+		Password: pw,
+	}); err != nil {
+		return "", err
+	}
+	if err := repo.SetEmailVerified(ctx, id); err != nil {
+		return "", err
+	}
+
+	return id, nil
+}
+
+// freeLogin return base or base with random suffix which is not taken yet
+func (s *AuthService) freeLogin(ctx context.Context, base string) (string, error) {
+	const op = "service.freeLogin"
+
+	repo, err := s.userRepo(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	login := base
+	for range loginAttempts {
+		_, err := repo.GetIdFromLogin(ctx, login)
+		if errors.Is(err, domain.ErrNotFound) {
+			return login, nil
+		}
+		if err != nil {
+			return "", err
+		}
+
+		b := make([]byte, 3)
+		if _, err := rand.Read(b); err != nil {
+			return "", format.Error(op, err)
+		}
+		login = base + "_" + hex.EncodeToString(b)
+	}
+
+	return "", format.Error(op, domain.ErrAlreadyExists)
+}
+
+// loginFromEmail return local part of email with only [a-z0-9_.-]
+func loginFromEmail(email string) string {
+	local, _, _ := strings.Cut(strings.ToLower(email), "@")
+
+	var b strings.Builder
+	for _, r := range local {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') || r == '_' || r == '.' || r == '-' {
+			b.WriteRune(r)
+		}
+	}
+
+	login := cutString(b.String(), maxLoginLn)
+	if login == "" {
+		return "user"
+	}
+	return login
+}
@@ -0,0 +1,21 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoginFromEmail(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]string{
+		"John.Doe@corp.example":                         "john.doe",
+		"a+tag@corp.example":                            "atag",
+		"имя@corp.example":                              "user",
+		"very-long-local-part-of-email-address@corp.io": "very-long-local-part-of-email-",
+	}
+	for email, want := range cases {
+		assert.Equal(t, want, loginFromEmail(email), email)
+	}
+}
@@ -0,0 +1,155 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/autumnterror/breezynotes/internal/auth/domain"
+	"github.com/autumnterror/utils_go/pkg/log"
+	"github.com/autumnterror/utils_go/pkg/utils/format"
+	"github.com/autumnterror/utils_go/pkg/utils/uid"
+)
+
+const (
+	maxPatNameLn   = 100
+	maxPatsPerUser = 50
+	maxPatLifeTime = 366 * 24 * time.Hour
+)
+
+// patScopes check scopes and return them sorted without duplicates
+func patScopes(scopes []string) ([]string, error) {
+	if len(scopes) == 0 {
+		return nil, errors.New("scopes are empty")
+	}
+	res := make([]string, 0, len(scopes))
+	for _, sc := range scopes {
+		if !domain.ValidScope(sc) {
+			return nil, fmt.Errorf("unknown scope %q", sc)
+		}
+		res = append(res, sc)
+	}
+	slices.Sort(res)
+	return slices.Compact(res), nil
+}
+
+// CreatePat create personal access token. Token is returned only here, only its hash is stored
+func (s *AuthService) CreatePat(ctx context.Context, idUser, name string, scopes []string, expiresAt int64) (string, *domain.Pat, error) {
+	const op = "service.CreatePat"
+
+	if err := idValidation(idUser); err != nil {
+		return "", nil, wrapServiceCheck(op, err)
+	}
+	name = strings.TrimSpace(name)
+	if stringEmpty(name) {
+		return "", nil, wrapServiceCheck(op, errors.New("name is empty"))
+	}
+	scopes, err := patScopes(scopes)
+	if err != nil {
+		return "", nil, wrapServiceCheck(op, err)
+	}
+	now := time.Now().UTC()
+	if expiresAt <= now.Unix() || expiresAt > now.Add(maxPatLifeTime).Unix() {
+		return "", nil, wrapServiceCheck(op, errors.New("expiration must be in future and not later than year"))
+	}
+
+	secret, _, err := newActionToken()
+	if err != nil {
+		return "", nil, format.Error(op, err)
+	}
+	p := &domain.Pat{
+		Id:        uid.New(),
+		UserId:    idUser,
+		Name:      cutString(name, maxPatNameLn),
+		Scopes:    scopes,
+		CreatedAt: now.Unix(),
+		ExpiresAt: expiresAt,
+	}
+
+	token := domain.PatPrefix + secret
+	if err := s.runInTx(ctx, op, func(ctx context.Context) error {
+		repo, err := s.patRepo(ctx)
+		if err != nil {
+			return err
+		}
+		n, err := repo.CountPats(ctx, idUser)
+		if err != nil {
+			return err
+		}
+		if n >= maxPatsPerUser {
+			return wrapServiceCheck(op, errors.New("too many tokens"))
+		}
+		return repo.CreatePat(ctx, p, hashActionToken(token))
+	}); err != nil {
+		return "", nil, err
+	}
+
+	return token, p, nil
+}
+
+// ListPats return tokens of user without their secrets
+func (s *AuthService) ListPats(ctx context.Context, idUser string) ([]*domain.Pat, error) {
+	const op = "service.ListPats"
+	if err := idValidation(idUser); err != nil {
+		return nil, wrapServiceCheck(op, err)
+	}
+
+	repo, err := s.patRepo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return repo.GetPats(ctx, idUser)
+}
+
+func (s *AuthService) RevokePat(ctx context.Context, idUser, id string) error {
+	const op = "service.RevokePat"
+	if err := idValidation(idUser); err != nil {
+		return wrapServiceCheck(op, err)
+	}
+	if err := idValidation(id); err != nil {
+		return wrapServiceCheck(op, err)
+	}
+
+	repo, err := s.patRepo(ctx)
+	if err != nil {
+		return err
+	}
+	return repo.DeletePat(ctx, idUser, id)
+}
+
+// ValidatePat return owner of token and its scopes. Unknown token is domain.ErrTokenInvalid
+func (s *AuthService) ValidatePat(ctx context.Context, token string) (*domain.Pat, error) {
+	const op = "service.ValidatePat"
+	if !strings.HasPrefix(token, domain.PatPrefix) {
+		return nil, format.Error(op, domain.ErrTokenInvalid)
+	}
+
+	repo, err := s.patRepo(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	p, err := repo.GetPatByHash(ctx, hashActionToken(token))
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, format.Error(op, domain.ErrTokenInvalid)
+		}
+		return nil, err
+	}
+	now := time.Now().UTC().Unix()
+	if p.ExpiresAt <= now {
+		return nil, format.Error(op, domain.ErrTokenExpired)
+	}
+
+	if now-p.LastUsedAt >= int64(sessionTouchInterval.Seconds()) {
+		if err := repo.TouchPat(ctx, p.Id, now, int64(sessionTouchInterval.Seconds())); err != nil {
+			log.Error(op, "touch "+p.Id, err)
+		}
+		p.LastUsedAt = now
+	}
+
+	return p, nil
+}
@@ -0,0 +1,22 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/autumnterror/breezynotes/internal/auth/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPatScopes(t *testing.T) {
+	t.Parallel()
+
+	res, err := patScopes([]string{domain.ScopeNotesWrite, domain.ScopeNotesRead, domain.ScopeNotesWrite})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{domain.ScopeNotesRead, domain.ScopeNotesWrite}, res)
+
+	_, err = patScopes(nil)
+	assert.Error(t, err)
+
+	_, err = patScopes([]string{domain.ScopeTagsRead, "user:write"})
+	assert.Error(t, err)
+}
@@ -0,0 +1,58 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/autumnterror/breezynotes/internal/auth/domain"
+)
+
+// GetPreferences return saved preferences of user or default ones
+func (s *AuthService) GetPreferences(ctx context.Context, idUser string) (*domain.Preferences, error) {
+	const op = "service.GetPreferences"
+	if err := idValidation(idUser); err != nil {
+		return nil, wrapServiceCheck(op, err)
+	}
+
+	repo, err := s.preferencesRepo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	p, err := repo.GetPreferences(ctx, idUser)
+	if errors.Is(err, domain.ErrNotFound) {
+		return domain.DefaultPreferences(), nil
+	}
+	return p, err
+}
+
+// UpdatePreferences replace whole document of preferences and return saved one. p.Revision must be revision
+// of preferences p is made from, domain.ErrConflict if they were changed since
+func (s *AuthService) UpdatePreferences(ctx context.Context, idUser string, p *domain.Preferences) (*domain.Preferences, error) {
+	const op = "service.UpdatePreferences"
+	if err := idValidation(idUser); err != nil {
+		return nil, wrapServiceCheck(op, err)
+	}
+	if p == nil {
+		return nil, wrapServiceCheck(op, errors.New("preferences are empty"))
+	}
+	if err := p.Validate(); err != nil {
+		return nil, wrapServiceCheck(op, err)
+	}
+	if p.DefaultTag != "" {
+		if err := idValidation(p.DefaultTag); err != nil {
+			return nil, wrapServiceCheck(op, errors.New("default tag must be id of tag"))
+		}
+	}
+	p.Version = domain.PreferencesVersion
+
+	repo, err := s.preferencesRepo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	rev, err := repo.SetPreferences(ctx, idUser, p)
+	if err != nil {
+		return nil, err
+	}
+	p.Revision = rev
+	return p, nil
+}
@@ -56,6 +56,132 @@ func (s *AuthService) healthRepo(ctx context.Context) (repository.HealthRepo, er
 	return res, nil
 }
 
+func (s *AuthService) workspaceRepo(ctx context.Context) (repository.WorkspaceRepo, error) {
+	repo, err := s.repoGetter(ctx, func(p repository.Provider) any {
+		return p.Workspace(ctx)
+	})
+	if err != nil {
+		return nil, err
+	}
+	res, _ := repo.(repository.WorkspaceRepo)
+	if res == nil {
+		return nil, errors.New("workspace repository is nil")
+	}
+	return res, nil
+}
+
+func (s *AuthService) familyRepo(ctx context.Context) (repository.TokenRepo, error) {
+	repo, err := s.repoGetter(ctx, func(p repository.Provider) any {
+		return p.Token(ctx)
+	})
+	if err != nil {
+		return nil, err
+	}
+	res, _ := repo.(repository.TokenRepo)
+	if res == nil {
+		return nil, errors.New("token family repository is nil")
+	}
+	return res, nil
+}
+
+func (s *AuthService) twoFactorRepo(ctx context.Context) (repository.TwoFactorRepo, error) {
+	repo, err := s.repoGetter(ctx, func(p repository.Provider) any {
+		return p.TwoFactor(ctx)
+	})
+	if err != nil {
+		return nil, err
+	}
+	res, _ := repo.(repository.TwoFactorRepo)
+	if res == nil {
+		return nil, errors.New("two factor repository is nil")
+	}
+	return res, nil
+}
+
+func (s *AuthService) actionTokenRepo(ctx context.Context) (repository.ActionTokenRepo, error) {
+	repo, err := s.repoGetter(ctx, func(p repository.Provider) any {
+		return p.ActionToken(ctx)
+	})
+	if err != nil {
+		return nil, err
+	}
+	res, _ := repo.(repository.ActionTokenRepo)
+	if res == nil {
+		return nil, errors.New("action token repository is nil")
+	}
+	return res, nil
+}
+
+func (s *AuthService) identityRepo(ctx context.Context) (repository.IdentityRepo, error) {
+	repo, err := s.repoGetter(ctx, func(p repository.Provider) any {
+		return p.Identity(ctx)
+	})
+	if err != nil {
+		return nil, err
+	}
+	res, _ := repo.(repository.IdentityRepo)
+	if res == nil {
+		return nil, errors.New("identity repository is nil")
+	}
+	return res, nil
+}
+
+func (s *AuthService) patRepo(ctx context.Context) (repository.PatRepo, error) {
+	repo, err := s.repoGetter(ctx, func(p repository.Provider) any {
+		return p.Pat(ctx)
+	})
+	if err != nil {
+		return nil, err
+	}
+	res, _ := repo.(repository.PatRepo)
+	if res == nil {
+		return nil, errors.New("personal access token repository is nil")
+	}
+	return res, nil
+}
+
+func (s *AuthService) auditRepo(ctx context.Context) (repository.AuditRepo, error) {
+	repo, err := s.repoGetter(ctx, func(p repository.Provider) any {
+		return p.Audit(ctx)
+	})
+	if err != nil {
+		return nil, err
+	}
+	res, _ := repo.(repository.AuditRepo)
+	if res == nil {
+		return nil, errors.New("audit repository is nil")
+	}
+	return res, nil
+}
+
+func (s *AuthService) preferencesRepo(ctx context.Context) (repository.PreferencesRepo, error) {
+	repo, err := s.repoGetter(ctx, func(p repository.Provider) any {
+		return p.Preferences(ctx)
+	})
+	if err != nil {
+		return nil, err
+	}
+	res, _ := repo.(repository.PreferencesRepo)
+	if res == nil {
+		return nil, errors.New("preferences repository is nil")
+	}
+	return res, nil
+}
+
+func (s *AuthService) deletionRepo(ctx context.Context) (repository.DeletionRepo, error) {
+	repo, err := s.repoGetter(ctx, func(p repository.Provider) any {
+		return p.Deletion(ctx)
+	})
+	if err != nil {
+		return nil, err
+	}
+	res, _ := repo.(repository.DeletionRepo)
+	if res == nil {
+		return nil, errors.New("deletion repository is nil")
+	}
+	return res, nil
+}
+
 func (s *AuthService) repoGetter(ctx context.Context, getter func(repository.Provider) any) (any, error) {
 	if s.repos == nil {
 		return nil, errors.New("repository provider is nil")
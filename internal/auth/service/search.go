@@ -0,0 +1,62 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/autumnterror/breezynotes/internal/auth/domain"
+)
+
+const (
+	// minSearchQueryLn shorter query matches too many users and makes directory easy to dump
+	minSearchQueryLn     = 2
+	maxSearchQueryLn     = 50
+	defaultSearchResults = 10
+	maxSearchResults     = 20
+)
+
+// searchQuery trim query and check its length
+func searchQuery(query string) (string, error) {
+	query = strings.TrimSpace(query)
+	if n := utf8.RuneCountInString(query); n < minSearchQueryLn || n > maxSearchQueryLn {
+		return "", errors.New("query must be from 2 to 50 symbols")
+	}
+	return query, nil
+}
+
+// SearchUsers find users to share with by start of login or email or by login with typo.
+// Users who turned off discoverability are found only by exact login or email
+func (s *AuthService) SearchUsers(ctx context.Context, idUser, query string, limit int) ([]*domain.UserCard, error) {
+	const op = "service.SearchUsers"
+	if err := idValidation(idUser); err != nil {
+		return nil, wrapServiceCheck(op, err)
+	}
+	query, err := searchQuery(query)
+	if err != nil {
+		return nil, wrapServiceCheck(op, err)
+	}
+	if limit <= 0 {
+		limit = defaultSearchResults
+	}
+
+	repo, err := s.userRepo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return repo.SearchUsers(ctx, idUser, query, min(limit, maxSearchResults))
+}
+
+func (s *AuthService) UpdateDiscoverable(ctx context.Context, id string, discoverable bool) error {
+	const op = "service.UpdateDiscoverable"
+	if err := idValidation(id); err != nil {
+		return wrapServiceCheck(op, err)
+	}
+
+	repo, err := s.userRepo(ctx)
+	if err != nil {
+		return err
+	}
+	return repo.SetDiscoverable(ctx, id, discoverable)
+}
@@ -0,0 +1,24 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSearchQuery(t *testing.T) {
+	t.Parallel()
+
+	q, err := searchQuery("  al ")
+	require.NoError(t, err)
+	assert.Equal(t, "al", q)
+
+	_, err = searchQuery("пр")
+	assert.NoError(t, err, "length is in symbols")
+
+	for _, bad := range []string{"", " a ", string(make([]rune, maxSearchQueryLn+1))} {
+		_, err := searchQuery(bad)
+		assert.Error(t, err, bad)
+	}
+}
@@ -2,7 +2,11 @@ package service
 
 import (
 	"github.com/autumnterror/breezynotes/internal/auth/config"
+	"github.com/autumnterror/breezynotes/internal/auth/denylist"
 	"github.com/autumnterror/breezynotes/internal/auth/jwt"
+	"github.com/autumnterror/breezynotes/internal/auth/limiter"
+	"github.com/autumnterror/breezynotes/internal/auth/mail"
+	"github.com/autumnterror/breezynotes/internal/auth/pkg/oidc"
 	"github.com/autumnterror/breezynotes/internal/auth/repository"
 )
 
@@ -10,19 +14,32 @@ type AuthService struct {
 	tx     TxRunner
 	repos  repository.Provider
 	tokens jwt.WithConfigRepo
+	mail   mail.Sender
 	cfg    *config.Config
+	oidc   map[string]*oidc.Provider
+	// attempts counts failed logins
+	attempts limiter.Counter
+	// revoked tells gateway which families are revoked, so it denies their access tokens
+	revoked denylist.Publisher
 }
 
 func NewAuthService(
 	tx TxRunner,
 	repos repository.Provider,
 	tokens jwt.WithConfigRepo,
+	mail mail.Sender,
+	attempts limiter.Counter,
+	revoked denylist.Publisher,
 	cfg *config.Config,
 ) *AuthService {
 	return &AuthService{
-		tx:     tx,
-		repos:  repos,
-		tokens: tokens,
-		cfg:    cfg,
+		tx:       tx,
+		repos:    repos,
+		tokens:   tokens,
+		mail:     mail,
+		cfg:      cfg,
+		oidc:     newOidcProviders(cfg),
+		attempts: attempts,
+		revoked:  revoked,
 	}
 }
@@ -0,0 +1,108 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/autumnterror/breezynotes/internal/auth/domain"
+	"github.com/autumnterror/utils_go/pkg/utils/format"
+)
+
+const (
+	// sessionTouchInterval limit writes of last seen time: it is updated not often than once per interval
+	sessionTouchInterval = time.Minute
+	maxUserAgentLn       = 255
+	maxIpLn              = 50
+)
+
+// currentFamily return family of access token. Token must belong to user
+func (s *AuthService) currentFamily(ctx context.Context, idUser, at string) (string, error) {
+	const op = "service.currentFamily"
+
+	raw, repo, err := s.parseAccessToken(ctx, at)
+	if err != nil {
+		return "", err
+	}
+	claims, err := repo.GetClaimsFromToken(raw)
+	if err != nil {
+		return "", err
+	}
+	if claims.Id != idUser {
+		return "", format.Error(op, domain.ErrUnauthorized)
+	}
+
+	return claims.Family, nil
+}
+
+// ListSessions return active sessions of user. Session of access token at is marked as current
+func (s *AuthService) ListSessions(ctx context.Context, idUser, at string) ([]*domain.Session, error) {
+	const op = "service.ListSessions"
+	if err := idValidation(idUser); err != nil {
+		return nil, wrapServiceCheck(op, err)
+	}
+
+	current := ""
+	if !stringEmpty(at) {
+		f, err := s.currentFamily(ctx, idUser, at)
+		if err != nil {
+			return nil, err
+		}
+		current = f
+	}
+
+	repo, err := s.familyRepo(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	fs, err := repo.GetActiveFamiliesByUser(ctx, idUser, time.Now().UTC().Unix())
+	if err != nil {
+		return nil, err
+	}
+
+	ss := make([]*domain.Session, 0, len(fs))
+	for _, f := range fs {
+		ss = append(ss, domain.SessionFromFamily(f, current))
+	}
+
+	return ss, nil
+}
+
+// RevokeSession revoke session of user. Refresh token stops working immediately, access token verified
+// by gateway locally works until it expires
+func (s *AuthService) RevokeSession(ctx context.Context, idUser, idSession string) error {
+	const op = "service.RevokeSession"
+	if err := idValidation(idUser); err != nil {
+		return wrapServiceCheck(op, err)
+	}
+	if err := idValidation(idSession); err != nil {
+		return wrapServiceCheck(op, err)
+	}
+
+	repo, err := s.familyRepo(ctx)
+	if err != nil {
+		return err
+	}
+
+	f, err := repo.GetFamily(ctx, idSession)
+	if err != nil {
+		return err
+	}
+	// session of other user is not found for caller
+	if f.UserId != idUser {
+		return format.Error(op, domain.ErrNotFound)
+	}
+	if f.RevokedAt != 0 {
+		return nil
+	}
+
+	if err := repo.RevokeFamily(ctx, idSession, time.Now().UTC().Unix()); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil
+		}
+		return err
+	}
+	s.publishRevoked(ctx, idSession)
+	return nil
+}
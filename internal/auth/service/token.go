@@ -3,9 +3,13 @@ package service
 import (
 	"context"
 	"errors"
+	"time"
+
 	"github.com/autumnterror/breezynotes/internal/auth/domain"
 	"github.com/autumnterror/breezynotes/internal/auth/jwt"
+	"github.com/autumnterror/utils_go/pkg/log"
 	"github.com/autumnterror/utils_go/pkg/utils/format"
+	"github.com/autumnterror/utils_go/pkg/utils/uid"
 	jwtlib "github.com/golang-jwt/jwt/v5"
 )
 
@@ -45,6 +49,14 @@ func (s *AuthService) parseToken(ctx context.Context, token string) (*jwtlib.Tok
 		return nil, nil, err
 	}
 
+	claims, err := repo.GetClaimsFromToken(raw)
+	if err != nil {
+		return nil, nil, err
+	}
+	if _, err := s.activeFamily(ctx, claims); err != nil {
+		return nil, nil, err
+	}
+
 	return raw, repo, nil
 }
 
@@ -67,6 +79,312 @@ func (s *AuthService) parseAccessToken(ctx context.Context, token string) (*jwtl
 	return raw, repo, nil
 }
 
+// activeFamily return family of token if it is not revoked and belongs to user of token.
+// Tokens without family are treated as revoked
+func (s *AuthService) activeFamily(ctx context.Context, claims *domain.TokenClaims) (*domain.TokenFamily, error) {
+	const op = "service.activeFamily"
+	if claims.Family == "" {
+		return nil, format.Error(op, domain.ErrTokenRevoked)
+	}
+
+	repo, err := s.familyRepo(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	f, err := repo.GetFamily(ctx, claims.Family)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, format.Error(op, domain.ErrTokenRevoked)
+		}
+		return nil, err
+	}
+	if !familyValid(f, claims.Id) {
+		return nil, format.Error(op, domain.ErrTokenRevoked)
+	}
+
+	if now := time.Now().UTC().Unix(); now-f.LastSeenAt >= int64(sessionTouchInterval.Seconds()) {
+		if err := repo.TouchFamily(ctx, f.Id, now); err != nil {
+			log.Error(op, "touch session "+f.Id, err)
+		} else {
+			f.LastSeenAt = now
+		}
+	}
+
+	return f, nil
+}
+
+// familyValid family is not revoked and token of idUser can't use family of other user
+func familyValid(f *domain.TokenFamily, idUser string) bool {
+	return f.RevokedAt == 0 && f.UserId == idUser
+}
+
+// issueTokens start new family (session) for user and return access and refresh tokens of it
+func (s *AuthService) issueTokens(ctx context.Context, id, userAgent, ip string) (string, string, error) {
+	repoJwt, err := s.tokenRepo()
+	if err != nil {
+		return "", "", err
+	}
+	repo, err := s.familyRepo(ctx)
+	if err != nil {
+		return "", "", err
+	}
+
+	now := time.Now().UTC()
+	family, jti := uid.New(), uid.New()
+
+	if err := repo.CreateFamily(ctx, &domain.TokenFamily{
+		Id:         family,
+		UserId:     id,
+		CurrentJti: jti,
+		RotatedAt:  now.Unix(),
+		ExpiresAt:  now.Add(s.cfg.RefreshTokenLifeTime).Unix(),
+		UserAgent:  cutString(userAgent, maxUserAgentLn),
+		Ip:         cutString(ip, maxIpLn),
+		CreatedAt:  now.Unix(),
+	}); err != nil {
+		return "", "", err
+	}
+
+	at, err := repoJwt.GenerateTokenInFamily(id, domain.TokenTypeAccess, family, uid.New())
+	if err != nil {
+		return "", "", err
+	}
+	rt, err := repoJwt.GenerateTokenInFamily(id, domain.TokenTypeRefresh, family, jti)
+	if err != nil {
+		return "", "", err
+	}
+
+	return at, rt, nil
+}
+
+type refreshAction int
+
+const (
+	// refreshRotate token is current in family: issue new refresh token
+	refreshRotate refreshAction = iota
+	// refreshGrace token was rotated just now by parallel request: issue only access token
+	refreshGrace
+	// refreshReuse old token is used again: family is compromised
+	refreshReuse
+)
+
+func refreshDecision(f *domain.TokenFamily, jti string, now time.Time, grace time.Duration) refreshAction {
+	switch {
+	case jti == "":
+		return refreshReuse
+	case jti == f.CurrentJti:
+		return refreshRotate
+	case jti == f.PrevJti && now.Sub(time.Unix(f.RotatedAt, 0)) <= grace:
+		return refreshGrace
+	default:
+		return refreshReuse
+	}
+}
+
+// refresh rotate refresh token of family. Reuse of rotated token revoke whole family.
+// Refresh of returned tokens is empty when previous refresh token is used within grace period
+func (s *AuthService) refresh(ctx context.Context, rt string) (*domain.Tokens, error) {
+	const op = "service.refresh"
+
+	raw, repoJwt, err := s.parseToken(ctx, rt)
+	if err != nil {
+		return nil, err
+	}
+	claims, err := repoJwt.GetClaimsFromToken(raw)
+	if err != nil {
+		return nil, err
+	}
+	if claims.Type != domain.TokenTypeRefresh {
+		return nil, wrapServiceCheck(op, domain.ErrTokenWrongType)
+	}
+
+	repo, err := s.familyRepo(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// second attempt is needed only when parallel request rotated family between read and update
+	for attempt := 0; attempt < 2; attempt++ {
+		f, err := s.activeFamily(ctx, claims)
+		if err != nil {
+			return nil, err
+		}
+
+		now := time.Now().UTC()
+		res := &domain.Tokens{}
+
+		switch refreshDecision(f, claims.Jti, now, s.cfg.RefreshReuseGrace) {
+		case refreshReuse:
+			log.Warn(op, "refresh token reuse, revoke family "+claims.Family, domain.ErrTokenRevoked)
+			if err := repo.RevokeFamily(ctx, claims.Family, now.Unix()); err != nil {
+				return nil, err
+			}
+			s.publishRevoked(ctx, claims.Family)
+			return nil, format.Error(op, domain.ErrTokenRevoked)
+		case refreshRotate:
+			jti := uid.New()
+			if err := repo.RotateFamily(ctx, f.Id, claims.Jti, jti, now.Unix(), now.Add(s.cfg.RefreshTokenLifeTime).Unix()); err != nil {
+				if errors.Is(err, domain.ErrNotFound) {
+					continue
+				}
+				return nil, err
+			}
+			nrt, err := repoJwt.GenerateTokenInFamily(claims.Id, domain.TokenTypeRefresh, f.Id, jti)
+			if err != nil {
+				return nil, err
+			}
+			res.Refresh = domain.Token{Value: nrt, Exp: now.Add(s.cfg.RefreshTokenLifeTime)}
+		case refreshGrace:
+		}
+
+		at, err := repoJwt.GenerateTokenInFamily(claims.Id, domain.TokenTypeAccess, f.Id, uid.New())
+		if err != nil {
+			return nil, err
+		}
+		res.Access = domain.Token{Value: at, Exp: now.Add(s.cfg.AccessTokenLifeTime)}
+
+		return res, nil
+	}
+
+	return nil, format.Error(op, domain.ErrTokenRevoked)
+}
+
+// Logout revoke family of tokens. Expired or already revoked tokens are not error: nothing to revoke
+func (s *AuthService) Logout(ctx context.Context, at, rt string) error {
+	const op = "service.Logout"
+	if stringEmpty(at) && stringEmpty(rt) {
+		return wrapServiceCheck(op, errors.New("tokens are empty"))
+	}
+
+	repoJwt, err := s.tokenRepo()
+	if err != nil {
+		return err
+	}
+	repo, err := s.familyRepo(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, t := range []string{rt, at} {
+		if stringEmpty(t) {
+			continue
+		}
+		raw, err := repoJwt.VerifyToken(t)
+		if err != nil {
+			continue
+		}
+		claims, err := repoJwt.GetClaimsFromToken(raw)
+		if err != nil || claims.Family == "" {
+			continue
+		}
+
+		if err := repo.RevokeFamily(ctx, claims.Family, time.Now().UTC().Unix()); err != nil {
+			if errors.Is(err, domain.ErrNotFound) {
+				return nil
+			}
+			return err
+		}
+		s.publishRevoked(ctx, claims.Family)
+		return nil
+	}
+
+	return nil
+}
+
+// LogoutAll revoke all families of user, all his devices must login again
+func (s *AuthService) LogoutAll(ctx context.Context, idUser string) error {
+	const op = "service.LogoutAll"
+	if err := idValidation(idUser); err != nil {
+		return wrapServiceCheck(op, err)
+	}
+
+	repo, err := s.familyRepo(ctx)
+	if err != nil {
+		return err
+	}
+
+	ids, err := repo.RevokeFamiliesByUser(ctx, idUser, "", time.Now().UTC().Unix())
+	if err != nil {
+		return err
+	}
+	s.publishRevoked(ctx, ids...)
+	return nil
+}
+
+// publishRevoked tell gateway that families are revoked. They are revoked in db already, so error is only logged:
+// gateway accepts access tokens of them until they expire
+func (s *AuthService) publishRevoked(ctx context.Context, ids ...string) {
+	const op = "service.publishRevoked"
+
+	if err := s.revoked.Revoke(ctx, ids, s.cfg.AccessTokenLifeTime); err != nil {
+		log.Warn(op, "families are not denied in gateway", err)
+	}
+}
+
+// GetJWKS return public keys to verify tokens outside of auth
+func (s *AuthService) GetJWKS(ctx context.Context) ([]domain.JWK, error) {
+	const op = "service.GetJWKS"
+
+	repo, err := s.tokenRepo()
+	if err != nil {
+		return nil, wrapServiceCheck(op, err)
+	}
+	return repo.JWKS(), nil
+}
+
+// PurgeExpiredFamilies rm families which refresh tokens are expired, expired tokens from letters
+// not finished SSO logins and expired personal access tokens
+func (s *AuthService) PurgeExpiredFamilies(ctx context.Context, now time.Time) error {
+	repo, err := s.familyRepo(ctx)
+	if err != nil {
+		return err
+	}
+	repoAction, err := s.actionTokenRepo(ctx)
+	if err != nil {
+		return err
+	}
+	repoIdentity, err := s.identityRepo(ctx)
+	if err != nil {
+		return err
+	}
+	repoPat, err := s.patRepo(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err := repo.DeleteExpiredFamilies(ctx, now.Unix()); err != nil {
+		return err
+	}
+	if err := repoAction.DeleteExpiredActionTokens(ctx, now.Unix()); err != nil {
+		return err
+	}
+	if err := repoIdentity.DeleteExpiredOidcLogins(ctx, now.Unix()); err != nil {
+		return err
+	}
+	return repoPat.DeleteExpiredPats(ctx, now.Unix())
+}
+
+// RunFamilyCleaner purge expired families every cfg.TokenCleanInterval until ctx is done
+func (s *AuthService) RunFamilyCleaner(ctx context.Context) {
+	const op = "service.RunFamilyCleaner"
+
+	t := time.NewTicker(s.cfg.TokenCleanInterval)
+	defer t.Stop()
+
+	for {
+		if err := s.PurgeExpiredFamilies(ctx, time.Now().UTC()); err != nil {
+			log.Error(op, "purge token families", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+		}
+	}
+}
+
 //func (s *AuthService) GenerateAccessToken(ctx context.Context, id string) (*domain.Token, error) {
 //	const op = "service.GenerateAccessToken"
 //	if err := idValidation(id); err != nil {
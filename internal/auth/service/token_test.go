@@ -0,0 +1,43 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/autumnterror/breezynotes/internal/auth/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRefreshDecision(t *testing.T) {
+	now := time.Date(2025, 1, 31, 12, 0, 0, 0, time.UTC)
+	grace := 30 * time.Second
+	f := &domain.TokenFamily{
+		Id:         "family",
+		CurrentJti: "current",
+		PrevJti:    "prev",
+		RotatedAt:  now.Add(-10 * time.Second).Unix(),
+	}
+
+	assert.Equal(t, refreshRotate, refreshDecision(f, "current", now, grace))
+	assert.Equal(t, refreshGrace, refreshDecision(f, "prev", now, grace))
+	assert.Equal(t, refreshReuse, refreshDecision(f, "prev", now.Add(time.Minute), grace))
+	assert.Equal(t, refreshReuse, refreshDecision(f, "stolen", now, grace))
+	assert.Equal(t, refreshReuse, refreshDecision(f, "", now, grace))
+}
+
+func TestFamilyValid(t *testing.T) {
+	f := &domain.TokenFamily{Id: "family", UserId: "user"}
+
+	assert.True(t, familyValid(f, "user"))
+	assert.False(t, familyValid(f, "other"), "family of other user")
+	assert.False(t, familyValid(f, ""))
+
+	f.RevokedAt = 1
+	assert.False(t, familyValid(f, "user"))
+}
+
+func TestCutString(t *testing.T) {
+	assert.Equal(t, "Mozilla", cutString("Mozilla", 10))
+	assert.Equal(t, "Moz", cutString("Mozilla", 3))
+	assert.Equal(t, "при", cutString("привет", 3))
+}
@@ -0,0 +1,287 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base32"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/autumnterror/breezynotes/internal/auth/domain"
+	"github.com/autumnterror/breezynotes/internal/auth/pkg/totp"
+	"github.com/autumnterror/utils_go/pkg/log"
+	"github.com/autumnterror/utils_go/pkg/utils/format"
+)
+
+const (
+	totpIssuer = "BreezyNotes"
+	// totpSkew accept codes of one step before and after current for clock drift of phone
+	totpSkew = 1
+	// maxTotpAttempts wrong codes in row after which second factor is locked for totpLockTime
+	maxTotpAttempts    = 5
+	totpLockTime       = 5 * time.Minute
+	recoveryCodesCount = 10
+	recoveryCodeLn     = 10
+)
+
+var recoveryEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// generateRecoveryCodes return codes in form xxxxx-xxxxx
+func generateRecoveryCodes() ([]string, error) {
+	codes := make([]string, 0, recoveryCodesCount)
+	for i := 0; i < recoveryCodesCount; i++ {
+		b := make([]byte, 7)
+		if _, err := rand.Read(b); err != nil {
+			return nil, err
+		}
+		c := strings.ToLower(recoveryEncoding.EncodeToString(b))[:recoveryCodeLn]
+		codes = append(codes, c[:recoveryCodeLn/2]+"-"+c[recoveryCodeLn/2:])
+	}
+	return codes, nil
+}
+
+// normalizeRecoveryCode make code as it is stored: user can type it in any case, with or without dash
+func normalizeRecoveryCode(code string) string {
+	code = strings.ToLower(strings.TrimSpace(code))
+	return strings.NewReplacer("-", "", " ", "").Replace(code)
+}
+
+// SetupTotp generate new secret for user. 2FA is not enabled until ConfirmTotp
+func (s *AuthService) SetupTotp(ctx context.Context, idUser string) (*domain.TotpSetup, error) {
+	const op = "service.SetupTotp"
+	if err := idValidation(idUser); err != nil {
+		return nil, wrapServiceCheck(op, err)
+	}
+
+	repoUser, err := s.userRepo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	repo, err := s.twoFactorRepo(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	u, err := repoUser.GetInfo(ctx, idUser)
+	if err != nil {
+		return nil, err
+	}
+
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		return nil, format.Error(op, err)
+	}
+	if err := repo.SaveTotp(ctx, &domain.Totp{
+		UserId:    idUser,
+		Secret:    secret,
+		CreatedAt: time.Now().UTC().Unix(),
+	}); err != nil {
+		return nil, err
+	}
+
+	return &domain.TotpSetup{
+		Secret: secret,
+		Uri:    totp.URI(totpIssuer, u.Login, secret),
+	}, nil
+}
+
+// ConfirmTotp enable 2FA if code from app is right. Return recovery codes, they are shown only once
+func (s *AuthService) ConfirmTotp(ctx context.Context, idUser, code string) ([]string, error) {
+	const op = "service.ConfirmTotp"
+	if err := idValidation(idUser); err != nil {
+		return nil, wrapServiceCheck(op, err)
+	}
+
+	repo, err := s.twoFactorRepo(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	t, err := repo.GetTotp(ctx, idUser)
+	if err != nil {
+		return nil, err
+	}
+	if t.Enabled {
+		return nil, format.Error(op, domain.ErrAlreadyExists)
+	}
+
+	now := time.Now().UTC()
+	step, ok := totp.Validate(t.Secret, code, now, totpSkew)
+	if !ok {
+		return nil, format.Error(op, domain.ErrInvalidCode)
+	}
+
+	codes, err := generateRecoveryCodes()
+	if err != nil {
+		return nil, format.Error(op, err)
+	}
+	normalized := make([]string, 0, len(codes))
+	for _, c := range codes {
+		normalized = append(normalized, normalizeRecoveryCode(c))
+	}
+
+	if err := s.runInTx(ctx, op, func(ctx context.Context) error {
+		repo, err := s.twoFactorRepo(ctx)
+		if err != nil {
+			return err
+		}
+		if err := repo.EnableTotp(ctx, idUser, step, now.Unix()); err != nil {
+			return err
+		}
+		return repo.ReplaceRecoveryCodes(ctx, idUser, normalized)
+	}); err != nil {
+		return nil, err
+	}
+
+	return codes, nil
+}
+
+// DisableTotp turn off 2FA. Password and code from app or recovery code are required
+func (s *AuthService) DisableTotp(ctx context.Context, idUser, pw, code string) error {
+	const op = "service.DisableTotp"
+	if err := idValidation(idUser); err != nil {
+		return wrapServiceCheck(op, err)
+	}
+
+	repoAuth, err := s.authRepo(ctx)
+	if err != nil {
+		return err
+	}
+	repo, err := s.twoFactorRepo(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err := repoAuth.CheckPassword(ctx, idUser, pw); err != nil {
+		return err
+	}
+
+	t, err := repo.GetTotp(ctx, idUser)
+	if err != nil {
+		return err
+	}
+	if t.Enabled {
+		if err := s.checkSecondFactor(ctx, t, code); err != nil {
+			return err
+		}
+	}
+
+	return s.runInTx(ctx, op, func(ctx context.Context) error {
+		repo, err := s.twoFactorRepo(ctx)
+		if err != nil {
+			return err
+		}
+		return repo.DeleteTotp(ctx, idUser)
+	})
+}
+
+// checkSecondFactor accept code from app or recovery code. Every code can be used only once,
+// after maxTotpAttempts wrong codes in row user must wait totpLockTime
+func (s *AuthService) checkSecondFactor(ctx context.Context, t *domain.Totp, code string) error {
+	const op = "service.checkSecondFactor"
+
+	repo, err := s.twoFactorRepo(ctx)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now().UTC()
+	if t.FailedAttempts >= maxTotpAttempts && now.Sub(time.Unix(t.FailedAt, 0)) < totpLockTime {
+		return format.Error(op, domain.ErrTooManyAttempts)
+	}
+
+	if step, ok := totp.Validate(t.Secret, code, now, totpSkew); ok {
+		err = repo.UseTotpStep(ctx, t.UserId, step)
+	} else if rc := normalizeRecoveryCode(code); len(rc) == recoveryCodeLn {
+		err = repo.UseRecoveryCode(ctx, t.UserId, rc, now.Unix())
+	} else {
+		err = domain.ErrInvalidCode
+	}
+	if err == nil {
+		return nil
+	}
+	if !errors.Is(err, domain.ErrInvalidCode) {
+		return err
+	}
+
+	if err := repo.FailTotp(ctx, t.UserId, now.Unix()); err != nil {
+		log.Error(op, "count failed attempt", err)
+	}
+	return format.Error(op, domain.ErrInvalidCode)
+}
+
+// challenge return short-lived token which proves that password of user is checked
+func (s *AuthService) challenge(id string) (string, error) {
+	repo, err := s.tokenRepo()
+	if err != nil {
+		return "", err
+	}
+	return repo.GenerateTokenInFamily(id, domain.TokenTypeChallenge, "", "")
+}
+
+// VerifySecondFactor finish login with 2FA: exchange challenge from Auth and code to tokens
+func (s *AuthService) VerifySecondFactor(ctx context.Context, challenge, code, userAgent, ip string) (*domain.LoginResult, error) {
+	const op = "service.VerifySecondFactor"
+	if stringEmpty(challenge) {
+		return nil, wrapServiceCheck(op, errors.New("challenge is empty"))
+	}
+	if stringEmpty(code) {
+		return nil, wrapServiceCheck(op, errors.New("code is empty"))
+	}
+
+	repoJwt, err := s.tokenRepo()
+	if err != nil {
+		return nil, wrapServiceCheck(op, err)
+	}
+	raw, err := repoJwt.VerifyToken(challenge)
+	if err != nil {
+		return nil, err
+	}
+	claims, err := repoJwt.GetClaimsFromToken(raw)
+	if err != nil {
+		return nil, err
+	}
+	if claims.Type != domain.TokenTypeChallenge {
+		return nil, wrapServiceCheck(op, domain.ErrTokenWrongType)
+	}
+
+	repo, err := s.twoFactorRepo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	repoUser, err := s.userRepo(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// account can be disabled by admin or deletion after password was checked
+	user, err := repoUser.GetInfo(ctx, claims.Id)
+	if err != nil {
+		return nil, err
+	}
+	if user.Disabled {
+		return nil, format.Error(op, domain.ErrUserDisabled)
+	}
+
+	t, err := repo.GetTotp(ctx, claims.Id)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, format.Error(op, domain.ErrUnauthorized)
+		}
+		return nil, err
+	}
+	if !t.Enabled {
+		return nil, format.Error(op, domain.ErrUnauthorized)
+	}
+	if err := s.checkSecondFactor(ctx, t, code); err != nil {
+		return nil, err
+	}
+
+	at, rt, err := s.issueTokens(ctx, claims.Id, userAgent, ip)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.LoginResult{User: user, Access: at, Refresh: rt}, nil
+}
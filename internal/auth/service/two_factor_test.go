@@ -0,0 +1,25 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRecoveryCodes(t *testing.T) {
+	codes, err := generateRecoveryCodes()
+	assert.NoError(t, err)
+	assert.Len(t, codes, recoveryCodesCount)
+
+	seen := map[string]bool{}
+	for _, c := range codes {
+		assert.Len(t, c, recoveryCodeLn+1)
+		assert.Equal(t, "-", c[recoveryCodeLn/2:recoveryCodeLn/2+1])
+		assert.Len(t, normalizeRecoveryCode(c), recoveryCodeLn)
+		assert.False(t, seen[c])
+		seen[c] = true
+	}
+
+	assert.Equal(t, "abcdeqwert", normalizeRecoveryCode(" ABCDE-qwert "))
+	assert.Equal(t, "abcdeqwert", normalizeRecoveryCode("abcde qwert"))
+}
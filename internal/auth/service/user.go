@@ -3,6 +3,7 @@ package service
 import (
 	"context"
 	"errors"
+	"time"
 
 	"github.com/autumnterror/breezynotes/internal/auth/domain"
 	"github.com/autumnterror/utils_go/pkg/utils/validate"
@@ -21,7 +22,8 @@ func (s *AuthService) CreateAdmin(ctx context.Context) error {
 	})
 }
 
-func (s *AuthService) UpdatePassword(ctx context.Context, id, oldPassword string, newPassword string) error {
+// UpdatePassword change password of user. If revokeOthers all sessions except session of access token at are revoked
+func (s *AuthService) UpdatePassword(ctx context.Context, id, oldPassword string, newPassword string, revokeOthers bool, at string) error {
 	const op = "service.UpdatePassword"
 	if err := idValidation(id); err != nil {
 		return wrapServiceCheck(op, err)
@@ -30,7 +32,18 @@ func (s *AuthService) UpdatePassword(ctx context.Context, id, oldPassword string
 	if !validate.Password(newPassword) {
 		return wrapServiceCheck(op, errors.New("new password not in policy"))
 	}
-	return s.runInTx(ctx, op, func(ctx context.Context) error {
+
+	current := ""
+	if revokeOthers && !stringEmpty(at) {
+		f, err := s.currentFamily(ctx, id, at)
+		if err != nil {
+			return err
+		}
+		current = f
+	}
+
+	var revoked []string
+	if err := s.runInTx(ctx, op, func(ctx context.Context) error {
 		repo, err := s.userRepo(ctx)
 		if err != nil {
 			return err
@@ -40,16 +53,28 @@ func (s *AuthService) UpdatePassword(ctx context.Context, id, oldPassword string
 			return err
 		}
 
-		info, err := repo.GetInfo(ctx, id)
-		if err != nil {
+		if err := repoAuth.CheckPassword(ctx, id, oldPassword); err != nil {
+			return domain.ErrNotFound
+		}
+
+		if err := repo.UpdatePassword(ctx, id, newPassword); err != nil {
 			return err
 		}
-		if _, err := repoAuth.Authentication(ctx, "", info.Login, oldPassword); err != nil {
-			return domain.ErrNotFound
+		if !revokeOthers {
+			return nil
 		}
 
-		return repo.UpdatePassword(ctx, id, newPassword)
-	})
+		repoFamily, err := s.familyRepo(ctx)
+		if err != nil {
+			return err
+		}
+		revoked, err = repoFamily.RevokeFamiliesByUser(ctx, id, current, time.Now().UTC().Unix())
+		return err
+	}); err != nil {
+		return err
+	}
+	s.publishRevoked(ctx, revoked...)
+	return nil
 }
 func (s *AuthService) UpdatePhoto(ctx context.Context, id, np string) error {
 	const op = "service.UpdatePhoto"
@@ -77,13 +102,18 @@ func (s *AuthService) UpdateEmail(ctx context.Context, id, email string) error {
 		return wrapServiceCheck(op, errors.New("new email is empty"))
 	}
 
-	return s.runInTx(ctx, op, func(ctx context.Context) error {
+	if err := s.runInTx(ctx, op, func(ctx context.Context) error {
 		repo, err := s.userRepo(ctx)
 		if err != nil {
 			return err
 		}
 		return repo.UpdateEmail(ctx, id, email)
-	})
+	}); err != nil {
+		return err
+	}
+
+	s.sendVerificationAsync(ctx, id)
+	return nil
 }
 func (s *AuthService) UpdateAbout(ctx context.Context, id, about string) error {
 	const op = "service.UpdateAbout"
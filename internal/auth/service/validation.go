@@ -52,3 +52,12 @@ func userValidation(u *domain.User) error {
 
 	return nil
 }
+
+// cutString cut s to n runes
+func cutString(s string, n int) string {
+	r := []rune(s)
+	if len(r) <= n {
+		return s
+	}
+	return string(r[:n])
+}
@@ -0,0 +1,173 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/autumnterror/breezynotes/internal/auth/domain"
+)
+
+func workspaceRoleValidation(role string) error {
+	switch role {
+	case domain.WorkspaceRoleEditor, domain.WorkspaceRoleReader:
+		return nil
+	case domain.WorkspaceRoleOwner:
+		return errors.New("workspace can have only one owner")
+	default:
+		return errors.New("role undefined")
+	}
+}
+
+func (s *AuthService) CreateWorkspace(ctx context.Context, id, title, idUser string) error {
+	const op = "service.CreateWorkspace"
+	if err := idValidation(id); err != nil {
+		return wrapServiceCheck(op, err)
+	}
+	if err := idValidation(idUser); err != nil {
+		return wrapServiceCheck(op, err)
+	}
+	if stringEmpty(title) {
+		return wrapServiceCheck(op, errors.New("title is empty"))
+	}
+
+	return s.runInTx(ctx, op, func(ctx context.Context) error {
+		repo, err := s.workspaceRepo(ctx)
+		if err != nil {
+			return err
+		}
+		return repo.CreateWorkspace(ctx, &domain.Workspace{
+			Id:      id,
+			Title:   title,
+			OwnerId: idUser,
+		})
+	})
+}
+
+func (s *AuthService) DeleteWorkspace(ctx context.Context, id, idUser string) error {
+	const op = "service.DeleteWorkspace"
+	if err := idValidation(id); err != nil {
+		return wrapServiceCheck(op, err)
+	}
+	if err := idValidation(idUser); err != nil {
+		return wrapServiceCheck(op, err)
+	}
+
+	return s.runInTx(ctx, op, func(ctx context.Context) error {
+		repo, err := s.workspaceRepo(ctx)
+		if err != nil {
+			return err
+		}
+		w, err := repo.GetWorkspace(ctx, id)
+		if err != nil {
+			return err
+		}
+		if w.OwnerId != idUser {
+			return domain.ErrUnauthorized
+		}
+		return repo.DeleteWorkspace(ctx, id)
+	})
+}
+
+func (s *AuthService) GetWorkspacesByUser(ctx context.Context, idUser string) ([]*domain.Workspace, error) {
+	const op = "service.GetWorkspacesByUser"
+	if err := idValidation(idUser); err != nil {
+		return nil, wrapServiceCheck(op, err)
+	}
+
+	repo, err := s.workspaceRepo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return repo.GetWorkspacesByUser(ctx, idUser)
+}
+
+// GetWorkspaceMembers available only for members of workspace
+func (s *AuthService) GetWorkspaceMembers(ctx context.Context, id, idUser string) ([]*domain.WorkspaceMember, error) {
+	const op = "service.GetWorkspaceMembers"
+	if err := idValidation(id); err != nil {
+		return nil, wrapServiceCheck(op, err)
+	}
+	if err := idValidation(idUser); err != nil {
+		return nil, wrapServiceCheck(op, err)
+	}
+
+	repo, err := s.workspaceRepo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := repo.GetRole(ctx, id, idUser); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, domain.ErrUnauthorized
+		}
+		return nil, err
+	}
+
+	return repo.GetMembers(ctx, id)
+}
+
+// AddWorkspaceMember add member or change his role. Only owner can manage members
+func (s *AuthService) AddWorkspaceMember(ctx context.Context, id, idUser, idMember, role string) error {
+	const op = "service.AddWorkspaceMember"
+	if err := idValidation(id); err != nil {
+		return wrapServiceCheck(op, err)
+	}
+	if err := idValidation(idUser); err != nil {
+		return wrapServiceCheck(op, err)
+	}
+	if err := idValidation(idMember); err != nil {
+		return wrapServiceCheck(op, err)
+	}
+	if err := workspaceRoleValidation(role); err != nil {
+		return wrapServiceCheck(op, err)
+	}
+
+	return s.runInTx(ctx, op, func(ctx context.Context) error {
+		repo, err := s.workspaceRepo(ctx)
+		if err != nil {
+			return err
+		}
+		w, err := repo.GetWorkspace(ctx, id)
+		if err != nil {
+			return err
+		}
+		if w.OwnerId != idUser {
+			return domain.ErrUnauthorized
+		}
+		if w.OwnerId == idMember {
+			return wrapServiceCheck(op, errors.New("can't change role of owner"))
+		}
+		return repo.SetMember(ctx, id, idMember, role)
+	})
+}
+
+// RemoveWorkspaceMember owner can remove anyone except himself, member can leave workspace
+func (s *AuthService) RemoveWorkspaceMember(ctx context.Context, id, idUser, idMember string) error {
+	const op = "service.RemoveWorkspaceMember"
+	if err := idValidation(id); err != nil {
+		return wrapServiceCheck(op, err)
+	}
+	if err := idValidation(idUser); err != nil {
+		return wrapServiceCheck(op, err)
+	}
+	if err := idValidation(idMember); err != nil {
+		return wrapServiceCheck(op, err)
+	}
+
+	return s.runInTx(ctx, op, func(ctx context.Context) error {
+		repo, err := s.workspaceRepo(ctx)
+		if err != nil {
+			return err
+		}
+		w, err := repo.GetWorkspace(ctx, id)
+		if err != nil {
+			return err
+		}
+		if w.OwnerId == idMember {
+			return wrapServiceCheck(op, errors.New("owner can't leave workspace, delete it instead"))
+		}
+		if w.OwnerId != idUser && idUser != idMember {
+			return domain.ErrUnauthorized
+		}
+		return repo.RemoveMember(ctx, id, idMember)
+	})
+}
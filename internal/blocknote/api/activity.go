@@ -0,0 +1,42 @@
+package api
+
+import (
+	"context"
+
+	brzrpc "github.com/autumnterror/breezynotes/api/proto/gen"
+	"github.com/autumnterror/breezynotes/internal/blocknote/domain"
+)
+
+func (s *ServerAPI) GetNoteActivity(ctx context.Context, req *brzrpc.NoteActivityRequest) (*brzrpc.Activities, error) {
+	const op = "block.note.grpc.GetNoteActivity"
+
+	ctx, done := context.WithTimeout(ctx, waitTime)
+	defer done()
+
+	res, err := handleCRUDResponse(ctx, op, func() (any, error) {
+		return s.service.GetNoteActivity(ctx, req.GetNoteId(), req.GetUserId(), int(req.GetStart()), int(req.GetEnd()))
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return domain.FromActivitiesDb(res.(*domain.Activities)), nil
+}
+
+func (s *ServerAPI) GetActivityFeed(ctx context.Context, req *brzrpc.ActivityFeedRequest) (*brzrpc.Activities, error) {
+	const op = "block.note.grpc.GetActivityFeed"
+
+	ctx, done := context.WithTimeout(ctx, waitTime)
+	defer done()
+
+	res, err := handleCRUDResponse(ctx, op, func() (any, error) {
+		return s.service.GetActivityFeed(ctx, req.GetUserId(), int(req.GetLimit()))
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return domain.FromActivitiesDb(res.(*domain.Activities)), nil
+}
@@ -26,6 +26,9 @@ func New(
 		grpc.KeepaliveParams(keepalive.ServerParameters{
 			MaxConnectionIdle: 0,
 		}),
+		grpc.MaxRecvMsgSize(maxRecvMsgSize),
+		grpc.ChainUnaryInterceptor(workspaceRolesUnary),
+		grpc.ChainStreamInterceptor(workspaceRolesStream),
 	)
 	Register(s, noteAPI)
 
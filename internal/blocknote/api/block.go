@@ -179,3 +179,37 @@ func (s *ServerAPI) GetBlockAsFirst(ctx context.Context, req *brzrpc.BlockId) (*
 	//return &brzrpc.StringResponse{Value: res.(string)}, nil
 	return nil, nil
 }
+
+func (s *ServerAPI) GetDeletedBlocks(ctx context.Context, req *brzrpc.UserNoteId) (*brzrpc.DeletedBlocks, error) {
+	const op = "block.note.grpc.GetDeletedBlocks"
+
+	ctx, done := context.WithTimeout(ctx, waitTime)
+	defer done()
+
+	res, err := handleCRUDResponse(ctx, op, func() (any, error) {
+		return s.service.GetDeletedBlocks(ctx, req.GetNoteId(), req.GetUserId())
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return domain.FromDeletedBlocksDb(res.(*domain.DeletedBlocks)), nil
+}
+
+func (s *ServerAPI) RestoreBlock(ctx context.Context, req *brzrpc.NoteBlockUserId) (*emptypb.Empty, error) {
+	const op = "block.note.grpc.RestoreBlock"
+
+	ctx, done := context.WithTimeout(ctx, waitTime)
+	defer done()
+
+	_, err := handleCRUDResponse(ctx, op, func() (any, error) {
+		return nil, s.service.RestoreBlock(ctx, req.GetNoteId(), req.GetBlockId(), req.GetUserId())
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,104 @@
+package api
+
+import (
+	"context"
+
+	brzrpc "github.com/autumnterror/breezynotes/api/proto/gen"
+	"github.com/autumnterror/breezynotes/internal/blocknote/domain"
+
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+func (s *ServerAPI) CreateComment(ctx context.Context, req *brzrpc.CreateCommentRequest) (*emptypb.Empty, error) {
+	const op = "block.note.grpc.CreateComment"
+
+	ctx, done := context.WithTimeout(ctx, waitTime)
+	defer done()
+
+	_, err := handleCRUDResponse(ctx, op, func() (any, error) {
+		return nil, s.service.CreateComment(ctx, &domain.Comment{
+			Id:       req.GetId(),
+			NoteId:   req.GetNoteId(),
+			BlockId:  req.GetBlockId(),
+			ThreadId: req.GetThreadId(),
+			Author:   req.GetUserId(),
+			Text:     req.GetText(),
+			Mentions: req.GetMentions(),
+			Anchor:   domain.ToTextRangeDb(req.GetAnchor()),
+		})
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+func (s *ServerAPI) GetComments(ctx context.Context, req *brzrpc.UserNoteId) (*brzrpc.Comments, error) {
+	const op = "block.note.grpc.GetComments"
+
+	ctx, done := context.WithTimeout(ctx, waitTime)
+	defer done()
+
+	res, err := handleCRUDResponse(ctx, op, func() (any, error) {
+		return s.service.GetComments(ctx, req.GetNoteId(), req.GetUserId())
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return domain.FromCommentsDb(res.(*domain.Comments)), nil
+}
+
+func (s *ServerAPI) UpdateComment(ctx context.Context, req *brzrpc.UpdateCommentRequest) (*emptypb.Empty, error) {
+	const op = "block.note.grpc.UpdateComment"
+
+	ctx, done := context.WithTimeout(ctx, waitTime)
+	defer done()
+
+	_, err := handleCRUDResponse(ctx, op, func() (any, error) {
+		return nil, s.service.UpdateComment(ctx, req.GetId(), req.GetUserId(), req.GetText(), req.GetMentions())
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+func (s *ServerAPI) DeleteComment(ctx context.Context, req *brzrpc.UserCommentId) (*emptypb.Empty, error) {
+	const op = "block.note.grpc.DeleteComment"
+
+	ctx, done := context.WithTimeout(ctx, waitTime)
+	defer done()
+
+	_, err := handleCRUDResponse(ctx, op, func() (any, error) {
+		return nil, s.service.DeleteComment(ctx, req.GetCommentId(), req.GetUserId())
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+func (s *ServerAPI) ResolveThread(ctx context.Context, req *brzrpc.ResolveThreadRequest) (*emptypb.Empty, error) {
+	const op = "block.note.grpc.ResolveThread"
+
+	ctx, done := context.WithTimeout(ctx, waitTime)
+	defer done()
+
+	_, err := handleCRUDResponse(ctx, op, func() (any, error) {
+		return nil, s.service.ResolveThread(ctx, req.GetThreadId(), req.GetUserId(), req.GetResolved())
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,79 @@
+package api
+
+import (
+	"context"
+	"io"
+
+	brzrpc "github.com/autumnterror/breezynotes/api/proto/gen"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// exportChunk max size of data in one part
+const exportChunk = 1 << 20
+
+func (s *ServerAPI) ExportNote(req *brzrpc.ExportNoteRequest, stream brzrpc.BlockNoteService_ExportNoteServer) error {
+	const op = "block.note.grpc.ExportNote"
+
+	ctx, done := context.WithTimeout(stream.Context(), exportTime)
+	defer done()
+
+	w := partWriter{send: stream.Send}
+	if err := s.service.ExportNote(ctx, req.GetNoteId(), req.GetUserId(), req.GetFormat(), req.GetFiles(), w); err != nil {
+		return exportError(ctx, op, err)
+	}
+	return nil
+}
+
+func (s *ServerAPI) ExportCollection(req *brzrpc.ExportCollectionRequest, stream brzrpc.BlockNoteService_ExportCollectionServer) error {
+	const op = "block.note.grpc.ExportCollection"
+
+	ctx, done := context.WithTimeout(stream.Context(), exportTime)
+	defer done()
+
+	a := partArchive{send: stream.Send}
+	if err := s.service.ExportCollection(ctx, req.GetAuthorId(), req.GetUserId(), req.GetAuthorName(), req.GetFormat(), a); err != nil {
+		return exportError(ctx, op, err)
+	}
+	return nil
+}
+
+// exportError is status of failed export. Export is streamed, so it isn't run by handleCRUDResponse
+func exportError(ctx context.Context, op string, err error) error {
+	if ctx.Err() != nil {
+		return status.Error(codes.DeadlineExceeded, "Context dead")
+	}
+	if _, ok := status.FromError(err); ok {
+		// error of stream
+		return err
+	}
+	return statusError(op, err)
+}
+
+// partWriter send written data as parts of file with name, big writes are split to chunks
+type partWriter struct {
+	send func(*brzrpc.ExportPart) error
+	name string
+}
+
+func (w partWriter) Write(p []byte) (int, error) {
+	for i := 0; i < len(p); i += exportChunk {
+		if err := w.send(&brzrpc.ExportPart{Name: w.name, Data: p[i:min(i+exportChunk, len(p))]}); err != nil {
+			return i, err
+		}
+	}
+	return len(p), nil
+}
+
+// partArchive send files of archive as named parts, stored files are sent by src
+type partArchive struct {
+	send func(*brzrpc.ExportPart) error
+}
+
+func (a partArchive) Create(name string) (io.Writer, error) {
+	return partWriter{send: a.send, name: name}, nil
+}
+
+func (a partArchive) File(name, src string) error {
+	return a.send(&brzrpc.ExportPart{Name: name, File: src})
+}
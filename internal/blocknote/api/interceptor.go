@@ -0,0 +1,39 @@
+package api
+
+import (
+	"context"
+
+	"github.com/autumnterror/breezynotes/internal/blocknote/domain"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// workspaceRolesToContext move workspace membership sent by gateway from metadata into context
+func workspaceRolesToContext(ctx context.Context) context.Context {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ctx
+	}
+	vals := md.Get(domain.WorkspaceRolesMD)
+	if len(vals) == 0 {
+		return ctx
+	}
+	return domain.WithWorkspaceRoles(ctx, domain.ParseWorkspaceRoles(vals[0]))
+}
+
+func workspaceRolesUnary(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	return handler(workspaceRolesToContext(ctx), req)
+}
+
+type ctxStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *ctxStream) Context() context.Context {
+	return s.ctx
+}
+
+func workspaceRolesStream(srv any, ss grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	return handler(srv, &ctxStream{ServerStream: ss, ctx: workspaceRolesToContext(ss.Context())})
+}
@@ -40,14 +40,14 @@ func (s *ServerAPI) GetNote(ctx context.Context, req *brzrpc.UserNoteId) (*brzrp
 	return domain.FromNoteWithBlocksDb(res.(*domain.NoteWithBlocks)), nil
 }
 
-func (s *ServerAPI) GetAllNotes(ctx context.Context, req *brzrpc.UserId) (*brzrpc.NoteParts, error) {
+func (s *ServerAPI) GetAllNotes(ctx context.Context, req *brzrpc.UserWorkspaceId) (*brzrpc.NoteParts, error) {
 	const op = "block.note.grpc.GetAllNotes"
 
 	ctx, done := context.WithTimeout(ctx, waitTime)
 	defer done()
 
 	res, err := handleCRUDResponse(ctx, op, func() (any, error) {
-		return s.service.GetNoteListByUser(ctx, req.GetUserId())
+		return s.service.GetNoteListByUser(ctx, req.GetUserId(), req.GetWorkspaceId())
 	})
 
 	if err != nil {
@@ -80,7 +80,7 @@ func (s *ServerAPI) Search(req *brzrpc.SearchRequest, stream brzrpc.BlockNoteSer
 	ctx, done := context.WithTimeout(stream.Context(), waitTime)
 	defer done()
 
-	chn, err := s.service.Search(ctx, req.GetUserId(), req.GetPrompt())
+	chn, err := s.service.Search(ctx, req.GetUserId(), req.GetPrompt(), req.GetWorkspaceId())
 	if err != nil {
 		return err
 	}
@@ -216,3 +216,156 @@ func (s *ServerAPI) BlogNote(ctx context.Context, req *brzrpc.UserNoteId) (*empt
 // 	}
 // 	return nil, nil
 // }
+
+func (s *ServerAPI) GetUserStats(ctx context.Context, req *brzrpc.UserId) (*brzrpc.UserStats, error) {
+	const op = "block.note.grpc.GetUserStats"
+
+	ctx, done := context.WithTimeout(ctx, waitTime)
+	defer done()
+
+	res, err := handleCRUDResponse(ctx, op, func() (any, error) {
+		return s.service.UserStats(ctx, req.GetUserId())
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return domain.FromUserStatsDb(res.(*domain.UserStats)), nil
+}
+
+func (s *ServerAPI) RemoveUserFromNotes(ctx context.Context, req *brzrpc.UserId) (*emptypb.Empty, error) {
+	const op = "block.note.grpc.RemoveUserFromNotes"
+
+	ctx, done := context.WithTimeout(ctx, waitTime)
+	defer done()
+
+	_, err := handleCRUDResponse(ctx, op, func() (any, error) {
+		return nil, s.service.RemoveUserFromNotes(ctx, req.GetUserId())
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+func (s *ServerAPI) GetUserFiles(ctx context.Context, req *brzrpc.UserId) (*brzrpc.Strings, error) {
+	const op = "block.note.grpc.GetUserFiles"
+
+	ctx, done := context.WithTimeout(ctx, waitTime)
+	defer done()
+
+	res, err := handleCRUDResponse(ctx, op, func() (any, error) {
+		return s.service.UserFiles(ctx, req.GetUserId())
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &brzrpc.Strings{Values: res.([]string)}, nil
+}
+
+func (s *ServerAPI) RenderNote(ctx context.Context, req *brzrpc.RenderNoteRequest) (*brzrpc.String, error) {
+	const op = "block.note.grpc.RenderNote"
+
+	ctx, done := context.WithTimeout(ctx, waitTime)
+	defer done()
+
+	res, err := handleCRUDResponse(ctx, op, func() (any, error) {
+		return s.service.RenderNote(ctx, req.GetNote(), req.GetFormat())
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &brzrpc.String{Value: res.(string)}, nil
+}
+
+func (s *ServerAPI) ImportNote(ctx context.Context, req *brzrpc.ImportNoteRequest) (*brzrpc.ImportReport, error) {
+	const op = "block.note.grpc.ImportNote"
+
+	ctx, done := context.WithTimeout(ctx, waitTime)
+	defer done()
+
+	res, err := handleCRUDResponse(ctx, op, func() (any, error) {
+		return s.service.ImportNote(ctx, req.GetUserId(), domain.ToNoteWithBlocksDb(req.GetNote()), req.GetTrash(), req.GetTags(), req.GetNewId(), req.GetHtml())
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return domain.FromImportReportDb(res.(*domain.ImportReport)), nil
+}
+
+func (s *ServerAPI) ConvertMarkdown(ctx context.Context, req *brzrpc.String) (*brzrpc.Blocks, error) {
+	const op = "block.note.grpc.ConvertMarkdown"
+
+	ctx, done := context.WithTimeout(ctx, waitTime)
+	defer done()
+
+	res, err := handleCRUDResponse(ctx, op, func() (any, error) {
+		return s.service.ConvertMarkdown(ctx, req.GetValue())
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return res.(*brzrpc.Blocks), nil
+}
+
+func (s *ServerAPI) ConvertDocument(ctx context.Context, req *brzrpc.String) (*brzrpc.NoteWithBlocks, error) {
+	const op = "block.note.grpc.ConvertDocument"
+
+	ctx, done := context.WithTimeout(ctx, waitTime)
+	defer done()
+
+	res, err := handleCRUDResponse(ctx, op, func() (any, error) {
+		return s.service.ConvertDocument(ctx, req.GetValue())
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return res.(*brzrpc.NoteWithBlocks), nil
+}
+
+func (s *ServerAPI) GetDocumentSchema(ctx context.Context, req *emptypb.Empty) (*brzrpc.String, error) {
+	const op = "block.note.grpc.GetDocumentSchema"
+
+	ctx, done := context.WithTimeout(ctx, waitTime)
+	defer done()
+
+	res, err := handleCRUDResponse(ctx, op, func() (any, error) {
+		return s.service.DocumentSchema(ctx)
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &brzrpc.String{Value: res.(string)}, nil
+}
+
+func (s *ServerAPI) ConvertHTML(ctx context.Context, req *brzrpc.ConvertHTMLRequest) (*brzrpc.Blocks, error) {
+	const op = "block.note.grpc.ConvertHTML"
+
+	ctx, done := context.WithTimeout(ctx, waitTime)
+	defer done()
+
+	res, err := handleCRUDResponse(ctx, op, func() (any, error) {
+		return s.service.ConvertHTML(ctx, req.GetHtml(), req.GetNoteId(), req.GetUserId(), int(req.GetPos()))
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return res.(*brzrpc.Blocks), nil
+}
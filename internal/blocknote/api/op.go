@@ -25,21 +25,7 @@ func handleCRUDResponse(ctx context.Context, op string, action func() (any, erro
 		return nil, status.Error(codes.DeadlineExceeded, "Context dead")
 	case r := <-res:
 		if r.err != nil {
-			switch {
-			case errors.Is(r.err, domain.ErrUnauthorized):
-				return nil, status.Error(codes.Unauthenticated, r.err.Error())
-			case errors.Is(r.err, domain.ErrNotFound):
-				return nil, status.Error(codes.NotFound, r.err.Error())
-			case errors.Is(r.err, domain.ErrTypeNotDefined):
-				return nil, status.Error(codes.FailedPrecondition, r.err.Error())
-			case errors.Is(r.err, domain.ErrAlreadyUsed):
-				return nil, status.Error(codes.PermissionDenied, r.err.Error())
-			case errors.Is(r.err, domain.ErrBadRequest), errors.Is(r.err, service.ErrBadServiceCheck):
-				return nil, status.Error(codes.InvalidArgument, r.err.Error())
-			default:
-				log.Error(op, "", r.err)
-				return nil, status.Error(codes.Internal, "check logs")
-			}
+			return nil, statusError(op, r.err)
 		}
 		if r.res != nil {
 			return r.res, nil
@@ -47,3 +33,22 @@ func handleCRUDResponse(ctx context.Context, op string, action func() (any, erro
 		return nil, nil
 	}
 }
+
+// statusError convert error of service to grpc status
+func statusError(op string, err error) error {
+	switch {
+	case errors.Is(err, domain.ErrUnauthorized):
+		return status.Error(codes.Unauthenticated, err.Error())
+	case errors.Is(err, domain.ErrNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, domain.ErrTypeNotDefined):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, domain.ErrAlreadyUsed):
+		return status.Error(codes.PermissionDenied, err.Error())
+	case errors.Is(err, domain.ErrBadRequest), errors.Is(err, service.ErrBadServiceCheck):
+		return status.Error(codes.InvalidArgument, err.Error())
+	default:
+		log.Error(op, "", err)
+		return status.Error(codes.Internal, "check logs")
+	}
+}
@@ -24,4 +24,8 @@ func Register(
 
 const (
 	waitTime = 3 * time.Second
+	// exportTime is longer, export renders all blocks of notes
+	exportTime = time.Minute
+	// maxRecvMsgSize is bigger than default 4MB, request of export has pictures of note
+	maxRecvMsgSize = 64 << 20
 )
@@ -117,14 +117,14 @@ func (s *ServerAPI) DeleteTags(ctx context.Context, req *brzrpc.UserId) (*emptyp
 	return nil, nil
 }
 
-func (s *ServerAPI) GetTagsByUser(ctx context.Context, req *brzrpc.UserId) (*brzrpc.Tags, error) {
+func (s *ServerAPI) GetTagsByUser(ctx context.Context, req *brzrpc.UserWorkspaceId) (*brzrpc.Tags, error) {
 	const op = "grpc.GetTagsByUser"
 
 	ctx, done := context.WithTimeout(ctx, waitTime)
 	defer done()
 
 	res, err := handleCRUDResponse(ctx, op, func() (any, error) {
-		r, err := s.service.GetAllByIdTag(ctx, req.GetUserId())
+		r, err := s.service.GetAllByIdTag(ctx, req.GetUserId(), req.GetWorkspaceId())
 		if err != nil {
 			return nil, err
 		}
@@ -179,3 +179,20 @@ func (s *ServerAPI) GetPinnedTagsByUser(ctx context.Context, req *brzrpc.UserId)
 //
 //	return res.(*brzrpc.Tag), nil
 //}
+
+func (s *ServerAPI) ImportTags(ctx context.Context, req *brzrpc.ImportTagsRequest) (*brzrpc.ImportReport, error) {
+	const op = "grpc.ImportTags"
+
+	ctx, done := context.WithTimeout(ctx, waitTime)
+	defer done()
+
+	res, err := handleCRUDResponse(ctx, op, func() (any, error) {
+		return s.service.ImportTags(ctx, req.GetUserId(), domain.ToTagsDb(&brzrpc.Tags{Items: req.GetTags()}).Tgs)
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return domain.FromImportReportDb(res.(*domain.ImportReport)), nil
+}
@@ -107,3 +107,53 @@ func (s *ServerAPI) GetNotesFromTrash(ctx context.Context, req *brzrpc.UserId) (
 
 	return domain.FromNotePartsDb(res.(*domain.NoteParts)), nil
 }
+
+func (s *ServerAPI) PurgeNoteFromTrash(ctx context.Context, req *brzrpc.UserNoteId) (*emptypb.Empty, error) {
+	const op = "block.note.grpc.PurgeNoteFromTrash"
+
+	ctx, done := context.WithTimeout(ctx, waitTime)
+	defer done()
+
+	_, err := handleCRUDResponse(ctx, op, func() (any, error) {
+		return nil, s.service.PurgeFromTrash(ctx, req.GetNoteId(), req.GetUserId())
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+func (s *ServerAPI) SetTrashRetention(ctx context.Context, req *brzrpc.TrashRetentionRequest) (*emptypb.Empty, error) {
+	const op = "block.note.grpc.SetTrashRetention"
+
+	ctx, done := context.WithTimeout(ctx, waitTime)
+	defer done()
+
+	_, err := handleCRUDResponse(ctx, op, func() (any, error) {
+		return nil, s.service.SetTrashRetention(ctx, req.GetUserId(), int(req.GetDays()))
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+func (s *ServerAPI) GetTrashRetention(ctx context.Context, req *brzrpc.UserId) (*brzrpc.TrashRetention, error) {
+	const op = "block.note.grpc.GetTrashRetention"
+
+	ctx, done := context.WithTimeout(ctx, waitTime)
+	defer done()
+
+	res, err := handleCRUDResponse(ctx, op, func() (any, error) {
+		return s.service.GetTrashRetention(ctx, req.GetUserId())
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &brzrpc.TrashRetention{Days: int32(res.(int))}, nil
+}
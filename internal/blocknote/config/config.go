@@ -5,7 +5,10 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"time"
 
+	"github.com/autumnterror/breezynotes/internal/blocknote/domain"
+	"github.com/autumnterror/breezynotes/internal/blocknote/pkg/render/pdf"
 	"github.com/autumnterror/utils_go/pkg/utils/format"
 	"github.com/joho/godotenv"
 	"github.com/spf13/viper"
@@ -14,6 +17,15 @@ import (
 type Config struct {
 	Uri  string
 	Port int
+	// TrashRetention how long note stays in trash if user did not set his own retention
+	TrashRetention time.Duration
+	// TrashPurgeInterval how often expired notes are removed from trash
+	TrashPurgeInterval time.Duration
+	// ActivityRetention how long events of notes activity are kept
+	ActivityRetention time.Duration
+	// PdfFont and PdfMonoFont are TrueType fonts of exported pdf, nil is standard font which has only latin chars
+	PdfFont     *pdf.Font
+	PdfMonoFont *pdf.Font
 }
 
 // MustSetup return config and panic if error
@@ -45,6 +57,14 @@ func setup() (*Config, error) {
 		ReplicaSet string `mapstructure:"replica_set"`
 		Port       int    `mapstructure:"port"`
 		Mode       string `mapstructure:"mode"`
+
+		TrashRetentionDays int           `mapstructure:"trash_retention_days"`
+		TrashPurgeInterval time.Duration `mapstructure:"trash_purge_interval"`
+
+		ActivityRetentionDays int `mapstructure:"activity_retention_days"`
+
+		PdfFont     string `mapstructure:"pdf_font"`
+		PdfMonoFont string `mapstructure:"pdf_mono_font"`
 	}
 
 	if err := viper.ReadInConfig(); err != nil {
@@ -61,6 +81,30 @@ func setup() (*Config, error) {
 		return nil, format.Error(op, errors.New("missing environment variables"))
 	}
 
+	trashRetention := domain.TrashRetention
+	if cfg.TrashRetentionDays > 0 {
+		trashRetention = time.Duration(cfg.TrashRetentionDays) * 24 * time.Hour
+	}
+	activityRetention := domain.ActivityRetention
+	if cfg.ActivityRetentionDays > 0 {
+		activityRetention = time.Duration(cfg.ActivityRetentionDays) * 24 * time.Hour
+	}
+	if cfg.TrashPurgeInterval <= 0 {
+		cfg.TrashPurgeInterval = domain.TrashPurgeInterval
+	}
+
+	var fonts [2]*pdf.Font
+	for i, path := range []string{cfg.PdfFont, cfg.PdfMonoFont} {
+		if path == "" {
+			continue
+		}
+		f, err := pdf.LoadTTF(path)
+		if err != nil {
+			return nil, format.Error(op, fmt.Errorf("font %s: %w", path, err))
+		}
+		fonts[i] = f
+	}
+
 	if cfg.Mode == "DEV" {
 		log.Println(format.Struct(cfg), fmt.Sprintf("URI: mongodb://%s:%s@%s/%s?authSource=admin",
 			user, pw, cfg.DataSource, db))
@@ -78,13 +122,23 @@ func setup() (*Config, error) {
 			cfg.ReplicaSet,
 		)
 		return &Config{
-			Uri:  uri,
-			Port: cfg.Port,
+			Uri:                uri,
+			Port:               cfg.Port,
+			TrashRetention:     trashRetention,
+			TrashPurgeInterval: cfg.TrashPurgeInterval,
+			ActivityRetention:  activityRetention,
+			PdfFont:            fonts[0],
+			PdfMonoFont:        fonts[1],
 		}, nil
 	}
 	return &Config{
 		Uri: fmt.Sprintf("mongodb://%s:%s@%s/%s?authSource=admin",
 			user, pw, cfg.DataSource, db),
-		Port: cfg.Port,
+		Port:               cfg.Port,
+		TrashRetention:     trashRetention,
+		TrashPurgeInterval: cfg.TrashPurgeInterval,
+		ActivityRetention:  activityRetention,
+		PdfFont:            fonts[0],
+		PdfMonoFont:        fonts[1],
 	}, nil
 }
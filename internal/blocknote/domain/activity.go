@@ -0,0 +1,126 @@
+package domain
+
+import (
+	"fmt"
+
+	brzrpc "github.com/autumnterror/breezynotes/api/proto/gen"
+)
+
+const (
+	ActionCreateNote   = "create_note"
+	ActionChangeTitle  = "change_title"
+	ActionAddTag       = "add_tag"
+	ActionRemoveTag    = "remove_tag"
+	ActionShare        = "share"
+	ActionUnshare      = "unshare"
+	ActionJoinPublic   = "join_public"
+	ActionChangePublic = "change_public"
+	ActionChangeBlog   = "change_blog"
+	ActionToTrash      = "to_trash"
+	ActionFromTrash    = "from_trash"
+	ActionImportNote   = "import_note"
+
+	ActionCreateBlock  = "create_block"
+	ActionDeleteBlock  = "delete_block"
+	ActionRestoreBlock = "restore_block"
+	ActionOpBlock      = "op_block"
+	ActionChangeType   = "change_type"
+	ActionChangeOrder  = "change_order"
+
+	ActionComment        = "comment"
+	ActionEditComment    = "edit_comment"
+	ActionDeleteComment  = "delete_comment"
+	ActionResolveThread  = "resolve_thread"
+	ActionReopenThread   = "reopen_thread"
+	maxActivitySummaryLn = 80
+)
+
+// Activity is event of change in note. Summary is short human-readable description of diff
+type Activity struct {
+	Id        string `bson:"_id"`
+	NoteId    string `bson:"note_id"`
+	Actor     string `bson:"actor"`
+	Action    string `bson:"action"`
+	BlockId   string `bson:"block_id,omitempty"`
+	Summary   string `bson:"summary"`
+	CreatedAt int64  `bson:"created_at"`
+}
+
+type Activities struct {
+	Acts  []*Activity
+	Total int64
+}
+
+// ShortSummary cut s to maxActivitySummaryLn runes
+func ShortSummary(s string) string {
+	r := []rune(s)
+	if len(r) <= maxActivitySummaryLn {
+		return s
+	}
+	return string(r[:maxActivitySummaryLn-1]) + "…"
+}
+
+// ChangeSummary describe change of value from old to new
+func ChangeSummary(old, new string) string {
+	return ShortSummary(fmt.Sprintf("%q -> %q", old, new))
+}
+
+// OpSummary describe block op by its data
+func OpSummary(opName string, data map[string]any) string {
+	num := func(key string) int {
+		switch v := data[key].(type) {
+		case float64:
+			return int(v)
+		case int:
+			return v
+		case int64:
+			return int(v)
+		default:
+			return 0
+		}
+	}
+
+	switch opName {
+	case "insert_text":
+		text, _ := data["new_text"].(string)
+		return ShortSummary(fmt.Sprintf("insert_text at %d: %q", num("pos"), text))
+	case "delete_range":
+		return fmt.Sprintf("delete_range [%d, %d)", num("start"), num("end"))
+	case "apply_style":
+		style, _ := data["style"].(string)
+		return ShortSummary(fmt.Sprintf("apply_style %s [%d, %d)", style, num("start"), num("end")))
+	default:
+		return ShortSummary(opName)
+	}
+}
+
+func FromActivityDb(a *Activity) *brzrpc.Activity {
+	if a == nil {
+		return nil
+	}
+	return &brzrpc.Activity{
+		Id:        a.Id,
+		NoteId:    a.NoteId,
+		Actor:     a.Actor,
+		Action:    a.Action,
+		BlockId:   a.BlockId,
+		Summary:   a.Summary,
+		CreatedAt: a.CreatedAt,
+	}
+}
+
+func FromActivitiesDb(a *Activities) *brzrpc.Activities {
+	if a == nil {
+		return nil
+	}
+
+	acts := make([]*brzrpc.Activity, 0, len(a.Acts))
+	for _, act := range a.Acts {
+		acts = append(acts, FromActivityDb(act))
+	}
+
+	return &brzrpc.Activities{
+		Items: acts,
+		Total: a.Total,
+	}
+}
@@ -0,0 +1,26 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOpSummary(t *testing.T) {
+	assert.Equal(t, `insert_text at 3: "abc"`, OpSummary("insert_text", map[string]any{"pos": float64(3), "new_text": "abc"}))
+	assert.Equal(t, "delete_range [2, 5)", OpSummary("delete_range", map[string]any{"start": 2, "end": 5}))
+	assert.Equal(t, "apply_style bold [0, 4)", OpSummary("apply_style", map[string]any{"start": 0, "end": 4, "style": "bold"}))
+	assert.Equal(t, "toggle", OpSummary("toggle", nil))
+}
+
+func TestShortSummary(t *testing.T) {
+	assert.Equal(t, "short", ShortSummary("short"))
+
+	long := ShortSummary(strings.Repeat("я", 200))
+	assert.Equal(t, maxActivitySummaryLn, utf8.RuneCountInString(long))
+	assert.True(t, strings.HasSuffix(long, "…"))
+
+	assert.Equal(t, `"old" -> "new"`, ChangeSummary("old", "new"))
+}
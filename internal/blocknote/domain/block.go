@@ -131,3 +131,88 @@ func FromBlocksDb(b *Blocks) *brzrpc.Blocks {
 		Items: blks,
 	}
 }
+
+// DeletedBlock is block in block trash. PrevId and NextId are neighbours of block at the moment of deleting,
+// they are used to find place for block if note was changed after
+type DeletedBlock struct {
+	Id        string `bson:"_id"`
+	NoteId    string `bson:"note_id"`
+	Position  int    `bson:"position"`
+	PrevId    string `bson:"prev_id,omitempty"`
+	NextId    string `bson:"next_id,omitempty"`
+	DeletedAt int64  `bson:"deleted_at"`
+	DeletedBy string `bson:"deleted_by"`
+	Block     *Block `bson:"block"`
+}
+
+type DeletedBlocks struct {
+	Blks []*DeletedBlock
+}
+
+// NewDeletedBlock remember position and neighbours of b in blocks of note
+func NewDeletedBlock(b *Block, blocks []string, idUser string, deletedAt int64) *DeletedBlock {
+	db := &DeletedBlock{
+		Id:        b.Id,
+		NoteId:    b.NoteId,
+		Position:  len(blocks),
+		DeletedAt: deletedAt,
+		DeletedBy: idUser,
+		Block:     b,
+	}
+	for i, id := range blocks {
+		if id != b.Id {
+			continue
+		}
+		db.Position = i
+		if i > 0 {
+			db.PrevId = blocks[i-1]
+		}
+		if i < len(blocks)-1 {
+			db.NextId = blocks[i+1]
+		}
+		break
+	}
+	return db
+}
+
+// RestorePosition return index in blocks where deleted block should be inserted.
+// After previous neighbour, else before next neighbour, else old position cut by len(blocks)
+func (db *DeletedBlock) RestorePosition(blocks []string) int {
+	for i, id := range blocks {
+		if db.PrevId != "" && id == db.PrevId {
+			return i + 1
+		}
+	}
+	for i, id := range blocks {
+		if db.NextId != "" && id == db.NextId {
+			return i
+		}
+	}
+	if db.Position > len(blocks) {
+		return len(blocks)
+	}
+	if db.Position < 0 {
+		return 0
+	}
+	return db.Position
+}
+
+func FromDeletedBlocksDb(b *DeletedBlocks) *brzrpc.DeletedBlocks {
+	if b == nil {
+		return nil
+	}
+
+	blks := make([]*brzrpc.DeletedBlock, 0, len(b.Blks))
+	for _, blk := range b.Blks {
+		blks = append(blks, &brzrpc.DeletedBlock{
+			Block:     FromBlockDb(blk.Block),
+			Position:  int32(blk.Position),
+			DeletedAt: blk.DeletedAt,
+			DeletedBy: blk.DeletedBy,
+		})
+	}
+
+	return &brzrpc.DeletedBlocks{
+		Items: blks,
+	}
+}
@@ -0,0 +1,38 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewDeletedBlock(t *testing.T) {
+	blocks := []string{"a", "b", "c"}
+
+	db := NewDeletedBlock(&Block{Id: "b", NoteId: "n"}, blocks, "u", 10)
+	assert.Equal(t, 1, db.Position)
+	assert.Equal(t, "a", db.PrevId)
+	assert.Equal(t, "c", db.NextId)
+	assert.Equal(t, "n", db.NoteId)
+
+	db = NewDeletedBlock(&Block{Id: "a"}, blocks, "u", 10)
+	assert.Equal(t, 0, db.Position)
+	assert.Equal(t, "", db.PrevId)
+	assert.Equal(t, "b", db.NextId)
+
+	db = NewDeletedBlock(&Block{Id: "x"}, blocks, "u", 10)
+	assert.Equal(t, 3, db.Position)
+}
+
+func TestRestorePosition(t *testing.T) {
+	db := &DeletedBlock{Position: 1, PrevId: "a", NextId: "c"}
+
+	assert.Equal(t, 1, db.RestorePosition([]string{"a", "c"}))
+	assert.Equal(t, 3, db.RestorePosition([]string{"x", "y", "a", "c"}))
+	assert.Equal(t, 1, db.RestorePosition([]string{"x", "c"}))
+	assert.Equal(t, 1, db.RestorePosition([]string{"x", "y", "z"}))
+	assert.Equal(t, 0, db.RestorePosition([]string{}))
+
+	db = &DeletedBlock{Position: 5}
+	assert.Equal(t, 2, db.RestorePosition([]string{"x", "y"}))
+}
@@ -0,0 +1,126 @@
+package domain
+
+import brzrpc "github.com/autumnterror/breezynotes/api/proto/gen"
+
+// TextRange is range of characters [Start, End) inside text data of block
+type TextRange struct {
+	Start int `bson:"start"`
+	End   int `bson:"end"`
+}
+
+// Comment in thread. Root comment of thread has ThreadId == Id and keeps Anchor, Resolved and Orphaned
+type Comment struct {
+	Id        string     `bson:"_id"`
+	NoteId    string     `bson:"note_id"`
+	BlockId   string     `bson:"block_id"`
+	ThreadId  string     `bson:"thread_id"`
+	Author    string     `bson:"author"`
+	Text      string     `bson:"text"`
+	Mentions  []string   `bson:"mentions"`
+	Anchor    *TextRange `bson:"anchor,omitempty"`
+	Resolved  bool       `bson:"resolved"`
+	Orphaned  bool       `bson:"orphaned"`
+	CreatedAt int64      `bson:"created_at"`
+	UpdatedAt int64      `bson:"updated_at"`
+}
+
+type Comments struct {
+	Cmts []*Comment
+}
+
+func (c *Comment) IsRoot() bool {
+	return c.ThreadId == c.Id
+}
+
+// ShiftInsert move range after insert of n characters on pos.
+// Insert inside range expand it, insert on start of range move it
+func (r *TextRange) ShiftInsert(pos, n int) {
+	if n <= 0 {
+		return
+	}
+	if pos <= r.Start {
+		r.Start += n
+		r.End += n
+		return
+	}
+	if pos < r.End {
+		r.End += n
+	}
+}
+
+// ShiftDelete move range after delete of characters [start, end). Deleted part of range is cut
+func (r *TextRange) ShiftDelete(start, end int) {
+	if start < 0 {
+		start = 0
+	}
+	if start >= end {
+		return
+	}
+	shift := func(p int) int {
+		switch {
+		case p <= start:
+			return p
+		case p <= end:
+			return start
+		default:
+			return p - (end - start)
+		}
+	}
+	r.Start = shift(r.Start)
+	r.End = shift(r.End)
+}
+
+func ToTextRangeDb(r *brzrpc.TextRange) *TextRange {
+	if r == nil {
+		return nil
+	}
+	return &TextRange{
+		Start: int(r.GetStart()),
+		End:   int(r.GetEnd()),
+	}
+}
+
+func FromTextRangeDb(r *TextRange) *brzrpc.TextRange {
+	if r == nil {
+		return nil
+	}
+	return &brzrpc.TextRange{
+		Start: int32(r.Start),
+		End:   int32(r.End),
+	}
+}
+
+func FromCommentDb(c *Comment) *brzrpc.Comment {
+	if c == nil {
+		return nil
+	}
+	return &brzrpc.Comment{
+		Id:        c.Id,
+		NoteId:    c.NoteId,
+		BlockId:   c.BlockId,
+		ThreadId:  c.ThreadId,
+		Author:    c.Author,
+		Text:      c.Text,
+		Mentions:  c.Mentions,
+		Anchor:    FromTextRangeDb(c.Anchor),
+		Resolved:  c.Resolved,
+		Orphaned:  c.Orphaned,
+		CreatedAt: c.CreatedAt,
+		UpdatedAt: c.UpdatedAt,
+	}
+}
+
+func FromCommentsDb(c *Comments) *brzrpc.Comments {
+	if c == nil {
+		return nil
+	}
+
+	cmts := make([]*brzrpc.Comment, 0, len(c.Cmts))
+	for _, cmt := range c.Cmts {
+		cmts = append(cmts, FromCommentDb(cmt))
+	}
+
+	return &brzrpc.Comments{
+		Items: cmts,
+	}
+}
@@ -0,0 +1,53 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTextRangeShiftInsert(t *testing.T) {
+	tests := []struct {
+		name   string
+		pos, n int
+		want   TextRange
+	}{
+		{"before", 1, 3, TextRange{8, 13}},
+		{"on start", 5, 3, TextRange{8, 13}},
+		{"inside", 7, 3, TextRange{5, 13}},
+		{"on end", 10, 3, TextRange{5, 10}},
+		{"after", 20, 3, TextRange{5, 10}},
+		{"empty", 1, 0, TextRange{5, 10}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := TextRange{5, 10}
+			r.ShiftInsert(tt.pos, tt.n)
+			assert.Equal(t, tt.want, r)
+		})
+	}
+}
+
+func TestTextRangeShiftDelete(t *testing.T) {
+	tests := []struct {
+		name       string
+		start, end int
+		want       TextRange
+	}{
+		{"before", 0, 2, TextRange{3, 8}},
+		{"overlap start", 3, 7, TextRange{3, 6}},
+		{"inside", 6, 8, TextRange{5, 8}},
+		{"overlap end", 8, 12, TextRange{5, 8}},
+		{"after", 12, 15, TextRange{5, 10}},
+		{"whole", 0, 20, TextRange{0, 0}},
+		{"negative start", -3, 2, TextRange{3, 8}},
+		{"empty", 4, 4, TextRange{5, 10}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := TextRange{5, 10}
+			r.ShiftDelete(tt.start, tt.end)
+			assert.Equal(t, tt.want, r)
+		})
+	}
+}
@@ -0,0 +1,60 @@
+package domain
+
+import brzrpc "github.com/autumnterror/breezynotes/api/proto/gen"
+
+const (
+	ConflictTagMerged    = "tag_merged"
+	ConflictTagInvalid   = "tag_invalid"
+	ConflictNoteExists   = "note_exists"
+	ConflictBlockType    = "block_type"
+	ConflictBlockInvalid = "block_invalid"
+)
+
+// ImportConflict something from archive which is merged or skipped. Id is old id from archive
+type ImportConflict struct {
+	Kind   string
+	Id     string
+	Title  string
+	Detail string
+}
+
+// ImportReport result of import. Ids maps old ids of tags, notes and blocks to new ones
+type ImportReport struct {
+	Ids       map[string]string
+	Notes     int32
+	Trash     int32
+	Blocks    int32
+	Tags      int32
+	Conflicts []*ImportConflict
+}
+
+func NewImportReport() *ImportReport {
+	return &ImportReport{Ids: make(map[string]string)}
+}
+
+func (r *ImportReport) Conflict(kind, id, title, detail string) {
+	r.Conflicts = append(r.Conflicts, &ImportConflict{Kind: kind, Id: id, Title: title, Detail: detail})
+}
+
+func FromImportReportDb(r *ImportReport) *brzrpc.ImportReport {
+	if r == nil {
+		return nil
+	}
+	cs := make([]*brzrpc.ImportConflict, 0, len(r.Conflicts))
+	for _, c := range r.Conflicts {
+		cs = append(cs, &brzrpc.ImportConflict{
+			Kind:   c.Kind,
+			Id:     c.Id,
+			Title:  c.Title,
+			Detail: c.Detail,
+		})
+	}
+	return &brzrpc.ImportReport{
+		Ids:       r.Ids,
+		Notes:     r.Notes,
+		Trash:     r.Trash,
+		Blocks:    r.Blocks,
+		Tags:      r.Tags,
+		Conflicts: cs,
+	}
+}
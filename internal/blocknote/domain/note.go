@@ -8,17 +8,19 @@ type NoteTags struct {
 }
 
 type Note struct {
-	Id        string `bson:"_id"`
-	Title     string `bson:"title"`
-	CreatedAt int64  `bson:"created_at"`
-	UpdatedAt int64  `bson:"updated_at"`
-	Tag       *Tag
-	Author    string   `bson:"author"`
-	Editors   []string `bson:"editors"`
-	Readers   []string `bson:"readers"`
-	Blocks    []string `bson:"blocks"`
-	IsPublic  bool     `bson:"is_public"`
-	IsBlog    bool     `bson:"is_blog"`
+	Id          string `bson:"_id"`
+	Title       string `bson:"title"`
+	CreatedAt   int64  `bson:"created_at"`
+	UpdatedAt   int64  `bson:"updated_at"`
+	Tag         *Tag
+	Author      string   `bson:"author"`
+	Editors     []string `bson:"editors"`
+	Readers     []string `bson:"readers"`
+	Blocks      []string `bson:"blocks"`
+	IsPublic    bool     `bson:"is_public"`
+	IsBlog      bool     `bson:"is_blog"`
+	WorkspaceId string   `bson:"workspace_id,omitempty"`
+	DeletedAt   int64    `bson:"deleted_at,omitempty"`
 }
 
 type Notes struct {
@@ -40,17 +42,18 @@ func ToNoteDb(n *brzrpc.Note) *Note {
 		nn.Readers = []string{}
 	}
 	return &Note{
-		Id:        n.Id,
-		Title:     n.Title,
-		CreatedAt: n.CreatedAt,
-		UpdatedAt: n.UpdatedAt,
-		Tag:       ToTagDb(n.Tag),
-		Author:    n.Author,
-		Editors:   nn.Editors,
-		Readers:   nn.Readers,
-		Blocks:    nn.Blocks,
-		IsPublic:  nn.IsPublic,
-		IsBlog:    nn.IsBlog,
+		Id:          n.Id,
+		Title:       n.Title,
+		CreatedAt:   n.CreatedAt,
+		UpdatedAt:   n.UpdatedAt,
+		Tag:         ToTagDb(n.Tag),
+		Author:      n.Author,
+		Editors:     nn.Editors,
+		Readers:     nn.Readers,
+		Blocks:      nn.Blocks,
+		IsPublic:    nn.IsPublic,
+		IsBlog:      nn.IsBlog,
+		WorkspaceId: n.WorkspaceId,
 	}
 }
 
@@ -69,17 +72,18 @@ func FromNoteDb(n *Note) *brzrpc.Note {
 		nn.Readers = []string{}
 	}
 	return &brzrpc.Note{
-		Id:        n.Id,
-		Title:     n.Title,
-		CreatedAt: n.CreatedAt,
-		UpdatedAt: n.UpdatedAt,
-		Tag:       FromTagDb(n.Tag),
-		Author:    n.Author,
-		Editors:   nn.Editors,
-		Readers:   nn.Readers,
-		Blocks:    nn.Blocks,
-		IsPublic:  nn.IsPublic,
-		IsBlog:    nn.IsBlog,
+		Id:          n.Id,
+		Title:       n.Title,
+		CreatedAt:   n.CreatedAt,
+		UpdatedAt:   n.UpdatedAt,
+		Tag:         FromTagDb(n.Tag),
+		Author:      n.Author,
+		Editors:     nn.Editors,
+		Readers:     nn.Readers,
+		Blocks:      nn.Blocks,
+		IsPublic:    nn.IsPublic,
+		IsBlog:      nn.IsBlog,
+		WorkspaceId: n.WorkspaceId,
 	}
 }
 
@@ -114,17 +118,18 @@ func FromNotesDb(n *Notes) *brzrpc.Notes {
 }
 
 type NoteWithBlocks struct {
-	Id        string   `bson:"_id"`
-	Title     string   `bson:"title"`
-	CreatedAt int64    `bson:"created_at"`
-	UpdatedAt int64    `bson:"updated_at"`
-	Tag       *Tag     `bson:"tag"`
-	Author    string   `bson:"author"`
-	Editors   []string `bson:"editors"`
-	Readers   []string `bson:"readers"`
-	Blocks    []*Block `bson:"blocks"`
-	IsPublic  bool     `bson:"isPublic"`
-	IsBlog    bool     `bson:"isBlog"`
+	Id          string   `bson:"_id"`
+	Title       string   `bson:"title"`
+	CreatedAt   int64    `bson:"created_at"`
+	UpdatedAt   int64    `bson:"updated_at"`
+	Tag         *Tag     `bson:"tag"`
+	Author      string   `bson:"author"`
+	Editors     []string `bson:"editors"`
+	Readers     []string `bson:"readers"`
+	Blocks      []*Block `bson:"blocks"`
+	IsPublic    bool     `bson:"isPublic"`
+	IsBlog      bool     `bson:"isBlog"`
+	WorkspaceId string   `bson:"workspace_id,omitempty"`
 }
 
 func ToNoteWithBlocksDb(n *brzrpc.NoteWithBlocks) *NoteWithBlocks {
@@ -142,17 +147,18 @@ func ToNoteWithBlocksDb(n *brzrpc.NoteWithBlocks) *NoteWithBlocks {
 		nn.Readers = []string{}
 	}
 	return &NoteWithBlocks{
-		Id:        n.Id,
-		Title:     n.Title,
-		CreatedAt: n.CreatedAt,
-		UpdatedAt: n.UpdatedAt,
-		Tag:       ToTagDb(n.Tag),
-		Author:    n.Author,
-		Editors:   nn.Editors,
-		Readers:   nn.Readers,
-		Blocks:    ToBlocksDb(&brzrpc.Blocks{Items: nn.Blocks}).Blks,
-		IsPublic:  nn.IsPublic,
-		IsBlog:    nn.IsBlog,
+		Id:          n.Id,
+		Title:       n.Title,
+		CreatedAt:   n.CreatedAt,
+		UpdatedAt:   n.UpdatedAt,
+		Tag:         ToTagDb(n.Tag),
+		Author:      n.Author,
+		Editors:     nn.Editors,
+		Readers:     nn.Readers,
+		Blocks:      ToBlocksDb(&brzrpc.Blocks{Items: nn.Blocks}).Blks,
+		IsPublic:    nn.IsPublic,
+		IsBlog:      nn.IsBlog,
+		WorkspaceId: n.WorkspaceId,
 	}
 }
 
@@ -171,29 +177,34 @@ func FromNoteWithBlocksDb(n *NoteWithBlocks) *brzrpc.NoteWithBlocks {
 		nn.Readers = []string{}
 	}
 	return &brzrpc.NoteWithBlocks{
-		Id:        n.Id,
-		Title:     n.Title,
-		CreatedAt: n.CreatedAt,
-		UpdatedAt: n.UpdatedAt,
-		Tag:       FromTagDb(n.Tag),
-		Author:    n.Author,
-		Editors:   nn.Editors,
-		Readers:   nn.Readers,
-		Blocks:    FromBlocksDb(&Blocks{Blks: nn.Blocks}).GetItems(),
-		IsPublic:  n.IsPublic,
-		IsBlog:    n.IsBlog,
+		Id:          n.Id,
+		Title:       n.Title,
+		CreatedAt:   n.CreatedAt,
+		UpdatedAt:   n.UpdatedAt,
+		Tag:         FromTagDb(n.Tag),
+		Author:      n.Author,
+		Editors:     nn.Editors,
+		Readers:     nn.Readers,
+		Blocks:      FromBlocksDb(&Blocks{Blks: nn.Blocks}).GetItems(),
+		IsPublic:    n.IsPublic,
+		IsBlog:      n.IsBlog,
+		WorkspaceId: n.WorkspaceId,
 	}
 }
 
 type NotePart struct {
-	Id         string
-	Title      string
-	Tag        *Tag
-	FirstBlock string
-	UpdatedAt  int64
-	Role       string
-	IsPublic   bool
-	IsBlog     bool
+	Id          string
+	Title       string
+	Tag         *Tag
+	FirstBlock  string
+	CreatedAt   int64
+	UpdatedAt   int64
+	Role        string
+	IsPublic    bool
+	IsBlog      bool
+	WorkspaceId string
+	DeletedAt   int64
+	DaysLeft    int32
 }
 type NoteParts struct {
 	Ntps []*NotePart
@@ -201,27 +212,33 @@ type NoteParts struct {
 
 func FromNotePartDb(n *NotePart) *brzrpc.NotePart {
 	return &brzrpc.NotePart{
-		Id:         n.Id,
-		Title:      n.Title,
-		Tag:        FromTagDb(n.Tag),
-		FirstBlock: n.FirstBlock,
-		UpdatedAt:  n.UpdatedAt,
-		Role:       n.Role,
-		IsPublic:   n.IsPublic,
-		IsBlog:     n.IsBlog,
+		Id:          n.Id,
+		Title:       n.Title,
+		Tag:         FromTagDb(n.Tag),
+		FirstBlock:  n.FirstBlock,
+		CreatedAt:   n.CreatedAt,
+		UpdatedAt:   n.UpdatedAt,
+		Role:        n.Role,
+		IsPublic:    n.IsPublic,
+		IsBlog:      n.IsBlog,
+		WorkspaceId: n.WorkspaceId,
+		DaysLeft:    n.DaysLeft,
 	}
 }
 
 func ToNotePartDb(n *brzrpc.NotePart) *NotePart {
 	return &NotePart{
-		Id:         n.GetId(),
-		Title:      n.GetTitle(),
-		Tag:        ToTagDb(n.Tag),
-		FirstBlock: n.GetFirstBlock(),
-		UpdatedAt:  n.GetUpdatedAt(),
-		Role:       n.GetRole(),
-		IsPublic:   n.GetIsPublic(),
-		IsBlog:     n.GetIsBlog(),
+		Id:          n.GetId(),
+		Title:       n.GetTitle(),
+		Tag:         ToTagDb(n.Tag),
+		FirstBlock:  n.GetFirstBlock(),
+		CreatedAt:   n.GetCreatedAt(),
+		UpdatedAt:   n.GetUpdatedAt(),
+		Role:        n.GetRole(),
+		IsPublic:    n.GetIsPublic(),
+		IsBlog:      n.GetIsBlog(),
+		WorkspaceId: n.GetWorkspaceId(),
+		DaysLeft:    n.GetDaysLeft(),
 	}
 }
 
@@ -0,0 +1,7 @@
+package domain
+
+// Settings of user in blocknote service
+type Settings struct {
+	UserId             string `bson:"_id"`
+	TrashRetentionDays int    `bson:"trash_retention_days,omitempty"`
+}
@@ -0,0 +1,23 @@
+package domain
+
+import brzrpc "github.com/autumnterror/breezynotes/api/proto/gen"
+
+// UserStats what user stores. StorageBytes is size of notes, blocks and deleted blocks in db, without files
+type UserStats struct {
+	Notes        int64
+	TrashNotes   int64
+	Blocks       int64
+	StorageBytes int64
+}
+
+func FromUserStatsDb(s *UserStats) *brzrpc.UserStats {
+	if s == nil {
+		return nil
+	}
+	return &brzrpc.UserStats{
+		Notes:        s.Notes,
+		TrashNotes:   s.TrashNotes,
+		Blocks:       s.Blocks,
+		StorageBytes: s.StorageBytes,
+	}
+}
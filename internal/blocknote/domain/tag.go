@@ -3,12 +3,13 @@ package domain
 import brzrpc "github.com/autumnterror/breezynotes/api/proto/gen"
 
 type Tag struct {
-	Id       string `bson:"_id"`
-	Title    string `bson:"title"`
-	Color    string `bson:"color"`
-	Emoji    string `bson:"emoji"`
-	UserId   string `bson:"user_id"`
-	IsPinned bool   `bson:"is_pinned"`
+	Id          string `bson:"_id"`
+	Title       string `bson:"title"`
+	Color       string `bson:"color"`
+	Emoji       string `bson:"emoji"`
+	UserId      string `bson:"user_id"`
+	IsPinned    bool   `bson:"is_pinned"`
+	WorkspaceId string `bson:"workspace_id,omitempty"`
 }
 
 type Tags struct {
@@ -20,12 +21,13 @@ func ToTagDb(t *brzrpc.Tag) *Tag {
 		return nil
 	}
 	return &Tag{
-		Id:       t.Id,
-		Title:    t.Title,
-		Color:    t.Color,
-		Emoji:    t.Emoji,
-		UserId:   t.UserId,
-		IsPinned: t.IsPinned,
+		Id:          t.Id,
+		Title:       t.Title,
+		Color:       t.Color,
+		Emoji:       t.Emoji,
+		UserId:      t.UserId,
+		IsPinned:    t.IsPinned,
+		WorkspaceId: t.WorkspaceId,
 	}
 }
 
@@ -34,12 +36,13 @@ func FromTagDb(t *Tag) *brzrpc.Tag {
 		return nil
 	}
 	return &brzrpc.Tag{
-		Id:       t.Id,
-		Title:    t.Title,
-		Color:    t.Color,
-		Emoji:    t.Emoji,
-		UserId:   t.UserId,
-		IsPinned: t.IsPinned,
+		Id:          t.Id,
+		Title:       t.Title,
+		Color:       t.Color,
+		Emoji:       t.Emoji,
+		UserId:      t.UserId,
+		IsPinned:    t.IsPinned,
+		WorkspaceId: t.WorkspaceId,
 	}
 }
 
@@ -5,14 +5,26 @@ import (
 )
 
 const (
-	WaitTime     = 3 * time.Second
-	Db           = "blocknotedb"
-	TagColl      = "tags"
-	NoteColl     = "notes"
-	BlockColl    = "blocks"
-	TrashColl    = "trash"
-	NoteTagsColl = "notetags"
+	WaitTime       = 3 * time.Second
+	Db             = "blocknotedb"
+	TagColl        = "tags"
+	NoteColl       = "notes"
+	BlockColl      = "blocks"
+	TrashColl      = "trash"
+	NoteTagsColl   = "notetags"
+	SettingsColl   = "settings"
+	BlockTrashColl = "block_trash"
+	CommentColl    = "comments"
+	ActivityColl   = "activity"
 
-	ReaderRole = "reader"
-	EditorRole = "editor"
+	TrashRetention     = 30 * 24 * time.Hour
+	TrashPurgeInterval = time.Hour
+	ActivityRetention  = 90 * 24 * time.Hour
+
+	AuthorRole         = "author"
+	ReaderRole         = "reader"
+	EditorRole         = "editor"
+	WorkspaceOwnerRole = "owner"
+
+	WorkspaceRolesMD = "x-workspace-roles"
 )
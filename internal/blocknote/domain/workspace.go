@@ -0,0 +1,76 @@
+package domain
+
+import (
+	"context"
+	"strings"
+
+	"github.com/autumnterror/utils_go/pkg/utils/alg"
+)
+
+// WorkspaceRoles is map of workspace id to role of user in it. Membership lives in auth service,
+// gateway passes it in metadata WorkspaceRolesMD as "id:role,id:role"
+type WorkspaceRoles map[string]string
+
+type workspaceRolesCtx struct{}
+
+func ParseWorkspaceRoles(s string) WorkspaceRoles {
+	ws := WorkspaceRoles{}
+	for _, pair := range strings.Split(s, ",") {
+		id, role, ok := strings.Cut(strings.TrimSpace(pair), ":")
+		if !ok || id == "" || role == "" {
+			continue
+		}
+		ws[id] = role
+	}
+	return ws
+}
+
+func (ws WorkspaceRoles) Ids() []string {
+	ids := make([]string, 0, len(ws))
+	for id := range ws {
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+// NoteRole converts workspace role to role on note
+func (ws WorkspaceRoles) NoteRole(idWorkspace string) string {
+	switch ws[idWorkspace] {
+	case WorkspaceOwnerRole, EditorRole:
+		return EditorRole
+	case ReaderRole:
+		return ReaderRole
+	default:
+		return ""
+	}
+}
+
+func WithWorkspaceRoles(ctx context.Context, ws WorkspaceRoles) context.Context {
+	return context.WithValue(ctx, workspaceRolesCtx{}, ws)
+}
+
+func WorkspaceRolesFromContext(ctx context.Context) WorkspaceRoles {
+	if ws, ok := ctx.Value(workspaceRolesCtx{}).(WorkspaceRoles); ok {
+		return ws
+	}
+	return WorkspaceRoles{}
+}
+
+// RoleOf return "author", EditorRole, ReaderRole or "" if user has no access.
+// Direct share has priority over role derived from workspace membership
+func (n *Note) RoleOf(idUser string, ws WorkspaceRoles) string {
+	switch {
+	case idUser == "":
+		return ""
+	case n.Author == idUser:
+		return AuthorRole
+	case alg.IsIn(idUser, n.Editors):
+		return EditorRole
+	case alg.IsIn(idUser, n.Readers):
+		return ReaderRole
+	case n.WorkspaceId != "":
+		return ws.NoteRole(n.WorkspaceId)
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,44 @@
+package domain
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseWorkspaceRoles(t *testing.T) {
+	ws := ParseWorkspaceRoles("w1:owner, w2:reader,bad,:editor,w3:")
+	assert.Equal(t, WorkspaceRoles{"w1": "owner", "w2": "reader"}, ws)
+	assert.Empty(t, ParseWorkspaceRoles(""))
+}
+
+func TestWorkspaceRolesContext(t *testing.T) {
+	assert.Empty(t, WorkspaceRolesFromContext(context.Background()))
+
+	ws := WorkspaceRoles{"w1": WorkspaceOwnerRole}
+	assert.Equal(t, ws, WorkspaceRolesFromContext(WithWorkspaceRoles(context.Background(), ws)))
+}
+
+func TestNoteRoleOf(t *testing.T) {
+	ws := WorkspaceRoles{"w1": WorkspaceOwnerRole, "w2": EditorRole, "w3": ReaderRole}
+	n := &Note{
+		Author:  "a",
+		Editors: []string{"e"},
+		Readers: []string{"r"},
+	}
+
+	assert.Equal(t, AuthorRole, n.RoleOf("a", ws))
+	assert.Equal(t, EditorRole, n.RoleOf("e", ws))
+	assert.Equal(t, ReaderRole, n.RoleOf("r", ws))
+	assert.Equal(t, "", n.RoleOf("x", ws))
+	assert.Equal(t, "", n.RoleOf("", ws))
+
+	n.WorkspaceId = "w1"
+	assert.Equal(t, EditorRole, n.RoleOf("x", ws))
+	assert.Equal(t, ReaderRole, n.RoleOf("r", ws))
+	n.WorkspaceId = "w3"
+	assert.Equal(t, ReaderRole, n.RoleOf("x", ws))
+	n.WorkspaceId = "w4"
+	assert.Equal(t, "", n.RoleOf("x", ws))
+}
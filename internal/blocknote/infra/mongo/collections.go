@@ -23,3 +23,15 @@ func (c *Client) Trash() *mongo.Collection {
 func (c *Client) NoteTags() *mongo.Collection {
 	return c.C.Database(domain.Db).Collection(domain.NoteTagsColl)
 }
+func (c *Client) Settings() *mongo.Collection {
+	return c.C.Database(domain.Db).Collection(domain.SettingsColl)
+}
+func (c *Client) BlockTrash() *mongo.Collection {
+	return c.C.Database(domain.Db).Collection(domain.BlockTrashColl)
+}
+func (c *Client) Comments() *mongo.Collection {
+	return c.C.Database(domain.Db).Collection(domain.CommentColl)
+}
+func (c *Client) Activity() *mongo.Collection {
+	return c.C.Database(domain.Db).Collection(domain.ActivityColl)
+}
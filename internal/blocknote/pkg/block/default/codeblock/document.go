@@ -0,0 +1,46 @@
+package codeblock
+
+import (
+	"context"
+	"encoding/json"
+
+	brzrpc "github.com/autumnterror/breezynotes/api/proto/gen"
+	"github.com/autumnterror/breezynotes/internal/blocknote/domain/domainblocks"
+	"github.com/autumnterror/breezynotes/internal/blocknote/pkg/document"
+	"github.com/autumnterror/utils_go/pkg/utils/format"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// docData is data of code block in document, empty lang is not known yet
+type docData struct {
+	Lang string `json:"lang"`
+	Code string `json:"code"`
+}
+
+func (tb *Driver) Document(ctx context.Context, block *brzrpc.Block) (json.RawMessage, error) {
+	const op = "codeblock.Document"
+	b, err := domainblocks.FromUnifiedToCodeBlock(block)
+	if err != nil {
+		return nil, format.Error(op, err)
+	}
+	if b.Data == nil {
+		b.Data = &domainblocks.CodeData{}
+	}
+	return json.Marshal(docData{Lang: b.Data.Lang, Code: b.Data.Text})
+}
+
+func (tb *Driver) FromDocument(ctx context.Context, data json.RawMessage) (*structpb.Struct, error) {
+	const op = "codeblock.FromDocument"
+	var d docData
+	if err := document.DecodeData(data, &d, "code"); err != nil {
+		return nil, format.Error(op, err)
+	}
+	return structpb.NewStruct((&domainblocks.CodeData{Text: d.Code, Lang: d.Lang}).ToMap())
+}
+
+func (tb *Driver) Schema() map[string]any {
+	return document.Object(map[string]any{
+		"lang": map[string]any{"type": "string"},
+		"code": map[string]any{"type": "string"},
+	}, "code")
+}
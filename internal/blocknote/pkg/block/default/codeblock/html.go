@@ -0,0 +1,18 @@
+package codeblock
+
+import (
+	"context"
+
+	brzrpc "github.com/autumnterror/breezynotes/api/proto/gen"
+	"github.com/autumnterror/breezynotes/internal/blocknote/domain/domainblocks"
+	"github.com/autumnterror/breezynotes/internal/blocknote/pkg/render"
+)
+
+// HTML highlighted code, language which is unknown is guessed by code
+func (tb *Driver) HTML(ctx context.Context, block *brzrpc.Block, files render.Files) string {
+	b, err := domainblocks.FromUnifiedToCodeBlock(block)
+	if err != nil || b.Data == nil {
+		return ""
+	}
+	return render.CodeHTML(b.Data.Text, b.Data.Lang)
+}
@@ -0,0 +1,23 @@
+package codeblock
+
+import (
+	"context"
+	"strings"
+
+	brzrpc "github.com/autumnterror/breezynotes/api/proto/gen"
+	"github.com/autumnterror/breezynotes/internal/blocknote/domain/domainblocks"
+)
+
+// Markdown fenced code. Fence is longer than any run of backticks in code
+func (tb *Driver) Markdown(ctx context.Context, block *brzrpc.Block) string {
+	b, err := domainblocks.FromUnifiedToCodeBlock(block)
+	if err != nil || b.Data == nil {
+		return ""
+	}
+
+	fence := "```"
+	for strings.Contains(b.Data.Text, fence) {
+		fence += "`"
+	}
+	return fence + b.Data.Lang + "\n" + strings.TrimSuffix(b.Data.Text, "\n") + "\n" + fence
+}
@@ -0,0 +1,18 @@
+package codeblock
+
+import (
+	"context"
+
+	brzrpc "github.com/autumnterror/breezynotes/api/proto/gen"
+	"github.com/autumnterror/breezynotes/internal/blocknote/domain/domainblocks"
+	"github.com/autumnterror/breezynotes/internal/blocknote/pkg/render"
+	"github.com/autumnterror/breezynotes/internal/blocknote/pkg/render/pdf"
+)
+
+func (tb *Driver) PDF(ctx context.Context, block *brzrpc.Block, d *pdf.Doc, files render.Files) {
+	b, err := domainblocks.FromUnifiedToCodeBlock(block)
+	if err != nil || b.Data == nil {
+		return
+	}
+	d.Text(render.CodeSpans(b.Data.Text, b.Data.Lang, 9), pdf.Par{Before: 8, Indent: 4, Fill: render.CodeBackground()})
+}
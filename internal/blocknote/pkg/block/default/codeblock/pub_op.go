@@ -59,6 +59,10 @@ func (tb *Driver) Create(ctx context.Context, data map[string]any) (*brzrpc.Bloc
 	if err != nil {
 		return nil, err
 	}
+	// lang which is given (import, fence of markdown) is kept
+	if cb.Data != nil && cb.Data.Lang != "" {
+		return b, nil
+	}
 
 	newData, err := analyseLang(cb)
 	if err != nil {
@@ -10,6 +10,7 @@ import (
 	"github.com/autumnterror/utils_go/pkg/log"
 	"github.com/autumnterror/utils_go/pkg/utils/format"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"google.golang.org/protobuf/types/known/structpb"
 )
 
@@ -271,6 +272,18 @@ func TestChangeTypeNil(t *testing.T) {
 	})
 }
 
+func TestCreate(t *testing.T) {
+	t.Parallel()
+
+	b, err := d.Create(ctx, map[string]any{"text": "<html></html>"})
+	require.NoError(t, err)
+	assert.Equal(t, "HTML", b.GetData().GetFields()["lang"].GetStringValue())
+
+	b, err = d.Create(ctx, map[string]any{"text": "<html></html>", "lang": "text"})
+	require.NoError(t, err)
+	assert.Equal(t, "text", b.GetData().GetFields()["lang"].GetStringValue())
+}
+
 var (
 	d   = Driver{}
 	ctx = context.Background()
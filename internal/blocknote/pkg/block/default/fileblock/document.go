@@ -0,0 +1,42 @@
+package fileblock
+
+import (
+	"context"
+	"encoding/json"
+
+	brzrpc "github.com/autumnterror/breezynotes/api/proto/gen"
+	"github.com/autumnterror/breezynotes/internal/blocknote/domain/domainblocks"
+	"github.com/autumnterror/breezynotes/internal/blocknote/pkg/document"
+	"github.com/autumnterror/utils_go/pkg/utils/format"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// docData is data of file block in document, src is url or name of uploaded file
+type docData struct {
+	Src string `json:"src"`
+}
+
+func (d *Driver) Document(ctx context.Context, block *brzrpc.Block) (json.RawMessage, error) {
+	const op = "fileblock.Document"
+	b, err := domainblocks.FromUnifiedToFileBlock(block)
+	if err != nil {
+		return nil, format.Error(op, err)
+	}
+	if b.Data == nil {
+		b.Data = &domainblocks.FileData{}
+	}
+	return json.Marshal(docData{Src: b.Data.Src})
+}
+
+func (d *Driver) FromDocument(ctx context.Context, data json.RawMessage) (*structpb.Struct, error) {
+	const op = "fileblock.FromDocument"
+	var dd docData
+	if err := document.DecodeData(data, &dd, "src"); err != nil {
+		return nil, format.Error(op, err)
+	}
+	return structpb.NewStruct((&domainblocks.FileData{Src: dd.Src}).ToMap())
+}
+
+func (d *Driver) Schema() map[string]any {
+	return document.Object(map[string]any{"src": map[string]any{"type": "string"}}, "src")
+}
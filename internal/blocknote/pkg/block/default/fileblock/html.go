@@ -0,0 +1,24 @@
+package fileblock
+
+import (
+	"context"
+	"path"
+
+	brzrpc "github.com/autumnterror/breezynotes/api/proto/gen"
+	"github.com/autumnterror/breezynotes/internal/blocknote/domain/domainblocks"
+	"github.com/autumnterror/breezynotes/internal/blocknote/pkg/render"
+)
+
+// HTML link to file, files are not put in document, only pictures are
+func (d *Driver) HTML(ctx context.Context, block *brzrpc.Block, files render.Files) string {
+	b, err := domainblocks.FromUnifiedToFileBlock(block)
+	if err != nil || b.Data == nil || b.Data.Src == "" {
+		return ""
+	}
+	name := render.Escape(path.Base(b.Data.Src))
+	u := render.SafeUrl(b.Data.Src, false)
+	if u == "" {
+		return "<p>" + name + "</p>"
+	}
+	return `<p><a href="` + render.Escape(u) + `">` + name + "</a></p>"
+}
@@ -0,0 +1,18 @@
+package fileblock
+
+import (
+	"context"
+	"path"
+
+	brzrpc "github.com/autumnterror/breezynotes/api/proto/gen"
+	"github.com/autumnterror/breezynotes/internal/blocknote/domain/domainblocks"
+	"github.com/autumnterror/breezynotes/internal/blocknote/pkg/text"
+)
+
+func (d *Driver) Markdown(ctx context.Context, block *brzrpc.Block) string {
+	b, err := domainblocks.FromUnifiedToFileBlock(block)
+	if err != nil || b.Data == nil || b.Data.Src == "" {
+		return ""
+	}
+	return "[" + text.EscapeMarkdown(path.Base(b.Data.Src)) + "](<" + b.Data.Src + ">)"
+}
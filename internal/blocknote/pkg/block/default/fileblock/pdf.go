@@ -0,0 +1,23 @@
+package fileblock
+
+import (
+	"context"
+	"path"
+
+	brzrpc "github.com/autumnterror/breezynotes/api/proto/gen"
+	"github.com/autumnterror/breezynotes/internal/blocknote/domain/domainblocks"
+	"github.com/autumnterror/breezynotes/internal/blocknote/pkg/render"
+	"github.com/autumnterror/breezynotes/internal/blocknote/pkg/render/pdf"
+)
+
+func (d *Driver) PDF(ctx context.Context, block *brzrpc.Block, doc *pdf.Doc, files render.Files) {
+	b, err := domainblocks.FromUnifiedToFileBlock(block)
+	if err != nil || b.Data == nil || b.Data.Src == "" {
+		return
+	}
+	st := pdf.Style{Color: pdf.Gray}
+	if u := render.WebUrl(b.Data.Src); u != "" {
+		st = pdf.Style{Color: pdf.Blue, Link: u}
+	}
+	doc.Text([]pdf.Span{{Text: path.Base(b.Data.Src), Style: st}}, pdf.Par{Before: 6})
+}
@@ -0,0 +1,50 @@
+package headerblock
+
+import (
+	"context"
+	"encoding/json"
+
+	brzrpc "github.com/autumnterror/breezynotes/api/proto/gen"
+	"github.com/autumnterror/breezynotes/internal/blocknote/domain/domainblocks"
+	"github.com/autumnterror/breezynotes/internal/blocknote/pkg/document"
+	"github.com/autumnterror/breezynotes/internal/blocknote/pkg/text"
+	"github.com/autumnterror/utils_go/pkg/utils/format"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// docData is data of header block in document
+type docData struct {
+	Level uint            `json:"level"`
+	Text  []document.Span `json:"text"`
+}
+
+func (tb *Driver) Document(ctx context.Context, block *brzrpc.Block) (json.RawMessage, error) {
+	const op = "headerblock.Document"
+	b, err := domainblocks.FromUnifiedToHeaderBlock(block)
+	if err != nil {
+		return nil, format.Error(op, err)
+	}
+	if b.Data == nil {
+		b.Data = &domainblocks.HeaderData{}
+	}
+	return json.Marshal(docData{Level: b.Data.Level, Text: b.Data.TextData.Document()})
+}
+
+func (tb *Driver) FromDocument(ctx context.Context, data json.RawMessage) (*structpb.Struct, error) {
+	const op = "headerblock.FromDocument"
+	var d docData
+	if err := document.DecodeData(data, &d, "level", "text"); err != nil {
+		return nil, format.Error(op, err)
+	}
+	if err := document.CheckText(d.Text); err != nil {
+		return nil, format.Error(op, err)
+	}
+	return structpb.NewStruct((&domainblocks.HeaderData{Level: d.Level, TextData: text.FromDocument(d.Text)}).ToMap())
+}
+
+func (tb *Driver) Schema() map[string]any {
+	return document.Object(map[string]any{
+		"level": map[string]any{"type": "integer", "minimum": 0},
+		"text":  document.TextSchema(),
+	}, "level", "text")
+}
@@ -0,0 +1,20 @@
+package headerblock
+
+import (
+	"context"
+	"strconv"
+
+	brzrpc "github.com/autumnterror/breezynotes/api/proto/gen"
+	"github.com/autumnterror/breezynotes/internal/blocknote/domain/domainblocks"
+	"github.com/autumnterror/breezynotes/internal/blocknote/pkg/render"
+)
+
+// HTML header of note title is h1, so level of block is one deeper
+func (tb *Driver) HTML(ctx context.Context, block *brzrpc.Block, files render.Files) string {
+	b, err := domainblocks.FromUnifiedToHeaderBlock(block)
+	if err != nil || b.Data == nil {
+		return ""
+	}
+	tag := "h" + strconv.Itoa(min(max(int(b.Data.Level), 1), 5)+1)
+	return "<" + tag + ">" + b.Data.TextData.HTML() + "</" + tag + ">"
+}
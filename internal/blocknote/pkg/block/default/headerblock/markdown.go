@@ -0,0 +1,19 @@
+package headerblock
+
+import (
+	"context"
+	"strings"
+
+	brzrpc "github.com/autumnterror/breezynotes/api/proto/gen"
+	"github.com/autumnterror/breezynotes/internal/blocknote/domain/domainblocks"
+)
+
+// Markdown header of note title is #, so level of block is one deeper
+func (tb *Driver) Markdown(ctx context.Context, block *brzrpc.Block) string {
+	b, err := domainblocks.FromUnifiedToHeaderBlock(block)
+	if err != nil || b.Data == nil {
+		return ""
+	}
+	level := min(max(int(b.Data.Level), 1), 5)
+	return strings.Repeat("#", level+1) + " " + b.Data.TextData.Markdown()
+}
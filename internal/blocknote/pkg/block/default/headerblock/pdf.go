@@ -0,0 +1,22 @@
+package headerblock
+
+import (
+	"context"
+
+	brzrpc "github.com/autumnterror/breezynotes/api/proto/gen"
+	"github.com/autumnterror/breezynotes/internal/blocknote/domain/domainblocks"
+	"github.com/autumnterror/breezynotes/internal/blocknote/pkg/render"
+	"github.com/autumnterror/breezynotes/internal/blocknote/pkg/render/pdf"
+)
+
+// sizes of headers by level, header of note title is bigger
+var sizes = []float64{18, 15, 13, 12, 11}
+
+func (tb *Driver) PDF(ctx context.Context, block *brzrpc.Block, d *pdf.Doc, files render.Files) {
+	b, err := domainblocks.FromUnifiedToHeaderBlock(block)
+	if err != nil || b.Data == nil {
+		return
+	}
+	size := sizes[min(max(int(b.Data.Level), 1), len(sizes))-1]
+	d.Text(b.Data.TextData.Spans(pdf.Style{Size: size, Bold: true}), pdf.Par{Before: 14})
+}
@@ -0,0 +1,46 @@
+package imgblock
+
+import (
+	"context"
+	"encoding/json"
+
+	brzrpc "github.com/autumnterror/breezynotes/api/proto/gen"
+	"github.com/autumnterror/breezynotes/internal/blocknote/domain/domainblocks"
+	"github.com/autumnterror/breezynotes/internal/blocknote/pkg/document"
+	"github.com/autumnterror/utils_go/pkg/utils/format"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// docData is data of picture in document, src is url or name of uploaded file
+type docData struct {
+	Src string `json:"src"`
+	Alt string `json:"alt"`
+}
+
+func (d *Driver) Document(ctx context.Context, block *brzrpc.Block) (json.RawMessage, error) {
+	const op = "imgblock.Document"
+	b, err := domainblocks.FromUnifiedToImgBlock(block)
+	if err != nil {
+		return nil, format.Error(op, err)
+	}
+	if b.Data == nil {
+		b.Data = &domainblocks.ImgData{}
+	}
+	return json.Marshal(docData{Src: b.Data.Src, Alt: b.Data.Alt})
+}
+
+func (d *Driver) FromDocument(ctx context.Context, data json.RawMessage) (*structpb.Struct, error) {
+	const op = "imgblock.FromDocument"
+	var dd docData
+	if err := document.DecodeData(data, &dd, "src"); err != nil {
+		return nil, format.Error(op, err)
+	}
+	return structpb.NewStruct((&domainblocks.ImgData{Src: dd.Src, Alt: dd.Alt}).ToMap())
+}
+
+func (d *Driver) Schema() map[string]any {
+	return document.Object(map[string]any{
+		"src": map[string]any{"type": "string"},
+		"alt": map[string]any{"type": "string"},
+	}, "src")
+}
@@ -0,0 +1,33 @@
+package imgblock
+
+import (
+	"context"
+
+	brzrpc "github.com/autumnterror/breezynotes/api/proto/gen"
+	"github.com/autumnterror/breezynotes/internal/blocknote/domain/domainblocks"
+	"github.com/autumnterror/breezynotes/internal/blocknote/pkg/render"
+)
+
+// HTML picture, stored picture is taken from files, others are left by their url
+func (d *Driver) HTML(ctx context.Context, block *brzrpc.Block, files render.Files) string {
+	b, err := domainblocks.FromUnifiedToImgBlock(block)
+	if err != nil || b.Data == nil || b.Data.Src == "" {
+		return ""
+	}
+	src := ""
+	if files != nil {
+		src = files.Src(b.Data.Src)
+	}
+	if src == "" {
+		src = render.SafeUrl(b.Data.Src, false)
+	}
+	if src == "" {
+		return ""
+	}
+
+	res := `<figure><img src="` + render.Escape(src) + `" alt="` + render.Escape(b.Data.Alt) + `" />`
+	if b.Data.Alt != "" {
+		res += "<figcaption>" + render.Escape(b.Data.Alt) + "</figcaption>"
+	}
+	return res + "</figure>"
+}
@@ -0,0 +1,17 @@
+package imgblock
+
+import (
+	"context"
+
+	brzrpc "github.com/autumnterror/breezynotes/api/proto/gen"
+	"github.com/autumnterror/breezynotes/internal/blocknote/domain/domainblocks"
+	"github.com/autumnterror/breezynotes/internal/blocknote/pkg/text"
+)
+
+func (d *Driver) Markdown(ctx context.Context, block *brzrpc.Block) string {
+	b, err := domainblocks.FromUnifiedToImgBlock(block)
+	if err != nil || b.Data == nil || b.Data.Src == "" {
+		return ""
+	}
+	return "![" + text.EscapeMarkdown(b.Data.Alt) + "](<" + b.Data.Src + ">)"
+}
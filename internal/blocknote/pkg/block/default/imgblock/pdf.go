@@ -0,0 +1,36 @@
+package imgblock
+
+import (
+	"context"
+
+	brzrpc "github.com/autumnterror/breezynotes/api/proto/gen"
+	"github.com/autumnterror/breezynotes/internal/blocknote/domain/domainblocks"
+	"github.com/autumnterror/breezynotes/internal/blocknote/pkg/render"
+	"github.com/autumnterror/breezynotes/internal/blocknote/pkg/render/pdf"
+)
+
+// PDF picture from files. Picture which is not available or has unsupported format is written as its alt and url
+func (d *Driver) PDF(ctx context.Context, block *brzrpc.Block, doc *pdf.Doc, files render.Files) {
+	b, err := domainblocks.FromUnifiedToImgBlock(block)
+	if err != nil || b.Data == nil || b.Data.Src == "" {
+		return
+	}
+	if files != nil {
+		if data := files.Data(b.Data.Src); data != nil && doc.Image(data, 8) == nil {
+			if b.Data.Alt != "" {
+				doc.Text([]pdf.Span{{Text: b.Data.Alt, Style: pdf.Style{Size: 9, Color: pdf.Gray}}}, pdf.Par{Before: 2})
+			}
+			return
+		}
+	}
+
+	title := b.Data.Alt
+	if title == "" {
+		title = b.Data.Src
+	}
+	st := pdf.Style{Color: pdf.Gray, Italic: true}
+	if u := render.WebUrl(b.Data.Src); u != "" {
+		st.Link = u
+	}
+	doc.Text([]pdf.Span{{Text: "[" + title + "]", Style: st}}, pdf.Par{Before: 8})
+}
@@ -0,0 +1,46 @@
+package linkblock
+
+import (
+	"context"
+	"encoding/json"
+
+	brzrpc "github.com/autumnterror/breezynotes/api/proto/gen"
+	"github.com/autumnterror/breezynotes/internal/blocknote/domain/domainblocks"
+	"github.com/autumnterror/breezynotes/internal/blocknote/pkg/document"
+	"github.com/autumnterror/utils_go/pkg/utils/format"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// docData is data of link block in document, empty text is shown as url
+type docData struct {
+	Url  string `json:"url"`
+	Text string `json:"text"`
+}
+
+func (d *Driver) Document(ctx context.Context, block *brzrpc.Block) (json.RawMessage, error) {
+	const op = "linkblock.Document"
+	b, err := domainblocks.FromUnifiedToLinkBlock(block)
+	if err != nil {
+		return nil, format.Error(op, err)
+	}
+	if b.Data == nil {
+		b.Data = &domainblocks.LinkData{}
+	}
+	return json.Marshal(docData{Url: b.Data.Url, Text: b.Data.Text})
+}
+
+func (d *Driver) FromDocument(ctx context.Context, data json.RawMessage) (*structpb.Struct, error) {
+	const op = "linkblock.FromDocument"
+	var dd docData
+	if err := document.DecodeData(data, &dd, "url"); err != nil {
+		return nil, format.Error(op, err)
+	}
+	return structpb.NewStruct((&domainblocks.LinkData{Url: dd.Url, Text: dd.Text}).ToMap())
+}
+
+func (d *Driver) Schema() map[string]any {
+	return document.Object(map[string]any{
+		"url":  map[string]any{"type": "string"},
+		"text": map[string]any{"type": "string"},
+	}, "url")
+}
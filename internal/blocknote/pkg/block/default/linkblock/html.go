@@ -0,0 +1,26 @@
+package linkblock
+
+import (
+	"context"
+
+	brzrpc "github.com/autumnterror/breezynotes/api/proto/gen"
+	"github.com/autumnterror/breezynotes/internal/blocknote/domain/domainblocks"
+	"github.com/autumnterror/breezynotes/internal/blocknote/pkg/render"
+)
+
+// HTML link, url which is not http, https, relative or mailto is dropped
+func (d *Driver) HTML(ctx context.Context, block *brzrpc.Block, files render.Files) string {
+	b, err := domainblocks.FromUnifiedToLinkBlock(block)
+	if err != nil || b.Data == nil {
+		return ""
+	}
+	title := b.Data.Text
+	if title == "" {
+		title = b.Data.Url
+	}
+	u := render.SafeUrl(b.Data.Url, true)
+	if u == "" {
+		return "<p>" + render.Escape(title) + "</p>"
+	}
+	return `<p><a href="` + render.Escape(u) + `">` + render.Escape(title) + "</a></p>"
+}
@@ -0,0 +1,24 @@
+package linkblock
+
+import (
+	"context"
+
+	brzrpc "github.com/autumnterror/breezynotes/api/proto/gen"
+	"github.com/autumnterror/breezynotes/internal/blocknote/domain/domainblocks"
+	"github.com/autumnterror/breezynotes/internal/blocknote/pkg/text"
+)
+
+func (d *Driver) Markdown(ctx context.Context, block *brzrpc.Block) string {
+	b, err := domainblocks.FromUnifiedToLinkBlock(block)
+	if err != nil || b.Data == nil {
+		return ""
+	}
+	if b.Data.Url == "" {
+		return text.EscapeMarkdown(b.Data.Text)
+	}
+	title := b.Data.Text
+	if title == "" {
+		title = b.Data.Url
+	}
+	return "[" + text.EscapeMarkdown(title) + "](<" + b.Data.Url + ">)"
+}
@@ -0,0 +1,27 @@
+package linkblock
+
+import (
+	"context"
+
+	brzrpc "github.com/autumnterror/breezynotes/api/proto/gen"
+	"github.com/autumnterror/breezynotes/internal/blocknote/domain/domainblocks"
+	"github.com/autumnterror/breezynotes/internal/blocknote/pkg/render"
+	"github.com/autumnterror/breezynotes/internal/blocknote/pkg/render/pdf"
+)
+
+// PDF link, it can be opened only if it is absolute url
+func (d *Driver) PDF(ctx context.Context, block *brzrpc.Block, doc *pdf.Doc, files render.Files) {
+	b, err := domainblocks.FromUnifiedToLinkBlock(block)
+	if err != nil || b.Data == nil {
+		return
+	}
+	title := b.Data.Text
+	if title == "" {
+		title = b.Data.Url
+	}
+	st := pdf.Style{}
+	if u := render.WebUrl(b.Data.Url); u != "" {
+		st = pdf.Style{Color: pdf.Blue, Link: u}
+	}
+	doc.Text([]pdf.Span{{Text: title, Style: st}}, pdf.Par{Before: 6})
+}
@@ -0,0 +1,74 @@
+package listblock
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	brzrpc "github.com/autumnterror/breezynotes/api/proto/gen"
+	"github.com/autumnterror/breezynotes/internal/blocknote/domain/domainblocks"
+	"github.com/autumnterror/breezynotes/internal/blocknote/pkg/document"
+	"github.com/autumnterror/breezynotes/internal/blocknote/pkg/text"
+	"github.com/autumnterror/utils_go/pkg/utils/format"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// docData is data of list item in document. Value is number of ordered item or 1 if todo is done
+type docData struct {
+	Kind  string          `json:"kind"`
+	Level uint            `json:"level"`
+	Value int             `json:"value"`
+	Text  []document.Span `json:"text"`
+}
+
+var kinds = []any{domainblocks.ListBlockToDoType, domainblocks.ListBlockUnorderedType, domainblocks.ListBlockOrderedType}
+
+// Document item of unknown type is written as unordered one, it is shown so
+func (tb *Driver) Document(ctx context.Context, block *brzrpc.Block) (json.RawMessage, error) {
+	const op = "listblock.Document"
+	b, err := domainblocks.FromUnifiedToListBlock(block)
+	if err != nil {
+		return nil, format.Error(op, err)
+	}
+	if b.Data == nil {
+		b.Data = &domainblocks.ListData{}
+	}
+	d := docData{Kind: b.Data.Type, Level: b.Data.Level, Value: b.Data.Value, Text: b.Data.TextData.Document()}
+	switch d.Kind {
+	case domainblocks.ListBlockToDoType, domainblocks.ListBlockOrderedType:
+	default:
+		d.Kind = domainblocks.ListBlockUnorderedType
+	}
+	return json.Marshal(d)
+}
+
+func (tb *Driver) FromDocument(ctx context.Context, data json.RawMessage) (*structpb.Struct, error) {
+	const op = "listblock.FromDocument"
+	var d docData
+	if err := document.DecodeData(data, &d, "kind", "text"); err != nil {
+		return nil, format.Error(op, err)
+	}
+	switch d.Kind {
+	case domainblocks.ListBlockToDoType, domainblocks.ListBlockUnorderedType, domainblocks.ListBlockOrderedType:
+	default:
+		return nil, format.Error(op, fmt.Errorf("unknown kind %q", d.Kind))
+	}
+	if err := document.CheckText(d.Text); err != nil {
+		return nil, format.Error(op, err)
+	}
+	return structpb.NewStruct((&domainblocks.ListData{
+		Type:     d.Kind,
+		Level:    d.Level,
+		Value:    d.Value,
+		TextData: text.FromDocument(d.Text),
+	}).ToMap())
+}
+
+func (tb *Driver) Schema() map[string]any {
+	return document.Object(map[string]any{
+		"kind":  map[string]any{"enum": kinds},
+		"level": map[string]any{"type": "integer", "minimum": 0},
+		"value": map[string]any{"type": "integer", "description": "number of ordered item, 1 if todo is done"},
+		"text":  document.TextSchema(),
+	}, "kind", "text")
+}
@@ -0,0 +1,31 @@
+package listblock
+
+import (
+	"context"
+	"strconv"
+
+	brzrpc "github.com/autumnterror/breezynotes/api/proto/gen"
+	"github.com/autumnterror/breezynotes/internal/blocknote/domain/domainblocks"
+	"github.com/autumnterror/breezynotes/internal/blocknote/pkg/render"
+)
+
+// HTML item of list. Items are separate blocks, so they are paragraphs with marker, not li of one list
+func (tb *Driver) HTML(ctx context.Context, block *brzrpc.Block, files render.Files) string {
+	b, err := domainblocks.FromUnifiedToListBlock(block)
+	if err != nil || b.Data == nil {
+		return ""
+	}
+
+	class, marker := "item", "•"
+	switch b.Data.Type {
+	case domainblocks.ListBlockToDoType:
+		marker = "☐"
+		if b.Data.Value != 0 {
+			class, marker = "item done", "☑"
+		}
+	case domainblocks.ListBlockOrderedType:
+		marker = strconv.Itoa(max(b.Data.Value, 1)) + "."
+	}
+	return `<p class="` + class + `" style="margin-left: ` + strconv.FormatFloat(1.5*float64(b.Data.Level), 'f', -1, 64) + `em">` +
+		`<span class="marker">` + marker + `</span>` + b.Data.TextData.HTML() + `</p>`
+}
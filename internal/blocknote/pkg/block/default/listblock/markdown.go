@@ -0,0 +1,32 @@
+package listblock
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	brzrpc "github.com/autumnterror/breezynotes/api/proto/gen"
+	"github.com/autumnterror/breezynotes/internal/blocknote/domain/domainblocks"
+)
+
+// Markdown item of list. Value of todo item is 1 if it is checked, value of ordered item is its number
+func (tb *Driver) Markdown(ctx context.Context, block *brzrpc.Block) string {
+	b, err := domainblocks.FromUnifiedToListBlock(block)
+	if err != nil || b.Data == nil {
+		return ""
+	}
+
+	var marker string
+	switch b.Data.Type {
+	case domainblocks.ListBlockToDoType:
+		marker = "- [ ] "
+		if b.Data.Value != 0 {
+			marker = "- [x] "
+		}
+	case domainblocks.ListBlockOrderedType:
+		marker = strconv.Itoa(max(b.Data.Value, 1)) + ". "
+	default:
+		marker = "- "
+	}
+	return strings.Repeat("  ", int(b.Data.Level)) + marker + b.Data.TextData.Markdown()
+}
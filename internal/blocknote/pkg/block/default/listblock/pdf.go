@@ -0,0 +1,37 @@
+package listblock
+
+import (
+	"context"
+	"strconv"
+
+	brzrpc "github.com/autumnterror/breezynotes/api/proto/gen"
+	"github.com/autumnterror/breezynotes/internal/blocknote/domain/domainblocks"
+	"github.com/autumnterror/breezynotes/internal/blocknote/pkg/render"
+	"github.com/autumnterror/breezynotes/internal/blocknote/pkg/render/pdf"
+)
+
+// PDF item of list. Marker of todo is text, standard fonts have no check boxes
+func (tb *Driver) PDF(ctx context.Context, block *brzrpc.Block, d *pdf.Doc, files render.Files) {
+	b, err := domainblocks.FromUnifiedToListBlock(block)
+	if err != nil || b.Data == nil {
+		return
+	}
+
+	base := pdf.Style{}
+	marker := "•"
+	switch b.Data.Type {
+	case domainblocks.ListBlockToDoType:
+		marker = "[ ]"
+		if b.Data.Value != 0 {
+			marker = "[x]"
+			base = pdf.Style{Strike: true, Color: pdf.Gray}
+		}
+	case domainblocks.ListBlockOrderedType:
+		marker = strconv.Itoa(max(b.Data.Value, 1)) + "."
+	}
+	d.Text(b.Data.TextData.Spans(base), pdf.Par{
+		Before: 3,
+		Indent: 20 * float64(b.Data.Level+1),
+		Marker: []pdf.Span{{Text: marker}},
+	})
+}
@@ -743,3 +743,10 @@ func testBlockUnordered() *brzrpc.Block {
 
 	return lstUnif
 }
+
+func TestMarkdown(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "      2. text default **text bold**", d.Markdown(ctx, testBlock()))
+	assert.Equal(t, "", d.Markdown(ctx, testBlockNil()))
+}
@@ -0,0 +1,42 @@
+package quoteblock
+
+import (
+	"context"
+	"encoding/json"
+
+	brzrpc "github.com/autumnterror/breezynotes/api/proto/gen"
+	"github.com/autumnterror/breezynotes/internal/blocknote/domain/domainblocks"
+	"github.com/autumnterror/breezynotes/internal/blocknote/pkg/document"
+	"github.com/autumnterror/utils_go/pkg/utils/format"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// docData is data of quote block in document, quote is plain text
+type docData struct {
+	Text string `json:"text"`
+}
+
+func (d *Driver) Document(ctx context.Context, block *brzrpc.Block) (json.RawMessage, error) {
+	const op = "quoteblock.Document"
+	b, err := domainblocks.FromUnifiedToQuoteBlock(block)
+	if err != nil {
+		return nil, format.Error(op, err)
+	}
+	if b.Data == nil {
+		b.Data = &domainblocks.QuoteData{}
+	}
+	return json.Marshal(docData{Text: b.Data.Text})
+}
+
+func (d *Driver) FromDocument(ctx context.Context, data json.RawMessage) (*structpb.Struct, error) {
+	const op = "quoteblock.FromDocument"
+	var dd docData
+	if err := document.DecodeData(data, &dd, "text"); err != nil {
+		return nil, format.Error(op, err)
+	}
+	return structpb.NewStruct((&domainblocks.QuoteData{Text: dd.Text}).ToMap())
+}
+
+func (d *Driver) Schema() map[string]any {
+	return document.Object(map[string]any{"text": map[string]any{"type": "string"}}, "text")
+}
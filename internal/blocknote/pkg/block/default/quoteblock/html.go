@@ -0,0 +1,18 @@
+package quoteblock
+
+import (
+	"context"
+	"strings"
+
+	brzrpc "github.com/autumnterror/breezynotes/api/proto/gen"
+	"github.com/autumnterror/breezynotes/internal/blocknote/domain/domainblocks"
+	"github.com/autumnterror/breezynotes/internal/blocknote/pkg/render"
+)
+
+func (d *Driver) HTML(ctx context.Context, block *brzrpc.Block, files render.Files) string {
+	b, err := domainblocks.FromUnifiedToQuoteBlock(block)
+	if err != nil || b.Data == nil {
+		return ""
+	}
+	return "<blockquote><p>" + strings.ReplaceAll(render.Escape(b.Data.Text), "\n", "<br />") + "</p></blockquote>"
+}
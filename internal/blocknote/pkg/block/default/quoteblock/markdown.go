@@ -0,0 +1,19 @@
+package quoteblock
+
+import (
+	"context"
+	"strings"
+
+	brzrpc "github.com/autumnterror/breezynotes/api/proto/gen"
+	"github.com/autumnterror/breezynotes/internal/blocknote/domain/domainblocks"
+	"github.com/autumnterror/breezynotes/internal/blocknote/pkg/text"
+)
+
+func (d *Driver) Markdown(ctx context.Context, block *brzrpc.Block) string {
+	b, err := domainblocks.FromUnifiedToQuoteBlock(block)
+	if err != nil || b.Data == nil {
+		return ""
+	}
+	lines := strings.Split(text.EscapeMarkdown(b.Data.Text), "\n")
+	return "> " + strings.Join(lines, "\n> ")
+}
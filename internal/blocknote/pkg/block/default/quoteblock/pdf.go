@@ -0,0 +1,18 @@
+package quoteblock
+
+import (
+	"context"
+
+	brzrpc "github.com/autumnterror/breezynotes/api/proto/gen"
+	"github.com/autumnterror/breezynotes/internal/blocknote/domain/domainblocks"
+	"github.com/autumnterror/breezynotes/internal/blocknote/pkg/render"
+	"github.com/autumnterror/breezynotes/internal/blocknote/pkg/render/pdf"
+)
+
+func (d *Driver) PDF(ctx context.Context, block *brzrpc.Block, doc *pdf.Doc, files render.Files) {
+	b, err := domainblocks.FromUnifiedToQuoteBlock(block)
+	if err != nil || b.Data == nil {
+		return
+	}
+	doc.Text([]pdf.Span{{Text: b.Data.Text, Style: pdf.Style{Color: pdf.Gray}}}, pdf.Par{Before: 8, Indent: 16, Bar: true})
+}
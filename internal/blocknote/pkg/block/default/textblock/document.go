@@ -0,0 +1,47 @@
+package textblock
+
+import (
+	"context"
+	"encoding/json"
+
+	brzrpc "github.com/autumnterror/breezynotes/api/proto/gen"
+	"github.com/autumnterror/breezynotes/internal/blocknote/domain/domainblocks"
+	"github.com/autumnterror/breezynotes/internal/blocknote/pkg/document"
+	"github.com/autumnterror/breezynotes/internal/blocknote/pkg/text"
+	"github.com/autumnterror/utils_go/pkg/utils/format"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// docData is data of text block in document
+type docData struct {
+	Text []document.Span `json:"text"`
+}
+
+func (tb *Driver) Document(ctx context.Context, block *brzrpc.Block) (json.RawMessage, error) {
+	const op = "textblock.Document"
+	b, err := domainblocks.FromUnifiedToTextBlock(block)
+	if err != nil {
+		return nil, format.Error(op, err)
+	}
+	var td *text.Data
+	if b.Data != nil {
+		td = b.Data.TextData
+	}
+	return json.Marshal(docData{Text: td.Document()})
+}
+
+func (tb *Driver) FromDocument(ctx context.Context, data json.RawMessage) (*structpb.Struct, error) {
+	const op = "textblock.FromDocument"
+	var d docData
+	if err := document.DecodeData(data, &d, "text"); err != nil {
+		return nil, format.Error(op, err)
+	}
+	if err := document.CheckText(d.Text); err != nil {
+		return nil, format.Error(op, err)
+	}
+	return structpb.NewStruct((&domainblocks.TextData{TextData: text.FromDocument(d.Text)}).ToMap())
+}
+
+func (tb *Driver) Schema() map[string]any {
+	return document.Object(map[string]any{"text": document.TextSchema()}, "text")
+}
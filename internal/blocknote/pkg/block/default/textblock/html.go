@@ -0,0 +1,17 @@
+package textblock
+
+import (
+	"context"
+
+	brzrpc "github.com/autumnterror/breezynotes/api/proto/gen"
+	"github.com/autumnterror/breezynotes/internal/blocknote/domain/domainblocks"
+	"github.com/autumnterror/breezynotes/internal/blocknote/pkg/render"
+)
+
+func (tb *Driver) HTML(ctx context.Context, block *brzrpc.Block, files render.Files) string {
+	b, err := domainblocks.FromUnifiedToTextBlock(block)
+	if err != nil || b.Data == nil {
+		return ""
+	}
+	return "<p>" + b.Data.TextData.HTML() + "</p>"
+}
@@ -0,0 +1,16 @@
+package textblock
+
+import (
+	"context"
+
+	brzrpc "github.com/autumnterror/breezynotes/api/proto/gen"
+	"github.com/autumnterror/breezynotes/internal/blocknote/domain/domainblocks"
+)
+
+func (tb *Driver) Markdown(ctx context.Context, block *brzrpc.Block) string {
+	b, err := domainblocks.FromUnifiedToTextBlock(block)
+	if err != nil || b.Data == nil {
+		return ""
+	}
+	return b.Data.TextData.Markdown()
+}
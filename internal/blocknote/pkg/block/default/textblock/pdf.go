@@ -0,0 +1,18 @@
+package textblock
+
+import (
+	"context"
+
+	brzrpc "github.com/autumnterror/breezynotes/api/proto/gen"
+	"github.com/autumnterror/breezynotes/internal/blocknote/domain/domainblocks"
+	"github.com/autumnterror/breezynotes/internal/blocknote/pkg/render"
+	"github.com/autumnterror/breezynotes/internal/blocknote/pkg/render/pdf"
+)
+
+func (tb *Driver) PDF(ctx context.Context, block *brzrpc.Block, d *pdf.Doc, files render.Files) {
+	b, err := domainblocks.FromUnifiedToTextBlock(block)
+	if err != nil || b.Data == nil {
+		return
+	}
+	d.Text(b.Data.TextData.Spans(pdf.Style{}), pdf.Par{Before: 6})
+}
@@ -0,0 +1,100 @@
+package block
+
+import (
+	"context"
+	"fmt"
+
+	brzrpc "github.com/autumnterror/breezynotes/api/proto/gen"
+	"github.com/autumnterror/breezynotes/internal/blocknote/domain/domainblocks"
+	"github.com/autumnterror/breezynotes/internal/blocknote/pkg/document"
+)
+
+// NoteDocument return note in document format. Block of unknown type can't be written, so it is error
+func NoteDocument(ctx context.Context, n *brzrpc.NoteWithBlocks) (*document.Document, error) {
+	dn := document.Note{
+		Id:          n.GetId(),
+		Title:       n.GetTitle(),
+		CreatedAt:   n.GetCreatedAt(),
+		UpdatedAt:   n.GetUpdatedAt(),
+		Author:      n.GetAuthor(),
+		Editors:     n.GetEditors(),
+		Readers:     n.GetReaders(),
+		IsPublic:    n.GetIsPublic(),
+		IsBlog:      n.GetIsBlog(),
+		WorkspaceId: n.GetWorkspaceId(),
+		Blocks:      make([]document.Block, 0, len(n.GetBlocks())),
+	}
+	if t := n.GetTag(); t != nil {
+		dn.Tag = &document.Tag{Id: t.GetId(), Title: t.GetTitle(), Color: t.GetColor(), Emoji: t.GetEmoji()}
+	}
+
+	for i, b := range n.GetBlocks() {
+		r := Registry[b.GetType()]
+		if r == nil {
+			return nil, fmt.Errorf("block %d of type %q: %w", i, b.GetType(), domainblocks.ErrUnsupportedType)
+		}
+		data, err := r.Document(ctx, b)
+		if err != nil {
+			return nil, fmt.Errorf("block %d: %w", i, err)
+		}
+		dn.Blocks = append(dn.Blocks, document.Block{
+			Id:        b.GetId(),
+			Type:      b.GetType(),
+			CreatedAt: b.GetCreatedAt(),
+			UpdatedAt: b.GetUpdatedAt(),
+			Data:      data,
+		})
+	}
+	return document.New(dn), nil
+}
+
+// NoteFromDocument return note with blocks of document. Data of blocks is checked by drivers of their types,
+// error of check is document.ErrInvalid
+func NoteFromDocument(ctx context.Context, d *document.Document) (*brzrpc.NoteWithBlocks, error) {
+	dn := d.Note
+	n := &brzrpc.NoteWithBlocks{
+		Id:          dn.Id,
+		Title:       dn.Title,
+		CreatedAt:   dn.CreatedAt,
+		UpdatedAt:   dn.UpdatedAt,
+		Author:      dn.Author,
+		Editors:     dn.Editors,
+		Readers:     dn.Readers,
+		IsPublic:    dn.IsPublic,
+		IsBlog:      dn.IsBlog,
+		WorkspaceId: dn.WorkspaceId,
+		Blocks:      make([]*brzrpc.Block, 0, len(dn.Blocks)),
+	}
+	if dn.Tag != nil {
+		n.Tag = &brzrpc.Tag{Id: dn.Tag.Id, Title: dn.Tag.Title, Color: dn.Tag.Color, Emoji: dn.Tag.Emoji}
+	}
+
+	for i, b := range dn.Blocks {
+		r := Registry[b.Type]
+		if r == nil {
+			return nil, fmt.Errorf("%w: note.blocks[%d]: unknown type %q", document.ErrInvalid, i, b.Type)
+		}
+		data, err := r.FromDocument(ctx, b.Data)
+		if err != nil {
+			return nil, fmt.Errorf("%w: note.blocks[%d]: %v", document.ErrInvalid, i, err)
+		}
+		n.Blocks = append(n.Blocks, &brzrpc.Block{
+			Id:        b.Id,
+			Type:      b.Type,
+			NoteId:    dn.Id,
+			CreatedAt: b.CreatedAt,
+			UpdatedAt: b.UpdatedAt,
+			Data:      data,
+		})
+	}
+	return n, nil
+}
+
+// DocumentSchema return JSON schema of document with data of registered types
+func DocumentSchema() map[string]any {
+	blocks := make(map[string]map[string]any, len(Registry))
+	for t, r := range Registry {
+		blocks[t] = r.Schema()
+	}
+	return document.Schema(blocks)
+}
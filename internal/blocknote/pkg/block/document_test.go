@@ -0,0 +1,161 @@
+package block_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	brzrpc "github.com/autumnterror/breezynotes/api/proto/gen"
+	"github.com/autumnterror/breezynotes/internal/blocknote/domain/domainblocks"
+	"github.com/autumnterror/breezynotes/internal/blocknote/pkg/block"
+	"github.com/autumnterror/breezynotes/internal/blocknote/pkg/block/default/codeblock"
+	"github.com/autumnterror/breezynotes/internal/blocknote/pkg/block/default/fileblock"
+	"github.com/autumnterror/breezynotes/internal/blocknote/pkg/block/default/headerblock"
+	"github.com/autumnterror/breezynotes/internal/blocknote/pkg/block/default/imgblock"
+	"github.com/autumnterror/breezynotes/internal/blocknote/pkg/block/default/linkblock"
+	"github.com/autumnterror/breezynotes/internal/blocknote/pkg/block/default/listblock"
+	"github.com/autumnterror/breezynotes/internal/blocknote/pkg/block/default/quoteblock"
+	"github.com/autumnterror/breezynotes/internal/blocknote/pkg/block/default/textblock"
+	"github.com/autumnterror/breezynotes/internal/blocknote/pkg/document"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+func registerAll() {
+	block.RegisterBlock("text", &textblock.Driver{})
+	block.RegisterBlock("code", &codeblock.Driver{})
+	block.RegisterBlock("file", &fileblock.Driver{})
+	block.RegisterBlock("header", &headerblock.Driver{})
+	block.RegisterBlock("img", &imgblock.Driver{})
+	block.RegisterBlock("link", &linkblock.Driver{})
+	block.RegisterBlock("list", &listblock.Driver{})
+	block.RegisterBlock("quote", &quoteblock.Driver{})
+}
+
+// docSamples data of every type as it is stored
+var docSamples = map[string]map[string]any{
+	"text": {"text_data": map[string]any{"text": []any{
+		map[string]any{"style": "default", "string": "plain "},
+		map[string]any{"style": "bold italic", "string": "both"},
+	}}},
+	"code":   {"text": "x := 1\n", "lang": "go"},
+	"file":   {"src": "/files/a.pdf"},
+	"header": {"level": 2, "text_data": map[string]any{"text": []any{map[string]any{"style": "code", "string": "h"}}}},
+	"img":    {"src": "/files/a.png", "alt": "pic"},
+	"link":   {"text": "site", "url": "https://example.com"},
+	"list":   {"type": "ordered", "level": 1, "value": 3, "text_data": map[string]any{"text": []any{map[string]any{"style": "strikethrough", "string": "item"}}}},
+	"quote":  {"text": "line\nline"},
+}
+
+func TestDocumentRoundTrip(t *testing.T) {
+	registerAll()
+	ctx := context.Background()
+
+	for _, typ := range block.GetRegisteredTypes() {
+		sample, ok := docSamples[typ]
+		require.True(t, ok, "no sample of registered type %s", typ)
+
+		data, err := structpb.NewStruct(sample)
+		require.NoError(t, err)
+		n := &brzrpc.NoteWithBlocks{
+			Id:        "note",
+			Title:     "Note of " + typ,
+			CreatedAt: 10,
+			UpdatedAt: 20,
+			Author:    "author",
+			Editors:   []string{"editor"},
+			IsBlog:    true,
+			Tag:       &brzrpc.Tag{Id: "tag", Title: "Tag", Color: "red", Emoji: "x"},
+			Blocks:    []*brzrpc.Block{{Id: "b", Type: typ, NoteId: "note", CreatedAt: 11, UpdatedAt: 12, Data: data}},
+		}
+
+		d, err := block.NoteDocument(ctx, n)
+		require.NoError(t, err, typ)
+		raw, err := d.Encode()
+		require.NoError(t, err, typ)
+
+		decoded, err := document.Decode(raw)
+		require.NoError(t, err, typ)
+		res, err := block.NoteFromDocument(ctx, decoded)
+		require.NoError(t, err, typ)
+		assert.True(t, proto.Equal(n, res), "%s: note is not same after round trip\n%s", typ, raw)
+
+		again, err := block.NoteDocument(ctx, res)
+		require.NoError(t, err, typ)
+		rawAgain, err := again.Encode()
+		require.NoError(t, err, typ)
+		assert.JSONEq(t, string(raw), string(rawAgain), typ)
+
+		checkSchema(t, typ, block.Registry[typ].Schema(), d.Note.Blocks[0].Data)
+	}
+}
+
+// checkSchema check that data has required fields of schema and no other fields
+func checkSchema(t *testing.T, typ string, schema map[string]any, data json.RawMessage) {
+	t.Helper()
+	var fields map[string]any
+	require.NoError(t, json.Unmarshal(data, &fields), typ)
+
+	props := schema["properties"].(map[string]any)
+	for f := range fields {
+		assert.Contains(t, props, f, "%s: field is not in schema", typ)
+	}
+	for _, f := range schema["required"].([]any) {
+		assert.Contains(t, fields, f, "%s: required field is not written", typ)
+	}
+}
+
+func TestDocumentEmptyData(t *testing.T) {
+	registerAll()
+	ctx := context.Background()
+
+	for _, typ := range block.GetRegisteredTypes() {
+		n := &brzrpc.NoteWithBlocks{Blocks: []*brzrpc.Block{{Type: typ}}}
+		d, err := block.NoteDocument(ctx, n)
+		require.NoError(t, err, typ)
+		_, err = block.NoteFromDocument(ctx, d)
+		assert.NoError(t, err, "%s: block without data is written as valid document", typ)
+	}
+}
+
+func TestNoteFromDocumentInvalid(t *testing.T) {
+	registerAll()
+	ctx := context.Background()
+
+	for name, b := range map[string]document.Block{
+		"unknown type":  {Type: "video", Data: json.RawMessage(`{}`)},
+		"unknown field": {Type: "text", Data: json.RawMessage(`{"text":[],"color":"red"}`)},
+		"no text":       {Type: "text", Data: json.RawMessage(`{}`)},
+		"null text":     {Type: "text", Data: json.RawMessage(`{"text":null}`)},
+		"not object":    {Type: "code", Data: json.RawMessage(`"code"`)},
+		"wrong type":    {Type: "code", Data: json.RawMessage(`{"code":1}`)},
+		"bad mark":      {Type: "text", Data: json.RawMessage(`{"text":[{"text":"a","marks":["Bold!"]}]}`)},
+		"repeated mark": {Type: "text", Data: json.RawMessage(`{"text":[{"text":"a","marks":["bold","bold"]}]}`)},
+		"bad kind":      {Type: "list", Data: json.RawMessage(`{"kind":"star","text":[]}`)},
+		"neg level":     {Type: "header", Data: json.RawMessage(`{"level":-1,"text":[]}`)},
+		"no src":        {Type: "img", Data: json.RawMessage(`{"alt":"a"}`)},
+	} {
+		_, err := block.NoteFromDocument(ctx, document.New(document.Note{Blocks: []document.Block{b}}))
+		assert.ErrorIs(t, err, document.ErrInvalid, name)
+	}
+}
+
+func TestNoteDocumentUnknownType(t *testing.T) {
+	registerAll()
+
+	_, err := block.NoteDocument(context.Background(), &brzrpc.NoteWithBlocks{Blocks: []*brzrpc.Block{{Type: "video"}}})
+	assert.ErrorIs(t, err, domainblocks.ErrUnsupportedType)
+}
+
+func TestDocumentSchema(t *testing.T) {
+	registerAll()
+
+	defs := block.DocumentSchema()["$defs"].(map[string]any)
+	for _, typ := range block.GetRegisteredTypes() {
+		assert.Contains(t, defs, typ)
+	}
+	_, err := json.Marshal(block.DocumentSchema())
+	assert.NoError(t, err)
+}
@@ -0,0 +1,418 @@
+package block
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	brzrpc "github.com/autumnterror/breezynotes/api/proto/gen"
+	"github.com/autumnterror/breezynotes/internal/blocknote/domain/domainblocks"
+	"github.com/autumnterror/breezynotes/internal/blocknote/pkg/render"
+	"github.com/autumnterror/breezynotes/internal/blocknote/pkg/text"
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// htmlHeaders level of header block is same as of tag, renderers show levels deeper than 5 as 5
+var htmlHeaders = map[atom.Atom]uint{
+	atom.H1: 1, atom.H2: 2, atom.H3: 3, atom.H4: 4, atom.H5: 5, atom.H6: 6,
+}
+
+var htmlStyles = map[atom.Atom]string{
+	atom.B: "bold", atom.Strong: "bold",
+	atom.I: "italic", atom.Em: "italic",
+	atom.S: "strikethrough", atom.Strike: "strikethrough", atom.Del: "strikethrough",
+	atom.U: "underline", atom.Ins: "underline",
+	atom.Code: "code", atom.Kbd: "code", atom.Samp: "code",
+}
+
+// htmlSkipped elements which are not content, they are dropped with children
+var htmlSkipped = map[atom.Atom]bool{
+	atom.Head: true, atom.Script: true, atom.Style: true, atom.Noscript: true, atom.Template: true,
+	atom.Iframe: true, atom.Object: true, atom.Embed: true, atom.Svg: true, atom.Math: true, atom.Canvas: true,
+	atom.Audio: true, atom.Video: true, atom.Button: true, atom.Select: true, atom.Textarea: true,
+}
+
+// htmlBlocks elements which start new paragraph
+var htmlBlocks = map[atom.Atom]bool{
+	atom.P: true, atom.Div: true, atom.Section: true, atom.Article: true, atom.Header: true, atom.Footer: true,
+	atom.Main: true, atom.Aside: true, atom.Nav: true, atom.Figure: true, atom.Figcaption: true, atom.Table: true,
+	atom.Tr: true, atom.Dl: true, atom.Dt: true, atom.Dd: true, atom.Details: true, atom.Summary: true, atom.Address: true,
+}
+
+// FromHTML convert html to blocks like FromMarkdown: h1-h6 are headers of same level, ul and ol are lists, checkboxes make todo,
+// pre is code, blockquote is quote, img and a are img and link blocks after text, a with download attribute is file block. Scripts, styles, media and forms
+// are dropped, links and images are kept only with http, https, mailto or relative urls.
+// Checklists and code of Notion and Evernote are also read
+func FromHTML(ctx context.Context, src string) []*brzrpc.Block {
+	doc, err := html.Parse(strings.NewReader(src))
+	if err != nil {
+		return nil
+	}
+	c := &htmlConverter{mdConverter: mdConverter{ctx: ctx}}
+	c.node(doc)
+	c.flushText()
+	return c.blocks
+}
+
+type htmlConverter struct {
+	mdConverter
+
+	// paragraph which is read now
+	parts  []text.Part
+	links  []text.Link
+	styles []string
+	// todo is set by checkbox: 0 there is no checkbox, 1 unchecked, 2 checked
+	todo int
+	// item of list which is read now
+	item *domainblocks.ListData
+}
+
+func (c *htmlConverter) walk(n *html.Node) {
+	for ch := n.FirstChild; ch != nil; ch = ch.NextSibling {
+		c.node(ch)
+	}
+}
+
+func (c *htmlConverter) node(n *html.Node) {
+	switch n.Type {
+	case html.DocumentNode:
+		c.walk(n)
+		return
+	case html.TextNode:
+		c.text(n.Data)
+		return
+	case html.ElementNode:
+	default:
+		return
+	}
+
+	a := n.DataAtom
+	switch {
+	case htmlSkipped[a]:
+	case a == atom.Pre || htmlStyle(n, "-en-codeblock:true"):
+		c.flushText()
+		c.code(n)
+	case htmlHeaders[a] > 0:
+		c.flushText()
+		c.walk(n)
+		data, links := c.take()
+		if len(data.Text) > 0 {
+			c.add(headerType, (&domainblocks.HeaderData{TextData: data, Level: htmlHeaders[a]}).ToMap())
+		}
+		c.addLinks(links)
+	case a == atom.Ul || a == atom.Ol:
+		c.flushText()
+		c.list(n, 0)
+	case a == atom.Blockquote:
+		c.flushText()
+		c.walk(n)
+		data, links := c.take()
+		if s := strings.TrimSpace(data.PlainText()); s != "" {
+			c.add(quoteType, (&domainblocks.QuoteData{Text: s}).ToMap())
+		}
+		c.addLinks(links)
+	case a == atom.Br:
+		c.parts = append(c.parts, text.Part{Style: c.style(), String: "\n"})
+	case a == atom.Hr:
+		c.flushText()
+	case a == atom.Img:
+		if src := render.SafeUrl(attr(n, "src"), false); src != "" {
+			c.links = append(c.links, text.Link{Text: attr(n, "alt"), Url: src, Image: true})
+		}
+	case a == atom.A:
+		c.link(n)
+	case a == atom.Input:
+		if strings.EqualFold(attr(n, "type"), "checkbox") {
+			c.checkbox(hasAttr(n, "checked"))
+		}
+	case htmlClass(n, "checkbox-on"), htmlClass(n, "checkbox-off"):
+		// checkbox of Notion export
+		c.checkbox(htmlClass(n, "checkbox-on"))
+	case htmlStyles[a] != "":
+		prev := c.styles
+		c.styles = withStyle(c.styles, htmlStyles[a])
+		c.walk(n)
+		c.styles = prev
+	case htmlBlocks[a]:
+		// paragraphs inside of list item stay in it
+		if c.item != nil {
+			c.newLine()
+			c.walk(n)
+			return
+		}
+		c.flushText()
+		c.walk(n)
+		c.flushText()
+	case a == atom.Td || a == atom.Th:
+		c.walk(n)
+		c.text(" ")
+	default:
+		c.walk(n)
+	}
+}
+
+// text add text with whitespace collapsed like browser does. Non-breaking spaces are kept
+func (c *htmlConverter) text(s string) {
+	if s == "" {
+		return
+	}
+	words := strings.FieldsFunc(s, func(r rune) bool { return r < utf8.RuneSelf && isSpace(byte(r)) })
+	var sb strings.Builder
+	if isSpace(s[0]) && !c.endsWithSpace() {
+		sb.WriteByte(' ')
+	}
+	sb.WriteString(strings.Join(words, " "))
+	if len(words) > 0 && isSpace(s[len(s)-1]) {
+		sb.WriteByte(' ')
+	}
+	if sb.Len() > 0 {
+		c.parts = append(c.parts, text.Part{Style: c.style(), String: sb.String()})
+	}
+}
+
+// endsWithSpace paragraph is empty or ends with space or new line, so next space isn't needed
+func (c *htmlConverter) endsWithSpace() bool {
+	for i := len(c.parts) - 1; i >= 0; i-- {
+		if s := c.parts[i].String; s != "" {
+			return isSpace(s[len(s)-1])
+		}
+	}
+	return true
+}
+
+func (c *htmlConverter) newLine() {
+	if len(c.parts) > 0 {
+		c.parts = append(c.parts, text.Part{Style: "default", String: "\n"})
+	}
+}
+
+func (c *htmlConverter) style() string {
+	if len(c.styles) == 0 {
+		return "default"
+	}
+	return strings.Join(c.styles, " ")
+}
+
+func (c *htmlConverter) checkbox(checked bool) {
+	c.todo = 1
+	if checked {
+		c.todo = 2
+	}
+}
+
+func (c *htmlConverter) link(n *html.Node) {
+	href := render.SafeUrl(attr(n, "href"), true)
+	if href == "" || strings.HasPrefix(href, "#") {
+		c.walk(n)
+		return
+	}
+	c.walk(n)
+	if wrapsImage(n, href) {
+		// link around image to same file, like Notion makes, is image only
+		return
+	}
+	// title is read from link itself, block content inside of it flushes paragraph
+	var title strings.Builder
+	rawText(n, &title)
+	c.links = append(c.links, text.Link{Text: strings.Join(strings.Fields(title.String()), " "), Url: href, File: hasAttr(n, "download")})
+}
+
+// wrapsImage element has image with src
+func wrapsImage(n *html.Node, src string) bool {
+	for ch := n.FirstChild; ch != nil; ch = ch.NextSibling {
+		if ch.DataAtom == atom.Img && strings.TrimSpace(attr(ch, "src")) == src || wrapsImage(ch, src) {
+			return true
+		}
+	}
+	return false
+}
+
+// code write pre or code block of Evernote. Lang is taken from class language-x or lang-x of it or of code inside
+func (c *htmlConverter) code(n *html.Node) {
+	var sb strings.Builder
+	rawText(n, &sb)
+	lang := htmlLang(n)
+	for ch := n.FirstChild; ch != nil && lang == ""; ch = ch.NextSibling {
+		if ch.DataAtom == atom.Code {
+			lang = htmlLang(ch)
+		}
+	}
+	code := strings.Trim(sb.String(), "\n")
+	if code == "" {
+		return
+	}
+	c.add(codeType, (&domainblocks.CodeData{Text: code, Lang: lang}).ToMap())
+}
+
+// list write items of ul or ol, nested lists are on next level
+func (c *htmlConverter) list(n *html.Node, level int) {
+	num := 1
+	if s, err := strconv.Atoi(attr(n, "start")); err == nil {
+		num = s
+	}
+	// lists of Notion and Evernote with checkboxes
+	todoList := htmlClass(n, "to-do-list") || htmlStyle(n, "--en-todo:true")
+
+	for li := n.FirstChild; li != nil; li = li.NextSibling {
+		if li.Type != html.ElementNode {
+			continue
+		}
+		if li.DataAtom == atom.Ul || li.DataAtom == atom.Ol {
+			c.list(li, level+1)
+			continue
+		}
+		if li.DataAtom != atom.Li {
+			continue
+		}
+
+		item := &domainblocks.ListData{Level: uint(level), Type: domainblocks.ListBlockUnorderedType}
+		if n.DataAtom == atom.Ol {
+			item.Type, item.Value = domainblocks.ListBlockOrderedType, num
+			num++
+		}
+		c.item, c.todo = item, 0
+		if todoList {
+			c.checkbox(htmlStyle(li, "--en-checked:true"))
+		}
+
+		var nested []*html.Node
+		for ch := li.FirstChild; ch != nil; ch = ch.NextSibling {
+			if ch.DataAtom == atom.Ul || ch.DataAtom == atom.Ol {
+				nested = append(nested, ch)
+				continue
+			}
+			c.node(ch)
+		}
+		c.flushText()
+		for _, nl := range nested {
+			c.list(nl, level+1)
+		}
+	}
+}
+
+// take text and links of paragraph which is read now and start new one
+func (c *htmlConverter) take() (*text.Data, []text.Link) {
+	parts, links := c.parts, c.links
+	c.parts, c.links = nil, nil
+
+	for len(parts) > 0 && strings.TrimSpace(parts[0].String) == "" {
+		parts = parts[1:]
+	}
+	for len(parts) > 0 && strings.TrimSpace(parts[len(parts)-1].String) == "" {
+		parts = parts[:len(parts)-1]
+	}
+	if len(parts) > 0 {
+		parts[0].String = strings.TrimLeft(parts[0].String, " \n")
+		last := len(parts) - 1
+		parts[last].String = strings.TrimRight(parts[last].String, " \n")
+	}
+	for i := range parts {
+		parts[i].String = strings.ReplaceAll(strings.ReplaceAll(parts[i].String, " \n", "\n"), "\n ", "\n")
+	}
+	return &text.Data{Text: text.MergeSameStyles(parts)}, links
+}
+
+// flushText write paragraph or item of list which is read now
+func (c *htmlConverter) flushText() {
+	item, todo := c.item, c.todo
+	c.item, c.todo = nil, 0
+	data, links := c.take()
+	empty := strings.TrimSpace(data.PlainText()) == ""
+
+	switch {
+	case item != nil || todo != 0 && !empty:
+		if item == nil {
+			item = &domainblocks.ListData{Type: domainblocks.ListBlockUnorderedType}
+		}
+		if todo != 0 {
+			item.Type, item.Value = domainblocks.ListBlockToDoType, todo-1
+		}
+		if !empty || len(links) == 0 {
+			item.TextData = data
+			c.add(listType, item.ToMap())
+		}
+	case empty:
+	case len(links) == 1 && !links[0].Image && strings.TrimSpace(data.PlainText()) == links[0].Text:
+		// paragraph of only link is block of it
+	default:
+		c.add(textType, (&domainblocks.TextData{TextData: data}).ToMap())
+	}
+	c.addLinks(links)
+}
+
+// rawText text of code with line breaks of br and blocks
+func rawText(n *html.Node, sb *strings.Builder) {
+	for ch := n.FirstChild; ch != nil; ch = ch.NextSibling {
+		switch {
+		case ch.Type == html.TextNode:
+			sb.WriteString(ch.Data)
+		case ch.Type != html.ElementNode, htmlSkipped[ch.DataAtom]:
+		case ch.DataAtom == atom.Br:
+			sb.WriteByte('\n')
+		default:
+			rawText(ch, sb)
+			if htmlBlocks[ch.DataAtom] && !strings.HasSuffix(sb.String(), "\n") {
+				sb.WriteByte('\n')
+			}
+		}
+	}
+}
+
+func htmlLang(n *html.Node) string {
+	for _, cl := range strings.Fields(attr(n, "class")) {
+		for _, p := range []string{"language-", "lang-"} {
+			if l, ok := strings.CutPrefix(cl, p); ok && l != "" {
+				return l
+			}
+		}
+	}
+	return ""
+}
+
+func attr(n *html.Node, key string) string {
+	for _, a := range n.Attr {
+		if a.Key == key {
+			return a.Val
+		}
+	}
+	return ""
+}
+
+func hasAttr(n *html.Node, key string) bool {
+	for _, a := range n.Attr {
+		if a.Key == key {
+			return a.Val != "false"
+		}
+	}
+	return false
+}
+
+func htmlClass(n *html.Node, class string) bool {
+	for _, cl := range strings.Fields(attr(n, "class")) {
+		if cl == class {
+			return true
+		}
+	}
+	return false
+}
+
+// htmlStyle inline style of element has declaration, spaces are ignored
+func htmlStyle(n *html.Node, decl string) bool {
+	return strings.Contains(strings.ReplaceAll(attr(n, "style"), " ", ""), decl)
+}
+
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\n' || c == '\t' || c == '\r' || c == '\f'
+}
+
+func withStyle(styles []string, style string) []string {
+	for _, s := range styles {
+		if s == style {
+			return styles
+		}
+	}
+	res := make([]string, 0, len(styles)+1)
+	return append(append(res, styles...), style)
+}
@@ -0,0 +1,133 @@
+package block_test
+
+import (
+	"context"
+	"testing"
+
+	brzrpc "github.com/autumnterror/breezynotes/api/proto/gen"
+	"github.com/autumnterror/breezynotes/internal/blocknote/pkg/block"
+	"github.com/autumnterror/breezynotes/internal/blocknote/pkg/block/default/codeblock"
+	"github.com/autumnterror/breezynotes/internal/blocknote/pkg/block/default/fileblock"
+	"github.com/autumnterror/breezynotes/internal/blocknote/pkg/block/default/headerblock"
+	"github.com/autumnterror/breezynotes/internal/blocknote/pkg/block/default/imgblock"
+	"github.com/autumnterror/breezynotes/internal/blocknote/pkg/block/default/linkblock"
+	"github.com/autumnterror/breezynotes/internal/blocknote/pkg/block/default/listblock"
+	"github.com/autumnterror/breezynotes/internal/blocknote/pkg/block/default/quoteblock"
+	"github.com/autumnterror/breezynotes/internal/blocknote/pkg/block/default/textblock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFromHTML(t *testing.T) {
+	block.RegisterBlock("text", &textblock.Driver{})
+	block.RegisterBlock("list", &listblock.Driver{})
+	block.RegisterBlock("header", &headerblock.Driver{})
+	block.RegisterBlock("code", &codeblock.Driver{})
+	block.RegisterBlock("quote", &quoteblock.Driver{})
+	block.RegisterBlock("img", &imgblock.Driver{})
+	block.RegisterBlock("link", &linkblock.Driver{})
+
+	src := `<html><head><title>x</title><style>p{}</style></head><body>
+<h2>Plan</h2>
+<p>intro  with <b>bold</b><br>and <a href="https://x.y">docs</a><script>alert(1)</script></p>
+<ul>
+  <li>one
+    <ul><li><input type="checkbox" checked> two</li></ul>
+  </li>
+</ul>
+<ol start="3"><li><p>three</p></li></ol>
+<blockquote>quoted <i>text</i></blockquote>
+<pre><code class="language-go">fmt.Println(1)
+fmt.Println(2)</code></pre>
+<p><img src="cat.png" alt="cat"><img src="data:image/png;base64,AAAA"></p>
+<p><a href="javascript:alert(1)">bad</a> <a href="https://a.b">only link</a></p>
+<div style="--en-codeblock:true"><div>a := 1</div><div>b := 2</div></div>
+<ul class="to-do-list"><li><div class="checkbox checkbox-off"></div> four</li></ul>
+</body></html>`
+
+	blks := block.FromHTML(context.Background(), src)
+
+	var types []string
+	for _, b := range blks {
+		types = append(types, b.GetType())
+	}
+	require.Equal(t, []string{"header", "text", "link", "list", "list", "list", "quote", "code", "img", "text", "link", "code", "list"}, types)
+
+	data := func(i int) map[string]any { return blks[i].GetData().AsMap() }
+
+	assert.Equal(t, float64(2), data(0)["level"])
+	assert.Equal(t, "intro with **bold**\nand docs", block.Registry["text"].Markdown(context.Background(), blks[1]))
+	assert.Equal(t, map[string]any{"text": "docs", "url": "https://x.y"}, data(2))
+
+	assert.Equal(t, "unordered", data(3)["type"])
+	assert.Equal(t, float64(0), data(3)["level"])
+	assert.Equal(t, "todo", data(4)["type"])
+	assert.Equal(t, float64(1), data(4)["level"])
+	assert.Equal(t, float64(1), data(4)["value"])
+	assert.Equal(t, "ordered", data(5)["type"])
+	assert.Equal(t, float64(3), data(5)["value"])
+
+	assert.Equal(t, "quoted text", data(6)["text"])
+	assert.Equal(t, "fmt.Println(1)\nfmt.Println(2)", data(7)["text"])
+	assert.Equal(t, "go", data(7)["lang"])
+	assert.Equal(t, map[string]any{"src": "cat.png", "alt": "cat"}, data(8), "data url is dropped")
+	assert.Equal(t, "bad only link", block.Registry["text"].Markdown(context.Background(), blks[9]), "javascript link is text")
+	assert.Equal(t, map[string]any{"text": "only link", "url": "https://a.b"}, data(10))
+	assert.Equal(t, "a := 1\nb := 2", data(11)["text"])
+	assert.Equal(t, "todo", data(12)["type"])
+	assert.Equal(t, float64(0), data(12)["value"])
+}
+
+func TestFromHTMLHeaders(t *testing.T) {
+	block.RegisterBlock("header", &headerblock.Driver{})
+
+	blks := block.FromHTML(context.Background(), `<h1>a</h1><h2>b</h2><h3>c</h3><h4>d</h4><h5>e</h5><h6>f</h6>`)
+	require.Len(t, blks, 6)
+	for i, b := range blks {
+		assert.Equal(t, "header", b.GetType())
+		assert.Equal(t, float64(i+1), b.GetData().AsMap()["level"], "level of h%d is kept", i+1)
+	}
+	assert.Equal(t, "<h6>f</h6>", block.Registry["header"].HTML(context.Background(), blks[5], nil), "deepest header is rendered as h6")
+}
+
+func TestFromHTMLFiles(t *testing.T) {
+	block.RegisterBlock("text", &textblock.Driver{})
+	block.RegisterBlock("img", &imgblock.Driver{})
+	block.RegisterBlock("link", &linkblock.Driver{})
+	block.RegisterBlock("file", &fileblock.Driver{})
+
+	src := `<figure><a href="cat.png"><img src="cat.png"></a></figure>
+<div><a href="a.pdf" download>a.pdf</a></div>
+<p><a href="b.pdf">b.pdf</a></p>`
+
+	blks := block.FromHTML(context.Background(), src)
+
+	var types []string
+	for _, b := range blks {
+		types = append(types, b.GetType())
+	}
+	require.Equal(t, []string{"img", "file", "link"}, types, "link around image is dropped")
+	assert.Equal(t, map[string]any{"src": "a.pdf"}, blks[1].GetData().AsMap())
+}
+
+func TestFromHTMLLinkAroundBlocks(t *testing.T) {
+	block.RegisterBlock("text", &textblock.Driver{})
+	block.RegisterBlock("list", &listblock.Driver{})
+	block.RegisterBlock("header", &headerblock.Driver{})
+	block.RegisterBlock("link", &linkblock.Driver{})
+
+	for name, src := range map[string]string{
+		"div": `<div>hello <a href="https://x.org"><div>card</div></a></div>`,
+		"p":   `<li>x <a href="https://x.org"><p>card</p></a></li>`,
+		"h2":  `<p>text <a href="https://x.org"><h2>card</h2></a></p>`,
+		"ul":  `text <a href="https://x.org"><ul><li>card</li></ul></a>`,
+	} {
+		var blks []*brzrpc.Block
+		require.NotPanics(t, func() { blks = block.FromHTML(context.Background(), src) }, name)
+		require.NotEmpty(t, blks, name)
+
+		last := blks[len(blks)-1]
+		assert.Equal(t, "link", last.GetType(), name)
+		assert.Equal(t, map[string]any{"text": "card", "url": "https://x.org"}, last.GetData().AsMap(), name)
+	}
+}
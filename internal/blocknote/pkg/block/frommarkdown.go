@@ -0,0 +1,248 @@
+package block
+
+import (
+	"context"
+	"regexp"
+	"strconv"
+	"strings"
+
+	brzrpc "github.com/autumnterror/breezynotes/api/proto/gen"
+	"github.com/autumnterror/breezynotes/internal/blocknote/domain/domainblocks"
+	"github.com/autumnterror/breezynotes/internal/blocknote/pkg/text"
+)
+
+var (
+	mdHeader   = regexp.MustCompile(`^(#{1,6})\s+(.*?)\s*#*\s*$`)
+	mdListItem = regexp.MustCompile(`^([ \t]*)([-*+]|\d{1,9}[.)])\s+(?:\[([ xX])\]\s+)?(.*)$`)
+	mdFence    = regexp.MustCompile("^[ \t]*(`{3,}|~{3,})\\s*([^`\\s]*)")
+	mdRule     = regexp.MustCompile(`^[ \t]*([-*_])([ \t]*[-*_]){2,}[ \t]*$`)
+	// logseqMarker task marker at start of list item of Logseq
+	logseqMarker = regexp.MustCompile(`^(TODO|DOING|NOW|LATER|WAITING|DONE|CANCELED)\s+`)
+)
+
+// FromMarkdown convert markdown to blocks: headers, lists with todo, code, quotes, images, links and text.
+// Blocks are made by Create of drivers from Registry, types which are not registered are written as text.
+// Links and images inside of text become link and img blocks right after it, text keeps only their titles.
+// Blocks have only type and data
+func FromMarkdown(ctx context.Context, md string) []*brzrpc.Block {
+	c := &mdConverter{ctx: ctx}
+	lines := strings.Split(strings.ReplaceAll(md, "\r\n", "\n"), "\n")
+
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+
+		if m := mdFence.FindStringSubmatch(line); m != nil {
+			c.flush()
+			var code []string
+			for i++; i < len(lines); i++ {
+				if strings.HasPrefix(strings.TrimSpace(lines[i]), m[1]) {
+					break
+				}
+				code = append(code, lines[i])
+			}
+			c.add(codeType, (&domainblocks.CodeData{Text: strings.Join(code, "\n"), Lang: m[2]}).ToMap())
+			continue
+		}
+
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case trimmed == "":
+			c.flush()
+
+		case mdRule.MatchString(line):
+			c.flush()
+
+		case mdHeader.MatchString(trimmed):
+			c.flush()
+			m := mdHeader.FindStringSubmatch(trimmed)
+			data, links := text.ParseMarkdown(m[2])
+			c.add(headerType, (&domainblocks.HeaderData{TextData: data, Level: uint(min(len(m[1]), 3))}).ToMap())
+			c.addLinks(links)
+
+		case strings.HasPrefix(trimmed, ">"):
+			if c.kind != quoteType {
+				c.flush()
+				c.kind = quoteType
+			}
+			c.lines = append(c.lines, strings.TrimPrefix(strings.TrimPrefix(trimmed, ">"), " "))
+
+		case trimmed == "-" || trimmed == "*" || trimmed == "+":
+			// empty list item, Logseq writes empty blocks of outline so
+			c.flush()
+
+		case mdListItem.MatchString(line):
+			c.flush()
+			c.listItem(mdListItem.FindStringSubmatch(line))
+
+		default:
+			switch c.kind {
+			case listType:
+				// continuation of list item
+				c.lines = append(c.lines, trimmed)
+			case quoteType:
+				c.flush()
+				fallthrough
+			default:
+				c.kind = textType
+				c.lines = append(c.lines, trimmed)
+			}
+		}
+	}
+	c.flush()
+
+	return c.blocks
+}
+
+const (
+	textType   = "text"
+	headerType = "header"
+	codeType   = "code"
+	quoteType  = "quote"
+	imgType    = "img"
+	linkType   = "link"
+	fileType   = "file"
+)
+
+type mdConverter struct {
+	ctx    context.Context
+	blocks []*brzrpc.Block
+
+	// block which is read now
+	kind  string
+	lines []string
+	list  domainblocks.ListData
+
+	// indents of open lists, index is level
+	indents []int
+}
+
+// listItem start list item, m is match of mdListItem
+func (c *mdConverter) listItem(m []string) {
+	indent := len(strings.ReplaceAll(m[1], "\t", "    "))
+	for len(c.indents) > 0 && indent < c.indents[len(c.indents)-1] {
+		c.indents = c.indents[:len(c.indents)-1]
+	}
+	if len(c.indents) == 0 || indent > c.indents[len(c.indents)-1] {
+		c.indents = append(c.indents, indent)
+	}
+
+	item := m[4]
+	c.list = domainblocks.ListData{Level: uint(len(c.indents) - 1), Type: domainblocks.ListBlockUnorderedType}
+	switch {
+	case m[3] != "":
+		c.list.Type = domainblocks.ListBlockToDoType
+		if m[3] != " " {
+			c.list.Value = 1
+		}
+	case m[2][0] >= '0' && m[2][0] <= '9':
+		c.list.Type = domainblocks.ListBlockOrderedType
+		c.list.Value, _ = strconv.Atoi(strings.TrimRight(m[2], ".)"))
+	}
+	if mk := logseqMarker.FindStringSubmatch(item); mk != nil {
+		c.list.Type = domainblocks.ListBlockToDoType
+		if mk[1] == "DONE" {
+			c.list.Value = 1
+		}
+		item = item[len(mk[0]):]
+	}
+
+	c.kind = listType
+	c.lines = []string{item}
+}
+
+// flush write block which is read now
+func (c *mdConverter) flush() {
+	kind, lines := c.kind, c.lines
+	c.kind, c.lines = "", nil
+	if kind != listType {
+		c.indents = nil
+	}
+	if len(lines) == 0 {
+		return
+	}
+
+	raw := strings.Join(lines, "\n")
+	switch kind {
+	case quoteType:
+		data, links := text.ParseMarkdown(raw)
+		c.add(quoteType, (&domainblocks.QuoteData{Text: data.PlainText()}).ToMap())
+		c.addLinks(links)
+
+	case listType:
+		data, links := text.ParseMarkdown(raw)
+		ld := c.list
+		ld.TextData = data
+		c.add(listType, ld.ToMap())
+		c.addLinks(links)
+
+	default:
+		data, links := text.ParseMarkdown(raw)
+		// paragraph of only links or images is blocks of them
+		rest := strings.TrimSpace(data.PlainText())
+		if len(links) == 1 && !links[0].Image && rest == strings.TrimSpace(links[0].Text) {
+			rest = ""
+		}
+		if rest != "" {
+			c.add(textType, (&domainblocks.TextData{TextData: data}).ToMap())
+		}
+		c.addLinks(links)
+	}
+}
+
+func (c *mdConverter) addLinks(links []text.Link) {
+	for _, l := range links {
+		if l.Url == "" {
+			continue
+		}
+		switch {
+		case l.Image:
+			c.add(imgType, (&domainblocks.ImgData{Src: l.Url, Alt: l.Text}).ToMap())
+		case l.File && Registry[fileType] != nil:
+			c.add(fileType, (&domainblocks.FileData{Src: l.Url}).ToMap())
+		default:
+			c.add(linkType, (&domainblocks.LinkData{Text: l.Text, Url: l.Url}).ToMap())
+		}
+	}
+}
+
+// add create block by driver of type. If type isn't registered, text of block is written as text block
+func (c *mdConverter) add(typ string, data map[string]any) {
+	r := Registry[typ]
+	if r == nil && typ != textType {
+		c.addText(typ, data)
+		return
+	}
+	if r == nil {
+		return
+	}
+	b, err := r.Create(c.ctx, data)
+	if err != nil {
+		return
+	}
+	b.Type = typ
+	c.blocks = append(c.blocks, b)
+}
+
+// addText write block of not registered type as text
+func (c *mdConverter) addText(typ string, data map[string]any) {
+	var s string
+	switch typ {
+	case codeType, quoteType:
+		s, _ = data["text"].(string)
+	case imgType:
+		s, _ = data["src"].(string)
+	case linkType:
+		s, _ = data["url"].(string)
+	default:
+		if td, ok := data["text_data"].(map[string]any); ok {
+			if d, err := text.NewDataFromMap(td); err == nil && d != nil {
+				c.add(textType, (&domainblocks.TextData{TextData: d}).ToMap())
+			}
+		}
+		return
+	}
+	if s == "" {
+		return
+	}
+	c.add(textType, (&domainblocks.TextData{TextData: &text.Data{Text: []text.Part{{Style: "default", String: s}}}}).ToMap())
+}
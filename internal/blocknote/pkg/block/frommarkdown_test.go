@@ -0,0 +1,96 @@
+package block_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/autumnterror/breezynotes/internal/blocknote/pkg/block"
+	"github.com/autumnterror/breezynotes/internal/blocknote/pkg/block/default/codeblock"
+	"github.com/autumnterror/breezynotes/internal/blocknote/pkg/block/default/headerblock"
+	"github.com/autumnterror/breezynotes/internal/blocknote/pkg/block/default/imgblock"
+	"github.com/autumnterror/breezynotes/internal/blocknote/pkg/block/default/linkblock"
+	"github.com/autumnterror/breezynotes/internal/blocknote/pkg/block/default/listblock"
+	"github.com/autumnterror/breezynotes/internal/blocknote/pkg/block/default/quoteblock"
+	"github.com/autumnterror/breezynotes/internal/blocknote/pkg/block/default/textblock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFromMarkdown(t *testing.T) {
+	block.RegisterBlock("text", &textblock.Driver{})
+	block.RegisterBlock("list", &listblock.Driver{})
+	block.RegisterBlock("header", &headerblock.Driver{})
+	block.RegisterBlock("code", &codeblock.Driver{})
+	block.RegisterBlock("quote", &quoteblock.Driver{})
+	block.RegisterBlock("img", &imgblock.Driver{})
+	block.RegisterBlock("link", &linkblock.Driver{})
+
+	md := "## Plan\n" +
+		"intro with **bold**\nand [docs](https://x.y)\n\n" +
+		"- one\n" +
+		"  - [x] two\n" +
+		"3. three\n" +
+		"- TODO four\n\n" +
+		"> quoted *text*\n\n" +
+		"```go\nfmt.Println(1)\n```\n" +
+		"---\n" +
+		"![cat](cat.png)\n\n" +
+		"[only link](https://a.b)\n"
+
+	blks := block.FromMarkdown(context.Background(), md)
+
+	var types []string
+	for _, b := range blks {
+		types = append(types, b.GetType())
+	}
+	require.Equal(t, []string{"header", "text", "link", "list", "list", "list", "list", "quote", "code", "img", "link"}, types)
+
+	data := func(i int) map[string]any { return blks[i].GetData().AsMap() }
+
+	assert.Equal(t, float64(2), data(0)["level"])
+	assert.Equal(t, "intro with **bold**\nand docs", block.Registry["text"].Markdown(context.Background(), blks[1]))
+	assert.Equal(t, map[string]any{"text": "docs", "url": "https://x.y"}, data(2))
+
+	assert.Equal(t, "unordered", data(3)["type"])
+	assert.Equal(t, float64(0), data(3)["level"])
+	assert.Equal(t, "todo", data(4)["type"])
+	assert.Equal(t, float64(1), data(4)["level"])
+	assert.Equal(t, float64(1), data(4)["value"])
+	assert.Equal(t, "ordered", data(5)["type"])
+	assert.Equal(t, float64(3), data(5)["value"])
+	assert.Equal(t, "todo", data(6)["type"])
+	assert.Equal(t, float64(0), data(6)["value"])
+
+	assert.Equal(t, "quoted text", data(7)["text"])
+	assert.Equal(t, "fmt.Println(1)", data(8)["text"])
+	assert.Equal(t, "go", data(8)["lang"])
+	assert.Equal(t, map[string]any{"src": "cat.png", "alt": "cat"}, data(9))
+	assert.Equal(t, map[string]any{"text": "only link", "url": "https://a.b"}, data(10))
+}
+
+func TestFromMarkdownLogseq(t *testing.T) {
+	block.RegisterBlock("text", &textblock.Driver{})
+	block.RegisterBlock("list", &listblock.Driver{})
+
+	md := "- note\n" +
+		"-\n" +
+		"- DONE  shipped\n" +
+		"\t- LATER\tnext\n"
+
+	blks := block.FromMarkdown(context.Background(), md)
+
+	var types []string
+	for _, b := range blks {
+		types = append(types, b.GetType())
+	}
+	require.Equal(t, []string{"list", "list", "list"}, types, "empty item is dropped")
+
+	data := func(i int) map[string]any { return blks[i].GetData().AsMap() }
+	assert.Equal(t, "unordered", data(0)["type"])
+	assert.Equal(t, "todo", data(1)["type"])
+	assert.Equal(t, float64(1), data(1)["value"])
+	assert.Equal(t, "- [x] shipped", block.Registry["list"].Markdown(context.Background(), blks[1]))
+	assert.Equal(t, "todo", data(2)["type"])
+	assert.Equal(t, float64(0), data(2)["value"])
+	assert.Equal(t, float64(1), data(2)["level"])
+}
@@ -0,0 +1,26 @@
+package block
+
+import (
+	"context"
+	"strings"
+
+	brzrpc "github.com/autumnterror/breezynotes/api/proto/gen"
+	"github.com/autumnterror/breezynotes/internal/blocknote/pkg/render"
+)
+
+// NoteHTML render note to standalone html page: title as header and blocks in order. Blocks of unknown types are skipped
+func NoteHTML(ctx context.Context, n *brzrpc.NoteWithBlocks, files render.Files) string {
+	var sb strings.Builder
+	sb.WriteString("<article>\n<h1>" + render.Escape(n.GetTitle()) + "</h1>\n")
+	for _, b := range n.GetBlocks() {
+		r := Registry[b.GetType()]
+		if r == nil {
+			continue
+		}
+		if h := r.HTML(ctx, b, files); h != "" {
+			sb.WriteString(h + "\n")
+		}
+	}
+	sb.WriteString("</article>\n")
+	return render.Document(n.GetTitle(), sb.String())
+}
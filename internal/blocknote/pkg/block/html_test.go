@@ -0,0 +1,97 @@
+package block_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"strings"
+	"testing"
+
+	brzrpc "github.com/autumnterror/breezynotes/api/proto/gen"
+	"github.com/autumnterror/breezynotes/internal/blocknote/pkg/block"
+	"github.com/autumnterror/breezynotes/internal/blocknote/pkg/block/default/codeblock"
+	"github.com/autumnterror/breezynotes/internal/blocknote/pkg/block/default/headerblock"
+	"github.com/autumnterror/breezynotes/internal/blocknote/pkg/block/default/imgblock"
+	"github.com/autumnterror/breezynotes/internal/blocknote/pkg/block/default/linkblock"
+	"github.com/autumnterror/breezynotes/internal/blocknote/pkg/block/default/listblock"
+	"github.com/autumnterror/breezynotes/internal/blocknote/pkg/block/default/textblock"
+	"github.com/autumnterror/breezynotes/internal/blocknote/pkg/render"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+func exportNote(t *testing.T) *brzrpc.NoteWithBlocks {
+	block.RegisterBlock("text", &textblock.Driver{})
+	block.RegisterBlock("header", &headerblock.Driver{})
+	block.RegisterBlock("list", &listblock.Driver{})
+	block.RegisterBlock("code", &codeblock.Driver{})
+	block.RegisterBlock("link", &linkblock.Driver{})
+	block.RegisterBlock("img", &imgblock.Driver{})
+
+	text := func(style, s string) map[string]any {
+		return map[string]any{"text": []any{map[string]any{"style": style, "string": s}}}
+	}
+	blk := func(typ string, data map[string]any) *brzrpc.Block {
+		s, err := structpb.NewStruct(data)
+		require.NoError(t, err)
+		return &brzrpc.Block{Type: typ, Data: s}
+	}
+
+	return &brzrpc.NoteWithBlocks{
+		Title: "Plan <1>",
+		Blocks: []*brzrpc.Block{
+			blk("header", map[string]any{"level": 1, "text_data": text("default", "Intro")}),
+			blk("text", map[string]any{"text_data": text("bold", "a < b")}),
+			blk("list", map[string]any{"type": "todo", "value": 1, "text_data": text("default", "done")}),
+			blk("code", map[string]any{"lang": "go", "text": "func main() {\n\tprintln(1)\n}"}),
+			blk("link", map[string]any{"url": "javascript:alert(1)", "text": "bad"}),
+			blk("link", map[string]any{"url": "https://example.com", "text": "site"}),
+			blk("img", map[string]any{"src": "/files/a.png", "alt": "pic"}),
+			blk("img", map[string]any{"src": "/files/missing.png", "alt": "lost"}),
+			blk("unknown", map[string]any{}),
+		},
+	}
+}
+
+func testPNG(t *testing.T) []byte {
+	img := image.NewNRGBA(image.Rect(0, 0, 4, 3))
+	img.Set(1, 1, color.NRGBA{R: 255, A: 128})
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, img))
+	return buf.Bytes()
+}
+
+func TestNoteHTML(t *testing.T) {
+	n := exportNote(t)
+	files := render.Inline{"/files/a.png": testPNG(t)}
+
+	res := block.NoteHTML(context.Background(), n, files)
+
+	assert.Contains(t, res, "<title>Plan &lt;1&gt;</title>")
+	assert.Contains(t, res, "<h2>Intro</h2>")
+	assert.Contains(t, res, "<strong>a &lt; b</strong>")
+	assert.Contains(t, res, `<p class="item done"`)
+	assert.Contains(t, res, `<span style="`, "code is highlighted")
+	assert.NotContains(t, res, "javascript:")
+	assert.Contains(t, res, "<p>bad</p>")
+	assert.Contains(t, res, `<a href="https://example.com">site</a>`)
+	assert.Contains(t, res, `src="data:image/png;base64,`)
+	assert.Contains(t, res, `src="/files/missing.png"`, "not available picture is left by url")
+
+	// page is XHTML, so it can be chapter of epub
+	dec := xml.NewDecoder(strings.NewReader(res))
+	dec.Strict = true
+	dec.Entity = xml.HTMLEntity
+	for {
+		_, err := dec.Token()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+	}
+}
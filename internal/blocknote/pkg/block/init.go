@@ -2,12 +2,29 @@ package block
 
 import (
 	"context"
+	"encoding/json"
+
 	brzrpc "github.com/autumnterror/breezynotes/api/proto/gen"
+	"github.com/autumnterror/breezynotes/internal/blocknote/pkg/render"
+	"github.com/autumnterror/breezynotes/internal/blocknote/pkg/render/pdf"
+	"google.golang.org/protobuf/types/known/structpb"
 )
 
 type Repo interface {
 	Op(ctx context.Context, block *brzrpc.Block, op string, data map[string]any) (map[string]any, error)
 	GetAsFirst(ctx context.Context, block *brzrpc.Block) string
+	// Markdown return block as markdown, empty if block has nothing to show
+	Markdown(ctx context.Context, block *brzrpc.Block) string
+	// HTML return block as html, empty if block has nothing to show. Stored files are taken from files
+	HTML(ctx context.Context, block *brzrpc.Block, files render.Files) string
+	// PDF write block to document
+	PDF(ctx context.Context, block *brzrpc.Block, d *pdf.Doc, files render.Files)
+	// Document return data of block in document format, see document package
+	Document(ctx context.Context, block *brzrpc.Block) (json.RawMessage, error)
+	// FromDocument check data of block from document and return it as data of block
+	FromDocument(ctx context.Context, data json.RawMessage) (*structpb.Struct, error)
+	// Schema return JSON schema of data of block in document
+	Schema() map[string]any
 	ChangeType(ctx context.Context, block *brzrpc.Block, newType string) error
 	Create(ctx context.Context, data map[string]any) (*brzrpc.Block, error)
 	//Render(ctx context.Context, block *domain.Block) (*domain.Block, error)
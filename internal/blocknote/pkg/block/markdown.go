@@ -0,0 +1,36 @@
+package block
+
+import (
+	"context"
+	"strings"
+
+	brzrpc "github.com/autumnterror/breezynotes/api/proto/gen"
+	"github.com/autumnterror/breezynotes/internal/blocknote/pkg/text"
+)
+
+// listType items of list are written without empty line between them
+const listType = "list"
+
+// NoteMarkdown render note to markdown: title as header and blocks in order. Blocks of unknown types are skipped
+func NoteMarkdown(ctx context.Context, n *brzrpc.NoteWithBlocks) string {
+	var sb strings.Builder
+	sb.WriteString("# " + text.EscapeMarkdown(n.GetTitle()) + "\n")
+
+	prev := ""
+	for _, b := range n.GetBlocks() {
+		r := Registry[b.GetType()]
+		if r == nil {
+			continue
+		}
+		md := r.Markdown(ctx, b)
+		if md == "" {
+			continue
+		}
+		if !(prev == listType && b.GetType() == listType) {
+			sb.WriteString("\n")
+		}
+		sb.WriteString(md + "\n")
+		prev = b.GetType()
+	}
+	return sb.String()
+}
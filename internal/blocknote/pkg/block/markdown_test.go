@@ -0,0 +1,41 @@
+package block_test
+
+import (
+	"context"
+	"testing"
+
+	brzrpc "github.com/autumnterror/breezynotes/api/proto/gen"
+	"github.com/autumnterror/breezynotes/internal/blocknote/pkg/block"
+	"github.com/autumnterror/breezynotes/internal/blocknote/pkg/block/default/listblock"
+	"github.com/autumnterror/breezynotes/internal/blocknote/pkg/block/default/textblock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+func TestNoteMarkdown(t *testing.T) {
+	block.RegisterBlock("text", &textblock.Driver{})
+	block.RegisterBlock("list", &listblock.Driver{})
+
+	text := func(s string) map[string]any {
+		return map[string]any{"text": []any{map[string]any{"style": "default", "string": s}}}
+	}
+	blk := func(typ string, data map[string]any) *brzrpc.Block {
+		s, err := structpb.NewStruct(data)
+		require.NoError(t, err)
+		return &brzrpc.Block{Type: typ, Data: s}
+	}
+
+	n := &brzrpc.NoteWithBlocks{
+		Title: "Plan",
+		Blocks: []*brzrpc.Block{
+			blk("text", map[string]any{"text_data": text("intro")}),
+			blk("list", map[string]any{"type": "unordered", "text_data": text("one")}),
+			blk("list", map[string]any{"type": "todo", "value": 1, "text_data": text("two")}),
+			blk("unknown", map[string]any{}),
+			blk("text", map[string]any{"text_data": text("end")}),
+		},
+	}
+
+	assert.Equal(t, "# Plan\n\nintro\n\n- one\n- [x] two\n\nend\n", block.NoteMarkdown(context.Background(), n))
+}
@@ -0,0 +1,24 @@
+package block
+
+import (
+	"context"
+	"io"
+
+	brzrpc "github.com/autumnterror/breezynotes/api/proto/gen"
+	"github.com/autumnterror/breezynotes/internal/blocknote/pkg/render"
+	"github.com/autumnterror/breezynotes/internal/blocknote/pkg/render/pdf"
+)
+
+// NotePDF write note as A4 document: title as header and blocks in order. Blocks of unknown types are skipped.
+// Nil fonts are standard fonts, see pdf.New
+func NotePDF(ctx context.Context, n *brzrpc.NoteWithBlocks, w io.Writer, font, mono *pdf.Font, files render.Files) error {
+	d := pdf.New(n.GetTitle(), font, mono)
+	d.Text([]pdf.Span{{Text: n.GetTitle(), Style: pdf.Style{Size: 22, Bold: true}}}, pdf.Par{})
+	for _, b := range n.GetBlocks() {
+		if r := Registry[b.GetType()]; r != nil {
+			r.PDF(ctx, b, d, files)
+		}
+	}
+	_, err := d.WriteTo(w)
+	return err
+}
@@ -0,0 +1,29 @@
+package block_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/autumnterror/breezynotes/internal/blocknote/pkg/block"
+	"github.com/autumnterror/breezynotes/internal/blocknote/pkg/render"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNotePDF(t *testing.T) {
+	n := exportNote(t)
+	files := render.Inline{"/files/a.png": testPNG(t)}
+
+	var buf bytes.Buffer
+	require.NoError(t, block.NotePDF(context.Background(), n, &buf, nil, nil, files))
+	res := buf.String()
+
+	assert.True(t, bytes.HasPrefix(buf.Bytes(), []byte("%PDF-")))
+	assert.True(t, bytes.HasSuffix(buf.Bytes(), []byte("%%EOF\n")))
+	assert.Contains(t, res, "/Subtype /Image", "stored picture is put into document")
+	assert.Contains(t, res, "/SMask", "alpha of picture is kept")
+	assert.Contains(t, res, "/URI (https://example.com)")
+	assert.NotContains(t, res, "javascript:")
+	assert.Contains(t, res, "/BaseFont /Courier", "code is monospace")
+}
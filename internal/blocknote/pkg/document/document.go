@@ -0,0 +1,156 @@
+// Package document is BreezyNotes document format: versioned JSON of note with blocks, which doesn't depend
+// on how blocks are stored. Data of every block type has its own schema, it is given by driver of type, see block.Repo
+package document
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+)
+
+const (
+	// Format is value of format field of every document
+	Format = "breezynotes"
+	// Version of document layout. It is increased when layout or data of some block type is changed incompatibly,
+	// new optional fields don't change it
+	Version = 1
+)
+
+var (
+	ErrFormat = errors.New("not a breezynotes document")
+	// ErrVersion document is made by newer version of format
+	ErrVersion = errors.New("unsupported version of document")
+	ErrInvalid = errors.New("invalid document")
+)
+
+type Document struct {
+	Format  string `json:"format"`
+	Version int    `json:"version"`
+	Note    Note   `json:"note"`
+}
+
+type Note struct {
+	Id          string   `json:"id,omitempty"`
+	Title       string   `json:"title"`
+	CreatedAt   int64    `json:"created_at,omitempty"`
+	UpdatedAt   int64    `json:"updated_at,omitempty"`
+	Author      string   `json:"author,omitempty"`
+	Editors     []string `json:"editors,omitempty"`
+	Readers     []string `json:"readers,omitempty"`
+	IsPublic    bool     `json:"is_public,omitempty"`
+	IsBlog      bool     `json:"is_blog,omitempty"`
+	WorkspaceId string   `json:"workspace_id,omitempty"`
+	Tag         *Tag     `json:"tag,omitempty"`
+	Blocks      []Block  `json:"blocks"`
+}
+
+type Tag struct {
+	Id    string `json:"id,omitempty"`
+	Title string `json:"title"`
+	Color string `json:"color,omitempty"`
+	Emoji string `json:"emoji,omitempty"`
+}
+
+type Block struct {
+	Id        string `json:"id,omitempty"`
+	Type      string `json:"type"`
+	CreatedAt int64  `json:"created_at,omitempty"`
+	UpdatedAt int64  `json:"updated_at,omitempty"`
+	// Data of block by schema of its type
+	Data json.RawMessage `json:"data"`
+}
+
+// Span is part of rich text with same marks. Known marks are bold, italic, underline, strikethrough and code,
+// others are kept, but are not shown by renderers
+type Span struct {
+	Text  string   `json:"text"`
+	Marks []string `json:"marks,omitempty"`
+}
+
+var markRe = regexp.MustCompile(`^[a-z][a-z_-]*$`)
+
+// New return empty document of current version
+func New(n Note) *Document {
+	if n.Blocks == nil {
+		n.Blocks = []Block{}
+	}
+	return &Document{Format: Format, Version: Version, Note: n}
+}
+
+// Encode write document as indented JSON
+func (d *Document) Encode() ([]byte, error) {
+	return json.MarshalIndent(d, "", "  ")
+}
+
+// Decode read document and check its layout. Data of blocks is checked by drivers when blocks are made from it
+func Decode(raw []byte) (*Document, error) {
+	var head struct {
+		Format  string `json:"format"`
+		Version int    `json:"version"`
+	}
+	if err := json.Unmarshal(raw, &head); err != nil || head.Format != Format {
+		return nil, ErrFormat
+	}
+	if head.Version > Version {
+		return nil, fmt.Errorf("%w: %d, max is %d", ErrVersion, head.Version, Version)
+	}
+	if head.Version < 1 {
+		return nil, ErrFormat
+	}
+
+	var d Document
+	if err := strict(raw, &d); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalid, err)
+	}
+	if d.Note.Blocks == nil {
+		return nil, fmt.Errorf("%w: note.blocks is required", ErrInvalid)
+	}
+	for i, b := range d.Note.Blocks {
+		if b.Type == "" {
+			return nil, fmt.Errorf("%w: note.blocks[%d]: type is empty", ErrInvalid, i)
+		}
+		if len(b.Data) == 0 || string(b.Data) == "null" {
+			return nil, fmt.Errorf("%w: note.blocks[%d]: data is empty", ErrInvalid, i)
+		}
+	}
+	return &d, nil
+}
+
+// DecodeData read data of block to v, unknown fields are error. Required fields must be set and not null
+func DecodeData(data json.RawMessage, v any, required ...string) error {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return errors.New("data must be object")
+	}
+	for _, f := range required {
+		if raw, ok := fields[f]; !ok || string(raw) == "null" {
+			return fmt.Errorf("field %q is required", f)
+		}
+	}
+	return strict(data, v)
+}
+
+// CheckText check spans of rich text
+func CheckText(spans []Span) error {
+	for i, s := range spans {
+		for _, m := range s.Marks {
+			if !markRe.MatchString(m) {
+				return fmt.Errorf("text[%d]: bad mark %q", i, m)
+			}
+		}
+		if len(slices.Compact(slices.Sorted(slices.Values(s.Marks)))) != len(s.Marks) {
+			return fmt.Errorf("text[%d]: repeated mark", i)
+		}
+	}
+	return nil
+}
+
+// strict decode JSON, it is already checked by json.Unmarshal, so there is no data after value
+func strict(raw []byte, v any) error {
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.DisallowUnknownFields()
+	return dec.Decode(v)
+}
@@ -0,0 +1,81 @@
+package document
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecode(t *testing.T) {
+	t.Parallel()
+
+	raw, err := New(Note{Title: "t"}).Encode()
+	require.NoError(t, err)
+	d, err := Decode(raw)
+	require.NoError(t, err)
+	assert.Equal(t, Version, d.Version)
+	assert.Equal(t, []Block{}, d.Note.Blocks)
+
+	for name, tc := range map[string]struct {
+		raw  string
+		want error
+	}{
+		"not json":      {`{`, ErrFormat},
+		"other format":  {`{"format":"notion","version":1,"note":{"blocks":[]}}`, ErrFormat},
+		"no version":    {`{"format":"breezynotes","note":{"blocks":[]}}`, ErrFormat},
+		"newer version": {`{"format":"breezynotes","version":2,"note":{"blocks":[]}}`, ErrVersion},
+		"unknown field": {`{"format":"breezynotes","version":1,"note":{"blocks":[],"pinned":true}}`, ErrInvalid},
+		"no blocks":     {`{"format":"breezynotes","version":1,"note":{"title":"t"}}`, ErrInvalid},
+		"no type":       {`{"format":"breezynotes","version":1,"note":{"blocks":[{"data":{}}]}}`, ErrInvalid},
+		"no data":       {`{"format":"breezynotes","version":1,"note":{"blocks":[{"type":"text"}]}}`, ErrInvalid},
+		"null data":     {`{"format":"breezynotes","version":1,"note":{"blocks":[{"type":"text","data":null}]}}`, ErrInvalid},
+		"trailing data": {`{"format":"breezynotes","version":1,"note":{"blocks":[]}} {}`, ErrFormat},
+	} {
+		_, err := Decode([]byte(tc.raw))
+		assert.ErrorIs(t, err, tc.want, name)
+	}
+}
+
+func TestDecodeData(t *testing.T) {
+	t.Parallel()
+
+	var v struct {
+		Src string `json:"src"`
+		Alt string `json:"alt"`
+	}
+	require.NoError(t, DecodeData(json.RawMessage(`{"src":"a"}`), &v, "src"))
+	assert.Equal(t, "a", v.Src)
+
+	assert.Error(t, DecodeData(json.RawMessage(`{"alt":"a"}`), &v, "src"))
+	assert.Error(t, DecodeData(json.RawMessage(`{"src":"a","size":1}`), &v, "src"))
+	assert.Error(t, DecodeData(json.RawMessage(`[]`), &v))
+}
+
+func TestCheckText(t *testing.T) {
+	t.Parallel()
+
+	assert.NoError(t, CheckText([]Span{{Text: "a"}, {Text: "b", Marks: []string{"bold", "my-mark"}}}))
+	assert.Error(t, CheckText([]Span{{Text: "a", Marks: []string{""}}}))
+	assert.Error(t, CheckText([]Span{{Text: "a", Marks: []string{"bold italic"}}}))
+	assert.Error(t, CheckText([]Span{{Text: "a", Marks: []string{"code", "code"}}}))
+}
+
+func TestSchema(t *testing.T) {
+	t.Parallel()
+
+	s := Schema(map[string]map[string]any{
+		"b": Object(map[string]any{"src": map[string]any{"type": "string"}}, "src"),
+		"a": Object(map[string]any{"text": TextSchema()}, "text"),
+	})
+	assert.Contains(t, s["$defs"], "a")
+	assert.Contains(t, s["$defs"], "b")
+
+	note := s["properties"].(map[string]any)["note"].(map[string]any)
+	blocks := note["properties"].(map[string]any)["blocks"].(map[string]any)
+	variants := blocks["items"].(map[string]any)["oneOf"].([]any)
+	require.Len(t, variants, 2)
+	first := variants[0].(map[string]any)["properties"].(map[string]any)
+	assert.Equal(t, map[string]any{"const": "a"}, first["type"], "types are sorted, so schema is stable")
+}
@@ -0,0 +1,104 @@
+package document
+
+import "slices"
+
+// TextSchema is JSON schema of rich text, list of spans
+func TextSchema() map[string]any {
+	return map[string]any{
+		"type": "array",
+		"items": map[string]any{
+			"type":                 "object",
+			"required":             []any{"text"},
+			"additionalProperties": false,
+			"properties": map[string]any{
+				"text": map[string]any{"type": "string"},
+				"marks": map[string]any{
+					"type":        "array",
+					"uniqueItems": true,
+					"items":       map[string]any{"type": "string", "pattern": markRe.String()},
+				},
+			},
+		},
+	}
+}
+
+// Object is JSON schema of object with only given properties
+func Object(props map[string]any, required ...string) map[string]any {
+	req := make([]any, 0, len(required))
+	for _, r := range required {
+		req = append(req, r)
+	}
+	return map[string]any{
+		"type":                 "object",
+		"required":             req,
+		"additionalProperties": false,
+		"properties":           props,
+	}
+}
+
+// Schema is JSON schema of document, blocks is schema of data by type of block
+func Schema(blocks map[string]map[string]any) map[string]any {
+	types := make([]string, 0, len(blocks))
+	for t := range blocks {
+		types = append(types, t)
+	}
+	slices.Sort(types)
+
+	defs := make(map[string]any, len(blocks))
+	variants := make([]any, 0, len(blocks))
+	for _, t := range types {
+		defs[t] = blocks[t]
+		variants = append(variants, map[string]any{
+			"properties": map[string]any{
+				"type": map[string]any{"const": t},
+				"data": map[string]any{"$ref": "#/$defs/" + t},
+			},
+		})
+	}
+
+	str := map[string]any{"type": "string"}
+	ts := map[string]any{"type": "integer", "description": "unix time in seconds"}
+	ids := map[string]any{"type": "array", "items": str}
+	flag := map[string]any{"type": "boolean"}
+
+	block := Object(map[string]any{
+		"id":         str,
+		"type":       str,
+		"created_at": ts,
+		"updated_at": ts,
+		"data":       map[string]any{"type": "object"},
+	}, "type", "data")
+	block["oneOf"] = variants
+
+	return map[string]any{
+		"$schema":              "https://json-schema.org/draft/2020-12/schema",
+		"title":                "BreezyNotes document",
+		"type":                 "object",
+		"required":             []any{"format", "version", "note"},
+		"additionalProperties": false,
+		"properties": map[string]any{
+			"format":  map[string]any{"const": Format},
+			"version": map[string]any{"const": Version},
+			"note": Object(map[string]any{
+				"id":           str,
+				"title":        str,
+				"created_at":   ts,
+				"updated_at":   ts,
+				"author":       str,
+				"editors":      ids,
+				"readers":      ids,
+				"is_public":    flag,
+				"is_blog":      flag,
+				"workspace_id": str,
+				"tag": Object(map[string]any{
+					"id":    str,
+					"title": str,
+					"color": str,
+					"emoji": str,
+				}),
+				"blocks": map[string]any{"type": "array", "items": block},
+			}, "blocks"),
+		},
+		"$defs": defs,
+	}
+}
@@ -0,0 +1,70 @@
+package render
+
+import (
+	"strings"
+
+	"github.com/alecthomas/chroma/v2"
+	chromahtml "github.com/alecthomas/chroma/v2/formatters/html"
+	"github.com/alecthomas/chroma/v2/lexers"
+	"github.com/alecthomas/chroma/v2/styles"
+	"github.com/autumnterror/breezynotes/internal/blocknote/pkg/render/pdf"
+)
+
+var (
+	codeStyle     = styles.Get("github")
+	codeFormatter = chromahtml.New(chromahtml.WithClasses(false), chromahtml.TabWidth(4))
+)
+
+// lexer of language by name, alias or extension. Unknown language is guessed by code
+func lexer(code, lang string) chroma.Lexer {
+	l := lexers.Get(lang)
+	if l == nil {
+		l = lexers.Analyse(code)
+	}
+	if l == nil {
+		l = lexers.Fallback
+	}
+	return chroma.Coalesce(l)
+}
+
+// CodeHTML return highlighted code in pre with inline styles
+func CodeHTML(code, lang string) string {
+	code = strings.TrimSuffix(code, "\n")
+	it, err := lexer(code, lang).Tokenise(nil, code)
+	if err == nil {
+		var sb strings.Builder
+		if err := codeFormatter.Format(&sb, codeStyle, it); err == nil {
+			return sb.String()
+		}
+	}
+	return "<pre><code>" + Escape(code) + "</code></pre>"
+}
+
+// CodeSpans return highlighted code as text of pdf in monospace font
+func CodeSpans(code, lang string, size float64) []pdf.Span {
+	code = strings.TrimSuffix(code, "\n")
+	it, err := lexer(code, lang).Tokenise(nil, code)
+	if err != nil {
+		return []pdf.Span{{Text: code, Style: pdf.Style{Mono: true, Size: size}}}
+	}
+
+	var res []pdf.Span
+	for _, t := range it.Tokens() {
+		e := codeStyle.Get(t.Type)
+		st := pdf.Style{Mono: true, Size: size, Bold: e.Bold == chroma.Yes, Italic: e.Italic == chroma.Yes}
+		if e.Colour.IsSet() {
+			st.Color = pdf.Color{float64(e.Colour.Red()) / 255, float64(e.Colour.Green()) / 255, float64(e.Colour.Blue()) / 255}
+		}
+		res = append(res, pdf.Span{Text: t.Value, Style: st})
+	}
+	return res
+}
+
+// CodeBackground is background of highlighted code
+func CodeBackground() pdf.Color {
+	bg := codeStyle.Get(chroma.Background).Background
+	if !bg.IsSet() {
+		return pdf.Color{0.96, 0.96, 0.97}
+	}
+	return pdf.Color{float64(bg.Red()) / 255, float64(bg.Green()) / 255, float64(bg.Blue()) / 255}
+}
@@ -0,0 +1,154 @@
+package epub
+
+import (
+	"fmt"
+	"io"
+	"net/url"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/autumnterror/breezynotes/internal/blocknote/pkg/render"
+)
+
+// Archive receives files of book in order. Archive must write mimetype first and not compressed
+type Archive interface {
+	Create(name string) (io.Writer, error)
+	// File put stored file by src as content of name
+	File(name, src string) error
+}
+
+// Book is EPUB 3. Chapters are written at once, pictures and table of contents on Close
+type Book struct {
+	a      Archive
+	id     string
+	title  string
+	author string
+
+	chapters []chapter
+	images   map[string]string
+	order    []string
+}
+
+type chapter struct {
+	href, title string
+}
+
+const (
+	container = `<?xml version="1.0" encoding="utf-8"?>
+<container version="1.0" xmlns="urn:oasis:names:tc:opendocument:xmlns:container">
+<rootfiles>
+<rootfile full-path="OEBPS/content.opf" media-type="application/oebps-package+xml"/>
+</rootfiles>
+</container>
+`
+	// MimeType of epub, it is also content of first file of archive
+	MimeType = "application/epub+zip"
+)
+
+// New start book, id is unique id of it
+func New(a Archive, id, title, author string) (*Book, error) {
+	b := &Book{a: a, id: id, title: title, author: author, images: make(map[string]string)}
+	if err := b.write("mimetype", MimeType); err != nil {
+		return nil, err
+	}
+	if err := b.write("META-INF/container.xml", container); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+func (b *Book) write(name, content string) error {
+	w, err := b.a.Create(name)
+	if err != nil {
+		return err
+	}
+	_, err = io.WriteString(w, content)
+	return err
+}
+
+// Files return files for chapters, stored pictures are put in book and others are left by url
+func (b *Book) Files() render.Files {
+	return bookFiles{b}
+}
+
+type bookFiles struct {
+	b *Book
+}
+
+func (f bookFiles) Src(src string) string {
+	u, err := url.Parse(src)
+	if err != nil || u.Scheme != "" || u.Host != "" || path.Base(u.Path) == "." || path.Base(u.Path) == "/" {
+		return ""
+	}
+	name, ok := f.b.images[src]
+	if !ok {
+		name = fmt.Sprintf("%d-%s", len(f.b.order)+1, path.Base(u.Path))
+		f.b.images[src] = name
+		f.b.order = append(f.b.order, src)
+	}
+	return "../images/" + url.PathEscape(name)
+}
+
+func (f bookFiles) Data(string) []byte {
+	return nil
+}
+
+// Chapter write page of chapter, it must be XHTML, see render.Document
+func (b *Book) Chapter(title, xhtml string) error {
+	href := fmt.Sprintf("notes/%d.xhtml", len(b.chapters)+1)
+	if err := b.write("OEBPS/"+href, xhtml); err != nil {
+		return err
+	}
+	b.chapters = append(b.chapters, chapter{href: href, title: title})
+	return nil
+}
+
+// Close write pictures, table of contents and package document
+func (b *Book) Close() error {
+	for _, src := range b.order {
+		if err := b.a.File("OEBPS/images/"+b.images[src], src); err != nil {
+			return err
+		}
+	}
+
+	var nav strings.Builder
+	nav.WriteString("<!DOCTYPE html>\n<html xmlns=\"http://www.w3.org/1999/xhtml\" xmlns:epub=\"http://www.idpf.org/2007/ops\">\n")
+	nav.WriteString("<head>\n<meta charset=\"utf-8\" />\n<title>" + render.Escape(b.title) + "</title>\n</head>\n<body>\n")
+	nav.WriteString("<nav epub:type=\"toc\" id=\"toc\">\n<h1>" + render.Escape(b.title) + "</h1>\n<ol>\n")
+	for _, c := range b.chapters {
+		nav.WriteString("<li><a href=\"" + c.href + "\">" + render.Escape(c.title) + "</a></li>\n")
+	}
+	nav.WriteString("</ol>\n</nav>\n</body>\n</html>\n")
+	if err := b.write("OEBPS/nav.xhtml", nav.String()); err != nil {
+		return err
+	}
+
+	var opf strings.Builder
+	opf.WriteString("<?xml version=\"1.0\" encoding=\"utf-8\"?>\n")
+	opf.WriteString("<package xmlns=\"http://www.idpf.org/2007/opf\" version=\"3.0\" unique-identifier=\"id\">\n")
+	opf.WriteString("<metadata xmlns:dc=\"http://purl.org/dc/elements/1.1/\">\n")
+	opf.WriteString("<dc:identifier id=\"id\">urn:uuid:" + render.Escape(b.id) + "</dc:identifier>\n")
+	opf.WriteString("<dc:title>" + render.Escape(b.title) + "</dc:title>\n")
+	if b.author != "" {
+		opf.WriteString("<dc:creator>" + render.Escape(b.author) + "</dc:creator>\n")
+	}
+	opf.WriteString("<dc:language>und</dc:language>\n")
+	opf.WriteString("<meta property=\"dcterms:modified\">" + time.Now().UTC().Format("2006-01-02T15:04:05Z") + "</meta>\n")
+	opf.WriteString("</metadata>\n<manifest>\n")
+	opf.WriteString("<item id=\"nav\" href=\"nav.xhtml\" media-type=\"application/xhtml+xml\" properties=\"nav\"/>\n")
+	for i, c := range b.chapters {
+		fmt.Fprintf(&opf, "<item id=\"c%d\" href=\"%s\" media-type=\"application/xhtml+xml\"/>\n", i+1, c.href)
+	}
+	for i, src := range b.order {
+		name := b.images[src]
+		fmt.Fprintf(&opf, "<item id=\"i%d\" href=\"images/%s\" media-type=\"%s\"/>\n",
+			i+1, render.Escape(url.PathEscape(name)), render.MimeType(name, nil))
+	}
+	opf.WriteString("</manifest>\n<spine>\n")
+	for i := range b.chapters {
+		fmt.Fprintf(&opf, "<itemref idref=\"c%d\"/>\n", i+1)
+	}
+	opf.WriteString("</spine>\n</package>\n")
+	return b.write("OEBPS/content.opf", opf.String())
+}
@@ -0,0 +1,69 @@
+package epub
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/autumnterror/breezynotes/internal/blocknote/pkg/render"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type archive struct {
+	names []string
+	files map[string]*bytes.Buffer
+	srcs  map[string]string
+}
+
+func (a *archive) Create(name string) (io.Writer, error) {
+	a.names = append(a.names, name)
+	a.files[name] = &bytes.Buffer{}
+	return a.files[name], nil
+}
+
+func (a *archive) File(name, src string) error {
+	a.names = append(a.names, name)
+	a.srcs[name] = src
+	return nil
+}
+
+func TestBook(t *testing.T) {
+	t.Parallel()
+
+	a := &archive{files: make(map[string]*bytes.Buffer), srcs: make(map[string]string)}
+	b, err := New(a, "id", "Tom & Jerry: blog", "Tom & Jerry")
+	require.NoError(t, err)
+
+	f := b.Files()
+	assert.Equal(t, "../images/1-a.png", f.Src("/files/a.png"))
+	assert.Equal(t, "../images/1-a.png", f.Src("/files/a.png"), "same picture is put once")
+	assert.Equal(t, "../images/2-b%20c.jpg", f.Src("/files/b c.jpg"))
+	assert.Empty(t, f.Src("https://example.com/c.png"), "picture from other site is left by url")
+	assert.Nil(t, f.Data("/files/a.png"))
+
+	require.NoError(t, b.Chapter("First <1>", render.Document("First", "<p>one</p>")))
+	require.NoError(t, b.Chapter("Second", render.Document("Second", "<p>two</p>")))
+	require.NoError(t, b.Close())
+
+	assert.Equal(t, []string{
+		"mimetype",
+		"META-INF/container.xml",
+		"OEBPS/notes/1.xhtml",
+		"OEBPS/notes/2.xhtml",
+		"OEBPS/images/1-a.png",
+		"OEBPS/images/2-b c.jpg",
+		"OEBPS/nav.xhtml",
+		"OEBPS/content.opf",
+	}, a.names)
+	assert.Equal(t, MimeType, a.files["mimetype"].String())
+	assert.Equal(t, "/files/b c.jpg", a.srcs["OEBPS/images/2-b c.jpg"])
+
+	nav := a.files["OEBPS/nav.xhtml"].String()
+	assert.Contains(t, nav, `<a href="notes/1.xhtml">First &lt;1&gt;</a>`)
+
+	opf := a.files["OEBPS/content.opf"].String()
+	assert.Contains(t, opf, "<dc:creator>Tom &amp; Jerry</dc:creator>")
+	assert.Contains(t, opf, `href="images/2-b%20c.jpg" media-type="image/jpeg"`)
+	assert.Contains(t, opf, `<itemref idref="c2"/>`)
+}
@@ -0,0 +1,41 @@
+package render
+
+import (
+	"html"
+	"strings"
+)
+
+// CSS of exported notes. Code is highlighted with inline styles, so it is not here
+const CSS = `body { margin: 0 auto; max-width: 46em; padding: 2em 1em; font-family: -apple-system, "Segoe UI", Roboto, "Helvetica Neue", Arial, sans-serif; line-height: 1.6; color: #1f2328; }
+h1, h2, h3, h4 { line-height: 1.25; margin: 1.4em 0 0.6em; }
+h1 { font-size: 2em; margin-top: 0; }
+p { margin: 0.6em 0; }
+code, pre { font-family: ui-monospace, SFMono-Regular, Menlo, Consolas, monospace; font-size: 0.9em; }
+code { background: #f3f4f6; border-radius: 4px; padding: 0.1em 0.3em; }
+pre { padding: 0.8em 1em; border-radius: 6px; overflow-x: auto; line-height: 1.45; }
+pre code { background: none; padding: 0; }
+blockquote { margin: 0.8em 0; padding: 0 1em; border-left: 4px solid #d0d7de; color: #59636e; }
+figure { margin: 1em 0; text-align: center; }
+img { max-width: 100%; height: auto; }
+figcaption { color: #59636e; font-size: 0.9em; }
+a { color: #0969da; }
+.item { margin: 0.2em 0; }
+.marker { display: inline-block; min-width: 1.4em; }
+.done { color: #59636e; text-decoration: line-through; }
+`
+
+// Escape text for html and xml
+func Escape(s string) string {
+	return html.EscapeString(s)
+}
+
+// Document return standalone page. It is also valid XHTML, so it is used for chapters of EPUB
+func Document(title, body string) string {
+	var sb strings.Builder
+	sb.WriteString("<!DOCTYPE html>\n<html xmlns=\"http://www.w3.org/1999/xhtml\">\n<head>\n<meta charset=\"utf-8\" />\n")
+	sb.WriteString("<meta name=\"viewport\" content=\"width=device-width, initial-scale=1\" />\n")
+	sb.WriteString("<title>" + Escape(title) + "</title>\n<style>\n" + CSS + "</style>\n</head>\n<body>\n")
+	sb.WriteString(body)
+	sb.WriteString("</body>\n</html>\n")
+	return sb.String()
+}
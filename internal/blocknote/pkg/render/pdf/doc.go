@@ -0,0 +1,387 @@
+package pdf
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// size of A4 page and its margins in points
+const (
+	PageWidth  = 595.28
+	PageHeight = 841.89
+	Margin     = 56.0
+
+	// leading height of line relative to font size
+	leading = 1.4
+)
+
+type Color [3]float64
+
+var (
+	Black = Color{0, 0, 0}
+	Gray  = Color{0.4, 0.4, 0.4}
+	Blue  = Color{0.05, 0.35, 0.75}
+)
+
+// Style of part of text. Zero size is 11pt
+type Style struct {
+	Size      float64
+	Mono      bool
+	Bold      bool
+	Italic    bool
+	Underline bool
+	Strike    bool
+	Color     Color
+	// Link url which is opened by click on text
+	Link string
+}
+
+type Span struct {
+	Text  string
+	Style Style
+}
+
+// Par layout of paragraph
+type Par struct {
+	// Indent of lines from left margin
+	Indent float64
+	// Marker is written before first line in place of indent, like bullet of list
+	Marker []Span
+	// Before space above paragraph
+	Before float64
+	// Fill is background of lines, zero is none
+	Fill Color
+	// Bar is drawn left of lines, like border of quote
+	Bar bool
+}
+
+// Doc is document which is written from top to bottom, new page is added when text doesn't fit
+type Doc struct {
+	font, mono *Font
+	title      string
+
+	pages []*page
+	y     float64
+
+	images []*image
+	// used glyphs of fonts for width array and text extraction
+	used [2]map[uint16]rune
+}
+
+type page struct {
+	content strings.Builder
+	links   []link
+}
+
+type link struct {
+	rect [4]float64
+	url  string
+}
+
+// New return empty document. Nil font is Helvetica, nil mono is Courier
+func New(title string, font, mono *Font) *Doc {
+	if font == nil {
+		font = Helvetica()
+	}
+	if mono == nil {
+		mono = Courier()
+	}
+	return &Doc{
+		font:  font,
+		mono:  mono,
+		title: title,
+		used:  [2]map[uint16]rune{make(map[uint16]rune), make(map[uint16]rune)},
+	}
+}
+
+func (d *Doc) width() float64 {
+	return PageWidth - 2*Margin
+}
+
+func (d *Doc) page() *page {
+	if len(d.pages) == 0 {
+		d.newPage()
+	}
+	return d.pages[len(d.pages)-1]
+}
+
+func (d *Doc) newPage() {
+	d.pages = append(d.pages, &page{})
+	d.y = PageHeight - Margin
+}
+
+// room start new page if there is no h points left on this one
+func (d *Doc) room(h float64) {
+	d.page()
+	if d.y-h < Margin && d.y < PageHeight-Margin {
+		d.newPage()
+	}
+}
+
+// Space move down by h points, at top of page space is skipped
+func (d *Doc) Space(h float64) {
+	d.page()
+	if d.y >= PageHeight-Margin {
+		return
+	}
+	d.y -= h
+}
+
+func (st Style) size() float64 {
+	if st.Size <= 0 {
+		return 11
+	}
+	return st.Size
+}
+
+func (d *Doc) fontOf(st Style) (*Font, int) {
+	if st.Mono {
+		return d.mono, 1
+	}
+	return d.font, 0
+}
+
+// word is piece of text which is not split between lines
+type word struct {
+	text  string
+	style Style
+	w     float64
+	space bool
+	br    bool
+}
+
+func (d *Doc) words(spans []Span) []word {
+	var res []word
+	for _, s := range spans {
+		f, _ := d.fontOf(s.Style)
+		size := s.Style.size()
+		rest := s.Text
+		for rest != "" {
+			i := strings.IndexFunc(rest, unicode.IsSpace)
+			switch {
+			case i == 0:
+				r := []rune(rest)[0]
+				n := len(string(r))
+				switch r {
+				case '\n':
+					res = append(res, word{br: true, style: s.Style})
+				case '\t':
+					res = append(res, word{text: "    ", style: s.Style, w: f.width("    ", size), space: true})
+				default:
+					res = append(res, word{text: " ", style: s.Style, w: f.width(" ", size), space: true})
+				}
+				rest = rest[n:]
+				continue
+			case i < 0:
+				i = len(rest)
+			}
+			res = append(res, word{text: rest[:i], style: s.Style, w: f.width(rest[:i], size)})
+			rest = rest[i:]
+		}
+	}
+	return res
+}
+
+// lines split words to lines not wider than max. Word which is wider than line is split by chars.
+// Spaces at start of line are kept only after line break, so indents of code stay
+func (d *Doc) lines(ws []word, max float64) [][]word {
+	var (
+		res     [][]word
+		line    []word
+		x       float64
+		wrapped bool
+	)
+	flush := func(wrap bool) {
+		for len(line) > 0 && line[len(line)-1].space {
+			line = line[:len(line)-1]
+		}
+		res = append(res, line)
+		line, x, wrapped = nil, 0, wrap
+	}
+
+	for _, w := range ws {
+		switch {
+		case w.br:
+			if len(line) == 0 {
+				// empty line keeps height of its text
+				line = append(line, word{style: w.style})
+			}
+			flush(false)
+			continue
+		case w.space && len(line) == 0 && wrapped:
+			continue
+		case x+w.w <= max || w.space:
+			line = append(line, w)
+			x += w.w
+			continue
+		case len(line) > 0 && w.w <= max:
+			flush(true)
+			line = append(line, w)
+			x = w.w
+			continue
+		}
+
+		f, _ := d.fontOf(w.style)
+		size := w.style.size()
+		part := ""
+		for _, r := range w.text {
+			rw := f.width(string(r), size)
+			if x+rw > max && (part != "" || len(line) > 0) {
+				if part != "" {
+					line = append(line, word{text: part, style: w.style, w: f.width(part, size)})
+				}
+				flush(true)
+				part = ""
+			}
+			part += string(r)
+			x += rw
+		}
+		if part != "" {
+			line = append(line, word{text: part, style: w.style, w: f.width(part, size)})
+		}
+	}
+	if len(line) > 0 || len(res) == 0 {
+		flush(false)
+	}
+	return res
+}
+
+// Text write paragraph, lines are wrapped by words
+func (d *Doc) Text(spans []Span, p Par) {
+	d.Space(p.Before)
+
+	left := Margin + p.Indent
+	lines := d.lines(d.words(spans), PageWidth-Margin-left)
+	for i, line := range lines {
+		size := 0.0
+		for _, w := range line {
+			size = max(size, w.style.size())
+		}
+		if size == 0 {
+			size = Style{}.size()
+		}
+		h := size * leading
+
+		d.room(h)
+		pg := d.page()
+		top := d.y
+		base := top - size*1.1
+
+		if p.Fill != (Color{}) {
+			fmt.Fprintf(&pg.content, "q %s rg %s re f Q\n", p.Fill.pdf(), rect(left-4, top-h, PageWidth-Margin-left+8, h))
+		}
+		if p.Bar {
+			fmt.Fprintf(&pg.content, "q %s rg %s re f Q\n", Color{0.8, 0.8, 0.8}.pdf(), rect(left-12, top-h, 3, h))
+		}
+		if i == 0 && len(p.Marker) > 0 {
+			mw := 0.0
+			for _, m := range p.Marker {
+				f, _ := d.fontOf(m.Style)
+				mw += f.width(m.Text, m.Style.size())
+			}
+			x := left - mw - 6
+			for _, m := range p.Marker {
+				x += d.run(pg, m.Text, m.Style, x, base)
+			}
+		}
+
+		// words of one style are drawn at once
+		x := left
+		for j := 0; j < len(line); {
+			s := line[j].text
+			k := j + 1
+			for ; k < len(line) && line[k].style == line[j].style; k++ {
+				s += line[k].text
+			}
+			x += d.run(pg, s, line[j].style, x, base)
+			j = k
+		}
+		d.y -= h
+	}
+}
+
+// run draw text of one style at baseline and return its width
+func (d *Doc) run(pg *page, s string, st Style, x, base float64) float64 {
+	f, n := d.fontOf(st)
+	size := st.size()
+	w := f.width(s, size)
+	if s == "" {
+		return 0
+	}
+
+	var hex strings.Builder
+	for _, r := range s {
+		g, _ := f.glyph(r)
+		if f.std != "" {
+			fmt.Fprintf(&hex, "%02X", g)
+			continue
+		}
+		if _, ok := d.used[n][g]; !ok {
+			d.used[n][g] = r
+		}
+		fmt.Fprintf(&hex, "%04X", g)
+	}
+
+	skew := 0.0
+	if st.Italic {
+		skew = 0.2
+	}
+	c := st.Color.pdf()
+	fmt.Fprintf(&pg.content, "q %s rg %s RG ", c, c)
+	if st.Bold {
+		fmt.Fprintf(&pg.content, "2 Tr %s w ", num(size*0.03))
+	}
+	fmt.Fprintf(&pg.content, "BT /F%d %s Tf 1 0 %s 1 %s %s Tm <%s> Tj ET", n+1, num(size), num(skew), num(x), num(base), hex.String())
+	if st.Underline || st.Link != "" {
+		fmt.Fprintf(&pg.content, " %s re f", rect(x, base-size*0.15, w, size*0.05))
+	}
+	if st.Strike {
+		fmt.Fprintf(&pg.content, " %s re f", rect(x, base+size*0.28, w, size*0.05))
+	}
+	pg.content.WriteString(" Q\n")
+
+	if st.Link != "" {
+		pg.links = append(pg.links, link{rect: [4]float64{x, base - size*0.3, x + w, base + size}, url: st.Link})
+	}
+	return w
+}
+
+// Image write picture scaled to width of page, or to its height if picture is tall.
+// Picture isn't made bigger than 1px to 1pt. Error is returned if format of picture isn't supported
+func (d *Doc) Image(data []byte, before float64) error {
+	img, err := loadImage(data)
+	if err != nil {
+		return err
+	}
+	d.Space(before)
+
+	scale := min(1, d.width()/img.w, (PageHeight-2*Margin)/img.h)
+	w, h := img.w*scale, img.h*scale
+	d.room(h)
+	pg := d.page()
+
+	img.id = len(d.images) + 1
+	d.images = append(d.images, img)
+	fmt.Fprintf(&pg.content, "q %s 0 0 %s %s %s cm /Im%d Do Q\n", num(w), num(h), num(Margin), num(d.y-h), img.id)
+	d.y -= h
+	return nil
+}
+
+func (c Color) pdf() string {
+	return num(c[0]) + " " + num(c[1]) + " " + num(c[2])
+}
+
+func rect(x, y, w, h float64) string {
+	return num(x) + " " + num(y) + " " + num(w) + " " + num(h)
+}
+
+// num format number with 2 digits after point without trailing zeros
+func num(f float64) string {
+	s := strings.TrimRight(fmt.Sprintf("%.2f", f), "0")
+	s = strings.TrimSuffix(s, ".")
+	if s == "-0" || s == "" {
+		return "0"
+	}
+	return s
+}
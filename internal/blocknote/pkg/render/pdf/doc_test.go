@@ -0,0 +1,119 @@
+package pdf
+
+import (
+	"bytes"
+	goimage "image"
+	"image/jpeg"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// checkXref check that offsets of xref table point to objects
+func checkXref(t *testing.T, raw []byte) {
+	t.Helper()
+	m := regexp.MustCompile(`startxref\n(\d+)\n%%EOF\n$`).FindSubmatch(raw)
+	require.NotNil(t, m)
+	xref, err := strconv.Atoi(string(m[1]))
+	require.NoError(t, err)
+	require.True(t, bytes.HasPrefix(raw[xref:], []byte("xref\n")))
+
+	lines := strings.Split(string(raw[xref:]), "\n")
+	count, err := strconv.Atoi(strings.Fields(lines[1])[1])
+	require.NoError(t, err)
+	for i := 1; i < count; i++ {
+		off, err := strconv.Atoi(lines[2+i][:10])
+		require.NoError(t, err)
+		assert.True(t, bytes.HasPrefix(raw[off:], []byte(strconv.Itoa(i)+" 0 obj\n")), "object %d", i)
+	}
+}
+
+func TestWriteTo(t *testing.T) {
+	t.Parallel()
+
+	d := New("Title (1)", nil, nil)
+	d.Text([]Span{
+		{Text: "plain "},
+		{Text: "link", Style: Style{Link: "https://example.com/путь", Underline: true, Color: Blue}},
+		{Text: " code", Style: Style{Mono: true}},
+	}, Par{})
+
+	var buf bytes.Buffer
+	_, err := d.WriteTo(&buf)
+	require.NoError(t, err)
+	raw := buf.Bytes()
+
+	assert.True(t, bytes.HasPrefix(raw, []byte("%PDF-1.7\n")))
+	assert.Contains(t, buf.String(), "/Title (Title \\(1\\))")
+	assert.Contains(t, buf.String(), "/URI (https://example.com/%D0%BF%D1%83%D1%82%D1%8C)")
+	assert.Contains(t, buf.String(), "/BaseFont /Helvetica")
+	assert.Contains(t, buf.String(), "/BaseFont /Courier")
+	checkXref(t, raw)
+}
+
+func TestTextPages(t *testing.T) {
+	t.Parallel()
+
+	d := New("", nil, nil)
+	long := strings.Repeat("word ", 2000)
+	d.Text([]Span{{Text: long}}, Par{})
+	assert.Greater(t, len(d.pages), 1, "text which doesn't fit is continued on next page")
+
+	var buf bytes.Buffer
+	_, err := d.WriteTo(&buf)
+	require.NoError(t, err)
+	assert.Contains(t, buf.String(), "/Count "+strconv.Itoa(len(d.pages)))
+	checkXref(t, buf.Bytes())
+}
+
+func TestLines(t *testing.T) {
+	t.Parallel()
+
+	d := New("", nil, nil)
+	ls := d.lines(d.words([]Span{{Text: "aaa bbb\n  ccc", Style: Style{Mono: true}}}), 1000)
+	require.Len(t, ls, 2, "hard break starts new line")
+
+	ls = d.lines(d.words([]Span{{Text: strings.Repeat("a ", 200)}}), 100)
+	assert.Greater(t, len(ls), 1, "long line is wrapped")
+}
+
+func TestImage(t *testing.T) {
+	t.Parallel()
+
+	d := New("", nil, nil)
+	assert.ErrorIs(t, d.Image([]byte("not a picture"), 0), ErrImageFormat)
+
+	var buf bytes.Buffer
+	require.NoError(t, jpeg.Encode(&buf, goimage.NewRGBA(goimage.Rect(0, 0, 2000, 10)), nil))
+	require.NoError(t, d.Image(buf.Bytes(), 0))
+	require.Len(t, d.images, 1)
+	assert.Equal(t, "/DCTDecode", d.images[0].filter, "jpeg is written as is")
+
+	var out bytes.Buffer
+	_, err := d.WriteTo(&out)
+	require.NoError(t, err)
+	assert.Contains(t, out.String(), "/Width 2000 /Height 10")
+	checkXref(t, out.Bytes())
+}
+
+func TestParseTTF(t *testing.T) {
+	t.Parallel()
+
+	_, err := ParseTTF([]byte("short"))
+	assert.ErrorIs(t, err, ErrBadFont)
+	_, err = ParseTTF([]byte("OTTO00000000000000"))
+	assert.ErrorIs(t, err, ErrFontOutlines)
+	_, err = ParseTTF([]byte("\x00\x01\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00"))
+	assert.Error(t, err, "font without tables")
+}
+
+func TestPdfString(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, `(a\(b\)\\)`, pdfString(`a(b)\`))
+	assert.Equal(t, "<FEFF0430>", pdfString("а"))
+}
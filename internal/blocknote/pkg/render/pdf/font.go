@@ -0,0 +1,325 @@
+package pdf
+
+import (
+	"encoding/binary"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode"
+)
+
+var (
+	ErrBadFont       = errors.New("bad font")
+	ErrFontOutlines  = errors.New("only fonts with TrueType outlines are supported")
+	ErrFontNoUnicode = errors.New("font has no unicode cmap")
+)
+
+// Font for text of document. Standard fonts are not embedded and have only chars of WinAnsi encoding,
+// others are shown as '?'. TrueType font is embedded whole, so document has all its chars
+type Font struct {
+	name string
+	// std is base font of standard font, empty for TrueType
+	std    string
+	widths func(r rune) int
+
+	raw        []byte
+	glyphs     map[rune]uint16
+	advances   []int
+	unitsPerEm int
+	ascent     int
+	descent    int
+	bbox       [4]int
+}
+
+// Helvetica standard sans font
+func Helvetica() *Font {
+	return &Font{name: "Helvetica", std: "Helvetica", widths: helveticaWidth}
+}
+
+// Courier standard monospace font
+func Courier() *Font {
+	return &Font{name: "Courier", std: "Courier", widths: func(rune) int { return 600 }}
+}
+
+// LoadTTF read TrueType font from file, see ParseTTF
+func LoadTTF(path string) (*Font, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	f, err := ParseTTF(raw)
+	if err != nil {
+		return nil, err
+	}
+	f.name = fontName(strings.TrimSuffix(filepath.Base(path), filepath.Ext(path)))
+	return f, nil
+}
+
+// ParseTTF read metrics and unicode cmap of TrueType font
+func ParseTTF(raw []byte) (*Font, error) {
+	if len(raw) < 12 {
+		return nil, ErrBadFont
+	}
+	switch string(raw[:4]) {
+	case "\x00\x01\x00\x00", "true":
+	case "OTTO":
+		return nil, ErrFontOutlines
+	default:
+		return nil, ErrBadFont
+	}
+
+	tables := make(map[string][]byte)
+	n := int(u16(raw, 4))
+	for i := 0; i < n; i++ {
+		rec := 12 + 16*i
+		if rec+16 > len(raw) {
+			return nil, ErrBadFont
+		}
+		off, size := int(u32(raw, rec+8)), int(u32(raw, rec+12))
+		if off < 0 || size < 0 || off+size > len(raw) {
+			return nil, ErrBadFont
+		}
+		tables[string(raw[rec:rec+4])] = raw[off : off+size]
+	}
+
+	head, hhea, hmtx, cmap := tables["head"], tables["hhea"], tables["hmtx"], tables["cmap"]
+	if len(head) < 54 || len(hhea) < 36 || hmtx == nil || cmap == nil {
+		return nil, ErrBadFont
+	}
+
+	f := &Font{
+		name:       "Font",
+		raw:        raw,
+		unitsPerEm: int(u16(head, 18)),
+		ascent:     int(int16(u16(hhea, 4))),
+		descent:    int(int16(u16(hhea, 6))),
+		bbox: [4]int{
+			int(int16(u16(head, 36))), int(int16(u16(head, 38))),
+			int(int16(u16(head, 40))), int(int16(u16(head, 42))),
+		},
+	}
+	if f.unitsPerEm == 0 {
+		return nil, ErrBadFont
+	}
+
+	metrics := int(u16(hhea, 34))
+	if metrics == 0 || len(hmtx) < 4*metrics {
+		return nil, ErrBadFont
+	}
+	for i := 0; i < metrics; i++ {
+		f.advances = append(f.advances, int(u16(hmtx, 4*i)))
+	}
+
+	glyphs, err := parseCmap(cmap)
+	if err != nil {
+		return nil, err
+	}
+	f.glyphs = glyphs
+	return f, nil
+}
+
+// parseCmap read unicode subtable of cmap, full unicode subtable (format 12) is preferred to BMP one (format 4)
+func parseCmap(cmap []byte) (map[rune]uint16, error) {
+	if len(cmap) < 4 {
+		return nil, ErrBadFont
+	}
+	bmp, full := -1, -1
+	for i := 0; i < int(u16(cmap, 2)); i++ {
+		rec := 4 + 8*i
+		if rec+8 > len(cmap) {
+			return nil, ErrBadFont
+		}
+		platform, encoding, off := u16(cmap, rec), u16(cmap, rec+2), int(u32(cmap, rec+4))
+		if off+2 > len(cmap) || !(platform == 0 || platform == 3 && (encoding == 1 || encoding == 10)) {
+			continue
+		}
+		switch u16(cmap, off) {
+		case 4:
+			bmp = off
+		case 12:
+			full = off
+		}
+	}
+
+	switch {
+	case full >= 0:
+		return cmap12(cmap[full:])
+	case bmp >= 0:
+		return cmap4(cmap[bmp:])
+	}
+	return nil, ErrFontNoUnicode
+}
+
+func cmap4(t []byte) (map[rune]uint16, error) {
+	if len(t) < 14 {
+		return nil, ErrBadFont
+	}
+	segs := int(u16(t, 6)) / 2
+	ends, starts, deltas, ranges := 14, 16+2*segs, 16+4*segs, 16+6*segs
+	if ranges+2*segs > len(t) {
+		return nil, ErrBadFont
+	}
+
+	res := make(map[rune]uint16)
+	for i := 0; i < segs; i++ {
+		end, start := int(u16(t, ends+2*i)), int(u16(t, starts+2*i))
+		delta, rangeOff := u16(t, deltas+2*i), int(u16(t, ranges+2*i))
+		for c := start; c <= end && c != 0xFFFF; c++ {
+			g := uint16(c) + delta
+			if rangeOff != 0 {
+				at := ranges + 2*i + rangeOff + 2*(c-start)
+				if at+2 > len(t) {
+					break
+				}
+				if g = u16(t, at); g != 0 {
+					g += delta
+				}
+			}
+			if g != 0 {
+				res[rune(c)] = g
+			}
+		}
+	}
+	return res, nil
+}
+
+func cmap12(t []byte) (map[rune]uint16, error) {
+	if len(t) < 16 {
+		return nil, ErrBadFont
+	}
+	groups := int(u32(t, 12))
+	if 16+12*groups > len(t) {
+		return nil, ErrBadFont
+	}
+
+	res := make(map[rune]uint16)
+	for i := 0; i < groups; i++ {
+		g := 16 + 12*i
+		start, end, glyph := u32(t, g), u32(t, g+4), u32(t, g+8)
+		if end > unicode.MaxRune || end < start {
+			continue
+		}
+		for c := start; c <= end; c++ {
+			res[rune(c)] = uint16(glyph + c - start)
+		}
+	}
+	return res, nil
+}
+
+// glyph return code of char in font and its width in 1/1000 of font size. Char which font doesn't have is '?'
+func (f *Font) glyph(r rune) (uint16, int) {
+	if f.std != "" {
+		c, ok := winAnsi(r)
+		if !ok {
+			c, r = '?', '?'
+		}
+		return uint16(c), f.widths(r)
+	}
+
+	g := f.glyphs[r]
+	if g == 0 {
+		g = f.glyphs['?']
+	}
+	adv := f.advances[len(f.advances)-1]
+	if int(g) < len(f.advances) {
+		adv = f.advances[g]
+	}
+	return g, adv * 1000 / f.unitsPerEm
+}
+
+// width of text in points
+func (f *Font) width(s string, size float64) float64 {
+	w := 0
+	for _, r := range s {
+		_, gw := f.glyph(r)
+		w += gw
+	}
+	return float64(w) * size / 1000
+}
+
+func fontName(s string) string {
+	var sb strings.Builder
+	for _, r := range s {
+		if r < 128 && (unicode.IsLetter(r) || unicode.IsDigit(r) || r == '-') {
+			sb.WriteRune(r)
+		}
+	}
+	if sb.Len() == 0 {
+		return "Font"
+	}
+	return sb.String()
+}
+
+func u16(b []byte, off int) uint16 {
+	if off < 0 || off+2 > len(b) {
+		return 0
+	}
+	return binary.BigEndian.Uint16(b[off:])
+}
+
+func u32(b []byte, off int) uint32 {
+	if off < 0 || off+4 > len(b) {
+		return 0
+	}
+	return binary.BigEndian.Uint32(b[off:])
+}
+
+// winAnsiExtra chars of WinAnsi encoding which are not at their latin-1 place
+var winAnsiExtra = map[rune]byte{
+	'€': 0x80, '‚': 0x82, 'ƒ': 0x83, '„': 0x84, '…': 0x85, '†': 0x86, '‡': 0x87, 'ˆ': 0x88,
+	'‰': 0x89, 'Š': 0x8A, '‹': 0x8B, 'Œ': 0x8C, 'Ž': 0x8E, '‘': 0x91, '’': 0x92, '“': 0x93,
+	'”': 0x94, '•': 0x95, '–': 0x96, '—': 0x97, '˜': 0x98, '™': 0x99, 'š': 0x9A, '›': 0x9B,
+	'œ': 0x9C, 'ž': 0x9E, 'Ÿ': 0x9F,
+}
+
+func winAnsi(r rune) (byte, bool) {
+	switch {
+	case r >= 0x20 && r < 0x7F, r >= 0xA0 && r <= 0xFF:
+		return byte(r), true
+	case r == '\t':
+		return ' ', true
+	}
+	c, ok := winAnsiExtra[r]
+	return c, ok
+}
+
+// helveticaAscii widths of chars from ' ' to '~'
+var helveticaAscii = [...]int{
+	278, 278, 355, 556, 556, 889, 667, 191, 333, 333, 389, 584, 278, 333, 278, 278,
+	556, 556, 556, 556, 556, 556, 556, 556, 556, 556, 278, 278, 584, 584, 584, 556,
+	1015, 667, 667, 722, 722, 667, 611, 778, 722, 278, 500, 667, 556, 833, 722, 778,
+	667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, 278, 278, 278, 469, 556,
+	333, 556, 556, 500, 556, 556, 278, 556, 556, 222, 222, 500, 222, 833, 556, 556,
+	556, 556, 333, 500, 278, 556, 500, 722, 500, 500, 500, 334, 260, 334, 584,
+}
+
+// helveticaLatin widths of chars from 0xA0 to 0xFF
+var helveticaLatin = [...]int{
+	278, 333, 556, 556, 556, 556, 260, 556, 333, 737, 370, 556, 584, 333, 737, 333,
+	400, 584, 333, 333, 333, 556, 537, 278, 333, 333, 365, 556, 834, 834, 834, 611,
+	667, 667, 667, 667, 667, 667, 1000, 722, 667, 667, 667, 667, 278, 278, 278, 278,
+	722, 722, 778, 778, 778, 778, 778, 584, 778, 722, 722, 722, 722, 667, 667, 611,
+	556, 556, 556, 556, 556, 556, 889, 500, 556, 556, 556, 556, 278, 278, 278, 278,
+	556, 556, 556, 556, 556, 556, 556, 584, 611, 556, 556, 556, 556, 500, 556, 500,
+}
+
+var helveticaExtra = map[rune]int{
+	'‚': 222, '„': 333, '…': 1000, '‹': 333, '›': 333, '‘': 222, '’': 222, '“': 333, '”': 333,
+	'•': 350, '—': 1000, '™': 1000, 'Œ': 1000, 'œ': 944, 'ˆ': 333, '˜': 333, '‰': 1000,
+}
+
+func helveticaWidth(r rune) int {
+	switch {
+	case r >= 0x20 && r < 0x7F:
+		return helveticaAscii[r-0x20]
+	case r >= 0xA0 && r <= 0xFF:
+		return helveticaLatin[r-0xA0]
+	case r == '\t':
+		return helveticaAscii[0]
+	}
+	if w, ok := helveticaExtra[r]; ok {
+		return w
+	}
+	return 556
+}
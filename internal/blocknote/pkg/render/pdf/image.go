@@ -0,0 +1,71 @@
+package pdf
+
+import (
+	"bytes"
+	"errors"
+	goimage "image"
+	"image/color"
+	_ "image/gif"
+	_ "image/jpeg"
+	_ "image/png"
+)
+
+var ErrImageFormat = errors.New("unsupported image format")
+
+// image is XObject of picture. JPEG is written as is, other formats are decoded to RGB with alpha mask
+type image struct {
+	id   int
+	w, h float64
+
+	data   []byte
+	filter string
+	space  string
+	alpha  []byte
+}
+
+func loadImage(data []byte) (*image, error) {
+	cfg, kind, err := goimage.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, ErrImageFormat
+	}
+	if cfg.Width == 0 || cfg.Height == 0 {
+		return nil, ErrImageFormat
+	}
+	img := &image{w: float64(cfg.Width), h: float64(cfg.Height)}
+
+	if kind == "jpeg" {
+		switch cfg.ColorModel {
+		case color.GrayModel:
+			img.space = "/DeviceGray"
+		case color.YCbCrModel, color.RGBAModel:
+			img.space = "/DeviceRGB"
+		}
+		if img.space != "" {
+			img.data, img.filter = data, "/DCTDecode"
+			return img, nil
+		}
+	}
+
+	src, _, err := goimage.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, ErrImageFormat
+	}
+	b := src.Bounds()
+	rgb := make([]byte, 0, 3*b.Dx()*b.Dy())
+	alpha := make([]byte, 0, b.Dx()*b.Dy())
+	opaque := true
+	for y := b.Min.Y; y < b.Max.Y; y++ {
+		for x := b.Min.X; x < b.Max.X; x++ {
+			c := color.NRGBAModel.Convert(src.At(x, y)).(color.NRGBA)
+			rgb = append(rgb, c.R, c.G, c.B)
+			alpha = append(alpha, c.A)
+			opaque = opaque && c.A == 0xFF
+		}
+	}
+	img.data, img.space = deflate(rgb), "/DeviceRGB"
+	img.filter = "/FlateDecode"
+	if !opaque {
+		img.alpha = deflate(alpha)
+	}
+	return img, nil
+}
@@ -0,0 +1,200 @@
+package pdf
+
+import (
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"unicode/utf16"
+)
+
+// writer collect objects of document, number of object is its index + 1
+type writer struct {
+	objs [][]byte
+}
+
+func (w *writer) reserve() int {
+	w.objs = append(w.objs, nil)
+	return len(w.objs)
+}
+
+func (w *writer) set(n int, body string) {
+	w.objs[n-1] = []byte(body)
+}
+
+func (w *writer) add(body string) int {
+	n := w.reserve()
+	w.set(n, body)
+	return n
+}
+
+// stream add stream object, dict is entries of its dictionary without length
+func (w *writer) stream(dict string, data []byte) int {
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "<< %s /Length %d >>\nstream\n", dict, len(data))
+	b.Write(data)
+	b.WriteString("\nendstream")
+	n := w.reserve()
+	w.objs[n-1] = b.Bytes()
+	return n
+}
+
+// WriteTo write document as PDF
+func (d *Doc) WriteTo(out io.Writer) (int64, error) {
+	d.page()
+	w := &writer{}
+
+	catalog := w.reserve()
+	pages := w.reserve()
+	info := w.add("<< /Title " + pdfString(d.title) + " /Producer (BreezyNotes) >>")
+	w.set(catalog, fmt.Sprintf("<< /Type /Catalog /Pages %d 0 R >>", pages))
+
+	fonts := []int{d.writeFont(w, d.font, d.used[0]), d.writeFont(w, d.mono, d.used[1])}
+
+	var xobjects strings.Builder
+	for _, img := range d.images {
+		dict := fmt.Sprintf("/Type /XObject /Subtype /Image /Width %d /Height %d /ColorSpace %s /BitsPerComponent 8 /Filter %s",
+			int(img.w), int(img.h), img.space, img.filter)
+		if img.alpha != nil {
+			mask := w.stream(fmt.Sprintf("/Type /XObject /Subtype /Image /Width %d /Height %d /ColorSpace /DeviceGray /BitsPerComponent 8 /Filter /FlateDecode",
+				int(img.w), int(img.h)), img.alpha)
+			dict += fmt.Sprintf(" /SMask %d 0 R", mask)
+		}
+		fmt.Fprintf(&xobjects, " /Im%d %d 0 R", img.id, w.stream(dict, img.data))
+	}
+	resources := fmt.Sprintf("<< /Font << /F1 %d 0 R /F2 %d 0 R >> /XObject <<%s >> >>", fonts[0], fonts[1], xobjects.String())
+
+	var kids []string
+	for _, p := range d.pages {
+		content := w.stream("/Filter /FlateDecode", deflate([]byte(p.content.String())))
+
+		var annots []string
+		for _, l := range p.links {
+			annots = append(annots, fmt.Sprintf("%d 0 R", w.add(fmt.Sprintf(
+				"<< /Type /Annot /Subtype /Link /Rect [%s %s %s %s] /Border [0 0 0] /A << /S /URI /URI %s >> >>",
+				num(l.rect[0]), num(l.rect[1]), num(l.rect[2]), num(l.rect[3]), uriString(l.url)))))
+		}
+		dict := fmt.Sprintf("<< /Type /Page /Parent %d 0 R /MediaBox [0 0 %s %s] /Resources %s /Contents %d 0 R",
+			pages, num(PageWidth), num(PageHeight), resources, content)
+		if len(annots) > 0 {
+			dict += " /Annots [" + strings.Join(annots, " ") + "]"
+		}
+		kids = append(kids, fmt.Sprintf("%d 0 R", w.add(dict+" >>")))
+	}
+	w.set(pages, fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(kids)))
+
+	var b bytes.Buffer
+	b.WriteString("%PDF-1.7\n%\xE2\xE3\xCF\xD3\n")
+	offsets := make([]int, len(w.objs))
+	for i, o := range w.objs {
+		offsets[i] = b.Len()
+		fmt.Fprintf(&b, "%d 0 obj\n", i+1)
+		b.Write(o)
+		b.WriteString("\nendobj\n")
+	}
+	xref := b.Len()
+	fmt.Fprintf(&b, "xref\n0 %d\n0000000000 65535 f \n", len(w.objs)+1)
+	for _, off := range offsets {
+		fmt.Fprintf(&b, "%010d 00000 n \n", off)
+	}
+	fmt.Fprintf(&b, "trailer\n<< /Size %d /Root %d 0 R /Info %d 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(w.objs)+1, catalog, info, xref)
+
+	return b.WriteTo(out)
+}
+
+// writeFont add font objects and return number of font dictionary. TrueType font is written as
+// composite font with glyph ids as codes, so text of any language can be shown
+func (d *Doc) writeFont(w *writer, f *Font, used map[uint16]rune) int {
+	if f.std != "" {
+		return w.add("<< /Type /Font /Subtype /Type1 /BaseFont /" + f.std + " /Encoding /WinAnsiEncoding >>")
+	}
+
+	scale := func(v int) int { return v * 1000 / f.unitsPerEm }
+	file := w.stream(fmt.Sprintf("/Filter /FlateDecode /Length1 %d", len(f.raw)), deflate(f.raw))
+	desc := w.add(fmt.Sprintf("<< /Type /FontDescriptor /FontName /%s /Flags 32 /FontBBox [%d %d %d %d] /ItalicAngle 0 /Ascent %d /Descent %d /CapHeight %d /StemV 80 /FontFile2 %d 0 R >>",
+		f.name, scale(f.bbox[0]), scale(f.bbox[1]), scale(f.bbox[2]), scale(f.bbox[3]),
+		scale(f.ascent), scale(f.descent), scale(f.ascent), file))
+
+	gids := make([]uint16, 0, len(used))
+	for g := range used {
+		gids = append(gids, g)
+	}
+	slices.Sort(gids)
+
+	var widths, cmap strings.Builder
+	for _, g := range gids {
+		_, gw := f.glyph(used[g])
+		fmt.Fprintf(&widths, "%d [%d] ", g, gw)
+	}
+	// missing glyph is shown for different chars, so it has no text
+	chars := slices.DeleteFunc(slices.Clone(gids), func(g uint16) bool { return g == 0 })
+	for i := 0; i < len(chars); i += 100 {
+		part := chars[i:min(i+100, len(chars))]
+		fmt.Fprintf(&cmap, "%d beginbfchar\n", len(part))
+		for _, g := range part {
+			fmt.Fprintf(&cmap, "<%04X> <", g)
+			for _, u := range utf16.Encode([]rune{used[g]}) {
+				fmt.Fprintf(&cmap, "%04X", u)
+			}
+			cmap.WriteString(">\n")
+		}
+		cmap.WriteString("endbfchar\n")
+	}
+	toUnicode := w.stream("/Filter /FlateDecode", deflate([]byte(
+		"/CIDInit /ProcSet findresource begin\n12 dict begin\nbegincmap\n"+
+			"/CIDSystemInfo << /Registry (Adobe) /Ordering (UCS) /Supplement 0 >> def\n"+
+			"/CMapName /Adobe-Identity-UCS def\n/CMapType 2 def\n"+
+			"1 begincodespacerange\n<0000> <FFFF>\nendcodespacerange\n"+
+			cmap.String()+
+			"endcmap\nCMapName currentdict /CMap defineresource pop\nend\nend\n")))
+
+	cid := w.add(fmt.Sprintf("<< /Type /Font /Subtype /CIDFontType2 /BaseFont /%s /CIDSystemInfo << /Registry (Adobe) /Ordering (Identity) /Supplement 0 >> /FontDescriptor %d 0 R /W [%s] /CIDToGIDMap /Identity >>",
+		f.name, desc, widths.String()))
+	return w.add(fmt.Sprintf("<< /Type /Font /Subtype /Type0 /BaseFont /%s /Encoding /Identity-H /DescendantFonts [%d 0 R] /ToUnicode %d 0 R >>",
+		f.name, cid, toUnicode))
+}
+
+// pdfString return text string, non-ASCII text is written as UTF-16 with byte order mark
+func pdfString(s string) string {
+	ascii := true
+	for _, r := range s {
+		if r < 0x20 || r > 0x7E {
+			ascii = false
+			break
+		}
+	}
+	if ascii {
+		return "(" + strings.NewReplacer(`\`, `\\`, "(", `\(`, ")", `\)`).Replace(s) + ")"
+	}
+
+	var b strings.Builder
+	b.WriteString("<FEFF")
+	for _, u := range utf16.Encode([]rune(s)) {
+		fmt.Fprintf(&b, "%04X", u)
+	}
+	b.WriteString(">")
+	return b.String()
+}
+
+// uriString return url as ASCII string, other bytes are percent encoded
+func uriString(u string) string {
+	var b strings.Builder
+	for i := 0; i < len(u); i++ {
+		if c := u[i]; c < 0x21 || c > 0x7E {
+			fmt.Fprintf(&b, "%%%02X", c)
+			continue
+		}
+		b.WriteByte(u[i])
+	}
+	return pdfString(b.String())
+}
+
+func deflate(data []byte) []byte {
+	var b bytes.Buffer
+	zw := zlib.NewWriter(&b)
+	_, _ = zw.Write(data)
+	_ = zw.Close()
+	return b.Bytes()
+}
@@ -0,0 +1,82 @@
+package render
+
+import (
+	"encoding/base64"
+	"mime"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+)
+
+// Files give stored files of blocks to renderers. Notes keep only src of file, content is in storage of gateway
+type Files interface {
+	// Src return url by which file is put in document, empty if file is not available
+	Src(src string) string
+	// Data return content of file, nil if it is not available
+	Data(src string) []byte
+}
+
+// Inline is content of files by src, in html they are put as data urls
+type Inline map[string][]byte
+
+func (f Inline) Src(src string) string {
+	data, ok := f[src]
+	if !ok {
+		return ""
+	}
+	return "data:" + MimeType(src, data) + ";base64," + base64.StdEncoding.EncodeToString(data)
+}
+
+func (f Inline) Data(src string) []byte {
+	return f[src]
+}
+
+// MimeType of file by extension of name, or by content if extension is unknown
+func MimeType(name string, data []byte) string {
+	if t := mime.TypeByExtension(strings.ToLower(path.Ext(name))); t != "" {
+		t, _, _ = strings.Cut(t, ";")
+		return t
+	}
+	if data == nil {
+		return "application/octet-stream"
+	}
+	t, _, _ := strings.Cut(http.DetectContentType(data), ";")
+	return t
+}
+
+// SafeUrl return url if it is http, https, relative or, for links, mailto. Other urls, like javascript: or data:, are dropped
+func SafeUrl(raw string, link bool) string {
+	raw = strings.TrimSpace(raw)
+	u, err := url.Parse(raw)
+	if err != nil || raw == "" {
+		return ""
+	}
+	switch strings.ToLower(u.Scheme) {
+	case "", "http", "https":
+		return raw
+	case "mailto":
+		if link {
+			return raw
+		}
+	}
+	return ""
+}
+
+// WebUrl return url if it is absolute http, https or mailto url, so it can be opened outside of site
+func WebUrl(raw string) string {
+	raw = strings.TrimSpace(raw)
+	u, err := url.Parse(raw)
+	if err != nil {
+		return ""
+	}
+	switch strings.ToLower(u.Scheme) {
+	case "http", "https":
+		if u.Host != "" {
+			return raw
+		}
+	case "mailto":
+		return raw
+	}
+	return ""
+}
@@ -0,0 +1,39 @@
+package text
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/autumnterror/breezynotes/internal/blocknote/pkg/document"
+)
+
+// Document return text as spans of document. Style is split to marks, default style has no marks
+func (tb *Data) Document() []document.Span {
+	res := []document.Span{}
+	if tb == nil {
+		return res
+	}
+	for _, p := range tb.Text {
+		var marks []string
+		for _, s := range styles(p.Style) {
+			if s != "default" && !slices.Contains(marks, s) {
+				marks = append(marks, s)
+			}
+		}
+		res = append(res, document.Span{Text: p.String, Marks: marks})
+	}
+	return res
+}
+
+// FromDocument return text of spans, marks are joined to style
+func FromDocument(spans []document.Span) *Data {
+	res := &Data{Text: make([]Part, 0, len(spans))}
+	for _, s := range spans {
+		style := "default"
+		if len(s.Marks) > 0 {
+			style = strings.Join(s.Marks, " ")
+		}
+		res.Text = append(res.Text, Part{Style: style, String: s.Text})
+	}
+	return res
+}
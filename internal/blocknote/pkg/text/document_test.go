@@ -0,0 +1,34 @@
+package text
+
+import (
+	"testing"
+
+	"github.com/autumnterror/breezynotes/internal/blocknote/pkg/document"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDocument(t *testing.T) {
+	t.Parallel()
+
+	var nilData *Data
+	assert.Equal(t, []document.Span{}, nilData.Document())
+
+	d := &Data{Text: []Part{
+		{Style: "default", String: "a"},
+		{Style: "italic,bold", String: "b"},
+		{Style: "bold bold", String: "c"},
+	}}
+	spans := d.Document()
+	assert.Equal(t, []document.Span{
+		{Text: "a"},
+		{Text: "b", Marks: []string{"italic", "bold"}},
+		{Text: "c", Marks: []string{"bold"}},
+	}, spans)
+
+	assert.Equal(t, []Part{
+		{Style: "default", String: "a"},
+		{Style: "italic bold", String: "b"},
+		{Style: "bold", String: "c"},
+	}, FromDocument(spans).Text)
+	assert.Equal(t, spans, FromDocument(spans).Document())
+}
@@ -0,0 +1,45 @@
+package text
+
+import (
+	"html"
+	"strings"
+)
+
+// htmlTags of styles, unknown styles are written as plain text
+var htmlTags = map[string]string{
+	"bold":          "strong",
+	"italic":        "em",
+	"strikethrough": "s",
+	"strike":        "s",
+	"underline":     "u",
+	"code":          "code",
+}
+
+// HTML return text with styles as html, new lines are written as br
+func (tb *Data) HTML() string {
+	if tb == nil {
+		return ""
+	}
+	var sb strings.Builder
+	for _, p := range tb.Text {
+		var tags []string
+		for _, s := range styles(p.Style) {
+			if t, ok := htmlTags[s]; ok {
+				tags = append(tags, t)
+			}
+		}
+		for _, t := range tags {
+			sb.WriteString("<" + t + ">")
+		}
+		sb.WriteString(strings.ReplaceAll(html.EscapeString(p.String), "\n", "<br />"))
+		for i := len(tags) - 1; i >= 0; i-- {
+			sb.WriteString("</" + tags[i] + ">")
+		}
+	}
+	return sb.String()
+}
+
+// styles of part, they are split by space or comma
+func styles(style string) []string {
+	return strings.FieldsFunc(style, func(r rune) bool { return r == ' ' || r == ',' })
+}
@@ -0,0 +1,47 @@
+package text
+
+import (
+	"testing"
+
+	"github.com/autumnterror/breezynotes/internal/blocknote/pkg/render/pdf"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHTML(t *testing.T) {
+	t.Parallel()
+
+	for name, tc := range map[string]struct {
+		data *Data
+		want string
+	}{
+		"nil":   {nil, ""},
+		"plain": {&Data{Text: []Part{{Style: "default", String: "a<b & c"}}}, "a&lt;b &amp; c"},
+		"styles": {&Data{Text: []Part{
+			{Style: "default", String: "hello "},
+			{Style: "italic,bold", String: "x"},
+		}}, "hello <em><strong>x</strong></em>"},
+		"lines":   {&Data{Text: []Part{{Style: "code", String: "a\nb"}}}, "<code>a<br />b</code>"},
+		"unknown": {&Data{Text: []Part{{Style: "shadow", String: "u"}}}, "u"},
+	} {
+		assert.Equal(t, tc.want, tc.data.HTML(), name)
+	}
+}
+
+func TestSpans(t *testing.T) {
+	t.Parallel()
+
+	var nilData *Data
+	assert.Nil(t, nilData.Spans(pdf.Style{}))
+
+	base := pdf.Style{Size: 12, Color: pdf.Gray}
+	res := (&Data{Text: []Part{
+		{Style: "default", String: "a"},
+		{Style: "bold underline", String: "b"},
+		{Style: "code,strike", String: "c"},
+	}}).Spans(base)
+	assert.Equal(t, []pdf.Span{
+		{Text: "a", Style: base},
+		{Text: "b", Style: pdf.Style{Size: 12, Color: pdf.Gray, Bold: true, Underline: true}},
+		{Text: "c", Style: pdf.Style{Size: 12, Color: pdf.Gray, Mono: true, Strike: true}},
+	}, res)
+}
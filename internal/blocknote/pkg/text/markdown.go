@@ -0,0 +1,73 @@
+package text
+
+import (
+	"strings"
+	"unicode"
+)
+
+// mdMarks markdown of styles. Styles without markdown, like underline, are written as plain text
+var mdMarks = map[string]string{
+	"bold":          "**",
+	"italic":        "*",
+	"strikethrough": "~~",
+	"strike":        "~~",
+	"code":          "`",
+}
+
+var mdEscaper = strings.NewReplacer(`\`, `\\`, "*", `\*`, "_", `\_`, "`", "\\`", "[", `\[`, "]", `\]`, "~", `\~`)
+
+// EscapeMarkdown escape chars which markdown reads as formatting
+func EscapeMarkdown(s string) string {
+	return mdEscaper.Replace(s)
+}
+
+// Markdown return text with styles as markdown. Style of part can be several styles split by space or comma
+func (tb *Data) Markdown() string {
+	if tb == nil {
+		return ""
+	}
+	var sb strings.Builder
+	for _, p := range tb.Text {
+		sb.WriteString(partMarkdown(p))
+	}
+	return sb.String()
+}
+
+func partMarkdown(p Part) string {
+	var marks []string
+	isCode := false
+	for _, s := range styles(p.Style) {
+		m, ok := mdMarks[s]
+		if !ok {
+			continue
+		}
+		if s == "code" {
+			isCode = true
+		}
+		marks = append(marks, m)
+	}
+
+	body := strings.TrimFunc(p.String, unicode.IsSpace)
+	if len(marks) == 0 || body == "" {
+		return EscapeMarkdown(p.String)
+	}
+	if !isCode {
+		body = EscapeMarkdown(body)
+	}
+
+	// markdown doesn't allow spaces inside of marks, so they are moved outside
+	start := strings.Index(p.String, strings.TrimLeftFunc(p.String, unicode.IsSpace))
+	end := len(strings.TrimRightFunc(p.String, unicode.IsSpace))
+
+	var sb strings.Builder
+	sb.WriteString(p.String[:start])
+	for _, m := range marks {
+		sb.WriteString(m)
+	}
+	sb.WriteString(body)
+	for i := len(marks) - 1; i >= 0; i-- {
+		sb.WriteString(marks[i])
+	}
+	sb.WriteString(p.String[end:])
+	return sb.String()
+}
@@ -0,0 +1,29 @@
+package text
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMarkdown(t *testing.T) {
+	t.Parallel()
+
+	for name, tc := range map[string]struct {
+		data *Data
+		want string
+	}{
+		"nil":   {nil, ""},
+		"plain": {&Data{Text: []Part{{Style: "default", String: "a*b_c"}}}, `a\*b\_c`},
+		"styles": {&Data{Text: []Part{
+			{Style: "default", String: "hello"},
+			{Style: "bold", String: " world "},
+			{Style: "italic,bold", String: "x"},
+		}}, "hello **world** ***x***"},
+		"code":    {&Data{Text: []Part{{Style: "code", String: "a*b"}}}, "`a*b`"},
+		"spaces":  {&Data{Text: []Part{{Style: "bold", String: "  "}}}, "  "},
+		"unknown": {&Data{Text: []Part{{Style: "underline", String: "u"}}}, "u"},
+	} {
+		assert.Equal(t, tc.want, tc.data.Markdown(), name)
+	}
+}
@@ -0,0 +1,230 @@
+package text
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Link found in markdown text. Image is ![alt](src), then Text is alt. File is link to file for download
+type Link struct {
+	Text  string
+	Url   string
+	Image bool
+	File  bool
+}
+
+// mdDelims markdown marks which are read as styles, longer first
+var mdDelims = []struct {
+	mark  string
+	style string
+}{
+	{"**", "bold"},
+	{"__", "bold"},
+	{"~~", "strikethrough"},
+	{"*", "italic"},
+	{"_", "italic"},
+}
+
+// ParseMarkdown read inline markdown: bold, italic, strikethrough and code become styles of parts,
+// links and images are returned separately and only their text stays in data. Unclosed marks are plain text
+func ParseMarkdown(s string) (*Data, []Link) {
+	p := &mdParser{}
+	p.parse(s, nil)
+	return &Data{Text: MergeSameStyles(p.parts)}, p.links
+}
+
+type mdParser struct {
+	parts []Part
+	links []Link
+}
+
+func (p *mdParser) add(s string, styles []string) {
+	if s == "" {
+		return
+	}
+	style := "default"
+	if len(styles) > 0 {
+		style = strings.Join(styles, " ")
+	}
+	p.parts = append(p.parts, Part{Style: style, String: s})
+}
+
+func (p *mdParser) parse(s string, styles []string) {
+	var plain strings.Builder
+	flush := func() {
+		p.add(plain.String(), styles)
+		plain.Reset()
+	}
+
+	for i := 0; i < len(s); {
+		c := s[i]
+		switch {
+		case c == '\\' && i+1 < len(s) && isPunct(s[i+1]):
+			plain.WriteByte(s[i+1])
+			i += 2
+			continue
+
+		case c == '`':
+			if end := strings.IndexByte(s[i+1:], '`'); end > 0 {
+				flush()
+				p.add(s[i+1:i+1+end], withStyle(styles, "code"))
+				i += end + 2
+				continue
+			}
+
+		case c == '!' && strings.HasPrefix(s[i+1:], "["):
+			if txt, url, n := parseLink(s[i+1:]); n > 0 {
+				p.links = append(p.links, Link{Text: txt, Url: url, Image: true})
+				i += n + 1
+				continue
+			}
+
+		case c == '[':
+			if txt, url, n := parseLink(s[i:]); n > 0 {
+				flush()
+				before := len(p.parts)
+				p.parse(txt, styles)
+				title := ""
+				for _, pt := range p.parts[before:] {
+					title += pt.String
+				}
+				p.links = append(p.links, Link{Text: title, Url: url})
+				i += n
+				continue
+			}
+
+		case c == '*' || c == '_' || c == '~':
+			if inner, style, n := p.delimited(s, i); n > 0 {
+				flush()
+				p.parse(inner, withStyle(styles, style))
+				i += n
+				continue
+			}
+		}
+
+		_, size := utf8.DecodeRuneInString(s[i:])
+		plain.WriteString(s[i : i+size])
+		i += size
+	}
+	flush()
+}
+
+// delimited find text between pair of marks at s[i:]. n is length of it with marks, 0 if there is no pair
+func (p *mdParser) delimited(s string, i int) (string, string, int) {
+	for _, d := range mdDelims {
+		if !strings.HasPrefix(s[i:], d.mark) {
+			continue
+		}
+		// _ inside of word is not mark: snake_case
+		if d.mark[0] == '_' && i > 0 && isWordByte(s[i-1]) {
+			return "", "", 0
+		}
+		start := i + len(d.mark)
+		if start >= len(s) || s[start] == ' ' {
+			return "", "", 0
+		}
+		end := closingMark(s[start:], d.mark)
+		if end <= 0 || s[start+end-1] == ' ' {
+			continue
+		}
+		if d.mark[0] == '_' && start+end+len(d.mark) < len(s) && isWordByte(s[start+end+len(d.mark)]) {
+			continue
+		}
+		return s[start : start+end], d.style, end + 2*len(d.mark)
+	}
+	return "", "", 0
+}
+
+// closingMark index of mark which closes text, code and escaped chars are skipped
+func closingMark(s, mark string) int {
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+			continue
+		case '`':
+			if end := strings.IndexByte(s[i+1:], '`'); end >= 0 {
+				i += end + 1
+				continue
+			}
+		}
+		if strings.HasPrefix(s[i:], mark) {
+			// single * is not closed by first char of **
+			if len(mark) == 1 && strings.HasPrefix(s[i:], mark+mark) {
+				if end := closingMark(s[i+2:], mark+mark); end >= 0 {
+					i += end + 3
+					continue
+				}
+			}
+			// ***: last two chars close, first one closes inner italic
+			if len(mark) == 2 && i+2 < len(s) && s[i+2] == mark[0] {
+				return i + 1
+			}
+			return i
+		}
+	}
+	return -1
+}
+
+// parseLink read [text](url) or [text](<url>) at start of s, n is its length, 0 if it is not link
+func parseLink(s string) (string, string, int) {
+	depth := 0
+	closeText := -1
+	for i := 0; i < len(s) && closeText < 0; i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case '[':
+			depth++
+		case ']':
+			depth--
+			if depth == 0 {
+				closeText = i
+			}
+		}
+	}
+	if closeText < 0 || closeText+1 >= len(s) || s[closeText+1] != '(' {
+		return "", "", 0
+	}
+
+	rest := s[closeText+2:]
+	var url string
+	var n int
+	if strings.HasPrefix(rest, "<") {
+		end := strings.IndexByte(rest, '>')
+		if end < 0 || end+1 >= len(rest) || rest[end+1] != ')' {
+			return "", "", 0
+		}
+		url, n = rest[1:end], end+2
+	} else {
+		end := strings.IndexByte(rest, ')')
+		if end < 0 {
+			return "", "", 0
+		}
+		url, n = rest[:end], end+1
+		// title of link: [text](url "title")
+		if sp := strings.IndexByte(url, ' '); sp >= 0 {
+			url = url[:sp]
+		}
+	}
+	return s[1:closeText], strings.TrimSpace(url), closeText + 2 + n
+}
+
+func withStyle(styles []string, style string) []string {
+	for _, s := range styles {
+		if s == style {
+			return styles
+		}
+	}
+	res := make([]string, 0, len(styles)+1)
+	return append(append(res, styles...), style)
+}
+
+func isPunct(c byte) bool {
+	return c < utf8.RuneSelf && unicode.IsPunct(rune(c)) || c == '`' || c == '~' || c == '|' || c == '<' || c == '>'
+}
+
+func isWordByte(c byte) bool {
+	return c >= utf8.RuneSelf || unicode.IsLetter(rune(c)) || unicode.IsDigit(rune(c))
+}
@@ -0,0 +1,54 @@
+package text
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseMarkdown(t *testing.T) {
+	t.Parallel()
+
+	for name, tc := range map[string]struct {
+		md    string
+		want  []Part
+		links []Link
+	}{
+		"plain": {"hello", []Part{{"default", "hello"}}, nil},
+		"styles": {"a **b** *c* ~~d~~ `e*`", []Part{
+			{"default", "a "}, {"bold", "b"}, {"default", " "}, {"italic", "c"},
+			{"default", " "}, {"strikethrough", "d"}, {"default", " "}, {"code", "e*"},
+		}, nil},
+		"nested":    {"**a *b***", []Part{{"bold", "a "}, {"bold italic", "b"}}, nil},
+		"unclosed":  {"2 * 3 = **6", []Part{{"default", "2 * 3 = **6"}}, nil},
+		"snake":     {"snake_case_name", []Part{{"default", "snake_case_name"}}, nil},
+		"escaped":   {`\*not\* \[x\]`, []Part{{"default", "*not* [x]"}}, nil},
+		"underline": {"__bold__ _it_", []Part{{"bold", "bold"}, {"default", " "}, {"italic", "it"}}, nil},
+		"link": {"see [**docs**](https://x.y/a \"t\") now", []Part{
+			{"default", "see "}, {"bold", "docs"}, {"default", " now"},
+		}, []Link{{Text: "docs", Url: "https://x.y/a"}}},
+		"angle link": {"[a](<b c.md>)", []Part{{"default", "a"}}, []Link{{Text: "a", Url: "b c.md"}}},
+		"image":      {"x ![alt](img.png)", []Part{{"default", "x "}}, []Link{{Text: "alt", Url: "img.png", Image: true}}},
+		"wiki":       {"[[Note]]", []Part{{"default", "[[Note]]"}}, nil},
+		"unicode":    {"**привет** мир", []Part{{"bold", "привет"}, {"default", " мир"}}, nil},
+	} {
+		data, links := ParseMarkdown(tc.md)
+		assert.Equal(t, tc.want, data.Text, name)
+		assert.Equal(t, tc.links, links, name)
+	}
+}
+
+func TestParseMarkdownRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	d := &Data{Text: []Part{
+		{Style: "default", String: "a*b "},
+		{Style: "bold", String: "c_d"},
+		{Style: "default", String: " "},
+		{Style: "italic", String: "e"},
+		{Style: "default", String: " [f]"},
+	}}
+	got, links := ParseMarkdown(d.Markdown())
+	assert.Equal(t, d, got)
+	assert.Empty(t, links)
+}
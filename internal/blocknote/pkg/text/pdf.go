@@ -0,0 +1,30 @@
+package text
+
+import "github.com/autumnterror/breezynotes/internal/blocknote/pkg/render/pdf"
+
+// Spans return text with styles as text of pdf, base is style of text without styles
+func (tb *Data) Spans(base pdf.Style) []pdf.Span {
+	if tb == nil {
+		return nil
+	}
+	var res []pdf.Span
+	for _, p := range tb.Text {
+		st := base
+		for _, s := range styles(p.Style) {
+			switch s {
+			case "bold":
+				st.Bold = true
+			case "italic":
+				st.Italic = true
+			case "strikethrough", "strike":
+				st.Strike = true
+			case "underline":
+				st.Underline = true
+			case "code":
+				st.Mono = true
+			}
+		}
+		res = append(res, pdf.Span{Text: p.String, Style: st})
+	}
+	return res
+}
@@ -0,0 +1,128 @@
+package activity
+
+import (
+	"context"
+
+	"github.com/autumnterror/breezynotes/internal/blocknote/domain"
+	"github.com/autumnterror/utils_go/pkg/utils/format"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+type Repo interface {
+	Create(ctx context.Context, a *domain.Activity) error
+	GetByNote(ctx context.Context, idNote string, start, end int) (*domain.Activities, error)
+	GetByNotes(ctx context.Context, idNotes []string, exceptActor string, limit int) (*domain.Activities, error)
+	DeleteBefore(ctx context.Context, before int64) error
+	DeleteByNotes(ctx context.Context, idNotes []string) error
+}
+
+func (a *API) Create(ctx context.Context, act *domain.Activity) error {
+	const op = "activity.Create"
+	ctx, done := context.WithTimeout(ctx, domain.WaitTime)
+	defer done()
+
+	if _, err := a.db.InsertOne(ctx, act); err != nil {
+		return format.Error(op, err)
+	}
+	return nil
+}
+
+// GetByNote return events [start, end) of note, newest first. Total is count of all events of note
+func (a *API) GetByNote(ctx context.Context, idNote string, start, end int) (*domain.Activities, error) {
+	const op = "activity.GetByNote"
+	ctx, done := context.WithTimeout(ctx, domain.WaitTime)
+	defer done()
+
+	filter := bson.M{"note_id": idNote}
+
+	total, err := a.db.CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, format.Error(op, err)
+	}
+
+	acts, err := a.find(ctx, op, filter, options.Find().
+		SetSort(bson.D{{Key: "created_at", Value: -1}}).
+		SetSkip(int64(start)).
+		SetLimit(int64(end-start)),
+	)
+	if err != nil {
+		return nil, err
+	}
+	acts.Total = total
+
+	return acts, nil
+}
+
+// GetByNotes return last limit events of notes made not by exceptActor, newest first
+func (a *API) GetByNotes(ctx context.Context, idNotes []string, exceptActor string, limit int) (*domain.Activities, error) {
+	const op = "activity.GetByNotes"
+	ctx, done := context.WithTimeout(ctx, domain.WaitTime)
+	defer done()
+
+	if len(idNotes) == 0 {
+		return &domain.Activities{Acts: make([]*domain.Activity, 0)}, nil
+	}
+
+	acts, err := a.find(ctx, op, bson.M{
+		"note_id": bson.M{"$in": idNotes},
+		"actor":   bson.M{"$ne": exceptActor},
+	}, options.Find().
+		SetSort(bson.D{{Key: "created_at", Value: -1}}).
+		SetLimit(int64(limit)),
+	)
+	if err != nil {
+		return nil, err
+	}
+	acts.Total = int64(len(acts.Acts))
+
+	return acts, nil
+}
+
+func (a *API) find(ctx context.Context, op string, filter bson.M, opts *options.FindOptionsBuilder) (*domain.Activities, error) {
+	cur, err := a.db.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, format.Error(op, err)
+	}
+	defer cur.Close(ctx)
+
+	acts := &domain.Activities{
+		Acts: make([]*domain.Activity, 0),
+	}
+	for cur.Next(ctx) {
+		var act domain.Activity
+		if err := cur.Decode(&act); err != nil {
+			return acts, format.Error(op, err)
+		}
+		acts.Acts = append(acts.Acts, &act)
+	}
+
+	return acts, nil
+}
+
+// DeleteBefore rm events older than before (unix seconds)
+func (a *API) DeleteBefore(ctx context.Context, before int64) error {
+	const op = "activity.DeleteBefore"
+	ctx, done := context.WithTimeout(ctx, domain.WaitTime)
+	defer done()
+
+	if _, err := a.db.DeleteMany(ctx, bson.M{"created_at": bson.M{"$lt": before}}); err != nil {
+		return format.Error(op, err)
+	}
+	return nil
+}
+
+func (a *API) DeleteByNotes(ctx context.Context, idNotes []string) error {
+	const op = "activity.DeleteByNotes"
+	ctx, done := context.WithTimeout(ctx, domain.WaitTime)
+	defer done()
+
+	if len(idNotes) == 0 {
+		return nil
+	}
+
+	if _, err := a.db.DeleteMany(ctx, bson.M{"note_id": bson.M{"$in": idNotes}}); err != nil {
+		return format.Error(op, err)
+	}
+	return nil
+}
@@ -7,11 +7,12 @@ import (
 )
 
 type API struct {
-	db repository.NoSqlRepo
+	db      repository.NoSqlRepo
+	trashDb repository.NoSqlRepo
 }
 
-func NewApi(db repository.NoSqlRepo) *API {
-	return &API{db: db}
+func NewApi(db repository.NoSqlRepo, trashDb repository.NoSqlRepo) *API {
+	return &API{db: db, trashDb: trashDb}
 }
 
 type Repo interface {
@@ -25,4 +26,13 @@ type Repo interface {
 	GetMany(ctx context.Context, ids []string) (*domain.Blocks, error)
 	GetAsFirst(ctx context.Context, id string) (string, error)
 	GetAsFirstNoDb(ctx context.Context, b *domain.Block) (string, error)
+	Size(ctx context.Context, ids, idNotes []string) (int64, int64, error)
+	FileNames(ctx context.Context, idNotes []string) ([]string, error)
+
+	ToTrash(ctx context.Context, b *domain.DeletedBlock) error
+	GetTrashByNote(ctx context.Context, idNote string) (*domain.DeletedBlocks, error)
+	GetFromTrash(ctx context.Context, id string) (*domain.DeletedBlock, error)
+	FromTrash(ctx context.Context, id string) error
+	CleanTrashByNotes(ctx context.Context, idNotes []string) error
+	CleanTrashBefore(ctx context.Context, before int64) error
 }
@@ -0,0 +1,114 @@
+package blocks
+
+import (
+	"context"
+	"net/url"
+	"path"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/autumnterror/breezynotes/internal/blocknote/domain"
+	"github.com/autumnterror/breezynotes/internal/blocknote/domain/domainblocks"
+	"github.com/autumnterror/breezynotes/internal/blocknote/repository"
+	"github.com/autumnterror/utils_go/pkg/utils/format"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+var fileTypes = []string{domainblocks.FileBlockType, domainblocks.ImgBlockType}
+
+// FileNames return names of local files of file and image blocks of notes idNotes, deleted blocks too.
+// Src is compared by name of file, see fileName, so file which is used also by block of other note is skipped
+// even if other block has other url of it
+func (a *API) FileNames(ctx context.Context, idNotes []string) ([]string, error) {
+	const op = "blocks.FileNames"
+	ctx, done := context.WithTimeout(ctx, domain.WaitTime)
+	defer done()
+
+	if len(idNotes) == 0 {
+		return []string{}, nil
+	}
+
+	own := bson.D{{"$in", idNotes}}
+	srcs, err := sources(ctx, a.db, "", bson.D{{"note_id", own}})
+	if err != nil {
+		return nil, format.Error(op, err)
+	}
+	deleted, err := sources(ctx, a.trashDb, "block.", bson.D{{"note_id", own}})
+	if err != nil {
+		return nil, format.Error(op, err)
+	}
+	names := fileNames(append(srcs, deleted...))
+	if len(names) == 0 {
+		return []string{}, nil
+	}
+
+	quoted := make([]string, 0, len(names))
+	for _, n := range names {
+		quoted = append(quoted, regexp.QuoteMeta(n))
+	}
+	// src of other block can be name or any url which ends with name
+	pattern := bson.Regex{Pattern: "(^|/)(" + strings.Join(quoted, "|") + ")([?#].*)?$"}
+
+	other := bson.D{{"$nin", idNotes}}
+	used, err := sources(ctx, a.db, "", bson.D{{"note_id", other}, {"data.src", pattern}})
+	if err != nil {
+		return nil, format.Error(op, err)
+	}
+	usedDeleted, err := sources(ctx, a.trashDb, "block.", bson.D{{"note_id", other}, {"block.data.src", pattern}})
+	if err != nil {
+		return nil, format.Error(op, err)
+	}
+	used = fileNames(append(used, usedDeleted...))
+
+	return slices.DeleteFunc(names, func(n string) bool { return slices.Contains(used, n) }), nil
+}
+
+// fileNames return sorted unique names of local files from srcs, other urls are skipped
+func fileNames(srcs []string) []string {
+	names := make([]string, 0, len(srcs))
+	for _, src := range srcs {
+		if n := fileName(src); n != "" {
+			names = append(names, n)
+		}
+	}
+	slices.Sort(names)
+	return slices.Compact(names)
+}
+
+// fileName return name of file in files dir of gateway from src: name given by upload or url of it like /files/<name>.
+// Empty for other urls. It must match localFileName of gateway, which deletes files by these names
+func fileName(src string) string {
+	u, err := url.Parse(src)
+	if err != nil || u.Path == "" {
+		return ""
+	}
+	if !strings.Contains(u.Path, "/") && u.Host == "" {
+		return u.Path
+	}
+	dir, name := path.Split(u.Path)
+	if strings.TrimPrefix(dir, "/") != "files/" {
+		return ""
+	}
+	return name
+}
+
+// sources find not empty src of file blocks by filter. prefix is path of block in document
+func sources(ctx context.Context, db repository.NoSqlRepo, prefix string, filter bson.D) ([]string, error) {
+	filter = append(filter, bson.E{Key: prefix + "type", Value: bson.D{{"$in", fileTypes}}})
+	cur, err := db.Find(ctx, filter, options.Find().SetProjection(bson.D{{prefix + "data.src", 1}}))
+	if err != nil {
+		return nil, err
+	}
+	defer cur.Close(ctx)
+
+	var res []string
+	for cur.Next(ctx) {
+		src, ok := cur.Current.Lookup(strings.Split(prefix+"data.src", ".")...).StringValueOK()
+		if ok && src != "" {
+			res = append(res, src)
+		}
+	}
+	return res, cur.Err()
+}
@@ -18,7 +18,7 @@ import (
 func TestOnText(t *testing.T) {
 	block.RegisterBlock("text", &textblock.Driver{})
 	m := mongo.MustConnect(config.Test())
-	a := NewApi(m.Blocks())
+	a := NewApi(m.Blocks(), m.BlockTrash())
 
 	id := "test_block_"
 
@@ -114,3 +114,51 @@ func TestOnText(t *testing.T) {
 	_, err = a.Get(context.Background(), id3)
 	assert.ErrorIs(t, err, domain.ErrNotFound)
 }
+
+func TestFileName(t *testing.T) {
+	t.Parallel()
+	for src, name := range map[string]string{
+		"a1b2.png":        "a1b2.png",
+		"/files/a1b2.png": "a1b2.png",
+		"files/a1b2.png":  "a1b2.png",
+		"https://notes.example.com/files/a1b2.png": "a1b2.png",
+		"/files/a1b2.png?v=2#top":                  "a1b2.png",
+		"https://example.com/a1b2.png":             "",
+		"/static/a1b2.png":                         "",
+		"":                                         "",
+	} {
+		assert.Equal(t, name, fileName(src), src)
+	}
+	assert.Equal(t, []string{"a.png", "b.pdf"}, fileNames([]string{"/files/b.pdf", "a.png", "https://x.org/y.png", "files/a.png"}))
+}
+
+func TestFileNames(t *testing.T) {
+	m := mongo.MustConnect(config.Test())
+	a := NewApi(m.Blocks(), m.BlockTrash())
+	ctx := context.Background()
+
+	blocks := map[string]*domain.Block{
+		"test_file_own":    {Type: "img", NoteId: "test_note_own", Data: map[string]any{"src": "/files/own.png"}},
+		"test_file_shared": {Type: "file", NoteId: "test_note_own", Data: map[string]any{"src": "shared.pdf"}},
+		"test_file_other":  {Type: "file", NoteId: "test_note_other", Data: map[string]any{"src": "https://notes.example.com/files/shared.pdf?dl=1"}},
+		"test_file_remote": {Type: "img", NoteId: "test_note_own", Data: map[string]any{"src": "https://example.com/remote.png"}},
+	}
+	t.Cleanup(func() {
+		for id := range blocks {
+			assert.NoError(t, a.Delete(ctx, id))
+		}
+		assert.NoError(t, m.Disconnect())
+	})
+	for id, b := range blocks {
+		b.Id = id
+		assert.NoError(t, a.CreateBlock(ctx, b))
+	}
+
+	names, err := a.FileNames(ctx, []string{"test_note_own"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"own.png"}, names)
+
+	names, err = a.FileNames(ctx, []string{"test_note_own", "test_note_other"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"own.png", "shared.pdf"}, names)
+}
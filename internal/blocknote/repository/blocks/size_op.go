@@ -0,0 +1,35 @@
+package blocks
+
+import (
+	"context"
+
+	"github.com/autumnterror/breezynotes/internal/blocknote/domain"
+	"github.com/autumnterror/breezynotes/internal/blocknote/repository"
+	"github.com/autumnterror/utils_go/pkg/utils/format"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// Size return count and summary BSON size of blocks by ids and of deleted blocks of notes idNotes
+func (a *API) Size(ctx context.Context, ids, idNotes []string) (int64, int64, error) {
+	const op = "blocks.Size"
+	ctx, done := context.WithTimeout(ctx, domain.WaitTime)
+	defer done()
+
+	var count, size int64
+	if len(ids) != 0 {
+		n, s, err := repository.DocsSize(ctx, a.db, bson.D{{"_id", bson.D{{"$in", ids}}}})
+		if err != nil {
+			return 0, 0, format.Error(op, err)
+		}
+		count, size = n, s
+	}
+	if len(idNotes) != 0 {
+		_, s, err := repository.DocsSize(ctx, a.trashDb, bson.D{{"note_id", bson.D{{"$in", idNotes}}}})
+		if err != nil {
+			return 0, 0, format.Error(op, err)
+		}
+		size += s
+	}
+
+	return count, size, nil
+}
@@ -0,0 +1,137 @@
+package blocks
+
+import (
+	"context"
+	"errors"
+
+	"github.com/autumnterror/breezynotes/internal/blocknote/domain"
+	"github.com/autumnterror/utils_go/pkg/utils/format"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+// ToTrash insert b in a.trashDb() and remove block from a.db()
+func (a *API) ToTrash(ctx context.Context, b *domain.DeletedBlock) error {
+	const op = "blocks.ToTrash"
+	ctx, done := context.WithTimeout(ctx, domain.WaitTime)
+	defer done()
+
+	if _, err := a.trashDb.InsertOne(ctx, b); err != nil {
+		return format.Error(op, err)
+	}
+
+	res, err := a.db.DeleteOne(ctx, bson.D{{"_id", b.Id}})
+	if err != nil {
+		return format.Error(op, err)
+	}
+	if res.DeletedCount == 0 {
+		return format.Error(op, domain.ErrNotFound)
+	}
+	return nil
+}
+
+// GetTrashByNote return deleted blocks of note, last deleted first
+func (a *API) GetTrashByNote(ctx context.Context, idNote string) (*domain.DeletedBlocks, error) {
+	const op = "blocks.GetTrashByNote"
+	ctx, done := context.WithTimeout(ctx, domain.WaitTime)
+	defer done()
+
+	cur, err := a.trashDb.Find(ctx, bson.M{"note_id": idNote}, options.Find().SetSort(bson.D{{Key: "deleted_at", Value: -1}}))
+	if err != nil {
+		return nil, format.Error(op, err)
+	}
+	defer cur.Close(ctx)
+
+	blks := &domain.DeletedBlocks{
+		Blks: make([]*domain.DeletedBlock, 0),
+	}
+	for cur.Next(ctx) {
+		var b domain.DeletedBlock
+		if err := cur.Decode(&b); err != nil {
+			return blks, format.Error(op, err)
+		}
+		blks.Blks = append(blks.Blks, &b)
+	}
+
+	return blks, nil
+}
+
+func (a *API) GetFromTrash(ctx context.Context, id string) (*domain.DeletedBlock, error) {
+	const op = "blocks.GetFromTrash"
+	ctx, done := context.WithTimeout(ctx, domain.WaitTime)
+	defer done()
+
+	res := a.trashDb.FindOne(ctx, bson.M{"_id": id})
+	if res.Err() != nil {
+		if errors.Is(res.Err(), mongo.ErrNoDocuments) {
+			return nil, format.Error(op, domain.ErrNotFound)
+		}
+		return nil, format.Error(op, res.Err())
+	}
+
+	var b domain.DeletedBlock
+	if err := res.Decode(&b); err != nil {
+		return nil, format.Error(op, err)
+	}
+
+	return &b, nil
+}
+
+// FromTrash remove block from a.trashDb() and insert it in a.db()
+func (a *API) FromTrash(ctx context.Context, id string) error {
+	const op = "blocks.FromTrash"
+	ctx, done := context.WithTimeout(ctx, domain.WaitTime)
+	defer done()
+
+	res := a.trashDb.FindOneAndDelete(ctx, bson.M{"_id": id})
+	if res.Err() != nil {
+		if errors.Is(res.Err(), mongo.ErrNoDocuments) {
+			return format.Error(op, domain.ErrNotFound)
+		}
+		return format.Error(op, res.Err())
+	}
+
+	var b domain.DeletedBlock
+	if err := res.Decode(&b); err != nil {
+		return format.Error(op, err)
+	}
+	if b.Block == nil {
+		return format.Error(op, domain.ErrNotFound)
+	}
+	b.Block.IsUsed = false
+
+	if _, err := a.db.InsertOne(ctx, b.Block); err != nil {
+		return format.Error(op, err)
+	}
+
+	return nil
+}
+
+// CleanTrashByNotes rm deleted blocks of notes
+func (a *API) CleanTrashByNotes(ctx context.Context, idNotes []string) error {
+	const op = "blocks.CleanTrashByNotes"
+	ctx, done := context.WithTimeout(ctx, domain.WaitTime)
+	defer done()
+
+	if len(idNotes) == 0 {
+		return nil
+	}
+
+	if _, err := a.trashDb.DeleteMany(ctx, bson.M{"note_id": bson.M{"$in": idNotes}}); err != nil {
+		return format.Error(op, err)
+	}
+	return nil
+}
+
+// CleanTrashBefore rm deleted blocks which was deleted before time before
+func (a *API) CleanTrashBefore(ctx context.Context, before int64) error {
+	const op = "blocks.CleanTrashBefore"
+	ctx, done := context.WithTimeout(ctx, domain.WaitTime)
+	defer done()
+
+	if _, err := a.trashDb.DeleteMany(ctx, bson.M{"deleted_at": bson.M{"$lt": before}}); err != nil {
+		return format.Error(op, err)
+	}
+	return nil
+}
@@ -0,0 +1,196 @@
+package comments
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/autumnterror/breezynotes/internal/blocknote/domain"
+	"github.com/autumnterror/utils_go/pkg/utils/format"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+type Repo interface {
+	Create(ctx context.Context, c *domain.Comment) error
+	Get(ctx context.Context, id string) (*domain.Comment, error)
+	GetByNote(ctx context.Context, idNote string) (*domain.Comments, error)
+	GetAnchoredByBlock(ctx context.Context, idBlock string) (*domain.Comments, error)
+	UpdateText(ctx context.Context, id, text string, mentions []string) error
+	UpdateAnchor(ctx context.Context, id string, anchor *domain.TextRange) error
+	UpdateResolved(ctx context.Context, idThread string, resolved bool) error
+	UpdateOrphaned(ctx context.Context, idBlock string, orphaned bool) error
+	Delete(ctx context.Context, id string) error
+	DeleteThread(ctx context.Context, idThread string) error
+	DeleteByNotes(ctx context.Context, idNotes []string) error
+}
+
+func (a *API) Create(ctx context.Context, c *domain.Comment) error {
+	const op = "comments.Create"
+	ctx, done := context.WithTimeout(ctx, domain.WaitTime)
+	defer done()
+
+	if _, err := a.db.InsertOne(ctx, c); err != nil {
+		return format.Error(op, err)
+	}
+	return nil
+}
+
+func (a *API) Get(ctx context.Context, id string) (*domain.Comment, error) {
+	const op = "comments.Get"
+	ctx, done := context.WithTimeout(ctx, domain.WaitTime)
+	defer done()
+
+	res := a.db.FindOne(ctx, bson.M{"_id": id})
+	if res.Err() != nil {
+		if errors.Is(res.Err(), mongo.ErrNoDocuments) {
+			return nil, format.Error(op, domain.ErrNotFound)
+		}
+		return nil, format.Error(op, res.Err())
+	}
+
+	var c domain.Comment
+	if err := res.Decode(&c); err != nil {
+		return nil, format.Error(op, err)
+	}
+	return &c, nil
+}
+
+// GetByNote return all comments of note, oldest first
+func (a *API) GetByNote(ctx context.Context, idNote string) (*domain.Comments, error) {
+	const op = "comments.GetByNote"
+	ctx, done := context.WithTimeout(ctx, domain.WaitTime)
+	defer done()
+
+	return a.find(ctx, op, bson.M{"note_id": idNote})
+}
+
+// GetAnchoredByBlock return root comments of block which have anchor in text
+func (a *API) GetAnchoredByBlock(ctx context.Context, idBlock string) (*domain.Comments, error) {
+	const op = "comments.GetAnchoredByBlock"
+	ctx, done := context.WithTimeout(ctx, domain.WaitTime)
+	defer done()
+
+	return a.find(ctx, op, bson.M{"block_id": idBlock, "anchor": bson.M{"$exists": true}})
+}
+
+func (a *API) find(ctx context.Context, op string, filter bson.M) (*domain.Comments, error) {
+	cur, err := a.db.Find(ctx, filter, options.Find().SetSort(bson.D{{Key: "created_at", Value: 1}}))
+	if err != nil {
+		return nil, format.Error(op, err)
+	}
+	defer cur.Close(ctx)
+
+	cmts := &domain.Comments{
+		Cmts: make([]*domain.Comment, 0),
+	}
+	for cur.Next(ctx) {
+		var c domain.Comment
+		if err := cur.Decode(&c); err != nil {
+			return cmts, format.Error(op, err)
+		}
+		cmts.Cmts = append(cmts.Cmts, &c)
+	}
+
+	return cmts, nil
+}
+
+// UpdateText can return domain.ErrNotFound. Set updated_at to time.Now().UTC().Unix()
+func (a *API) UpdateText(ctx context.Context, id, text string, mentions []string) error {
+	const op = "comments.UpdateText"
+	ctx, done := context.WithTimeout(ctx, domain.WaitTime)
+	defer done()
+
+	return a.updateOne(ctx, op, bson.M{"_id": id}, bson.M{
+		"text":       text,
+		"mentions":   mentions,
+		"updated_at": time.Now().UTC().Unix(),
+	})
+}
+
+func (a *API) UpdateAnchor(ctx context.Context, id string, anchor *domain.TextRange) error {
+	const op = "comments.UpdateAnchor"
+	ctx, done := context.WithTimeout(ctx, domain.WaitTime)
+	defer done()
+
+	return a.updateOne(ctx, op, bson.M{"_id": id}, bson.M{"anchor": anchor})
+}
+
+// UpdateResolved change state of thread by its root comment
+func (a *API) UpdateResolved(ctx context.Context, idThread string, resolved bool) error {
+	const op = "comments.UpdateResolved"
+	ctx, done := context.WithTimeout(ctx, domain.WaitTime)
+	defer done()
+
+	return a.updateOne(ctx, op, bson.M{"_id": idThread, "thread_id": idThread}, bson.M{"resolved": resolved})
+}
+
+// UpdateOrphaned mark all threads of block. Block can has no threads
+func (a *API) UpdateOrphaned(ctx context.Context, idBlock string, orphaned bool) error {
+	const op = "comments.UpdateOrphaned"
+	ctx, done := context.WithTimeout(ctx, domain.WaitTime)
+	defer done()
+
+	if _, err := a.db.UpdateMany(ctx, bson.M{"block_id": idBlock}, bson.M{"$set": bson.M{"orphaned": orphaned}}); err != nil {
+		return format.Error(op, err)
+	}
+	return nil
+}
+
+func (a *API) updateOne(ctx context.Context, op string, filter, set bson.M) error {
+	res, err := a.db.UpdateOne(ctx, filter, bson.M{"$set": set})
+	if err != nil {
+		return format.Error(op, err)
+	}
+	if res.MatchedCount == 0 {
+		return format.Error(op, domain.ErrNotFound)
+	}
+	return nil
+}
+
+func (a *API) Delete(ctx context.Context, id string) error {
+	const op = "comments.Delete"
+	ctx, done := context.WithTimeout(ctx, domain.WaitTime)
+	defer done()
+
+	res, err := a.db.DeleteOne(ctx, bson.M{"_id": id})
+	if err != nil {
+		return format.Error(op, err)
+	}
+	if res.DeletedCount == 0 {
+		return format.Error(op, domain.ErrNotFound)
+	}
+	return nil
+}
+
+// DeleteThread rm root comment and all replies
+func (a *API) DeleteThread(ctx context.Context, idThread string) error {
+	const op = "comments.DeleteThread"
+	ctx, done := context.WithTimeout(ctx, domain.WaitTime)
+	defer done()
+
+	res, err := a.db.DeleteMany(ctx, bson.M{"thread_id": idThread})
+	if err != nil {
+		return format.Error(op, err)
+	}
+	if res.DeletedCount == 0 {
+		return format.Error(op, domain.ErrNotFound)
+	}
+	return nil
+}
+
+func (a *API) DeleteByNotes(ctx context.Context, idNotes []string) error {
+	const op = "comments.DeleteByNotes"
+	ctx, done := context.WithTimeout(ctx, domain.WaitTime)
+	defer done()
+
+	if len(idNotes) == 0 {
+		return nil
+	}
+
+	if _, err := a.db.DeleteMany(ctx, bson.M{"note_id": bson.M{"$in": idNotes}}); err != nil {
+		return format.Error(op, err)
+	}
+	return nil
+}
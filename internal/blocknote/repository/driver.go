@@ -48,6 +48,8 @@ type NoSqlRepo interface {
 	) (*mongo.UpdateResult, error)
 	Find(ctx context.Context, filter any,
 		opts ...options.Lister[options.FindOptions]) (*mongo.Cursor, error)
+	CountDocuments(ctx context.Context, filter any,
+		opts ...options.Lister[options.CountOptions]) (int64, error)
 	FindOne(ctx context.Context, filter any,
 		opts ...options.Lister[options.FindOneOptions]) *mongo.SingleResult
 	FindOneAndDelete(
@@ -0,0 +1,118 @@
+package notes
+
+import (
+	"context"
+	"time"
+
+	"github.com/autumnterror/breezynotes/internal/blocknote/domain"
+	"github.com/autumnterror/breezynotes/internal/blocknote/repository"
+	"github.com/autumnterror/utils_go/pkg/utils/format"
+	"github.com/autumnterror/utils_go/pkg/utils/uid"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+// RemoveUserFromNotes pull user from editors and readers of all notes, also of notes in trash
+func (a *API) RemoveUserFromNotes(ctx context.Context, idUser string) error {
+	const op = "notes.RemoveUserFromNotes"
+
+	ctx, done := context.WithTimeout(ctx, domain.WaitTime)
+	defer done()
+
+	filter := bson.M{"$or": bson.A{bson.M{"editors": idUser}, bson.M{"readers": idUser}}}
+	update := bson.M{
+		"$pull": bson.M{"editors": idUser, "readers": idUser},
+		"$set":  bson.M{"updated_at": time.Now().UTC().Unix()},
+	}
+	if _, err := a.noteAPI.UpdateMany(ctx, filter, update); err != nil {
+		return format.Error(op, err)
+	}
+	if _, err := a.trashAPI.UpdateMany(ctx, filter, update); err != nil {
+		return format.Error(op, err)
+	}
+
+	return nil
+}
+
+// FileNamesByAuthor return names of local files and images in notes of author, in notes and in trash,
+// which are not used by notes of other users
+func (a *API) FileNamesByAuthor(ctx context.Context, idUser string) ([]string, error) {
+	const op = "notes.FileNamesByAuthor"
+
+	ctx, done := context.WithTimeout(ctx, domain.WaitTime)
+	defer done()
+
+	var idNotes []string
+	for _, db := range []repository.NoSqlRepo{a.noteAPI, a.trashAPI} {
+		cur, err := db.Find(ctx, bson.M{"author": idUser}, options.Find().SetProjection(bson.M{"_id": 1}))
+		if err != nil {
+			return nil, format.Error(op, err)
+		}
+		for cur.Next(ctx) {
+			if id, ok := cur.Current.Lookup("_id").StringValueOK(); ok {
+				idNotes = append(idNotes, id)
+			}
+		}
+		err = cur.Err()
+		_ = cur.Close(ctx)
+		if err != nil {
+			return nil, format.Error(op, err)
+		}
+	}
+
+	names, err := a.blockAPI.FileNames(ctx, idNotes)
+	if err != nil {
+		return nil, format.Error(op, err)
+	}
+	return names, nil
+}
+
+// Import insert note made from archive with tag of it, timestamps are not changed.
+// Note with DeletedAt is inserted in trash
+func (a *API) Import(ctx context.Context, n *domain.Note) error {
+	const op = "notes.Import"
+
+	ctx, done := context.WithTimeout(ctx, domain.WaitTime)
+	defer done()
+
+	coll := a.noteAPI
+	if n.DeletedAt != 0 {
+		coll = a.trashAPI
+	}
+	if _, err := coll.InsertOne(ctx, n); err != nil {
+		return format.Error(op, err)
+	}
+
+	if n.Tag == nil {
+		return nil
+	}
+	if _, err := a.noteTagsAPI.InsertOne(ctx, bson.M{
+		"_id":     uid.New(),
+		"note_id": n.Id,
+		"tag":     n.Tag,
+	}); err != nil {
+		return format.Error(op, err)
+	}
+	return nil
+}
+
+// HasNote report if author has note or note in trash with this title and creation time,
+// so note from archive which is imported second time is found
+func (a *API) HasNote(ctx context.Context, idUser, title string, createdAt int64) (bool, error) {
+	const op = "notes.HasNote"
+
+	ctx, done := context.WithTimeout(ctx, domain.WaitTime)
+	defer done()
+
+	filter := bson.M{"author": idUser, "title": title, "created_at": createdAt}
+	for _, coll := range []repository.NoSqlRepo{a.noteAPI, a.trashAPI} {
+		cnt, err := coll.CountDocuments(ctx, filter, options.Count().SetLimit(1))
+		if err != nil {
+			return false, format.Error(op, err)
+		}
+		if cnt > 0 {
+			return true, nil
+		}
+	}
+	return false, nil
+}
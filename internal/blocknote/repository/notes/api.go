@@ -28,11 +28,15 @@ type Repo interface {
 	ToTrashAll(ctx context.Context, idUser string) error
 	FromTrash(ctx context.Context, id string) error
 	FindOnTrash(ctx context.Context, idNote, idUser string) (*domain.Note, error)
+	StampTrash(ctx context.Context, deletedAt int64) error
+	GetExpiredFromTrash(ctx context.Context, before int64, idUser string, except []string) (*domain.Notes, error)
+	DeleteFromTrash(ctx context.Context, ids []string) error
 
 	Create(ctx context.Context, n *domain.Note) error
 	Get(ctx context.Context, idNote, idUser string) (*domain.Note, error)
-	GetNoteListByUser(ctx context.Context, id string) (*domain.NoteParts, error)
+	GetNoteListByUser(ctx context.Context, id string, ws domain.WorkspaceRoles, idWorkspace string) (*domain.NoteParts, error)
 	GetNoteListByTag(ctx context.Context, idTag, idUser string) (*domain.NoteParts, error)
+	GetBlogNotes(ctx context.Context, idAuthor string) (*domain.Notes, error)
 
 	AddTagToNote(ctx context.Context, id string, tag *domain.Tag) error
 	RemoveTagFromNote(ctx context.Context, idNote string, idUser string) error
@@ -48,7 +52,14 @@ type Repo interface {
 
 	ShareNote(ctx context.Context, noteId, userId, role string) error
 	DeleteRole(ctx context.Context, noteId, userId string) error
+	GetSharedIds(ctx context.Context, id string, ws domain.WorkspaceRoles) ([]string, error)
 	//ChangeUserRole(ctx context.Context, noteId, userId, newRole string) error
 
-	Search(ctx context.Context, id, prompt string) <-chan *domain.NotePart
+	Stats(ctx context.Context, idUser string) (*domain.UserStats, error)
+	RemoveUserFromNotes(ctx context.Context, idUser string) error
+	FileNamesByAuthor(ctx context.Context, idUser string) ([]string, error)
+	Import(ctx context.Context, n *domain.Note) error
+	HasNote(ctx context.Context, idUser, title string, createdAt int64) (bool, error)
+
+	Search(ctx context.Context, id, prompt string, ws domain.WorkspaceRoles, idWorkspace string) <-chan *domain.NotePart
 }
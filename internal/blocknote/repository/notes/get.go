@@ -52,8 +52,25 @@ func (a *API) Get(ctx context.Context, idNote, idUser string) (*domain.Note, err
 	return &n, nil
 }
 
-// GetNoteListByUser use func a.blockAPI.GetAsFirst
-func (a *API) GetNoteListByUser(ctx context.Context, id string) (*domain.NoteParts, error) {
+// accessFilter notes where user is author, editor, reader or member of note workspace.
+// Non-empty idWorkspace narrows to notes of this workspace
+func accessFilter(id string, ws domain.WorkspaceRoles, idWorkspace string) bson.M {
+	if idWorkspace != "" {
+		return bson.M{"workspace_id": idWorkspace}
+	}
+	or := []bson.M{
+		{"author": id},
+		{"editors": id},
+		{"readers": id},
+	}
+	if len(ws) > 0 {
+		or = append(or, bson.M{"workspace_id": bson.M{"$in": ws.Ids()}})
+	}
+	return bson.M{"$or": or}
+}
+
+// GetNoteListByUser use func a.blockAPI.GetAsFirst. Role of workspace notes derived from ws
+func (a *API) GetNoteListByUser(ctx context.Context, id string, ws domain.WorkspaceRoles, idWorkspace string) (*domain.NoteParts, error) {
 	const op = "notes.GetNoteListByUser"
 
 	ctx, done := context.WithTimeout(ctx, domain.WaitTime)
@@ -76,13 +93,7 @@ func (a *API) GetNoteListByUser(ctx context.Context, id string) (*domain.NotePar
 	}
 
 	cur, err = a.noteAPI.Find(ctx,
-		bson.M{
-			"$or": []bson.M{
-				{"author": id},
-				{"editors": id},
-				{"readers": id},
-			},
-		},
+		accessFilter(id, ws, idWorkspace),
 		options.Find().SetSort(bson.M{"updated_at": -1}),
 	)
 
@@ -109,24 +120,17 @@ func (a *API) GetNoteListByUser(ctx context.Context, id string) (*domain.NotePar
 				log.Warn(op, "get as first", err)
 			}
 		}
-		var role string
-		switch {
-		case n.Author == id:
-			role = "author"
-		case alg.IsIn(id, n.Editors):
-			role = "editor"
-		case alg.IsIn(id, n.Readers):
-			role = "reader"
-		}
 		nts.Ntps = append(nts.Ntps, &domain.NotePart{
-			Id:         n.Id,
-			Title:      n.Title,
-			Tag:        noteTag[n.Id],
-			FirstBlock: fb,
-			UpdatedAt:  n.UpdatedAt,
-			Role:       role,
-			IsBlog:     n.IsBlog,
-			IsPublic:   n.IsPublic,
+			Id:          n.Id,
+			Title:       n.Title,
+			Tag:         noteTag[n.Id],
+			FirstBlock:  fb,
+			CreatedAt:   n.CreatedAt,
+			UpdatedAt:   n.UpdatedAt,
+			Role:        n.RoleOf(id, ws),
+			IsBlog:      n.IsBlog,
+			IsPublic:    n.IsPublic,
+			WorkspaceId: n.WorkspaceId,
 		})
 
 	}
@@ -208,6 +212,7 @@ func (a *API) GetNoteListByTag(ctx context.Context, idTag, idUser string) (*doma
 			Title:      n.Title,
 			Tag:        tag,
 			FirstBlock: fb,
+			CreatedAt:  n.CreatedAt,
 			UpdatedAt:  n.UpdatedAt,
 			Role:       role,
 			IsBlog:     n.IsBlog,
@@ -246,6 +251,34 @@ func (a *API) getAllByUser(ctx context.Context, id string) (*domain.Notes, error
 	return nts, nil
 }
 
+// GetBlogNotes return blog notes of author from oldest to newest
+func (a *API) GetBlogNotes(ctx context.Context, idAuthor string) (*domain.Notes, error) {
+	const op = "notes.GetBlogNotes"
+
+	ctx, done := context.WithTimeout(ctx, domain.WaitTime)
+	defer done()
+
+	cur, err := a.noteAPI.Find(ctx, bson.M{"author": idAuthor, "is_blog": true}, options.Find().SetSort(bson.D{{"created_at", 1}, {"_id", 1}}))
+	if err != nil {
+		return nil, format.Error(op, err)
+	}
+	defer cur.Close(ctx)
+
+	nts := &domain.Notes{
+		Nts: []*domain.Note{},
+	}
+
+	for cur.Next(ctx) {
+		var n domain.Note
+		if err = cur.Decode(&n); err != nil {
+			return nts, format.Error(op, err)
+		}
+		nts.Nts = append(nts.Nts, &n)
+	}
+
+	return nts, nil
+}
+
 func chunkStrings(ids []string, size int) [][]string {
 	if size <= 0 {
 		size = 1000
@@ -262,7 +295,7 @@ func chunkStrings(ids []string, size int) [][]string {
 }
 
 // Search to title, after to content. With batching
-func (a *API) Search(ctx context.Context, id, prompt string) <-chan *domain.NotePart {
+func (a *API) Search(ctx context.Context, id, prompt string, ws domain.WorkspaceRoles, idWorkspace string) <-chan *domain.NotePart {
 	const op = "notes.Search"
 
 	ctx, done := context.WithTimeout(ctx, domain.WaitTime)
@@ -279,11 +312,7 @@ func (a *API) Search(ctx context.Context, id, prompt string) <-chan *domain.Note
 
 		cur, err := a.noteAPI.Find(
 			ctx,
-			bson.M{"$or": []bson.M{
-				{"author": id},
-				{"editors": id},
-				{"readers": id},
-			}},
+			accessFilter(id, ws, idWorkspace),
 			options.Find().SetSort(bson.M{"updated_at": -1}),
 		)
 		if err != nil {
@@ -306,12 +335,14 @@ func (a *API) Search(ctx context.Context, id, prompt string) <-chan *domain.Note
 				case <-ctx.Done():
 					return
 				case notesChan <- &domain.NotePart{
-					Id:         n.Id,
-					Title:      n.Title,
-					FirstBlock: "",
-					UpdatedAt:  n.UpdatedAt,
-					IsBlog:     n.IsBlog,
-					IsPublic:   n.IsPublic,
+					Id:          n.Id,
+					Title:       n.Title,
+					FirstBlock:  "",
+					CreatedAt:   n.CreatedAt,
+					UpdatedAt:   n.UpdatedAt,
+					IsBlog:      n.IsBlog,
+					IsPublic:    n.IsPublic,
+					WorkspaceId: n.WorkspaceId,
 				}:
 				}
 			}
@@ -343,12 +374,14 @@ func (a *API) Search(ctx context.Context, id, prompt string) <-chan *domain.Note
 					case <-ctx.Done():
 						return
 					case notesChan <- &domain.NotePart{
-						Id:         n.Id,
-						Title:      n.Title,
-						FirstBlock: str,
-						UpdatedAt:  n.UpdatedAt,
-						IsBlog:     n.IsBlog,
-						IsPublic:   n.IsPublic,
+						Id:          n.Id,
+						Title:       n.Title,
+						FirstBlock:  str,
+						CreatedAt:   n.CreatedAt,
+						UpdatedAt:   n.UpdatedAt,
+						IsBlog:      n.IsBlog,
+						IsPublic:    n.IsPublic,
+						WorkspaceId: n.WorkspaceId,
 					}:
 					}
 
@@ -29,7 +29,7 @@ func TestWithBlocks(t *testing.T) {
 	t.Run("test with blocks", func(t *testing.T) {
 		block.RegisterBlock("text", &textblock.Driver{})
 		m := mongo.MustConnect(config.Test())
-		b := blocks.NewApi(m.Blocks())
+		b := blocks.NewApi(m.Blocks(), m.BlockTrash())
 		tgs := tags.NewApi(m.Tags(), m.NoteTags())
 		a := NewApi(m.Notes(), m.Trash(), m.NoteTags(), tgs, b)
 		idNote := uid.New()
@@ -143,7 +143,7 @@ func TestWithBlocks(t *testing.T) {
 			log.Green("get by tag ", nts)
 		}
 
-		if nts, err := a.GetNoteListByUser(context.Background(), idUser); assert.NoError(t, err) && assert.NotEqual(t, 0, len(nts.Ntps)) {
+		if nts, err := a.GetNoteListByUser(context.Background(), idUser, nil, ""); assert.NoError(t, err) && assert.NotEqual(t, 0, len(nts.Ntps)) {
 			log.Green("get by user ", nts)
 			if assert.Greater(t, len(nts.Ntps), 0) {
 				assert.Equal(t, "test3 test4", nts.Ntps[0].FirstBlock)
@@ -157,7 +157,7 @@ func TestCrudGood(t *testing.T) {
 
 	t.Run("crud good", func(t *testing.T) {
 		m := mongo.MustConnect(config.Test())
-		b := blocks.NewApi(m.Blocks())
+		b := blocks.NewApi(m.Blocks(), m.BlockTrash())
 		tgs := tags.NewApi(m.Tags(), m.NoteTags())
 		a := NewApi(m.Notes(), m.Trash(), m.NoteTags(), tgs, b)
 		idNote := uid.New()
@@ -256,11 +256,11 @@ func TestCrudGood(t *testing.T) {
 			assert.Contains(t, n.Editors, "neweditor")
 		}
 
-		n, err := a.GetNoteListByUser(context.Background(), "neweditor")
+		n, err := a.GetNoteListByUser(context.Background(), "neweditor", nil, "")
 		assert.NoError(t, err)
 		assert.Len(t, n.Ntps, 1)
 
-		n, err = a.GetNoteListByUser(context.Background(), "newreader")
+		n, err = a.GetNoteListByUser(context.Background(), "newreader", nil, "")
 		assert.NoError(t, err)
 		assert.Len(t, n.Ntps, 1)
 
@@ -278,6 +278,10 @@ func TestCrudGood(t *testing.T) {
 			assert.Equal(t, n.IsBlog, true)
 			assert.Equal(t, n.IsPublic, false)
 		}
+		if nts, err := a.GetBlogNotes(context.Background(), idUser); assert.NoError(t, err) {
+			assert.Len(t, nts.Nts, 1)
+			assert.Equal(t, idNote, nts.Nts[0].Id)
+		}
 		assert.NoError(t, a.UpdatePublic(context.Background(), idNote, true))
 		if n, err := a.Get(context.Background(), idNote, idUser); assert.NoError(t, err) {
 			log.Green("get UpdateBlogOrPublic ", n)
@@ -302,7 +306,7 @@ func TestCrudNotExist(t *testing.T) {
 
 	t.Run("crud bad", func(t *testing.T) {
 		m := mongo.MustConnect(config.Test())
-		b := blocks.NewApi(m.Blocks())
+		b := blocks.NewApi(m.Blocks(), m.BlockTrash())
 		tgs := tags.NewApi(m.Tags(), m.NoteTags())
 		a := NewApi(m.Notes(), m.Trash(), m.NoteTags(), tgs, b)
 		id := uid.New()
@@ -312,7 +316,7 @@ func TestCrudNotExist(t *testing.T) {
 
 		if _, err := a.Get(context.Background(), id, ""); assert.ErrorIs(t, err, domain.ErrNotFound) {
 		}
-		if n, err := a.GetNoteListByUser(context.Background(), id); assert.NoError(t, err) && assert.Equal(t, 0, len(n.Ntps)) {
+		if n, err := a.GetNoteListByUser(context.Background(), id, nil, ""); assert.NoError(t, err) && assert.Equal(t, 0, len(n.Ntps)) {
 		}
 		if n, err := a.GetNoteListByTag(context.Background(), id, id); assert.NoError(t, err) && assert.Equal(t, 0, len(n.Ntps)) {
 		}
@@ -328,7 +332,7 @@ func TestBlockOrder(t *testing.T) {
 
 	t.Run("test block order", func(t *testing.T) {
 		m := mongo.MustConnect(config.Test())
-		b := blocks.NewApi(m.Blocks())
+		b := blocks.NewApi(m.Blocks(), m.BlockTrash())
 		tgs := tags.NewApi(m.Tags(), m.NoteTags())
 		a := NewApi(m.Notes(), m.Trash(), m.NoteTags(), tgs, b)
 
@@ -378,7 +382,7 @@ func TestBlockOrder2(t *testing.T) {
 
 	t.Run("test block order 2 el", func(t *testing.T) {
 		m := mongo.MustConnect(config.Test())
-		b := blocks.NewApi(m.Blocks())
+		b := blocks.NewApi(m.Blocks(), m.BlockTrash())
 		tgs := tags.NewApi(m.Tags(), m.NoteTags())
 		a := NewApi(m.Notes(), m.Trash(), m.NoteTags(), tgs, b)
 		id := uid.New()
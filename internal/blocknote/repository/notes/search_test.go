@@ -26,7 +26,7 @@ func TestSearch(t *testing.T) {
 		block.RegisterBlock("text", &textblock.Driver{})
 
 		m := mongo.MustConnect(config.Test())
-		b := blocks.NewApi(m.Blocks())
+		b := blocks.NewApi(m.Blocks(), m.BlockTrash())
 		tgs := tags.NewApi(m.Tags(), m.NoteTags())
 		a := NewApi(m.Notes(), m.Trash(), m.NoteTags(), tgs, b)
 
@@ -155,7 +155,7 @@ func TestSearch(t *testing.T) {
 		}
 
 		{
-			ch := a.Search(context.Background(), uAuthor, "TeStCaSe")
+			ch := a.Search(context.Background(), uAuthor, "TeStCaSe", nil, "")
 			got := collect(ch)
 			for _, r := range got {
 				log.Green("search(author) result", r.Id, r.Title, r.FirstBlock)
@@ -173,7 +173,7 @@ func TestSearch(t *testing.T) {
 		}
 
 		{
-			ch := a.Search(context.Background(), uReader, "testcase")
+			ch := a.Search(context.Background(), uReader, "testcase", nil, "")
 			got := collect(ch)
 			count := map[string]int{}
 			for _, r := range got {
@@ -187,7 +187,7 @@ func TestSearch(t *testing.T) {
 		}
 
 		{
-			ch := a.Search(context.Background(), uEditor, "testcase")
+			ch := a.Search(context.Background(), uEditor, "testcase", nil, "")
 			got := collect(ch)
 			count := map[string]int{}
 			for _, r := range got {
@@ -201,13 +201,13 @@ func TestSearch(t *testing.T) {
 		}
 
 		{
-			ch := a.Search(context.Background(), uStranger, "testcase")
+			ch := a.Search(context.Background(), uStranger, "testcase", nil, "")
 			got := collect(ch)
 			assert.Len(t, got, 0)
 		}
 
 		{
-			ch := a.Search(context.Background(), uAuthor, "tr")
+			ch := a.Search(context.Background(), uAuthor, "tr", nil, "")
 			got := collect(ch)
 			count := map[string]int{}
 			for _, r := range got {
@@ -8,6 +8,7 @@ import (
 	"github.com/autumnterror/breezynotes/internal/blocknote/domain"
 	"github.com/autumnterror/utils_go/pkg/utils/format"
 	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
 )
 
 func (a *API) ShareNote(ctx context.Context, noteId, userId, role string) error {
@@ -111,3 +112,41 @@ func (a *API) DeleteRole(ctx context.Context, noteId, userId string) error {
 //
 //	return nil
 //}
+
+// GetSharedIds return ids of notes available to user which have more than one participant:
+// shared with editors or readers or placed in workspace of user
+func (a *API) GetSharedIds(ctx context.Context, id string, ws domain.WorkspaceRoles) ([]string, error) {
+	const op = "notes.GetSharedIds"
+	ctx, done := context.WithTimeout(ctx, domain.WaitTime)
+	defer done()
+
+	shared := []bson.M{
+		{"editors.0": bson.M{"$exists": true}},
+		{"readers.0": bson.M{"$exists": true}},
+	}
+	if len(ws) > 0 {
+		shared = append(shared, bson.M{"workspace_id": bson.M{"$in": ws.Ids()}})
+	}
+
+	cur, err := a.noteAPI.Find(ctx, bson.M{"$and": []bson.M{
+		accessFilter(id, ws, ""),
+		{"$or": shared},
+	}}, options.Find().SetProjection(bson.M{"_id": 1}))
+	if err != nil {
+		return nil, format.Error(op, err)
+	}
+	defer cur.Close(ctx)
+
+	ids := []string{}
+	for cur.Next(ctx) {
+		var n struct {
+			Id string `bson:"_id"`
+		}
+		if err := cur.Decode(&n); err != nil {
+			return nil, format.Error(op, err)
+		}
+		ids = append(ids, n.Id)
+	}
+
+	return ids, nil
+}
@@ -0,0 +1,64 @@
+package notes
+
+import (
+	"context"
+
+	"github.com/autumnterror/breezynotes/internal/blocknote/domain"
+	"github.com/autumnterror/breezynotes/internal/blocknote/repository"
+	"github.com/autumnterror/utils_go/pkg/utils/format"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// Stats count notes of author in notes and trash, their blocks and size in db
+func (a *API) Stats(ctx context.Context, idUser string) (*domain.UserStats, error) {
+	const op = "notes.Stats"
+
+	ctx, done := context.WithTimeout(ctx, domain.WaitTime)
+	defer done()
+
+	var (
+		st       domain.UserStats
+		idNotes  []string
+		idBlocks []string
+	)
+	for _, c := range []struct {
+		db    repository.NoSqlRepo
+		count *int64
+	}{
+		{a.noteAPI, &st.Notes},
+		{a.trashAPI, &st.TrashNotes},
+	} {
+		cur, err := c.db.Find(ctx, bson.M{"author": idUser})
+		if err != nil {
+			return nil, format.Error(op, err)
+		}
+		for cur.Next(ctx) {
+			var n struct {
+				Id     string   `bson:"_id"`
+				Blocks []string `bson:"blocks"`
+			}
+			if err := cur.Decode(&n); err != nil {
+				_ = cur.Close(ctx)
+				return nil, format.Error(op, err)
+			}
+			*c.count++
+			st.StorageBytes += int64(len(cur.Current))
+			idNotes = append(idNotes, n.Id)
+			idBlocks = append(idBlocks, n.Blocks...)
+		}
+		err = cur.Err()
+		_ = cur.Close(ctx)
+		if err != nil {
+			return nil, format.Error(op, err)
+		}
+	}
+
+	blocks, size, err := a.blockAPI.Size(ctx, idBlocks, idNotes)
+	if err != nil {
+		return nil, format.Error(op, err)
+	}
+	st.Blocks = blocks
+	st.StorageBytes += size
+
+	return &st, nil
+}
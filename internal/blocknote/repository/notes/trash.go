@@ -3,6 +3,8 @@ package notes
 import (
 	"context"
 	"errors"
+	"time"
+
 	"go.mongodb.org/mongo-driver/v2/mongo"
 
 	"github.com/autumnterror/breezynotes/internal/blocknote/domain"
@@ -92,7 +94,9 @@ func (a *API) GetNotesFromTrash(ctx context.Context, uid string) (*domain.NotePa
 			Title:      n.Title,
 			Tag:        noteTag[n.Id],
 			FirstBlock: fb,
+			CreatedAt:  n.CreatedAt,
 			UpdatedAt:  n.UpdatedAt,
+			DeletedAt:  n.DeletedAt,
 		}
 		pts.Ntps = append(pts.Ntps, &np)
 	}
@@ -140,6 +144,7 @@ func (a *API) ToTrash(ctx context.Context, id string) error {
 	if err != nil {
 		return format.Error(op, err)
 	}
+	n.DeletedAt = time.Now().UTC().Unix()
 
 	if _, err := a.trashAPI.InsertOne(ctx, n); err != nil {
 		return format.Error(op, err)
@@ -169,6 +174,11 @@ func (a *API) ToTrashAll(ctx context.Context, idUser string) error {
 		return nil
 	}
 
+	deletedAt := time.Now().UTC().Unix()
+	for _, nt := range n.Nts {
+		nt.DeletedAt = deletedAt
+	}
+
 	if _, err := a.trashAPI.InsertMany(ctx, n.Nts); err != nil {
 		return format.Error(op, err)
 	}
@@ -200,6 +210,7 @@ func (a *API) FromTrash(ctx context.Context, id string) error {
 	if err := res.Decode(&n); err != nil {
 		return format.Error(op, err)
 	}
+	n.DeletedAt = 0
 
 	if err := a.insert(ctx, &n); err != nil {
 		return format.Error(op, err)
@@ -251,3 +262,83 @@ func (a *API) FindOnTrash(ctx context.Context, idNote, idUser string) (*domain.N
 
 	return &n, nil
 }
+
+// StampTrash set deleted_at for notes moved to trash before it was stored
+func (a *API) StampTrash(ctx context.Context, deletedAt int64) error {
+	const op = "notes.StampTrash"
+
+	ctx, done := context.WithTimeout(ctx, domain.WaitTime)
+	defer done()
+
+	if _, err := a.trashAPI.UpdateMany(
+		ctx,
+		bson.M{"deleted_at": bson.M{"$exists": false}},
+		bson.M{"$set": bson.M{"deleted_at": deletedAt}},
+	); err != nil {
+		return format.Error(op, err)
+	}
+
+	return nil
+}
+
+// GetExpiredFromTrash return notes deleted before time before. If idUser is not empty only his notes,
+// else notes of all authors except authors in except
+func (a *API) GetExpiredFromTrash(ctx context.Context, before int64, idUser string, except []string) (*domain.Notes, error) {
+	const op = "notes.GetExpiredFromTrash"
+
+	ctx, done := context.WithTimeout(ctx, domain.WaitTime)
+	defer done()
+
+	filter := bson.M{"deleted_at": bson.M{"$lt": before}}
+	switch {
+	case idUser != "":
+		filter["author"] = idUser
+	case len(except) != 0:
+		filter["author"] = bson.M{"$nin": except}
+	}
+
+	cur, err := a.trashAPI.Find(ctx, filter)
+	if err != nil {
+		return nil, format.Error(op, err)
+	}
+	defer cur.Close(ctx)
+
+	nts := &domain.Notes{
+		Nts: make([]*domain.Note, 0),
+	}
+	for cur.Next(ctx) {
+		var n domain.Note
+		if err = cur.Decode(&n); err != nil {
+			return nts, format.Error(op, err)
+		}
+		nts.Nts = append(nts.Nts, &n)
+	}
+
+	return nts, nil
+}
+
+// DeleteFromTrash permanently rm notes from a.trash() with their notetags. Blocks are not removed
+func (a *API) DeleteFromTrash(ctx context.Context, ids []string) error {
+	const op = "notes.DeleteFromTrash"
+
+	if len(ids) == 0 {
+		return nil
+	}
+
+	ctx, done := context.WithTimeout(ctx, domain.WaitTime)
+	defer done()
+
+	if _, err := a.noteTagsAPI.DeleteMany(ctx, bson.M{"note_id": bson.M{"$in": ids}}); err != nil {
+		return format.Error(op, err)
+	}
+
+	res, err := a.trashAPI.DeleteMany(ctx, bson.M{"_id": bson.M{"$in": ids}})
+	if err != nil {
+		return format.Error(op, err)
+	}
+	if res.DeletedCount == 0 {
+		return format.Error(op, domain.ErrNotFound)
+	}
+
+	return nil
+}
@@ -18,7 +18,7 @@ import (
 func TestTrashCycle(t *testing.T) {
 	t.Run("trash Cycle", func(t *testing.T) {
 		m := mongo.MustConnect(config.Test())
-		b := blocks.NewApi(m.Blocks())
+		b := blocks.NewApi(m.Blocks(), m.BlockTrash())
 		tgs := tags.NewApi(m.Tags(), m.NoteTags())
 		a := NewApi(m.Notes(), m.Trash(), m.NoteTags(), tgs, b)
 
@@ -100,7 +100,7 @@ func TestTrashCycle(t *testing.T) {
 func TestTrashCycleBad(t *testing.T) {
 	t.Run("trash Cycle Bad", func(t *testing.T) {
 		m := mongo.MustConnect(config.Test())
-		b := blocks.NewApi(m.Blocks())
+		b := blocks.NewApi(m.Blocks(), m.BlockTrash())
 		tgs := tags.NewApi(m.Tags(), m.NoteTags())
 		a := NewApi(m.Notes(), m.Trash(), m.NoteTags(), tgs, b)
 
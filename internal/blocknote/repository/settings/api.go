@@ -0,0 +1,13 @@
+package settings
+
+import (
+	"github.com/autumnterror/breezynotes/internal/blocknote/repository"
+)
+
+type API struct {
+	db repository.NoSqlRepo
+}
+
+func NewApi(db repository.NoSqlRepo) *API {
+	return &API{db: db}
+}
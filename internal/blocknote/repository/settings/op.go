@@ -0,0 +1,86 @@
+package settings
+
+import (
+	"context"
+	"errors"
+
+	"github.com/autumnterror/breezynotes/internal/blocknote/domain"
+	"github.com/autumnterror/utils_go/pkg/utils/format"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+type Repo interface {
+	GetTrashRetention(ctx context.Context, idUser string) (int, error)
+	GetAllTrashRetention(ctx context.Context) (map[string]int, error)
+	SetTrashRetention(ctx context.Context, idUser string, days int) error
+}
+
+// GetTrashRetention return days which notes of user stay in trash. 0 if user use global retention
+func (a *API) GetTrashRetention(ctx context.Context, idUser string) (int, error) {
+	const op = "settings.GetTrashRetention"
+
+	ctx, done := context.WithTimeout(ctx, domain.WaitTime)
+	defer done()
+
+	res := a.db.FindOne(ctx, bson.M{"_id": idUser})
+	if err := res.Err(); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return 0, nil
+		}
+		return 0, format.Error(op, err)
+	}
+	var s domain.Settings
+	if err := res.Decode(&s); err != nil {
+		return 0, format.Error(op, err)
+	}
+
+	return s.TrashRetentionDays, nil
+}
+
+// GetAllTrashRetention return map of user id to his retention in days, only users with own retention
+func (a *API) GetAllTrashRetention(ctx context.Context) (map[string]int, error) {
+	const op = "settings.GetAllTrashRetention"
+
+	ctx, done := context.WithTimeout(ctx, domain.WaitTime)
+	defer done()
+
+	cur, err := a.db.Find(ctx, bson.M{"trash_retention_days": bson.M{"$gt": 0}})
+	if err != nil {
+		return nil, format.Error(op, err)
+	}
+	defer cur.Close(ctx)
+
+	res := make(map[string]int)
+	for cur.Next(ctx) {
+		var s domain.Settings
+		if err := cur.Decode(&s); err != nil {
+			return nil, format.Error(op, err)
+		}
+		res[s.UserId] = s.TrashRetentionDays
+	}
+
+	return res, nil
+}
+
+// SetTrashRetention days == 0 reset retention of user to global
+func (a *API) SetTrashRetention(ctx context.Context, idUser string, days int) error {
+	const op = "settings.SetTrashRetention"
+
+	ctx, done := context.WithTimeout(ctx, domain.WaitTime)
+	defer done()
+
+	var update bson.M
+	if days == 0 {
+		update = bson.M{"$unset": bson.M{"trash_retention_days": ""}}
+	} else {
+		update = bson.M{"$set": bson.M{"trash_retention_days": days}}
+	}
+
+	if _, err := a.db.UpdateOne(ctx, bson.M{"_id": idUser}, update, options.UpdateOne().SetUpsert(true)); err != nil {
+		return format.Error(op, err)
+	}
+
+	return nil
+}
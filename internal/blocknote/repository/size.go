@@ -0,0 +1,21 @@
+package repository
+
+import (
+	"context"
+)
+
+// DocsSize return count and summary BSON size of documents by filter
+func DocsSize(ctx context.Context, db NoSqlRepo, filter any) (int64, int64, error) {
+	cur, err := db.Find(ctx, filter)
+	if err != nil {
+		return 0, 0, err
+	}
+	defer cur.Close(ctx)
+
+	var count, size int64
+	for cur.Next(ctx) {
+		count++
+		size += int64(len(cur.Current))
+	}
+	return count, size, cur.Err()
+}
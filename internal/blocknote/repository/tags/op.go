@@ -14,6 +14,7 @@ type Repo interface {
 	Get(ctx context.Context, id string) (*domain.Tag, error)
 	GetAllById(ctx context.Context, id string) (*domain.Tags, error)
 	GetAllByIdPinned(ctx context.Context, id string) (*domain.Tags, error)
+	GetAllByWorkspaces(ctx context.Context, ids []string) (*domain.Tags, error)
 	Create(ctx context.Context, t *domain.Tag) error
 	Delete(ctx context.Context, id string) error
 	DeleteMany(ctx context.Context, ids []string) error
@@ -44,13 +45,14 @@ func (a *API) Get(ctx context.Context, id string) (*domain.Tag, error) {
 	return &t, nil
 }
 
+// GetAllById return personal tags of user, workspace tags are not included
 func (a *API) GetAllById(ctx context.Context, id string) (*domain.Tags, error) {
 	const op = "tags.GetAllById"
 
 	ctx, done := context.WithTimeout(ctx, domain.WaitTime)
 	defer done()
 
-	cur, err := a.db.Find(ctx, bson.M{"user_id": id})
+	cur, err := a.db.Find(ctx, bson.M{"user_id": id, "workspace_id": bson.M{"$exists": false}})
 	if err != nil {
 		return nil, format.Error(op, err)
 	}
@@ -77,7 +79,7 @@ func (a *API) GetAllByIdPinned(ctx context.Context, id string) (*domain.Tags, er
 	ctx, done := context.WithTimeout(ctx, domain.WaitTime)
 	defer done()
 
-	cur, err := a.db.Find(ctx, bson.M{"user_id": id, "is_pinned": true})
+	cur, err := a.db.Find(ctx, bson.M{"user_id": id, "is_pinned": true, "workspace_id": bson.M{"$exists": false}})
 	if err != nil {
 		return nil, format.Error(op, err)
 	}
@@ -98,6 +100,37 @@ func (a *API) GetAllByIdPinned(ctx context.Context, id string) (*domain.Tags, er
 	return tags, nil
 }
 
+// GetAllByWorkspaces return tags owned by workspaces
+func (a *API) GetAllByWorkspaces(ctx context.Context, ids []string) (*domain.Tags, error) {
+	const op = "tags.GetAllByWorkspaces"
+
+	ctx, done := context.WithTimeout(ctx, domain.WaitTime)
+	defer done()
+
+	tags := &domain.Tags{
+		Tgs: []*domain.Tag{},
+	}
+	if len(ids) == 0 {
+		return tags, nil
+	}
+
+	cur, err := a.db.Find(ctx, bson.D{{"workspace_id", bson.D{{"$in", ids}}}})
+	if err != nil {
+		return nil, format.Error(op, err)
+	}
+	defer cur.Close(ctx)
+
+	for cur.Next(ctx) {
+		var t domain.Tag
+		if err = cur.Decode(&t); err != nil {
+			return nil, format.Error(op, err)
+		}
+		tags.Tgs = append(tags.Tgs, &t)
+	}
+
+	return tags, nil
+}
+
 // Create tag. Don't create id
 func (a *API) Create(ctx context.Context, t *domain.Tag) error {
 	const op = "tags.Create"
@@ -0,0 +1,38 @@
+package service
+
+import (
+	"context"
+
+	"github.com/autumnterror/breezynotes/internal/blocknote/domain"
+)
+
+// canRead author, editors, readers and members of note workspace
+func canRead(ctx context.Context, n *domain.Note, idUser string) bool {
+	return n.RoleOf(idUser, domain.WorkspaceRolesFromContext(ctx)) != ""
+}
+
+// canEdit author, editors and workspace owner/editors
+func canEdit(ctx context.Context, n *domain.Note, idUser string) bool {
+	switch n.RoleOf(idUser, domain.WorkspaceRolesFromContext(ctx)) {
+	case domain.AuthorRole, domain.EditorRole:
+		return true
+	default:
+		return false
+	}
+}
+
+// canEditTag owner of personal tag or workspace owner/editors for workspace tag
+func canEditTag(ctx context.Context, t *domain.Tag, idUser string) bool {
+	if t.WorkspaceId == "" {
+		return t.UserId == idUser
+	}
+	return domain.WorkspaceRolesFromContext(ctx).NoteRole(t.WorkspaceId) == domain.EditorRole
+}
+
+// canWriteWorkspace empty workspace means personal space
+func canWriteWorkspace(ctx context.Context, idWorkspace string) bool {
+	if idWorkspace == "" {
+		return true
+	}
+	return domain.WorkspaceRolesFromContext(ctx).NoteRole(idWorkspace) == domain.EditorRole
+}
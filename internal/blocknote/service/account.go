@@ -0,0 +1,40 @@
+package service
+
+import (
+	"context"
+	"errors"
+)
+
+// RemoveUserFromNotes remove deleted user from sharing of notes of other users
+func (s *BN) RemoveUserFromNotes(ctx context.Context, idUser string) error {
+	const op = "service.RemoveUserFromNotes"
+	if err := idValidation(idUser); err != nil {
+		return wrapServiceCheck(op, err)
+	}
+
+	_, err := s.tx.RunInTx(ctx, func(ctx context.Context) (interface{}, error) {
+		return nil, s.nts.RemoveUserFromNotes(ctx, idUser)
+	})
+	return err
+}
+
+// UserFiles return names of local files and images which are used only in notes of user
+func (s *BN) UserFiles(ctx context.Context, idUser string) ([]string, error) {
+	const op = "service.UserFiles"
+	if err := idValidation(idUser); err != nil {
+		return nil, wrapServiceCheck(op, err)
+	}
+
+	res, err := s.tx.RunInTx(ctx, func(ctx context.Context) (interface{}, error) {
+		return s.nts.FileNamesByAuthor(ctx, idUser)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	srcs, ok := res.([]string)
+	if !ok {
+		return nil, wrapServiceCheck(op, errors.New("response type mismatch"))
+	}
+	return srcs, nil
+}
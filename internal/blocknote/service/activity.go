@@ -0,0 +1,94 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/autumnterror/breezynotes/internal/blocknote/domain"
+	"github.com/autumnterror/utils_go/pkg/log"
+	"github.com/autumnterror/utils_go/pkg/utils/uid"
+)
+
+const (
+	defaultFeedLimit = 50
+	maxFeedLimit     = 200
+	maxActivityPage  = 200
+)
+
+// logActivity append event to activity of note. Call it after transaction of change is committed:
+// failed write of activity must not abort the change, so error is only logged
+func (s *BN) logActivity(ctx context.Context, idNote, actor, action, idBlock, summary string) {
+	const op = "service.logActivity"
+
+	if err := s.act.Create(ctx, &domain.Activity{
+		Id:        uid.New(),
+		NoteId:    idNote,
+		Actor:     actor,
+		Action:    action,
+		BlockId:   idBlock,
+		Summary:   domain.ShortSummary(summary),
+		CreatedAt: time.Now().UTC().Unix(),
+	}); err != nil {
+		log.Error(op, action, err)
+	}
+}
+
+// GetNoteActivity return events [start, end) of note, newest first. Everyone who can read note can see it
+func (s *BN) GetNoteActivity(ctx context.Context, idNote, idUser string, start, end int) (*domain.Activities, error) {
+	const op = "service.GetNoteActivity"
+
+	if err := idValidation(idNote); err != nil {
+		return nil, wrapServiceCheck(op, err)
+	}
+	if err := idValidation(idUser); err != nil {
+		return nil, wrapServiceCheck(op, err)
+	}
+	if start < 0 || end < start {
+		return nil, wrapServiceCheck(op, errors.New("bad pagination"))
+	}
+	if end-start > maxActivityPage {
+		end = start + maxActivityPage
+	}
+
+	n, err := s.nts.Get(ctx, idNote, idUser)
+	if err != nil {
+		return nil, domain.ErrNotFound
+	}
+	if !canRead(ctx, n, idUser) {
+		return nil, domain.ErrUnauthorized
+	}
+
+	if start == end {
+		return &domain.Activities{Acts: make([]*domain.Activity, 0)}, nil
+	}
+
+	return s.act.GetByNote(ctx, idNote, start, end)
+}
+
+// GetActivityFeed return last events made by other users in shared notes available to user
+func (s *BN) GetActivityFeed(ctx context.Context, idUser string, limit int) (*domain.Activities, error) {
+	const op = "service.GetActivityFeed"
+
+	if err := idValidation(idUser); err != nil {
+		return nil, wrapServiceCheck(op, err)
+	}
+	if limit <= 0 {
+		limit = defaultFeedLimit
+	}
+	if limit > maxFeedLimit {
+		limit = maxFeedLimit
+	}
+
+	ids, err := s.nts.GetSharedIds(ctx, idUser, domain.WorkspaceRolesFromContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	return s.act.GetByNotes(ctx, ids, idUser, limit)
+}
+
+// PurgeExpiredActivity rm events older than cfg.ActivityRetention
+func (s *BN) PurgeExpiredActivity(ctx context.Context, now time.Time) error {
+	return s.act.DeleteBefore(ctx, now.Add(-s.cfg.ActivityRetention).Unix())
+}
@@ -3,13 +3,15 @@ package service
 import (
 	"context"
 	"errors"
+	"fmt"
 	"time"
 
+	brzrpc "github.com/autumnterror/breezynotes/api/proto/gen"
 	"github.com/autumnterror/breezynotes/internal/blocknote/domain"
 	"github.com/autumnterror/breezynotes/internal/blocknote/domain/domainblocks"
 	"github.com/autumnterror/breezynotes/internal/blocknote/pkg/block"
-	"github.com/autumnterror/utils_go/pkg/utils/alg"
 	"github.com/autumnterror/utils_go/pkg/utils/format"
+	"github.com/autumnterror/utils_go/pkg/utils/uid"
 )
 
 func (s *BN) GetRegisteredBlocks(ctx context.Context) []string {
@@ -39,14 +41,18 @@ func (s *BN) ChangeBlockOrder(ctx context.Context, idNote, idUser string, oldOrd
 			return nil, domain.ErrNotFound
 		}
 
-		if n.Author != idUser && !alg.IsIn(idUser, n.Editors) {
+		if !canEdit(ctx, n, idUser) {
 			return nil, domain.ErrUnauthorized
 		}
 
 		return nil, s.nts.ChangeBlockOrder(ctx, idNote, oldOrder, newOrder)
 	})
+	if err != nil {
+		return err
+	}
 
-	return err
+	s.logActivity(ctx, idNote, idUser, domain.ActionChangeOrder, "", fmt.Sprintf("%d -> %d", oldOrder, newOrder))
+	return nil
 }
 
 func (s *BN) GetBlock(ctx context.Context, idBlock, idNote, idUser string) (*domain.Block, error) {
@@ -65,7 +71,7 @@ func (s *BN) GetBlock(ctx context.Context, idBlock, idNote, idUser string) (*dom
 	if err != nil {
 		return nil, domain.ErrNotFound
 	}
-	if n.Author != idUser && !alg.IsIn(idUser, n.Editors) && !alg.IsIn(idUser, n.Readers) && !n.IsBlog && !n.IsPublic {
+	if !canRead(ctx, n, idUser) && !n.IsBlog && !n.IsPublic {
 		return nil, domain.ErrUnauthorized
 	}
 
@@ -97,20 +103,108 @@ func (s *BN) DeleteBlock(ctx context.Context, idNote, blockId, idUser string) er
 		return wrapServiceCheck(op, errors.New("bad block idNote"))
 	}
 
-	_, err := s.tx.RunInTx(ctx, func(ctx context.Context) (interface{}, error) {
-		if n, err := s.nts.Get(ctx, idNote, idUser); err != nil {
+	_type, err := s.tx.RunInTx(ctx, func(ctx context.Context) (interface{}, error) {
+		n, err := s.nts.Get(ctx, idNote, idUser)
+		if err != nil {
+			return nil, domain.ErrNotFound
+		}
+		if !canEdit(ctx, n, idUser) {
+			return nil, domain.ErrUnauthorized
+		}
+
+		b, err := s.blk.Get(ctx, blockId)
+		if err != nil {
+			return nil, err
+		}
+		if b.NoteId != idNote {
+			return nil, domain.ErrNotFound
+		}
+
+		if err := s.blk.ToTrash(ctx, domain.NewDeletedBlock(b, n.Blocks, idUser, time.Now().UTC().Unix())); err != nil {
+			return nil, err
+		}
+		if err := s.cmt.UpdateOrphaned(ctx, blockId, true); err != nil {
+			return nil, err
+		}
+		return b.Type, s.nts.DeleteBlock(ctx, idNote, blockId)
+	})
+	if err != nil {
+		return err
+	}
+
+	s.logActivity(ctx, idNote, idUser, domain.ActionDeleteBlock, blockId, _type.(string))
+	return nil
+}
+
+// GetDeletedBlocks return blocks from block trash of note, last deleted first
+func (s *BN) GetDeletedBlocks(ctx context.Context, idNote, idUser string) (*domain.DeletedBlocks, error) {
+	const op = "service.GetDeletedBlocks"
+
+	if err := idValidation(idNote); err != nil {
+		return nil, wrapServiceCheck(op, err)
+	}
+	if err := idValidation(idUser); err != nil {
+		return nil, wrapServiceCheck(op, err)
+	}
+
+	n, err := s.nts.Get(ctx, idNote, idUser)
+	if err != nil {
+		return nil, domain.ErrNotFound
+	}
+	if !canEdit(ctx, n, idUser) {
+		return nil, domain.ErrUnauthorized
+	}
+
+	return s.blk.GetTrashByNote(ctx, idNote)
+}
+
+// RestoreBlock return block from block trash to its note. Block is placed near his old neighbours
+// if they still exist, else on old position
+func (s *BN) RestoreBlock(ctx context.Context, idNote, idBlock, idUser string) error {
+	const op = "service.RestoreBlock"
+
+	if err := idValidation(idNote); err != nil {
+		return wrapServiceCheck(op, err)
+	}
+	if err := idValidation(idBlock); err != nil {
+		return wrapServiceCheck(op, err)
+	}
+	if err := idValidation(idUser); err != nil {
+		return wrapServiceCheck(op, err)
+	}
+
+	pos, err := s.tx.RunInTx(ctx, func(ctx context.Context) (interface{}, error) {
+		n, err := s.nts.Get(ctx, idNote, idUser)
+		if err != nil {
 			return nil, domain.ErrNotFound
-		} else if n.Author != idUser && !alg.IsIn(idUser, n.Editors) {
+		}
+		if !canEdit(ctx, n, idUser) {
 			return nil, domain.ErrUnauthorized
 		}
 
-		if err := s.blk.Delete(ctx, blockId); err != nil {
+		db, err := s.blk.GetFromTrash(ctx, idBlock)
+		if err != nil {
+			return nil, err
+		}
+		if db.NoteId != idNote {
+			return nil, domain.ErrNotFound
+		}
+
+		if err := s.blk.FromTrash(ctx, idBlock); err != nil {
 			return nil, err
 		}
-		return nil, s.nts.DeleteBlock(ctx, idNote, blockId)
+		if err := s.cmt.UpdateOrphaned(ctx, idBlock, false); err != nil {
+			return nil, err
+		}
+		pos := db.RestorePosition(n.Blocks)
+		return pos, s.nts.InsertBlock(ctx, idNote, idBlock, pos)
 	})
+	if err != nil {
+		return err
+	}
 
-	return err
+	s.logActivity(ctx, idNote, idUser, domain.ActionRestoreBlock, idBlock, fmt.Sprintf("at %d", pos.(int)))
+	return nil
 }
 
 func (s *BN) CreateBlock(ctx context.Context, newId, _type, idNote string, data map[string]any, pos int, idUser string) (string, error) {
@@ -133,7 +227,7 @@ func (s *BN) CreateBlock(ctx context.Context, newId, _type, idNote string, data
 	res, err := s.tx.RunInTx(ctx, func(ctx context.Context) (interface{}, error) {
 		if n, err := s.nts.Get(ctx, idNote, idUser); err != nil {
 			return nil, domain.ErrNotFound
-		} else if n.Author != idUser && !alg.IsIn(idUser, n.Editors) {
+		} else if !canEdit(ctx, n, idUser) {
 			return nil, domain.ErrUnauthorized
 		}
 
@@ -166,10 +260,61 @@ func (s *BN) CreateBlock(ctx context.Context, newId, _type, idNote string, data
 	if resS, ok := res.(string); !ok {
 		return "", wrapServiceCheck(op, errors.New("response type mismatch"))
 	} else {
+		s.logActivity(ctx, idNote, idUser, domain.ActionCreateBlock, resS, fmt.Sprintf("%s at %d", _type, pos))
 		return resS, nil
 	}
 }
 
+// insertBlocks create blocks made by Create of drivers and insert them into note one after another from pos.
+// Ids, note and times of blocks are set
+func (s *BN) insertBlocks(ctx context.Context, idNote, idUser string, pos int, blks []*brzrpc.Block) error {
+	const op = "service.insertBlocks"
+
+	if pos < 0 {
+		return wrapServiceCheck(op, errors.New("pos < 0"))
+	}
+	if idValidation(idNote) != nil {
+		return wrapServiceCheck(op, errors.New("bad note id"))
+	}
+	if idValidation(idUser) != nil {
+		return wrapServiceCheck(op, errors.New("bad user id"))
+	}
+	if len(blks) == 0 {
+		return nil
+	}
+
+	_, err := s.tx.RunInTx(ctx, func(ctx context.Context) (interface{}, error) {
+		if n, err := s.nts.Get(ctx, idNote, idUser); err != nil {
+			return nil, domain.ErrNotFound
+		} else if !canEdit(ctx, n, idUser) {
+			return nil, domain.ErrUnauthorized
+		}
+
+		now := time.Now().UTC().Unix()
+		for i, b := range blks {
+			b.Id = uid.New()
+			b.NoteId = idNote
+			b.CreatedAt = now
+			b.UpdatedAt = now
+			b.IsUsed = false
+
+			if err := s.blk.CreateBlock(ctx, domain.ToBlockDb(b)); err != nil {
+				return nil, format.Error(op, err)
+			}
+			if err := s.nts.InsertBlock(ctx, idNote, b.Id, pos+i); err != nil {
+				return nil, err
+			}
+		}
+		return nil, nil
+	})
+	if err != nil {
+		return err
+	}
+
+	s.logActivity(ctx, idNote, idUser, domain.ActionCreateBlock, blks[0].Id, fmt.Sprintf("%d blocks at %d", len(blks), pos))
+	return nil
+}
+
 func (s *BN) OpBlock(ctx context.Context, id, opName string, data map[string]any, idNote, idUser string) error {
 	const op = "service.OpBlock"
 
@@ -180,10 +325,10 @@ func (s *BN) OpBlock(ctx context.Context, id, opName string, data map[string]any
 		return wrapServiceCheck(op, errors.New("bad id"))
 	}
 
-	_, err := s.tx.RunInTx(ctx, func(ctx context.Context) (interface{}, error) {
+	changed, err := s.tx.RunInTx(ctx, func(ctx context.Context) (interface{}, error) {
 		if n, err := s.nts.Get(ctx, idNote, idUser); err != nil {
 			return nil, domain.ErrNotFound
-		} else if n.Author != idUser && !alg.IsIn(idUser, n.Editors) && !alg.IsIn(idUser, n.Readers) {
+		} else if !canRead(ctx, n, idUser) {
 			return nil, domain.ErrUnauthorized
 		}
 
@@ -209,22 +354,31 @@ func (s *BN) OpBlock(ctx context.Context, id, opName string, data map[string]any
 		}
 
 		if newData == nil {
-			return nil, nil
+			return false, nil
 		}
 
 		if err := s.blk.UpdateData(ctx, id, newData); err != nil {
 			return nil, err
 		}
+		if err := s.shiftAnchors(ctx, id, opName, data); err != nil {
+			return nil, err
+		}
 		if err := s.blk.UpdateUsed(ctx, id, false); err != nil {
 			return nil, err
 		}
 		if err := s.nts.UpdateUpdatedAt(ctx, idNote); err != nil {
 			return nil, err
 		}
-		return nil, nil
+		return true, nil
 	})
+	if err != nil {
+		return err
+	}
 
-	return err
+	if changed.(bool) {
+		s.logActivity(ctx, idNote, idUser, domain.ActionOpBlock, id, domain.OpSummary(opName, data))
+	}
+	return nil
 }
 
 func (s *BN) ChangeTypeBlock(ctx context.Context, idBlock, idNote, idUser, newType string) error {
@@ -236,10 +390,10 @@ func (s *BN) ChangeTypeBlock(ctx context.Context, idBlock, idNote, idUser, newTy
 		return wrapServiceCheck(op, errors.New("bad idBlock"))
 	}
 
-	_, err := s.tx.RunInTx(ctx, func(ctx context.Context) (interface{}, error) {
+	oldType, err := s.tx.RunInTx(ctx, func(ctx context.Context) (interface{}, error) {
 		if n, err := s.nts.Get(ctx, idNote, idUser); err != nil {
 			return nil, domain.ErrNotFound
-		} else if n.Author != idUser && !alg.IsIn(idUser, n.Editors) {
+		} else if !canEdit(ctx, n, idUser) {
 			return nil, domain.ErrUnauthorized
 		}
 
@@ -271,8 +425,12 @@ func (s *BN) ChangeTypeBlock(ctx context.Context, idBlock, idNote, idUser, newTy
 		if err := s.blk.UpdateData(ctx, idBlock, nb.Data.AsMap()); err != nil {
 			return nil, format.Error(op, err)
 		}
-		return nil, nil
+		return b.Type, nil
 	})
+	if err != nil {
+		return err
+	}
 
-	return err
+	s.logActivity(ctx, idNote, idUser, domain.ActionChangeType, idBlock, domain.ChangeSummary(oldType.(string), newType))
+	return nil
 }
@@ -0,0 +1,290 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+	"unicode/utf8"
+
+	"github.com/autumnterror/breezynotes/internal/blocknote/domain"
+)
+
+const (
+	maxCommentLen = 5000
+
+	insertTextOp  = "insert_text"
+	deleteRangeOp = "delete_range"
+)
+
+func commentValidation(text string, mentions []string) error {
+	if stringEmpty(text) {
+		return errors.New("text is empty")
+	}
+	if utf8.RuneCountInString(text) > maxCommentLen {
+		return errors.New("comment is too long")
+	}
+	for _, id := range mentions {
+		if err := idValidation(id); err != nil {
+			return errors.New("mentions: id not in uuid")
+		}
+	}
+	return nil
+}
+
+// CreateComment start new thread or reply to thread if c.ThreadId is set. Everyone who can read note can comment
+func (s *BN) CreateComment(ctx context.Context, c *domain.Comment) error {
+	const op = "service.CreateComment"
+
+	if err := idValidation(c.Id); err != nil {
+		return wrapServiceCheck(op, err)
+	}
+	if err := idValidation(c.NoteId); err != nil {
+		return wrapServiceCheck(op, err)
+	}
+	if err := idValidation(c.Author); err != nil {
+		return wrapServiceCheck(op, err)
+	}
+	if err := commentValidation(c.Text, c.Mentions); err != nil {
+		return wrapServiceCheck(op, err)
+	}
+	if c.ThreadId == "" {
+		if err := idValidation(c.BlockId); err != nil {
+			return wrapServiceCheck(op, err)
+		}
+		if c.Anchor != nil && (c.Anchor.Start < 0 || c.Anchor.End < c.Anchor.Start) {
+			return wrapServiceCheck(op, errors.New("bad anchor"))
+		}
+	} else if err := idValidation(c.ThreadId); err != nil {
+		return wrapServiceCheck(op, err)
+	}
+
+	_, err := s.tx.RunInTx(ctx, func(ctx context.Context) (interface{}, error) {
+		n, err := s.nts.Get(ctx, c.NoteId, c.Author)
+		if err != nil {
+			return nil, domain.ErrNotFound
+		}
+		if !canRead(ctx, n, c.Author) {
+			return nil, domain.ErrUnauthorized
+		}
+
+		if c.ThreadId == "" {
+			b, err := s.blk.Get(ctx, c.BlockId)
+			if err != nil {
+				return nil, err
+			}
+			if b.NoteId != c.NoteId {
+				return nil, domain.ErrNotFound
+			}
+			c.ThreadId = c.Id
+		} else {
+			root, err := s.cmt.Get(ctx, c.ThreadId)
+			if err != nil {
+				return nil, err
+			}
+			if !root.IsRoot() || root.NoteId != c.NoteId {
+				return nil, domain.ErrNotFound
+			}
+			c.BlockId = root.BlockId
+			c.Orphaned = root.Orphaned
+			c.Anchor = nil
+		}
+
+		if c.Mentions == nil {
+			c.Mentions = []string{}
+		}
+		c.Resolved = false
+		c.CreatedAt = time.Now().UTC().Unix()
+		c.UpdatedAt = c.CreatedAt
+
+		return nil, s.cmt.Create(ctx, c)
+	})
+	if err != nil {
+		return err
+	}
+
+	s.logActivity(ctx, c.NoteId, c.Author, domain.ActionComment, c.BlockId, c.Text)
+	return nil
+}
+
+func (s *BN) GetComments(ctx context.Context, idNote, idUser string) (*domain.Comments, error) {
+	const op = "service.GetComments"
+
+	if err := idValidation(idNote); err != nil {
+		return nil, wrapServiceCheck(op, err)
+	}
+	if err := idValidation(idUser); err != nil {
+		return nil, wrapServiceCheck(op, err)
+	}
+
+	n, err := s.nts.Get(ctx, idNote, idUser)
+	if err != nil {
+		return nil, domain.ErrNotFound
+	}
+	if !canRead(ctx, n, idUser) {
+		return nil, domain.ErrUnauthorized
+	}
+
+	return s.cmt.GetByNote(ctx, idNote)
+}
+
+// UpdateComment only author can edit his comment
+func (s *BN) UpdateComment(ctx context.Context, id, idUser, text string, mentions []string) error {
+	const op = "service.UpdateComment"
+
+	if err := idValidation(id); err != nil {
+		return wrapServiceCheck(op, err)
+	}
+	if err := idValidation(idUser); err != nil {
+		return wrapServiceCheck(op, err)
+	}
+	if err := commentValidation(text, mentions); err != nil {
+		return wrapServiceCheck(op, err)
+	}
+	if mentions == nil {
+		mentions = []string{}
+	}
+
+	res, err := s.tx.RunInTx(ctx, func(ctx context.Context) (interface{}, error) {
+		c, err := s.cmt.Get(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		if c.Author != idUser {
+			return nil, domain.ErrUnauthorized
+		}
+
+		return c, s.cmt.UpdateText(ctx, id, text, mentions)
+	})
+	if err != nil {
+		return err
+	}
+
+	c := res.(*domain.Comment)
+	s.logActivity(ctx, c.NoteId, idUser, domain.ActionEditComment, c.BlockId, text)
+	return nil
+}
+
+// DeleteComment only author can delete his comment. Delete of root comment delete whole thread
+func (s *BN) DeleteComment(ctx context.Context, id, idUser string) error {
+	const op = "service.DeleteComment"
+
+	if err := idValidation(id); err != nil {
+		return wrapServiceCheck(op, err)
+	}
+	if err := idValidation(idUser); err != nil {
+		return wrapServiceCheck(op, err)
+	}
+
+	res, err := s.tx.RunInTx(ctx, func(ctx context.Context) (interface{}, error) {
+		c, err := s.cmt.Get(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		if c.Author != idUser {
+			return nil, domain.ErrUnauthorized
+		}
+
+		if c.IsRoot() {
+			return c, s.cmt.DeleteThread(ctx, id)
+		}
+		return c, s.cmt.Delete(ctx, id)
+	})
+	if err != nil {
+		return err
+	}
+
+	c := res.(*domain.Comment)
+	s.logActivity(ctx, c.NoteId, idUser, domain.ActionDeleteComment, c.BlockId, c.Text)
+	return nil
+}
+
+// ResolveThread resolve or reopen thread. Everyone who can read note can do it
+func (s *BN) ResolveThread(ctx context.Context, idThread, idUser string, resolved bool) error {
+	const op = "service.ResolveThread"
+
+	if err := idValidation(idThread); err != nil {
+		return wrapServiceCheck(op, err)
+	}
+	if err := idValidation(idUser); err != nil {
+		return wrapServiceCheck(op, err)
+	}
+
+	res, err := s.tx.RunInTx(ctx, func(ctx context.Context) (interface{}, error) {
+		root, err := s.cmt.Get(ctx, idThread)
+		if err != nil {
+			return nil, err
+		}
+		if !root.IsRoot() {
+			return nil, domain.ErrBadRequest
+		}
+
+		n, err := s.nts.Get(ctx, root.NoteId, idUser)
+		if err != nil {
+			return nil, domain.ErrNotFound
+		}
+		if !canRead(ctx, n, idUser) {
+			return nil, domain.ErrUnauthorized
+		}
+
+		return root, s.cmt.UpdateResolved(ctx, idThread, resolved)
+	})
+	if err != nil {
+		return err
+	}
+
+	action := domain.ActionReopenThread
+	if resolved {
+		action = domain.ActionResolveThread
+	}
+	root := res.(*domain.Comment)
+	s.logActivity(ctx, root.NoteId, idUser, action, root.BlockId, root.Text)
+	return nil
+}
+
+// shiftAnchors move anchors of comments on block after text op. Other ops do not change text positions
+func (s *BN) shiftAnchors(ctx context.Context, idBlock, opName string, data map[string]any) error {
+	if opName != insertTextOp && opName != deleteRangeOp {
+		return nil
+	}
+
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	var req struct {
+		Pos     int    `json:"pos"`
+		NewText string `json:"new_text"`
+		Start   int    `json:"start"`
+		End     int    `json:"end"`
+	}
+	if err := json.Unmarshal(raw, &req); err != nil {
+		return err
+	}
+
+	cmts, err := s.cmt.GetAnchoredByBlock(ctx, idBlock)
+	if err != nil {
+		return err
+	}
+
+	for _, c := range cmts.Cmts {
+		if c.Anchor == nil {
+			continue
+		}
+		old := *c.Anchor
+		switch opName {
+		case insertTextOp:
+			c.Anchor.ShiftInsert(req.Pos, utf8.RuneCountInString(req.NewText))
+		case deleteRangeOp:
+			c.Anchor.ShiftDelete(req.Start, req.End)
+		}
+		if old == *c.Anchor {
+			continue
+		}
+		if err := s.cmt.UpdateAnchor(ctx, c.Id, c.Anchor); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,97 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"io"
+
+	"github.com/autumnterror/breezynotes/internal/blocknote/domain"
+	"github.com/autumnterror/breezynotes/internal/blocknote/pkg/block"
+	"github.com/autumnterror/breezynotes/internal/blocknote/pkg/render"
+	"github.com/autumnterror/breezynotes/internal/blocknote/pkg/render/epub"
+	"github.com/autumnterror/breezynotes/internal/blocknote/pkg/render/pdf"
+	"github.com/autumnterror/utils_go/pkg/utils/uid"
+)
+
+const (
+	ExportHTML = "html"
+	ExportPDF  = "pdf"
+	ExportEPUB = "epub"
+)
+
+// ExportNote write note as standalone html or pdf. Stored pictures of note are taken from files by src
+func (s *BN) ExportNote(ctx context.Context, idNote, idUser, format string, files map[string][]byte, w io.Writer) error {
+	const op = "service.ExportNote"
+	if format != ExportHTML && format != ExportPDF {
+		return wrapServiceCheck(op, errors.New("unknown format"))
+	}
+
+	n, err := s.GetNote(ctx, idNote, idUser)
+	if err != nil {
+		return err
+	}
+	rn := domain.FromNoteWithBlocksDb(n)
+
+	if format == ExportPDF {
+		font, mono := s.pdfFonts()
+		return block.NotePDF(ctx, rn, w, font, mono, render.Inline(files))
+	}
+	_, err = io.WriteString(w, block.NoteHTML(ctx, rn, render.Inline(files)))
+	return err
+}
+
+// ExportCollection write blog notes of author as book, from oldest to newest. Blog notes are readable by everyone,
+// so only id of user is checked
+func (s *BN) ExportCollection(ctx context.Context, idAuthor, idUser, authorName, format string, a epub.Archive) error {
+	const op = "service.ExportCollection"
+	if err := idValidation(idAuthor); err != nil {
+		return wrapServiceCheck(op, err)
+	}
+	if err := idValidation(idUser); err != nil {
+		return wrapServiceCheck(op, err)
+	}
+	if format != ExportEPUB {
+		return wrapServiceCheck(op, errors.New("unknown format"))
+	}
+
+	nts, err := s.nts.GetBlogNotes(ctx, idAuthor)
+	if err != nil {
+		return err
+	}
+	if len(nts.Nts) == 0 {
+		return domain.ErrNotFound
+	}
+
+	title := "Blog"
+	if authorName != "" {
+		title = authorName + ": blog"
+	}
+	b, err := epub.New(a, uid.New(), title, authorName)
+	if err != nil {
+		return err
+	}
+	for _, n := range nts.Nts {
+		blks, err := s.blk.GetMany(ctx, n.Blocks)
+		if err != nil {
+			return err
+		}
+		rn := domain.FromNoteWithBlocksDb(&domain.NoteWithBlocks{Id: n.Id, Title: n.Title, Blocks: blks.Blks})
+		if err := b.Chapter(n.Title, block.NoteHTML(ctx, rn, b.Files())); err != nil {
+			return err
+		}
+	}
+	return b.Close()
+}
+
+// pdfFonts return fonts from config. Code is written by main font if there is no monospace one,
+// so it has same chars as text
+func (s *BN) pdfFonts() (*pdf.Font, *pdf.Font) {
+	if s.cfg == nil {
+		return nil, nil
+	}
+	mono := s.cfg.PdfMonoFont
+	if mono == nil {
+		mono = s.cfg.PdfFont
+	}
+	return s.cfg.PdfFont, mono
+}
@@ -0,0 +1,36 @@
+package service
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	"github.com/autumnterror/breezynotes/internal/blocknote/config"
+	"github.com/autumnterror/breezynotes/internal/blocknote/pkg/render/pdf"
+	"github.com/autumnterror/utils_go/pkg/utils/uid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExportCheck(t *testing.T) {
+	s := &BN{}
+	ctx := context.Background()
+
+	err := s.ExportNote(ctx, uid.New(), uid.New(), "docx", nil, io.Discard)
+	assert.ErrorIs(t, err, ErrBadServiceCheck)
+
+	err = s.ExportCollection(ctx, "bad", uid.New(), "", ExportEPUB, nil)
+	assert.ErrorIs(t, err, ErrBadServiceCheck)
+	err = s.ExportCollection(ctx, uid.New(), uid.New(), "", ExportPDF, nil)
+	assert.ErrorIs(t, err, ErrBadServiceCheck)
+}
+
+func TestPdfFonts(t *testing.T) {
+	font, mono := (&BN{}).pdfFonts()
+	assert.Nil(t, font)
+	assert.Nil(t, mono)
+
+	f := pdf.Helvetica()
+	font, mono = (&BN{cfg: &config.Config{PdfFont: f}}).pdfFonts()
+	assert.Same(t, f, font)
+	assert.Same(t, f, mono, "code is written by main font if there is no monospace one")
+}
@@ -0,0 +1,241 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/autumnterror/breezynotes/internal/blocknote/domain"
+	"github.com/autumnterror/breezynotes/internal/blocknote/pkg/block"
+	"github.com/autumnterror/utils_go/pkg/utils/uid"
+)
+
+// ImportTags create personal tags of archive for user. Tag with same title as existing tag of user isn't created,
+// it is merged with existing one and reported as conflict
+func (s *BN) ImportTags(ctx context.Context, idUser string, tgs []*domain.Tag) (*domain.ImportReport, error) {
+	const op = "service.ImportTags"
+	if err := idValidation(idUser); err != nil {
+		return nil, wrapServiceCheck(op, err)
+	}
+
+	res, err := s.tx.RunInTx(ctx, func(ctx context.Context) (interface{}, error) {
+		r := domain.NewImportReport()
+
+		existing, err := s.tgs.GetAllById(ctx, idUser)
+		if err != nil {
+			return nil, err
+		}
+		byTitle := make(map[string]string, len(existing.Tgs))
+		for _, t := range existing.Tgs {
+			byTitle[tagKey(t.Title)] = t.Id
+		}
+
+		for _, t := range tgs {
+			if t == nil {
+				continue
+			}
+			if id, ok := byTitle[tagKey(t.Title)]; ok {
+				r.Ids[t.Id] = id
+				r.Conflict(domain.ConflictTagMerged, t.Id, t.Title, "merged with existing tag")
+				continue
+			}
+
+			nt := &domain.Tag{
+				Id:       uid.New(),
+				Title:    t.Title,
+				Color:    t.Color,
+				Emoji:    t.Emoji,
+				UserId:   idUser,
+				IsPinned: t.IsPinned,
+			}
+			if err := tagValidation(nt); err != nil {
+				r.Conflict(domain.ConflictTagInvalid, t.Id, t.Title, err.Error())
+				continue
+			}
+			if err := s.tgs.Create(ctx, nt); err != nil {
+				return nil, err
+			}
+			byTitle[tagKey(nt.Title)] = nt.Id
+			r.Ids[t.Id] = nt.Id
+			r.Tags++
+		}
+
+		return r, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	r, ok := res.(*domain.ImportReport)
+	if !ok {
+		return nil, wrapServiceCheck(op, errors.New("response type mismatch"))
+	}
+	return r, nil
+}
+
+// ImportNote create note of archive with blocks for user under new ids. Timestamps are kept, sharing is not.
+// tags maps old tag ids to new ones, see ImportTags. Note which user already has (same title and creation time,
+// so restore to same server or second import) is skipped, blocks of unknown type or with bad data are skipped.
+// Both are reported as conflicts. Non-empty newId is id of created note. Non-empty src is html of note,
+// it is converted to blocks which are put after blocks of note, see ConvertHTML
+func (s *BN) ImportNote(ctx context.Context, idUser string, n *domain.NoteWithBlocks, trash bool, tags map[string]string, newId, src string) (*domain.ImportReport, error) {
+	const op = "service.ImportNote"
+	if err := idValidation(idUser); err != nil {
+		return nil, wrapServiceCheck(op, err)
+	}
+	if n == nil {
+		return nil, wrapServiceCheck(op, errors.New("note is empty"))
+	}
+	if newId != "" {
+		if err := idValidation(newId); err != nil {
+			return nil, wrapServiceCheck(op, err)
+		}
+	}
+	if stringEmpty(n.Title) {
+		return nil, wrapServiceCheck(op, errors.New("title is empty"))
+	}
+	if src != "" {
+		blks, err := s.ConvertHTML(ctx, src, "", "", 0)
+		if err != nil {
+			return nil, err
+		}
+		for _, b := range blks.GetItems() {
+			n.Blocks = append(n.Blocks, domain.ToBlockDb(b))
+		}
+	}
+
+	res, err := s.tx.RunInTx(ctx, func(ctx context.Context) (interface{}, error) {
+		r := domain.NewImportReport()
+
+		if n.CreatedAt != 0 {
+			exists, err := s.nts.HasNote(ctx, idUser, n.Title, n.CreatedAt)
+			if err != nil {
+				return nil, err
+			}
+			if exists {
+				r.Conflict(domain.ConflictNoteExists, n.Id, n.Title, "note with same title and creation time exists")
+				return r, nil
+			}
+		}
+
+		nn, blks := remapNote(ctx, n, idUser, newId, time.Now().UTC(), r)
+		if trash {
+			nn.DeletedAt = time.Now().UTC().Unix()
+		}
+		if n.Tag != nil {
+			if id, ok := tags[n.Tag.Id]; ok {
+				t, err := s.tgs.Get(ctx, id)
+				if err != nil {
+					return nil, err
+				}
+				if t.UserId != idUser {
+					return nil, domain.ErrUnauthorized
+				}
+				nn.Tag = t
+			}
+		}
+		if err := noteValidation(nn); err != nil {
+			return nil, wrapServiceCheck(op, err)
+		}
+
+		for _, b := range blks {
+			if err := s.blk.CreateBlock(ctx, b); err != nil {
+				return nil, err
+			}
+		}
+		if err := s.nts.Import(ctx, nn); err != nil {
+			return nil, err
+		}
+
+		if trash {
+			r.Trash++
+		} else {
+			r.Notes++
+		}
+		return r, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	r, ok := res.(*domain.ImportReport)
+	if !ok {
+		return nil, wrapServiceCheck(op, errors.New("response type mismatch"))
+	}
+	if id, ok := r.Ids[n.Id]; ok {
+		s.logActivity(ctx, id, idUser, domain.ActionImportNote, "", n.Title)
+	}
+	return r, nil
+}
+
+// remapNote make note and blocks with new ids for user. Blocks which can't be created by their driver are skipped
+// and reported. Tag, sharing and workspace are dropped. Zero timestamps are set to now, empty newId is generated
+func remapNote(ctx context.Context, n *domain.NoteWithBlocks, idUser, newId string, now time.Time, r *domain.ImportReport) (*domain.Note, []*domain.Block) {
+	if newId == "" {
+		newId = uid.New()
+	}
+	nn := &domain.Note{
+		Id:        newId,
+		Title:     n.Title,
+		CreatedAt: n.CreatedAt,
+		UpdatedAt: n.UpdatedAt,
+		Author:    idUser,
+		Editors:   []string{},
+		Readers:   []string{},
+		Blocks:    []string{},
+	}
+	if nn.CreatedAt == 0 {
+		nn.CreatedAt = now.Unix()
+	}
+	if nn.UpdatedAt == 0 {
+		nn.UpdatedAt = nn.CreatedAt
+	}
+	r.Ids[n.Id] = nn.Id
+
+	var blks []*domain.Block
+	for _, b := range n.Blocks {
+		if b == nil {
+			continue
+		}
+		drv := block.Registry[b.Type]
+		if drv == nil {
+			r.Conflict(domain.ConflictBlockType, b.Id, n.Title, "unknown block type "+b.Type)
+			continue
+		}
+		created, err := drv.Create(ctx, b.Data)
+		if err != nil {
+			r.Conflict(domain.ConflictBlockInvalid, b.Id, n.Title, err.Error())
+			continue
+		}
+
+		nb := &domain.Block{
+			Id:        uid.New(),
+			Type:      b.Type,
+			NoteId:    nn.Id,
+			CreatedAt: b.CreatedAt,
+			UpdatedAt: b.UpdatedAt,
+			IsUsed:    b.IsUsed,
+			Data:      created.GetData().AsMap(),
+		}
+		if nb.CreatedAt == 0 {
+			nb.CreatedAt = nn.CreatedAt
+		}
+		if nb.UpdatedAt == 0 {
+			nb.UpdatedAt = nb.CreatedAt
+		}
+
+		if b.Id != "" {
+			r.Ids[b.Id] = nb.Id
+		}
+		r.Blocks++
+		nn.Blocks = append(nn.Blocks, nb.Id)
+		blks = append(blks, nb)
+	}
+
+	return nn, blks
+}
+
+func tagKey(title string) string {
+	return strings.ToLower(strings.TrimSpace(title))
+}
@@ -0,0 +1,70 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/autumnterror/breezynotes/internal/blocknote/domain"
+	"github.com/autumnterror/breezynotes/internal/blocknote/pkg/block"
+	"github.com/autumnterror/breezynotes/internal/blocknote/pkg/block/default/textblock"
+	"github.com/autumnterror/utils_go/pkg/utils/uid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRemapNote(t *testing.T) {
+	block.RegisterBlock("text", &textblock.Driver{})
+
+	now := time.Date(2025, 1, 31, 12, 0, 0, 0, time.UTC)
+	idUser := uid.New()
+	n := &domain.NoteWithBlocks{
+		Id:        uid.New(),
+		Title:     "Plan",
+		CreatedAt: 100,
+		UpdatedAt: 200,
+		Author:    uid.New(),
+		Editors:   []string{uid.New()},
+		IsPublic:  true,
+		Blocks: []*domain.Block{
+			{Id: uid.New(), Type: "text", CreatedAt: 110, UpdatedAt: 120, Data: map[string]any{
+				"text": []any{map[string]any{"style": "default", "string": "buy milk"}},
+			}},
+			{Id: uid.New(), Type: "unknown", Data: map[string]any{}},
+			{Id: uid.New(), Type: "text", Data: map[string]any{}},
+		},
+	}
+
+	r := domain.NewImportReport()
+	nn, blks := remapNote(context.Background(), n, idUser, "", now, r)
+
+	assert.NotEqual(t, n.Id, nn.Id)
+	assert.Equal(t, nn.Id, r.Ids[n.Id])
+	assert.Equal(t, idUser, nn.Author)
+	assert.Empty(t, nn.Editors)
+	assert.False(t, nn.IsPublic)
+	assert.Equal(t, int64(100), nn.CreatedAt)
+	assert.Equal(t, int64(200), nn.UpdatedAt)
+
+	require.Len(t, blks, 2)
+	assert.Equal(t, []string{blks[0].Id, blks[1].Id}, nn.Blocks)
+	assert.Equal(t, blks[0].Id, r.Ids[n.Blocks[0].Id])
+	assert.Equal(t, nn.Id, blks[0].NoteId)
+	assert.Equal(t, int64(110), blks[0].CreatedAt)
+	assert.Equal(t, int64(120), blks[0].UpdatedAt)
+	assert.Equal(t, n.Blocks[0].Data, blks[0].Data)
+	assert.Equal(t, int64(100), blks[1].CreatedAt, "zero time of block is taken from note")
+	assert.Equal(t, int32(2), r.Blocks)
+
+	require.Len(t, r.Conflicts, 1)
+	assert.Equal(t, domain.ConflictBlockType, r.Conflicts[0].Kind)
+	assert.Equal(t, n.Blocks[1].Id, r.Conflicts[0].Id)
+
+	r = domain.NewImportReport()
+	newId := uid.New()
+	nn, _ = remapNote(context.Background(), &domain.NoteWithBlocks{Id: uid.New(), Title: "Empty"}, idUser, newId, now, r)
+	assert.Equal(t, newId, nn.Id)
+	assert.Equal(t, now.Unix(), nn.CreatedAt)
+	assert.Equal(t, now.Unix(), nn.UpdatedAt)
+	assert.Empty(t, nn.Blocks)
+}
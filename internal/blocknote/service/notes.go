@@ -3,9 +3,9 @@ package service
 import (
 	"context"
 	"errors"
+	"strconv"
 
 	"github.com/autumnterror/breezynotes/internal/blocknote/domain"
-	"github.com/autumnterror/utils_go/pkg/utils/alg"
 )
 
 func (s *BN) CreateNote(ctx context.Context, n *domain.Note) error {
@@ -13,12 +13,19 @@ func (s *BN) CreateNote(ctx context.Context, n *domain.Note) error {
 	if err := noteValidation(n); err != nil {
 		return wrapServiceCheck(op, err)
 	}
+	if !canWriteWorkspace(ctx, n.WorkspaceId) {
+		return domain.ErrUnauthorized
+	}
 
 	_, err := s.tx.RunInTx(ctx, func(ctx context.Context) (interface{}, error) {
 		return nil, s.nts.Create(ctx, n)
 	})
+	if err != nil {
+		return err
+	}
 
-	return err
+	s.logActivity(ctx, n.Id, n.Author, domain.ActionCreateNote, "", n.Title)
+	return nil
 }
 
 func (s *BN) GetNote(ctx context.Context, idNote, idUser string) (*domain.NoteWithBlocks, error) {
@@ -37,7 +44,7 @@ func (s *BN) GetNote(ctx context.Context, idNote, idUser string) (*domain.NoteWi
 		return nil, domain.ErrNotFound
 	}
 
-	if n.Author != idUser && !alg.IsIn(idUser, n.Editors) && !alg.IsIn(idUser, n.Readers) && !n.IsBlog && !n.IsPublic {
+	if !canRead(ctx, n, idUser) && !n.IsBlog && !n.IsPublic {
 		return nil, domain.ErrUnauthorized
 	}
 
@@ -61,13 +68,23 @@ func (s *BN) GetNote(ctx context.Context, idNote, idUser string) (*domain.NoteWi
 	}, nil
 }
 
-func (s *BN) GetNoteListByUser(ctx context.Context, idUser string) (*domain.NoteParts, error) {
+// GetNoteListByUser return notes of user and his workspaces. Non-empty idWorkspace narrows list to this workspace
+func (s *BN) GetNoteListByUser(ctx context.Context, idUser, idWorkspace string) (*domain.NoteParts, error) {
 	const op = "service.GetNoteListByUser"
 	if err := idValidation(idUser); err != nil {
 		return nil, wrapServiceCheck(op, err)
 	}
+	ws := domain.WorkspaceRolesFromContext(ctx)
+	if idWorkspace != "" {
+		if err := idValidation(idWorkspace); err != nil {
+			return nil, wrapServiceCheck(op, err)
+		}
+		if _, ok := ws[idWorkspace]; !ok {
+			return nil, domain.ErrUnauthorized
+		}
+	}
 
-	return s.nts.GetNoteListByUser(ctx, idUser)
+	return s.nts.GetNoteListByUser(ctx, idUser, ws, idWorkspace)
 }
 
 func (s *BN) GetNoteListByTag(ctx context.Context, idTag, idUser string) (*domain.NoteParts, error) {
@@ -94,12 +111,12 @@ func (s *BN) AddTagToNote(ctx context.Context, idNote, tagId, idUser string) err
 		return wrapServiceCheck(op, err)
 	}
 
-	_, err := s.tx.RunInTx(ctx, func(ctx context.Context) (interface{}, error) {
+	title, err := s.tx.RunInTx(ctx, func(ctx context.Context) (interface{}, error) {
 		n, err := s.nts.Get(ctx, idNote, idUser)
 		if err != nil {
 			return nil, domain.ErrNotFound
 		}
-		if n.Author != idUser && !alg.IsIn(idUser, n.Editors) && !alg.IsIn(idUser, n.Readers) {
+		if !canRead(ctx, n, idUser) {
 			return nil, domain.ErrUnauthorized
 		}
 
@@ -115,10 +132,14 @@ func (s *BN) AddTagToNote(ctx context.Context, idNote, tagId, idUser string) err
 		if err != nil {
 			return nil, domain.ErrNotFound
 		}
-		return nil, nil
+		return tag.Title, nil
 	})
+	if err != nil {
+		return err
+	}
 
-	return err
+	s.logActivity(ctx, idNote, idUser, domain.ActionAddTag, "", title.(string))
+	return nil
 }
 
 func (s *BN) RemoveTagFromNote(ctx context.Context, idNote string, idUser string) error {
@@ -136,14 +157,18 @@ func (s *BN) RemoveTagFromNote(ctx context.Context, idNote string, idUser string
 		if err != nil {
 			return nil, domain.ErrUnauthorized
 		}
-		if n.Author != idUser && !alg.IsIn(idUser, n.Editors) && !alg.IsIn(idUser, n.Readers) {
+		if !canRead(ctx, n, idUser) {
 			return nil, domain.ErrUnauthorized
 		}
 
 		return nil, s.nts.RemoveTagFromNote(ctx, idNote, idUser)
 	})
+	if err != nil {
+		return err
+	}
 
-	return err
+	s.logActivity(ctx, idNote, idUser, domain.ActionRemoveTag, "", "")
+	return nil
 }
 
 func (s *BN) UpdateTitleNote(ctx context.Context, idNote, idUser, nTitle string) error {
@@ -160,30 +185,43 @@ func (s *BN) UpdateTitleNote(ctx context.Context, idNote, idUser, nTitle string)
 		return wrapServiceCheck(op, errors.New("title empty"))
 	}
 
-	_, err := s.tx.RunInTx(ctx, func(ctx context.Context) (interface{}, error) {
+	old, err := s.tx.RunInTx(ctx, func(ctx context.Context) (interface{}, error) {
 		n, err := s.nts.Get(ctx, idNote, idUser)
 		if err != nil {
 			return nil, domain.ErrNotFound
 		}
-		if n.Author != idUser && !alg.IsIn(idUser, n.Editors) {
+		if !canEdit(ctx, n, idUser) {
 			return nil, domain.ErrUnauthorized
 		}
 
-		return nil, s.nts.UpdateTitle(ctx, idNote, nTitle)
+		return n.Title, s.nts.UpdateTitle(ctx, idNote, nTitle)
 	})
+	if err != nil {
+		return err
+	}
 
-	return err
+	s.logActivity(ctx, idNote, idUser, domain.ActionChangeTitle, "", domain.ChangeSummary(old.(string), nTitle))
+	return nil
 }
 
-func (s *BN) Search(ctx context.Context, idUser, prompt string) (<-chan *domain.NotePart, error) {
+func (s *BN) Search(ctx context.Context, idUser, prompt, idWorkspace string) (<-chan *domain.NotePart, error) {
 	const op = "service.Search"
 	if err := idValidation(idUser); err != nil {
 		return nil, wrapServiceCheck(op, err)
 	}
+	ws := domain.WorkspaceRolesFromContext(ctx)
+	if idWorkspace != "" {
+		if err := idValidation(idWorkspace); err != nil {
+			return nil, wrapServiceCheck(op, err)
+		}
+		if _, ok := ws[idWorkspace]; !ok {
+			return nil, domain.ErrUnauthorized
+		}
+	}
 	if stringEmpty(prompt) {
 		return nil, nil
 	}
-	return s.nts.Search(ctx, idUser, prompt), nil
+	return s.nts.Search(ctx, idUser, prompt, ws, idWorkspace), nil
 }
 
 func (s *BN) ShareNote(ctx context.Context, idNote, idUser, idUserToShare, role string) error {
@@ -215,7 +253,7 @@ func (s *BN) ShareNote(ctx context.Context, idNote, idUser, idUserToShare, role
 	_, err := s.tx.RunInTx(ctx, func(ctx context.Context) (interface{}, error) {
 		if n, err := s.nts.Get(ctx, idNote, idUser); err != nil {
 			return nil, domain.ErrNotFound
-		} else if n.Author != idUser && !alg.IsIn(idUser, n.Editors) {
+		} else if !canEdit(ctx, n, idUser) {
 			return nil, domain.ErrUnauthorized
 		} else {
 			if n.Author == idUserToShare {
@@ -229,6 +267,8 @@ func (s *BN) ShareNote(ctx context.Context, idNote, idUser, idUserToShare, role
 	if err != nil {
 		return err
 	}
+
+	s.logActivity(ctx, idNote, idUser, domain.ActionShare, "", idUserToShare+" as "+role)
 	return nil
 }
 
@@ -242,22 +282,26 @@ func (s *BN) AddPublicNote(ctx context.Context, idNote, idUser string) error {
 		return wrapServiceCheck(op, errors.New("bad user id"))
 	}
 
-	_, err := s.tx.RunInTx(ctx, func(ctx context.Context) (interface{}, error) {
+	joined, err := s.tx.RunInTx(ctx, func(ctx context.Context) (interface{}, error) {
 
 		if n, err := s.nts.Get(ctx, idNote, idUser); err != nil {
-			return nil, domain.ErrNotFound
-		} else if n.Author == idUser || alg.IsIn(idUser, n.Editors) || alg.IsIn(idUser, n.Readers) {
-			return nil, nil
+			return false, domain.ErrNotFound
+		} else if canRead(ctx, n, idUser) {
+			return false, nil
 		} else if !n.IsPublic || !n.IsBlog {
-			return nil, domain.ErrUnauthorized
+			return false, domain.ErrUnauthorized
 		}
 
-		return nil, s.nts.ShareNote(ctx, idNote, idUser, domain.ReaderRole)
+		return true, s.nts.ShareNote(ctx, idNote, idUser, domain.ReaderRole)
 	})
 
 	if err != nil {
 		return err
 	}
+
+	if joined.(bool) {
+		s.logActivity(ctx, idNote, idUser, domain.ActionJoinPublic, "", "")
+	}
 	return nil
 }
 
@@ -271,23 +315,25 @@ func (s *BN) PublicNote(ctx context.Context, idNote, idUser string) error {
 		return wrapServiceCheck(op, errors.New("bad user id"))
 	}
 
-	_, err := s.tx.RunInTx(ctx, func(ctx context.Context) (interface{}, error) {
+	res, err := s.tx.RunInTx(ctx, func(ctx context.Context) (interface{}, error) {
 		isPublic := false
 		if n, err := s.nts.Get(ctx, idNote, idUser); err != nil {
 			return nil, domain.ErrNotFound
-		} else if n.Author != idUser && !alg.IsIn(idUser, n.Editors) {
+		} else if !canEdit(ctx, n, idUser) {
 			return nil, domain.ErrUnauthorized
 		} else {
 			if !n.IsPublic {
 				isPublic = true
 			}
 		}
-		return nil, s.nts.UpdatePublic(ctx, idNote, isPublic)
+		return isPublic, s.nts.UpdatePublic(ctx, idNote, isPublic)
 	})
 
 	if err != nil {
 		return err
 	}
+
+	s.logActivity(ctx, idNote, idUser, domain.ActionChangePublic, "", strconv.FormatBool(res.(bool)))
 	return nil
 }
 
@@ -301,23 +347,25 @@ func (s *BN) BlogNote(ctx context.Context, idNote, idUser string) error {
 		return wrapServiceCheck(op, errors.New("bad user id"))
 	}
 
-	_, err := s.tx.RunInTx(ctx, func(ctx context.Context) (interface{}, error) {
+	res, err := s.tx.RunInTx(ctx, func(ctx context.Context) (interface{}, error) {
 		isBlog := false
 		if n, err := s.nts.Get(ctx, idNote, idUser); err != nil {
 			return nil, domain.ErrNotFound
-		} else if n.Author != idUser && !alg.IsIn(idUser, n.Editors) {
+		} else if !canEdit(ctx, n, idUser) {
 			return nil, domain.ErrUnauthorized
 		} else {
 			if !n.IsBlog {
 				isBlog = true
 			}
 		}
-		return nil, s.nts.UpdateBlog(ctx, idNote, isBlog)
+		return isBlog, s.nts.UpdateBlog(ctx, idNote, isBlog)
 	})
 
 	if err != nil {
 		return err
 	}
+
+	s.logActivity(ctx, idNote, idUser, domain.ActionChangeBlog, "", strconv.FormatBool(res.(bool)))
 	return nil
 }
 
@@ -348,7 +396,7 @@ func (s *BN) BlogNote(ctx context.Context, idNote, idUser string) error {
 // 	_, err := s.tx.RunInTx(ctx, func(ctx context.Context) (interface{}, error) {
 // 		if n, err := s.nts.Get(ctx, idNote, idUser); err != nil {
 // 			return nil, domain.ErrNotFound
-// 		} else if n.Author != idUser && !alg.IsIn(idUser, n.Editors) {
+// 		} else if !canEdit(ctx, n, idUser) {
 // 			return nil, domain.ErrUnauthorized
 // 		} else {
 // 			if n.Author == idUserToChange {
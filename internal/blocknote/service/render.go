@@ -0,0 +1,97 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	brzrpc "github.com/autumnterror/breezynotes/api/proto/gen"
+	"github.com/autumnterror/breezynotes/internal/blocknote/pkg/block"
+	"github.com/autumnterror/breezynotes/internal/blocknote/pkg/document"
+)
+
+const (
+	RenderMarkdown = "md"
+	// RenderDocument is BreezyNotes document format, see document package
+	RenderDocument = "json"
+	// maxConvertLen size of text which can be converted to blocks at once
+	maxConvertLen = 1 << 20
+	// maxDocumentLen size of document, it is whole note, for example from archive of export
+	maxDocumentLen = 64 << 20
+)
+
+// RenderNote render note to format. Note isn't read from db, so caller must have access to it
+func (s *BN) RenderNote(ctx context.Context, n *brzrpc.NoteWithBlocks, format string) (string, error) {
+	const op = "service.RenderNote"
+	if n == nil {
+		return "", wrapServiceCheck(op, errors.New("note is empty"))
+	}
+
+	switch format {
+	case RenderMarkdown:
+		return block.NoteMarkdown(ctx, n), nil
+	case RenderDocument:
+		d, err := block.NoteDocument(ctx, n)
+		if err != nil {
+			return "", wrapServiceCheck(op, err)
+		}
+		raw, err := d.Encode()
+		if err != nil {
+			return "", err
+		}
+		return string(raw), nil
+	}
+	return "", wrapServiceCheck(op, errors.New("unknown format"))
+}
+
+// ConvertDocument check document and convert it to note with blocks. Note is not saved
+func (s *BN) ConvertDocument(ctx context.Context, raw string) (*brzrpc.NoteWithBlocks, error) {
+	const op = "service.ConvertDocument"
+	if len(raw) > maxDocumentLen {
+		return nil, wrapServiceCheck(op, errors.New("document is too long"))
+	}
+	d, err := document.Decode([]byte(raw))
+	if err != nil {
+		return nil, wrapServiceCheck(op, err)
+	}
+	n, err := block.NoteFromDocument(ctx, d)
+	if err != nil {
+		return nil, wrapServiceCheck(op, err)
+	}
+	return n, nil
+}
+
+// DocumentSchema return JSON schema of document with registered block types
+func (s *BN) DocumentSchema(ctx context.Context) (string, error) {
+	raw, err := json.MarshalIndent(block.DocumentSchema(), "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(raw), nil
+}
+
+// ConvertMarkdown convert markdown to blocks, see block.FromMarkdown. Blocks are not saved
+func (s *BN) ConvertMarkdown(ctx context.Context, md string) (*brzrpc.Blocks, error) {
+	const op = "service.ConvertMarkdown"
+	if len(md) > maxConvertLen {
+		return nil, wrapServiceCheck(op, errors.New("text is too long"))
+	}
+	return &brzrpc.Blocks{Items: block.FromMarkdown(ctx, md)}, nil
+}
+
+// ConvertHTML convert html to blocks, see block.FromHTML. If idNote is not empty, blocks are inserted into note
+// from pos and returned with ids, otherwise they are not saved
+func (s *BN) ConvertHTML(ctx context.Context, src, idNote, idUser string, pos int) (*brzrpc.Blocks, error) {
+	const op = "service.ConvertHTML"
+	if len(src) > maxConvertLen {
+		return nil, wrapServiceCheck(op, errors.New("text is too long"))
+	}
+	blks := block.FromHTML(ctx, src)
+	if idNote == "" {
+		return &brzrpc.Blocks{Items: blks}, nil
+	}
+	if err := s.insertBlocks(ctx, idNote, idUser, pos, blks); err != nil {
+		return nil, err
+	}
+	return &brzrpc.Blocks{Items: blks}, nil
+}
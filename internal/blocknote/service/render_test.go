@@ -0,0 +1,60 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/autumnterror/breezynotes/internal/blocknote/pkg/block"
+	"github.com/autumnterror/breezynotes/internal/blocknote/pkg/block/default/textblock"
+	"github.com/autumnterror/utils_go/pkg/utils/uid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConvertHTML(t *testing.T) {
+	block.RegisterBlock("text", &textblock.Driver{})
+	s := &BN{}
+	ctx := context.Background()
+
+	res, err := s.ConvertHTML(ctx, "<p>one</p><script>alert(1)</script><p>two</p>", "", "", 0)
+	require.NoError(t, err)
+	require.Len(t, res.GetItems(), 2)
+	assert.Empty(t, res.GetItems()[0].GetId(), "converted blocks are not saved")
+
+	_, err = s.ConvertHTML(ctx, "<p>one</p>", uid.New(), uid.New(), -1)
+	assert.ErrorIs(t, err, ErrBadServiceCheck)
+	_, err = s.ConvertHTML(ctx, "<p>one</p>", "bad", uid.New(), 0)
+	assert.ErrorIs(t, err, ErrBadServiceCheck)
+	_, err = s.ConvertHTML(ctx, strings.Repeat("a", maxConvertLen+1), "", "", 0)
+	assert.ErrorIs(t, err, ErrBadServiceCheck)
+
+	res, err = s.ConvertHTML(ctx, "<script>alert(1)</script>", uid.New(), uid.New(), 0)
+	require.NoError(t, err, "nothing to insert")
+	assert.Empty(t, res.GetItems())
+}
+
+func TestDocument(t *testing.T) {
+	block.RegisterBlock("text", &textblock.Driver{})
+	s := &BN{}
+	ctx := context.Background()
+
+	n, err := s.ConvertDocument(ctx, `{"format":"breezynotes","version":1,"note":{"title":"t","blocks":[
+		{"type":"text","data":{"text":[{"text":"hi","marks":["bold"]}]}}
+	]}}`)
+	require.NoError(t, err)
+	require.Len(t, n.GetBlocks(), 1)
+
+	raw, err := s.RenderNote(ctx, n, RenderDocument)
+	require.NoError(t, err)
+	assert.Contains(t, raw, `"marks": [`)
+
+	_, err = s.ConvertDocument(ctx, `{"format":"breezynotes","version":1,"note":{"blocks":[{"type":"text","data":{}}]}}`)
+	assert.ErrorIs(t, err, ErrBadServiceCheck)
+	_, err = s.ConvertDocument(ctx, `{}`)
+	assert.ErrorIs(t, err, ErrBadServiceCheck)
+
+	schema, err := s.DocumentSchema(ctx)
+	require.NoError(t, err)
+	assert.Contains(t, schema, `"text"`)
+}
@@ -2,8 +2,11 @@ package service
 
 import (
 	"context"
+	"github.com/autumnterror/breezynotes/internal/blocknote/repository/activity"
 	"github.com/autumnterror/breezynotes/internal/blocknote/repository/blocks"
+	"github.com/autumnterror/breezynotes/internal/blocknote/repository/comments"
 	"github.com/autumnterror/breezynotes/internal/blocknote/repository/notes"
+	"github.com/autumnterror/breezynotes/internal/blocknote/repository/settings"
 	"github.com/autumnterror/breezynotes/internal/blocknote/repository/tags"
 
 	"github.com/autumnterror/breezynotes/internal/blocknote/config"
@@ -14,6 +17,9 @@ type BN struct {
 	nts notes.Repo
 	tgs tags.Repo
 	blk blocks.Repo
+	stg settings.Repo
+	cmt comments.Repo
+	act activity.Repo
 	cfg *config.Config
 }
 
@@ -23,6 +29,9 @@ func NewNoteService(
 	nts notes.Repo,
 	blk blocks.Repo,
 	tgs tags.Repo,
+	stg settings.Repo,
+	cmt comments.Repo,
+	act activity.Repo,
 ) *BN {
 	return &BN{
 		tx:  tx,
@@ -30,6 +39,9 @@ func NewNoteService(
 		cfg: cfg,
 		blk: blk,
 		tgs: tgs,
+		stg: stg,
+		cmt: cmt,
+		act: act,
 	}
 }
 
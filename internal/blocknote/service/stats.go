@@ -0,0 +1,29 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/autumnterror/breezynotes/internal/blocknote/domain"
+)
+
+// UserStats return how many notes and blocks user has and their size
+func (s *BN) UserStats(ctx context.Context, idUser string) (*domain.UserStats, error) {
+	const op = "service.UserStats"
+	if err := idValidation(idUser); err != nil {
+		return nil, wrapServiceCheck(op, err)
+	}
+
+	res, err := s.tx.RunInTx(ctx, func(ctx context.Context) (interface{}, error) {
+		return s.nts.Stats(ctx, idUser)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	st, ok := res.(*domain.UserStats)
+	if !ok {
+		return nil, wrapServiceCheck(op, errors.New("response type mismatch"))
+	}
+	return st, nil
+}
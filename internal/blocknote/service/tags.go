@@ -15,12 +15,34 @@ import (
 //	return s.tgs.Get(ctx, id)
 //}
 
-func (s *BN) GetAllByIdTag(ctx context.Context, id string) (*domain.Tags, error) {
+// GetAllByIdTag return personal tags of user and tags of his workspaces. Non-empty idWorkspace narrows list to this workspace
+func (s *BN) GetAllByIdTag(ctx context.Context, id, idWorkspace string) (*domain.Tags, error) {
 	const op = "service.GetAllByIdTag"
 	if err := idValidation(id); err != nil {
 		return nil, wrapServiceCheck(op, err)
 	}
-	return s.tgs.GetAllById(ctx, id)
+	ws := domain.WorkspaceRolesFromContext(ctx)
+	if idWorkspace != "" {
+		if err := idValidation(idWorkspace); err != nil {
+			return nil, wrapServiceCheck(op, err)
+		}
+		if _, ok := ws[idWorkspace]; !ok {
+			return nil, domain.ErrUnauthorized
+		}
+		return s.tgs.GetAllByWorkspaces(ctx, []string{idWorkspace})
+	}
+
+	tgs, err := s.tgs.GetAllById(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	wTgs, err := s.tgs.GetAllByWorkspaces(ctx, ws.Ids())
+	if err != nil {
+		return nil, err
+	}
+	tgs.Tgs = append(tgs.Tgs, wTgs.Tgs...)
+
+	return tgs, nil
 }
 
 func (s *BN) CreateTag(ctx context.Context, t *domain.Tag) error {
@@ -28,6 +50,9 @@ func (s *BN) CreateTag(ctx context.Context, t *domain.Tag) error {
 	if err := tagValidation(t); err != nil {
 		return wrapServiceCheck(op, err)
 	}
+	if !canWriteWorkspace(ctx, t.WorkspaceId) {
+		return domain.ErrUnauthorized
+	}
 
 	_, err := s.tx.RunInTx(ctx, func(ctx context.Context) (interface{}, error) {
 		return nil, s.tgs.Create(ctx, t)
@@ -50,7 +75,7 @@ func (s *BN) DeleteTag(ctx context.Context, idTag, idUser string) error {
 		if err != nil {
 			return nil, domain.ErrNotFound
 		}
-		if tag.UserId != idUser {
+		if !canEditTag(ctx, tag, idUser) {
 			return nil, domain.ErrUnauthorized
 		}
 		return nil, s.tgs.Delete(ctx, idTag)
@@ -102,7 +127,7 @@ func (s *BN) UpdateTitleTag(ctx context.Context, idTag, idUser, nTitle string) e
 		if err != nil {
 			return nil, domain.ErrNotFound
 		}
-		if tag.UserId != idUser {
+		if !canEditTag(ctx, tag, idUser) {
 			return nil, domain.ErrUnauthorized
 		}
 		return nil, s.tgs.UpdateTitle(ctx, idTag, nTitle)
@@ -128,7 +153,7 @@ func (s *BN) UpdateColorTag(ctx context.Context, idTag, idUser, nColor string) e
 		if err != nil {
 			return nil, domain.ErrNotFound
 		}
-		if tag.UserId != idUser {
+		if !canEditTag(ctx, tag, idUser) {
 			return nil, domain.ErrUnauthorized
 		}
 		return nil, s.tgs.UpdateColor(ctx, idTag, nColor)
@@ -154,7 +179,7 @@ func (s *BN) UpdateEmojiTag(ctx context.Context, idTag, idUser, nEmoji string) e
 		if err != nil {
 			return nil, domain.ErrNotFound
 		}
-		if tag.UserId != idUser {
+		if !canEditTag(ctx, tag, idUser) {
 			return nil, domain.ErrUnauthorized
 		}
 		return nil, s.tgs.UpdateEmoji(ctx, idTag, nEmoji)
@@ -178,7 +203,7 @@ func (s *BN) UpdatePinned(ctx context.Context, idTag, idUser string) error {
 		if err != nil {
 			return nil, domain.ErrNotFound
 		}
-		if tag.UserId != idUser {
+		if !canEditTag(ctx, tag, idUser) {
 			return nil, domain.ErrUnauthorized
 		}
 		if !tag.IsPinned {
@@ -3,11 +3,16 @@ package service
 import (
 	"context"
 	"errors"
+	"time"
 
 	"github.com/autumnterror/breezynotes/internal/blocknote/domain"
+	"github.com/autumnterror/utils_go/pkg/log"
 	"github.com/autumnterror/utils_go/pkg/utils/alg"
 )
 
+// maxTrashRetentionDays upper bound of retention which user can set
+const maxTrashRetentionDays = 365
+
 func (s *BN) CleanTrash(ctx context.Context, uid string) error {
 	const op = "service.CleanTrash"
 	if err := idValidation(uid); err != nil {
@@ -23,14 +28,24 @@ func (s *BN) CleanTrash(ctx context.Context, uid string) error {
 			return nil, nil
 		}
 
-		var ids []string
+		var ids, idNotes []string
 		for _, n := range nts.Nts {
 			ids = append(ids, n.Blocks...)
+			idNotes = append(idNotes, n.Id)
 		}
 
 		if err := s.blk.DeleteMany(ctx, ids); err != nil {
 			return nil, err
 		}
+		if err := s.blk.CleanTrashByNotes(ctx, idNotes); err != nil {
+			return nil, err
+		}
+		if err := s.cmt.DeleteByNotes(ctx, idNotes); err != nil {
+			return nil, err
+		}
+		if err := s.act.DeleteByNotes(ctx, idNotes); err != nil {
+			return nil, err
+		}
 
 		return nil, s.nts.CleanTrash(ctx, uid)
 	})
@@ -51,11 +66,21 @@ func (s *BN) GetNotesFromTrash(ctx context.Context, uid string) (*domain.NotePar
 		return nil, err
 	}
 
-	if resS, ok := res.(*domain.NoteParts); !ok {
+	resS, ok := res.(*domain.NoteParts)
+	if !ok {
 		return nil, wrapServiceCheck(op, errors.New("response type mismatch"))
-	} else {
-		return resS, nil
 	}
+
+	retention, err := s.trashRetention(ctx, uid)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now().UTC()
+	for _, np := range resS.Ntps {
+		np.DaysLeft = trashDaysLeft(np.DeletedAt, retention, now)
+	}
+
+	return resS, nil
 }
 func (s *BN) ToTrash(ctx context.Context, idNote, idUser string) error {
 	const op = "service.ToTrash"
@@ -65,7 +90,7 @@ func (s *BN) ToTrash(ctx context.Context, idNote, idUser string) error {
 	if err := idValidation(idUser); err != nil {
 		return wrapServiceCheck(op, err)
 	}
-	_, err := s.tx.RunInTx(ctx, func(ctx context.Context) (interface{}, error) {
+	action, err := s.tx.RunInTx(ctx, func(ctx context.Context) (interface{}, error) {
 		n, err := s.nts.Get(ctx, idNote, idUser)
 		if err != nil {
 			return nil, domain.ErrNotFound
@@ -73,17 +98,25 @@ func (s *BN) ToTrash(ctx context.Context, idNote, idUser string) error {
 
 		switch {
 		case n.Author == idUser:
-			return nil, s.nts.ToTrash(ctx, idNote)
+			return domain.ActionToTrash, s.nts.ToTrash(ctx, idNote)
 		case alg.IsIn(idUser, n.Editors) || alg.IsIn(idUser, n.Readers):
-			return nil, s.nts.DeleteRole(ctx, idNote, idUser)
+			return domain.ActionUnshare, s.nts.DeleteRole(ctx, idNote, idUser)
+		case canEdit(ctx, n, idUser):
+			// workspace editors can trash workspace notes
+			return domain.ActionToTrash, s.nts.ToTrash(ctx, idNote)
 		default:
 			return nil, domain.ErrUnauthorized
 		}
 	})
+	if err != nil {
+		return err
+	}
 
-	return err
+	s.logActivity(ctx, idNote, idUser, action.(string), "", "")
+	return nil
 }
 
+// ToTrashAll is not recorded in activity: it is part of user removal, not change of single note
 func (s *BN) ToTrashAll(ctx context.Context, idUser string) error {
 	const op = "service.ToTrash"
 	if err := idValidation(idUser); err != nil {
@@ -110,14 +143,18 @@ func (s *BN) FromTrash(ctx context.Context, idNote, idUser string) error {
 		if err != nil {
 			return nil, domain.ErrNotFound
 		}
-		if n.Author != idUser && !alg.IsIn(idUser, n.Editors) {
+		if !canEdit(ctx, n, idUser) {
 			return nil, domain.ErrUnauthorized
 		}
 
 		return nil, s.nts.FromTrash(ctx, idNote)
 	})
+	if err != nil {
+		return err
+	}
 
-	return err
+	s.logActivity(ctx, idNote, idUser, domain.ActionFromTrash, "", "")
+	return nil
 }
 
 func (s *BN) FindOnTrash(ctx context.Context, idNote, idUser string) (*domain.NoteWithBlocks, error) {
@@ -135,7 +172,7 @@ func (s *BN) FindOnTrash(ctx context.Context, idNote, idUser string) (*domain.No
 		return nil, domain.ErrNotFound
 	}
 
-	if n.Author != idUser && !alg.IsIn(idUser, n.Editors) && !alg.IsIn(idUser, n.Readers) && !n.IsBlog && !n.IsPublic {
+	if !canRead(ctx, n, idUser) && !n.IsBlog && !n.IsPublic {
 		return nil, domain.ErrUnauthorized
 	}
 
@@ -158,3 +195,181 @@ func (s *BN) FindOnTrash(ctx context.Context, idNote, idUser string) (*domain.No
 		IsPublic:  n.IsPublic,
 	}, nil
 }
+
+// PurgeFromTrash permanently rm note from trash with its blocks
+func (s *BN) PurgeFromTrash(ctx context.Context, idNote, idUser string) error {
+	const op = "service.PurgeFromTrash"
+
+	if err := idValidation(idNote); err != nil {
+		return wrapServiceCheck(op, err)
+	}
+	if err := idValidation(idUser); err != nil {
+		return wrapServiceCheck(op, err)
+	}
+	_, err := s.tx.RunInTx(ctx, func(ctx context.Context) (interface{}, error) {
+		n, err := s.nts.FindOnTrash(ctx, idNote, idUser)
+		if err != nil {
+			return nil, domain.ErrNotFound
+		}
+		if !canEdit(ctx, n, idUser) {
+			return nil, domain.ErrUnauthorized
+		}
+
+		if err := s.blk.DeleteMany(ctx, n.Blocks); err != nil {
+			return nil, err
+		}
+		if err := s.blk.CleanTrashByNotes(ctx, []string{idNote}); err != nil {
+			return nil, err
+		}
+		if err := s.cmt.DeleteByNotes(ctx, []string{idNote}); err != nil {
+			return nil, err
+		}
+		if err := s.act.DeleteByNotes(ctx, []string{idNote}); err != nil {
+			return nil, err
+		}
+
+		return nil, s.nts.DeleteFromTrash(ctx, []string{idNote})
+	})
+
+	return err
+}
+
+// SetTrashRetention days == 0 reset retention of user to global from config
+func (s *BN) SetTrashRetention(ctx context.Context, idUser string, days int) error {
+	const op = "service.SetTrashRetention"
+
+	if err := idValidation(idUser); err != nil {
+		return wrapServiceCheck(op, err)
+	}
+	if days < 0 || days > maxTrashRetentionDays {
+		return wrapServiceCheck(op, errors.New("retention must be from 0 to 365 days"))
+	}
+
+	return s.stg.SetTrashRetention(ctx, idUser, days)
+}
+
+// GetTrashRetention return days set by user, 0 if he uses retention of server
+func (s *BN) GetTrashRetention(ctx context.Context, idUser string) (int, error) {
+	const op = "service.GetTrashRetention"
+
+	if err := idValidation(idUser); err != nil {
+		return 0, wrapServiceCheck(op, err)
+	}
+
+	return s.stg.GetTrashRetention(ctx, idUser)
+}
+
+// PurgeExpiredTrash permanently rm notes which stay in trash longer than retention of their author
+// and deleted blocks older than global retention
+func (s *BN) PurgeExpiredTrash(ctx context.Context, now time.Time) error {
+	const op = "service.PurgeExpiredTrash"
+
+	if err := s.nts.StampTrash(ctx, now.Unix()); err != nil {
+		return err
+	}
+	if err := s.blk.CleanTrashBefore(ctx, now.Add(-s.cfg.TrashRetention).Unix()); err != nil {
+		return err
+	}
+
+	custom, err := s.stg.GetAllTrashRetention(ctx)
+	if err != nil {
+		return err
+	}
+
+	except := make([]string, 0, len(custom))
+	for idUser, days := range custom {
+		except = append(except, idUser)
+		if err := s.purgeExpired(ctx, now.Add(-retentionFromDays(days)).Unix(), idUser, nil); err != nil {
+			log.Error(op, idUser, err)
+		}
+	}
+
+	return s.purgeExpired(ctx, now.Add(-s.cfg.TrashRetention).Unix(), "", except)
+}
+
+func (s *BN) purgeExpired(ctx context.Context, before int64, idUser string, except []string) error {
+	_, err := s.tx.RunInTx(ctx, func(ctx context.Context) (interface{}, error) {
+		nts, err := s.nts.GetExpiredFromTrash(ctx, before, idUser, except)
+		if err != nil {
+			return nil, err
+		}
+		if len(nts.Nts) == 0 {
+			return nil, nil
+		}
+
+		var ids, blks []string
+		for _, n := range nts.Nts {
+			ids = append(ids, n.Id)
+			blks = append(blks, n.Blocks...)
+		}
+
+		if err := s.blk.DeleteMany(ctx, blks); err != nil {
+			return nil, err
+		}
+		if err := s.blk.CleanTrashByNotes(ctx, ids); err != nil {
+			return nil, err
+		}
+		if err := s.cmt.DeleteByNotes(ctx, ids); err != nil {
+			return nil, err
+		}
+		if err := s.act.DeleteByNotes(ctx, ids); err != nil {
+			return nil, err
+		}
+
+		return nil, s.nts.DeleteFromTrash(ctx, ids)
+	})
+
+	return err
+}
+
+// RunPurger purge expired notes from trash and old activity every cfg.TrashPurgeInterval until ctx is done
+func (s *BN) RunPurger(ctx context.Context) {
+	const op = "service.RunPurger"
+
+	t := time.NewTicker(s.cfg.TrashPurgeInterval)
+	defer t.Stop()
+
+	for {
+		if err := s.PurgeExpiredTrash(ctx, time.Now().UTC()); err != nil {
+			log.Error(op, "purge trash", err)
+		}
+		if err := s.PurgeExpiredActivity(ctx, time.Now().UTC()); err != nil {
+			log.Error(op, "purge activity", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+		}
+	}
+}
+
+func (s *BN) trashRetention(ctx context.Context, idUser string) (time.Duration, error) {
+	days, err := s.stg.GetTrashRetention(ctx, idUser)
+	if err != nil {
+		return 0, err
+	}
+	if days == 0 {
+		return s.cfg.TrashRetention, nil
+	}
+	return retentionFromDays(days), nil
+}
+
+func retentionFromDays(days int) time.Duration {
+	return time.Duration(days) * 24 * time.Hour
+}
+
+// trashDaysLeft full or partial days before note will be purged. Notes without deletedAt
+// are stamped on next purge, so they have whole retention
+func trashDaysLeft(deletedAt int64, retention time.Duration, now time.Time) int32 {
+	if deletedAt == 0 {
+		deletedAt = now.Unix()
+	}
+	left := time.Unix(deletedAt, 0).Add(retention).Sub(now)
+	if left <= 0 {
+		return 0
+	}
+	day := 24 * time.Hour
+	return int32((left + day - 1) / day)
+}
@@ -0,0 +1,20 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTrashDaysLeft(t *testing.T) {
+	now := time.Date(2025, 1, 31, 12, 0, 0, 0, time.UTC)
+	retention := 30 * 24 * time.Hour
+
+	assert.Equal(t, int32(30), trashDaysLeft(now.Unix(), retention, now))
+	assert.Equal(t, int32(30), trashDaysLeft(0, retention, now))
+	assert.Equal(t, int32(1), trashDaysLeft(now.Add(-29*24*time.Hour-time.Hour).Unix(), retention, now))
+	assert.Equal(t, int32(0), trashDaysLeft(now.Add(-retention).Unix(), retention, now))
+	assert.Equal(t, int32(0), trashDaysLeft(now.Add(-40*24*time.Hour).Unix(), retention, now))
+	assert.Equal(t, int32(7), trashDaysLeft(now.Unix(), retentionFromDays(7), now))
+}
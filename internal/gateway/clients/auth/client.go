@@ -17,7 +17,10 @@ import (
 )
 
 type Client struct {
-	API brzrpc.AuthServiceClient
+	API      brzrpc.AuthServiceClient
+	Admin    brzrpc.AdminServiceClient
+	Deletion brzrpc.AccountDeletionServiceClient
+	Verifier *Verifier
 }
 
 func New(
@@ -54,7 +57,18 @@ func New(
 		log.Success(op, "CONNECT!!")
 	}
 
+	api := brzrpc.NewAuthServiceClient(cc)
+
+	// connection is lazy, verifier falls back to auth while redis service is down
+	rc, err := grpc.NewClient(cfg.AddrRedis, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, format.Error(op, err)
+	}
+
 	return &Client{
-		API: brzrpc.NewAuthServiceClient(cc),
+		API:      api,
+		Admin:    brzrpc.NewAdminServiceClient(cc),
+		Deletion: brzrpc.NewAccountDeletionServiceClient(cc),
+		Verifier: NewVerifier(api, brzrpc.NewRedisServiceClient(rc), cfg.JWKSRefresh),
 	}, nil
 }
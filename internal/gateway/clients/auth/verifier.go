@@ -0,0 +1,217 @@
+package auth
+
+import (
+	"context"
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	brzrpc "github.com/autumnterror/breezynotes/api/proto/gen"
+	"github.com/autumnterror/utils_go/pkg/utils/format"
+	"github.com/golang-jwt/jwt/v5"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+const (
+	tokenTypeAccess = "ACCESS"
+	// minRefetch limit requests of JWKS from auth when tokens with unknown kid come
+	minRefetch = 10 * time.Second
+)
+
+var (
+	// ErrUnknownKey token can't be checked locally (e.g. HS256 token of shared key or redis service is down),
+	// it must be checked by auth
+	ErrUnknownKey = errors.New("unknown signing key")
+	ErrBadToken   = errors.New("bad access token")
+)
+
+type publicKey struct {
+	method jwt.SigningMethod
+	key    crypto.PublicKey
+}
+
+// Verifier check access tokens locally by JWKS of auth, so gateway does not call auth on every request.
+// Families revoked by auth are denied by list in redis service
+type Verifier struct {
+	api     brzrpc.AuthServiceClient
+	revoked brzrpc.RedisServiceClient
+	ttl     time.Duration
+
+	mu        sync.RWMutex
+	jwks      *brzrpc.JWKS
+	keys      map[string]publicKey
+	fetchedAt time.Time
+	triedAt   time.Time
+}
+
+func NewVerifier(api brzrpc.AuthServiceClient, revoked brzrpc.RedisServiceClient, ttl time.Duration) *Verifier {
+	return &Verifier{api: api, revoked: revoked, ttl: ttl, keys: map[string]publicKey{}}
+}
+
+// AccessId return id of user from valid access token
+func (v *Verifier) AccessId(ctx context.Context, token string) (string, error) {
+	const op = "grpc.auth.Verifier.AccessId"
+
+	parsed, err := jwt.Parse(token, func(t *jwt.Token) (interface{}, error) {
+		kid, _ := t.Header["kid"].(string)
+		if kid == "" {
+			return nil, ErrUnknownKey
+		}
+		k, err := v.key(ctx, kid)
+		if err != nil {
+			return nil, err
+		}
+		if k.method.Alg() != t.Method.Alg() {
+			return nil, ErrBadToken
+		}
+		return k.key, nil
+	})
+	if err != nil {
+		if errors.Is(err, ErrUnknownKey) {
+			return "", ErrUnknownKey
+		}
+		return "", format.Error(op, fmt.Errorf("%w: %v", ErrBadToken, err))
+	}
+
+	claims, ok := parsed.Claims.(jwt.MapClaims)
+	if !ok || !parsed.Valid {
+		return "", format.Error(op, ErrBadToken)
+	}
+	if tp, _ := claims["type"].(string); tp != tokenTypeAccess {
+		return "", format.Error(op, ErrBadToken)
+	}
+	id, _ := claims["id"].(string)
+	if id == "" {
+		return "", format.Error(op, ErrBadToken)
+	}
+	fid, _ := claims["fid"].(string)
+	if fid == "" {
+		return "", format.Error(op, ErrBadToken)
+	}
+
+	// redis service is not available: auth checks family itself
+	revoked, err := v.revoked.IsFamilyRevoked(ctx, &brzrpc.String{Value: fid})
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", ErrUnknownKey, err)
+	}
+	if revoked.GetOk() {
+		return "", format.Error(op, fmt.Errorf("%w: family is revoked", ErrBadToken))
+	}
+
+	return id, nil
+}
+
+// JWKS return cached public keys of auth
+func (v *Verifier) JWKS(ctx context.Context) (*brzrpc.JWKS, error) {
+	v.mu.RLock()
+	jwks, fresh := v.jwks, time.Since(v.fetchedAt) < v.ttl
+	v.mu.RUnlock()
+	if jwks != nil && fresh {
+		return jwks, nil
+	}
+
+	if err := v.refresh(ctx, v.ttl); err != nil {
+		return nil, err
+	}
+
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+	return v.jwks, nil
+}
+
+// key return public key by kid. Unknown kid can be new key after rotation, so JWKS is fetched again
+func (v *Verifier) key(ctx context.Context, kid string) (publicKey, error) {
+	v.mu.RLock()
+	k, ok := v.keys[kid]
+	fresh := time.Since(v.fetchedAt) < v.ttl
+	v.mu.RUnlock()
+	if ok && fresh {
+		return k, nil
+	}
+
+	after := v.ttl
+	if !ok {
+		after = minRefetch
+	}
+	if err := v.refresh(ctx, after); err != nil {
+		// auth is not available: keys from cache are still good, else token is checked by auth later
+		if ok {
+			return k, nil
+		}
+		return publicKey{}, fmt.Errorf("%w: %v", ErrUnknownKey, err)
+	}
+
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+	if k, ok := v.keys[kid]; ok {
+		return k, nil
+	}
+	return publicKey{}, ErrUnknownKey
+}
+
+// refresh fetch JWKS from auth if last fetch is older than after. Auth is asked not often than once per minRefetch
+func (v *Verifier) refresh(ctx context.Context, after time.Duration) error {
+	const op = "grpc.auth.Verifier.refresh"
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	if v.jwks != nil && time.Since(v.fetchedAt) < after {
+		return nil
+	}
+	if time.Since(v.triedAt) < minRefetch {
+		if v.jwks == nil {
+			return format.Error(op, errors.New("jwks is not fetched yet"))
+		}
+		return nil
+	}
+	v.triedAt = time.Now()
+
+	jwks, err := v.api.GetJWKS(ctx, &emptypb.Empty{})
+	if err != nil {
+		return format.Error(op, err)
+	}
+
+	keys := make(map[string]publicKey, len(jwks.GetKeys()))
+	for _, jwk := range jwks.GetKeys() {
+		k, err := parseJWK(jwk)
+		if err != nil {
+			return format.Error(op, err)
+		}
+		keys[jwk.GetKid()] = k
+	}
+
+	v.jwks, v.keys, v.fetchedAt = jwks, keys, time.Now()
+	return nil
+}
+
+func parseJWK(jwk *brzrpc.JWK) (publicKey, error) {
+	switch {
+	case jwk.GetKty() == "OKP" && jwk.GetCrv() == "Ed25519":
+		x, err := base64.RawURLEncoding.DecodeString(jwk.GetX())
+		if err != nil || len(x) != ed25519.PublicKeySize {
+			return publicKey{}, fmt.Errorf("bad x of key %s", jwk.GetKid())
+		}
+		return publicKey{method: jwt.SigningMethodEdDSA, key: ed25519.PublicKey(x)}, nil
+	case jwk.GetKty() == "RSA":
+		n, err := base64.RawURLEncoding.DecodeString(jwk.GetN())
+		if err != nil {
+			return publicKey{}, fmt.Errorf("bad n of key %s", jwk.GetKid())
+		}
+		e, err := base64.RawURLEncoding.DecodeString(jwk.GetE())
+		if err != nil {
+			return publicKey{}, fmt.Errorf("bad e of key %s", jwk.GetKid())
+		}
+		return publicKey{method: jwt.SigningMethodRS256, key: &rsa.PublicKey{
+			N: new(big.Int).SetBytes(n),
+			E: int(new(big.Int).SetBytes(e).Int64()),
+		}}, nil
+	default:
+		return publicKey{}, fmt.Errorf("unsupported key %s of type %s", jwk.GetKid(), jwk.GetKty())
+	}
+}
@@ -4,12 +4,20 @@ import (
 	"errors"
 	"log"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/autumnterror/utils_go/pkg/utils/format"
 	"github.com/spf13/viper"
 )
 
+const (
+	defaultJWKSRefresh     = 10 * time.Minute
+	defaultSearchRateLimit = 30
+	defaultDeletionPeriod  = time.Minute
+	defaultExportTtl       = 24 * time.Hour
+)
+
 type Config struct {
 	AddrAuth        string
 	AddrBlockNote   string
@@ -20,6 +28,16 @@ type Config struct {
 	Port            int
 	RateLimit       int
 	RateLimitWindow time.Duration
+	// SearchRateLimit searches of users per minute for one user, directory must not be dumped by script
+	SearchRateLimit int
+	// JWKSRefresh how long public keys of auth are cached to verify access tokens locally
+	JWKSRefresh time.Duration
+	// PublicUrl of frontend, browser is redirected to it after SSO login
+	PublicUrl string
+	// DeletionPeriod how often worker looks for accounts to delete
+	DeletionPeriod time.Duration
+	// ExportTtl how long archive of account export can be downloaded
+	ExportTtl time.Duration
 }
 
 // MustSetup return config and panic if error
@@ -53,6 +71,11 @@ func setup() (*Config, error) {
 		Mode            string        `mapstructure:"mode"`
 		RateLimit       int           `mapstructure:"rate_limit"`
 		RateLimitWindow time.Duration `mapstructure:"rate_limit_window"`
+		SearchRateLimit int           `mapstructure:"search_rate_limit"`
+		JWKSRefresh     time.Duration `mapstructure:"jwks_refresh"`
+		PublicUrl       string        `mapstructure:"public_url"`
+		DeletionPeriod  time.Duration `mapstructure:"deletion_period"`
+		ExportTtl       time.Duration `mapstructure:"export_ttl"`
 	}
 
 	if err := viper.ReadInConfig(); err != nil {
@@ -62,6 +85,19 @@ func setup() (*Config, error) {
 		return nil, format.Error(op, err)
 	}
 
+	if cfg.JWKSRefresh <= 0 {
+		cfg.JWKSRefresh = defaultJWKSRefresh
+	}
+	if cfg.SearchRateLimit <= 0 {
+		cfg.SearchRateLimit = defaultSearchRateLimit
+	}
+	if cfg.DeletionPeriod <= 0 {
+		cfg.DeletionPeriod = defaultDeletionPeriod
+	}
+	if cfg.ExportTtl <= 0 {
+		cfg.ExportTtl = defaultExportTtl
+	}
+
 	if cfg.Mode == "DEV" {
 		log.Println(format.Struct(cfg))
 	}
@@ -76,5 +112,10 @@ func setup() (*Config, error) {
 		Port:            cfg.Port,
 		RateLimit:       cfg.RateLimit,
 		RateLimitWindow: cfg.RateLimitWindow,
+		SearchRateLimit: cfg.SearchRateLimit,
+		JWKSRefresh:     cfg.JWKSRefresh,
+		PublicUrl:       strings.TrimSuffix(cfg.PublicUrl, "/"),
+		DeletionPeriod:  cfg.DeletionPeriod,
+		ExportTtl:       cfg.ExportTtl,
 	}, nil
 }
@@ -0,0 +1,37 @@
+package domain
+
+import brzrpc "github.com/autumnterror/breezynotes/api/proto/gen"
+
+type Activity struct {
+	Id        string `json:"id"`
+	NoteId    string `json:"note_id"`
+	Actor     string `json:"actor"`
+	Action    string `json:"action"`
+	BlockId   string `json:"block_id,omitempty"`
+	Summary   string `json:"summary"`
+	CreatedAt int64  `json:"created_at"`
+}
+
+type Activities struct {
+	Items []Activity `json:"items"`
+	Total int64      `json:"total"`
+}
+
+func ToActivities(a *brzrpc.Activities) Activities {
+	res := Activities{
+		Items: []Activity{},
+		Total: a.GetTotal(),
+	}
+	for _, act := range a.GetItems() {
+		res.Items = append(res.Items, Activity{
+			Id:        act.GetId(),
+			NoteId:    act.GetNoteId(),
+			Actor:     act.GetActor(),
+			Action:    act.GetAction(),
+			BlockId:   act.GetBlockId(),
+			Summary:   act.GetSummary(),
+			CreatedAt: act.GetCreatedAt(),
+		})
+	}
+	return res
+}
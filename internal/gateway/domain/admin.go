@@ -0,0 +1,84 @@
+package domain
+
+import brzrpc "github.com/autumnterror/breezynotes/api/proto/gen"
+
+type AdminUsers struct {
+	Users []*User `json:"users"`
+	// Total count of found users, Users is one page of them
+	Total int `json:"total"`
+}
+
+// UserStats StorageBytes is size of notes and blocks in db, files are not counted
+type UserStats struct {
+	Notes        int64 `json:"notes"`
+	TrashNotes   int64 `json:"trash_notes"`
+	Blocks       int64 `json:"blocks"`
+	StorageBytes int64 `json:"storage_bytes"`
+}
+
+type AdminUser struct {
+	User  *User      `json:"user"`
+	Stats *UserStats `json:"stats"`
+}
+
+type AdminUserRequest struct {
+	Id string `json:"id"`
+}
+type SetUserDisabledRequest struct {
+	Id       string `json:"id"`
+	Disabled bool   `json:"disabled"`
+}
+type SetUserRoleRequest struct {
+	Id   string `json:"id"`
+	Role string `json:"role"`
+}
+type UnlockLoginRequest struct {
+	// Identifier login or email
+	Identifier string `json:"identifier"`
+}
+
+type AuditEvent struct {
+	Id        string `json:"id"`
+	UserId    string `json:"user_id"`
+	ActorId   string `json:"actor_id"`
+	Action    string `json:"action"`
+	Target    string `json:"target"`
+	Ip        string `json:"ip"`
+	CreatedAt int64  `json:"created_at"`
+}
+
+func ToAdminUsers(us *brzrpc.AdminUsers) AdminUsers {
+	res := AdminUsers{Users: make([]*User, 0, len(us.GetUsers())), Total: int(us.GetTotal())}
+	for _, u := range us.GetUsers() {
+		res.Users = append(res.Users, UserFromRpc(u))
+	}
+	return res
+}
+
+func UserStatsFromRpc(s *brzrpc.UserStats) *UserStats {
+	if s == nil {
+		return nil
+	}
+	return &UserStats{
+		Notes:        s.GetNotes(),
+		TrashNotes:   s.GetTrashNotes(),
+		Blocks:       s.GetBlocks(),
+		StorageBytes: s.GetStorageBytes(),
+	}
+}
+
+func ToAuditEvents(es *brzrpc.AuditEvents) []AuditEvent {
+	res := make([]AuditEvent, 0, len(es.GetEvents()))
+	for _, e := range es.GetEvents() {
+		res = append(res, AuditEvent{
+			Id:        e.GetId(),
+			UserId:    e.GetUserId(),
+			ActorId:   e.GetActorId(),
+			Action:    e.GetAction(),
+			Target:    e.GetTarget(),
+			Ip:        e.GetIp(),
+			CreatedAt: e.GetCreatedAt(),
+		})
+	}
+	return res
+}
@@ -50,6 +50,13 @@ type CreateBlockRequest struct {
 	Type   string         `json:"type"`
 	Data   map[string]any `json:"data"`
 }
+
+// ConvertHTMLRequest html which is converted to blocks. If NoteId is set, blocks are inserted into note from Pos
+type ConvertHTMLRequest struct {
+	Html   string `json:"html"`
+	NoteId string `json:"note_id,omitempty"`
+	Pos    int    `json:"pos"`
+}
 type OpBlockRequest struct {
 	BlockId string         `json:"block_id"`
 	Op      string         `json:"op"`
@@ -71,3 +78,23 @@ type BlockNoteId struct {
 	BlockId string `json:"block_id"`
 	NoteId  string `json:"note_id"`
 }
+
+type DeletedBlock struct {
+	Block     *Block `json:"block"`
+	Position  int32  `json:"position"`
+	DeletedAt int64  `json:"deleted_at"`
+	DeletedBy string `json:"deleted_by"`
+}
+
+func ToDeletedBlocks(b *brzrpc.DeletedBlocks) []DeletedBlock {
+	blks := []DeletedBlock{}
+	for _, blk := range b.GetItems() {
+		blks = append(blks, DeletedBlock{
+			Block:     ToBlockDb(blk.GetBlock()),
+			Position:  blk.GetPosition(),
+			DeletedAt: blk.GetDeletedAt(),
+			DeletedBy: blk.GetDeletedBy(),
+		})
+	}
+	return blks
+}
@@ -0,0 +1,76 @@
+package domain
+
+import brzrpc "github.com/autumnterror/breezynotes/api/proto/gen"
+
+type TextRange struct {
+	Start int32 `json:"start"`
+	End   int32 `json:"end"`
+}
+
+type Comment struct {
+	Id        string     `json:"id"`
+	NoteId    string     `json:"note_id"`
+	BlockId   string     `json:"block_id"`
+	ThreadId  string     `json:"thread_id"`
+	Author    string     `json:"author"`
+	Text      string     `json:"text"`
+	Mentions  []string   `json:"mentions"`
+	Anchor    *TextRange `json:"anchor,omitempty"`
+	Resolved  bool       `json:"resolved"`
+	Orphaned  bool       `json:"orphaned"`
+	CreatedAt int64      `json:"created_at"`
+	UpdatedAt int64      `json:"updated_at"`
+}
+
+func ToComments(c *brzrpc.Comments) []Comment {
+	res := []Comment{}
+	for _, cmt := range c.GetItems() {
+		var anchor *TextRange
+		if a := cmt.GetAnchor(); a != nil {
+			anchor = &TextRange{Start: a.GetStart(), End: a.GetEnd()}
+		}
+		mentions := cmt.GetMentions()
+		if mentions == nil {
+			mentions = []string{}
+		}
+		res = append(res, Comment{
+			Id:        cmt.GetId(),
+			NoteId:    cmt.GetNoteId(),
+			BlockId:   cmt.GetBlockId(),
+			ThreadId:  cmt.GetThreadId(),
+			Author:    cmt.GetAuthor(),
+			Text:      cmt.GetText(),
+			Mentions:  mentions,
+			Anchor:    anchor,
+			Resolved:  cmt.GetResolved(),
+			Orphaned:  cmt.GetOrphaned(),
+			CreatedAt: cmt.GetCreatedAt(),
+			UpdatedAt: cmt.GetUpdatedAt(),
+		})
+	}
+	return res
+}
+
+type CreateCommentRequest struct {
+	NoteId string `json:"note_id"`
+	// BlockId required for new thread
+	BlockId string `json:"block_id"`
+	// ThreadId if set comment is reply to thread
+	ThreadId string `json:"thread_id"`
+	Text     string `json:"text"`
+	// Mentions logins of users
+	Mentions []string   `json:"mentions"`
+	Anchor   *TextRange `json:"anchor"`
+}
+
+type UpdateCommentRequest struct {
+	Id   string `json:"id"`
+	Text string `json:"text"`
+	// Mentions logins of users
+	Mentions []string `json:"mentions"`
+}
+
+type ResolveThreadRequest struct {
+	ThreadId string `json:"thread_id"`
+	Resolved bool   `json:"resolved"`
+}
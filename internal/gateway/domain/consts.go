@@ -3,6 +3,51 @@ package domain
 import "time"
 
 const (
-	IdFromContext = "idUserCtx"
-	WaitTime      = 5 * time.Second
+	IdFromContext         = "idUserCtx"
+	WorkspacesFromContext = "workspacesCtx"
+	// ScopesFromContext is set only for requests with personal access token
+	ScopesFromContext = "scopesCtx"
+	// PatPrefix marks personal access tokens in Authorization header
+	PatPrefix = "brzpat_"
+	WaitTime  = 5 * time.Second
+	// OidcWaitTime SSO calls of auth go to external provider
+	OidcWaitTime = 20 * time.Second
+
+	// WorkspaceRolesMD grpc metadata with workspace membership of user for blocknote service
+	WorkspaceRolesMD = "x-workspace-roles"
+)
+
+// States and steps of account deletion, same as in auth
+const (
+	DeletionScheduled = "scheduled"
+	DeletionRunning   = "running"
+
+	DeletionStepFiles = "files"
+	DeletionStepAcl   = "acl"
+	DeletionStepNotes = "notes"
+	DeletionStepTags  = "tags"
+	DeletionStepCache = "cache"
+)
+
+// States of account export
+const (
+	ExportQueued  = "queued"
+	ExportRunning = "running"
+	ExportReady   = "ready"
+	ExportFailed  = "failed"
+)
+
+// AuthorRole role of user in note, given by blocknote
+const AuthorRole = "author"
+
+// Kinds of import conflicts found by gateway, others come from blocknote
+const (
+	ConflictNotAuthor   = "not_author"
+	ConflictNoteInvalid = "note_invalid"
+	ConflictFileMissing = "file_missing"
+	ConflictFileInvalid = "file_invalid"
+	// ConflictLinkUnresolved link of vault leads to note which is not in it
+	ConflictLinkUnresolved = "link_unresolved"
+	// ConflictTagDropped note of vault has more than one tag
+	ConflictTagDropped = "tag_dropped"
 )
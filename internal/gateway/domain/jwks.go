@@ -0,0 +1,35 @@
+package domain
+
+import brzrpc "github.com/autumnterror/breezynotes/api/proto/gen"
+
+type JWK struct {
+	Kid string `json:"kid"`
+	Kty string `json:"kty"`
+	Alg string `json:"alg"`
+	Use string `json:"use"`
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+	N   string `json:"n,omitempty"`
+	E   string `json:"e,omitempty"`
+}
+
+type JWKS struct {
+	Keys []JWK `json:"keys"`
+}
+
+func ToJWKS(jwks *brzrpc.JWKS) JWKS {
+	res := JWKS{Keys: []JWK{}}
+	for _, k := range jwks.GetKeys() {
+		res.Keys = append(res.Keys, JWK{
+			Kid: k.GetKid(),
+			Kty: k.GetKty(),
+			Alg: k.GetAlg(),
+			Use: k.GetUse(),
+			Crv: k.GetCrv(),
+			X:   k.GetX(),
+			N:   k.GetN(),
+			E:   k.GetE(),
+		})
+	}
+	return res
+}
@@ -7,7 +7,8 @@ type ChangeTitleNoteRequest struct {
 	Title string `json:"title"`
 }
 type CreateNoteRequest struct {
-	Title string `json:"title"`
+	Title       string `json:"title"`
+	WorkspaceId string `json:"workspace_id"`
 }
 type NoteListPaginationResponse struct {
 	Items []*NotePart `json:"items"`
@@ -41,22 +42,26 @@ type Roles struct {
 }
 
 type NotePart struct {
-	Id         string `json:"id"`
-	Title      string `json:"title"`
-	Tag        *Tag   `json:"tag"`
-	FirstBlock string `json:"first_block"`
-	UpdatedAt  int64  `json:"updated_at"`
-	Role       string `json:"role"`
+	Id          string `json:"id"`
+	Title       string `json:"title"`
+	Tag         *Tag   `json:"tag"`
+	FirstBlock  string `json:"first_block"`
+	CreatedAt   int64  `json:"created_at"`
+	UpdatedAt   int64  `json:"updated_at"`
+	Role        string `json:"role"`
+	WorkspaceId string `json:"workspace_id"`
 }
 
 func ToNotePart(n *brzrpc.NotePart) *NotePart {
 	return &NotePart{
-		Id:         n.GetId(),
-		Title:      n.GetTitle(),
-		Tag:        ToTag(n.Tag),
-		FirstBlock: n.GetFirstBlock(),
-		UpdatedAt:  n.GetUpdatedAt(),
-		Role:       n.GetRole(),
+		Id:          n.GetId(),
+		Title:       n.GetTitle(),
+		Tag:         ToTag(n.Tag),
+		FirstBlock:  n.GetFirstBlock(),
+		CreatedAt:   n.GetCreatedAt(),
+		UpdatedAt:   n.GetUpdatedAt(),
+		Role:        n.GetRole(),
+		WorkspaceId: n.GetWorkspaceId(),
 	}
 }
 func ToNotePartList(n []*brzrpc.NotePart) []*NotePart {
@@ -81,9 +86,10 @@ type NoteWithBlocks struct {
 	// Author    string   `json:"author"`
 	// Editors   []string `json:"editors"`
 	// Readers   []string `json:"readers"`
-	Blocks   []Block `json:"blocks"`
-	IsPublic bool    `json:"is_public"`
-	IsBlog   bool    `json:"is_blog"`
+	Blocks      []Block `json:"blocks"`
+	IsPublic    bool    `json:"is_public"`
+	IsBlog      bool    `json:"is_blog"`
+	WorkspaceId string  `json:"workspace_id"`
 }
 
 func ToNoteWithBlocksDb(n *brzrpc.NoteWithBlocks) *NoteWithBlocks {
@@ -109,8 +115,14 @@ func ToNoteWithBlocksDb(n *brzrpc.NoteWithBlocks) *NoteWithBlocks {
 		// Author:    n.Author,
 		// Editors:   nn.Editors,
 		// Readers:   nn.Readers,
-		IsPublic: n.IsPublic,
-		IsBlog:   n.IsBlog,
-		Blocks:   ToBlocksDb(&brzrpc.Blocks{Items: nn.Blocks}),
+		IsPublic:    n.IsPublic,
+		IsBlog:      n.IsBlog,
+		Blocks:      ToBlocksDb(&brzrpc.Blocks{Items: nn.Blocks}),
+		WorkspaceId: n.WorkspaceId,
 	}
 }
+
+type TrashRetentionRequest struct {
+	// Days 0 means global retention
+	Days int32 `json:"days"`
+}
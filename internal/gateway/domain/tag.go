@@ -3,12 +3,13 @@ package domain
 import brzrpc "github.com/autumnterror/breezynotes/api/proto/gen"
 
 type Tag struct {
-	Id       string `json:"id"`
-	Title    string `json:"title"`
-	Color    string `json:"color"`
-	Emoji    string `json:"emoji"`
-	UserId   string `json:"user_id"`
-	IsPinned bool   `json:"is_pinned"`
+	Id          string `json:"id"`
+	Title       string `json:"title"`
+	Color       string `json:"color"`
+	Emoji       string `json:"emoji"`
+	UserId      string `json:"user_id"`
+	IsPinned    bool   `json:"is_pinned"`
+	WorkspaceId string `json:"workspace_id"`
 }
 type Tags struct {
 	Tgs []Tag `json:"tags"`
@@ -19,12 +20,13 @@ func ToTag(t *brzrpc.Tag) *Tag {
 		return nil
 	}
 	return &Tag{
-		Id:       t.Id,
-		Title:    t.Title,
-		Color:    t.Color,
-		Emoji:    t.Emoji,
-		UserId:   t.UserId,
-		IsPinned: t.IsPinned,
+		Id:          t.Id,
+		Title:       t.Title,
+		Color:       t.Color,
+		Emoji:       t.Emoji,
+		UserId:      t.UserId,
+		IsPinned:    t.IsPinned,
+		WorkspaceId: t.WorkspaceId,
 	}
 }
 
@@ -45,9 +47,10 @@ func ToTags(t *brzrpc.Tags) *Tags {
 }
 
 type CreateTagRequest struct {
-	Title string `json:"title"`
-	Color string `json:"color"`
-	Emoji string `json:"emoji"`
+	Title       string `json:"title"`
+	Color       string `json:"color"`
+	Emoji       string `json:"emoji"`
+	WorkspaceId string `json:"workspace_id"`
 }
 
 type UpdateTagTitleRequest struct {
@@ -9,6 +9,13 @@ type User struct {
 	About    string `json:"about"`
 	Photo    string `json:"photo"`
 	Password string `json:"password,omitempty"`
+	// EmailVerified user without verified email can't share notes and publish them to blog
+	EmailVerified bool `json:"email_verified"`
+	// Role is "user" or "admin". Admin can use /api/admin
+	Role     string `json:"role,omitempty"`
+	Disabled bool   `json:"disabled,omitempty"`
+	// Discoverable user is found in search by part of login or email, else only by exact login or email
+	Discoverable bool `json:"discoverable"`
 }
 
 func UserFromRpc(u *brzrpc.User) *User {
@@ -16,28 +23,69 @@ func UserFromRpc(u *brzrpc.User) *User {
 		return nil
 	}
 	return &User{
-		Id:       u.GetId(),
-		Login:    u.GetLogin(),
-		Email:    u.GetEmail(),
-		About:    u.GetAbout(),
-		Photo:    u.GetPhoto(),
-		Password: u.GetPassword(),
+		Id:            u.GetId(),
+		Login:         u.GetLogin(),
+		Email:         u.GetEmail(),
+		About:         u.GetAbout(),
+		Photo:         u.GetPhoto(),
+		Password:      u.GetPassword(),
+		EmailVerified: u.GetEmailVerified(),
+		Role:          u.GetRole(),
+		Disabled:      u.GetDisabled(),
+		Discoverable:  u.GetDiscoverable(),
 	}
 }
 
+// UserCard is user in search results, without email
+type UserCard struct {
+	Id    string `json:"id"`
+	Login string `json:"login"`
+	Photo string `json:"photo"`
+	About string `json:"about"`
+}
+
+func ToUserCards(us *brzrpc.UserCards) []UserCard {
+	res := make([]UserCard, 0, len(us.GetUsers()))
+	for _, u := range us.GetUsers() {
+		res = append(res, UserCard{
+			Id:    u.GetId(),
+			Login: u.GetLogin(),
+			Photo: u.GetPhoto(),
+			About: u.GetAbout(),
+		})
+	}
+	return res
+}
+
+type VerifyEmailRequest struct {
+	Token string `json:"token"`
+}
+type ForgotPasswordRequest struct {
+	Email string `json:"email"`
+}
+type ResetPasswordRequest struct {
+	Token        string `json:"token"`
+	NewPassword  string `json:"new_password"`
+	NewPassword2 string `json:"new_password_2"`
+}
+
 type UpdateAboutRequest struct {
 	NewAbout string `json:"new_about"`
 }
 type UpdateEmailRequest struct {
 	NewEmail string `json:"new_email"`
 }
+type UpdateDiscoverableRequest struct {
+	Discoverable bool `json:"discoverable"`
+}
 type UpdatePhotoRequest struct {
 	NewPhoto string `json:"new_photo"`
 }
 type ChangePasswordRequest struct {
-	OldPassword  string `json:"old_password"`
-	NewPassword  string `json:"new_password"`
-	NewPassword2 string `json:"new_password_2"`
+	OldPassword         string `json:"old_password"`
+	NewPassword         string `json:"new_password"`
+	NewPassword2        string `json:"new_password_2"`
+	RevokeOtherSessions bool   `json:"revoke_other_sessions"`
 }
 type UpdatePasswordRequest struct {
 	OldPassword  string `json:"old_password"`
@@ -74,4 +122,254 @@ type AuthResponse struct {
 	ExpAccess    int64  `json:"expAccess"`
 	ExpRefresh   int64  `json:"expRefresh"`
 	Metadata     *User  `json:"metadata"`
+	// ChallengeToken is set instead of tokens when second factor is required
+	ChallengeToken string `json:"challengeToken,omitempty"`
+}
+
+type OidcProviders struct {
+	Providers []string `json:"providers"`
+}
+
+type SecondFactorRequest struct {
+	ChallengeToken string `json:"challengeToken"`
+	Code           string `json:"code"`
+}
+
+type TotpSetup struct {
+	Secret string `json:"secret"`
+	Uri    string `json:"uri"`
+}
+
+type TotpCodeRequest struct {
+	Code     string `json:"code"`
+	Password string `json:"password,omitempty"`
+}
+
+type RecoveryCodes struct {
+	Codes []string `json:"codes"`
+}
+
+type Session struct {
+	Id         string `json:"id"`
+	UserAgent  string `json:"user_agent"`
+	Ip         string `json:"ip"`
+	CreatedAt  int64  `json:"created_at"`
+	LastSeenAt int64  `json:"last_seen_at"`
+	Current    bool   `json:"current"`
+}
+
+func ToSessions(ss *brzrpc.Sessions) []Session {
+	res := []Session{}
+	for _, s := range ss.GetItems() {
+		res = append(res, Session{
+			Id:         s.GetId(),
+			UserAgent:  s.GetUserAgent(),
+			Ip:         s.GetIp(),
+			CreatedAt:  s.GetCreatedAt(),
+			LastSeenAt: s.GetLastSeenAt(),
+			Current:    s.GetCurrent(),
+		})
+	}
+	return res
+}
+
+// Pat personal access token. Token is shown only in PatCreated
+type Pat struct {
+	Id         string   `json:"id"`
+	Name       string   `json:"name"`
+	Scopes     []string `json:"scopes"`
+	CreatedAt  int64    `json:"created_at"`
+	ExpiresAt  int64    `json:"expires_at"`
+	LastUsedAt int64    `json:"last_used_at"`
+}
+
+type CreatePatRequest struct {
+	Name      string   `json:"name"`
+	Scopes    []string `json:"scopes"`
+	ExpiresAt int64    `json:"expires_at"`
+}
+
+type PatCreated struct {
+	Token string `json:"token"`
+	Pat   Pat    `json:"pat"`
+}
+
+func PatFromRpc(p *brzrpc.Pat) Pat {
+	scopes := p.GetScopes()
+	if scopes == nil {
+		scopes = []string{}
+	}
+	return Pat{
+		Id:         p.GetId(),
+		Name:       p.GetName(),
+		Scopes:     scopes,
+		CreatedAt:  p.GetCreatedAt(),
+		ExpiresAt:  p.GetExpiresAt(),
+		LastUsedAt: p.GetLastUsedAt(),
+	}
+}
+
+func ToPats(ps *brzrpc.Pats) []Pat {
+	res := []Pat{}
+	for _, p := range ps.GetItems() {
+		res = append(res, PatFromRpc(p))
+	}
+	return res
+}
+
+type NotificationPreferences struct {
+	EmailShares   bool `json:"email_shares"`
+	EmailComments bool `json:"email_comments"`
+	EmailMentions bool `json:"email_mentions"`
+	// EmailSecurity letter about new login, password and 2FA changes
+	EmailSecurity bool `json:"email_security"`
+	// Digest is "off", "daily" or "weekly"
+	Digest string `json:"digest"`
+}
+
+// MaxTrashRetentionDays same bound as blocknote checks
+const MaxTrashRetentionDays = 365
+
+type Preferences struct {
+	Version int `json:"version"`
+	// Revision of saved preferences. Update with revision which isn't current is rejected with 409,
+	// without revision update is made over current preferences
+	Revision int64 `json:"revision"`
+	// Theme is "system", "light" or "dark"
+	Theme string `json:"theme"`
+	// Locale is language tag like "en" or "en-US"
+	Locale string `json:"locale"`
+	// NoteSort is "updated_desc", "updated_asc", "created_desc", "created_asc", "title_asc" or "title_desc"
+	NoteSort string `json:"note_sort"`
+	// DefaultTag id of tag for new notes, empty is none
+	DefaultTag string `json:"default_tag"`
+	// TrashRetentionDays from 0 to MaxTrashRetentionDays, 0 is default of server. It is same as /api/trash/retention
+	TrashRetentionDays int                     `json:"trash_retention_days"`
+	Notifications      NotificationPreferences `json:"notifications"`
+}
+
+func PreferencesFromRpc(p *brzrpc.Preferences) *Preferences {
+	n := p.GetNotifications()
+	return &Preferences{
+		Version:            int(p.GetVersion()),
+		Revision:           p.GetRevision(),
+		Theme:              p.GetTheme(),
+		Locale:             p.GetLocale(),
+		NoteSort:           p.GetNoteSort(),
+		DefaultTag:         p.GetDefaultTag(),
+		TrashRetentionDays: int(p.GetTrashRetentionDays()),
+		Notifications: NotificationPreferences{
+			EmailShares:   n.GetEmailShares(),
+			EmailComments: n.GetEmailComments(),
+			EmailMentions: n.GetEmailMentions(),
+			EmailSecurity: n.GetEmailSecurity(),
+			Digest:        n.GetDigest(),
+		},
+	}
+}
+
+func PreferencesToRpc(p *Preferences) *brzrpc.Preferences {
+	return &brzrpc.Preferences{
+		Version:            int32(p.Version),
+		Revision:           p.Revision,
+		Theme:              p.Theme,
+		Locale:             p.Locale,
+		NoteSort:           p.NoteSort,
+		DefaultTag:         p.DefaultTag,
+		TrashRetentionDays: int32(p.TrashRetentionDays),
+		Notifications: &brzrpc.NotificationPreferences{
+			EmailShares:   p.Notifications.EmailShares,
+			EmailComments: p.Notifications.EmailComments,
+			EmailMentions: p.Notifications.EmailMentions,
+			EmailSecurity: p.Notifications.EmailSecurity,
+			Digest:        p.Notifications.Digest,
+		},
+	}
+}
+
+// AccountDeletion state of account deletion. Scheduled deletion can be cancelled until purge_at,
+// then it is running by steps and is done when account is deleted
+type AccountDeletion struct {
+	State       string `json:"state"`
+	Step        string `json:"step,omitempty"`
+	RequestedAt int64  `json:"requested_at"`
+	PurgeAt     int64  `json:"purge_at"`
+}
+
+func AccountDeletionFromRpc(d *brzrpc.AccountDeletion) *AccountDeletion {
+	return &AccountDeletion{
+		State:       d.GetState(),
+		Step:        d.GetStep(),
+		RequestedAt: d.GetRequestedAt(),
+		PurgeAt:     d.GetPurgeAt(),
+	}
+}
+
+// ExportJob export of all data of user. DownloadUrl is set when state is ready
+type ExportJob struct {
+	Id          string `json:"id"`
+	State       string `json:"state"`
+	Error       string `json:"error,omitempty"`
+	CreatedAt   int64  `json:"created_at"`
+	FinishedAt  int64  `json:"finished_at,omitempty"`
+	ExpiresAt   int64  `json:"expires_at"`
+	Size        int64  `json:"size,omitempty"`
+	DownloadUrl string `json:"download_url,omitempty"`
+}
+
+func ExportJobFromRpc(j *brzrpc.ExportJob) *ExportJob {
+	res := &ExportJob{
+		Id:         j.GetId(),
+		State:      j.GetState(),
+		Error:      j.GetError(),
+		CreatedAt:  j.GetCreatedAt(),
+		FinishedAt: j.GetFinishedAt(),
+		ExpiresAt:  j.GetExpiresAt(),
+		Size:       j.GetSize(),
+	}
+	if res.State == ExportReady {
+		res.DownloadUrl = "/api/user/export/" + res.Id + "/download"
+	}
+	return res
+}
+
+// ImportConflict something from archive which is merged or skipped. Id is id from archive
+type ImportConflict struct {
+	Kind   string `json:"kind"`
+	Id     string `json:"id,omitempty"`
+	Title  string `json:"title,omitempty"`
+	Detail string `json:"detail,omitempty"`
+}
+
+// ImportReport result of import of archive. Ids maps ids from archive to new ids of tags, notes and blocks
+type ImportReport struct {
+	Notes     int32             `json:"notes"`
+	Trash     int32             `json:"trash"`
+	Blocks    int32             `json:"blocks"`
+	Tags      int32             `json:"tags"`
+	Files     int32             `json:"files"`
+	Ids       map[string]string `json:"ids"`
+	Conflicts []ImportConflict  `json:"conflicts"`
+}
+
+func NewImportReport() *ImportReport {
+	return &ImportReport{Ids: make(map[string]string), Conflicts: []ImportConflict{}}
+}
+
+// Add merge report of blocknote
+func (r *ImportReport) Add(rr *brzrpc.ImportReport) {
+	r.Notes += rr.GetNotes()
+	r.Trash += rr.GetTrash()
+	r.Blocks += rr.GetBlocks()
+	r.Tags += rr.GetTags()
+	for k, v := range rr.GetIds() {
+		r.Ids[k] = v
+	}
+	for _, c := range rr.GetConflicts() {
+		r.Conflict(c.GetKind(), c.GetId(), c.GetTitle(), c.GetDetail())
+	}
+}
+
+func (r *ImportReport) Conflict(kind, id, title, detail string) {
+	r.Conflicts = append(r.Conflicts, ImportConflict{Kind: kind, Id: id, Title: title, Detail: detail})
 }
@@ -0,0 +1,38 @@
+package domain
+
+import brzrpc "github.com/autumnterror/breezynotes/api/proto/gen"
+
+type Workspace struct {
+	Id      string `json:"id"`
+	Title   string `json:"title"`
+	OwnerId string `json:"owner_id"`
+	Role    string `json:"role"`
+}
+
+func ToWorkspaces(ws *brzrpc.Workspaces) []Workspace {
+	res := []Workspace{}
+	for _, w := range ws.GetItems() {
+		res = append(res, Workspace{
+			Id:      w.GetId(),
+			Title:   w.GetTitle(),
+			OwnerId: w.GetOwnerId(),
+			Role:    w.GetRole(),
+		})
+	}
+	return res
+}
+
+type WorkspaceMember struct {
+	User User   `json:"user"`
+	Role string `json:"role"`
+}
+
+type CreateWorkspaceRequest struct {
+	Title string `json:"title"`
+}
+
+type WorkspaceMemberRequest struct {
+	WorkspaceId string `json:"workspace_id"`
+	Login       string `json:"login"`
+	Role        string `json:"role"`
+}
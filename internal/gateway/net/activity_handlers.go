@@ -0,0 +1,112 @@
+package net
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	brzrpc "github.com/autumnterror/breezynotes/api/proto/gen"
+	"github.com/autumnterror/breezynotes/internal/gateway/domain"
+
+	"github.com/labstack/echo/v4"
+)
+
+// GetNoteActivity godoc
+// @Summary activity of note
+// @Description Returns events of note newest first: who changed what and when. Available for everyone who can read note
+// @Tags activity
+// @Produce json
+// @Param id query string true "Note ID"
+// @Param start query int true "start"
+// @Param end query int true "end"
+// @Success 200 {object} domain.Activities
+// @Failure 400 {object} domain.Error
+// @Failure 401 {object} domain.Error
+// @Failure 404 {object} domain.Error
+// @Failure 502 {object} domain.Error
+// @Failure 504 {object} domain.Error
+// @Router /api/note/activity [get]
+func (e *Echo) GetNoteActivity(c echo.Context) error {
+	const op = "gateway.net.GetNoteActivity"
+
+	api := e.bnAPI.API
+
+	noteId := c.QueryParam("id")
+	if noteId == "" {
+		return c.JSON(http.StatusBadRequest, domain.Error{Error: "bad param"})
+	}
+
+	start, end, resPag := getPagination(c)
+	if resPag != nil {
+		if r, ok := resPag.(domain.Error); ok {
+			return c.JSON(http.StatusBadRequest, r)
+		}
+		return c.JSON(http.StatusOK, domain.Activities{Items: []domain.Activity{}})
+	}
+
+	idUser, errGetId := getIdUser(c)
+	if errGetId != nil {
+		return c.JSON(http.StatusUnauthorized, domain.Error{Error: "bad idUser from access token"})
+	}
+
+	ctx, done := context.WithTimeout(c.Request().Context(), domain.WaitTime)
+	defer done()
+
+	acts, err := api.GetNoteActivity(ctx, &brzrpc.NoteActivityRequest{
+		NoteId: noteId,
+		UserId: idUser,
+		Start:  int32(start),
+		End:    int32(end),
+	})
+	code, errRes := bNErrors(op, err)
+	if code != http.StatusOK {
+		return c.JSON(code, errRes)
+	}
+
+	return c.JSON(http.StatusOK, domain.ToActivities(acts))
+}
+
+// GetActivityFeed godoc
+// @Summary recent activity
+// @Description Returns last changes made by other users in shared notes and workspace notes of user, newest first
+// @Tags activity
+// @Produce json
+// @Param limit query int false "count of events, 50 by default"
+// @Success 200 {object} domain.Activities
+// @Failure 400 {object} domain.Error
+// @Failure 401 {object} domain.Error
+// @Failure 502 {object} domain.Error
+// @Failure 504 {object} domain.Error
+// @Router /api/activity [get]
+func (e *Echo) GetActivityFeed(c echo.Context) error {
+	const op = "gateway.net.GetActivityFeed"
+
+	api := e.bnAPI.API
+
+	limit := 0
+	if l := c.QueryParam("limit"); l != "" {
+		var err error
+		if limit, err = strconv.Atoi(l); err != nil || limit < 0 {
+			return c.JSON(http.StatusBadRequest, domain.Error{Error: "limit must be positive int"})
+		}
+	}
+
+	idUser, errGetId := getIdUser(c)
+	if errGetId != nil {
+		return c.JSON(http.StatusUnauthorized, domain.Error{Error: "bad idUser from access token"})
+	}
+
+	ctx, done := context.WithTimeout(c.Request().Context(), domain.WaitTime)
+	defer done()
+
+	acts, err := api.GetActivityFeed(ctx, &brzrpc.ActivityFeedRequest{
+		UserId: idUser,
+		Limit:  int32(limit),
+	})
+	code, errRes := bNErrors(op, err)
+	if code != http.StatusOK {
+		return c.JSON(code, errRes)
+	}
+
+	return c.JSON(http.StatusOK, domain.ToActivities(acts))
+}
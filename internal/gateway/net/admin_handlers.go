@@ -0,0 +1,401 @@
+package net
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	brzrpc "github.com/autumnterror/breezynotes/api/proto/gen"
+	"github.com/autumnterror/breezynotes/internal/gateway/domain"
+	"github.com/labstack/echo/v4"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// adminErrors is authErrors where PermissionDenied means that user is not admin
+func adminErrors(op string, err error) (int, domain.Error) {
+	if status.Code(err) == codes.PermissionDenied {
+		return http.StatusForbidden, domain.Error{Error: "admin only"}
+	}
+	return authErrors(op, err)
+}
+
+// getLimitOffset read optional limit and offset of page, zero limit is default of auth
+func getLimitOffset(c echo.Context) (int32, int32, bool) {
+	var res [2]int
+	for i, name := range []string{"limit", "offset"} {
+		v := c.QueryParam(name)
+		if v == "" {
+			continue
+		}
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			return 0, 0, false
+		}
+		res[i] = n
+	}
+	return int32(res[0]), int32(res[1]), true
+}
+
+// AdminListUsers godoc
+// @Summary list users
+// @Description Admin only. Returns page of users sorted by login. q filters users which login or email contains it
+// @Tags admin
+// @Produce json
+// @Param q query string false "part of login or email"
+// @Param limit query int false "page size, 20 by default, at most 100"
+// @Param offset query int false "offset of page"
+// @Success 200 {object} domain.AdminUsers
+// @Failure 400 {object} domain.Error
+// @Failure 401 {object} domain.Error
+// @Failure 403 {object} domain.Error
+// @Failure 502 {object} domain.Error
+// @Failure 504 {object} domain.Error
+// @Router /api/admin/users [get]
+func (e *Echo) AdminListUsers(c echo.Context) error {
+	const op = "gateway.net.AdminListUsers"
+
+	idUser, errGetId := getIdUser(c)
+	if errGetId != nil {
+		return c.JSON(http.StatusUnauthorized, domain.Error{Error: "bad idUser from access token"})
+	}
+	limit, offset, ok := getLimitOffset(c)
+	if !ok {
+		return c.JSON(http.StatusBadRequest, domain.Error{Error: "limit and offset must be positive int"})
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request().Context(), domain.WaitTime)
+	defer cancel()
+
+	us, err := e.authAPI.Admin.ListUsers(ctx, &brzrpc.ListUsersRequest{
+		ActorId: idUser,
+		Query:   c.QueryParam("q"),
+		Limit:   limit,
+		Offset:  offset,
+	})
+	code, errRes := adminErrors(op, err)
+	if code != http.StatusOK {
+		return c.JSON(code, errRes)
+	}
+
+	return c.JSON(http.StatusOK, domain.ToAdminUsers(us))
+}
+
+// AdminGetUser godoc
+// @Summary user with stats
+// @Description Admin only. Returns account of user with count of his notes and blocks and their size
+// @Tags admin
+// @Produce json
+// @Param id query string true "User ID"
+// @Success 200 {object} domain.AdminUser
+// @Failure 400 {object} domain.Error
+// @Failure 401 {object} domain.Error
+// @Failure 403 {object} domain.Error
+// @Failure 404 {object} domain.Error
+// @Failure 502 {object} domain.Error
+// @Failure 504 {object} domain.Error
+// @Router /api/admin/user [get]
+func (e *Echo) AdminGetUser(c echo.Context) error {
+	const op = "gateway.net.AdminGetUser"
+
+	idUser, errGetId := getIdUser(c)
+	if errGetId != nil {
+		return c.JSON(http.StatusUnauthorized, domain.Error{Error: "bad idUser from access token"})
+	}
+	id := c.QueryParam("id")
+	if id == "" {
+		return c.JSON(http.StatusBadRequest, domain.Error{Error: "bad param"})
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request().Context(), domain.WaitTime)
+	defer cancel()
+
+	u, err := e.authAPI.Admin.GetUser(ctx, &brzrpc.AdminUserRequest{ActorId: idUser, UserId: id})
+	code, errRes := adminErrors(op, err)
+	if code != http.StatusOK {
+		return c.JSON(code, errRes)
+	}
+
+	st, err := e.bnAPI.API.GetUserStats(ctx, &brzrpc.UserId{UserId: id})
+	code, errRes = bNErrors(op, err)
+	if code != http.StatusOK {
+		return c.JSON(code, errRes)
+	}
+
+	return c.JSON(http.StatusOK, domain.AdminUser{
+		User:  domain.UserFromRpc(u),
+		Stats: domain.UserStatsFromRpc(st),
+	})
+}
+
+// AdminSetUserDisabled godoc
+// @Summary disable or enable user
+// @Description Admin only. Disabled user can't login, his sessions are revoked and personal access tokens don't work.
+// @Description Access tokens issued before work until they expire
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param request body domain.SetUserDisabledRequest true "user and state"
+// @Success 204
+// @Failure 400 {object} domain.Error
+// @Failure 401 {object} domain.Error
+// @Failure 403 {object} domain.Error
+// @Failure 404 {object} domain.Error
+// @Failure 502 {object} domain.Error
+// @Failure 504 {object} domain.Error
+// @Router /api/admin/user/disabled [patch]
+func (e *Echo) AdminSetUserDisabled(c echo.Context) error {
+	const op = "gateway.net.AdminSetUserDisabled"
+
+	idUser, errGetId := getIdUser(c)
+	if errGetId != nil {
+		return c.JSON(http.StatusUnauthorized, domain.Error{Error: "bad idUser from access token"})
+	}
+
+	var r domain.SetUserDisabledRequest
+	if err := c.Bind(&r); err != nil {
+		return c.JSON(http.StatusBadRequest, domain.Error{Error: "bad JSON"})
+	}
+	if r.Id == "" {
+		return c.JSON(http.StatusBadRequest, domain.Error{Error: "bad param"})
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request().Context(), domain.WaitTime)
+	defer cancel()
+
+	_, err := e.authAPI.Admin.SetUserDisabled(ctx, &brzrpc.SetUserDisabledRequest{
+		ActorId:  idUser,
+		UserId:   r.Id,
+		Disabled: r.Disabled,
+	})
+	code, errRes := adminErrors(op, err)
+	if code != http.StatusOK {
+		return c.JSON(code, errRes)
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// AdminSetUserRole godoc
+// @Summary change role of user
+// @Description Admin only. Role is "user" or "admin", admin can't change own role
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param request body domain.SetUserRoleRequest true "user and role"
+// @Success 204
+// @Failure 400 {object} domain.Error
+// @Failure 401 {object} domain.Error
+// @Failure 403 {object} domain.Error
+// @Failure 404 {object} domain.Error
+// @Failure 502 {object} domain.Error
+// @Failure 504 {object} domain.Error
+// @Router /api/admin/user/role [patch]
+func (e *Echo) AdminSetUserRole(c echo.Context) error {
+	const op = "gateway.net.AdminSetUserRole"
+
+	idUser, errGetId := getIdUser(c)
+	if errGetId != nil {
+		return c.JSON(http.StatusUnauthorized, domain.Error{Error: "bad idUser from access token"})
+	}
+
+	var r domain.SetUserRoleRequest
+	if err := c.Bind(&r); err != nil {
+		return c.JSON(http.StatusBadRequest, domain.Error{Error: "bad JSON"})
+	}
+	if r.Id == "" || r.Role == "" {
+		return c.JSON(http.StatusBadRequest, domain.Error{Error: "bad param"})
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request().Context(), domain.WaitTime)
+	defer cancel()
+
+	_, err := e.authAPI.Admin.SetUserRole(ctx, &brzrpc.SetUserRoleRequest{
+		ActorId: idUser,
+		UserId:  r.Id,
+		Role:    r.Role,
+	})
+	code, errRes := adminErrors(op, err)
+	if code != http.StatusOK {
+		return c.JSON(code, errRes)
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// AdminForcePasswordReset godoc
+// @Summary force password reset
+// @Description Admin only. Password of user is replaced by random one, his sessions are revoked
+// @Description and letter with link to set new password is sent
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param request body domain.AdminUserRequest true "user"
+// @Success 204
+// @Failure 400 {object} domain.Error
+// @Failure 401 {object} domain.Error
+// @Failure 403 {object} domain.Error
+// @Failure 404 {object} domain.Error
+// @Failure 502 {object} domain.Error
+// @Failure 504 {object} domain.Error
+// @Router /api/admin/user/reset [post]
+func (e *Echo) AdminForcePasswordReset(c echo.Context) error {
+	const op = "gateway.net.AdminForcePasswordReset"
+
+	idUser, errGetId := getIdUser(c)
+	if errGetId != nil {
+		return c.JSON(http.StatusUnauthorized, domain.Error{Error: "bad idUser from access token"})
+	}
+
+	var r domain.AdminUserRequest
+	if err := c.Bind(&r); err != nil {
+		return c.JSON(http.StatusBadRequest, domain.Error{Error: "bad JSON"})
+	}
+	if r.Id == "" {
+		return c.JSON(http.StatusBadRequest, domain.Error{Error: "bad param"})
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request().Context(), domain.WaitTime)
+	defer cancel()
+
+	_, err := e.authAPI.Admin.ForcePasswordReset(ctx, &brzrpc.AdminUserRequest{ActorId: idUser, UserId: r.Id})
+	code, errRes := adminErrors(op, err)
+	if code != http.StatusOK {
+		return c.JSON(code, errRes)
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// AdminImpersonate godoc
+// @Summary read-only access to account of user
+// @Description Admin only. Returns personal access token of user with read scopes which works 15 minutes.
+// @Description Use it in Authorization: Bearer header. User sees the token in his list of tokens
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param request body domain.AdminUserRequest true "user"
+// @Success 201 {object} domain.PatCreated
+// @Failure 400 {object} domain.Error
+// @Failure 401 {object} domain.Error
+// @Failure 403 {object} domain.Error
+// @Failure 404 {object} domain.Error
+// @Failure 502 {object} domain.Error
+// @Failure 504 {object} domain.Error
+// @Router /api/admin/user/impersonate [post]
+func (e *Echo) AdminImpersonate(c echo.Context) error {
+	const op = "gateway.net.AdminImpersonate"
+
+	idUser, errGetId := getIdUser(c)
+	if errGetId != nil {
+		return c.JSON(http.StatusUnauthorized, domain.Error{Error: "bad idUser from access token"})
+	}
+
+	var r domain.AdminUserRequest
+	if err := c.Bind(&r); err != nil {
+		return c.JSON(http.StatusBadRequest, domain.Error{Error: "bad JSON"})
+	}
+	if r.Id == "" {
+		return c.JSON(http.StatusBadRequest, domain.Error{Error: "bad param"})
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request().Context(), domain.WaitTime)
+	defer cancel()
+
+	res, err := e.authAPI.Admin.Impersonate(ctx, &brzrpc.AdminUserRequest{ActorId: idUser, UserId: r.Id})
+	code, errRes := adminErrors(op, err)
+	if code != http.StatusOK {
+		return c.JSON(code, errRes)
+	}
+
+	return c.JSON(http.StatusCreated, domain.PatCreated{
+		Token: res.GetToken(),
+		Pat:   domain.PatFromRpc(res.GetPat()),
+	})
+}
+
+// AdminUnlockLogin godoc
+// @Summary unlock login
+// @Description Admin only. Resets failed login attempts of login or email before lockout is over
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param request body domain.UnlockLoginRequest true "login or email"
+// @Success 204
+// @Failure 400 {object} domain.Error
+// @Failure 401 {object} domain.Error
+// @Failure 403 {object} domain.Error
+// @Failure 502 {object} domain.Error
+// @Failure 504 {object} domain.Error
+// @Router /api/admin/unlock [post]
+func (e *Echo) AdminUnlockLogin(c echo.Context) error {
+	const op = "gateway.net.AdminUnlockLogin"
+
+	idUser, errGetId := getIdUser(c)
+	if errGetId != nil {
+		return c.JSON(http.StatusUnauthorized, domain.Error{Error: "bad idUser from access token"})
+	}
+
+	var r domain.UnlockLoginRequest
+	if err := c.Bind(&r); err != nil {
+		return c.JSON(http.StatusBadRequest, domain.Error{Error: "bad JSON"})
+	}
+	if r.Identifier == "" {
+		return c.JSON(http.StatusBadRequest, domain.Error{Error: "bad param"})
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request().Context(), domain.WaitTime)
+	defer cancel()
+
+	_, err := e.authAPI.Admin.UnlockLogin(ctx, &brzrpc.UnlockLoginRequest{ActorId: idUser, Identifier: r.Identifier})
+	code, errRes := adminErrors(op, err)
+	if code != http.StatusOK {
+		return c.JSON(code, errRes)
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// AdminGetAuditEvents godoc
+// @Summary audit log
+// @Description Admin only. Returns security events and actions of admins, newest first
+// @Tags admin
+// @Produce json
+// @Param id query string false "only events about user or made by him"
+// @Param limit query int false "page size, 20 by default, at most 100"
+// @Param offset query int false "offset of page"
+// @Success 200 {array} domain.AuditEvent
+// @Failure 400 {object} domain.Error
+// @Failure 401 {object} domain.Error
+// @Failure 403 {object} domain.Error
+// @Failure 502 {object} domain.Error
+// @Failure 504 {object} domain.Error
+// @Router /api/admin/audit [get]
+func (e *Echo) AdminGetAuditEvents(c echo.Context) error {
+	const op = "gateway.net.AdminGetAuditEvents"
+
+	idUser, errGetId := getIdUser(c)
+	if errGetId != nil {
+		return c.JSON(http.StatusUnauthorized, domain.Error{Error: "bad idUser from access token"})
+	}
+	limit, offset, ok := getLimitOffset(c)
+	if !ok {
+		return c.JSON(http.StatusBadRequest, domain.Error{Error: "limit and offset must be positive int"})
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request().Context(), domain.WaitTime)
+	defer cancel()
+
+	es, err := e.authAPI.Admin.GetAuditEvents(ctx, &brzrpc.AuditEventsRequest{
+		ActorId: idUser,
+		UserId:  c.QueryParam("id"),
+		Limit:   limit,
+		Offset:  offset,
+	})
+	code, errRes := adminErrors(op, err)
+	if code != http.StatusOK {
+		return c.JSON(code, errRes)
+	}
+
+	return c.JSON(http.StatusOK, domain.ToAuditEvents(es))
+}
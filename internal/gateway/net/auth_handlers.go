@@ -17,7 +17,8 @@ import (
 
 // Auth godoc
 // @Summary Authorize user
-// @Description Authenticates user and returns access/refresh tokens
+// @Description Authenticates user and returns access/refresh tokens. With enabled 2FA only challengeToken is returned,
+// @Description login is finished by /api/auth/2fa
 // @Tags auth
 // @Accept json
 // @Produce json
@@ -41,35 +42,28 @@ func (e *Echo) Auth(c echo.Context) error {
 	defer done()
 
 	res, err := api.Auth(ctx, &brzrpc.AuthRequest{
-		Email:    r.Email,
-		Login:    r.Login,
-		Password: r.Password,
+		Email:     r.Email,
+		Login:     r.Login,
+		Password:  r.Password,
+		UserAgent: c.Request().UserAgent(),
+		Ip:        clientIP(e.rateCfg, c),
 	})
 	code, errRes := authErrors(op, err)
 	if code != http.StatusOK {
 		return c.JSON(code, errRes)
 	}
 
-	c.SetCookie(&http.Cookie{
-		Name:     "access_token",
-		Value:    res.GetAccessToken(),
-		Path:     "/",
-		HttpOnly: true,
-		//SameSite: http.SameSiteLaxMode,
-		Secure:   true,
-		SameSite: http.SameSiteNoneMode,
-		Expires:  time.Unix(res.ExpAccess, 0).UTC(),
-	})
-	c.SetCookie(&http.Cookie{
-		Name:     "refresh_token",
-		Value:    res.GetRefreshToken(),
-		Path:     "/",
-		HttpOnly: true,
-		//SameSite: http.SameSiteLaxMode,
-		Secure:   true,
-		SameSite: http.SameSiteNoneMode,
-		Expires:  time.Unix(res.ExpRefresh, 0).UTC(),
-	})
+	if res.GetChallengeToken() != "" {
+		return c.JSON(http.StatusOK, domain.AuthResponse{ChallengeToken: res.GetChallengeToken()})
+	}
+
+	return authResponse(c, res)
+}
+
+// authResponse set token cookies and return tokens with user
+func authResponse(c echo.Context, res *brzrpc.AuthResponse) error {
+	setTokenCookie(c, "access_token", res.GetAccessToken(), res.GetExpAccess())
+	setTokenCookie(c, "refresh_token", res.GetRefreshToken(), res.GetExpRefresh())
 
 	return c.JSON(http.StatusOK, domain.AuthResponse{
 		AccessToken:  res.GetAccessToken(),
@@ -80,6 +74,47 @@ func (e *Echo) Auth(c echo.Context) error {
 	})
 }
 
+// VerifySecondFactor godoc
+// @Summary Finish login with 2FA
+// @Description Exchanges challengeToken from /api/auth and code from authenticator app or recovery code to tokens
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param request body domain.SecondFactorRequest true "challenge and code"
+// @Success 200 {object} domain.AuthResponse
+// @Failure 400 {object} domain.Error
+// @Failure 401 {object} domain.Error
+// @Failure 502 {object} domain.Error
+// @Failure 504 {object} domain.Error
+// @Router /api/auth/2fa [post]
+func (e *Echo) VerifySecondFactor(c echo.Context) error {
+	const op = "gateway.net.VerifySecondFactor"
+
+	var r domain.SecondFactorRequest
+	if err := c.Bind(&r); err != nil {
+		return c.JSON(http.StatusBadRequest, domain.Error{Error: "bad JSON"})
+	}
+	if r.ChallengeToken == "" || r.Code == "" {
+		return c.JSON(http.StatusBadRequest, domain.Error{Error: "bad param"})
+	}
+
+	ctx, done := context.WithTimeout(c.Request().Context(), domain.WaitTime)
+	defer done()
+
+	res, err := e.authAPI.API.VerifySecondFactor(ctx, &brzrpc.SecondFactorRequest{
+		ChallengeToken: r.ChallengeToken,
+		Code:           r.Code,
+		UserAgent:      c.Request().UserAgent(),
+		Ip:             clientIP(e.rateCfg, c),
+	})
+	code, errRes := authErrors(op, err)
+	if code != http.StatusOK {
+		return c.JSON(code, errRes)
+	}
+
+	return authResponse(c, res)
+}
+
 // Reg godoc
 // @Summary Register new user
 // @Description Validates registration data, creates user and returns tokens
@@ -110,9 +145,11 @@ func (e *Echo) Reg(c echo.Context) error {
 	defer cancel()
 
 	tokens, err := auth.Reg(ctx, &brzrpc.AuthRequest{
-		Email:    u.Email,
-		Login:    u.Login,
-		Password: u.Pw1,
+		Email:     u.Email,
+		Login:     u.Login,
+		Password:  u.Pw1,
+		UserAgent: c.Request().UserAgent(),
+		Ip:        clientIP(e.rateCfg, c),
 	})
 	code, errRes := authErrors(op, err)
 	if code != http.StatusOK {
@@ -252,28 +289,121 @@ func (e *Echo) ValidateToken(c echo.Context) error {
 	})
 	code, errRes := authErrors(op, err)
 	if code != http.StatusOK {
+		if code == http.StatusGone {
+			clearAuthCookies(c)
+		}
 		return c.JSON(code, errRes)
 	}
 
 	if token != nil {
-		if token.Value == "" {
+		if token.GetAccessToken() == "" {
 			return c.JSON(http.StatusOK, domain.Message{Message: "tokens valid"})
 		}
-		c.SetCookie(&http.Cookie{
-			Name:     "access_token",
-			Value:    token.GetValue(),
-			Path:     "/",
-			HttpOnly: true,
-			//SameSite: http.SameSiteLaxMode,
-			Secure:   true,
-			SameSite: http.SameSiteNoneMode,
-			Expires:  time.Unix(token.GetExp(), 0).UTC(),
-		})
+		setRefreshedCookies(c, token)
 		return c.JSON(http.StatusCreated, domain.Token{
-			Value: token.GetValue(),
-			Exp:   token.GetExp(),
+			Value: token.GetAccessToken(),
+			Exp:   token.GetExpAccess(),
 		})
 	}
 
 	return c.JSON(http.StatusOK, domain.Message{Message: "tokens valid"})
 }
+
+// Logout godoc
+// @Summary Logout (uses cookies)
+// @Description Revokes tokens of current login on server and clears cookies. Expired tokens are not error
+// @Tags auth
+// @Produce json
+// @Success 204
+// @Failure 400 {object} domain.Error
+// @Failure 502 {object} domain.Error
+// @Failure 504 {object} domain.Error
+// @Router /api/auth/logout [post]
+func (e *Echo) Logout(c echo.Context) error {
+	const op = "gateway.net.Logout"
+
+	var at, rt string
+	if cookie, err := c.Cookie("access_token"); err == nil {
+		at = cookie.Value
+	}
+	if cookie, err := c.Cookie("refresh_token"); err == nil {
+		rt = cookie.Value
+	}
+	if at == "" && rt == "" {
+		clearAuthCookies(c)
+		return c.NoContent(http.StatusNoContent)
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request().Context(), domain.WaitTime)
+	defer cancel()
+
+	_, err := e.authAPI.API.Logout(ctx, &brzrpc.Tokens{
+		AccessToken:  at,
+		RefreshToken: rt,
+	})
+	code, errRes := authErrors(op, err)
+	if code != http.StatusOK {
+		return c.JSON(code, errRes)
+	}
+
+	clearAuthCookies(c)
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// LogoutAll godoc
+// @Summary Logout from all devices
+// @Description Revokes all tokens of user on server, every device must login again. Clears cookies
+// @Tags auth
+// @Produce json
+// @Success 204
+// @Failure 401 {object} domain.Error
+// @Failure 502 {object} domain.Error
+// @Failure 504 {object} domain.Error
+// @Router /api/user/logout-all [post]
+func (e *Echo) LogoutAll(c echo.Context) error {
+	const op = "gateway.net.LogoutAll"
+
+	idUser, errGetId := getIdUser(c)
+	if errGetId != nil {
+		return c.JSON(http.StatusUnauthorized, domain.Error{Error: "bad idUser from access token"})
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request().Context(), domain.WaitTime)
+	defer cancel()
+
+	_, err := e.authAPI.API.LogoutAll(ctx, &brzrpc.UserId{UserId: idUser})
+	code, errRes := authErrors(op, err)
+	if code != http.StatusOK {
+		return c.JSON(code, errRes)
+	}
+
+	clearAuthCookies(c)
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// GetJWKS godoc
+// @Summary public keys of tokens
+// @Description Returns JWKS (RFC 7517) with public keys which sign access and refresh tokens
+// @Tags auth
+// @Produce json
+// @Success 200 {object} domain.JWKS
+// @Failure 502 {object} domain.Error
+// @Failure 504 {object} domain.Error
+// @Router /.well-known/jwks.json [get]
+func (e *Echo) GetJWKS(c echo.Context) error {
+	const op = "gateway.net.GetJWKS"
+
+	ctx, cancel := context.WithTimeout(c.Request().Context(), domain.WaitTime)
+	defer cancel()
+
+	jwks, err := e.authAPI.Verifier.JWKS(ctx)
+	if err != nil {
+		log.Error(op, "get jwks", err)
+		return c.JSON(http.StatusBadGateway, domain.Error{Error: "auth service unavailable"})
+	}
+
+	c.Response().Header().Set(echo.HeaderCacheControl, "public, max-age=300")
+	return c.JSON(http.StatusOK, domain.ToJWKS(jwks))
+}
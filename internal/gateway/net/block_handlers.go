@@ -149,6 +149,81 @@ func (e *Echo) CreateBlock(c echo.Context) error {
 	return c.JSON(http.StatusCreated, id)
 }
 
+// ConvertHTML godoc
+// @Summary Convert html to blocks
+// @Description Converts pasted or clipped html to blocks ready for CreateBlock: h1-h6 are headers, ul/ol and checkboxes
+// @Description are lists, pre is code, blockquote is quote, links and images are link and img blocks. Scripts, styles,
+// @Description media and unsafe urls are dropped. If note_id is set, blocks are inserted into note from pos and returned with ids
+// @Tags block
+// @Accept json
+// @Produce json
+// @Param ConvertHTMLRequest body domain.ConvertHTMLRequest true "Html and optional note and position"
+// @Success 200 {array} domain.Block "Blocks are only converted"
+// @Success 201 {array} domain.Block "Blocks are inserted into note"
+// @Failure 400 {object} domain.Error
+// @Failure 401 {object} domain.Error
+// @Failure 404 {object} domain.Error
+// @Failure 502 {object} domain.Error
+// @Failure 504 {object} domain.Error
+// @Router /api/block/convert [post]
+func (e *Echo) ConvertHTML(c echo.Context) error {
+	const op = "gateway.net.ConvertHTML"
+
+	api := e.bnAPI.API
+
+	var r domain.ConvertHTMLRequest
+	if err := c.Bind(&r); err != nil {
+		return c.JSON(http.StatusBadRequest, domain.Error{Error: "bad JSON"})
+	}
+
+	idUser, errGetId := getIdUser(c)
+	if errGetId != nil {
+		return c.JSON(http.StatusUnauthorized, domain.Error{Error: "bad idUser from access token"})
+	}
+
+	ctx, done := context.WithTimeout(c.Request().Context(), domain.WaitTime)
+	defer done()
+
+	blks, err := api.ConvertHTML(ctx, &brzrpc.ConvertHTMLRequest{
+		Html:   r.Html,
+		NoteId: r.NoteId,
+		UserId: idUser,
+		Pos:    int32(r.Pos),
+	})
+	code, errRes := bNErrors(op, err)
+	if code != http.StatusOK {
+		return c.JSON(code, errRes)
+	}
+
+	if r.NoteId == "" {
+		return c.JSON(http.StatusOK, domain.ToBlocksDb(blks))
+	}
+
+	// note is shared, so it is dropped from cache of all users
+	if _, err := e.rdsAPI.API.CleanNoteById(ctx, &brzrpc.NoteId{NoteId: r.NoteId}); err != nil {
+		st, ok := status.FromError(err)
+		if !ok {
+			log.Error(op, "REDIS ERROR", err)
+		} else {
+			if st.Code() != codes.NotFound {
+				log.Error(op, "REDIS ERROR", err)
+			}
+		}
+	}
+	if _, err := e.rdsAPI.API.RmNoteListByUser(ctx, &brzrpc.UserId{UserId: idUser}); err != nil {
+		st, ok := status.FromError(err)
+		if !ok {
+			log.Error(op, "REDIS ERROR", err)
+		} else {
+			if st.Code() != codes.NotFound {
+				log.Error(op, "REDIS ERROR", err)
+			}
+		}
+	}
+
+	return c.JSON(http.StatusCreated, domain.ToBlocksDb(blks))
+}
+
 // OpBlock godoc
 // @Summary Operate on block
 // @Description Performs operation on block
@@ -426,3 +501,109 @@ func (e *Echo) DeleteBlock(c echo.Context) error {
 
 	return c.NoContent(http.StatusNoContent)
 }
+
+// GetDeletedBlocks godoc
+// @Summary deleted blocks of note
+// @Description Returns blocks from block trash of note, last deleted first
+// @Tags block
+// @Produce json
+// @Param id query string true "Note ID"
+// @Success 200 {object} []domain.DeletedBlock
+// @Failure 400 {object} domain.Error
+// @Failure 401 {object} domain.Error
+// @Failure 404 {object} domain.Error
+// @Failure 502 {object} domain.Error
+// @Failure 504 {object} domain.Error
+// @Router /api/block/trash [get]
+func (e *Echo) GetDeletedBlocks(c echo.Context) error {
+	const op = "gateway.net.GetDeletedBlocks"
+
+	api := e.bnAPI.API
+
+	noteId := c.QueryParam("id")
+	if noteId == "" {
+		return c.JSON(http.StatusBadRequest, domain.Error{Error: "no note id"})
+	}
+
+	idUser, errGetId := getIdUser(c)
+	if errGetId != nil {
+		return c.JSON(http.StatusUnauthorized, domain.Error{Error: "bad idUser from access token"})
+	}
+
+	ctx, done := context.WithTimeout(c.Request().Context(), domain.WaitTime)
+	defer done()
+
+	blks, err := api.GetDeletedBlocks(ctx, &brzrpc.UserNoteId{NoteId: noteId, UserId: idUser})
+	code, errRes := bNErrors(op, err)
+	if code != http.StatusOK {
+		return c.JSON(code, errRes)
+	}
+
+	return c.JSON(http.StatusOK, domain.ToDeletedBlocks(blks))
+}
+
+// RestoreBlock godoc
+// @Summary restore deleted block
+// @Description Returns block from block trash to note near its old neighbours
+// @Tags block
+// @Accept json
+// @Produce json
+// @Param RestoreBlockRequest body domain.BlockNoteId true "Block ID and Note ID"
+// @Success 204
+// @Failure 400 {object} domain.Error
+// @Failure 401 {object} domain.Error
+// @Failure 404 {object} domain.Error
+// @Failure 502 {object} domain.Error
+// @Failure 504 {object} domain.Error
+// @Router /api/block/restore [put]
+func (e *Echo) RestoreBlock(c echo.Context) error {
+	const op = "gateway.net.RestoreBlock"
+
+	api := e.bnAPI.API
+
+	idUser, errGetId := getIdUser(c)
+	if errGetId != nil {
+		return c.JSON(http.StatusUnauthorized, domain.Error{Error: "bad idUser from access token"})
+	}
+
+	var r domain.BlockNoteId
+	if err := c.Bind(&r); err != nil {
+		return c.JSON(http.StatusBadRequest, domain.Error{Error: "bad JSON"})
+	}
+
+	ctx, done := context.WithTimeout(c.Request().Context(), domain.WaitTime)
+	defer done()
+
+	_, err := api.RestoreBlock(ctx, &brzrpc.NoteBlockUserId{
+		NoteId:  r.NoteId,
+		BlockId: r.BlockId,
+		UserId:  idUser,
+	})
+	code, errRes := bNErrors(op, err)
+	if code != http.StatusOK {
+		return c.JSON(code, errRes)
+	}
+
+	if _, err := e.rdsAPI.API.RmNoteByUser(ctx, &brzrpc.UserNoteId{UserId: idUser, NoteId: r.NoteId}); err != nil {
+		st, ok := status.FromError(err)
+		if !ok {
+			log.Error(op, "REDIS ERROR", err)
+		} else {
+			if st.Code() != codes.NotFound {
+				log.Error(op, "REDIS ERROR", err)
+			}
+		}
+	}
+	if _, err := e.rdsAPI.API.RmNoteListByUser(ctx, &brzrpc.UserId{UserId: idUser}); err != nil {
+		st, ok := status.FromError(err)
+		if !ok {
+			log.Error(op, "REDIS ERROR", err)
+		} else {
+			if st.Code() != codes.NotFound {
+				log.Error(op, "REDIS ERROR", err)
+			}
+		}
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
@@ -0,0 +1,43 @@
+package net
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/autumnterror/breezynotes/internal/gateway/clients/blocknote"
+	"github.com/autumnterror/breezynotes/internal/gateway/domain"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConvertHTMLCache(t *testing.T) {
+	t.Parallel()
+	for name, tt := range map[string]struct {
+		body    string
+		code    int
+		cleaned []string
+	}{
+		"convert only": {`{"html":"<p>a</p>"}`, http.StatusOK, nil},
+		"insert":       {`{"html":"<p>a</p>","note_id":"note","pos":0}`, http.StatusCreated, []string{"note"}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			r := &fakeRedis{}
+			e := newTestEcho(&fakeAuth{}, r)
+			e.bnAPI = &blocknote.Client{API: &fakeBlocknote{}}
+
+			req := httptest.NewRequest(http.MethodPost, "/api/block/convert", strings.NewReader(tt.body))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			c := echo.New().NewContext(req, rec)
+			c.Set(domain.IdFromContext, "user")
+
+			require.NoError(t, e.ConvertHTML(c))
+			assert.Equal(t, tt.code, rec.Code, rec.Body.String())
+			assert.Equal(t, tt.cleaned, r.cleaned, "inserted note is dropped from cache of all collaborators")
+		})
+	}
+}
@@ -25,6 +25,9 @@ type Echo struct {
 	authAPI *auth.Client
 	bnAPI   *blocknote.Client
 	rdsAPI  *redis.Client
+	rateCfg rateLimitConfig
+	// exportSlots limits exports made at once
+	exportSlots chan struct{}
 }
 
 func New(
@@ -40,6 +43,8 @@ func New(
 		authAPI: authAPI,
 		bnAPI:   bnAPI,
 		rdsAPI:  rdsAPI,
+
+		exportSlots: make(chan struct{}, exportWorkers),
 	}
 
 	e.echo.GET("/swagger/*", echoSwagger.WrapHandler)
@@ -53,16 +58,17 @@ func New(
 		AllowCredentials: true,
 	}))
 
-	rateCfg := rateLimitConfig{
+	e.rateCfg = rateLimitConfig{
 		Limit:  int64(e.cfg.RateLimit),
 		Window: e.cfg.RateLimitWindow,
 	}
-	rateCfg.setDefaults()
-	rateCfg.PerRoute = true
-	e.echo.Use(e.RateLimitMW(rateCfg))
+	e.rateCfg.setDefaults()
+	e.rateCfg.PerRoute = true
+	e.echo.Use(e.RateLimitMW(e.rateCfg))
 
 	//e.echo.Use(middleware.Logger(), middleware.Recover())
 	e.echo.Static("/files", "./files")
+	e.echo.GET("/.well-known/jwks.json", e.GetJWKS)
 
 	apiPublic := e.echo.Group("/api", ValidateID())
 	{
@@ -73,32 +79,103 @@ func New(
 			auth.GET("/token", e.ValidateToken)
 			auth.POST("", e.Auth)
 			auth.POST("/reg", e.Reg)
+			auth.POST("/logout", e.Logout)
+			auth.POST("/2fa", e.VerifySecondFactor)
+			auth.POST("/verify", e.VerifyEmail)
+			auth.POST("/forgot", e.ForgotPassword)
+			auth.POST("/reset", e.ResetPassword)
+			auth.GET("/oidc", e.OidcProviders)
+			auth.GET("/oidc/:provider", e.OidcStart)
+			auth.GET("/oidc/:provider/callback", e.OidcCallback)
 		}
 
 		notes := apiPublic.Group("/note")
 		{
-			notes.GET("", e.GetNote, e.GetUserId())
+			notes.GET("", e.GetNote, e.GetUserId(), ScopeMW("notes"), e.WorkspacesMW())
 		}
 	}
 
-	api := e.echo.Group("/api", ValidateID(), e.GetUserId(), e.ValidateTokenMW())
+	api := e.echo.Group("/api", ValidateID(), e.GetUserId(), e.ValidateTokenMW(), e.WorkspacesMW())
 	{
-		f := api.Group("/files")
+		f := api.Group("/files", ScopeMW("files"))
 		{
 			f.POST("", e.UploadFile)
 			f.DELETE("", e.DeleteFile)
 		}
-		user := api.Group("/user")
+		// searchLimit is per user, so people behind one NAT don't share it
+		searchLimit := rateLimitConfig{
+			Limit:  int64(e.cfg.SearchRateLimit),
+			Window: time.Minute,
+			KeyFunc: func(c echo.Context) string {
+				idUser, _ := getIdUser(c)
+				return "ratelimit:search:" + idUser
+			},
+		}
+		searchLimit.setDefaults()
+		// exportLimit archive of all data is heavy, so it can't be made often
+		exportLimit := rateLimitConfig{
+			Limit:  5,
+			Window: time.Hour,
+			KeyFunc: func(c echo.Context) string {
+				idUser, _ := getIdUser(c)
+				return "ratelimit:export:" + idUser
+			},
+		}
+		exportLimit.setDefaults()
+		importLimit := rateLimitConfig{
+			Limit:  5,
+			Window: time.Hour,
+			KeyFunc: func(c echo.Context) string {
+				idUser, _ := getIdUser(c)
+				return "ratelimit:import:" + idUser
+			},
+		}
+		importLimit.setDefaults()
+		// renderLimit export of one note or blog is made while client waits
+		renderLimit := rateLimitConfig{
+			Limit:  30,
+			Window: time.Hour,
+			KeyFunc: func(c echo.Context) string {
+				idUser, _ := getIdUser(c)
+				return "ratelimit:render:" + idUser
+			},
+		}
+		renderLimit.setDefaults()
+
+		user := api.Group("/user", ScopeMW("user"))
 		{
+			user.GET("/search", e.SearchUsers, e.RateLimitMW(searchLimit))
+			user.PATCH("/discoverable", e.UpdateDiscoverable)
+			user.GET("/preferences", e.GetPreferences)
+			user.PATCH("/preferences", e.UpdatePreferences)
 			user.GET("/data", e.GetUserData)
 			user.DELETE("", e.DeleteUser)
+			user.GET("/deletion", e.GetDeletion)
+			user.DELETE("/deletion", e.CancelDeletion)
+			user.POST("/export", e.StartExport, e.RateLimitMW(exportLimit))
+			user.GET("/export", e.GetExport)
+			user.GET("/export/:id/download", e.DownloadExport)
+			user.POST("/import", e.ImportArchive, e.RateLimitMW(importLimit))
+			user.POST("/import/vault", e.ImportVault, e.RateLimitMW(importLimit))
+			user.POST("/import/notion", e.ImportNotion, e.RateLimitMW(importLimit))
+			user.POST("/import/enex", e.ImportEnex, e.RateLimitMW(importLimit))
 			user.PATCH("/about", e.UpdateAbout)
 			user.PATCH("/email", e.UpdateEmail)
 			user.PATCH("/photo", e.UpdatePhoto)
 			user.PATCH("/pw", e.ChangePassword)
+			user.POST("/logout-all", e.LogoutAll)
+			user.GET("/sessions", e.GetSessions)
+			user.DELETE("/sessions", e.RevokeSession)
+			user.POST("/2fa/totp", e.SetupTotp)
+			user.POST("/2fa/totp/confirm", e.ConfirmTotp)
+			user.DELETE("/2fa/totp", e.DisableTotp)
+			user.POST("/verify/resend", e.SendVerification)
+			user.GET("/tokens", e.GetPats)
+			user.POST("/tokens", e.CreatePat)
+			user.DELETE("/tokens", e.RevokePat)
 		}
 
-		notes := api.Group("/note")
+		notes := api.Group("/note", ScopeMW("notes"))
 		{
 			notes.GET("/search", e.Search)
 			notes.GET("/roles", e.GetRoles)
@@ -111,35 +188,52 @@ func New(
 			notes.POST("/tag", e.AddTagToNote)
 			notes.DELETE("/tag", e.RmTagFromNote)
 
-			notes.PATCH("/share", e.ShareNote)
-			notes.PATCH("/blog", e.BlogNote)
-			notes.PATCH("/public", e.PublicNote)
+			notes.PATCH("/share", e.ShareNote, e.VerifiedEmailMW())
+			notes.PATCH("/blog", e.BlogNote, e.VerifiedEmailMW())
+			notes.PATCH("/public", e.PublicNote, e.VerifiedEmailMW())
 			notes.PATCH("/public/add", e.AddPublicNote)
+
+			notes.GET("/activity", e.GetNoteActivity)
+
+			notes.GET("/export", e.ExportNote, e.RateLimitMW(renderLimit))
+			notes.GET("/blog/export", e.ExportBlog, e.RateLimitMW(renderLimit))
+
+			notes.GET("/document", e.GetNoteDocument)
+			notes.POST("/document/convert", e.ConvertDocument)
+			notes.GET("/document/schema", e.GetDocumentSchema)
 		}
 
-		blocks := api.Group("/block")
+		api.GET("/activity", e.GetActivityFeed, ScopeMW("notes"))
+
+		blocks := api.Group("/block", ScopeMW("notes"))
 		{
 			blocks.GET("/types", e.GetRegisteredTypes)
 			blocks.GET("", e.GetBlock)
 			blocks.POST("", e.CreateBlock)
+			blocks.POST("/convert", e.ConvertHTML)
 			blocks.DELETE("", e.DeleteBlock)
 
 			blocks.POST("/op", e.OpBlock)
 			blocks.PATCH("/type", e.ChangeTypeBlock)
 
 			blocks.PATCH("/order", e.ChangeBlockOrder)
+
+			blocks.GET("/trash", e.GetDeletedBlocks)
+			blocks.PUT("/restore", e.RestoreBlock)
 		}
 
-		trash := api.Group("/trash")
+		trash := api.Group("/trash", ScopeMW("notes"))
 		{
 			trash.DELETE("", e.CleanTrash)
 			trash.PUT("/to", e.NoteToTrash)
 			trash.PUT("/from", e.NoteFromTrash)
 			trash.GET("/note", e.FindNoteInTrash)
 			trash.GET("", e.GetNotesFromTrash)
+			trash.DELETE("/note", e.PurgeNoteFromTrash)
+			trash.PATCH("/retention", e.SetTrashRetention)
 		}
 
-		tags := api.Group("/tag")
+		tags := api.Group("/tag", ScopeMW("tags"))
 		{
 			tags.GET("/by-user", e.GetTagsByUser)
 			tags.GET("/pinned", e.GetPinnedTagsByUser)
@@ -153,6 +247,40 @@ func New(
 
 			tags.DELETE("", e.DeleteTag)
 		}
+
+		comments := api.Group("/comment", ScopeMW("notes"))
+		{
+			comments.GET("", e.GetComments)
+			comments.POST("", e.CreateComment)
+			comments.PATCH("", e.UpdateComment)
+			comments.DELETE("", e.DeleteComment)
+
+			comments.PATCH("/resolve", e.ResolveThread)
+		}
+
+		workspaces := api.Group("/workspace", ScopeMW("workspaces"))
+		{
+			workspaces.GET("", e.GetWorkspaces)
+			workspaces.POST("", e.CreateWorkspace)
+			workspaces.DELETE("", e.DeleteWorkspace)
+
+			workspaces.GET("/members", e.GetWorkspaceMembers)
+			workspaces.POST("/members", e.AddWorkspaceMember, e.VerifiedEmailMW())
+			workspaces.DELETE("/members", e.RemoveWorkspaceMember)
+		}
+
+		// admin rights are checked by auth, personal access tokens have no admin scope
+		admin := api.Group("/admin", ScopeMW("admin"))
+		{
+			admin.GET("/users", e.AdminListUsers)
+			admin.GET("/user", e.AdminGetUser)
+			admin.PATCH("/user/disabled", e.AdminSetUserDisabled)
+			admin.PATCH("/user/role", e.AdminSetUserRole)
+			admin.POST("/user/reset", e.AdminForcePasswordReset)
+			admin.POST("/user/impersonate", e.AdminImpersonate)
+			admin.POST("/unlock", e.AdminUnlockLogin)
+			admin.GET("/audit", e.AdminGetAuditEvents)
+		}
 	}
 
 	return e
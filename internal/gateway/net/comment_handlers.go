@@ -0,0 +1,262 @@
+package net
+
+import (
+	"context"
+	"net/http"
+
+	brzrpc "github.com/autumnterror/breezynotes/api/proto/gen"
+	"github.com/autumnterror/breezynotes/internal/gateway/domain"
+	"github.com/autumnterror/utils_go/pkg/utils/uid"
+
+	"github.com/labstack/echo/v4"
+)
+
+// GetComments godoc
+// @Summary comments of note
+// @Description Returns all comments of note, oldest first. Replies have thread_id of root comment
+// @Tags comment
+// @Produce json
+// @Param id query string true "Note ID"
+// @Success 200 {object} []domain.Comment
+// @Failure 400 {object} domain.Error
+// @Failure 401 {object} domain.Error
+// @Failure 404 {object} domain.Error
+// @Failure 502 {object} domain.Error
+// @Failure 504 {object} domain.Error
+// @Router /api/comment [get]
+func (e *Echo) GetComments(c echo.Context) error {
+	const op = "gateway.net.GetComments"
+
+	api := e.bnAPI.API
+
+	noteId := c.QueryParam("id")
+	if noteId == "" {
+		return c.JSON(http.StatusBadRequest, domain.Error{Error: "bad param"})
+	}
+
+	idUser, errGetId := getIdUser(c)
+	if errGetId != nil {
+		return c.JSON(http.StatusUnauthorized, domain.Error{Error: "bad idUser from access token"})
+	}
+
+	ctx, done := context.WithTimeout(c.Request().Context(), domain.WaitTime)
+	defer done()
+
+	cmts, err := api.GetComments(ctx, &brzrpc.UserNoteId{NoteId: noteId, UserId: idUser})
+	code, errRes := bNErrors(op, err)
+	if code != http.StatusOK {
+		return c.JSON(code, errRes)
+	}
+
+	return c.JSON(http.StatusOK, domain.ToComments(cmts))
+}
+
+// CreateComment godoc
+// @Summary create comment
+// @Description Starts new thread on block (optionally on range of text) or replies to thread. Readers can comment
+// @Tags comment
+// @Accept json
+// @Produce json
+// @Param Comment body domain.CreateCommentRequest true "comment"
+// @Success 201 {object} domain.Id
+// @Failure 400 {object} domain.Error
+// @Failure 401 {object} domain.Error
+// @Failure 404 {object} domain.Error
+// @Failure 502 {object} domain.Error
+// @Failure 504 {object} domain.Error
+// @Router /api/comment [post]
+func (e *Echo) CreateComment(c echo.Context) error {
+	const op = "gateway.net.CreateComment"
+
+	api := e.bnAPI.API
+
+	idUser, errGetId := getIdUser(c)
+	if errGetId != nil {
+		return c.JSON(http.StatusUnauthorized, domain.Error{Error: "bad idUser from access token"})
+	}
+
+	var r domain.CreateCommentRequest
+	if err := c.Bind(&r); err != nil {
+		return c.JSON(http.StatusBadRequest, domain.Error{Error: "bad JSON"})
+	}
+
+	ctx, done := context.WithTimeout(c.Request().Context(), domain.WaitTime)
+	defer done()
+
+	mentions, code, errRes := e.mentionsToIds(ctx, op, r.Mentions)
+	if code != http.StatusOK {
+		return c.JSON(code, errRes)
+	}
+
+	var anchor *brzrpc.TextRange
+	if r.Anchor != nil {
+		anchor = &brzrpc.TextRange{Start: r.Anchor.Start, End: r.Anchor.End}
+	}
+
+	id := uid.New()
+	_, err := api.CreateComment(ctx, &brzrpc.CreateCommentRequest{
+		Id:       id,
+		NoteId:   r.NoteId,
+		BlockId:  r.BlockId,
+		ThreadId: r.ThreadId,
+		Text:     r.Text,
+		Mentions: mentions,
+		Anchor:   anchor,
+		UserId:   idUser,
+	})
+	code, errRes = bNErrors(op, err)
+	if code != http.StatusOK {
+		return c.JSON(code, errRes)
+	}
+
+	return c.JSON(http.StatusCreated, domain.Id{Id: id})
+}
+
+// UpdateComment godoc
+// @Summary edit comment
+// @Description Changes text and mentions of own comment
+// @Tags comment
+// @Accept json
+// @Produce json
+// @Param Comment body domain.UpdateCommentRequest true "comment"
+// @Success 204
+// @Failure 400 {object} domain.Error
+// @Failure 401 {object} domain.Error
+// @Failure 404 {object} domain.Error
+// @Failure 502 {object} domain.Error
+// @Failure 504 {object} domain.Error
+// @Router /api/comment [patch]
+func (e *Echo) UpdateComment(c echo.Context) error {
+	const op = "gateway.net.UpdateComment"
+
+	api := e.bnAPI.API
+
+	idUser, errGetId := getIdUser(c)
+	if errGetId != nil {
+		return c.JSON(http.StatusUnauthorized, domain.Error{Error: "bad idUser from access token"})
+	}
+
+	var r domain.UpdateCommentRequest
+	if err := c.Bind(&r); err != nil {
+		return c.JSON(http.StatusBadRequest, domain.Error{Error: "bad JSON"})
+	}
+
+	ctx, done := context.WithTimeout(c.Request().Context(), domain.WaitTime)
+	defer done()
+
+	mentions, code, errRes := e.mentionsToIds(ctx, op, r.Mentions)
+	if code != http.StatusOK {
+		return c.JSON(code, errRes)
+	}
+
+	_, err := api.UpdateComment(ctx, &brzrpc.UpdateCommentRequest{
+		Id:       r.Id,
+		Text:     r.Text,
+		Mentions: mentions,
+		UserId:   idUser,
+	})
+	code, errRes = bNErrors(op, err)
+	if code != http.StatusOK {
+		return c.JSON(code, errRes)
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// DeleteComment godoc
+// @Summary delete comment
+// @Description Deletes own comment. Delete of first comment in thread deletes whole thread
+// @Tags comment
+// @Produce json
+// @Param id query string true "Comment ID"
+// @Success 204
+// @Failure 400 {object} domain.Error
+// @Failure 401 {object} domain.Error
+// @Failure 404 {object} domain.Error
+// @Failure 502 {object} domain.Error
+// @Failure 504 {object} domain.Error
+// @Router /api/comment [delete]
+func (e *Echo) DeleteComment(c echo.Context) error {
+	const op = "gateway.net.DeleteComment"
+
+	api := e.bnAPI.API
+
+	id := c.QueryParam("id")
+	if id == "" {
+		return c.JSON(http.StatusBadRequest, domain.Error{Error: "bad param"})
+	}
+
+	idUser, errGetId := getIdUser(c)
+	if errGetId != nil {
+		return c.JSON(http.StatusUnauthorized, domain.Error{Error: "bad idUser from access token"})
+	}
+
+	ctx, done := context.WithTimeout(c.Request().Context(), domain.WaitTime)
+	defer done()
+
+	_, err := api.DeleteComment(ctx, &brzrpc.UserCommentId{CommentId: id, UserId: idUser})
+	code, errRes := bNErrors(op, err)
+	if code != http.StatusOK {
+		return c.JSON(code, errRes)
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// ResolveThread godoc
+// @Summary resolve or reopen thread
+// @Tags comment
+// @Accept json
+// @Produce json
+// @Param Thread body domain.ResolveThreadRequest true "thread"
+// @Success 204
+// @Failure 400 {object} domain.Error
+// @Failure 401 {object} domain.Error
+// @Failure 404 {object} domain.Error
+// @Failure 502 {object} domain.Error
+// @Failure 504 {object} domain.Error
+// @Router /api/comment/resolve [patch]
+func (e *Echo) ResolveThread(c echo.Context) error {
+	const op = "gateway.net.ResolveThread"
+
+	api := e.bnAPI.API
+
+	idUser, errGetId := getIdUser(c)
+	if errGetId != nil {
+		return c.JSON(http.StatusUnauthorized, domain.Error{Error: "bad idUser from access token"})
+	}
+
+	var r domain.ResolveThreadRequest
+	if err := c.Bind(&r); err != nil {
+		return c.JSON(http.StatusBadRequest, domain.Error{Error: "bad JSON"})
+	}
+
+	ctx, done := context.WithTimeout(c.Request().Context(), domain.WaitTime)
+	defer done()
+
+	_, err := api.ResolveThread(ctx, &brzrpc.ResolveThreadRequest{
+		ThreadId: r.ThreadId,
+		Resolved: r.Resolved,
+		UserId:   idUser,
+	})
+	code, errRes := bNErrors(op, err)
+	if code != http.StatusOK {
+		return c.JSON(code, errRes)
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// mentionsToIds convert logins of mentioned users to their ids
+func (e *Echo) mentionsToIds(ctx context.Context, op string, logins []string) ([]string, int, domain.Error) {
+	ids := make([]string, 0, len(logins))
+	for _, login := range logins {
+		id, err := e.authAPI.API.GetIdFromLogin(ctx, &brzrpc.String{Value: login})
+		code, errRes := authErrors(op, err)
+		if code != http.StatusOK {
+			return nil, code, errRes
+		}
+		ids = append(ids, id.GetId())
+	}
+	return ids, http.StatusOK, domain.Error{}
+}
@@ -0,0 +1,271 @@
+package net
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"strings"
+	"time"
+
+	brzrpc "github.com/autumnterror/breezynotes/api/proto/gen"
+	"github.com/autumnterror/breezynotes/internal/gateway/domain"
+	"github.com/autumnterror/utils_go/pkg/log"
+	"github.com/labstack/echo/v4"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// deletionBatch deletions claimed by worker at once
+const deletionBatch = 10
+
+// DeleteUser godoc
+// @Summary delete user account
+// @Description Schedules deletion of account. Until purge_at deletion can be cancelled by DELETE /api/user/deletion,
+// @Description then notes, tags, files of user and his access to notes of others are deleted. Repeated request returns same deletion
+// @Tags user
+// @Produce json
+// @Success 202 {object} domain.AccountDeletion
+// @Failure 400 {object} domain.Error
+// @Failure 401 {object} domain.Error
+// @Failure 404 {object} domain.Error
+// @Failure 502 {object} domain.Error
+// @Failure 504 {object} domain.Error
+// @Router /api/user [delete]
+func (e *Echo) DeleteUser(c echo.Context) error {
+	const op = "gateway.net.DeleteUser"
+
+	idUser, errGetId := getIdUser(c)
+	if errGetId != nil {
+		return c.JSON(http.StatusUnauthorized, domain.Error{Error: "bad idUser from access token"})
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request().Context(), domain.WaitTime)
+	defer cancel()
+
+	d, err := e.authAPI.API.RequestDeletion(ctx, &brzrpc.UserId{UserId: idUser})
+	code, errRes := authErrors(op, err)
+	if code != http.StatusOK {
+		return c.JSON(code, errRes)
+	}
+
+	return c.JSON(http.StatusAccepted, domain.AccountDeletionFromRpc(d))
+}
+
+// GetDeletion godoc
+// @Summary state of account deletion
+// @Description State is scheduled, running, done or cancelled
+// @Tags user
+// @Produce json
+// @Success 200 {object} domain.AccountDeletion
+// @Failure 400 {object} domain.Error
+// @Failure 401 {object} domain.Error
+// @Failure 404 {object} domain.Error "deletion wasn't requested"
+// @Failure 502 {object} domain.Error
+// @Failure 504 {object} domain.Error
+// @Router /api/user/deletion [get]
+func (e *Echo) GetDeletion(c echo.Context) error {
+	const op = "gateway.net.GetDeletion"
+
+	idUser, errGetId := getIdUser(c)
+	if errGetId != nil {
+		return c.JSON(http.StatusUnauthorized, domain.Error{Error: "bad idUser from access token"})
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request().Context(), domain.WaitTime)
+	defer cancel()
+
+	d, err := e.authAPI.API.GetDeletion(ctx, &brzrpc.UserId{UserId: idUser})
+	code, errRes := authErrors(op, err)
+	if code != http.StatusOK {
+		return c.JSON(code, errRes)
+	}
+
+	return c.JSON(http.StatusOK, domain.AccountDeletionFromRpc(d))
+}
+
+// CancelDeletion godoc
+// @Summary cancel account deletion
+// @Description Cancels scheduled deletion. Running deletion can't be cancelled
+// @Tags user
+// @Produce json
+// @Success 204
+// @Failure 400 {object} domain.Error
+// @Failure 401 {object} domain.Error
+// @Failure 404 {object} domain.Error "no scheduled deletion"
+// @Failure 502 {object} domain.Error
+// @Failure 504 {object} domain.Error
+// @Router /api/user/deletion [delete]
+func (e *Echo) CancelDeletion(c echo.Context) error {
+	const op = "gateway.net.CancelDeletion"
+
+	idUser, errGetId := getIdUser(c)
+	if errGetId != nil {
+		return c.JSON(http.StatusUnauthorized, domain.Error{Error: "bad idUser from access token"})
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request().Context(), domain.WaitTime)
+	defer cancel()
+
+	_, err := e.authAPI.API.CancelDeletion(ctx, &brzrpc.UserId{UserId: idUser})
+	code, errRes := authErrors(op, err)
+	if code != http.StatusOK {
+		return c.JSON(code, errRes)
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// RunDeletions make steps of due account deletions every cfg.DeletionPeriod until ctx is done.
+// Failed step is retried by auth later, so errors are only logged
+func (e *Echo) RunDeletions(ctx context.Context) {
+	const op = "gateway.net.RunDeletions"
+
+	t := time.NewTicker(e.cfg.DeletionPeriod)
+	defer t.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+			e.runDeletions(ctx, op)
+		}
+	}
+}
+
+func (e *Echo) runDeletions(ctx context.Context, op string) {
+	claimCtx, cancel := context.WithTimeout(ctx, domain.WaitTime)
+	ds, err := e.authAPI.Deletion.ClaimDeletions(claimCtx, &brzrpc.ClaimDeletionsRequest{Limit: deletionBatch})
+	cancel()
+	if err != nil {
+		log.Error(op, "claim deletions", err)
+		return
+	}
+
+	for _, d := range ds.GetDeletions() {
+		e.runDeletion(ctx, op, d)
+	}
+}
+
+// runDeletion make steps of deletion one by one until it is done or step fails
+func (e *Echo) runDeletion(ctx context.Context, op string, d *brzrpc.AccountDeletion) {
+	for d.GetState() == domain.DeletionRunning {
+		step := d.GetStep()
+
+		stepCtx, cancel := context.WithTimeout(ctx, domain.WaitTime)
+		err := e.deletionStep(stepCtx, d.GetUserId(), step)
+		cancel()
+
+		reqCtx, cancel := context.WithTimeout(ctx, domain.WaitTime)
+		if err != nil {
+			log.Error(op, "deletion of "+d.GetUserId()+" step "+step, err)
+			if _, err := e.authAPI.Deletion.FailDeletionStep(reqCtx, &brzrpc.DeletionStepRequest{
+				UserId: d.GetUserId(),
+				Step:   step,
+				Error:  err.Error(),
+			}); err != nil {
+				log.Error(op, "fail deletion step", err)
+			}
+			cancel()
+			return
+		}
+
+		d, err = e.authAPI.Deletion.CompleteDeletionStep(reqCtx, &brzrpc.DeletionStepRequest{UserId: d.GetUserId(), Step: step})
+		cancel()
+		if err != nil {
+			// deletion is claimed again after lease, step is made once more
+			log.Error(op, "complete deletion step", err)
+			return
+		}
+	}
+}
+
+// deletionStep make one step of deletion. Every step can be repeated: what is already deleted is skipped
+func (e *Echo) deletionStep(ctx context.Context, idUser, step string) error {
+	id := &brzrpc.UserId{UserId: idUser}
+	switch step {
+	case domain.DeletionStepFiles:
+		return e.deleteUserFiles(ctx, idUser)
+	case domain.DeletionStepAcl:
+		_, err := e.bnAPI.API.RemoveUserFromNotes(ctx, id)
+		return err
+	case domain.DeletionStepNotes:
+		if _, err := e.bnAPI.API.NotesToTrash(ctx, id); ignoreNotFound(err) != nil {
+			return err
+		}
+		_, err := e.bnAPI.API.CleanTrash(ctx, id)
+		return ignoreNotFound(err)
+	case domain.DeletionStepTags:
+		_, err := e.bnAPI.API.DeleteTags(ctx, id)
+		return ignoreNotFound(err)
+	case domain.DeletionStepCache:
+		_, err := e.rdsAPI.API.RmSessionByUser(ctx, id)
+		return ignoreNotFound(err)
+	}
+	return errors.New("unknown step " + step)
+}
+
+// deleteUserFiles delete files from blocks of notes of user, his photo and archive of his export
+func (e *Echo) deleteUserFiles(ctx context.Context, idUser string) error {
+	files, err := e.bnAPI.API.GetUserFiles(ctx, &brzrpc.UserId{UserId: idUser})
+	if ignoreNotFound(err) != nil {
+		return err
+	}
+	srcs := files.GetValues()
+
+	us, err := e.authAPI.API.GetInfos(ctx, &brzrpc.Ids{Ids: []string{idUser}})
+	if ignoreNotFound(err) != nil {
+		return err
+	}
+	for _, u := range us.GetUsers() {
+		srcs = append(srcs, u.GetPhoto())
+	}
+
+	job, err := e.rdsAPI.API.GetExportJob(ctx, &brzrpc.UserId{UserId: idUser})
+	if ignoreNotFound(err) != nil {
+		return err
+	}
+	if job != nil {
+		if err := os.Remove(exportPath(job.GetId())); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+	}
+
+	for _, src := range srcs {
+		name := localFileName(src)
+		if name == "" {
+			continue
+		}
+		if err := deleteFile(name); err != nil && !errors.Is(err, ErrFileNotFound) {
+			return err
+		}
+	}
+	return nil
+}
+
+// localFileName return name of file in FilesDir from src: name given by upload or url of it like /files/<name>.
+// Empty for other urls. Uploaded files have random names, so host isn't checked
+func localFileName(src string) string {
+	u, err := url.Parse(src)
+	if err != nil || u.Path == "" {
+		return ""
+	}
+	if !strings.Contains(u.Path, "/") && u.Host == "" {
+		return u.Path
+	}
+	dir, name := path.Split(u.Path)
+	if strings.TrimPrefix(dir, "/") != "files/" {
+		return ""
+	}
+	return name
+}
+
+func ignoreNotFound(err error) error {
+	if status.Code(err) == codes.NotFound {
+		return nil
+	}
+	return err
+}
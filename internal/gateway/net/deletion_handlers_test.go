@@ -0,0 +1,24 @@
+package net
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLocalFileName(t *testing.T) {
+	t.Parallel()
+	for src, name := range map[string]string{
+		"a1b2.png":        "a1b2.png",
+		"/files/a1b2.png": "a1b2.png",
+		"files/a1b2.png":  "a1b2.png",
+		"https://notes.example.com/files/a1b2.png": "a1b2.png",
+		"/files/a1b2.png?v=2#top":                  "a1b2.png",
+		"https://example.com/a1b2.png":             "",
+		"/static/a1b2.png":                         "",
+		"/files/":                                  "",
+		"":                                         "",
+	} {
+		assert.Equal(t, name, localFileName(src), src)
+	}
+}
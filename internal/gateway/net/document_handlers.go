@@ -0,0 +1,124 @@
+package net
+
+import (
+	"context"
+	"io"
+	"net/http"
+
+	brzrpc "github.com/autumnterror/breezynotes/api/proto/gen"
+	"github.com/autumnterror/breezynotes/internal/gateway/domain"
+	"github.com/labstack/echo/v4"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// maxDocumentSize of document which is converted, same as limit of blocknote
+const maxDocumentSize = 1 << 20
+
+// GetNoteDocument godoc
+// @Summary note in BreezyNotes document format
+// @Description Returns note as versioned JSON document, which doesn't depend on how blocks are stored.
+// @Description Schema of document is GET /api/note/document/schema
+// @Tags note
+// @Produce json
+// @Param id query string true "Note ID"
+// @Success 200 {object} object
+// @Failure 400 {object} domain.Error
+// @Failure 401 {object} domain.Error
+// @Failure 404 {object} domain.Error
+// @Failure 502 {object} domain.Error
+// @Failure 504 {object} domain.Error
+// @Router /api/note/document [get]
+func (e *Echo) GetNoteDocument(c echo.Context) error {
+	const op = "gateway.net.GetNoteDocument"
+
+	api := e.bnAPI.API
+
+	idNote := c.QueryParam("id")
+	if idNote == "" {
+		return c.JSON(http.StatusBadRequest, domain.Error{Error: "bad param"})
+	}
+
+	idUser, errGetId := getIdUser(c)
+	if errGetId != nil {
+		return c.JSON(http.StatusUnauthorized, domain.Error{Error: "bad idUser from access token"})
+	}
+
+	ctx, done := context.WithTimeout(c.Request().Context(), domain.WaitTime)
+	defer done()
+
+	n, err := api.GetNote(ctx, &brzrpc.UserNoteId{UserId: idUser, NoteId: idNote})
+	code, errRes := bNErrors(op, err)
+	if code != http.StatusOK {
+		return c.JSON(code, errRes)
+	}
+
+	doc, err := api.RenderNote(ctx, &brzrpc.RenderNoteRequest{Note: n, Format: "json"})
+	code, errRes = bNErrors(op, err)
+	if code != http.StatusOK {
+		return c.JSON(code, errRes)
+	}
+
+	return c.JSONBlob(http.StatusOK, []byte(doc.GetValue()))
+}
+
+// ConvertDocument godoc
+// @Summary check document and convert it to note
+// @Description Checks note in BreezyNotes document format by schema of its version and returns note with blocks.
+// @Description Note is not saved. Error of check is in 400 answer
+// @Tags note
+// @Accept json
+// @Produce json
+// @Param document body object true "Document"
+// @Success 200 {object} domain.NoteWithBlocks
+// @Failure 400 {object} domain.Error
+// @Failure 401 {object} domain.Error
+// @Failure 413 {object} domain.Error
+// @Failure 502 {object} domain.Error
+// @Failure 504 {object} domain.Error
+// @Router /api/note/document/convert [post]
+func (e *Echo) ConvertDocument(c echo.Context) error {
+	const op = "gateway.net.ConvertDocument"
+
+	raw, err := io.ReadAll(io.LimitReader(c.Request().Body, maxDocumentSize+1))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, domain.Error{Error: "bad body"})
+	}
+	if len(raw) > maxDocumentSize {
+		return c.JSON(http.StatusRequestEntityTooLarge, domain.Error{Error: "document is too big"})
+	}
+
+	ctx, done := context.WithTimeout(c.Request().Context(), domain.WaitTime)
+	defer done()
+
+	n, err := e.bnAPI.API.ConvertDocument(ctx, &brzrpc.String{Value: string(raw)})
+	code, errRes := bNErrors(op, err)
+	if code != http.StatusOK {
+		return c.JSON(code, errRes)
+	}
+
+	return c.JSON(http.StatusOK, domain.ToNoteWithBlocksDb(n))
+}
+
+// GetDocumentSchema godoc
+// @Summary JSON schema of BreezyNotes document format
+// @Description Schema has data of every registered block type
+// @Tags note
+// @Produce json
+// @Success 200 {object} object
+// @Failure 502 {object} domain.Error
+// @Failure 504 {object} domain.Error
+// @Router /api/note/document/schema [get]
+func (e *Echo) GetDocumentSchema(c echo.Context) error {
+	const op = "gateway.net.GetDocumentSchema"
+
+	ctx, done := context.WithTimeout(c.Request().Context(), domain.WaitTime)
+	defer done()
+
+	schema, err := e.bnAPI.API.GetDocumentSchema(ctx, &emptypb.Empty{})
+	code, errRes := bNErrors(op, err)
+	if code != http.StatusOK {
+		return c.JSON(code, errRes)
+	}
+
+	return c.JSONBlob(http.StatusOK, []byte(schema.GetValue()))
+}
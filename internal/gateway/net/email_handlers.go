@@ -0,0 +1,155 @@
+package net
+
+import (
+	"context"
+	"net/http"
+
+	brzrpc "github.com/autumnterror/breezynotes/api/proto/gen"
+	"github.com/autumnterror/breezynotes/internal/gateway/domain"
+
+	"github.com/labstack/echo/v4"
+)
+
+// VerifyEmail godoc
+// @Summary Confirm email
+// @Description Confirms email of user by token from letter
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param request body domain.VerifyEmailRequest true "token from letter"
+// @Success 204
+// @Failure 400 {object} domain.Error
+// @Failure 401 {object} domain.Error
+// @Failure 502 {object} domain.Error
+// @Failure 504 {object} domain.Error
+// @Router /api/auth/verify [post]
+func (e *Echo) VerifyEmail(c echo.Context) error {
+	const op = "gateway.net.VerifyEmail"
+
+	var r domain.VerifyEmailRequest
+	if err := c.Bind(&r); err != nil {
+		return c.JSON(http.StatusBadRequest, domain.Error{Error: "bad JSON"})
+	}
+	if r.Token == "" {
+		return c.JSON(http.StatusBadRequest, domain.Error{Error: "bad param"})
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request().Context(), domain.WaitTime)
+	defer cancel()
+
+	_, err := e.authAPI.API.VerifyEmail(ctx, &brzrpc.String{Value: r.Token})
+	code, errRes := authErrors(op, err)
+	if code != http.StatusOK {
+		return c.JSON(code, errRes)
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// ForgotPassword godoc
+// @Summary Request password reset
+// @Description Sends letter with reset link if email is registered. Response does not tell whether it is
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param request body domain.ForgotPasswordRequest true "email"
+// @Success 204
+// @Failure 400 {object} domain.Error
+// @Failure 502 {object} domain.Error
+// @Failure 504 {object} domain.Error
+// @Router /api/auth/forgot [post]
+func (e *Echo) ForgotPassword(c echo.Context) error {
+	const op = "gateway.net.ForgotPassword"
+
+	var r domain.ForgotPasswordRequest
+	if err := c.Bind(&r); err != nil {
+		return c.JSON(http.StatusBadRequest, domain.Error{Error: "bad JSON"})
+	}
+	if r.Email == "" {
+		return c.JSON(http.StatusBadRequest, domain.Error{Error: "bad param"})
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request().Context(), domain.WaitTime)
+	defer cancel()
+
+	_, err := e.authAPI.API.ForgotPassword(ctx, &brzrpc.String{Value: r.Email})
+	code, errRes := authErrors(op, err)
+	if code != http.StatusOK {
+		return c.JSON(code, errRes)
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// ResetPassword godoc
+// @Summary Reset password
+// @Description Sets new password by token from letter. All sessions of user are logged out
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param request body domain.ResetPasswordRequest true "token and new password"
+// @Success 204
+// @Failure 400 {object} domain.Error
+// @Failure 401 {object} domain.Error
+// @Failure 502 {object} domain.Error
+// @Failure 504 {object} domain.Error
+// @Router /api/auth/reset [post]
+func (e *Echo) ResetPassword(c echo.Context) error {
+	const op = "gateway.net.ResetPassword"
+
+	var r domain.ResetPasswordRequest
+	if err := c.Bind(&r); err != nil {
+		return c.JSON(http.StatusBadRequest, domain.Error{Error: "bad JSON"})
+	}
+	if r.Token == "" {
+		return c.JSON(http.StatusBadRequest, domain.Error{Error: "bad param"})
+	}
+	if r.NewPassword != r.NewPassword2 {
+		return c.JSON(http.StatusBadRequest, domain.Error{Error: "password not same"})
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request().Context(), domain.WaitTime)
+	defer cancel()
+
+	_, err := e.authAPI.API.ResetPassword(ctx, &brzrpc.ResetPasswordRequest{
+		Token:       r.Token,
+		NewPassword: r.NewPassword,
+	})
+	code, errRes := authErrors(op, err)
+	if code != http.StatusOK {
+		return c.JSON(code, errRes)
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// SendVerification godoc
+// @Summary Resend verification letter
+// @Description Sends new letter with link to confirm email. Links from previous letters stop working
+// @Tags user
+// @Produce json
+// @Success 204
+// @Failure 302 {object} domain.Error
+// @Failure 401 {object} domain.Error
+// @Failure 502 {object} domain.Error
+// @Failure 504 {object} domain.Error
+// @Router /api/user/verify/resend [post]
+func (e *Echo) SendVerification(c echo.Context) error {
+	const op = "gateway.net.SendVerification"
+
+	idUser, errGetId := getIdUser(c)
+	if errGetId != nil {
+		return c.JSON(http.StatusUnauthorized, domain.Error{Error: "bad idUser from access token"})
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request().Context(), domain.WaitTime)
+	defer cancel()
+
+	_, err := e.authAPI.API.SendVerification(ctx, &brzrpc.UserId{UserId: idUser})
+	code, errRes := authErrors(op, err)
+	if code != http.StatusOK {
+		return c.JSON(code, errRes)
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
@@ -0,0 +1,532 @@
+package net
+
+import (
+	"bufio"
+	"context"
+	"crypto/md5"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"html"
+	"io"
+	"mime"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	brzrpc "github.com/autumnterror/breezynotes/api/proto/gen"
+	"github.com/autumnterror/breezynotes/internal/gateway/domain"
+	"github.com/autumnterror/utils_go/pkg/log"
+	"github.com/labstack/echo/v4"
+)
+
+// enexTime format of times in ENEX
+const enexTime = "20060102T150405Z"
+
+var (
+	enMedia   = regexp.MustCompile(`<en-media\b[^>]*>(\s*</en-media>)?`)
+	enTodo    = regexp.MustCompile(`<en-todo\b[^>]*>(\s*</en-todo>)?`)
+	enHash    = regexp.MustCompile(`\bhash="([0-9a-fA-F]+)"`)
+	enChecked = regexp.MustCompile(`\bchecked="true"`)
+)
+
+// mimeExts extensions of common types, mime package gives rare ones first
+var mimeExts = map[string]string{
+	"image/png": ".png", "image/jpeg": ".jpg", "image/gif": ".gif", "image/webp": ".webp", "image/svg+xml": ".svg",
+	"application/pdf": ".pdf", "text/plain": ".txt", "audio/mpeg": ".mp3", "audio/wav": ".wav", "video/mp4": ".mp4",
+}
+
+// ImportEnex godoc
+// @Summary import notes of Evernote
+// @Description Creates notes from .enex export of Evernote notebook or from zip of several .enex files. File is read note
+// @Description by note, attachments are decoded to files while they are read. Name of .enex file is name of notebook.
+// @Description First tag of note or its notebook becomes its tag, other tags are reported. Checkboxes become todo lists,
+// @Description code blocks get detected lang, attachments are uploaded as file and img blocks. Notes which can't be read or created are reported in conflicts, others are still imported
+// @Tags user
+// @Accept multipart/form-data
+// @Produce json
+// @Param file formData file true ".enex file or zip of them"
+// @Success 200 {object} domain.ImportReport
+// @Failure 400 {object} domain.Error "Неверный формат файла"
+// @Failure 401 {object} domain.Error
+// @Failure 413 {object} domain.Error "file exceeds limits of import"
+// @Failure 429 {object} domain.Error
+// @Failure 502 {object} domain.Error
+// @Router /api/user/import/enex [post]
+func (e *Echo) ImportEnex(c echo.Context) error {
+	idUser, errGetId := getIdUser(c)
+	if errGetId != nil {
+		return c.JSON(http.StatusUnauthorized, domain.Error{Error: "bad idUser from access token"})
+	}
+
+	f, fh, err := formFile(c)
+	if err != nil {
+		code, errRes := formError(err)
+		return c.JSON(code, errRes)
+	}
+	defer f.Close()
+
+	r := domain.NewImportReport()
+	im := &noteImport{r: r, idUser: idUser, tags: make(map[string]string), files: make(map[string]string)}
+	ctx := c.Request().Context()
+
+	if !strings.EqualFold(path.Ext(fh.Filename), ".zip") {
+		if code, errRes := e.importEnex(ctx, im, f, notebookName(fh.Filename)); code != http.StatusOK {
+			return c.JSON(code, errRes)
+		}
+		return c.JSON(http.StatusOK, r)
+	}
+
+	zr, err := zipReader(f, fh.Size)
+	if err != nil {
+		code, errRes := formError(err)
+		return c.JSON(code, errRes)
+	}
+	// entries are read by importReader, so unpacked size is limited
+	x := newImportReader(zr)
+	found := false
+	for _, zf := range zr.File {
+		if !strings.EqualFold(path.Ext(zf.Name), ".enex") || hiddenPath(zf.Name) {
+			continue
+		}
+		found = true
+		rc, err := x.open(zf.Name)
+		if err != nil {
+			r.Conflict(domain.ConflictNoteInvalid, zf.Name, "", err.Error())
+			continue
+		}
+		code, errRes := e.importEnex(ctx, im, rc, notebookName(zf.Name))
+		_ = rc.Close()
+		if code != http.StatusOK {
+			return c.JSON(code, errRes)
+		}
+	}
+	if !found {
+		return c.JSON(http.StatusBadRequest, domain.Error{Error: "there are no .enex files in archive"})
+	}
+	return c.JSON(http.StatusOK, r)
+}
+
+type enexNote struct {
+	Title     string
+	Content   string
+	Created   string
+	Updated   string
+	Tags      []string
+	Resources []enexResource
+}
+
+// enexResource attachment of note. Data is decoded to file Name while note is read, Err is set if it can't be
+type enexResource struct {
+	Mime     string
+	FileName string
+	Name     string
+	// Hash md5 of data, content refers to resource by it
+	Hash string
+	Err  error
+}
+
+var (
+	errBadResource = errors.New("bad data of resource")
+	// errSaveResource data of resource can't be written to FilesDir, import is aborted
+	errSaveResource = errors.New("failed to save file")
+)
+
+// importEnex read notes of one .enex file one by one and import them. Broken file is reported,
+// notes before broken place stay imported
+func (e *Echo) importEnex(ctx context.Context, im *noteImport, rd io.Reader, notebook string) (int, domain.Error) {
+	const op = "gateway.net.importEnex"
+
+	r := &enexReader{br: bufio.NewReader(rd)}
+	dec := xml.NewDecoder(r)
+	for i := 0; ; {
+		tok, err := dec.Token()
+		if errors.Is(err, io.EOF) {
+			return http.StatusOK, domain.Error{}
+		}
+		if errors.Is(err, errImportLimit) {
+			return importError(op, err)
+		}
+		if err != nil {
+			im.r.Conflict(domain.ConflictNoteInvalid, notebook, "", "bad enex: "+err.Error())
+			return http.StatusOK, domain.Error{}
+		}
+		se, ok := tok.(xml.StartElement)
+		if !ok || se.Name.Local != "note" {
+			continue
+		}
+
+		i++
+		im.notes++
+		if err := checkImportNotes(im.notes); err != nil {
+			return importError(op, err)
+		}
+		n, err := readEnexNote(dec, r)
+		if err != nil {
+			dropResources(op, n)
+			switch {
+			case errors.Is(err, errImportLimit), errors.Is(err, errSaveResource):
+				return importError(op, err)
+			case errors.Is(err, errTooLarge):
+				im.r.Conflict(domain.ConflictNoteInvalid, notebook, n.Title, "note is too large, rest of file is skipped")
+			default:
+				im.r.Conflict(domain.ConflictNoteInvalid, notebook, n.Title, "bad enex: "+err.Error())
+			}
+			return http.StatusOK, domain.Error{}
+		}
+		if code, errRes := e.importEnexNote(ctx, im, n, notebook, i); code != http.StatusOK {
+			return code, errRes
+		}
+	}
+}
+
+// readEnexNote read note which start element is read by dec. Data of resources isn't kept in memory,
+// it is decoded to FilesDir while note is read. On error note has resources stored before it
+func readEnexNote(dec *xml.Decoder, r *enexReader) (*enexNote, error) {
+	n := &enexNote{}
+	r.text = 0
+
+	var (
+		stack []string
+		res   *enexResource
+	)
+	for {
+		tok, err := dec.Token()
+		if errors.Is(err, io.EOF) {
+			err = io.ErrUnexpectedEOF
+		}
+		if err != nil {
+			return n, err
+		}
+
+		switch t := tok.(type) {
+		case xml.StartElement:
+			stack = append(stack, t.Name.Local)
+			switch strings.Join(stack, ">") {
+			case "tag":
+				n.Tags = append(n.Tags, "")
+			case "resource":
+				n.Resources = append(n.Resources, enexResource{})
+				res = &n.Resources[len(n.Resources)-1]
+			case "resource>data":
+				if r.selfClosed() {
+					continue
+				}
+				if err := res.store(r); err != nil {
+					return n, err
+				}
+			}
+		case xml.EndElement:
+			if len(stack) == 0 {
+				return n, nil
+			}
+			if strings.Join(stack, ">") == "resource" {
+				if err := res.finish(); err != nil {
+					return n, err
+				}
+			}
+			stack = stack[:len(stack)-1]
+		case xml.CharData:
+			switch strings.Join(stack, ">") {
+			case "title":
+				n.Title += string(t)
+			case "content":
+				n.Content += string(t)
+			case "created":
+				n.Created += string(t)
+			case "updated":
+				n.Updated += string(t)
+			case "tag":
+				n.Tags[len(n.Tags)-1] += string(t)
+			case "resource>mime":
+				res.Mime += string(t)
+			case "resource>resource-attributes>file-name":
+				res.FileName += string(t)
+			}
+		}
+	}
+}
+
+// importEnexNote change ENML to html and create note with stored resources, blocknote converts it to blocks.
+// Id of note in report is notebook/number of note in it
+func (e *Echo) importEnexNote(ctx context.Context, im *noteImport, n *enexNote, notebook string, i int) (int, domain.Error) {
+	const op = "gateway.net.importEnexNote"
+
+	id := notebook + "/" + strconv.Itoa(i)
+	title := strings.TrimSpace(n.Title)
+	if title == "" {
+		title = "Untitled"
+	}
+
+	// resources are referred from content by md5 of them
+	byHash := make(map[string]enexResource, len(n.Resources))
+	var copied []string
+	for _, res := range n.Resources {
+		if res.Err != nil {
+			im.r.Conflict(domain.ConflictFileInvalid, res.FileName, title, res.Err.Error())
+			continue
+		}
+		key := id + "/" + res.Hash
+		im.files[key] = res.Name
+		copied = append(copied, key)
+		byHash[res.Hash] = res
+	}
+
+	content := enMedia.ReplaceAllStringFunc(n.Content, func(m string) string {
+		h := enHash.FindStringSubmatch(m)
+		if h == nil {
+			return ""
+		}
+		hash := strings.ToLower(h[1])
+		name, ok := im.files[id+"/"+hash]
+		if !ok {
+			im.r.Conflict(domain.ConflictFileMissing, hash, title, "resource is not in note")
+			return ""
+		}
+		res := byHash[hash]
+		if strings.HasPrefix(res.Mime, "image/") {
+			return `<img src="` + name + `" alt="` + html.EscapeString(res.FileName) + `">`
+		}
+		label := res.FileName
+		if label == "" {
+			label = name
+		}
+		return `<div><a href="` + name + `" download>` + html.EscapeString(label) + `</a></div>`
+	})
+	content = enTodo.ReplaceAllStringFunc(content, func(m string) string {
+		if enChecked.MatchString(m) {
+			return `<input type="checkbox" checked>`
+		}
+		return `<input type="checkbox">`
+	})
+
+	note := &brzrpc.NoteWithBlocks{
+		Id:        id,
+		Title:     title,
+		CreatedAt: parseEnexTime(n.Created),
+		UpdatedAt: parseEnexTime(n.Updated),
+	}
+
+	tag := notebook
+	if len(n.Tags) > 0 {
+		tag = n.Tags[0]
+		for _, t := range n.Tags[1:] {
+			im.r.Conflict(domain.ConflictTagDropped, id, title, "note can have one tag, tag "+t+" is not set")
+		}
+	}
+	if key, code, errRes := e.ensureTag(ctx, im, tag, tagEmoji); code != http.StatusOK {
+		im.dropFiles(op, copied)
+		return code, errRes
+	} else if key != "" {
+		note.Tag = &brzrpc.Tag{Id: key}
+	}
+
+	return e.createNote(ctx, im, &brzrpc.ImportNoteRequest{
+		UserId: im.idUser,
+		Note:   note,
+		Tags:   im.tags,
+		Html:   content,
+	}, copied)
+}
+
+// store decode base64 data of resource from r to FilesDir, name is fixed when mime is read, see finish.
+// Bad and too large data is skipped and set to Err
+func (res *enexResource) store(r *enexReader) error {
+	data := &enexData{br: r.br}
+	h := md5.New()
+	name, err := storeFile(io.TeeReader(base64.NewDecoder(base64.StdEncoding, data), h), ".bin")
+	var corrupt base64.CorruptInputError
+	switch {
+	case errors.As(err, &corrupt):
+		res.Err = errBadResource
+	case errors.Is(err, errTooLarge):
+		res.Err = err
+	case errors.Is(err, errImportLimit):
+		return err
+	case err != nil:
+		return fmt.Errorf("%w: %w", errSaveResource, err)
+	default:
+		res.Name, res.Hash = name, hex.EncodeToString(h.Sum(nil))
+		return nil
+	}
+	// rest of skipped data
+	_, err = io.Copy(io.Discard, data)
+	return err
+}
+
+// finish give file of resource extension by its name or mime
+func (res *enexResource) finish() error {
+	if res.Name == "" {
+		if res.Err == nil {
+			res.Err = errBadResource
+		}
+		return nil
+	}
+
+	ext := strings.ToLower(path.Ext(res.FileName))
+	if ext == "" || strings.ContainsAny(ext, `/\ `) {
+		ext = mimeExt(res.Mime)
+	}
+	if ext == "" || ext == ".bin" {
+		return nil
+	}
+	name := strings.TrimSuffix(res.Name, ".bin") + ext
+	if err := os.Rename(filepath.Join(FilesDir, res.Name), filepath.Join(FilesDir, name)); err != nil {
+		return fmt.Errorf("%w: %w", errSaveResource, err)
+	}
+	res.Name = name
+	return nil
+}
+
+// dropResources remove files of resources of note which isn't read to the end
+func dropResources(op string, n *enexNote) {
+	for _, res := range n.Resources {
+		if res.Name == "" {
+			continue
+		}
+		if err := deleteFile(res.Name); err != nil {
+			log.Error(op, "remove file of skipped note", err)
+		}
+	}
+}
+
+// enexReader give .enex to xml.Decoder byte by byte, decoder doesn't buffer io.ByteReader, so data of resource
+// can be read from br past decoder. Text of note without data of resources is limited by maxImportEntry
+type enexReader struct {
+	br         *bufio.Reader
+	prev, last byte
+	// text read since start of note
+	text int64
+}
+
+func (r *enexReader) Read(p []byte) (int, error) {
+	for i := range p {
+		b, err := r.ReadByte()
+		if err != nil {
+			return i, err
+		}
+		p[i] = b
+	}
+	return len(p), nil
+}
+
+func (r *enexReader) ReadByte() (byte, error) {
+	b, err := r.br.ReadByte()
+	if err != nil {
+		return 0, err
+	}
+	r.text++
+	if r.text > maxImportEntry {
+		return 0, errTooLarge
+	}
+	r.prev, r.last = r.last, b
+	return b, nil
+}
+
+// selfClosed report whether start element which decoder just returned is <element/>, it has no text to read
+func (r *enexReader) selfClosed() bool {
+	return r.prev == '/' && r.last == '>'
+}
+
+// enexData base64 text of data of resource without spaces. It ends before '<' of end element
+type enexData struct {
+	br *bufio.Reader
+}
+
+func (d *enexData) Read(p []byte) (int, error) {
+	n := 0
+	for n < len(p) {
+		b, err := d.br.ReadByte()
+		if errors.Is(err, io.EOF) {
+			err = io.ErrUnexpectedEOF
+		}
+		if err != nil {
+			return n, err
+		}
+		if b == '<' {
+			_ = d.br.UnreadByte()
+			break
+		}
+		if b == ' ' || b == '\t' || b == '\n' || b == '\r' {
+			continue
+		}
+		p[n] = b
+		n++
+	}
+	if n == 0 {
+		return 0, io.EOF
+	}
+	return n, nil
+}
+
+// ensureTag create tag for import if it isn't created yet and return its key, see vault.tags
+func (e *Echo) ensureTag(ctx context.Context, im *noteImport, title, emoji string) (string, int, domain.Error) {
+	const op = "gateway.net.ensureTag"
+
+	key := tagKey(title)
+	if key == "" {
+		return "", http.StatusOK, domain.Error{}
+	}
+	if _, ok := im.tags[key]; ok {
+		return key, http.StatusOK, domain.Error{}
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, domain.WaitTime)
+	defer cancel()
+	tr, err := e.bnAPI.API.ImportTags(ctx, &brzrpc.ImportTagsRequest{
+		UserId: im.idUser,
+		Tags:   []*brzrpc.Tag{{Id: key, Title: strings.TrimSpace(title), Color: vaultTagColor, Emoji: emoji}},
+	})
+	if err != nil {
+		code, errRes := bNErrors(op, err)
+		return "", code, errRes
+	}
+	im.r.Add(tr)
+	// tag which can't be created isn't tried again
+	im.tags[key] = tr.GetIds()[key]
+	if im.tags[key] == "" {
+		return "", http.StatusOK, domain.Error{}
+	}
+	return key, http.StatusOK, domain.Error{}
+}
+
+// dropFiles remove files of note which isn't imported
+func (im *noteImport) dropFiles(op string, copied []string) {
+	for _, name := range copied {
+		if errDel := deleteFile(im.files[name]); errDel != nil {
+			log.Error(op, "remove file of skipped note", errDel)
+		}
+		delete(im.files, name)
+	}
+}
+
+func mimeExt(typ string) string {
+	typ, _, _ = strings.Cut(typ, ";")
+	if ext, ok := mimeExts[strings.TrimSpace(typ)]; ok {
+		return ext
+	}
+	if exts, err := mime.ExtensionsByType(typ); err == nil && len(exts) > 0 {
+		return exts[0]
+	}
+	return ""
+}
+
+func parseEnexTime(s string) int64 {
+	t, err := time.Parse(enexTime, strings.TrimSpace(s))
+	if err != nil {
+		return 0
+	}
+	return t.Unix()
+}
+
+// notebookName name of notebook from name of .enex file
+func notebookName(file string) string {
+	return strings.TrimSuffix(path.Base(strings.ReplaceAll(file, `\`, "/")), path.Ext(file))
+}
@@ -0,0 +1,96 @@
+package net
+
+import (
+	"bufio"
+	"crypto/md5"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/xml"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// enexDecoder start reading of enex like importEnex and return decoder positioned after <note>
+func enexDecoder(t *testing.T, enex string) (*xml.Decoder, *enexReader) {
+	t.Helper()
+	r := &enexReader{br: bufio.NewReader(strings.NewReader(enex))}
+	dec := xml.NewDecoder(r)
+	for {
+		tok, err := dec.Token()
+		require.NoError(t, err)
+		if se, ok := tok.(xml.StartElement); ok && se.Name.Local == "note" {
+			return dec, r
+		}
+	}
+}
+
+func TestReadEnexNote(t *testing.T) {
+	if _, err := os.Stat(FilesDir); os.IsNotExist(err) {
+		t.Cleanup(func() { _ = os.RemoveAll(FilesDir) })
+	}
+
+	png := []byte("\x89PNG not really an image, but enough bytes for two lines of base64 data")
+	data := base64.StdEncoding.EncodeToString(png)
+	sum := md5.Sum(png)
+
+	enex := `<?xml version="1.0" encoding="UTF-8"?>
+<!DOCTYPE en-export SYSTEM "http://xml.evernote.com/pub/evernote-export3.dtd">
+<en-export>
+<note>
+	<title>Trip</title>
+	<content><![CDATA[<en-note><div>plan</div><en-media hash="` + hex.EncodeToString(sum[:]) + `" type="image/png"/></en-note>]]></content>
+	<created>20240101T100000Z</created>
+	<resource>
+		<data encoding="base64">
+` + data[:40] + `
+` + data[40:] + `
+		</data>
+		<mime>image/png</mime>
+		<resource-attributes><file-name>map</file-name></resource-attributes>
+	</resource>
+	<resource><data encoding="base64"/><mime>text/plain</mime></resource>
+	<resource><data encoding="base64">%%%%</data><mime>text/plain</mime></resource>
+	<tag>travel</tag>
+	<tag>2024</tag>
+</note>
+</en-export>`
+
+	dec, r := enexDecoder(t, enex)
+	n, err := readEnexNote(dec, r)
+	require.NoError(t, err)
+	t.Cleanup(func() { dropResources("test", n) })
+
+	assert.Equal(t, "Trip", n.Title)
+	assert.Contains(t, n.Content, "<en-media")
+	assert.Equal(t, "20240101T100000Z", n.Created)
+	assert.Equal(t, []string{"travel", "2024"}, n.Tags)
+	require.Len(t, n.Resources, 3)
+
+	img := n.Resources[0]
+	require.NoError(t, img.Err)
+	assert.Equal(t, hex.EncodeToString(sum[:]), img.Hash)
+	assert.Equal(t, ".png", filepath.Ext(img.Name), "extension is given by mime after data is read")
+	stored, err := os.ReadFile(filepath.Join(FilesDir, img.Name))
+	require.NoError(t, err)
+	assert.Equal(t, png, stored)
+
+	assert.ErrorIs(t, n.Resources[1].Err, errBadResource, "resource without data")
+	assert.ErrorIs(t, n.Resources[2].Err, errBadResource)
+	assert.Empty(t, n.Resources[2].Name)
+
+	_, err = dec.Token()
+	assert.NoError(t, err, "decoder continues after note")
+}
+
+func TestReadEnexNoteTooLarge(t *testing.T) {
+	enex := `<en-export><note><title>Big</title><content>` + strings.Repeat("a", maxImportEntry) + `</content></note></en-export>`
+
+	dec, r := enexDecoder(t, enex)
+	_, err := readEnexNote(dec, r)
+	assert.ErrorIs(t, err, errTooLarge)
+}
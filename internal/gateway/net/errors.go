@@ -70,10 +70,14 @@ func authErrors(op string, err error) (int, domain.Error) {
 			return http.StatusFound, domain.Error{Error: "already exist"}
 		case codes.FailedPrecondition:
 			return http.StatusFailedDependency, domain.Error{Error: "bad foreign key"}
+		case codes.Aborted:
+			return http.StatusConflict, domain.Error{Error: "changed by other request, reload it"}
 		case codes.InvalidArgument:
 			return http.StatusBadRequest, domain.Error{Error: st.Message()}
 		case codes.ResourceExhausted:
 			return http.StatusUnauthorized, domain.Error{Error: "terminate auth. Refresh token is expired"}
+		case codes.PermissionDenied:
+			return http.StatusGone, domain.Error{Error: "terminate auth. Session is revoked"}
 		case codes.Internal:
 			return http.StatusBadGateway, domain.Error{Error: "check logs on service"}
 		case codes.DeadlineExceeded:
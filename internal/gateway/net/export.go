@@ -0,0 +1,385 @@
+package net
+
+import (
+	"archive/zip"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	brzrpc "github.com/autumnterror/breezynotes/api/proto/gen"
+	"github.com/autumnterror/breezynotes/internal/gateway/domain"
+	"github.com/autumnterror/utils_go/pkg/log"
+	"github.com/autumnterror/utils_go/pkg/utils/format"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	ExportsDir = "./exports"
+	// exportTimeout export which runs longer is lost, for example gateway was restarted
+	exportTimeout = 30 * time.Minute
+	// exportWorkers exports made at once, others wait in queue
+	exportWorkers = 2
+	// exportVersion of archive layout, see exportManifest
+	exportVersion = 2
+	// exportVersionProto archive with notes in protojson, it can be imported still
+	exportVersionProto = 1
+)
+
+// exportManifest is manifest.json of archive. Every note is notes/<id>.json in BreezyNotes document format
+// (protojson of note with blocks in version 1) and notes/<id>.md, notes from trash are in trash/ same way,
+// files of file and img blocks and photo are in files/
+type exportManifest struct {
+	Version   int          `json:"version"`
+	UserId    string       `json:"user_id"`
+	CreatedAt int64        `json:"created_at"`
+	Notes     []exportNote `json:"notes"`
+	Trash     []exportNote `json:"trash"`
+	Files     []string     `json:"files"`
+	// Missing files which are used in notes, but are not found on server
+	Missing []string `json:"missing,omitempty"`
+}
+
+type exportNote struct {
+	Id    string `json:"id"`
+	Title string `json:"title"`
+	// Role of user: author, editor or reader
+	Role string `json:"role,omitempty"`
+}
+
+func exportPath(id string) string {
+	return filepath.Join(ExportsDir, id+".zip")
+}
+
+// exportLost report if queued or running job will never finish
+func exportLost(job *brzrpc.ExportJob) bool {
+	switch job.GetState() {
+	case domain.ExportQueued, domain.ExportRunning:
+		return time.Since(time.Unix(job.GetCreatedAt(), 0)) > exportTimeout
+	}
+	return false
+}
+
+func (e *Echo) saveExportJob(ctx context.Context, op string, job *brzrpc.ExportJob) {
+	if _, err := e.rdsAPI.API.SetExportJob(ctx, &brzrpc.ExportJobTtl{
+		Job:             job,
+		TtlMilliseconds: time.Until(time.Unix(job.GetExpiresAt(), 0)).Milliseconds(),
+	}); err != nil {
+		log.Error(op, "REDIS ERROR", err)
+	}
+}
+
+// runExport make archive of job and save result. It waits for free worker, so it must be run in goroutine
+func (e *Echo) runExport(job *brzrpc.ExportJob) {
+	const op = "gateway.net.runExport"
+
+	e.exportSlots <- struct{}{}
+	defer func() { <-e.exportSlots }()
+
+	ctx, cancel := context.WithTimeout(context.Background(), exportTimeout)
+	defer cancel()
+
+	job.State = domain.ExportRunning
+	e.saveExportJob(ctx, op, job)
+
+	var size int64
+	ctx, err := e.exportContext(ctx, op, job.GetUserId())
+	if err == nil {
+		size, err = e.writeExport(ctx, job)
+	}
+
+	now := time.Now().UTC()
+	job.FinishedAt = now.Unix()
+	job.ExpiresAt = now.Add(e.cfg.ExportTtl).Unix()
+	if err != nil {
+		log.Error(op, "export of "+job.GetUserId(), err)
+		job.State = domain.ExportFailed
+		job.Error = "export failed, try again later"
+	} else {
+		job.State = domain.ExportReady
+		job.Size = size
+	}
+
+	saveCtx, done := context.WithTimeout(context.Background(), domain.WaitTime)
+	defer done()
+	e.saveExportJob(saveCtx, op, job)
+}
+
+// exportContext give ctx workspace roles of user like WorkspacesMW gives them to request,
+// so notes of his workspaces are exported too
+func (e *Echo) exportContext(ctx context.Context, op, idUser string) (context.Context, error) {
+	pairs, err := e.workspaceRoles(ctx, op, idUser)
+	if err != nil {
+		return ctx, err
+	}
+	if pairs != "" {
+		ctx = metadata.AppendToOutgoingContext(ctx, domain.WorkspaceRolesMD, pairs)
+	}
+	return ctx, nil
+}
+
+// writeExport write archive to temp file and move it to exportPath, so half written archive is never downloaded
+func (e *Echo) writeExport(ctx context.Context, job *brzrpc.ExportJob) (int64, error) {
+	const op = "gateway.net.writeExport"
+
+	if err := os.MkdirAll(ExportsDir, 0755); err != nil {
+		return 0, format.Error(op, err)
+	}
+
+	dst := exportPath(job.GetId())
+	tmp := dst + ".tmp"
+	f, err := os.Create(tmp)
+	if err != nil {
+		return 0, format.Error(op, err)
+	}
+
+	zw := zip.NewWriter(f)
+	err = e.fillExport(ctx, &exportWriter{zw: zw}, job.GetUserId())
+	if errClose := zw.Close(); err == nil {
+		err = errClose
+	}
+	if errClose := f.Close(); err == nil {
+		err = errClose
+	}
+	if err == nil {
+		err = os.Rename(tmp, dst)
+	}
+	if err != nil {
+		_ = os.Remove(tmp)
+		return 0, format.Error(op, err)
+	}
+
+	st, err := os.Stat(dst)
+	if err != nil {
+		return 0, format.Error(op, err)
+	}
+	return st.Size(), nil
+}
+
+func (e *Echo) fillExport(ctx context.Context, x *exportWriter, idUser string) error {
+	const op = "gateway.net.fillExport"
+
+	m := exportManifest{
+		Version:   exportVersion,
+		UserId:    idUser,
+		CreatedAt: time.Now().UTC().Unix(),
+		Notes:     []exportNote{},
+		Trash:     []exportNote{},
+		Files:     []string{},
+	}
+	var srcs []string
+
+	us, err := e.authAPI.API.GetInfos(ctx, &brzrpc.Ids{Ids: []string{idUser}})
+	if err != nil {
+		return format.Error(op, err)
+	}
+	if len(us.GetUsers()) != 1 {
+		return format.Error(op, errors.New("user not found"))
+	}
+	u := us.GetUsers()[0]
+	u.Password = ""
+	srcs = append(srcs, u.GetPhoto())
+
+	p, err := e.getPreferences(ctx, op, idUser)
+	if err != nil {
+		return format.Error(op, err)
+	}
+	if err := x.json("profile.json", map[string]any{
+		"user":        domain.UserFromRpc(u),
+		"preferences": domain.PreferencesFromRpc(p),
+	}); err != nil {
+		return err
+	}
+
+	tags, err := e.bnAPI.API.GetTagsByUser(ctx, &brzrpc.UserWorkspaceId{UserId: idUser})
+	if ignoreNotFound(err) != nil {
+		return format.Error(op, err)
+	}
+	if err := x.proto("tags.json", tags); err != nil {
+		return err
+	}
+
+	notes, err := e.bnAPI.API.GetAllNotes(ctx, &brzrpc.UserWorkspaceId{UserId: idUser})
+	if ignoreNotFound(err) != nil {
+		return format.Error(op, err)
+	}
+	for _, np := range notes.GetItems() {
+		n, err := e.bnAPI.API.GetNote(ctx, &brzrpc.UserNoteId{UserId: idUser, NoteId: np.GetId()})
+		if err != nil {
+			return format.Error(op, err)
+		}
+		if err := e.exportNote(ctx, x, "notes", n); err != nil {
+			return err
+		}
+		m.Notes = append(m.Notes, exportNote{Id: n.GetId(), Title: n.GetTitle(), Role: np.GetRole()})
+		srcs = append(srcs, blockFiles(n)...)
+	}
+
+	trash, err := e.bnAPI.API.GetNotesFromTrash(ctx, &brzrpc.UserId{UserId: idUser})
+	if ignoreNotFound(err) != nil {
+		return format.Error(op, err)
+	}
+	for _, np := range trash.GetItems() {
+		n, err := e.bnAPI.API.FindNoteInTrash(ctx, &brzrpc.UserNoteId{UserId: idUser, NoteId: np.GetId()})
+		if err != nil {
+			return format.Error(op, err)
+		}
+		if err := e.exportNote(ctx, x, "trash", n); err != nil {
+			return err
+		}
+		m.Trash = append(m.Trash, exportNote{Id: n.GetId(), Title: n.GetTitle()})
+		srcs = append(srcs, blockFiles(n)...)
+	}
+
+	seen := make(map[string]bool)
+	for _, src := range srcs {
+		name := localFileName(src)
+		if name == "" || seen[name] {
+			continue
+		}
+		seen[name] = true
+
+		err := x.file("files/"+name, filepath.Join(FilesDir, name))
+		switch {
+		case errors.Is(err, os.ErrNotExist):
+			m.Missing = append(m.Missing, name)
+		case err != nil:
+			return err
+		default:
+			m.Files = append(m.Files, name)
+		}
+	}
+
+	return x.json("manifest.json", m)
+}
+
+// exportNote write note in document format and as markdown
+func (e *Echo) exportNote(ctx context.Context, x *exportWriter, dir string, n *brzrpc.NoteWithBlocks) error {
+	const op = "gateway.net.exportNote"
+
+	doc, err := e.bnAPI.API.RenderNote(ctx, &brzrpc.RenderNoteRequest{Note: n, Format: "json"})
+	if err != nil {
+		return format.Error(op, err)
+	}
+	if err := x.text(dir+"/"+n.GetId()+".json", doc.GetValue()); err != nil {
+		return err
+	}
+	md, err := e.bnAPI.API.RenderNote(ctx, &brzrpc.RenderNoteRequest{Note: n, Format: "md"})
+	if err != nil {
+		return format.Error(op, err)
+	}
+	return x.text(dir+"/"+n.GetId()+".md", md.GetValue())
+}
+
+// blockFiles return src of file and img blocks of note
+func blockFiles(n *brzrpc.NoteWithBlocks) []string {
+	var res []string
+	for _, b := range n.GetBlocks() {
+		if b.GetType() != "file" && b.GetType() != "img" {
+			continue
+		}
+		if src := b.GetData().GetFields()["src"].GetStringValue(); src != "" {
+			res = append(res, src)
+		}
+	}
+	return res
+}
+
+type exportWriter struct {
+	zw *zip.Writer
+}
+
+func (x *exportWriter) create(name string) (io.Writer, error) {
+	const op = "gateway.net.exportWriter.create"
+	w, err := x.zw.CreateHeader(&zip.FileHeader{Name: name, Method: zip.Deflate, Modified: time.Now().UTC()})
+	if err != nil {
+		return nil, format.Error(op, err)
+	}
+	return w, nil
+}
+
+func (x *exportWriter) text(name, s string) error {
+	w, err := x.create(name)
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(w, strings.NewReader(s))
+	return err
+}
+
+func (x *exportWriter) json(name string, v any) error {
+	raw, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	return x.text(name, string(raw))
+}
+
+func (x *exportWriter) proto(name string, m proto.Message) error {
+	raw, err := protojson.MarshalOptions{Multiline: true, Indent: "  ", EmitUnpopulated: true}.Marshal(m)
+	if err != nil {
+		return err
+	}
+	return x.text(name, string(raw))
+}
+
+// file copy file from disk. Error is os.ErrNotExist if there is no such file, then archive is not changed
+func (x *exportWriter) file(name, path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	w, err := x.create(name)
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(w, f)
+	return err
+}
+
+// RunExportCleaner delete archives of exports which are expired until ctx is done
+func (e *Echo) RunExportCleaner(ctx context.Context) {
+	const op = "gateway.net.RunExportCleaner"
+
+	t := time.NewTicker(time.Hour)
+	defer t.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+			if err := cleanExports(e.cfg.ExportTtl + exportTimeout); err != nil {
+				log.Error(op, "", err)
+			}
+		}
+	}
+}
+
+func cleanExports(ttl time.Duration) error {
+	entries, err := os.ReadDir(ExportsDir)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+		return err
+	}
+	for _, en := range entries {
+		info, err := en.Info()
+		if err != nil || en.IsDir() || time.Since(info.ModTime()) < ttl {
+			continue
+		}
+		if err := os.Remove(filepath.Join(ExportsDir, en.Name())); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,166 @@
+package net
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"os"
+	"time"
+
+	brzrpc "github.com/autumnterror/breezynotes/api/proto/gen"
+	"github.com/autumnterror/breezynotes/internal/gateway/domain"
+	"github.com/autumnterror/utils_go/pkg/log"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// StartExport godoc
+// @Summary export all data of user
+// @Description Starts making of zip archive with profile, notes of user and notes shared with him (in BreezyNotes document format and markdown),
+// @Description tags, trash and uploaded files. If export is already running, returns it. Poll GET /api/user/export for state,
+// @Description when it is ready archive can be downloaded by download_url until expires_at
+// @Tags user
+// @Produce json
+// @Success 202 {object} domain.ExportJob
+// @Failure 401 {object} domain.Error
+// @Failure 429 {object} domain.Error
+// @Failure 502 {object} domain.Error
+// @Router /api/user/export [post]
+func (e *Echo) StartExport(c echo.Context) error {
+	const op = "gateway.net.StartExport"
+
+	idUser, errGetId := getIdUser(c)
+	if errGetId != nil {
+		return c.JSON(http.StatusUnauthorized, domain.Error{Error: "bad idUser from access token"})
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request().Context(), domain.WaitTime)
+	defer cancel()
+
+	old, err := e.rdsAPI.API.GetExportJob(ctx, &brzrpc.UserId{UserId: idUser})
+	switch {
+	case err == nil:
+		if (old.GetState() == domain.ExportQueued || old.GetState() == domain.ExportRunning) && !exportLost(old) {
+			return c.JSON(http.StatusAccepted, domain.ExportJobFromRpc(old))
+		}
+	case status.Code(err) != codes.NotFound:
+		log.Error(op, "REDIS ERROR", err)
+		return c.JSON(http.StatusBadGateway, domain.Error{Error: "check logs on service"})
+	}
+
+	now := time.Now().UTC()
+	job := &brzrpc.ExportJob{
+		Id:        uuid.NewString(),
+		UserId:    idUser,
+		State:     domain.ExportQueued,
+		CreatedAt: now.Unix(),
+		ExpiresAt: now.Add(exportTimeout + e.cfg.ExportTtl).Unix(),
+	}
+	if _, err := e.rdsAPI.API.SetExportJob(ctx, &brzrpc.ExportJobTtl{
+		Job:             job,
+		TtlMilliseconds: (exportTimeout + e.cfg.ExportTtl).Milliseconds(),
+	}); err != nil {
+		log.Error(op, "REDIS ERROR", err)
+		return c.JSON(http.StatusBadGateway, domain.Error{Error: "check logs on service"})
+	}
+
+	// only last export can be downloaded
+	if old != nil {
+		if err := os.Remove(exportPath(old.GetId())); err != nil && !errors.Is(err, os.ErrNotExist) {
+			log.Error(op, "remove old export", err)
+		}
+	}
+
+	go e.runExport(proto.Clone(job).(*brzrpc.ExportJob))
+
+	return c.JSON(http.StatusAccepted, domain.ExportJobFromRpc(job))
+}
+
+// GetExport godoc
+// @Summary state of last export
+// @Description State is queued, running, ready or failed. Ready export has download_url
+// @Tags user
+// @Produce json
+// @Success 200 {object} domain.ExportJob
+// @Failure 401 {object} domain.Error
+// @Failure 404 {object} domain.Error "no export or it is expired"
+// @Failure 502 {object} domain.Error
+// @Router /api/user/export [get]
+func (e *Echo) GetExport(c echo.Context) error {
+	const op = "gateway.net.GetExport"
+
+	idUser, errGetId := getIdUser(c)
+	if errGetId != nil {
+		return c.JSON(http.StatusUnauthorized, domain.Error{Error: "bad idUser from access token"})
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request().Context(), domain.WaitTime)
+	defer cancel()
+
+	job, code, errRes := e.getExportJob(ctx, op, idUser)
+	if code != http.StatusOK {
+		return c.JSON(code, errRes)
+	}
+
+	return c.JSON(http.StatusOK, domain.ExportJobFromRpc(job))
+}
+
+// DownloadExport godoc
+// @Summary download archive of export
+// @Description Only owner of export can download it, link works until expires_at of export
+// @Tags user
+// @Produce application/zip
+// @Param id path string true "export id"
+// @Success 200 {file} file
+// @Failure 401 {object} domain.Error
+// @Failure 404 {object} domain.Error "no such ready export or it is expired"
+// @Failure 502 {object} domain.Error
+// @Router /api/user/export/{id}/download [get]
+func (e *Echo) DownloadExport(c echo.Context) error {
+	const op = "gateway.net.DownloadExport"
+
+	idUser, errGetId := getIdUser(c)
+	if errGetId != nil {
+		return c.JSON(http.StatusUnauthorized, domain.Error{Error: "bad idUser from access token"})
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request().Context(), domain.WaitTime)
+	defer cancel()
+
+	job, code, errRes := e.getExportJob(ctx, op, idUser)
+	if code != http.StatusOK {
+		return c.JSON(code, errRes)
+	}
+	if job.GetId() != c.Param("id") || job.GetState() != domain.ExportReady || time.Now().Unix() >= job.GetExpiresAt() {
+		return c.JSON(http.StatusNotFound, domain.Error{Error: "export not found"})
+	}
+
+	path := exportPath(job.GetId())
+	if _, err := os.Stat(path); err != nil {
+		log.Error(op, "archive of ready export", err)
+		return c.JSON(http.StatusNotFound, domain.Error{Error: "export not found"})
+	}
+
+	return c.Attachment(path, "breezynotes-export-"+time.Unix(job.GetCreatedAt(), 0).UTC().Format("2006-01-02")+".zip")
+}
+
+// getExportJob return last export of user. Lost export is shown as failed
+func (e *Echo) getExportJob(ctx context.Context, op, idUser string) (*brzrpc.ExportJob, int, domain.Error) {
+	job, err := e.rdsAPI.API.GetExportJob(ctx, &brzrpc.UserId{UserId: idUser})
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return nil, http.StatusNotFound, domain.Error{Error: "export not found"}
+		}
+		log.Error(op, "REDIS ERROR", err)
+		return nil, http.StatusBadGateway, domain.Error{Error: "check logs on service"}
+	}
+
+	if exportLost(job) {
+		job.State = domain.ExportFailed
+		job.Error = "export was interrupted, try again"
+	}
+	return job, http.StatusOK, domain.Error{}
+}
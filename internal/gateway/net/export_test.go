@@ -0,0 +1,35 @@
+package net
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	brzrpc "github.com/autumnterror/breezynotes/api/proto/gen"
+	"github.com/autumnterror/breezynotes/internal/gateway/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestExportContext(t *testing.T) {
+	t.Parallel()
+	a := &fakeAuth{workspaces: map[string][]*brzrpc.Workspace{
+		"user": {{Id: "w1", Role: "editor"}, {Id: "w2", Role: "viewer"}},
+	}}
+	e := newTestEcho(a, &fakeRedis{roles: map[string]string{}})
+
+	ctx, err := e.exportContext(context.Background(), "test", "user")
+	require.NoError(t, err)
+	md, _ := metadata.FromOutgoingContext(ctx)
+	assert.Equal(t, []string{"w1:editor,w2:viewer"}, md.Get(domain.WorkspaceRolesMD))
+
+	ctx, err = e.exportContext(context.Background(), "test", "alone")
+	require.NoError(t, err)
+	md, _ = metadata.FromOutgoingContext(ctx)
+	assert.Empty(t, md.Get(domain.WorkspaceRolesMD), "user without workspaces")
+
+	a.err = errors.New("auth is down")
+	_, err = e.exportContext(context.Background(), "test", "other")
+	assert.Error(t, err, "export fails instead of losing notes of workspaces")
+}
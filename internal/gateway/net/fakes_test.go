@@ -0,0 +1,157 @@
+package net
+
+import (
+	"context"
+
+	brzrpc "github.com/autumnterror/breezynotes/api/proto/gen"
+	"github.com/autumnterror/breezynotes/internal/gateway/clients/auth"
+	"github.com/autumnterror/breezynotes/internal/gateway/clients/blocknote"
+	"github.com/autumnterror/breezynotes/internal/gateway/clients/redis"
+	"github.com/autumnterror/breezynotes/internal/gateway/config"
+	"github.com/autumnterror/breezynotes/internal/gateway/domain"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// fakeRedis cache of workspace roles and preferences in memory, other methods of interface panic
+type fakeRedis struct {
+	brzrpc.RedisServiceClient
+	roles map[string]string
+	prefs map[string]*brzrpc.Preferences
+	// cleaned ids of notes dropped from cache of all users
+	cleaned []string
+}
+
+func (f *fakeRedis) GetWorkspaceRolesByUser(_ context.Context, in *brzrpc.UserId, _ ...grpc.CallOption) (*brzrpc.String, error) {
+	r, ok := f.roles[in.GetUserId()]
+	if !ok {
+		return nil, status.Error(codes.NotFound, "not found")
+	}
+	return &brzrpc.String{Value: r}, nil
+}
+
+func (f *fakeRedis) SetWorkspaceRolesByUser(_ context.Context, in *brzrpc.WorkspaceRolesByUser, _ ...grpc.CallOption) (*emptypb.Empty, error) {
+	f.roles[in.GetUserId()] = in.GetRoles()
+	return &emptypb.Empty{}, nil
+}
+
+func (f *fakeRedis) GetPreferencesByUser(_ context.Context, in *brzrpc.UserId, _ ...grpc.CallOption) (*brzrpc.Preferences, error) {
+	p, ok := f.prefs[in.GetUserId()]
+	if !ok {
+		return nil, status.Error(codes.NotFound, "not found")
+	}
+	return proto.Clone(p).(*brzrpc.Preferences), nil
+}
+
+func (f *fakeRedis) SetPreferencesByUser(_ context.Context, in *brzrpc.PreferencesByUser, _ ...grpc.CallOption) (*emptypb.Empty, error) {
+	f.prefs[in.GetUserId()] = proto.Clone(in.GetPreferences()).(*brzrpc.Preferences)
+	return &emptypb.Empty{}, nil
+}
+
+func (f *fakeRedis) RmPreferencesByUser(_ context.Context, in *brzrpc.UserId, _ ...grpc.CallOption) (*emptypb.Empty, error) {
+	delete(f.prefs, in.GetUserId())
+	return &emptypb.Empty{}, nil
+}
+
+// RateLimit never limits
+func (f *fakeRedis) RateLimit(_ context.Context, _ *brzrpc.RateLimitRequest, _ ...grpc.CallOption) (*brzrpc.RateLimitResponse, error) {
+	return &brzrpc.RateLimitResponse{Count: 1}, nil
+}
+
+func (f *fakeRedis) CleanNoteById(_ context.Context, in *brzrpc.NoteId, _ ...grpc.CallOption) (*emptypb.Empty, error) {
+	f.cleaned = append(f.cleaned, in.GetNoteId())
+	return &emptypb.Empty{}, nil
+}
+
+func (f *fakeRedis) RmNoteListByUser(_ context.Context, _ *brzrpc.UserId, _ ...grpc.CallOption) (*emptypb.Empty, error) {
+	return &emptypb.Empty{}, nil
+}
+
+func (f *fakeRedis) RmNotesFromTrashByUser(_ context.Context, _ *brzrpc.UserId, _ ...grpc.CallOption) (*emptypb.Empty, error) {
+	return &emptypb.Empty{}, nil
+}
+
+// fakeAuth workspaces and preferences of users, err is returned by all calls if set
+type fakeAuth struct {
+	brzrpc.AuthServiceClient
+	workspaces map[string][]*brzrpc.Workspace
+	prefs      map[string]*brzrpc.Preferences
+	// pats owners of personal access tokens, unknown token is rejected same as revoked one
+	pats  map[string]*brzrpc.PatOwner
+	calls int
+	err   error
+}
+
+// ValidatePat "brzpat_expired" is expired token
+func (f *fakeAuth) ValidatePat(_ context.Context, in *brzrpc.Token, _ ...grpc.CallOption) (*brzrpc.PatOwner, error) {
+	if in.GetValue() == domain.PatPrefix+"expired" {
+		return nil, status.Error(codes.ResourceExhausted, "token expired")
+	}
+	p, ok := f.pats[in.GetValue()]
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "token invalid")
+	}
+	return p, nil
+}
+
+func (f *fakeAuth) GetPreferences(_ context.Context, in *brzrpc.UserId, _ ...grpc.CallOption) (*brzrpc.Preferences, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	return proto.Clone(f.prefs[in.GetUserId()]).(*brzrpc.Preferences), nil
+}
+
+// UpdatePreferences rejects old revision same as auth repository
+func (f *fakeAuth) UpdatePreferences(_ context.Context, in *brzrpc.UpdatePreferencesRequest, _ ...grpc.CallOption) (*brzrpc.Preferences, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	p := proto.Clone(in.GetPreferences()).(*brzrpc.Preferences)
+	if p.GetRevision() != f.prefs[in.GetUserId()].GetRevision() {
+		return nil, status.Error(codes.Aborted, "obj was changed by other request")
+	}
+	p.Revision++
+	p.TrashRetentionDays = 0
+	f.prefs[in.GetUserId()] = p
+	return proto.Clone(p).(*brzrpc.Preferences), nil
+}
+
+func (f *fakeAuth) GetWorkspacesByUser(_ context.Context, in *brzrpc.UserId, _ ...grpc.CallOption) (*brzrpc.Workspaces, error) {
+	f.calls++
+	if f.err != nil {
+		return nil, f.err
+	}
+	return &brzrpc.Workspaces{Items: f.workspaces[in.GetUserId()]}, nil
+}
+
+// fakeBlocknote trash retention of users, other methods of interface panic
+type fakeBlocknote struct {
+	brzrpc.BlockNoteServiceClient
+	retention map[string]int32
+}
+
+func (f *fakeBlocknote) GetTrashRetention(_ context.Context, in *brzrpc.UserId, _ ...grpc.CallOption) (*brzrpc.TrashRetention, error) {
+	return &brzrpc.TrashRetention{Days: f.retention[in.GetUserId()]}, nil
+}
+
+// ConvertHTML returns one text block with html as text
+func (f *fakeBlocknote) ConvertHTML(_ context.Context, in *brzrpc.ConvertHTMLRequest, _ ...grpc.CallOption) (*brzrpc.Blocks, error) {
+	data, err := structpb.NewStruct(map[string]any{"text": in.GetHtml()})
+	if err != nil {
+		return nil, err
+	}
+	return &brzrpc.Blocks{Items: []*brzrpc.Block{{Type: "text", NoteId: in.GetNoteId(), Data: data}}}, nil
+}
+
+func (f *fakeBlocknote) SetTrashRetention(_ context.Context, in *brzrpc.TrashRetentionRequest, _ ...grpc.CallOption) (*emptypb.Empty, error) {
+	f.retention[in.GetUserId()] = in.GetDays()
+	return &emptypb.Empty{}, nil
+}
+
+func newTestEcho(a *fakeAuth, r *fakeRedis) *Echo {
+	return New(&config.Config{}, &auth.Client{API: a}, &blocknote.Client{}, &redis.Client{API: r})
+}
@@ -0,0 +1,473 @@
+package net
+
+import (
+	"archive/zip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	brzrpc "github.com/autumnterror/breezynotes/api/proto/gen"
+	"github.com/autumnterror/breezynotes/internal/gateway/domain"
+	"github.com/autumnterror/utils_go/pkg/log"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+const (
+	MaxImportBytes = 100 << 20 // 100 MB
+	// maxImportEntry size of manifest, tags and note in archive
+	maxImportEntry = 64 << 20
+	// maxImportUnpacked total size of data read from archive, zip can be packed much better than notes are
+	maxImportUnpacked = 512 << 20
+	// maxImportEntries count of entries in zip archive
+	maxImportEntries = 10000
+	// maxImportNotes count of notes in one import
+	maxImportNotes = 2000
+)
+
+// errImportLimit import is aborted, because archive exceeds one of limits
+var errImportLimit = errors.New("import limit exceeded")
+
+// ImportArchive godoc
+// @Summary import archive of export
+// @Description Creates notes, blocks, tags and files from archive made by export, also on other server. All ids are new,
+// @Description creation and update times are kept. Only notes which user of archive authored are imported, without sharing.
+// @Description Tags with same title as existing are merged. Notes which user already has (same title and creation time)
+// @Description are skipped, so import can be repeated. Everything merged or skipped is in conflicts of report
+// @Tags user
+// @Accept multipart/form-data
+// @Produce json
+// @Param file formData file true "zip archive of export"
+// @Success 200 {object} domain.ImportReport
+// @Failure 400 {object} domain.Error "Неверный формат архива"
+// @Failure 401 {object} domain.Error
+// @Failure 413 {object} domain.Error "archive exceeds limits of import"
+// @Failure 429 {object} domain.Error
+// @Failure 502 {object} domain.Error
+// @Router /api/user/import [post]
+func (e *Echo) ImportArchive(c echo.Context) error {
+	const op = "gateway.net.ImportArchive"
+
+	idUser, errGetId := getIdUser(c)
+	if errGetId != nil {
+		return c.JSON(http.StatusUnauthorized, domain.Error{Error: "bad idUser from access token"})
+	}
+
+	zr, f, err := formZip(c)
+	if err != nil {
+		code, errRes := formError(err)
+		return c.JSON(code, errRes)
+	}
+	defer f.Close()
+	x := newImportReader(zr)
+
+	var m exportManifest
+	if err := x.json("manifest.json", &m); err != nil {
+		return c.JSON(http.StatusBadRequest, domain.Error{Error: "bad manifest.json, it is not archive of export"})
+	}
+	if m.Version < exportVersionProto || m.Version > exportVersion {
+		return c.JSON(http.StatusBadRequest, domain.Error{Error: "unsupported version of archive"})
+	}
+	if err := checkImportNotes(len(m.Notes) + len(m.Trash)); err != nil {
+		code, errRes := importError(op, err)
+		return c.JSON(code, errRes)
+	}
+	var tags brzrpc.Tags
+	if err := x.proto("tags.json", &tags); err != nil {
+		return c.JSON(http.StatusBadRequest, domain.Error{Error: "bad tags.json"})
+	}
+
+	r := domain.NewImportReport()
+
+	ctx, cancel := context.WithTimeout(c.Request().Context(), domain.WaitTime)
+	tr, err := e.bnAPI.API.ImportTags(ctx, &brzrpc.ImportTagsRequest{UserId: idUser, Tags: tags.GetItems()})
+	cancel()
+	if err != nil {
+		code, errRes := bNErrors(op, err)
+		return c.JSON(code, errRes)
+	}
+	r.Add(tr)
+
+	im := &noteImport{x: x, r: r, version: m.Version, idUser: idUser, tags: tr.GetIds(), files: make(map[string]string)}
+	for _, en := range m.Notes {
+		if en.Role != domain.AuthorRole {
+			r.Conflict(domain.ConflictNotAuthor, en.Id, en.Title, "note of other user is not imported")
+			continue
+		}
+		if code, errRes := e.importNote(c.Request().Context(), im, "notes/"+en.Id+".json", en, false); code != http.StatusOK {
+			return c.JSON(code, errRes)
+		}
+	}
+	for _, en := range m.Trash {
+		if code, errRes := e.importNote(c.Request().Context(), im, "trash/"+en.Id+".json", en, true); code != http.StatusOK {
+			return c.JSON(code, errRes)
+		}
+	}
+
+	return c.JSON(http.StatusOK, r)
+}
+
+// formFile open file of import from multipart field "file". Error is message for user
+func formFile(c echo.Context) (multipart.File, *multipart.FileHeader, error) {
+	fh, err := c.FormFile("file")
+	if err != nil {
+		return nil, nil, errors.New("file field 'file' is required")
+	}
+	if fh.Size > MaxImportBytes {
+		return nil, nil, fmt.Errorf("%w: archive is larger than %d MB", errImportLimit, MaxImportBytes>>20)
+	}
+	f, err := fh.Open()
+	if err != nil {
+		return nil, nil, errors.New("cannot open uploaded file")
+	}
+	return f, fh, nil
+}
+
+// formZip open zip archive from multipart field "file". Error is message for user
+func formZip(c echo.Context) (*zip.Reader, io.Closer, error) {
+	f, fh, err := formFile(c)
+	if err != nil {
+		return nil, nil, err
+	}
+	zr, err := zipReader(f, fh.Size)
+	if err != nil {
+		_ = f.Close()
+		return nil, nil, err
+	}
+	return zr, f, nil
+}
+
+// zipReader open zip archive of import and check count of its entries. Error is message for user
+func zipReader(f io.ReaderAt, size int64) (*zip.Reader, error) {
+	zr, err := zip.NewReader(f, size)
+	if err != nil {
+		return nil, errors.New("file is not zip archive")
+	}
+	if len(zr.File) > maxImportEntries {
+		return nil, fmt.Errorf("%w: archive has more than %d entries", errImportLimit, maxImportEntries)
+	}
+	return zr, nil
+}
+
+// formError response for error of formFile and formZip
+func formError(err error) (int, domain.Error) {
+	if errors.Is(err, errImportLimit) {
+		return http.StatusRequestEntityTooLarge, domain.Error{Error: err.Error()}
+	}
+	return http.StatusBadRequest, domain.Error{Error: err.Error()}
+}
+
+// checkImportNotes return errImportLimit if import has more than maxImportNotes notes
+func checkImportNotes(count int) error {
+	if count > maxImportNotes {
+		return fmt.Errorf("%w: archive has more than %d notes", errImportLimit, maxImportNotes)
+	}
+	return nil
+}
+
+// importError response for error which aborts import: exceeded limit or failed copy of files
+func importError(op string, err error) (int, domain.Error) {
+	if errors.Is(err, errImportLimit) {
+		return http.StatusRequestEntityTooLarge, domain.Error{Error: err.Error()}
+	}
+	log.Error(op, "copy of files", err)
+	return http.StatusInternalServerError, domain.Error{Error: "failed to save file"}
+}
+
+// noteImport state of import shared by notes. files maps names of files in archive to new names,
+// empty new name is for file which can't be imported
+type noteImport struct {
+	x *importReader
+	r *domain.ImportReport
+	// version of archive, notes are in protojson before version 2
+	version int
+	idUser  string
+	tags    map[string]string
+	files   map[string]string
+	// notes read from streamed files, their count isn't known before import
+	notes int
+}
+
+// importNote import note from archive with its files. Bad note is reported as conflict, not as error
+func (e *Echo) importNote(ctx context.Context, im *noteImport, name string, en exportNote, trash bool) (int, domain.Error) {
+	const op = "gateway.net.importNote"
+
+	n, code, errRes := e.readNote(ctx, im, name, en)
+	if n == nil {
+		return code, errRes
+	}
+
+	copied, err := im.importFiles(n)
+	if err != nil {
+		im.dropFiles(op, copied)
+		return importError(op, err)
+	}
+
+	return e.createNote(ctx, im, &brzrpc.ImportNoteRequest{
+		UserId: im.idUser,
+		Note:   n,
+		Trash:  trash,
+		Tags:   im.tags,
+	}, copied)
+}
+
+// readNote read note from archive. Bad note is reported as conflict, then note is nil and code is ok
+func (e *Echo) readNote(ctx context.Context, im *noteImport, name string, en exportNote) (*brzrpc.NoteWithBlocks, int, domain.Error) {
+	const op = "gateway.net.readNote"
+
+	if im.version == exportVersionProto {
+		var n brzrpc.NoteWithBlocks
+		if err := im.x.proto(name, &n); err != nil {
+			if errors.Is(err, errImportLimit) {
+				code, errRes := importError(op, err)
+				return nil, code, errRes
+			}
+			im.r.Conflict(domain.ConflictNoteInvalid, en.Id, en.Title, err.Error())
+			return nil, http.StatusOK, domain.Error{}
+		}
+		return &n, http.StatusOK, domain.Error{}
+	}
+
+	raw, err := im.x.read(name)
+	if err != nil {
+		if errors.Is(err, errImportLimit) {
+			code, errRes := importError(op, err)
+			return nil, code, errRes
+		}
+		im.r.Conflict(domain.ConflictNoteInvalid, en.Id, en.Title, err.Error())
+		return nil, http.StatusOK, domain.Error{}
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, domain.WaitTime)
+	defer cancel()
+
+	n, err := e.bnAPI.API.ConvertDocument(ctx, &brzrpc.String{Value: string(raw)})
+	switch {
+	case err == nil:
+		return n, http.StatusOK, domain.Error{}
+	case status.Code(err) == codes.InvalidArgument:
+		im.r.Conflict(domain.ConflictNoteInvalid, en.Id, en.Title, status.Convert(err).Message())
+		return nil, http.StatusOK, domain.Error{}
+	default:
+		code, errRes := bNErrors(op, err)
+		return nil, code, errRes
+	}
+}
+
+// createNote send note to blocknote. If note isn't created, files copied for it are removed.
+// Skipped and bad note is reported as conflict, not as error
+func (e *Echo) createNote(ctx context.Context, im *noteImport, req *brzrpc.ImportNoteRequest, copied []string) (int, domain.Error) {
+	const op = "gateway.net.createNote"
+
+	ctx, cancel := context.WithTimeout(ctx, domain.WaitTime)
+	defer cancel()
+
+	n := req.GetNote()
+	rr, err := e.bnAPI.API.ImportNote(ctx, req)
+	if err == nil && rr.GetIds()[n.GetId()] != "" {
+		im.r.Add(rr)
+		im.r.Files += int32(len(copied))
+		return http.StatusOK, domain.Error{}
+	}
+
+	// note isn't imported, so its new files are not used
+	im.dropFiles(op, copied)
+
+	switch {
+	case err == nil:
+		im.r.Add(rr)
+	case status.Code(err) == codes.InvalidArgument:
+		im.r.Conflict(domain.ConflictNoteInvalid, n.GetId(), n.GetTitle(), status.Convert(err).Message())
+	default:
+		return bNErrors(op, err)
+	}
+	return http.StatusOK, domain.Error{}
+}
+
+// importFiles copy files of file and img blocks from archive to FilesDir under new names and change src of blocks.
+// It returns names in archive of files copied for this note. Missing and bad files are reported, src of their blocks stays
+func (im *noteImport) importFiles(n *brzrpc.NoteWithBlocks) ([]string, error) {
+	var copied []string
+	for _, b := range n.GetBlocks() {
+		if b.GetType() != "file" && b.GetType() != "img" {
+			continue
+		}
+		src := b.GetData().GetFields()["src"].GetStringValue()
+		name := localFileName(src)
+		if name == "" {
+			continue
+		}
+
+		newName, ok := im.files[name]
+		if !ok {
+			var err error
+			newName, err = im.x.file(name)
+			switch {
+			case errors.Is(err, os.ErrNotExist):
+				im.r.Conflict(domain.ConflictFileMissing, name, n.GetTitle(), "file is not in archive")
+			case errors.Is(err, errTooLarge), errors.Is(err, ErrFileName):
+				im.r.Conflict(domain.ConflictFileInvalid, name, n.GetTitle(), err.Error())
+			case err != nil:
+				return copied, err
+			default:
+				copied = append(copied, name)
+			}
+			im.files[name] = newName
+		}
+		if newName != "" {
+			b.Data.Fields["src"] = structpb.NewStringValue(renameFileSrc(src, newName))
+		}
+	}
+	return copied, nil
+}
+
+// renameFileSrc return src of local file with new name, host of other server is dropped
+func renameFileSrc(src, newName string) string {
+	u, err := url.Parse(src)
+	if err != nil {
+		return newName
+	}
+	dir, _ := path.Split(u.Path)
+	return dir + newName
+}
+
+type importReader struct {
+	files map[string]*zip.File
+	// unpacked bytes read from archive, sizes in zip are not trusted
+	unpacked int64
+}
+
+func newImportReader(zr *zip.Reader) *importReader {
+	x := &importReader{files: make(map[string]*zip.File, len(zr.File))}
+	for _, f := range zr.File {
+		x.files[f.Name] = f
+	}
+	return x
+}
+
+// open entry of archive. Error is os.ErrNotExist if there is no such entry
+func (x *importReader) open(name string) (io.ReadCloser, error) {
+	f, ok := x.files[name]
+	if !ok {
+		return nil, os.ErrNotExist
+	}
+	rc, err := f.Open()
+	if err != nil {
+		return nil, err
+	}
+	return &importEntry{ReadCloser: rc, x: x}, nil
+}
+
+// importEntry count data read from archive. Read fails with errImportLimit after maxImportUnpacked bytes
+type importEntry struct {
+	io.ReadCloser
+	x *importReader
+}
+
+func (e *importEntry) Read(p []byte) (int, error) {
+	n, err := e.ReadCloser.Read(p)
+	e.x.unpacked += int64(n)
+	if e.x.unpacked > maxImportUnpacked {
+		return n, fmt.Errorf("%w: archive unpacks to more than %d MB", errImportLimit, maxImportUnpacked>>20)
+	}
+	return n, err
+}
+
+// read entry which is not larger than maxImportEntry
+func (x *importReader) read(name string) ([]byte, error) {
+	rc, err := x.open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+
+	raw, err := io.ReadAll(io.LimitReader(rc, maxImportEntry+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(raw) > maxImportEntry {
+		return nil, errTooLarge
+	}
+	return raw, nil
+}
+
+func (x *importReader) json(name string, v any) error {
+	raw, err := x.read(name)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(raw, v)
+}
+
+func (x *importReader) proto(name string, m proto.Message) error {
+	raw, err := x.read(name)
+	if err != nil {
+		return err
+	}
+	return protojson.UnmarshalOptions{DiscardUnknown: true}.Unmarshal(raw, m)
+}
+
+// file copy files/<name> of archive to FilesDir and return new name of it. Error is os.ErrNotExist
+// if there is no such file, errTooLarge if it is larger than MaxUploadBytes
+func (x *importReader) file(name string) (string, error) {
+	if strings.ContainsAny(name, `/\`) {
+		return "", ErrFileName
+	}
+	return x.copy("files/" + name)
+}
+
+// copy entry of archive to FilesDir under random name with same extension, see file
+func (x *importReader) copy(entry string) (string, error) {
+	ext := strings.ToLower(path.Ext(entry))
+	if ext == "" || strings.ContainsAny(ext, `/\ `) {
+		return "", ErrFileName
+	}
+
+	rc, err := x.open(entry)
+	if err != nil {
+		return "", err
+	}
+	defer rc.Close()
+	return storeFile(rc, ext)
+}
+
+// storeFile write file of import to FilesDir under random name with extension ext.
+// Error is errTooLarge if it is larger than MaxUploadBytes, then nothing is written
+func storeFile(rd io.Reader, ext string) (string, error) {
+	if err := os.MkdirAll(FilesDir, 0755); err != nil {
+		return "", err
+	}
+	newName := uuid.NewString() + ext
+	dstPath := filepath.Join(FilesDir, newName)
+	dst, err := os.Create(dstPath)
+	if err != nil {
+		return "", err
+	}
+
+	written, err := io.Copy(dst, io.LimitReader(rd, MaxUploadBytes+1))
+	if errClose := dst.Close(); err == nil {
+		err = errClose
+	}
+	if err == nil && written > MaxUploadBytes {
+		err = errTooLarge
+	}
+	if err != nil {
+		_ = os.Remove(dstPath)
+		return "", err
+	}
+	return newName, nil
+}
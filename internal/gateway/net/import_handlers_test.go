@@ -0,0 +1,93 @@
+package net
+
+import (
+	"archive/zip"
+	"bytes"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func zipOf(t *testing.T, entries map[string]string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for name, body := range entries {
+		w, err := zw.Create(name)
+		require.NoError(t, err)
+		_, err = io.WriteString(w, body)
+		require.NoError(t, err)
+	}
+	require.NoError(t, zw.Close())
+	return buf.Bytes()
+}
+
+func uploadContext(t *testing.T, file []byte) echo.Context {
+	t.Helper()
+	var body bytes.Buffer
+	mw := multipart.NewWriter(&body)
+	w, err := mw.CreateFormFile("file", "import.zip")
+	require.NoError(t, err)
+	_, err = w.Write(file)
+	require.NoError(t, err)
+	require.NoError(t, mw.Close())
+
+	req := httptest.NewRequest(http.MethodPost, "/api/user/import", &body)
+	req.Header.Set(echo.HeaderContentType, mw.FormDataContentType())
+	return echo.New().NewContext(req, httptest.NewRecorder())
+}
+
+func TestFormZipEntries(t *testing.T) {
+	t.Parallel()
+	entries := make(map[string]string, maxImportEntries+1)
+	for i := range maxImportEntries + 1 {
+		entries["notes/"+strconv.Itoa(i)+".md"] = ""
+	}
+
+	_, _, err := formZip(uploadContext(t, zipOf(t, entries)))
+	assert.ErrorIs(t, err, errImportLimit)
+	code, _ := formError(err)
+	assert.Equal(t, http.StatusRequestEntityTooLarge, code)
+
+	delete(entries, "notes/0.md")
+	_, f, err := formZip(uploadContext(t, zipOf(t, entries)))
+	require.NoError(t, err)
+	assert.NoError(t, f.Close())
+
+	_, _, err = formZip(uploadContext(t, []byte("not zip")))
+	code, _ = formError(err)
+	assert.Equal(t, http.StatusBadRequest, code)
+}
+
+func TestImportReaderUnpacked(t *testing.T) {
+	t.Parallel()
+	raw := zipOf(t, map[string]string{"a.md": strings.Repeat("a", 100), "b.md": strings.Repeat("b", 100)})
+	zr, err := zip.NewReader(bytes.NewReader(raw), int64(len(raw)))
+	require.NoError(t, err)
+	x := newImportReader(zr)
+
+	_, err = x.read("a.md")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(100), x.unpacked)
+
+	x.unpacked = maxImportUnpacked - 50
+	_, err = x.read("b.md")
+	assert.ErrorIs(t, err, errImportLimit, "limit is shared by entries")
+}
+
+func TestCheckImportNotes(t *testing.T) {
+	t.Parallel()
+	assert.NoError(t, checkImportNotes(maxImportNotes))
+	assert.ErrorIs(t, checkImportNotes(maxImportNotes+1), errImportLimit)
+
+	code, _ := importError("test", checkImportNotes(maxImportNotes+1))
+	assert.Equal(t, http.StatusRequestEntityTooLarge, code)
+}
@@ -2,23 +2,119 @@ package net
 
 import (
 	"context"
+	"errors"
 	"net/http"
-	"time"
+	"slices"
+	"strings"
 
 	brzrpc "github.com/autumnterror/breezynotes/api/proto/gen"
+	"github.com/autumnterror/breezynotes/internal/gateway/clients/auth"
 	"github.com/autumnterror/breezynotes/internal/gateway/domain"
+	"github.com/autumnterror/utils_go/pkg/log"
 	"github.com/autumnterror/utils_go/pkg/utils/uid"
 	"github.com/labstack/echo/v4"
+	"google.golang.org/grpc/metadata"
 )
 
+// userIdFromToken check access token locally by JWKS and ask auth only for tokens signed by unknown key
+func (e *Echo) userIdFromToken(ctx context.Context, token string) (string, error) {
+	id, err := e.authAPI.Verifier.AccessId(ctx, token)
+	if err == nil || !errors.Is(err, auth.ErrUnknownKey) {
+		return id, err
+	}
+
+	u, err := e.authAPI.API.GetIdFromToken(ctx, &brzrpc.Token{Value: token})
+	if err != nil {
+		return "", err
+	}
+	return u.GetId(), nil
+}
+
+// bearerPat return personal access token from Authorization header or empty string
+func bearerPat(c echo.Context) string {
+	t, ok := strings.CutPrefix(c.Request().Header.Get(echo.HeaderAuthorization), "Bearer ")
+	if !ok || !strings.HasPrefix(t, domain.PatPrefix) {
+		return ""
+	}
+	return t
+}
+
+// authByPat set owner and scopes of personal access token to context. Return false if response is written
+func (e *Echo) authByPat(ctx context.Context, c echo.Context, pat string) (bool, error) {
+	const op = "gateway.net.authByPat"
+
+	if _, ok := c.Get(domain.ScopesFromContext).([]string); ok {
+		return true, nil
+	}
+
+	owner, err := e.authAPI.API.ValidatePat(ctx, &brzrpc.Token{Value: pat})
+	code, errRes := authErrors(op, err)
+	if code != http.StatusOK {
+		if code != http.StatusBadGateway && code != http.StatusGatewayTimeout {
+			code, errRes = http.StatusUnauthorized, domain.Error{Error: "bad personal access token"}
+		}
+		return false, c.JSON(code, errRes)
+	}
+	if !uid.Validate(owner.GetUserId()) {
+		return false, c.JSON(http.StatusUnauthorized, domain.Error{Error: "id is not in format"})
+	}
+
+	scopes := owner.GetScopes()
+	if scopes == nil {
+		scopes = []string{}
+	}
+	c.Set(domain.IdFromContext, owner.GetUserId())
+	c.Set(domain.ScopesFromContext, scopes)
+	return true, nil
+}
+
+// ScopeMW limit requests with personal access token to routes of resource: GET needs <resource>:read
+// or <resource>:write, other methods need <resource>:write. Requests with cookies are not limited
+func ScopeMW(resource string) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			scopes, ok := c.Get(domain.ScopesFromContext).([]string)
+			if !ok {
+				return next(c)
+			}
+
+			write := resource + ":write"
+			if slices.Contains(scopes, write) {
+				return next(c)
+			}
+			m := c.Request().Method
+			if (m == http.MethodGet || m == http.MethodHead) && slices.Contains(scopes, resource+":read") {
+				return next(c)
+			}
+
+			return c.JSON(http.StatusForbidden, domain.Error{Error: "token has no scope for " + resource})
+		}
+	}
+}
+
+// ValidateTokenMW pass request with valid access token without call to auth. Else auth refresh tokens by refresh token.
+// Request with personal access token in Authorization header is checked only by it
 func (e *Echo) ValidateTokenMW() echo.MiddlewareFunc {
 	return func(next echo.HandlerFunc) echo.HandlerFunc {
 		return func(c echo.Context) error {
 			const op = "gateway.net.TokenValidate"
 
+			ctx, cancel := context.WithTimeout(c.Request().Context(), domain.WaitTime)
+			defer cancel()
+
+			if pat := bearerPat(c); pat != "" {
+				if ok, err := e.authByPat(ctx, c, pat); !ok {
+					return err
+				}
+				return next(c)
+			}
+
 			at, err := c.Cookie("access_token")
 			if err != nil {
 				at = &http.Cookie{Value: "BAD"}
+			} else if id, err := e.authAPI.Verifier.AccessId(ctx, at.Value); err == nil && uid.Validate(id) {
+				c.Set(domain.IdFromContext, id)
+				return next(c)
 			}
 			rt, err := c.Cookie("refresh_token")
 			if err != nil {
@@ -27,41 +123,32 @@ func (e *Echo) ValidateTokenMW() echo.MiddlewareFunc {
 
 			auth := e.authAPI.API
 
-			ctx, cancel := context.WithTimeout(c.Request().Context(), domain.WaitTime)
-			defer cancel()
-
 			token, err := auth.ValidateTokens(ctx, &brzrpc.Tokens{
 				AccessToken:  at.Value,
 				RefreshToken: rt.Value,
 			})
 			code, errRes := authErrors(op, err)
 			if code != http.StatusOK {
+				if code == http.StatusGone {
+					clearAuthCookies(c)
+				}
 				return c.JSON(code, errRes)
 			}
 
 			if token != nil {
-				if token.GetValue() == "" {
+				if token.GetAccessToken() == "" {
 					return next(c)
 				}
-				c.SetCookie(&http.Cookie{
-					Name:     "access_token",
-					Value:    token.GetValue(),
-					Path:     "/",
-					HttpOnly: true,
-					//SameSite: http.SameSiteLaxMode,
-					Secure:   true,
-					SameSite: http.SameSiteNoneMode,
-					Expires:  time.Unix(token.GetExp(), 0).UTC(),
-				})
-				u, err := e.authAPI.API.GetIdFromToken(ctx, &brzrpc.Token{Value: token.GetValue()})
-				if err != nil || u.GetId() == "" {
+				setRefreshedCookies(c, token)
+				id, err := e.userIdFromToken(ctx, token.GetAccessToken())
+				if err != nil || id == "" {
 					return c.JSON(http.StatusUnauthorized, domain.Error{Error: "bad access_token"})
 				}
-				if !uid.Validate(u.GetId()) {
+				if !uid.Validate(id) {
 					return c.JSON(http.StatusUnauthorized, domain.Error{Error: "id is not in format"})
 				}
 
-				c.Set(domain.IdFromContext, u.GetId())
+				c.Set(domain.IdFromContext, id)
 
 				return next(c)
 			}
@@ -87,21 +174,95 @@ func (e *Echo) GetUserId() echo.MiddlewareFunc {
 		return func(c echo.Context) error {
 			ctx, done := context.WithTimeout(c.Request().Context(), domain.WaitTime)
 			defer done()
+
+			if pat := bearerPat(c); pat != "" {
+				if ok, err := e.authByPat(ctx, c, pat); !ok {
+					return err
+				}
+				return next(c)
+			}
+
 			at, err := c.Cookie("access_token")
 			if err != nil {
 				return next(c)
 			}
-			u, err := e.authAPI.API.GetIdFromToken(ctx, &brzrpc.Token{Value: at.Value})
-			if err != nil || u.GetId() == "" {
+			id, err := e.userIdFromToken(ctx, at.Value)
+			if err != nil || id == "" {
 				return next(c)
 				//return c.JSON(http.StatusUnauthorized, domain.Error{Error: "bad access_token"})
 			}
 
-			if !uid.Validate(u.GetId()) {
+			if !uid.Validate(id) {
 				return c.JSON(http.StatusUnauthorized, domain.Error{Error: "id in token is not in format"})
 			}
 
-			c.Set(domain.IdFromContext, u.GetId())
+			c.Set(domain.IdFromContext, id)
+
+			return next(c)
+		}
+	}
+}
+
+// WorkspacesMW pass workspace membership of user to blocknote in grpc metadata, see workspaceRoles.
+// Without user in context does nothing
+func (e *Echo) WorkspacesMW() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			const op = "gateway.net.WorkspacesMW"
+
+			idUser, err := getIdUser(c)
+			if err != nil || idUser == "" {
+				return next(c)
+			}
+
+			ctx, done := context.WithTimeout(c.Request().Context(), domain.WaitTime)
+			defer done()
+
+			pairs, err := e.workspaceRoles(ctx, op, idUser)
+			if err != nil {
+				log.Error(op, "get workspaces", err)
+				return c.JSON(http.StatusBadGateway, domain.Error{Error: "can't load workspaces of user"})
+			}
+
+			roles := make(map[string]string)
+			if pairs != "" {
+				for _, p := range strings.Split(pairs, ",") {
+					id, role, _ := strings.Cut(p, ":")
+					roles[id] = role
+				}
+				c.SetRequest(c.Request().WithContext(
+					metadata.AppendToOutgoingContext(c.Request().Context(), domain.WorkspaceRolesMD, pairs),
+				))
+			}
+			c.Set(domain.WorkspacesFromContext, roles)
+
+			return next(c)
+		}
+	}
+}
+
+// VerifiedEmailMW pass only users with verified email
+func (e *Echo) VerifiedEmailMW() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			const op = "gateway.net.VerifiedEmailMW"
+
+			idUser, errGetId := getIdUser(c)
+			if errGetId != nil {
+				return c.JSON(http.StatusUnauthorized, domain.Error{Error: "bad idUser from access token"})
+			}
+
+			ctx, cancel := context.WithTimeout(c.Request().Context(), domain.WaitTime)
+			defer cancel()
+
+			us, err := e.authAPI.API.GetInfos(ctx, &brzrpc.Ids{Ids: []string{idUser}})
+			code, errRes := authErrors(op, err)
+			if code != http.StatusOK {
+				return c.JSON(code, errRes)
+			}
+			if len(us.GetUsers()) == 0 || !us.GetUsers()[0].GetEmailVerified() {
+				return c.JSON(http.StatusForbidden, domain.Error{Error: "verify email first"})
+			}
 
 			return next(c)
 		}
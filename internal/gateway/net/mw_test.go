@@ -0,0 +1,166 @@
+package net
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	brzrpc "github.com/autumnterror/breezynotes/api/proto/gen"
+	"github.com/autumnterror/breezynotes/internal/gateway/domain"
+	"github.com/autumnterror/utils_go/pkg/utils/uid"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/metadata"
+)
+
+// serveWorkspacesMW runs WorkspacesMW for idUser and returns response, roles and metadata which handler got
+func serveWorkspacesMW(t *testing.T, e *Echo, idUser string) (*httptest.ResponseRecorder, map[string]string, []string) {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	c := echo.New().NewContext(httptest.NewRequest(http.MethodGet, "/api/note/all", nil), rec)
+	c.Set(domain.IdFromContext, idUser)
+
+	var (
+		roles map[string]string
+		md    []string
+	)
+	h := e.WorkspacesMW()(func(c echo.Context) error {
+		roles = getWorkspaceRoles(c)
+		out, _ := metadata.FromOutgoingContext(c.Request().Context())
+		md = out.Get(domain.WorkspaceRolesMD)
+		return c.NoContent(http.StatusOK)
+	})
+	require.NoError(t, h(c))
+	return rec, roles, md
+}
+
+func TestWorkspacesMW(t *testing.T) {
+	t.Parallel()
+
+	t.Run("uncached", func(t *testing.T) {
+		t.Parallel()
+		a := &fakeAuth{workspaces: map[string][]*brzrpc.Workspace{
+			"user": {{Id: "w1", Role: "editor"}, {Id: "w2", Role: "viewer"}},
+		}}
+		r := &fakeRedis{roles: map[string]string{}}
+		e := newTestEcho(a, r)
+
+		rec, roles, md := serveWorkspacesMW(t, e, "user")
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, map[string]string{"w1": "editor", "w2": "viewer"}, roles)
+		assert.Equal(t, []string{"w1:editor,w2:viewer"}, md)
+		assert.Equal(t, 1, a.calls)
+		assert.Equal(t, "w1:editor,w2:viewer", r.roles["user"], "roles are cached")
+	})
+
+	t.Run("cached", func(t *testing.T) {
+		t.Parallel()
+		a := &fakeAuth{}
+		e := newTestEcho(a, &fakeRedis{roles: map[string]string{"user": "w3:owner"}})
+
+		rec, roles, md := serveWorkspacesMW(t, e, "user")
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, map[string]string{"w3": "owner"}, roles)
+		assert.Equal(t, []string{"w3:owner"}, md)
+		assert.Zero(t, a.calls, "auth isn't asked")
+	})
+
+	t.Run("without workspaces", func(t *testing.T) {
+		t.Parallel()
+		e := newTestEcho(&fakeAuth{}, &fakeRedis{roles: map[string]string{}})
+
+		rec, roles, md := serveWorkspacesMW(t, e, "user")
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Empty(t, roles)
+		assert.NotNil(t, roles)
+		assert.Empty(t, md)
+	})
+
+	t.Run("load failure", func(t *testing.T) {
+		t.Parallel()
+		e := newTestEcho(&fakeAuth{err: errors.New("auth is down")}, &fakeRedis{roles: map[string]string{}})
+
+		rec, roles, _ := serveWorkspacesMW(t, e, "user")
+		assert.Equal(t, http.StatusBadGateway, rec.Code)
+		assert.Nil(t, roles, "handler isn't called")
+	})
+}
+
+func TestScopeMW(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name   string
+		method string
+		scopes []string
+		code   int
+	}{
+		{"cookies", http.MethodPost, nil, http.StatusOK},
+		{"read", http.MethodGet, []string{"notes:read"}, http.StatusOK},
+		{"read can't write", http.MethodPost, []string{"notes:read"}, http.StatusForbidden},
+		{"write can read", http.MethodGet, []string{"notes:write"}, http.StatusOK},
+		{"write", http.MethodDelete, []string{"notes:write"}, http.StatusOK},
+		{"other resource", http.MethodGet, []string{"tags:write"}, http.StatusForbidden},
+		{"no scopes", http.MethodGet, []string{}, http.StatusForbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			rec := httptest.NewRecorder()
+			c := echo.New().NewContext(httptest.NewRequest(tt.method, "/api/note", nil), rec)
+			if tt.scopes != nil {
+				c.Set(domain.ScopesFromContext, tt.scopes)
+			}
+			h := ScopeMW("notes")(func(c echo.Context) error { return c.NoContent(http.StatusOK) })
+			require.NoError(t, h(c))
+			assert.Equal(t, tt.code, rec.Code)
+		})
+	}
+}
+
+// TestPatRoutes requests go through all middlewares of router, handlers must not be reached
+func TestPatRoutes(t *testing.T) {
+	t.Parallel()
+	const notesPat = domain.PatPrefix + "notes"
+	a := &fakeAuth{pats: map[string]*brzrpc.PatOwner{
+		notesPat: {UserId: uid.New(), Scopes: []string{"notes:read", "notes:write"}},
+	}}
+	e := newTestEcho(a, &fakeRedis{roles: map[string]string{}})
+
+	serve := func(method, path, pat string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, nil)
+		req.Header.Set(echo.HeaderAuthorization, "Bearer "+pat)
+		// cookies are not used when token is in header
+		req.AddCookie(&http.Cookie{Name: "access_token", Value: "BAD"})
+		rec := httptest.NewRecorder()
+		e.echo.ServeHTTP(rec, req)
+		return rec
+	}
+
+	t.Run("notes token out of scope", func(t *testing.T) {
+		for _, r := range []struct{ method, path string }{
+			{http.MethodGet, "/api/user/preferences"},
+			{http.MethodPatch, "/api/user/preferences"},
+			{http.MethodPost, "/api/user/tokens"},
+			{http.MethodGet, "/api/tag/by-user"},
+			{http.MethodPost, "/api/tag"},
+			{http.MethodGet, "/api/workspace"},
+			{http.MethodPost, "/api/workspace/members"},
+			{http.MethodGet, "/api/admin/users"},
+			{http.MethodPost, "/api/admin/user/impersonate"},
+		} {
+			rec := serve(r.method, r.path, notesPat)
+			assert.Equal(t, http.StatusForbidden, rec.Code, r.method+" "+r.path)
+		}
+	})
+
+	t.Run("revoked or expired token", func(t *testing.T) {
+		for _, pat := range []string{domain.PatPrefix + "revoked", domain.PatPrefix + "expired"} {
+			for _, path := range []string{"/api/note/all", "/api/tag/by-user", "/api/admin/users"} {
+				rec := serve(http.MethodGet, path, pat)
+				assert.Equal(t, http.StatusUnauthorized, rec.Code, pat+" "+path)
+			}
+		}
+	})
+}
@@ -1,8 +1,11 @@
 package net
 
 import (
+	"cmp"
 	"context"
 	"net/http"
+	"slices"
+	"strings"
 	"time"
 
 	"google.golang.org/grpc/codes"
@@ -142,7 +145,8 @@ func (e *Echo) GetNote(c echo.Context) error {
 		}
 	} else {
 		if note != nil {
-			if note.GetAuthor() != idUser && !alg.IsIn(idUser, note.GetEditors()) && !alg.IsIn(idUser, note.GetReaders()) && !note.IsPublic && !note.IsBlog {
+			_, isMember := getWorkspaceRoles(c)[note.GetWorkspaceId()]
+			if note.GetAuthor() != idUser && !alg.IsIn(idUser, note.GetEditors()) && !alg.IsIn(idUser, note.GetReaders()) && !isMember && !note.IsPublic && !note.IsBlog {
 				return c.JSON(http.StatusUnauthorized, domain.Error{Error: "user dont have permission FROM CACHE"})
 			}
 			return c.JSON(http.StatusOK, domain.ToNoteWithBlocksDb(note))
@@ -171,12 +175,13 @@ func (e *Echo) GetNote(c echo.Context) error {
 
 // GetAllNotes godoc
 // @Summary all notes of user
-// @Description Returns all notes by user ID
+// @Description Returns all notes by user ID in order of note_sort of preferences
 // @Tags note
 // @Accept json
 // @Produce json
 // @Param start query int true  "start > 0"
 // @Param end query int true  "end"
+// @Param workspace query string false  "Workspace ID, only notes of this workspace"
 // @Success 200 {object} domain.NoteListPaginationResponse
 // @Failure 400 {object} domain.Error
 // @Failure 401 {object} domain.Error
@@ -206,6 +211,32 @@ func (e *Echo) GetAllNotes(c echo.Context) error {
 	ctx, done := context.WithTimeout(c.Request().Context(), domain.WaitTime)
 	defer done()
 
+	// workspace lists are shared between members, so they are not cached per user
+	if idWorkspace := c.QueryParam("workspace"); idWorkspace != "" {
+		notes, err := api.GetAllNotes(ctx, &brzrpc.UserWorkspaceId{
+			UserId:      idUser,
+			WorkspaceId: idWorkspace,
+		})
+		code, errRes := bNErrors(op, err)
+		if code != http.StatusOK {
+			return c.JSON(code, errRes)
+		}
+
+		items := notes.GetItems()
+		sortNotes(items, e.noteSort(ctx, op, idUser))
+		if start >= len(items) {
+			return c.JSON(http.StatusOK, []*brzrpc.NotePart{})
+		}
+		if end > len(items) {
+			end = len(items)
+		}
+
+		return c.JSON(http.StatusOK, domain.NoteListPaginationResponse{
+			Items: domain.ToNotePartList(items[start:end]),
+			Total: len(items),
+		})
+	}
+
 	//---------------REDIS---------------
 	if nl, err := e.rdsAPI.API.GetNoteListByUser(ctx, &brzrpc.UserId{UserId: idUser}); err != nil {
 		st, ok := status.FromError(err)
@@ -235,6 +266,7 @@ func (e *Echo) GetAllNotes(c echo.Context) error {
 			if nl.GetItems() != nil {
 				if len(nl.GetItems()) != 0 {
 					items := nl.GetItems()
+					sortNotes(items, e.noteSort(ctx, op, idUser))
 					if start >= len(items) {
 						return c.JSON(http.StatusOK, []*brzrpc.NotePart{})
 					}
@@ -253,7 +285,7 @@ func (e *Echo) GetAllNotes(c echo.Context) error {
 	}
 	//---------------REDIS---------------
 
-	notes, err := api.GetAllNotes(ctx, &brzrpc.UserId{
+	notes, err := api.GetAllNotes(ctx, &brzrpc.UserWorkspaceId{
 		UserId: idUser,
 	})
 	code, errRes := bNErrors(op, err)
@@ -273,6 +305,7 @@ func (e *Echo) GetAllNotes(c echo.Context) error {
 	}
 
 	items := notes.GetItems()
+	sortNotes(items, e.noteSort(ctx, op, idUser))
 	if start >= len(items) {
 		return c.JSON(http.StatusOK, []*brzrpc.NotePart{})
 	}
@@ -287,6 +320,42 @@ func (e *Echo) GetAllNotes(c echo.Context) error {
 	})
 }
 
+// noteSort return note_sort of preferences of user. Preferences can't be read, so order of blocknote is kept
+func (e *Echo) noteSort(ctx context.Context, op, idUser string) string {
+	p, err := e.getPreferences(ctx, op, idUser)
+	if err != nil {
+		log.Error(op, "can't get preferences", err)
+		return ""
+	}
+	return p.GetNoteSort()
+}
+
+// sortNotes order notes by sort of preferences, unknown sort keeps order. Notes with same key are ordered by id,
+// so pages don't change between requests
+func sortNotes(items []*brzrpc.NotePart, sort string) {
+	field, dir, _ := strings.Cut(sort, "_")
+	var key func(a, b *brzrpc.NotePart) int
+	switch field {
+	case "updated":
+		key = func(a, b *brzrpc.NotePart) int { return cmp.Compare(a.GetUpdatedAt(), b.GetUpdatedAt()) }
+	case "created":
+		key = func(a, b *brzrpc.NotePart) int { return cmp.Compare(a.GetCreatedAt(), b.GetCreatedAt()) }
+	case "title":
+		key = func(a, b *brzrpc.NotePart) int {
+			return cmp.Compare(strings.ToLower(a.GetTitle()), strings.ToLower(b.GetTitle()))
+		}
+	default:
+		return
+	}
+	slices.SortStableFunc(items, func(a, b *brzrpc.NotePart) int {
+		r := key(a, b)
+		if dir == "desc" {
+			r = -r
+		}
+		return cmp.Or(r, cmp.Compare(a.GetId(), b.GetId()))
+	})
+}
+
 // GetNotesByTag godoc
 // @Summary notes by tag
 // @Description Returns all notes that contain given tag
@@ -356,7 +425,7 @@ func (e *Echo) GetNotesByTag(c echo.Context) error {
 
 // CreateNote godoc
 // @Summary Create note
-// @Description Creates new note
+// @Description Creates new note. Note out of workspace gets default_tag of preferences
 // @Tags note
 // @Accept json
 // @Produce json
@@ -388,21 +457,26 @@ func (e *Echo) CreateNote(c echo.Context) error {
 
 	id := uid.New()
 	_, err := api.CreateNote(ctx, &brzrpc.Note{
-		Id:        id,
-		Title:     r.Title,
-		CreatedAt: time.Now().UTC().Unix(),
-		UpdatedAt: time.Now().UTC().Unix(),
-		Tag:       nil,
-		Author:    idUser,
-		Editors:   []string{},
-		Readers:   []string{},
-		Blocks:    []string{},
+		Id:          id,
+		Title:       r.Title,
+		CreatedAt:   time.Now().UTC().Unix(),
+		UpdatedAt:   time.Now().UTC().Unix(),
+		Tag:         nil,
+		Author:      idUser,
+		Editors:     []string{},
+		Readers:     []string{},
+		Blocks:      []string{},
+		WorkspaceId: r.WorkspaceId,
 	})
 	code, errRes := bNErrors(op, err)
 	if code != http.StatusOK {
 		return c.JSON(code, errRes)
 	}
 
+	if r.WorkspaceId == "" {
+		e.addDefaultTag(ctx, op, idUser, id)
+	}
+
 	if _, err := e.rdsAPI.API.RmNoteListByUser(ctx, &brzrpc.UserId{UserId: idUser}); err != nil {
 		st, ok := status.FromError(err)
 		if !ok {
@@ -417,6 +491,26 @@ func (e *Echo) CreateNote(c echo.Context) error {
 	return c.JSON(http.StatusCreated, brzrpc.Id{Id: id})
 }
 
+// addDefaultTag add default_tag of preferences to new note. Note is created already, so errors are only logged,
+// for example tag was deleted after it was chosen
+func (e *Echo) addDefaultTag(ctx context.Context, op, idUser, idNote string) {
+	p, err := e.getPreferences(ctx, op, idUser)
+	if err != nil {
+		log.Error(op, "can't get preferences", err)
+		return
+	}
+	if p.GetDefaultTag() == "" {
+		return
+	}
+	if _, err := e.bnAPI.API.AddTagToNote(ctx, &brzrpc.NoteTagUserId{
+		NoteId: idNote,
+		TagId:  p.GetDefaultTag(),
+		UserId: idUser,
+	}); err != nil {
+		log.Error(op, "can't add default tag", err)
+	}
+}
+
 // AddTagToNote godoc
 // @Summary Add tag to note
 // @Description Attaches tag to note
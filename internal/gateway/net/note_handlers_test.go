@@ -0,0 +1,49 @@
+package net
+
+import (
+	"testing"
+
+	brzrpc "github.com/autumnterror/breezynotes/api/proto/gen"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSortNotes(t *testing.T) {
+	t.Parallel()
+	notes := func() []*brzrpc.NotePart {
+		return []*brzrpc.NotePart{
+			{Id: "a", Title: "beta", CreatedAt: 3, UpdatedAt: 10},
+			{Id: "b", Title: "Alpha", CreatedAt: 1, UpdatedAt: 30},
+			{Id: "c", Title: "gamma", CreatedAt: 2, UpdatedAt: 20},
+			{Id: "d", Title: "alpha", CreatedAt: 2, UpdatedAt: 20},
+		}
+	}
+	ids := func(items []*brzrpc.NotePart) []string {
+		res := make([]string, 0, len(items))
+		for _, n := range items {
+			res = append(res, n.GetId())
+		}
+		return res
+	}
+
+	tests := []struct {
+		sort string
+		want []string
+	}{
+		{"updated_desc", []string{"b", "c", "d", "a"}},
+		{"updated_asc", []string{"a", "c", "d", "b"}},
+		{"created_desc", []string{"a", "c", "d", "b"}},
+		{"created_asc", []string{"b", "c", "d", "a"}},
+		{"title_asc", []string{"b", "d", "a", "c"}},
+		{"title_desc", []string{"c", "a", "b", "d"}},
+		{"", []string{"a", "b", "c", "d"}},
+		{"unknown_asc", []string{"a", "b", "c", "d"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.sort, func(t *testing.T) {
+			t.Parallel()
+			items := notes()
+			sortNotes(items, tt.sort)
+			assert.Equal(t, tt.want, ids(items))
+		})
+	}
+}
@@ -0,0 +1,493 @@
+package net
+
+import (
+	"archive/zip"
+	"context"
+	"errors"
+	"html"
+	"net/http"
+	"net/url"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	brzrpc "github.com/autumnterror/breezynotes/api/proto/gen"
+	"github.com/autumnterror/breezynotes/internal/gateway/domain"
+	"github.com/autumnterror/utils_go/pkg/utils/uid"
+	"github.com/labstack/echo/v4"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	// htmlUrlAttr url of link or image in page of Notion
+	htmlUrlAttr = regexp.MustCompile(`\b(href|src)="([^"]*)"`)
+	// notionId id which Notion adds to names of pages and folders
+	notionId = regexp.MustCompile(`\s+[0-9a-f]{32}$`)
+	// notionProperty property of row of database in markdown of Notion
+	notionProperty = regexp.MustCompile(`^([^:\n]{1,40}): (.+)$`)
+	htmlTitle      = regexp.MustCompile(`(?is)<title>(.*?)</title>`)
+	htmlHeader     = regexp.MustCompile(`(?is)<header\b.*?</header>`)
+)
+
+// notionTimeLayouts times of properties of rows of databases
+var notionTimeLayouts = []string{
+	"January 2, 2006 3:04 PM",
+	"January 2, 2006",
+}
+
+// ImportNotion godoc
+// @Summary import export of Notion
+// @Description Creates notes from zip of Notion export in Markdown & CSV or HTML format. Ids are removed from names of pages,
+// @Description top page or database of note becomes its tag, tags and creation time of rows of databases are read.
+// @Description Links between pages lead to created notes, images and files are uploaded, checklists become todo lists
+// @Description and code gets detected lang. Pages which can't be read or created are reported in conflicts
+// @Tags user
+// @Accept multipart/form-data
+// @Produce json
+// @Param file formData file true "zip archive of Notion export"
+// @Success 200 {object} domain.ImportReport
+// @Failure 400 {object} domain.Error "Неверный формат архива"
+// @Failure 401 {object} domain.Error
+// @Failure 413 {object} domain.Error "archive exceeds limits of import"
+// @Failure 429 {object} domain.Error
+// @Failure 502 {object} domain.Error
+// @Router /api/user/import/notion [post]
+func (e *Echo) ImportNotion(c echo.Context) error {
+	const op = "gateway.net.ImportNotion"
+
+	idUser, errGetId := getIdUser(c)
+	if errGetId != nil {
+		return c.JSON(http.StatusUnauthorized, domain.Error{Error: "bad idUser from access token"})
+	}
+
+	zr, f, err := formZip(c)
+	if err != nil {
+		code, errRes := formError(err)
+		return c.JSON(code, errRes)
+	}
+	defer f.Close()
+
+	r := domain.NewImportReport()
+	ne := newNotionExport(zr)
+	if len(ne.notes) == 0 {
+		return c.JSON(http.StatusBadRequest, domain.Error{Error: "there are no pages in archive"})
+	}
+	if err := checkImportNotes(len(ne.notes)); err != nil {
+		code, errRes := importError(op, err)
+		return c.JSON(code, errRes)
+	}
+	for _, n := range ne.notes {
+		if err := ne.readPage(n); err != nil {
+			if errors.Is(err, errImportLimit) {
+				code, errRes := importError(op, err)
+				return c.JSON(code, errRes)
+			}
+			r.Conflict(domain.ConflictNoteInvalid, n.path, n.title, err.Error())
+			n.skip = true
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request().Context(), domain.WaitTime)
+	tr, err := e.bnAPI.API.ImportTags(ctx, &brzrpc.ImportTagsRequest{UserId: idUser, Tags: ne.tags()})
+	cancel()
+	if err != nil {
+		code, errRes := bNErrors(op, err)
+		return c.JSON(code, errRes)
+	}
+	r.Add(tr)
+
+	im := &noteImport{x: ne.x, r: r, idUser: idUser, tags: tr.GetIds(), files: make(map[string]string)}
+	for _, n := range ne.notes {
+		if n.skip {
+			continue
+		}
+		if code, errRes := e.importNotionPage(c.Request().Context(), im, ne, n); code != http.StatusOK {
+			return c.JSON(code, errRes)
+		}
+	}
+
+	return c.JSON(http.StatusOK, r)
+}
+
+// importNotionPage rewrite links of page, upload its files and create it. Markdown is converted to blocks here,
+// html is converted by blocknote on import
+func (e *Echo) importNotionPage(ctx context.Context, im *noteImport, ne *notionExport, n *vaultNote) (int, domain.Error) {
+	const op = "gateway.net.importNotionPage"
+
+	for _, t := range n.dropped {
+		im.r.Conflict(domain.ConflictTagDropped, n.path, n.title, "note can have one tag, tag "+t+" is not set")
+	}
+
+	l := &pageLinks{ne: ne, im: im, n: n, dir: path.Dir(n.path), publicUrl: e.cfg.PublicUrl}
+	var body string
+	if isHTML(n.path) {
+		body = l.rewriteHTML()
+	} else {
+		body = l.rewriteMarkdown()
+	}
+	if l.err != nil {
+		im.dropFiles(op, l.copied)
+		return importError(op, l.err)
+	}
+
+	note := &brzrpc.NoteWithBlocks{
+		Id:        n.path,
+		Title:     n.title,
+		CreatedAt: n.created,
+		UpdatedAt: n.updated,
+	}
+	if n.tag != "" {
+		note.Tag = &brzrpc.Tag{Id: tagKey(n.tag)}
+	}
+	req := &brzrpc.ImportNoteRequest{
+		UserId: im.idUser,
+		Note:   note,
+		Tags:   im.tags,
+		NewId:  n.id,
+	}
+
+	if isHTML(n.path) {
+		req.Html = body
+		return e.createNote(ctx, im, req, l.copied)
+	}
+
+	cctx, cancel := context.WithTimeout(ctx, domain.WaitTime)
+	blks, err := e.bnAPI.API.ConvertMarkdown(cctx, &brzrpc.String{Value: body})
+	cancel()
+	if err != nil {
+		im.dropFiles(op, l.copied)
+		if status.Code(err) == codes.InvalidArgument {
+			im.r.Conflict(domain.ConflictNoteInvalid, n.path, n.title, status.Convert(err).Message())
+			return http.StatusOK, domain.Error{}
+		}
+		return bNErrors(op, err)
+	}
+	note.Blocks = fileBlocks(blks.GetItems(), im.files)
+
+	return e.createNote(ctx, im, req, l.copied)
+}
+
+// notionExport pages and files of zip of Notion export. Index of pages, attachments and tags are kept like in vault
+type notionExport struct {
+	*vault
+}
+
+func newNotionExport(zr *zip.Reader) *notionExport {
+	ne := &notionExport{vault: &vault{
+		x:        newImportReader(zr),
+		files:    make(map[string]string),
+		byBase:   make(map[string]string),
+		index:    make(map[string]*vaultNote),
+		tagKinds: make(map[string]string),
+		tagNames: make(map[string]string),
+	}}
+
+	var names []string
+	for _, f := range zr.File {
+		if !f.FileInfo().IsDir() {
+			names = append(names, strings.ReplaceAll(f.Name, `\`, "/"))
+		}
+	}
+	ne.root = commonRoot(names)
+
+	for _, f := range zr.File {
+		if f.FileInfo().IsDir() {
+			continue
+		}
+		p := strings.TrimPrefix(strings.ReplaceAll(f.Name, `\`, "/"), ne.root)
+		if hiddenPath(p) {
+			continue
+		}
+		if pageExt(strings.ToLower(path.Ext(p))) {
+			ne.notes = append(ne.notes, &vaultNote{path: p, entry: f.Name, id: uid.New(), modified: f.Modified})
+		} else {
+			ne.files[p] = f.Name
+			if _, ok := ne.byBase[strings.ToLower(path.Base(p))]; !ok {
+				ne.byBase[strings.ToLower(path.Base(p))] = p
+			}
+		}
+	}
+	sort.Slice(ne.notes, func(i, j int) bool { return ne.notes[i].path < ne.notes[j].path })
+
+	for _, n := range ne.notes {
+		n.title = notionId.ReplaceAllString(strings.TrimSuffix(path.Base(n.path), path.Ext(n.path)), "")
+		ne.addIndex(n, n.title)
+		noExt := strings.TrimSuffix(n.path, path.Ext(n.path))
+		ne.addIndex(n, noExt)
+		ne.addIndex(n, path.Base(noExt))
+	}
+	return ne
+}
+
+// readPage read title, tag, times and properties of page. Properties are in header of html page,
+// markdown page of row of database has them in first lines
+func (ne *notionExport) readPage(n *vaultNote) error {
+	raw, err := ne.x.read(n.entry)
+	if err != nil {
+		return err
+	}
+	body := strings.ReplaceAll(string(raw), "\r\n", "\n")
+
+	props := make(map[string][]string)
+	if isHTML(n.path) {
+		if m := htmlTitle.FindStringSubmatch(body); m != nil {
+			props["title"] = []string{strings.TrimSpace(html.UnescapeString(m[1]))}
+		}
+		body = htmlHeader.ReplaceAllString(body, "")
+	} else {
+		body = dropTitle(body, n.title)
+		if _, ok := ne.files[path.Dir(n.path)+".csv"]; ok {
+			props, body = notionProperties(body)
+		}
+	}
+
+	if t := first(props["title"]); t != "" {
+		n.title = t
+		ne.addIndex(n, t)
+	}
+
+	tags := append(props["tags"], props["tag"]...)
+	if len(tags) == 0 {
+		if top, _, ok := strings.Cut(n.path, "/"); ok {
+			n.tag = notionId.ReplaceAllString(top, "")
+			ne.addTag(n.tag, folderEmoji)
+		}
+	} else {
+		n.tag = tags[0]
+		ne.addTag(tags[0], tagEmoji)
+		n.dropped = tags[1:]
+	}
+
+	n.created = notionTime(first(props["created"]), first(props["date"]), first(props["created time"]))
+	n.updated = notionTime(first(props["updated"]), first(props["last edited time"]))
+	if !n.modified.IsZero() {
+		if n.created == 0 {
+			n.created = n.modified.UTC().Unix()
+		}
+		if n.updated == 0 {
+			n.updated = n.modified.UTC().Unix()
+		}
+	}
+
+	if !isHTML(n.path) {
+		body = dropTitle(body, n.title)
+	}
+	n.body = body
+	return nil
+}
+
+// pageLinks resolve links of one page to notes of import and to uploaded files
+type pageLinks struct {
+	ne        *notionExport
+	im        *noteImport
+	n         *vaultNote
+	dir       string
+	publicUrl string
+	// paths of files uploaded for this page
+	copied []string
+	err    error
+}
+
+// rewriteMarkdown change links of markdown page. Links to pages which are not imported stay as text
+func (l *pageLinks) rewriteMarkdown() string {
+	lines := strings.Split(l.n.body, "\n")
+	inCode := false
+	for i, line := range lines {
+		if fence.MatchString(line) {
+			inCode = !inCode
+			continue
+		}
+		if inCode {
+			continue
+		}
+
+		lines[i] = mdLocalLink.ReplaceAllStringFunc(line, func(m string) string {
+			sm := mdLocalLink.FindStringSubmatch(m)
+			embed, text := sm[1] == "!", sm[2]
+			target := localTarget(sm[3])
+			if target == "" || l.err != nil {
+				return m
+			}
+			if key, ok := l.localPage(target); ok {
+				if u := l.noteUrl(key); u != "" {
+					return "[" + text + "](" + u + ")"
+				}
+				return text
+			}
+			p := l.file(target)
+			if p == "" {
+				return text
+			}
+			return l.fileLink(embed, text, p)
+		})
+		if l.err != nil {
+			return ""
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// rewriteHTML change href and src of html page, links to files which are not images are marked with download,
+// so they become file blocks. Unresolved urls are made empty, so their links are text
+func (l *pageLinks) rewriteHTML() string {
+	return htmlUrlAttr.ReplaceAllStringFunc(l.n.body, func(m string) string {
+		sm := htmlUrlAttr.FindStringSubmatch(m)
+		target := localTarget(html.UnescapeString(sm[2]))
+		if target == "" || l.err != nil {
+			return m
+		}
+		var res string
+		download := false
+		if key, ok := l.localPage(target); ok {
+			res = l.noteUrl(key)
+		} else if p := l.file(target); p != "" {
+			res = l.upload(p)
+			download = sm[1] == "href" && res != "" && !imageExts[strings.ToLower(path.Ext(p))]
+		}
+		attr := sm[1] + `="` + html.EscapeString(res) + `"`
+		if download {
+			attr += " download"
+		}
+		return attr
+	})
+}
+
+// upload file of export once for all pages. Name is empty if file can't be uploaded
+func (l *pageLinks) upload(p string) string {
+	newName, ok := l.im.files[p]
+	if ok {
+		return newName
+	}
+	newName, err := l.ne.x.copy(l.ne.files[p])
+	switch {
+	case errors.Is(err, errTooLarge), errors.Is(err, ErrFileName):
+		l.im.r.Conflict(domain.ConflictFileInvalid, p, l.n.title, err.Error())
+	case err != nil:
+		l.err = err
+		return ""
+	default:
+		l.copied = append(l.copied, p)
+	}
+	l.im.files[p] = newName
+	return newName
+}
+
+// noteUrl url of note of import by title or path without extension. Empty if there is no such note
+func (l *pageLinks) noteUrl(target string) string {
+	if to := l.ne.index[strings.ToLower(target)]; to != nil && !to.skip {
+		return l.publicUrl + "/note/" + to.id
+	}
+	l.im.r.Conflict(domain.ConflictLinkUnresolved, l.n.path, l.n.title, "page "+target+" is not in export")
+	return ""
+}
+
+// localPage key of page for relative path to its file, false if path isn't path of page
+func (l *pageLinks) localPage(target string) (string, bool) {
+	ext := path.Ext(target)
+	if !pageExt(strings.ToLower(ext)) {
+		return "", false
+	}
+	key := strings.TrimSuffix(strings.TrimPrefix(path.Join(l.dir, target), "/"), ext)
+	if l.ne.index[strings.ToLower(key)] == nil {
+		key = strings.TrimSuffix(path.Base(target), ext)
+	}
+	return key, true
+}
+
+// file path of attachment in export, empty if it is missing
+func (l *pageLinks) file(target string) string {
+	p := l.ne.attachment(l.dir, target)
+	if p == "" {
+		l.im.r.Conflict(domain.ConflictFileMissing, target, l.n.title, "file is not in export")
+	}
+	return p
+}
+
+func (l *pageLinks) fileLink(embed bool, text, p string) string {
+	name := l.upload(p)
+	if name == "" {
+		return text
+	}
+	if imageExts[strings.ToLower(path.Ext(p))] {
+		return "![" + text + "](" + name + ")"
+	}
+	if text == "" {
+		text = path.Base(p)
+	}
+	if embed {
+		// own paragraph, so it becomes file block
+		return "\n\n[" + text + "](" + name + ")\n\n"
+	}
+	return "[" + text + "](" + name + ")"
+}
+
+func pageExt(ext string) bool {
+	return ext == ".md" || ext == ".markdown" || ext == ".html"
+}
+
+func isHTML(p string) bool {
+	return strings.EqualFold(path.Ext(p), ".html")
+}
+
+// dropTitle remove first header of page if it is title
+func dropTitle(body, title string) string {
+	line, rest, _ := strings.Cut(strings.TrimLeft(body, "\n"), "\n")
+	if h, ok := strings.CutPrefix(line, "# "); ok && strings.EqualFold(strings.TrimSpace(h), title) {
+		return rest
+	}
+	return body
+}
+
+// localTarget decoded path of relative url without anchor, empty for urls with scheme and anchors
+func localTarget(raw string) string {
+	if u, err := url.Parse(raw); err != nil || u.Scheme != "" || strings.HasPrefix(raw, "#") || strings.HasPrefix(raw, "//") {
+		return ""
+	}
+	if un, err := url.PathUnescape(raw); err == nil {
+		raw = un
+	}
+	raw, _, _ = strings.Cut(raw, "#")
+	return raw
+}
+
+// notionProperties read properties of row of database, they are first lines of page after title.
+// Keys are lower case
+func notionProperties(s string) (map[string][]string, string) {
+	props := make(map[string][]string)
+	lines := strings.Split(strings.TrimLeft(s, "\n"), "\n")
+	i := 0
+	for ; i < len(lines); i++ {
+		m := notionProperty.FindStringSubmatch(lines[i])
+		if m == nil {
+			break
+		}
+		k := strings.ToLower(strings.TrimSpace(m[1]))
+		props[k] = append(props[k], strings.TrimSpace(m[2]))
+	}
+	for _, k := range []string{"tags", "tag"} {
+		var tags []string
+		for _, t := range props[k] {
+			tags = append(tags, strings.Split(t, ",")...)
+		}
+		props[k] = splitTags(tags, true)
+	}
+	return props, strings.Join(lines[i:], "\n")
+}
+
+// notionTime unix time of first value which is time of Notion or of vault, 0 if there is no such
+func notionTime(values ...string) int64 {
+	for _, s := range values {
+		for _, l := range notionTimeLayouts {
+			if t, err := time.Parse(l, s); err == nil {
+				return t.UTC().Unix()
+			}
+		}
+		if t := parseVaultTime(s); t != 0 {
+			return t
+		}
+	}
+	return 0
+}
@@ -0,0 +1,150 @@
+package net
+
+import (
+	"context"
+	"crypto/subtle"
+	"net/http"
+	"net/url"
+	"time"
+
+	brzrpc "github.com/autumnterror/breezynotes/api/proto/gen"
+	"github.com/autumnterror/breezynotes/internal/gateway/domain"
+	"github.com/autumnterror/utils_go/pkg/log"
+
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	oidcStateCookie = "oidc_state"
+	oidcStateAge    = 10 * time.Minute
+)
+
+// setOidcState bind state of SSO login to browser. Lax is required: callback is navigation from provider site.
+// maxAge < 0 deletes cookie
+func setOidcState(c echo.Context, state string, maxAge int) {
+	c.SetCookie(&http.Cookie{
+		Name:     oidcStateCookie,
+		Value:    state,
+		Path:     "/api/auth/oidc",
+		HttpOnly: true,
+		Secure:   true,
+		SameSite: http.SameSiteLaxMode,
+		MaxAge:   maxAge,
+	})
+}
+
+// ssoFailed redirect browser to login page of frontend with reason of failure
+func (e *Echo) ssoFailed(c echo.Context, reason string) error {
+	return c.Redirect(http.StatusFound, e.cfg.PublicUrl+"/login?"+url.Values{"sso_error": {reason}}.Encode())
+}
+
+// OidcProviders godoc
+// @Summary SSO providers
+// @Description Returns names of providers for /api/auth/oidc/{provider}
+// @Tags auth
+// @Produce json
+// @Success 200 {object} domain.OidcProviders
+// @Failure 502 {object} domain.Error
+// @Failure 504 {object} domain.Error
+// @Router /api/auth/oidc [get]
+func (e *Echo) OidcProviders(c echo.Context) error {
+	const op = "gateway.net.OidcProviders"
+
+	ctx, cancel := context.WithTimeout(c.Request().Context(), domain.WaitTime)
+	defer cancel()
+
+	res, err := e.authAPI.API.OidcProviders(ctx, nil)
+	code, errRes := authErrors(op, err)
+	if code != http.StatusOK {
+		return c.JSON(code, errRes)
+	}
+
+	providers := res.GetValues()
+	if providers == nil {
+		providers = []string{}
+	}
+	return c.JSON(http.StatusOK, domain.OidcProviders{Providers: providers})
+}
+
+// OidcStart godoc
+// @Summary Start SSO login
+// @Description Redirects browser to login page of provider. After login provider redirects to callback
+// @Tags auth
+// @Param provider path string true "provider name"
+// @Success 302
+// @Failure 404 {object} domain.Error
+// @Failure 502 {object} domain.Error
+// @Failure 504 {object} domain.Error
+// @Router /api/auth/oidc/{provider} [get]
+func (e *Echo) OidcStart(c echo.Context) error {
+	const op = "gateway.net.OidcStart"
+
+	ctx, cancel := context.WithTimeout(c.Request().Context(), domain.OidcWaitTime)
+	defer cancel()
+
+	res, err := e.authAPI.API.OidcStart(ctx, &brzrpc.String{Value: c.Param("provider")})
+	code, errRes := authErrors(op, err)
+	if code != http.StatusOK {
+		return c.JSON(code, errRes)
+	}
+
+	setOidcState(c, res.GetState(), int(oidcStateAge.Seconds()))
+	return c.Redirect(http.StatusFound, res.GetUrl())
+}
+
+// OidcCallback godoc
+// @Summary Finish SSO login
+// @Description Provider redirects here with code. Sets access/refresh cookies and redirects to frontend.
+// @Description With enabled 2FA redirects to /login/2fa#challenge=<challengeToken>, on failure to /login?sso_error=<reason>
+// @Tags auth
+// @Param provider path string true "provider name"
+// @Param code query string false "authorization code"
+// @Param state query string true "state from OidcStart"
+// @Param error query string false "error from provider"
+// @Success 302
+// @Router /api/auth/oidc/{provider}/callback [get]
+func (e *Echo) OidcCallback(c echo.Context) error {
+	const op = "gateway.net.OidcCallback"
+
+	if reason := c.QueryParam("error"); reason != "" {
+		return e.ssoFailed(c, reason)
+	}
+
+	state := c.QueryParam("state")
+	cookie, err := c.Cookie(oidcStateCookie)
+	if err != nil || state == "" || subtle.ConstantTimeCompare([]byte(cookie.Value), []byte(state)) != 1 {
+		return e.ssoFailed(c, "bad_state")
+	}
+	setOidcState(c, "", -1)
+
+	ctx, cancel := context.WithTimeout(c.Request().Context(), domain.OidcWaitTime)
+	defer cancel()
+
+	res, err := e.authAPI.API.OidcCallback(ctx, &brzrpc.OidcCallbackRequest{
+		Provider:  c.Param("provider"),
+		Code:      c.QueryParam("code"),
+		State:     state,
+		UserAgent: c.Request().UserAgent(),
+		Ip:        clientIP(e.rateCfg, c),
+	})
+	switch code, _ := authErrors(op, err); code {
+	case http.StatusOK:
+	case http.StatusFound:
+		// local account with same email is not verified, it can't be linked
+		return e.ssoFailed(c, "account_exists")
+	case http.StatusUnauthorized, http.StatusBadRequest, http.StatusNotFound:
+		return e.ssoFailed(c, "denied")
+	default:
+		log.Error(op, "sso callback", err)
+		return e.ssoFailed(c, "unavailable")
+	}
+
+	if res.GetChallengeToken() != "" {
+		return c.Redirect(http.StatusFound, e.cfg.PublicUrl+"/login/2fa#"+
+			url.Values{"challenge": {res.GetChallengeToken()}}.Encode())
+	}
+
+	setTokenCookie(c, "access_token", res.GetAccessToken(), res.GetExpAccess())
+	setTokenCookie(c, "refresh_token", res.GetRefreshToken(), res.GetExpRefresh())
+	return c.Redirect(http.StatusFound, e.cfg.PublicUrl+"/")
+}
@@ -5,7 +5,9 @@ import (
 	"github.com/autumnterror/breezynotes/api/proto/gen"
 	"github.com/autumnterror/breezynotes/internal/gateway/domain"
 	"github.com/labstack/echo/v4"
+	"net/http"
 	"strconv"
+	"time"
 )
 
 func getPagination(c echo.Context) (int, int, interface{}) {
@@ -46,3 +48,49 @@ func getIdUser(c echo.Context) (string, error) {
 	}
 	return idUser, nil
 }
+
+// getWorkspaceRoles set by WorkspacesMW. Workspace id to role of user
+func getWorkspaceRoles(c echo.Context) map[string]string {
+	if ws, ok := c.Get(domain.WorkspacesFromContext).(map[string]string); ok {
+		return ws
+	}
+	return map[string]string{}
+}
+
+// setTokenCookie set access_token or refresh_token cookie
+func setTokenCookie(c echo.Context, name, value string, exp int64) {
+	c.SetCookie(&http.Cookie{
+		Name:     name,
+		Value:    value,
+		Path:     "/",
+		HttpOnly: true,
+		//SameSite: http.SameSiteLaxMode,
+		Secure:   true,
+		SameSite: http.SameSiteNoneMode,
+		Expires:  time.Unix(exp, 0).UTC(),
+	})
+}
+
+// setRefreshedCookies set cookies from ValidateTokens. Refresh token is empty when it was not rotated
+func setRefreshedCookies(c echo.Context, tokens *brzrpc.Tokens) {
+	setTokenCookie(c, "access_token", tokens.GetAccessToken(), tokens.GetExpAccess())
+	if tokens.GetRefreshToken() != "" {
+		setTokenCookie(c, "refresh_token", tokens.GetRefreshToken(), tokens.GetExpRefresh())
+	}
+}
+
+// clearAuthCookies expire access_token and refresh_token cookies
+func clearAuthCookies(c echo.Context) {
+	for _, name := range []string{"access_token", "refresh_token"} {
+		c.SetCookie(&http.Cookie{
+			Name:     name,
+			Value:    "",
+			Path:     "/",
+			HttpOnly: true,
+			Secure:   true,
+			SameSite: http.SameSiteNoneMode,
+			MaxAge:   -1,
+			Expires:  time.Unix(0, 0).UTC(),
+		})
+	}
+}
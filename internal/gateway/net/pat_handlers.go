@@ -0,0 +1,132 @@
+package net
+
+import (
+	"context"
+	"net/http"
+
+	brzrpc "github.com/autumnterror/breezynotes/api/proto/gen"
+	"github.com/autumnterror/breezynotes/internal/gateway/domain"
+
+	"github.com/labstack/echo/v4"
+)
+
+// GetPats godoc
+// @Summary personal access tokens of user
+// @Description Returns tokens for scripts without their secrets, newest first
+// @Tags user
+// @Produce json
+// @Success 200 {array} domain.Pat
+// @Failure 401 {object} domain.Error
+// @Failure 502 {object} domain.Error
+// @Failure 504 {object} domain.Error
+// @Router /api/user/tokens [get]
+func (e *Echo) GetPats(c echo.Context) error {
+	const op = "gateway.net.GetPats"
+
+	idUser, errGetId := getIdUser(c)
+	if errGetId != nil {
+		return c.JSON(http.StatusUnauthorized, domain.Error{Error: "bad idUser from access token"})
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request().Context(), domain.WaitTime)
+	defer cancel()
+
+	ps, err := e.authAPI.API.ListPats(ctx, &brzrpc.UserId{UserId: idUser})
+	code, errRes := authErrors(op, err)
+	if code != http.StatusOK {
+		return c.JSON(code, errRes)
+	}
+
+	return c.JSON(http.StatusOK, domain.ToPats(ps))
+}
+
+// CreatePat godoc
+// @Summary create personal access token
+// @Description Creates token for Authorization: Bearer header. Token is shown only in this response.
+// @Description Scopes: notes:read, notes:write, tags:read, tags:write, files:write, workspaces:read, workspaces:write, user:read.
+// @Description expires_at is unix time not later than year from now. Token can't be created with other token
+// @Tags user
+// @Accept json
+// @Produce json
+// @Param request body domain.CreatePatRequest true "name, scopes and expiration"
+// @Success 201 {object} domain.PatCreated
+// @Failure 400 {object} domain.Error
+// @Failure 401 {object} domain.Error
+// @Failure 403 {object} domain.Error
+// @Failure 502 {object} domain.Error
+// @Failure 504 {object} domain.Error
+// @Router /api/user/tokens [post]
+func (e *Echo) CreatePat(c echo.Context) error {
+	const op = "gateway.net.CreatePat"
+
+	idUser, errGetId := getIdUser(c)
+	if errGetId != nil {
+		return c.JSON(http.StatusUnauthorized, domain.Error{Error: "bad idUser from access token"})
+	}
+
+	var r domain.CreatePatRequest
+	if err := c.Bind(&r); err != nil {
+		return c.JSON(http.StatusBadRequest, domain.Error{Error: "bad JSON"})
+	}
+	if r.Name == "" || len(r.Scopes) == 0 || r.ExpiresAt == 0 {
+		return c.JSON(http.StatusBadRequest, domain.Error{Error: "bad param"})
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request().Context(), domain.WaitTime)
+	defer cancel()
+
+	res, err := e.authAPI.API.CreatePat(ctx, &brzrpc.CreatePatRequest{
+		UserId:    idUser,
+		Name:      r.Name,
+		Scopes:    r.Scopes,
+		ExpiresAt: r.ExpiresAt,
+	})
+	code, errRes := authErrors(op, err)
+	if code != http.StatusOK {
+		return c.JSON(code, errRes)
+	}
+
+	return c.JSON(http.StatusCreated, domain.PatCreated{
+		Token: res.GetToken(),
+		Pat:   domain.PatFromRpc(res.GetPat()),
+	})
+}
+
+// RevokePat godoc
+// @Summary revoke personal access token
+// @Description Token stops working immediately
+// @Tags user
+// @Produce json
+// @Param id query string true "Token ID"
+// @Success 204
+// @Failure 400 {object} domain.Error
+// @Failure 401 {object} domain.Error
+// @Failure 403 {object} domain.Error
+// @Failure 404 {object} domain.Error
+// @Failure 502 {object} domain.Error
+// @Failure 504 {object} domain.Error
+// @Router /api/user/tokens [delete]
+func (e *Echo) RevokePat(c echo.Context) error {
+	const op = "gateway.net.RevokePat"
+
+	idUser, errGetId := getIdUser(c)
+	if errGetId != nil {
+		return c.JSON(http.StatusUnauthorized, domain.Error{Error: "bad idUser from access token"})
+	}
+
+	id := c.QueryParam("id")
+	if id == "" {
+		return c.JSON(http.StatusBadRequest, domain.Error{Error: "bad param"})
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request().Context(), domain.WaitTime)
+	defer cancel()
+
+	_, err := e.authAPI.API.RevokePat(ctx, &brzrpc.PatId{UserId: idUser, Id: id})
+	code, errRes := authErrors(op, err)
+	if code != http.StatusOK {
+		return c.JSON(code, errRes)
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
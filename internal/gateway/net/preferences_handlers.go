@@ -0,0 +1,166 @@
+package net
+
+import (
+	"context"
+	"net/http"
+	"slices"
+
+	brzrpc "github.com/autumnterror/breezynotes/api/proto/gen"
+	"github.com/autumnterror/breezynotes/internal/gateway/domain"
+	"github.com/autumnterror/utils_go/pkg/log"
+	"github.com/labstack/echo/v4"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// getPreferences return preferences from redis session or from auth and cache them.
+// Retention of trash is taken from blocknote, it is kept only there
+func (e *Echo) getPreferences(ctx context.Context, op, idUser string) (*brzrpc.Preferences, error) {
+	if p, err := e.rdsAPI.API.GetPreferencesByUser(ctx, &brzrpc.UserId{UserId: idUser}); err == nil {
+		return p, nil
+	} else if status.Code(err) != codes.NotFound {
+		log.Error(op, "REDIS ERROR", err)
+	}
+
+	p, err := e.authAPI.API.GetPreferences(ctx, &brzrpc.UserId{UserId: idUser})
+	if err != nil {
+		return nil, err
+	}
+	r, err := e.bnAPI.API.GetTrashRetention(ctx, &brzrpc.UserId{UserId: idUser})
+	if err != nil {
+		return nil, err
+	}
+	p.TrashRetentionDays = r.GetDays()
+	e.cachePreferences(ctx, op, idUser, p)
+	return p, nil
+}
+
+func (e *Echo) cachePreferences(ctx context.Context, op, idUser string, p *brzrpc.Preferences) {
+	if _, err := e.rdsAPI.API.SetPreferencesByUser(ctx, &brzrpc.PreferencesByUser{UserId: idUser, Preferences: p}); err != nil {
+		log.Error(op, "REDIS ERROR", err)
+	}
+}
+
+func (e *Echo) rmPreferencesCache(ctx context.Context, op, idUser string) {
+	if _, err := e.rdsAPI.API.RmPreferencesByUser(ctx, &brzrpc.UserId{UserId: idUser}); err != nil && status.Code(err) != codes.NotFound {
+		log.Error(op, "REDIS ERROR", err)
+	}
+}
+
+// GetPreferences godoc
+// @Summary user preferences
+// @Description Returns preferences of user, default ones if user never changed them
+// @Tags user
+// @Produce json
+// @Success 200 {object} domain.Preferences
+// @Failure 400 {object} domain.Error
+// @Failure 401 {object} domain.Error
+// @Failure 502 {object} domain.Error
+// @Failure 504 {object} domain.Error
+// @Router /api/user/preferences [get]
+func (e *Echo) GetPreferences(c echo.Context) error {
+	const op = "gateway.net.GetPreferences"
+
+	idUser, errGetId := getIdUser(c)
+	if errGetId != nil {
+		return c.JSON(http.StatusUnauthorized, domain.Error{Error: "bad idUser from access token"})
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request().Context(), domain.WaitTime)
+	defer cancel()
+
+	p, err := e.getPreferences(ctx, op, idUser)
+	code, errRes := authErrors(op, err)
+	if code != http.StatusOK {
+		return c.JSON(code, errRes)
+	}
+
+	return c.JSON(http.StatusOK, domain.PreferencesFromRpc(p))
+}
+
+// UpdatePreferences godoc
+// @Summary update user preferences
+// @Description Changes only fields which are in body, returns all preferences.
+// @Description default_tag must be tag of user, trash_retention_days is applied to trash same as /api/trash/retention.
+// @Description If revision is in body and preferences were changed since it, nothing is saved and 409 is returned
+// @Tags user
+// @Accept json
+// @Produce json
+// @Param request body domain.Preferences true "changed fields"
+// @Success 200 {object} domain.Preferences
+// @Failure 400 {object} domain.Error
+// @Failure 401 {object} domain.Error
+// @Failure 409 {object} domain.Error "preferences were changed by other request"
+// @Failure 502 {object} domain.Error
+// @Failure 504 {object} domain.Error
+// @Router /api/user/preferences [patch]
+func (e *Echo) UpdatePreferences(c echo.Context) error {
+	const op = "gateway.net.UpdatePreferences"
+
+	idUser, errGetId := getIdUser(c)
+	if errGetId != nil {
+		return c.JSON(http.StatusUnauthorized, domain.Error{Error: "bad idUser from access token"})
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request().Context(), domain.WaitTime)
+	defer cancel()
+
+	old, err := e.getPreferences(ctx, op, idUser)
+	code, errRes := authErrors(op, err)
+	if code != http.StatusOK {
+		return c.JSON(code, errRes)
+	}
+
+	// body is decoded over current preferences, so missing fields stay same
+	p := domain.PreferencesFromRpc(old)
+	if err := c.Bind(p); err != nil {
+		return c.JSON(http.StatusBadRequest, domain.Error{Error: "bad JSON"})
+	}
+	// checked before saving, else other fields would be saved without retention
+	if p.TrashRetentionDays < 0 || p.TrashRetentionDays > domain.MaxTrashRetentionDays {
+		return c.JSON(http.StatusBadRequest, domain.Error{Error: "bad trash_retention_days"})
+	}
+
+	if p.DefaultTag != "" && p.DefaultTag != old.GetDefaultTag() {
+		tags, err := e.bnAPI.API.GetTagsByUser(ctx, &brzrpc.UserWorkspaceId{UserId: idUser})
+		code, errRes := bNErrors(op, err)
+		if code != http.StatusOK {
+			return c.JSON(code, errRes)
+		}
+		if !slices.ContainsFunc(tags.GetItems(), func(t *brzrpc.Tag) bool { return t.GetId() == p.DefaultTag }) {
+			return c.JSON(http.StatusBadRequest, domain.Error{Error: "default tag not found"})
+		}
+	}
+
+	// revision of body or of old preferences is checked by auth, so update made meanwhile isn't overwritten
+	res, err := e.authAPI.API.UpdatePreferences(ctx, &brzrpc.UpdatePreferencesRequest{
+		UserId:      idUser,
+		Preferences: domain.PreferencesToRpc(p),
+	})
+	code, errRes = authErrors(op, err)
+	if code != http.StatusOK {
+		if code == http.StatusConflict {
+			// cached preferences can be old
+			e.rmPreferencesCache(ctx, op, idUser)
+		}
+		return c.JSON(code, errRes)
+	}
+
+	// blocknote deletes notes from trash, so it keeps retention, auth doesn't
+	if days := int32(p.TrashRetentionDays); days != old.GetTrashRetentionDays() {
+		_, err := e.bnAPI.API.SetTrashRetention(ctx, &brzrpc.TrashRetentionRequest{UserId: idUser, Days: days})
+		code, errRes := bNErrors(op, err)
+		if code != http.StatusOK {
+			e.rmPreferencesCache(ctx, op, idUser)
+			return c.JSON(code, errRes)
+		}
+		if _, err := e.rdsAPI.API.RmNotesFromTrashByUser(ctx, &brzrpc.UserId{UserId: idUser}); err != nil && status.Code(err) != codes.NotFound {
+			log.Error(op, "REDIS ERROR", err)
+		}
+	}
+	res.TrashRetentionDays = int32(p.TrashRetentionDays)
+
+	e.cachePreferences(ctx, op, idUser, res)
+
+	return c.JSON(http.StatusOK, domain.PreferencesFromRpc(res))
+}
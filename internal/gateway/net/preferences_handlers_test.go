@@ -0,0 +1,73 @@
+package net
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	brzrpc "github.com/autumnterror/breezynotes/api/proto/gen"
+	"github.com/autumnterror/breezynotes/internal/gateway/clients/blocknote"
+	"github.com/autumnterror/breezynotes/internal/gateway/domain"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func patchPreferences(t *testing.T, e *Echo, body string) *httptest.ResponseRecorder {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodPatch, "/api/user/preferences", strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := echo.New().NewContext(req, rec)
+	c.Set(domain.IdFromContext, "user")
+	require.NoError(t, e.UpdatePreferences(c))
+	return rec
+}
+
+func TestUpdatePreferences(t *testing.T) {
+	t.Parallel()
+	a := &fakeAuth{prefs: map[string]*brzrpc.Preferences{"user": {Version: 1, Theme: "light", Revision: 1}}}
+	r := &fakeRedis{prefs: map[string]*brzrpc.Preferences{}}
+	b := &fakeBlocknote{retention: map[string]int32{"user": 7}}
+	e := newTestEcho(a, r)
+	e.bnAPI = &blocknote.Client{API: b}
+
+	t.Run("retention is kept by blocknote", func(t *testing.T) {
+		rec := patchPreferences(t, e, `{"trash_retention_days":30}`)
+		require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+		var p domain.Preferences
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &p))
+		assert.Equal(t, 30, p.TrashRetentionDays)
+		assert.Equal(t, "light", p.Theme, "missing fields stay same")
+		assert.Equal(t, int64(2), p.Revision)
+		assert.Equal(t, int32(30), b.retention["user"])
+		assert.Zero(t, a.prefs["user"].GetTrashRetentionDays())
+	})
+
+	t.Run("bad retention saves nothing", func(t *testing.T) {
+		rec := patchPreferences(t, e, `{"theme":"dark","trash_retention_days":366}`)
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		assert.Equal(t, "light", a.prefs["user"].GetTheme())
+	})
+
+	t.Run("old revision", func(t *testing.T) {
+		rec := patchPreferences(t, e, `{"theme":"dark","revision":1}`)
+		assert.Equal(t, http.StatusConflict, rec.Code)
+		assert.Equal(t, "light", a.prefs["user"].GetTheme())
+		assert.NotContains(t, r.prefs, "user", "cache is dropped")
+	})
+
+	t.Run("cache changed by other request", func(t *testing.T) {
+		r.prefs["user"] = &brzrpc.Preferences{Version: 1, Theme: "light", Revision: 2}
+		a.prefs["user"].Revision = 5
+		rec := patchPreferences(t, e, `{"theme":"dark"}`)
+		assert.Equal(t, http.StatusConflict, rec.Code)
+
+		rec = patchPreferences(t, e, `{"theme":"dark"}`)
+		require.Equal(t, http.StatusOK, rec.Code, "retry loads current preferences")
+		assert.Equal(t, "dark", a.prefs["user"].GetTheme())
+		assert.Equal(t, int64(6), a.prefs["user"].GetRevision())
+	})
+}
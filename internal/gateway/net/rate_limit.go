@@ -22,7 +22,9 @@ type rateLimitConfig struct {
 	PerRoute          bool
 	TrustProxyHeaders bool
 	Skipper           func(c echo.Context) bool
-	OnLimit           func(c echo.Context, retryAfter time.Duration) error
+	// KeyFunc return key of bucket, by default it is built from ip and route
+	KeyFunc func(c echo.Context) string
+	OnLimit func(c echo.Context, retryAfter time.Duration) error
 }
 
 func (cfg *rateLimitConfig) setDefaults() {
@@ -57,6 +59,9 @@ func (e *Echo) RateLimitMW(cfg rateLimitConfig) echo.MiddlewareFunc {
 				return next(c)
 			}
 			key := defaultKey(cfg, c)
+			if cfg.KeyFunc != nil {
+				key = cfg.KeyFunc(c)
+			}
 			ctx := c.Request().Context()
 			resp, err := e.rdsAPI.API.RateLimit(ctx, &brzrpc.RateLimitRequest{
 				Key:                key,
@@ -0,0 +1,301 @@
+package net
+
+import (
+	"archive/zip"
+	"context"
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	brzrpc "github.com/autumnterror/breezynotes/api/proto/gen"
+	"github.com/autumnterror/breezynotes/internal/gateway/domain"
+	"github.com/autumnterror/utils_go/pkg/log"
+	"github.com/labstack/echo/v4"
+	"google.golang.org/grpc"
+)
+
+const (
+	// renderTime export of one note or blog is made while client waits
+	renderTime = time.Minute
+	// renderFilesSize pictures of note which are sent to blocknote for export, others are left out
+	renderFilesSize = 48 << 20
+	// renderSendSize max size of export request with pictures
+	renderSendSize = renderFilesSize + 16<<20
+)
+
+var renderTypes = map[string]string{
+	"html": "text/html; charset=utf-8",
+	"pdf":  "application/pdf",
+	"epub": "application/epub+zip",
+}
+
+// ExportNote godoc
+// @Summary export note as html or pdf
+// @Description Returns note as standalone html page or pdf document for sharing. Code is highlighted,
+// @Description uploaded pictures are put into document. Pdf without configured font has only latin chars
+// @Tags note
+// @Produce text/html
+// @Produce application/pdf
+// @Param id query string true "Note ID"
+// @Param format query string false "html or pdf, html by default"
+// @Success 200 {file} file
+// @Failure 400 {object} domain.Error
+// @Failure 401 {object} domain.Error
+// @Failure 404 {object} domain.Error
+// @Failure 429 {object} domain.Error
+// @Failure 502 {object} domain.Error
+// @Failure 504 {object} domain.Error
+// @Router /api/note/export [get]
+func (e *Echo) ExportNote(c echo.Context) error {
+	const op = "gateway.net.ExportNote"
+
+	api := e.bnAPI.API
+
+	idNote := c.QueryParam("id")
+	if idNote == "" {
+		return c.JSON(http.StatusBadRequest, domain.Error{Error: "bad param"})
+	}
+	f := c.QueryParam("format")
+	if f == "" {
+		f = "html"
+	}
+	if f != "html" && f != "pdf" {
+		return c.JSON(http.StatusBadRequest, domain.Error{Error: "format must be html or pdf"})
+	}
+
+	idUser, errGetId := getIdUser(c)
+	if errGetId != nil {
+		return c.JSON(http.StatusUnauthorized, domain.Error{Error: "bad idUser from access token"})
+	}
+
+	ctx, done := context.WithTimeout(c.Request().Context(), renderTime)
+	defer done()
+
+	n, err := api.GetNote(ctx, &brzrpc.UserNoteId{UserId: idUser, NoteId: idNote})
+	code, errRes := bNErrors(op, err)
+	if code != http.StatusOK {
+		return c.JSON(code, errRes)
+	}
+
+	stream, err := api.ExportNote(ctx, &brzrpc.ExportNoteRequest{
+		UserId: idUser,
+		NoteId: idNote,
+		Format: f,
+		Files:  noteImages(op, n),
+	}, grpc.MaxCallSendMsgSize(renderSendSize))
+	code, errRes = bNErrors(op, err)
+	if code != http.StatusOK {
+		return c.JSON(code, errRes)
+	}
+
+	// first part is received before answer, so error of export is returned as json
+	part, err := stream.Recv()
+	if errors.Is(err, io.EOF) {
+		log.Error(op, "empty export", err)
+		return c.JSON(http.StatusBadGateway, domain.Error{Error: "check logs on service"})
+	}
+	code, errRes = bNErrors(op, err)
+	if code != http.StatusOK {
+		return c.JSON(code, errRes)
+	}
+
+	setAttachment(c, f, n.GetTitle())
+	w := c.Response()
+	for {
+		if _, err := w.Write(part.GetData()); err != nil {
+			log.Error(op, "write export", err)
+			return nil
+		}
+		w.Flush()
+
+		part, err = stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			// answer is already started, client gets broken file
+			log.Error(op, "export stream", err)
+			return nil
+		}
+	}
+}
+
+// ExportBlog godoc
+// @Summary export blog of author as epub
+// @Description Returns blog notes of author as book, chapters go from oldest note to newest
+// @Tags note
+// @Produce application/epub+zip
+// @Param author query string true "Author ID"
+// @Param format query string false "only epub, it is default"
+// @Success 200 {file} file
+// @Failure 400 {object} domain.Error
+// @Failure 401 {object} domain.Error
+// @Failure 404 {object} domain.Error "author has no blog notes"
+// @Failure 429 {object} domain.Error
+// @Failure 502 {object} domain.Error
+// @Failure 504 {object} domain.Error
+// @Router /api/note/blog/export [get]
+func (e *Echo) ExportBlog(c echo.Context) error {
+	const op = "gateway.net.ExportBlog"
+
+	idAuthor := c.QueryParam("author")
+	if idAuthor == "" {
+		return c.JSON(http.StatusBadRequest, domain.Error{Error: "bad param"})
+	}
+	f := c.QueryParam("format")
+	if f == "" {
+		f = "epub"
+	}
+	if f != "epub" {
+		return c.JSON(http.StatusBadRequest, domain.Error{Error: "format must be epub"})
+	}
+
+	idUser, errGetId := getIdUser(c)
+	if errGetId != nil {
+		return c.JSON(http.StatusUnauthorized, domain.Error{Error: "bad idUser from access token"})
+	}
+
+	ctx, done := context.WithTimeout(c.Request().Context(), renderTime)
+	defer done()
+
+	us, err := e.authAPI.API.GetInfos(ctx, &brzrpc.Ids{Ids: []string{idAuthor}})
+	code, errRes := authErrors(op, err)
+	if code != http.StatusOK {
+		return c.JSON(code, errRes)
+	}
+	if len(us.GetUsers()) != 1 {
+		return c.JSON(http.StatusNotFound, domain.Error{Error: "not found"})
+	}
+	author := us.GetUsers()[0].GetLogin()
+
+	stream, err := e.bnAPI.API.ExportCollection(ctx, &brzrpc.ExportCollectionRequest{
+		UserId:     idUser,
+		AuthorId:   idAuthor,
+		AuthorName: author,
+		Format:     f,
+	})
+	code, errRes = bNErrors(op, err)
+	if code != http.StatusOK {
+		return c.JSON(code, errRes)
+	}
+
+	part, err := stream.Recv()
+	if errors.Is(err, io.EOF) {
+		log.Error(op, "empty export", err)
+		return c.JSON(http.StatusBadGateway, domain.Error{Error: "check logs on service"})
+	}
+	code, errRes = bNErrors(op, err)
+	if code != http.StatusOK {
+		return c.JSON(code, errRes)
+	}
+
+	setAttachment(c, f, author+" blog")
+	zw := zip.NewWriter(c.Response())
+	var (
+		w    io.Writer
+		name string
+	)
+	for {
+		if w == nil || part.GetName() != name {
+			name = part.GetName()
+			if w, err = bookEntry(zw, part); err != nil {
+				log.Error(op, "write export", err)
+				return nil
+			}
+		}
+		if _, err := w.Write(part.GetData()); err != nil {
+			log.Error(op, "write export", err)
+			return nil
+		}
+
+		part, err = stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			log.Error(op, "export stream", err)
+			return nil
+		}
+	}
+	if err := zw.Close(); err != nil {
+		log.Error(op, "write export", err)
+	}
+	return nil
+}
+
+// noteImages read uploaded pictures of note by src until renderFilesSize. Missing pictures are left out,
+// then document has alt of them
+func noteImages(op string, n *brzrpc.NoteWithBlocks) map[string][]byte {
+	res := make(map[string][]byte)
+	size := 0
+	for _, b := range n.GetBlocks() {
+		if b.GetType() != "img" {
+			continue
+		}
+		src := b.GetData().GetFields()["src"].GetStringValue()
+		name := localFileName(src)
+		if name == "" || res[src] != nil {
+			continue
+		}
+		st, err := os.Stat(filepath.Join(FilesDir, name))
+		if err != nil || size+int(st.Size()) > renderFilesSize {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(FilesDir, name))
+		if err != nil {
+			log.Error(op, "read file "+name, err)
+			continue
+		}
+		res[src] = data
+		size += len(data)
+	}
+	return res
+}
+
+// bookEntry start file of book in zip. Mimetype must be stored, stored pictures are copied from FilesDir
+func bookEntry(zw *zip.Writer, part *brzrpc.ExportPart) (io.Writer, error) {
+	method := zip.Deflate
+	if part.GetName() == "mimetype" {
+		method = zip.Store
+	}
+	w, err := zw.CreateHeader(&zip.FileHeader{Name: part.GetName(), Method: method, Modified: time.Now().UTC()})
+	if err != nil || part.GetFile() == "" {
+		return w, err
+	}
+
+	name := localFileName(part.GetFile())
+	if name == "" {
+		return w, nil
+	}
+	f, err := os.Open(filepath.Join(FilesDir, name))
+	if err != nil {
+		// picture is deleted, book is still readable
+		return w, nil
+	}
+	defer f.Close()
+	_, err = io.Copy(w, f)
+	return w, err
+}
+
+// setAttachment set headers of file download, name is made from title
+func setAttachment(c echo.Context, f, title string) {
+	name := strings.Map(func(r rune) rune {
+		if strings.ContainsRune(`/\:*?"<>|`, r) || r < ' ' {
+			return '_'
+		}
+		return r
+	}, strings.TrimSpace(title))
+	if name == "" {
+		name = "note"
+	}
+
+	h := c.Response().Header()
+	h.Set(echo.HeaderContentType, renderTypes[f])
+	h.Set(echo.HeaderContentDisposition, mime.FormatMediaType("attachment", map[string]string{"filename": name + "." + f}))
+	c.Response().WriteHeader(http.StatusOK)
+}
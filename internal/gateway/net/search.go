@@ -23,6 +23,7 @@ import (
 // @Param start query int true  "start > 0"
 // @Param end query int true  "end"
 // @Param prompt query string true  "prompt"
+// @Param workspace query string false  "Workspace ID, search only in this workspace"
 // @Success 200 {object} []brzrpc.NotePart
 // @Failure 400 {object} domain.Error
 // @Failure 408 {object} []brzrpc.NotePart
@@ -71,8 +72,9 @@ func (e *Echo) Search(c echo.Context) error {
 	defer done()
 
 	notes, err := api.Search(ctx, &brzrpc.SearchRequest{
-		UserId: idUser,
-		Prompt: prompt,
+		UserId:      idUser,
+		Prompt:      prompt,
+		WorkspaceId: c.QueryParam("workspace"),
 	})
 
 	code, errRes := bNErrors(op, err)
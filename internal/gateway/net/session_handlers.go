@@ -0,0 +1,90 @@
+package net
+
+import (
+	"context"
+	"net/http"
+
+	brzrpc "github.com/autumnterror/breezynotes/api/proto/gen"
+	"github.com/autumnterror/breezynotes/internal/gateway/domain"
+
+	"github.com/labstack/echo/v4"
+)
+
+// GetSessions godoc
+// @Summary active sessions of user
+// @Description Returns devices where user is logged in, last seen first. Session of this request is marked as current
+// @Tags user
+// @Produce json
+// @Success 200 {array} domain.Session
+// @Failure 401 {object} domain.Error
+// @Failure 502 {object} domain.Error
+// @Failure 504 {object} domain.Error
+// @Router /api/user/sessions [get]
+func (e *Echo) GetSessions(c echo.Context) error {
+	const op = "gateway.net.GetSessions"
+
+	idUser, errGetId := getIdUser(c)
+	if errGetId != nil {
+		return c.JSON(http.StatusUnauthorized, domain.Error{Error: "bad idUser from access token"})
+	}
+
+	at := ""
+	if cookie, err := c.Cookie("access_token"); err == nil {
+		at = cookie.Value
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request().Context(), domain.WaitTime)
+	defer cancel()
+
+	ss, err := e.authAPI.API.ListSessions(ctx, &brzrpc.ListSessionsRequest{
+		UserId:      idUser,
+		AccessToken: at,
+	})
+	code, errRes := authErrors(op, err)
+	if code != http.StatusOK {
+		return c.JSON(code, errRes)
+	}
+
+	return c.JSON(http.StatusOK, domain.ToSessions(ss))
+}
+
+// RevokeSession godoc
+// @Summary revoke session
+// @Description Logs out device of session: it can't refresh tokens and loses access when its access token expires
+// @Tags user
+// @Produce json
+// @Param id query string true "Session ID"
+// @Success 204
+// @Failure 400 {object} domain.Error
+// @Failure 401 {object} domain.Error
+// @Failure 404 {object} domain.Error
+// @Failure 502 {object} domain.Error
+// @Failure 504 {object} domain.Error
+// @Router /api/user/sessions [delete]
+func (e *Echo) RevokeSession(c echo.Context) error {
+	const op = "gateway.net.RevokeSession"
+
+	idUser, errGetId := getIdUser(c)
+	if errGetId != nil {
+		return c.JSON(http.StatusUnauthorized, domain.Error{Error: "bad idUser from access token"})
+	}
+
+	id := c.QueryParam("id")
+	if id == "" {
+		return c.JSON(http.StatusBadRequest, domain.Error{Error: "bad param"})
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request().Context(), domain.WaitTime)
+	defer cancel()
+
+	_, err := e.authAPI.API.RevokeSession(ctx, &brzrpc.UserSessionId{
+		UserId:    idUser,
+		SessionId: id,
+	})
+	code, errRes := authErrors(op, err)
+	if code != http.StatusOK {
+		return c.JSON(code, errRes)
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
@@ -47,11 +47,12 @@ func (e *Echo) CreateTag(c echo.Context) error {
 	newId := uid.New()
 
 	_, err := api.CreateTag(ctx, &brzrpc.Tag{
-		Id:     newId,
-		Title:  r.Title,
-		Color:  r.Color,
-		Emoji:  r.Emoji,
-		UserId: idUser,
+		Id:          newId,
+		Title:       r.Title,
+		Color:       r.Color,
+		Emoji:       r.Emoji,
+		UserId:      idUser,
+		WorkspaceId: r.WorkspaceId,
 	})
 
 	code, errRes := bNErrors(op, err)
@@ -361,6 +362,7 @@ func (e *Echo) DeleteTag(c echo.Context) error {
 // @Tags tag
 // @Accept json
 // @Produce json
+// @Param workspace query string false  "Workspace ID, only tags of this workspace"
 // @Success 200 {object} []domain.Tag
 // @Failure 401 {object} domain.Error
 // @Failure 502 {object} domain.Error
@@ -379,6 +381,22 @@ func (e *Echo) GetTagsByUser(c echo.Context) error {
 	ctx, done := context.WithTimeout(c.Request().Context(), domain.WaitTime)
 	defer done()
 
+	if idWorkspace := c.QueryParam("workspace"); idWorkspace != "" {
+		tags, err := api.GetTagsByUser(ctx, &brzrpc.UserWorkspaceId{
+			UserId:      idUser,
+			WorkspaceId: idWorkspace,
+		})
+		code, errRes := bNErrors(op, err)
+		if code != http.StatusOK {
+			return c.JSON(code, errRes)
+		}
+		if len(tags.Items) == 0 {
+			tags.Items = []*brzrpc.Tag{}
+		}
+
+		return c.JSON(http.StatusOK, domain.ToTags(tags).Tgs)
+	}
+
 	if tgs, err := e.rdsAPI.API.GetTagsByUser(ctx, &brzrpc.UserId{UserId: idUser}); err != nil {
 		st, ok := status.FromError(err)
 		if !ok {
@@ -411,7 +429,7 @@ func (e *Echo) GetTagsByUser(c echo.Context) error {
 		}
 	}
 
-	tags, err := api.GetTagsByUser(ctx, &brzrpc.UserId{UserId: idUser})
+	tags, err := api.GetTagsByUser(ctx, &brzrpc.UserWorkspaceId{UserId: idUser})
 	code, errRes := bNErrors(op, err)
 	if code != http.StatusOK {
 		return c.JSON(code, errRes)
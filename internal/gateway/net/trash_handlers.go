@@ -203,6 +203,111 @@ func (e *Echo) NoteFromTrash(c echo.Context) error {
 	return c.NoContent(http.StatusNoContent)
 }
 
+// PurgeNoteFromTrash godoc
+// @Summary Delete note from trash forever
+// @Description Permanently deletes note from trash with its blocks
+// @Tags trash
+// @Produce json
+// @Param id query string true "Note ID"
+// @Success 204
+// @Failure 400 {object} domain.Error
+// @Failure 401 {object} domain.Error
+// @Failure 404 {object} domain.Error
+// @Failure 502 {object} domain.Error
+// @Failure 504 {object} domain.Error
+// @Router /api/trash/note [delete]
+func (e *Echo) PurgeNoteFromTrash(c echo.Context) error {
+	const op = "gateway.net.PurgeNoteFromTrash"
+
+	api := e.bnAPI.API
+
+	id := c.QueryParam("id")
+	if id == "" {
+		return c.JSON(http.StatusBadRequest, domain.Error{Error: "bad param"})
+	}
+
+	idUser, errGetId := getIdUser(c)
+	if errGetId != nil {
+		return c.JSON(http.StatusUnauthorized, domain.Error{Error: "bad idUser from access token"})
+	}
+
+	ctx, done := context.WithTimeout(c.Request().Context(), domain.WaitTime)
+	defer done()
+
+	_, err := api.PurgeNoteFromTrash(ctx, &brzrpc.UserNoteId{NoteId: id, UserId: idUser})
+	code, errRes := bNErrors(op, err)
+	if code != http.StatusOK {
+		return c.JSON(code, errRes)
+	}
+
+	if _, err := e.rdsAPI.API.RmNotesFromTrashByUser(ctx, &brzrpc.UserId{UserId: idUser}); err != nil {
+		st, ok := status.FromError(err)
+		if !ok {
+			log.Error(op, "REDIS ERROR", err)
+		} else {
+			if st.Code() != codes.NotFound {
+				log.Error(op, "REDIS ERROR", err)
+			}
+		}
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// SetTrashRetention godoc
+// @Summary Set trash retention
+// @Description Sets how many days notes of user stay in trash before deleting forever. 0 resets to default
+// @Tags trash
+// @Accept json
+// @Produce json
+// @Param Retention body domain.TrashRetentionRequest true "days from 0 to 365"
+// @Success 204
+// @Failure 400 {object} domain.Error
+// @Failure 401 {object} domain.Error
+// @Failure 502 {object} domain.Error
+// @Failure 504 {object} domain.Error
+// @Router /api/trash/retention [patch]
+func (e *Echo) SetTrashRetention(c echo.Context) error {
+	const op = "gateway.net.SetTrashRetention"
+
+	api := e.bnAPI.API
+
+	idUser, errGetId := getIdUser(c)
+	if errGetId != nil {
+		return c.JSON(http.StatusUnauthorized, domain.Error{Error: "bad idUser from access token"})
+	}
+
+	var r domain.TrashRetentionRequest
+	if err := c.Bind(&r); err != nil {
+		return c.JSON(http.StatusBadRequest, domain.Error{Error: "bad JSON"})
+	}
+
+	ctx, done := context.WithTimeout(c.Request().Context(), domain.WaitTime)
+	defer done()
+
+	_, err := api.SetTrashRetention(ctx, &brzrpc.TrashRetentionRequest{UserId: idUser, Days: r.Days})
+	code, errRes := bNErrors(op, err)
+	if code != http.StatusOK {
+		return c.JSON(code, errRes)
+	}
+
+	// preferences show retention too
+	e.rmPreferencesCache(ctx, op, idUser)
+
+	if _, err := e.rdsAPI.API.RmNotesFromTrashByUser(ctx, &brzrpc.UserId{UserId: idUser}); err != nil {
+		st, ok := status.FromError(err)
+		if !ok {
+			log.Error(op, "REDIS ERROR", err)
+		} else {
+			if st.Code() != codes.NotFound {
+				log.Error(op, "REDIS ERROR", err)
+			}
+		}
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
 // GetNotesFromTrash godoc
 // @Summary notes from trash
 // @Description Returns notes from trash by user ID
@@ -0,0 +1,127 @@
+package net
+
+import (
+	"context"
+	"net/http"
+
+	brzrpc "github.com/autumnterror/breezynotes/api/proto/gen"
+	"github.com/autumnterror/breezynotes/internal/gateway/domain"
+
+	"github.com/labstack/echo/v4"
+)
+
+// SetupTotp godoc
+// @Summary start 2FA enrolment
+// @Description Generates TOTP secret and otpauth URI for authenticator app. 2FA is enabled after confirm
+// @Tags user
+// @Produce json
+// @Success 200 {object} domain.TotpSetup
+// @Failure 302 {object} domain.Error
+// @Failure 401 {object} domain.Error
+// @Failure 502 {object} domain.Error
+// @Failure 504 {object} domain.Error
+// @Router /api/user/2fa/totp [post]
+func (e *Echo) SetupTotp(c echo.Context) error {
+	const op = "gateway.net.SetupTotp"
+
+	idUser, errGetId := getIdUser(c)
+	if errGetId != nil {
+		return c.JSON(http.StatusUnauthorized, domain.Error{Error: "bad idUser from access token"})
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request().Context(), domain.WaitTime)
+	defer cancel()
+
+	ts, err := e.authAPI.API.SetupTotp(ctx, &brzrpc.UserId{UserId: idUser})
+	code, errRes := authErrors(op, err)
+	if code != http.StatusOK {
+		return c.JSON(code, errRes)
+	}
+
+	return c.JSON(http.StatusOK, domain.TotpSetup{Secret: ts.GetSecret(), Uri: ts.GetUri()})
+}
+
+// ConfirmTotp godoc
+// @Summary confirm 2FA enrolment
+// @Description Enables 2FA if code from authenticator app is right. Returns recovery codes, they are shown only once
+// @Tags user
+// @Accept json
+// @Produce json
+// @Param request body domain.TotpCodeRequest true "code from app"
+// @Success 200 {object} domain.RecoveryCodes
+// @Failure 400 {object} domain.Error
+// @Failure 401 {object} domain.Error
+// @Failure 404 {object} domain.Error
+// @Failure 502 {object} domain.Error
+// @Failure 504 {object} domain.Error
+// @Router /api/user/2fa/totp/confirm [post]
+func (e *Echo) ConfirmTotp(c echo.Context) error {
+	const op = "gateway.net.ConfirmTotp"
+
+	idUser, errGetId := getIdUser(c)
+	if errGetId != nil {
+		return c.JSON(http.StatusUnauthorized, domain.Error{Error: "bad idUser from access token"})
+	}
+
+	var r domain.TotpCodeRequest
+	if err := c.Bind(&r); err != nil {
+		return c.JSON(http.StatusBadRequest, domain.Error{Error: "bad JSON"})
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request().Context(), domain.WaitTime)
+	defer cancel()
+
+	rc, err := e.authAPI.API.ConfirmTotp(ctx, &brzrpc.TotpCodeRequest{
+		UserId: idUser,
+		Code:   r.Code,
+	})
+	code, errRes := authErrors(op, err)
+	if code != http.StatusOK {
+		return c.JSON(code, errRes)
+	}
+
+	return c.JSON(http.StatusOK, domain.RecoveryCodes{Codes: rc.GetCodes()})
+}
+
+// DisableTotp godoc
+// @Summary disable 2FA
+// @Description Turns off 2FA. Password and code from authenticator app or recovery code are required
+// @Tags user
+// @Accept json
+// @Produce json
+// @Param request body domain.TotpCodeRequest true "password and code"
+// @Success 204
+// @Failure 400 {object} domain.Error
+// @Failure 401 {object} domain.Error
+// @Failure 404 {object} domain.Error
+// @Failure 502 {object} domain.Error
+// @Failure 504 {object} domain.Error
+// @Router /api/user/2fa/totp [delete]
+func (e *Echo) DisableTotp(c echo.Context) error {
+	const op = "gateway.net.DisableTotp"
+
+	idUser, errGetId := getIdUser(c)
+	if errGetId != nil {
+		return c.JSON(http.StatusUnauthorized, domain.Error{Error: "bad idUser from access token"})
+	}
+
+	var r domain.TotpCodeRequest
+	if err := c.Bind(&r); err != nil {
+		return c.JSON(http.StatusBadRequest, domain.Error{Error: "bad JSON"})
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request().Context(), domain.WaitTime)
+	defer cancel()
+
+	_, err := e.authAPI.API.DisableTotp(ctx, &brzrpc.TotpCodeRequest{
+		UserId:   idUser,
+		Code:     r.Code,
+		Password: r.Password,
+	})
+	code, errRes := authErrors(op, err)
+	if code != http.StatusOK {
+		return c.JSON(code, errRes)
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
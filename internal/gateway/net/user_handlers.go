@@ -3,6 +3,7 @@ package net
 import (
 	"context"
 	"net/http"
+	"strconv"
 
 	brzrpc "github.com/autumnterror/breezynotes/api/proto/gen"
 	"github.com/autumnterror/breezynotes/internal/gateway/domain"
@@ -45,58 +46,6 @@ func (e *Echo) GetUserData(c echo.Context) error {
 	return c.JSON(http.StatusOK, domain.UserFromRpc(u))
 }
 
-// DeleteUser godoc
-// @Summary delete user account
-// @Description Deletes user account by ID. Requires authentication.
-// @Tags user
-// @Produce json
-// @Success 200
-// @Failure 400 {object} domain.Error
-// @Failure 401 {object} domain.Error
-// @Failure 404 {object} domain.Error
-// @Failure 502 {object} domain.Error
-// @Failure 504 {object} domain.Error
-// @Router /api/user [delete]
-func (e *Echo) DeleteUser(c echo.Context) error {
-	const op = "gateway.net.DeleteUser"
-
-	idUser, errGetId := getIdUser(c)
-	if errGetId != nil {
-		return c.JSON(http.StatusUnauthorized, domain.Error{Error: "bad idUser from access token"})
-	}
-
-	auth := e.authAPI.API
-
-	ctx, cancel := context.WithTimeout(c.Request().Context(), domain.WaitTime)
-	defer cancel()
-
-	_, err := e.bnAPI.API.NotesToTrash(ctx, &brzrpc.UserId{UserId: idUser})
-	code, errRes := bNErrors(op, err)
-	if code != http.StatusOK {
-		return c.JSON(code, errRes)
-	}
-	_, err = e.bnAPI.API.CleanTrash(ctx, &brzrpc.UserId{UserId: idUser})
-	code, errRes = bNErrors(op, err)
-	if code != http.StatusOK {
-		return c.JSON(code, errRes)
-	}
-
-	_, err = e.bnAPI.API.DeleteTags(ctx, &brzrpc.UserId{UserId: idUser})
-	code, errRes = bNErrors(op, err)
-	if code != http.StatusOK {
-		return c.JSON(code, errRes)
-	}
-
-	_, err = auth.DeleteUser(ctx, &brzrpc.UserId{UserId: idUser})
-
-	code, errRes = authErrors(op, err)
-	if code != http.StatusOK {
-		return c.JSON(code, errRes)
-	}
-
-	return c.NoContent(http.StatusNoContent)
-}
-
 // UpdateAbout godoc
 // @Summary update user about
 // @Description Updates user "about" field. Requires authentication.
@@ -234,7 +183,7 @@ func (e *Echo) UpdatePhoto(c echo.Context) error {
 
 // ChangePassword godoc
 // @Summary change user password
-// @Description Changes user password. Requires authentication.
+// @Description Changes user password. Requires authentication. With revoke_other_sessions all other devices are logged out.
 // @Tags user
 // @Accept json
 // @Produce json
@@ -269,10 +218,17 @@ func (e *Echo) ChangePassword(c echo.Context) error {
 	ctx, cancel := context.WithTimeout(c.Request().Context(), domain.WaitTime)
 	defer cancel()
 
+	at := ""
+	if cookie, err := c.Cookie("access_token"); err == nil {
+		at = cookie.Value
+	}
+
 	_, err := api.ChangePasswd(ctx, &brzrpc.ChangePasswordRequest{
-		Id:          idUser,
-		NewPassword: req.NewPassword,
-		OldPassword: req.OldPassword,
+		Id:                  idUser,
+		NewPassword:         req.NewPassword,
+		OldPassword:         req.OldPassword,
+		RevokeOtherSessions: req.RevokeOtherSessions,
+		AccessToken:         at,
 	})
 
 	code, errRes := authErrors(op, err)
@@ -282,3 +238,96 @@ func (e *Echo) ChangePassword(c echo.Context) error {
 
 	return c.NoContent(http.StatusNoContent)
 }
+
+// UpdateDiscoverable godoc
+// @Summary turn user search on or off
+// @Description Not discoverable user is found in /api/user/search only by exact login or email. Requires authentication.
+// @Tags user
+// @Accept json
+// @Produce json
+// @Param request body domain.UpdateDiscoverableRequest true "discoverable"
+// @Success 204
+// @Failure 400 {object} domain.Error
+// @Failure 401 {object} domain.Error
+// @Failure 404 {object} domain.Error
+// @Failure 502 {object} domain.Error
+// @Failure 504 {object} domain.Error
+// @Router /api/user/discoverable [patch]
+func (e *Echo) UpdateDiscoverable(c echo.Context) error {
+	const op = "gateway.net.UpdateDiscoverable"
+
+	idUser, errGetId := getIdUser(c)
+	if errGetId != nil {
+		return c.JSON(http.StatusUnauthorized, domain.Error{Error: "bad idUser from access token"})
+	}
+
+	var req domain.UpdateDiscoverableRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, domain.Error{Error: "bad JSON"})
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request().Context(), domain.WaitTime)
+	defer cancel()
+
+	_, err := e.authAPI.API.UpdateDiscoverable(ctx, &brzrpc.UpdateDiscoverableRequest{
+		Id:           idUser,
+		Discoverable: req.Discoverable,
+	})
+
+	code, errRes := authErrors(op, err)
+	if code != http.StatusOK {
+		return c.JSON(code, errRes)
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// SearchUsers godoc
+// @Summary search users to share with
+// @Description Finds users by start of login or email or by login with typo, exact match goes first.
+// @Description Users who turned off discoverability are found only by exact login or email. Email is not returned.
+// @Description Limited per user, see search_rate_limit of config
+// @Tags user
+// @Produce json
+// @Param q query string true "from 2 to 50 symbols"
+// @Param limit query int false "10 by default, at most 20"
+// @Success 200 {array} domain.UserCard
+// @Failure 400 {object} domain.Error
+// @Failure 401 {object} domain.Error
+// @Failure 429 {object} domain.Error
+// @Failure 502 {object} domain.Error
+// @Failure 504 {object} domain.Error
+// @Router /api/user/search [get]
+func (e *Echo) SearchUsers(c echo.Context) error {
+	const op = "gateway.net.SearchUsers"
+
+	idUser, errGetId := getIdUser(c)
+	if errGetId != nil {
+		return c.JSON(http.StatusUnauthorized, domain.Error{Error: "bad idUser from access token"})
+	}
+
+	limit := 0
+	if l := c.QueryParam("limit"); l != "" {
+		n, err := strconv.Atoi(l)
+		if err != nil || n <= 0 {
+			return c.JSON(http.StatusBadRequest, domain.Error{Error: "limit must be positive int"})
+		}
+		limit = n
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request().Context(), domain.WaitTime)
+	defer cancel()
+
+	us, err := e.authAPI.API.SearchUsers(ctx, &brzrpc.SearchUsersRequest{
+		UserId: idUser,
+		Query:  c.QueryParam("q"),
+		Limit:  int32(limit),
+	})
+
+	code, errRes := authErrors(op, err)
+	if code != http.StatusOK {
+		return c.JSON(code, errRes)
+	}
+
+	return c.JSON(http.StatusOK, domain.ToUserCards(us))
+}
@@ -0,0 +1,729 @@
+package net
+
+import (
+	"archive/zip"
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	brzrpc "github.com/autumnterror/breezynotes/api/proto/gen"
+	"github.com/autumnterror/breezynotes/internal/gateway/domain"
+	"github.com/autumnterror/utils_go/pkg/log"
+	"github.com/autumnterror/utils_go/pkg/utils/uid"
+	"github.com/labstack/echo/v4"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+const (
+	// vaultTagColor color of tags made from folders and front matter
+	vaultTagColor = "pink"
+	folderEmoji   = "📁"
+	tagEmoji      = "🏷️"
+)
+
+var (
+	// wikiLink [[Note]], [[Note#heading|alias]], ![[image.png|300]]
+	wikiLink = regexp.MustCompile(`(!?)\[\[([^\[\]\n]+)\]\]`)
+	// mdLocalLink [text](path) and ![alt](path), urls with scheme are skipped later
+	mdLocalLink = regexp.MustCompile(`(!?)\[([^\]\n]*)\]\(<?([^)<>\s]+)>?(?:\s+"[^"]*")?\)`)
+	// logseqProperty key:: value of Logseq page or block
+	logseqProperty = regexp.MustCompile(`^\s*(?:- )?([A-Za-z][\w-]*):: ?(.*)$`)
+	// imageSize alias of embedded image in Obsidian: ![[img.png|300]] or ![[img.png|300x200]]
+	imageSize = regexp.MustCompile(`^\d+(x\d+)?$`)
+	fence     = regexp.MustCompile("^\\s*(```|~~~)")
+)
+
+var imageExts = map[string]bool{
+	".png": true, ".jpg": true, ".jpeg": true, ".gif": true, ".webp": true, ".svg": true, ".bmp": true, ".avif": true,
+}
+
+// ImportVault godoc
+// @Summary import vault of Obsidian or Logseq
+// @Description Creates notes from zip of vault. Title and tags are taken from front matter (Logseq properties) or file name,
+// @Description first tag or folder of note becomes its tag, other tags are reported. [[Wiki links]] and links to .md files
+// @Description lead to created notes, embedded images and files are uploaded. Markdown is converted to blocks.
+// @Description Creation time is from front matter or from time of file, so repeated import skips same notes.
+// @Description Links to skipped notes and notes missing in vault stay as text and are reported
+// @Tags user
+// @Accept multipart/form-data
+// @Produce json
+// @Param file formData file true "zip archive of vault"
+// @Success 200 {object} domain.ImportReport
+// @Failure 400 {object} domain.Error "Неверный формат архива"
+// @Failure 401 {object} domain.Error
+// @Failure 413 {object} domain.Error "archive exceeds limits of import"
+// @Failure 429 {object} domain.Error
+// @Failure 502 {object} domain.Error
+// @Router /api/user/import/vault [post]
+func (e *Echo) ImportVault(c echo.Context) error {
+	const op = "gateway.net.ImportVault"
+
+	idUser, errGetId := getIdUser(c)
+	if errGetId != nil {
+		return c.JSON(http.StatusUnauthorized, domain.Error{Error: "bad idUser from access token"})
+	}
+
+	zr, f, err := formZip(c)
+	if err != nil {
+		code, errRes := formError(err)
+		return c.JSON(code, errRes)
+	}
+	defer f.Close()
+
+	r := domain.NewImportReport()
+	v := newVault(zr)
+	if len(v.notes) == 0 {
+		return c.JSON(http.StatusBadRequest, domain.Error{Error: "there are no markdown notes in archive"})
+	}
+	if err := checkImportNotes(len(v.notes)); err != nil {
+		code, errRes := importError(op, err)
+		return c.JSON(code, errRes)
+	}
+	for _, n := range v.notes {
+		if err := v.readNote(n); err != nil {
+			if errors.Is(err, errImportLimit) {
+				code, errRes := importError(op, err)
+				return c.JSON(code, errRes)
+			}
+			r.Conflict(domain.ConflictNoteInvalid, n.path, n.title, err.Error())
+			n.skip = true
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request().Context(), domain.WaitTime)
+	tr, err := e.bnAPI.API.ImportTags(ctx, &brzrpc.ImportTagsRequest{UserId: idUser, Tags: v.tags()})
+	cancel()
+	if err != nil {
+		code, errRes := bNErrors(op, err)
+		return c.JSON(code, errRes)
+	}
+	r.Add(tr)
+
+	im := &noteImport{x: v.x, r: r, idUser: idUser, tags: tr.GetIds(), files: make(map[string]string)}
+	for _, n := range v.notes {
+		if n.skip {
+			continue
+		}
+		if code, errRes := e.importVaultNote(c.Request().Context(), im, v, n); code != http.StatusOK {
+			return c.JSON(code, errRes)
+		}
+	}
+
+	return c.JSON(http.StatusOK, r)
+}
+
+// importVaultNote rewrite links of note, upload its files, convert it to blocks and create it
+func (e *Echo) importVaultNote(ctx context.Context, im *noteImport, v *vault, n *vaultNote) (int, domain.Error) {
+	const op = "gateway.net.importVaultNote"
+
+	for _, t := range n.dropped {
+		im.r.Conflict(domain.ConflictTagDropped, n.path, n.title, "note can have one tag, tag "+t+" is not set")
+	}
+
+	body, copied, err := v.rewrite(im, n, e.cfg.PublicUrl)
+	if err != nil {
+		im.dropFiles(op, copied)
+		return importError(op, err)
+	}
+
+	cctx, cancel := context.WithTimeout(ctx, domain.WaitTime)
+	blks, err := e.bnAPI.API.ConvertMarkdown(cctx, &brzrpc.String{Value: body})
+	cancel()
+	if err != nil {
+		for _, name := range copied {
+			if errDel := deleteFile(im.files[name]); errDel != nil {
+				log.Error(op, "remove file of skipped note", errDel)
+			}
+			delete(im.files, name)
+		}
+		if status.Code(err) == codes.InvalidArgument {
+			im.r.Conflict(domain.ConflictNoteInvalid, n.path, n.title, status.Convert(err).Message())
+			return http.StatusOK, domain.Error{}
+		}
+		return bNErrors(op, err)
+	}
+
+	note := &brzrpc.NoteWithBlocks{
+		Id:        n.path,
+		Title:     n.title,
+		CreatedAt: n.created,
+		UpdatedAt: n.updated,
+		Blocks:    fileBlocks(blks.GetItems(), im.files),
+	}
+	if n.tag != "" {
+		note.Tag = &brzrpc.Tag{Id: tagKey(n.tag)}
+	}
+
+	return e.createNote(ctx, im, &brzrpc.ImportNoteRequest{
+		UserId: im.idUser,
+		Note:   note,
+		Tags:   im.tags,
+		NewId:  n.id,
+	}, copied)
+}
+
+// fileBlocks make file blocks of link blocks which lead to uploaded files which are not images
+func fileBlocks(blks []*brzrpc.Block, files map[string]string) []*brzrpc.Block {
+	uploaded := make(map[string]bool, len(files))
+	for _, name := range files {
+		if name != "" && !imageExts[path.Ext(name)] {
+			uploaded[name] = true
+		}
+	}
+	for _, b := range blks {
+		if b.GetType() != "link" {
+			continue
+		}
+		src := b.GetData().GetFields()["url"].GetStringValue()
+		if !uploaded[src] {
+			continue
+		}
+		b.Type = "file"
+		b.Data = &structpb.Struct{Fields: map[string]*structpb.Value{"src": structpb.NewStringValue(src)}}
+	}
+	return blks
+}
+
+type vaultNote struct {
+	// path of note in vault, it is also id of note in report
+	path  string
+	entry string
+	// id of note which will be created
+	id    string
+	title string
+	tag   string
+	// tags which are not set, note has only one
+	dropped  []string
+	created  int64
+	updated  int64
+	modified time.Time
+	body     string
+	skip     bool
+}
+
+// vault notes and attachments of zip. Paths are without common root folder,
+// hidden folders and settings of Obsidian and Logseq are skipped
+type vault struct {
+	x      *importReader
+	root   string
+	logseq bool
+	notes  []*vaultNote
+	// attachments by path in vault and by lower base name
+	files  map[string]string
+	byBase map[string]string
+	// notes by lower title, alias, path and base name without extension
+	index map[string]*vaultNote
+	// tag key -> how tag is made, folder or front matter
+	tagKinds map[string]string
+	tagNames map[string]string
+}
+
+func newVault(zr *zip.Reader) *vault {
+	v := &vault{
+		x:        newImportReader(zr),
+		files:    make(map[string]string),
+		byBase:   make(map[string]string),
+		index:    make(map[string]*vaultNote),
+		tagKinds: make(map[string]string),
+		tagNames: make(map[string]string),
+	}
+
+	var names []string
+	for _, f := range zr.File {
+		if !f.FileInfo().IsDir() {
+			names = append(names, strings.ReplaceAll(f.Name, `\`, "/"))
+		}
+	}
+	v.root = commonRoot(names)
+
+	for _, f := range zr.File {
+		if f.FileInfo().IsDir() {
+			continue
+		}
+		p := strings.TrimPrefix(strings.ReplaceAll(f.Name, `\`, "/"), v.root)
+		if strings.HasPrefix(p, "logseq/") {
+			v.logseq = true
+		}
+		if hiddenPath(p) {
+			continue
+		}
+		switch strings.ToLower(path.Ext(p)) {
+		case ".md", ".markdown":
+			v.notes = append(v.notes, &vaultNote{path: p, entry: f.Name, id: uid.New(), modified: f.Modified})
+		default:
+			v.files[p] = f.Name
+			if _, ok := v.byBase[strings.ToLower(path.Base(p))]; !ok {
+				v.byBase[strings.ToLower(path.Base(p))] = p
+			}
+		}
+	}
+	if v.logseq {
+		// settings, backups and old versions of Logseq
+		notes := v.notes[:0]
+		for _, n := range v.notes {
+			if !strings.HasPrefix(n.path, "logseq/") {
+				notes = append(notes, n)
+			}
+		}
+		v.notes = notes
+	}
+	sort.Slice(v.notes, func(i, j int) bool { return v.notes[i].path < v.notes[j].path })
+
+	for _, n := range v.notes {
+		n.title = v.fileTitle(n.path)
+		v.addIndex(n, n.title)
+		noExt := strings.TrimSuffix(n.path, path.Ext(n.path))
+		v.addIndex(n, noExt)
+		v.addIndex(n, path.Base(noExt))
+	}
+	return v
+}
+
+// readNote read front matter or properties, title, tag and times of note
+func (v *vault) readNote(n *vaultNote) error {
+	raw, err := v.x.read(n.entry)
+	if err != nil {
+		return err
+	}
+	body := strings.ReplaceAll(string(raw), "\r\n", "\n")
+
+	var props map[string][]string
+	if v.logseq {
+		props, body = logseqProperties(body)
+	} else {
+		props, body = frontMatter(body)
+	}
+
+	if t := first(props["title"]); t != "" {
+		n.title = t
+		v.addIndex(n, t)
+	}
+	for _, a := range append(props["aliases"], props["alias"]...) {
+		v.addIndex(n, a)
+	}
+
+	tags := append(props["tags"], props["tag"]...)
+	if len(tags) == 0 {
+		if dir := v.folderTag(n); dir != "" {
+			n.tag = dir
+			v.addTag(dir, folderEmoji)
+		}
+	} else {
+		n.tag = tags[0]
+		v.addTag(tags[0], tagEmoji)
+		n.dropped = tags[1:]
+	}
+
+	n.created = parseVaultTime(first(props["created"]), first(props["date"]), first(props["created_at"]))
+	n.updated = parseVaultTime(first(props["updated"]), first(props["modified"]), first(props["updated_at"]))
+	if !n.modified.IsZero() {
+		if n.created == 0 {
+			n.created = n.modified.UTC().Unix()
+		}
+		if n.updated == 0 {
+			n.updated = n.modified.UTC().Unix()
+		}
+	}
+
+	// title is also first header of note in most vaults
+	trimmed := strings.TrimLeft(body, "\n")
+	line, rest, _ := strings.Cut(trimmed, "\n")
+	if h := strings.TrimSpace(strings.TrimPrefix(line, "# ")); strings.HasPrefix(line, "# ") && strings.EqualFold(h, n.title) {
+		body = rest
+	}
+	n.body = body
+	return nil
+}
+
+// fileTitle title from name of file. Logseq writes namespace a/b as a___b, older versions as a%2Fb
+func (v *vault) fileTitle(p string) string {
+	name := strings.TrimSuffix(path.Base(p), path.Ext(p))
+	if !v.logseq {
+		return name
+	}
+	name = strings.ReplaceAll(name, "___", "/")
+	if un, err := url.PathUnescape(name); err == nil {
+		name = un
+	}
+	if strings.HasPrefix(p, "journals/") {
+		// journals/2024_01_31.md
+		if t, err := time.Parse("2006_01_02", name); err == nil {
+			return t.Format("2006-01-02")
+		}
+	}
+	return name
+}
+
+// folderTag folder of note, for Logseq namespace of page
+func (v *vault) folderTag(n *vaultNote) string {
+	if v.logseq {
+		if i := strings.LastIndex(n.title, "/"); i > 0 {
+			return n.title[:i]
+		}
+		return ""
+	}
+	dir := path.Dir(n.path)
+	if dir == "." {
+		return ""
+	}
+	return dir
+}
+
+func (v *vault) addIndex(n *vaultNote, key string) {
+	key = strings.ToLower(strings.TrimSpace(key))
+	if _, ok := v.index[key]; !ok && key != "" {
+		v.index[key] = n
+	}
+}
+
+func (v *vault) addTag(title, emoji string) {
+	k := tagKey(title)
+	if _, ok := v.tagKinds[k]; !ok {
+		v.tagKinds[k] = emoji
+		v.tagNames[k] = title
+	}
+}
+
+// tags to create, id of tag is its key, so notes refer to it by key
+func (v *vault) tags() []*brzrpc.Tag {
+	keys := make([]string, 0, len(v.tagKinds))
+	for k := range v.tagKinds {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	tgs := make([]*brzrpc.Tag, 0, len(keys))
+	for _, k := range keys {
+		tgs = append(tgs, &brzrpc.Tag{Id: k, Title: v.tagNames[k], Color: vaultTagColor, Emoji: v.tagKinds[k]})
+	}
+	return tgs
+}
+
+// rewrite links of note to notes of import and uploaded files. It returns paths of files uploaded for this note
+func (v *vault) rewrite(im *noteImport, n *vaultNote, publicUrl string) (string, []string, error) {
+	var copied []string
+	var errCopy error
+
+	upload := func(p string) string {
+		newName, ok := im.files[p]
+		if ok {
+			return newName
+		}
+		newName, err := v.x.copy(v.files[p])
+		switch {
+		case errors.Is(err, errTooLarge), errors.Is(err, ErrFileName):
+			im.r.Conflict(domain.ConflictFileInvalid, p, n.title, err.Error())
+		case err != nil:
+			errCopy = err
+			return ""
+		default:
+			copied = append(copied, p)
+		}
+		im.files[p] = newName
+		return newName
+	}
+	noteLink := func(text, target string) string {
+		if to := v.index[strings.ToLower(target)]; to != nil && !to.skip {
+			return "[" + text + "](" + publicUrl + "/note/" + to.id + ")"
+		}
+		im.r.Conflict(domain.ConflictLinkUnresolved, n.path, n.title, "note "+target+" is not in vault")
+		return text
+	}
+	fileLink := func(embed bool, text, p string) string {
+		name := upload(p)
+		if name == "" {
+			return text
+		}
+		if imageExts[strings.ToLower(path.Ext(p))] {
+			return "![" + text + "](" + name + ")"
+		}
+		if text == "" {
+			text = path.Base(p)
+		}
+		if embed {
+			// own paragraph, so it becomes file block
+			return "\n\n[" + text + "](" + name + ")\n\n"
+		}
+		return "[" + text + "](" + name + ")"
+	}
+
+	dir := path.Dir(n.path)
+	lines := strings.Split(n.body, "\n")
+	inCode := false
+	for i, line := range lines {
+		if fence.MatchString(line) {
+			inCode = !inCode
+			continue
+		}
+		if inCode {
+			continue
+		}
+
+		// links made from wiki links are not local, so markdown links go first
+		line = mdLocalLink.ReplaceAllStringFunc(line, func(m string) string {
+			sm := mdLocalLink.FindStringSubmatch(m)
+			embed, text, target := sm[1] == "!", sm[2], sm[3]
+			if u, err := url.Parse(target); err != nil || u.Scheme != "" || strings.HasPrefix(target, "#") {
+				return m
+			}
+			if un, err := url.PathUnescape(target); err == nil {
+				target = un
+			}
+			target, _, _ = strings.Cut(target, "#")
+
+			if ext := path.Ext(target); strings.EqualFold(ext, ".md") {
+				key := strings.TrimSuffix(strings.TrimPrefix(path.Join(dir, target), "/"), ext)
+				if v.index[strings.ToLower(key)] == nil {
+					key = strings.TrimSuffix(path.Base(target), ext)
+				}
+				return noteLink(text, key)
+			}
+			p := v.attachment(dir, target)
+			if p == "" {
+				im.r.Conflict(domain.ConflictFileMissing, target, n.title, "file is not in vault")
+				return text
+			}
+			return fileLink(embed, text, p)
+		})
+		line = wikiLink.ReplaceAllStringFunc(line, func(m string) string {
+			sm := wikiLink.FindStringSubmatch(m)
+			embed := sm[1] == "!"
+			target, alias, _ := strings.Cut(sm[2], "|")
+			target = strings.TrimSpace(target)
+			target, heading, _ := strings.Cut(target, "#")
+			text := strings.TrimSpace(alias)
+			if text == "" || imageSize.MatchString(text) {
+				text = target
+				if heading != "" && target != "" {
+					text += " > " + strings.TrimPrefix(heading, "^")
+				}
+			}
+
+			ext := strings.ToLower(path.Ext(target))
+			if ext != "" && ext != ".md" {
+				p := v.attachment(dir, target)
+				if p == "" {
+					im.r.Conflict(domain.ConflictFileMissing, target, n.title, "file is not in vault")
+					return text
+				}
+				if embed && imageExts[ext] {
+					text = strings.TrimSuffix(path.Base(target), ext)
+				}
+				return fileLink(embed, text, p)
+			}
+			if target == "" {
+				// link to heading of same note
+				return text
+			}
+			return noteLink(text, strings.TrimSuffix(target, ".md"))
+		})
+
+		if errCopy != nil {
+			return "", copied, errCopy
+		}
+		lines[i] = line
+	}
+	return strings.Join(lines, "\n"), copied, nil
+}
+
+// attachment find file by path relative to note, by path from root of vault or by name
+func (v *vault) attachment(dir, target string) string {
+	for _, p := range []string{path.Join(dir, target), path.Clean(strings.TrimPrefix(target, "/"))} {
+		if _, ok := v.files[p]; ok {
+			return p
+		}
+	}
+	return v.byBase[strings.ToLower(path.Base(target))]
+}
+
+// commonRoot folder which has all files of archive, with slash. Empty if there is no such folder
+func commonRoot(names []string) string {
+	root := ""
+	for i, n := range names {
+		dir, _, ok := strings.Cut(n, "/")
+		if !ok {
+			return ""
+		}
+		if i == 0 {
+			root = dir
+		} else if dir != root {
+			return ""
+		}
+	}
+	if root == "" {
+		return ""
+	}
+	return root + "/"
+}
+
+// hiddenPath is path with hidden folder or file, like .obsidian, .trash or __MACOSX
+func hiddenPath(p string) bool {
+	for _, part := range strings.Split(p, "/") {
+		if strings.HasPrefix(part, ".") || part == "__MACOSX" {
+			return true
+		}
+	}
+	return false
+}
+
+// frontMatter read YAML front matter of Obsidian: scalars, [a, b] and lists with "- ". Keys are lower case.
+// Body is returned without it
+func frontMatter(s string) (map[string][]string, string) {
+	props := make(map[string][]string)
+	if !strings.HasPrefix(s, "---\n") {
+		return props, s
+	}
+	end := strings.Index(s[4:], "\n---")
+	if end < 0 {
+		return props, s
+	}
+	head := s[4 : 4+end]
+	body := s[4+end+4:]
+	if i := strings.IndexByte(body, '\n'); i >= 0 {
+		body = body[i+1:]
+	} else {
+		body = ""
+	}
+
+	key := ""
+	for _, line := range strings.Split(head, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+		if strings.HasPrefix(trimmed, "- ") && key != "" {
+			props[key] = append(props[key], yamlValues(trimmed[2:])...)
+			continue
+		}
+		k, val, ok := strings.Cut(trimmed, ":")
+		if !ok || strings.HasPrefix(line, " ") {
+			continue
+		}
+		key = strings.ToLower(strings.TrimSpace(k))
+		props[key] = append(props[key], yamlValues(val)...)
+	}
+	for _, k := range []string{"tags", "tag"} {
+		props[k] = splitTags(props[k], false)
+	}
+	return props, body
+}
+
+// yamlValues values of scalar or [a, b]
+func yamlValues(s string) []string {
+	s = strings.TrimSpace(s)
+	if strings.HasPrefix(s, "[") && strings.HasSuffix(s, "]") {
+		var res []string
+		for _, part := range strings.Split(s[1:len(s)-1], ",") {
+			if v := unquote(part); v != "" {
+				res = append(res, v)
+			}
+		}
+		return res
+	}
+	if v := unquote(s); v != "" {
+		return []string{v}
+	}
+	return nil
+}
+
+func unquote(s string) string {
+	s = strings.TrimSpace(s)
+	if len(s) >= 2 && (s[0] == '"' && s[len(s)-1] == '"' || s[0] == '\'' && s[len(s)-1] == '\'') {
+		return s[1 : len(s)-1]
+	}
+	return s
+}
+
+// logseqProperties read key:: value properties. Properties of page are first lines, properties of blocks
+// are removed from body. Keys are lower case
+func logseqProperties(s string) (map[string][]string, string) {
+	props := make(map[string][]string)
+	lines := strings.Split(s, "\n")
+	body := make([]string, 0, len(lines))
+	page := true
+	for _, line := range lines {
+		m := logseqProperty.FindStringSubmatch(line)
+		if m == nil {
+			if strings.TrimSpace(line) != "" {
+				page = false
+			}
+			body = append(body, line)
+			continue
+		}
+		if page {
+			k := strings.ToLower(m[1])
+			if k == "title" {
+				props[k] = append(props[k], strings.TrimSpace(m[2]))
+				continue
+			}
+			for _, part := range strings.Split(m[2], ",") {
+				if v := strings.TrimSpace(part); v != "" {
+					props[k] = append(props[k], v)
+				}
+			}
+		}
+	}
+	for _, k := range []string{"tags", "tag", "alias", "aliases"} {
+		props[k] = splitTags(props[k], true)
+	}
+	return props, strings.Join(body, "\n")
+}
+
+// splitTags clean tags: # and [[ ]] are removed. Tags of Obsidian can't have spaces, so they are also split by them
+func splitTags(tags []string, spaces bool) []string {
+	var res []string
+	for _, t := range tags {
+		parts := []string{t}
+		if !spaces {
+			parts = strings.Fields(t)
+		}
+		for _, p := range parts {
+			p = strings.TrimPrefix(strings.TrimSpace(p), "#")
+			p = strings.TrimSuffix(strings.TrimPrefix(p, "[["), "]]")
+			if p != "" {
+				res = append(res, p)
+			}
+		}
+	}
+	return res
+}
+
+var vaultTimeLayouts = []string{
+	time.RFC3339,
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05",
+	"2006-01-02T15:04",
+	"2006-01-02 15:04",
+	"2006-01-02",
+}
+
+// parseVaultTime unix time of first value which is time, 0 if there is no such
+func parseVaultTime(values ...string) int64 {
+	for _, s := range values {
+		for _, l := range vaultTimeLayouts {
+			if t, err := time.Parse(l, s); err == nil {
+				return t.UTC().Unix()
+			}
+		}
+	}
+	return 0
+}
+
+func first(values []string) string {
+	if len(values) == 0 {
+		return ""
+	}
+	return values[0]
+}
+
+func tagKey(title string) string {
+	return strings.ToLower(strings.TrimSpace(title))
+}
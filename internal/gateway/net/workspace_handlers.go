@@ -0,0 +1,353 @@
+package net
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	brzrpc "github.com/autumnterror/breezynotes/api/proto/gen"
+	"github.com/autumnterror/breezynotes/internal/gateway/domain"
+	"github.com/autumnterror/utils_go/pkg/utils/uid"
+
+	"github.com/autumnterror/utils_go/pkg/log"
+	"github.com/labstack/echo/v4"
+)
+
+// GetWorkspaces godoc
+// @Summary workspaces of user
+// @Description Returns all workspaces where user is member with his role
+// @Tags workspace
+// @Produce json
+// @Success 200 {object} []domain.Workspace
+// @Failure 401 {object} domain.Error
+// @Failure 502 {object} domain.Error
+// @Failure 504 {object} domain.Error
+// @Router /api/workspace [get]
+func (e *Echo) GetWorkspaces(c echo.Context) error {
+	const op = "gateway.net.GetWorkspaces"
+
+	idUser, errGetId := getIdUser(c)
+	if errGetId != nil {
+		return c.JSON(http.StatusUnauthorized, domain.Error{Error: "bad idUser from access token"})
+	}
+
+	ctx, done := context.WithTimeout(c.Request().Context(), domain.WaitTime)
+	defer done()
+
+	ws, err := e.authAPI.API.GetWorkspacesByUser(ctx, &brzrpc.UserId{UserId: idUser})
+	code, errRes := authErrors(op, err)
+	if code != http.StatusOK {
+		return c.JSON(code, errRes)
+	}
+
+	return c.JSON(http.StatusOK, domain.ToWorkspaces(ws))
+}
+
+// CreateWorkspace godoc
+// @Summary Create workspace
+// @Description Creates new workspace, user become its owner
+// @Tags workspace
+// @Accept json
+// @Produce json
+// @Param Workspace body domain.CreateWorkspaceRequest true "Workspace data"
+// @Success 201 {object} domain.Id
+// @Failure 400 {object} domain.Error
+// @Failure 401 {object} domain.Error
+// @Failure 502 {object} domain.Error
+// @Failure 504 {object} domain.Error
+// @Router /api/workspace [post]
+func (e *Echo) CreateWorkspace(c echo.Context) error {
+	const op = "gateway.net.CreateWorkspace"
+
+	idUser, errGetId := getIdUser(c)
+	if errGetId != nil {
+		return c.JSON(http.StatusUnauthorized, domain.Error{Error: "bad idUser from access token"})
+	}
+
+	var r domain.CreateWorkspaceRequest
+	if err := c.Bind(&r); err != nil {
+		return c.JSON(http.StatusBadRequest, domain.Error{Error: "bad JSON"})
+	}
+
+	ctx, done := context.WithTimeout(c.Request().Context(), domain.WaitTime)
+	defer done()
+
+	id := uid.New()
+	_, err := e.authAPI.API.CreateWorkspace(ctx, &brzrpc.CreateWorkspaceRequest{
+		Id:     id,
+		Title:  r.Title,
+		UserId: idUser,
+	})
+	code, errRes := authErrors(op, err)
+	if code != http.StatusOK {
+		return c.JSON(code, errRes)
+	}
+
+	e.rmWorkspaceCache(ctx, op, idUser)
+
+	return c.JSON(http.StatusCreated, domain.Id{Id: id})
+}
+
+// DeleteWorkspace godoc
+// @Summary Delete workspace
+// @Description Deletes workspace, only owner can do it. Notes stay with their authors
+// @Tags workspace
+// @Produce json
+// @Param id query string true "Workspace ID"
+// @Success 204
+// @Failure 400 {object} domain.Error
+// @Failure 401 {object} domain.Error
+// @Failure 404 {object} domain.Error
+// @Failure 502 {object} domain.Error
+// @Failure 504 {object} domain.Error
+// @Router /api/workspace [delete]
+func (e *Echo) DeleteWorkspace(c echo.Context) error {
+	const op = "gateway.net.DeleteWorkspace"
+
+	id := c.QueryParam("id")
+	if id == "" {
+		return c.JSON(http.StatusBadRequest, domain.Error{Error: "bad param"})
+	}
+
+	idUser, errGetId := getIdUser(c)
+	if errGetId != nil {
+		return c.JSON(http.StatusUnauthorized, domain.Error{Error: "bad idUser from access token"})
+	}
+
+	ctx, done := context.WithTimeout(c.Request().Context(), domain.WaitTime)
+	defer done()
+
+	members, err := e.authAPI.API.GetWorkspaceMembers(ctx, &brzrpc.UserWorkspaceId{UserId: idUser, WorkspaceId: id})
+	code, errRes := authErrors(op, err)
+	if code != http.StatusOK {
+		return c.JSON(code, errRes)
+	}
+
+	_, err = e.authAPI.API.DeleteWorkspace(ctx, &brzrpc.UserWorkspaceId{UserId: idUser, WorkspaceId: id})
+	code, errRes = authErrors(op, err)
+	if code != http.StatusOK {
+		return c.JSON(code, errRes)
+	}
+
+	for _, m := range members.GetItems() {
+		e.rmWorkspaceCache(ctx, op, m.GetUserId())
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// GetWorkspaceMembers godoc
+// @Summary members of workspace
+// @Description Returns users of workspace with their roles, available only for members
+// @Tags workspace
+// @Produce json
+// @Param id query string true "Workspace ID"
+// @Success 200 {object} []domain.WorkspaceMember
+// @Failure 400 {object} domain.Error
+// @Failure 401 {object} domain.Error
+// @Failure 502 {object} domain.Error
+// @Failure 504 {object} domain.Error
+// @Router /api/workspace/members [get]
+func (e *Echo) GetWorkspaceMembers(c echo.Context) error {
+	const op = "gateway.net.GetWorkspaceMembers"
+
+	id := c.QueryParam("id")
+	if id == "" {
+		return c.JSON(http.StatusBadRequest, domain.Error{Error: "bad param"})
+	}
+
+	idUser, errGetId := getIdUser(c)
+	if errGetId != nil {
+		return c.JSON(http.StatusUnauthorized, domain.Error{Error: "bad idUser from access token"})
+	}
+
+	ctx, done := context.WithTimeout(c.Request().Context(), domain.WaitTime)
+	defer done()
+
+	members, err := e.authAPI.API.GetWorkspaceMembers(ctx, &brzrpc.UserWorkspaceId{UserId: idUser, WorkspaceId: id})
+	code, errRes := authErrors(op, err)
+	if code != http.StatusOK {
+		return c.JSON(code, errRes)
+	}
+
+	roles := make(map[string]string, len(members.GetItems()))
+	ids := make([]string, 0, len(members.GetItems()))
+	for _, m := range members.GetItems() {
+		roles[m.GetUserId()] = m.GetRole()
+		ids = append(ids, m.GetUserId())
+	}
+
+	infos, err := e.authAPI.API.GetInfos(ctx, &brzrpc.Ids{Ids: ids})
+	code, errRes = authErrors(op, err)
+	if code != http.StatusOK {
+		return c.JSON(code, errRes)
+	}
+
+	res := make([]domain.WorkspaceMember, 0, len(infos.GetUsers()))
+	for _, info := range infos.GetUsers() {
+		res = append(res, domain.WorkspaceMember{
+			User: *domain.UserFromRpc(info),
+			Role: roles[info.GetId()],
+		})
+	}
+
+	return c.JSON(http.StatusOK, res)
+}
+
+// AddWorkspaceMember godoc
+// @Summary add member to workspace
+// @Description Adds user by login or changes his role. Only owner can manage members
+// @Tags workspace
+// @Accept json
+// @Produce json
+// @Param Member body domain.WorkspaceMemberRequest true "member info, role is editor or reader"
+// @Success 204
+// @Failure 400 {object} domain.Error
+// @Failure 401 {object} domain.Error
+// @Failure 404 {object} domain.Error
+// @Failure 502 {object} domain.Error
+// @Failure 504 {object} domain.Error
+// @Router /api/workspace/members [post]
+func (e *Echo) AddWorkspaceMember(c echo.Context) error {
+	const op = "gateway.net.AddWorkspaceMember"
+
+	idUser, errGetId := getIdUser(c)
+	if errGetId != nil {
+		return c.JSON(http.StatusUnauthorized, domain.Error{Error: "bad idUser from access token"})
+	}
+
+	var r domain.WorkspaceMemberRequest
+	if err := c.Bind(&r); err != nil {
+		return c.JSON(http.StatusBadRequest, domain.Error{Error: "bad JSON"})
+	}
+
+	ctx, done := context.WithTimeout(c.Request().Context(), domain.WaitTime)
+	defer done()
+
+	id, err := e.authAPI.API.GetIdFromLogin(ctx, &brzrpc.String{Value: r.Login})
+	code, errRes := authErrors(op, err)
+	if code != http.StatusOK {
+		return c.JSON(code, errRes)
+	}
+
+	_, err = e.authAPI.API.AddWorkspaceMember(ctx, &brzrpc.WorkspaceMemberRequest{
+		WorkspaceId: r.WorkspaceId,
+		MemberId:    id.GetId(),
+		Role:        r.Role,
+		UserId:      idUser,
+	})
+	code, errRes = authErrors(op, err)
+	if code != http.StatusOK {
+		return c.JSON(code, errRes)
+	}
+
+	e.rmWorkspaceCache(ctx, op, id.GetId())
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// RemoveWorkspaceMember godoc
+// @Summary remove member from workspace
+// @Description Owner removes member or member leaves workspace himself
+// @Tags workspace
+// @Accept json
+// @Produce json
+// @Param Member body domain.WorkspaceMemberRequest true "member info, role is ignored"
+// @Success 204
+// @Failure 400 {object} domain.Error
+// @Failure 401 {object} domain.Error
+// @Failure 404 {object} domain.Error
+// @Failure 502 {object} domain.Error
+// @Failure 504 {object} domain.Error
+// @Router /api/workspace/members [delete]
+func (e *Echo) RemoveWorkspaceMember(c echo.Context) error {
+	const op = "gateway.net.RemoveWorkspaceMember"
+
+	idUser, errGetId := getIdUser(c)
+	if errGetId != nil {
+		return c.JSON(http.StatusUnauthorized, domain.Error{Error: "bad idUser from access token"})
+	}
+
+	var r domain.WorkspaceMemberRequest
+	if err := c.Bind(&r); err != nil {
+		return c.JSON(http.StatusBadRequest, domain.Error{Error: "bad JSON"})
+	}
+
+	ctx, done := context.WithTimeout(c.Request().Context(), domain.WaitTime)
+	defer done()
+
+	id, err := e.authAPI.API.GetIdFromLogin(ctx, &brzrpc.String{Value: r.Login})
+	code, errRes := authErrors(op, err)
+	if code != http.StatusOK {
+		return c.JSON(code, errRes)
+	}
+
+	_, err = e.authAPI.API.RemoveWorkspaceMember(ctx, &brzrpc.WorkspaceMemberRequest{
+		WorkspaceId: r.WorkspaceId,
+		MemberId:    id.GetId(),
+		UserId:      idUser,
+	})
+	code, errRes = authErrors(op, err)
+	if code != http.StatusOK {
+		return c.JSON(code, errRes)
+	}
+
+	e.rmWorkspaceCache(ctx, op, id.GetId())
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// workspaceRoles return membership of user as workspaceId:role pairs joined by comma. It is taken from redis session
+// or from auth and cached, rmWorkspaceCache removes it when membership changes
+func (e *Echo) workspaceRoles(ctx context.Context, op, idUser string) (string, error) {
+	if r, err := e.rdsAPI.API.GetWorkspaceRolesByUser(ctx, &brzrpc.UserId{UserId: idUser}); err == nil {
+		return r.GetValue(), nil
+	} else if status.Code(err) != codes.NotFound {
+		log.Error(op, "REDIS ERROR", err)
+	}
+
+	ws, err := e.authAPI.API.GetWorkspacesByUser(ctx, &brzrpc.UserId{UserId: idUser})
+	if err != nil {
+		return "", err
+	}
+	pairs := make([]string, 0, len(ws.GetItems()))
+	for _, w := range ws.GetItems() {
+		pairs = append(pairs, w.GetId()+":"+w.GetRole())
+	}
+	roles := strings.Join(pairs, ",")
+
+	if _, err := e.rdsAPI.API.SetWorkspaceRolesByUser(ctx, &brzrpc.WorkspaceRolesByUser{UserId: idUser, Roles: roles}); err != nil {
+		log.Error(op, "REDIS ERROR", err)
+	}
+	return roles, nil
+}
+
+// rmWorkspaceCache drop cached membership, note list and tags of user after his membership changed
+func (e *Echo) rmWorkspaceCache(ctx context.Context, op, idUser string) {
+	if _, err := e.rdsAPI.API.RmWorkspaceRolesByUser(ctx, &brzrpc.UserId{UserId: idUser}); err != nil {
+		log.Error(op, "REDIS ERROR", err)
+	}
+	if _, err := e.rdsAPI.API.RmNoteListByUser(ctx, &brzrpc.UserId{UserId: idUser}); err != nil {
+		st, ok := status.FromError(err)
+		if !ok {
+			log.Error(op, "REDIS ERROR", err)
+		} else {
+			if st.Code() != codes.NotFound {
+				log.Error(op, "REDIS ERROR", err)
+			}
+		}
+	}
+	if _, err := e.rdsAPI.API.RmTagsByUser(ctx, &brzrpc.UserId{UserId: idUser}); err != nil {
+		st, ok := status.FromError(err)
+		if !ok {
+			log.Error(op, "REDIS ERROR", err)
+		} else {
+			if st.Code() != codes.NotFound {
+				log.Error(op, "REDIS ERROR", err)
+			}
+		}
+	}
+}
@@ -0,0 +1,49 @@
+package api
+
+import (
+	"context"
+	"time"
+
+	brzrpc "github.com/autumnterror/breezynotes/api/proto/gen"
+	"github.com/autumnterror/breezynotes/internal/redis/domain"
+	"github.com/autumnterror/utils_go/pkg/utils/format"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+func (s *ServerAPI) GetExportJob(ctx context.Context, req *brzrpc.UserId) (*brzrpc.ExportJob, error) {
+	const op = "redis.grpc.GetExportJob"
+
+	ctx, done := context.WithTimeout(ctx, waitTime)
+	defer done()
+
+	res, err := handleCRUDResponse(ctx, op, func() (any, error) {
+		job, err := s.rds.GetExportJob(ctx, req.GetUserId())
+		if err == nil && job == nil {
+			return nil, domain.ErrNotFound
+		}
+		return job, err
+	})
+
+	if err != nil {
+		return nil, format.Error(op, err)
+	}
+
+	return res.(*brzrpc.ExportJob), nil
+}
+
+func (s *ServerAPI) SetExportJob(ctx context.Context, req *brzrpc.ExportJobTtl) (*emptypb.Empty, error) {
+	const op = "redis.grpc.SetExportJob"
+
+	ctx, done := context.WithTimeout(ctx, waitTime)
+	defer done()
+
+	_, err := handleCRUDResponse(ctx, op, func() (any, error) {
+		return nil, s.rds.SetExportJob(ctx, req.GetJob(), time.Duration(req.GetTtlMilliseconds())*time.Millisecond)
+	})
+
+	if err != nil {
+		return nil, format.Error(op, err)
+	}
+
+	return nil, nil
+}
@@ -256,3 +256,20 @@ func (s *ServerAPI) CleanNoteById(ctx context.Context, req *brzrpc.NoteId) (*emp
 
 	return nil, nil
 }
+
+func (s *ServerAPI) RmSessionByUser(ctx context.Context, req *brzrpc.UserId) (*emptypb.Empty, error) {
+	const op = "redis.grpc.RmSessionByUser"
+
+	ctx, done := context.WithTimeout(ctx, waitTime)
+	defer done()
+
+	_, err := handleCRUDResponse(ctx, op, func() (any, error) {
+		return nil, s.rds.DeleteSession(ctx, req.GetUserId())
+	})
+
+	if err != nil {
+		return nil, format.Error(op, err)
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,79 @@
+package api
+
+import (
+	"context"
+
+	brzrpc "github.com/autumnterror/breezynotes/api/proto/gen"
+	"github.com/autumnterror/breezynotes/internal/redis/domain"
+	"github.com/autumnterror/utils_go/pkg/utils/format"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+func (s *ServerAPI) GetPreferencesByUser(ctx context.Context, req *brzrpc.UserId) (*brzrpc.Preferences, error) {
+	const op = "redis.grpc.GetPreferencesByUser"
+
+	ctx, done := context.WithTimeout(ctx, waitTime)
+	defer done()
+
+	if !s.ensureCreate(ctx, req.GetUserId()) {
+		return nil, status.Error(codes.Internal, "can't create session")
+	}
+
+	res, err := handleCRUDResponse(ctx, op, func() (any, error) {
+		p, err := s.rds.GetSessionPreferences(ctx, req.GetUserId())
+		if err == nil && p == nil {
+			return nil, domain.ErrNotFound
+		}
+		return p, err
+	})
+
+	if err != nil {
+		return nil, format.Error(op, err)
+	}
+
+	return res.(*brzrpc.Preferences), nil
+}
+
+func (s *ServerAPI) SetPreferencesByUser(ctx context.Context, req *brzrpc.PreferencesByUser) (*emptypb.Empty, error) {
+	const op = "redis.grpc.SetPreferencesByUser"
+
+	ctx, done := context.WithTimeout(ctx, waitTime)
+	defer done()
+
+	if !s.ensureCreate(ctx, req.GetUserId()) {
+		return nil, status.Error(codes.Internal, "can't create session")
+	}
+
+	_, err := handleCRUDResponse(ctx, op, func() (any, error) {
+		return nil, s.rds.SetSessionPreferences(ctx, req.GetUserId(), req.GetPreferences())
+	})
+
+	if err != nil {
+		return nil, format.Error(op, err)
+	}
+
+	return nil, nil
+}
+
+func (s *ServerAPI) RmPreferencesByUser(ctx context.Context, req *brzrpc.UserId) (*emptypb.Empty, error) {
+	const op = "redis.grpc.RmPreferencesByUser"
+
+	ctx, done := context.WithTimeout(ctx, waitTime)
+	defer done()
+
+	if !s.ensureCreate(ctx, req.GetUserId()) {
+		return nil, status.Error(codes.Internal, "can't create session")
+	}
+
+	_, err := handleCRUDResponse(ctx, op, func() (any, error) {
+		return nil, s.rds.SetSessionPreferences(ctx, req.GetUserId(), nil)
+	})
+
+	if err != nil {
+		return nil, format.Error(op, err)
+	}
+
+	return nil, nil
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	brzrpc "github.com/autumnterror/breezynotes/api/proto/gen"
 	"github.com/autumnterror/utils_go/pkg/utils/format"
+	"google.golang.org/protobuf/types/known/emptypb"
 )
 
 func (s *ServerAPI) RateLimit(ctx context.Context, req *brzrpc.RateLimitRequest) (*brzrpc.RateLimitResponse, error) {
@@ -30,3 +31,45 @@ func (s *ServerAPI) RateLimit(ctx context.Context, req *brzrpc.RateLimitRequest)
 
 	return res.(*brzrpc.RateLimitResponse), nil
 }
+
+func (s *ServerAPI) GetCounter(ctx context.Context, req *brzrpc.String) (*brzrpc.RateLimitResponse, error) {
+	const op = "redis.grpc.GetCounter"
+
+	ctx, done := context.WithTimeout(ctx, waitTime)
+	defer done()
+
+	res, err := handleCRUDResponse(ctx, op, func() (any, error) {
+		count, ttl, err := s.rds.GetCounter(ctx, req.GetValue())
+		if err != nil {
+			return nil, err
+		}
+
+		return &brzrpc.RateLimitResponse{
+			Count: count,
+			Ttl:   ttl,
+		}, nil
+	})
+
+	if err != nil {
+		return nil, format.Error(op, err)
+	}
+
+	return res.(*brzrpc.RateLimitResponse), nil
+}
+
+func (s *ServerAPI) ResetCounter(ctx context.Context, req *brzrpc.String) (*emptypb.Empty, error) {
+	const op = "redis.grpc.ResetCounter"
+
+	ctx, done := context.WithTimeout(ctx, waitTime)
+	defer done()
+
+	_, err := handleCRUDResponse(ctx, op, func() (any, error) {
+		return nil, s.rds.ResetCounter(ctx, req.GetValue())
+	})
+
+	if err != nil {
+		return nil, format.Error(op, err)
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,48 @@
+package api
+
+import (
+	"context"
+	"time"
+
+	brzrpc "github.com/autumnterror/breezynotes/api/proto/gen"
+	"github.com/autumnterror/utils_go/pkg/utils/format"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+func (s *ServerAPI) RevokeFamilies(ctx context.Context, req *brzrpc.RevokedFamilies) (*emptypb.Empty, error) {
+	const op = "redis.grpc.RevokeFamilies"
+
+	ctx, done := context.WithTimeout(ctx, waitTime)
+	defer done()
+
+	_, err := handleCRUDResponse(ctx, op, func() (any, error) {
+		return nil, s.rds.RevokeFamilies(ctx, req.GetIds(), time.Duration(req.GetTtlMilliseconds())*time.Millisecond)
+	})
+
+	if err != nil {
+		return nil, format.Error(op, err)
+	}
+
+	return nil, nil
+}
+
+func (s *ServerAPI) IsFamilyRevoked(ctx context.Context, req *brzrpc.String) (*brzrpc.BoolResponse, error) {
+	const op = "redis.grpc.IsFamilyRevoked"
+
+	ctx, done := context.WithTimeout(ctx, waitTime)
+	defer done()
+
+	res, err := handleCRUDResponse(ctx, op, func() (any, error) {
+		ok, err := s.rds.IsFamilyRevoked(ctx, req.GetValue())
+		if err != nil {
+			return nil, err
+		}
+		return &brzrpc.BoolResponse{Ok: ok}, nil
+	})
+
+	if err != nil {
+		return nil, format.Error(op, err)
+	}
+
+	return res.(*brzrpc.BoolResponse), nil
+}
@@ -0,0 +1,83 @@
+package api
+
+import (
+	"context"
+
+	brzrpc "github.com/autumnterror/breezynotes/api/proto/gen"
+	"github.com/autumnterror/breezynotes/internal/redis/domain"
+	"github.com/autumnterror/utils_go/pkg/utils/format"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+func (s *ServerAPI) GetWorkspaceRolesByUser(ctx context.Context, req *brzrpc.UserId) (*brzrpc.String, error) {
+	const op = "redis.grpc.GetWorkspaceRolesByUser"
+
+	ctx, done := context.WithTimeout(ctx, waitTime)
+	defer done()
+
+	if !s.ensureCreate(ctx, req.GetUserId()) {
+		return nil, status.Error(codes.Internal, "can't create session")
+	}
+
+	res, err := handleCRUDResponse(ctx, op, func() (any, error) {
+		roles, err := s.rds.GetSessionWorkspaceRoles(ctx, req.GetUserId())
+		if err == nil && roles == nil {
+			return nil, domain.ErrNotFound
+		}
+		if err != nil {
+			return nil, err
+		}
+		return &brzrpc.String{Value: *roles}, nil
+	})
+
+	if err != nil {
+		return nil, format.Error(op, err)
+	}
+
+	return res.(*brzrpc.String), nil
+}
+
+func (s *ServerAPI) SetWorkspaceRolesByUser(ctx context.Context, req *brzrpc.WorkspaceRolesByUser) (*emptypb.Empty, error) {
+	const op = "redis.grpc.SetWorkspaceRolesByUser"
+
+	ctx, done := context.WithTimeout(ctx, waitTime)
+	defer done()
+
+	if !s.ensureCreate(ctx, req.GetUserId()) {
+		return nil, status.Error(codes.Internal, "can't create session")
+	}
+
+	_, err := handleCRUDResponse(ctx, op, func() (any, error) {
+		roles := req.GetRoles()
+		return nil, s.rds.SetSessionWorkspaceRoles(ctx, req.GetUserId(), &roles)
+	})
+
+	if err != nil {
+		return nil, format.Error(op, err)
+	}
+
+	return nil, nil
+}
+
+func (s *ServerAPI) RmWorkspaceRolesByUser(ctx context.Context, req *brzrpc.UserId) (*emptypb.Empty, error) {
+	const op = "redis.grpc.RmWorkspaceRolesByUser"
+
+	ctx, done := context.WithTimeout(ctx, waitTime)
+	defer done()
+
+	if !s.ensureCreate(ctx, req.GetUserId()) {
+		return nil, status.Error(codes.Internal, "can't create session")
+	}
+
+	_, err := handleCRUDResponse(ctx, op, func() (any, error) {
+		return nil, s.rds.SetSessionWorkspaceRoles(ctx, req.GetUserId(), nil)
+	})
+
+	if err != nil {
+		return nil, format.Error(op, err)
+	}
+
+	return nil, nil
+}
@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	brzrpc "github.com/autumnterror/breezynotes/api/proto/gen"
 	"github.com/autumnterror/breezynotes/internal/redis/config"
@@ -29,8 +30,19 @@ type Repo interface {
 	SetSessionNotes(ctx context.Context, id string, notes []*brzrpc.NoteWithBlocks) error
 	GetSessionTags(ctx context.Context, id string) ([]*brzrpc.Tag, error)
 	SetSessionTags(ctx context.Context, id string, tags []*brzrpc.Tag) error
+	GetSessionPreferences(ctx context.Context, id string) (*brzrpc.Preferences, error)
+	SetSessionPreferences(ctx context.Context, id string, p *brzrpc.Preferences) error
+	GetSessionWorkspaceRoles(ctx context.Context, id string) (*string, error)
+	SetSessionWorkspaceRoles(ctx context.Context, id string, roles *string) error
 	CreateSession(ctx context.Context, id string) error
 	CheckSession(ctx context.Context, id string) error
+	DeleteSession(ctx context.Context, id string) error
 	CleanNoteById(ctx context.Context, noteID string) error
+	GetExportJob(ctx context.Context, idUser string) (*brzrpc.ExportJob, error)
+	SetExportJob(ctx context.Context, job *brzrpc.ExportJob, ttl time.Duration) error
+	RevokeFamilies(ctx context.Context, ids []string, ttl time.Duration) error
+	IsFamilyRevoked(ctx context.Context, id string) (bool, error)
 	RateLimit(ctx context.Context, key string, windowMilliseconds int64) (count int64, ttl int64, err error)
+	GetCounter(ctx context.Context, key string) (count int64, ttl int64, err error)
+	ResetCounter(ctx context.Context, key string) error
 }
@@ -0,0 +1,55 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	brzrpc "github.com/autumnterror/breezynotes/api/proto/gen"
+	"github.com/autumnterror/utils_go/pkg/utils/format"
+	"github.com/redis/go-redis/v9"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// exportJobKeyPrefix job is kept separately from session, it must live until archive expires
+const exportJobKeyPrefix = "export_job:"
+
+func exportJobKey(idUser string) string {
+	return exportJobKeyPrefix + idUser
+}
+
+// GetExportJob return nil if user has no export
+func (s *Client) GetExportJob(ctx context.Context, idUser string) (*brzrpc.ExportJob, error) {
+	const op = "redis.GetExportJob"
+
+	raw, err := s.Rdb.Get(ctx, exportJobKey(idUser)).Bytes()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil, nil
+		}
+		return nil, format.Error(op, err)
+	}
+
+	var job brzrpc.ExportJob
+	if err := protojson.Unmarshal(raw, &job); err != nil {
+		return nil, format.Error(op, err)
+	}
+	return &job, nil
+}
+
+// SetExportJob replace export of user. Job is deleted after ttl
+func (s *Client) SetExportJob(ctx context.Context, job *brzrpc.ExportJob, ttl time.Duration) error {
+	const op = "redis.SetExportJob"
+	if job.GetUserId() == "" {
+		return format.Error(op, errors.New("user id is empty"))
+	}
+
+	raw, err := protojson.Marshal(job)
+	if err != nil {
+		return format.Error(op, err)
+	}
+	if err := s.Rdb.Set(ctx, exportJobKey(job.GetUserId()), raw, ttl).Err(); err != nil {
+		return format.Error(op, err)
+	}
+	return nil
+}
@@ -119,6 +119,55 @@ func (s *Client) SetSessionTags(ctx context.Context, id string, tags []*brzrpc.T
 	return s.saveSession(ctx, us)
 }
 
+// GetSessionPreferences return nil if preferences are not cached
+func (s *Client) GetSessionPreferences(ctx context.Context, id string) (*brzrpc.Preferences, error) {
+	us, err := s.getSession(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if us == nil {
+		return nil, nil
+	}
+	return us.Preferences, nil
+}
+
+func (s *Client) SetSessionPreferences(ctx context.Context, id string, p *brzrpc.Preferences) error {
+	us, err := s.getSession(ctx, id)
+	if err != nil {
+		return err
+	}
+	if us == nil {
+		us = &userSession{Id: id}
+	}
+	us.Preferences = p
+	return s.saveSession(ctx, us)
+}
+
+// GetSessionWorkspaceRoles return nil if membership is not cached
+func (s *Client) GetSessionWorkspaceRoles(ctx context.Context, id string) (*string, error) {
+	us, err := s.getSession(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if us == nil {
+		return nil, nil
+	}
+	return us.WorkspaceRoles, nil
+}
+
+// SetSessionWorkspaceRoles cache membership of user, nil removes it
+func (s *Client) SetSessionWorkspaceRoles(ctx context.Context, id string, roles *string) error {
+	us, err := s.getSession(ctx, id)
+	if err != nil {
+		return err
+	}
+	if us == nil {
+		us = &userSession{Id: id}
+	}
+	us.WorkspaceRoles = roles
+	return s.saveSession(ctx, us)
+}
+
 func (s *Client) CleanNoteById(ctx context.Context, noteID string) error {
 	const op = "redis.CleanNoteById"
 	if noteID == "" {
@@ -7,6 +7,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"google.golang.org/protobuf/types/known/structpb"
 	"testing"
+	"time"
 )
 
 func TestCrudSession(t *testing.T) {
@@ -207,6 +208,30 @@ func TestCrudFields(t *testing.T) {
 		}
 	}
 
+	if p, err := c.GetSessionPreferences(ctx, idTest); assert.NoError(t, err) {
+		assert.Nil(t, p)
+	}
+	assert.NoError(t, c.SetSessionPreferences(ctx, idTest, &brzrpc.Preferences{Version: 1, Theme: "dark"}))
+	if p, err := c.GetSessionPreferences(ctx, idTest); assert.NoError(t, err) && assert.NotNil(t, p) {
+		assert.Equal(t, "dark", p.Theme)
+	}
+	if tgs, err := c.GetSessionTags(ctx, idTest); assert.NoError(t, err) {
+		assert.Equal(t, 2, len(tgs), "preferences don't touch tags")
+	}
+
+	if r, err := c.GetSessionWorkspaceRoles(ctx, idTest); assert.NoError(t, err) {
+		assert.Nil(t, r)
+	}
+	noRoles := ""
+	assert.NoError(t, c.SetSessionWorkspaceRoles(ctx, idTest, &noRoles))
+	if r, err := c.GetSessionWorkspaceRoles(ctx, idTest); assert.NoError(t, err) && assert.NotNil(t, r) {
+		assert.Equal(t, "", *r, "user without workspaces is cached too")
+	}
+	assert.NoError(t, c.SetSessionWorkspaceRoles(ctx, idTest, nil))
+	if r, err := c.GetSessionWorkspaceRoles(ctx, idTest); assert.NoError(t, err) {
+		assert.Nil(t, r)
+	}
+
 	assert.NoError(t, c.SetSessionNoteList(ctx, idTest, []*brzrpc.NotePart{
 		{
 			Id:    "test",
@@ -393,3 +418,47 @@ func TestCrudFields(t *testing.T) {
 	}
 
 }
+
+func TestExportJob(t *testing.T) {
+	c := New(config.Test())
+	ctx := context.Background()
+	idTest := "TestExportJob"
+
+	if job, err := c.GetExportJob(ctx, idTest); assert.NoError(t, err) {
+		assert.Nil(t, job)
+	}
+
+	assert.NoError(t, c.SetExportJob(ctx, &brzrpc.ExportJob{Id: "1", UserId: idTest, State: "running"}, time.Minute))
+	if job, err := c.GetExportJob(ctx, idTest); assert.NoError(t, err) && assert.NotNil(t, job) {
+		assert.Equal(t, "1", job.Id)
+		assert.Equal(t, "running", job.State)
+	}
+
+	assert.NoError(t, c.SetExportJob(ctx, &brzrpc.ExportJob{Id: "1", UserId: idTest, State: "ready"}, time.Millisecond))
+	time.Sleep(10 * time.Millisecond)
+	if job, err := c.GetExportJob(ctx, idTest); assert.NoError(t, err) {
+		assert.Nil(t, job, "job is expired")
+	}
+}
+
+func TestRevokedFamilies(t *testing.T) {
+	c := New(config.Test())
+	ctx := context.Background()
+
+	if ok, err := c.IsFamilyRevoked(ctx, "TestRevokedFamilies1"); assert.NoError(t, err) {
+		assert.False(t, ok)
+	}
+
+	assert.NoError(t, c.RevokeFamilies(ctx, nil, time.Minute))
+	assert.NoError(t, c.RevokeFamilies(ctx, []string{"TestRevokedFamilies1", "TestRevokedFamilies2"}, 10*time.Millisecond))
+	for _, id := range []string{"TestRevokedFamilies1", "TestRevokedFamilies2"} {
+		if ok, err := c.IsFamilyRevoked(ctx, id); assert.NoError(t, err) {
+			assert.True(t, ok)
+		}
+	}
+
+	time.Sleep(20 * time.Millisecond)
+	if ok, err := c.IsFamilyRevoked(ctx, "TestRevokedFamilies1"); assert.NoError(t, err) {
+		assert.False(t, ok, "family is expired")
+	}
+}
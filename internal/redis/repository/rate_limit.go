@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"github.com/redis/go-redis/v9"
 )
@@ -39,3 +40,28 @@ func (s *Client) RateLimit(ctx context.Context, key string, windowMilliseconds i
 
 	return cn, ttlms, nil
 }
+
+// GetCounter return counter of RateLimit and its ttl in milliseconds. Missing key is zero counter
+func (s *Client) GetCounter(ctx context.Context, key string) (count int64, ttl int64, err error) {
+	cn, err := s.Rdb.Get(ctx, key).Int64()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return 0, 0, nil
+		}
+		return 0, 0, err
+	}
+
+	ttlD, err := s.Rdb.PTTL(ctx, key).Result()
+	if err != nil {
+		return 0, 0, err
+	}
+	if ttlD < 0 {
+		return cn, 0, nil
+	}
+
+	return cn, ttlD.Milliseconds(), nil
+}
+
+func (s *Client) ResetCounter(ctx context.Context, key string) error {
+	return s.Rdb.Del(ctx, key).Err()
+}
@@ -0,0 +1,42 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/autumnterror/utils_go/pkg/utils/format"
+)
+
+// revokedFamilyKeyPrefix family is kept separately from session, gateway checks it on every request
+const revokedFamilyKeyPrefix = "revoked_family:"
+
+func revokedFamilyKey(id string) string {
+	return revokedFamilyKeyPrefix + id
+}
+
+// RevokeFamilies mark families revoked for ttl
+func (s *Client) RevokeFamilies(ctx context.Context, ids []string, ttl time.Duration) error {
+	const op = "redis.RevokeFamilies"
+	if len(ids) == 0 {
+		return nil
+	}
+
+	pipe := s.Rdb.Pipeline()
+	for _, id := range ids {
+		pipe.Set(ctx, revokedFamilyKey(id), 1, ttl)
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		return format.Error(op, err)
+	}
+	return nil
+}
+
+func (s *Client) IsFamilyRevoked(ctx context.Context, id string) (bool, error) {
+	const op = "redis.IsFamilyRevoked"
+
+	n, err := s.Rdb.Exists(ctx, revokedFamilyKey(id)).Result()
+	if err != nil {
+		return false, format.Error(op, err)
+	}
+	return n > 0, nil
+}
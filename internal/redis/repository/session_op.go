@@ -17,14 +17,20 @@ type userSession struct {
 	Notes     []*brzrpc.NoteWithBlocks `json:"notes"`
 	Tags      []*brzrpc.Tag            `json:"tags"`
 	NoteTrash []*brzrpc.NotePart       `json:"note_trash"`
+	// Preferences nil if not cached
+	Preferences *brzrpc.Preferences `json:"preferences"`
+	// WorkspaceRoles nil if not cached, empty if user has no workspaces
+	WorkspaceRoles *string `json:"workspace_roles"`
 }
 
 type userSessionRedis struct {
-	Id        string            `json:"id"`
-	NoteParts []json.RawMessage `json:"note_parts"`
-	Notes     []json.RawMessage `json:"notes"`
-	Tags      []json.RawMessage `json:"tags"`
-	NoteTrash []json.RawMessage `json:"note_trash"`
+	Id             string            `json:"id"`
+	NoteParts      []json.RawMessage `json:"note_parts"`
+	Notes          []json.RawMessage `json:"notes"`
+	Tags           []json.RawMessage `json:"tags"`
+	NoteTrash      []json.RawMessage `json:"note_trash"`
+	Preferences    json.RawMessage   `json:"preferences,omitempty"`
+	WorkspaceRoles *string           `json:"workspace_roles,omitempty"`
 }
 
 func userSessionKey(id string) string {
@@ -60,11 +66,12 @@ func (s *Client) saveSession(ctx context.Context, us *userSession) error {
 	}
 
 	rs := userSessionRedis{
-		Id:        us.Id,
-		NoteParts: make([]json.RawMessage, 0, len(us.NoteParts)),
-		NoteTrash: make([]json.RawMessage, 0, len(us.NoteTrash)),
-		Notes:     make([]json.RawMessage, 0, len(us.Notes)),
-		Tags:      make([]json.RawMessage, 0, len(us.Tags)),
+		Id:             us.Id,
+		NoteParts:      make([]json.RawMessage, 0, len(us.NoteParts)),
+		NoteTrash:      make([]json.RawMessage, 0, len(us.NoteTrash)),
+		Notes:          make([]json.RawMessage, 0, len(us.Notes)),
+		Tags:           make([]json.RawMessage, 0, len(us.Tags)),
+		WorkspaceRoles: us.WorkspaceRoles,
 	}
 
 	for _, np := range us.NoteParts {
@@ -115,6 +122,14 @@ func (s *Client) saveSession(ctx context.Context, us *userSession) error {
 		rs.Tags = append(rs.Tags, b)
 	}
 
+	if us.Preferences != nil {
+		b, err := pj.Marshal(us.Preferences)
+		if err != nil {
+			return format.Error(op, fmt.Errorf("marshal Preferences: %w", err))
+		}
+		rs.Preferences = b
+	}
+
 	data, err := json.Marshal(&rs)
 	if err != nil {
 		return format.Error(op, fmt.Errorf("marshal userSessionRedis: %w", err))
@@ -189,11 +204,12 @@ func (s *Client) getSession(ctx context.Context, id string) (*userSession, error
 	}
 
 	us := &userSession{
-		Id:        rs.Id,
-		NoteParts: make([]*brzrpc.NotePart, 0, len(rs.NoteParts)),
-		NoteTrash: make([]*brzrpc.NotePart, 0, len(rs.NoteTrash)),
-		Notes:     make([]*brzrpc.NoteWithBlocks, 0, len(rs.Notes)),
-		Tags:      make([]*brzrpc.Tag, 0, len(rs.Tags)),
+		Id:             rs.Id,
+		NoteParts:      make([]*brzrpc.NotePart, 0, len(rs.NoteParts)),
+		NoteTrash:      make([]*brzrpc.NotePart, 0, len(rs.NoteTrash)),
+		Notes:          make([]*brzrpc.NoteWithBlocks, 0, len(rs.Notes)),
+		Tags:           make([]*brzrpc.Tag, 0, len(rs.Tags)),
+		WorkspaceRoles: rs.WorkspaceRoles,
 	}
 
 	for _, raw := range rs.NoteParts {
@@ -245,6 +261,14 @@ func (s *Client) getSession(ctx context.Context, id string) (*userSession, error
 		us.Tags = append(us.Tags, t)
 	}
 
+	if rs.Preferences != nil {
+		p := &brzrpc.Preferences{}
+		if err := pj.Unmarshal(rs.Preferences, p); err != nil {
+			return nil, format.Error(op, fmt.Errorf("unmarshal Preferences: %w", err))
+		}
+		us.Preferences = p
+	}
+
 	return us, nil
 }
 
@@ -260,3 +284,8 @@ func (s *Client) deleteSession(ctx context.Context, id string) error {
 	}
 	return nil
 }
+
+// DeleteSession remove session of user, missing session is not error
+func (s *Client) DeleteSession(ctx context.Context, id string) error {
+	return s.deleteSession(ctx, id)
+}